@@ -0,0 +1,88 @@
+// Command migrate scaffolds new migration file pairs for the
+// migrations package. It only touches the filesystem; applying
+// migrations to a live database happens at service startup via
+// migrations/migrate.go (Run), not through this command.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "migrate:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dir := fs.String("dir", "migrations", "path to the migrations directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	rest := fs.Args()
+	if len(rest) != 2 || rest[0] != "create" {
+		return fmt.Errorf("usage: migrate [-dir path] create <name>")
+	}
+	return create(*dir, rest[1])
+}
+
+var nameSanitizer = regexp.MustCompile(`[^a-z0-9]+`)
+
+// create writes an empty NNNN_name.up.sql / NNNN_name.down.sql pair,
+// where NNNN is one greater than the highest existing version prefix
+// in dir, so the new pair sorts after every existing migration.
+func create(dir, name string) error {
+	next, err := nextVersion(dir)
+	if err != nil {
+		return err
+	}
+
+	slug := strings.Trim(nameSanitizer.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if slug == "" {
+		return fmt.Errorf("name must contain at least one letter or digit")
+	}
+	stem := fmt.Sprintf("%04d_%s", next, slug)
+
+	for _, suffix := range []string{".up.sql", ".down.sql"} {
+		path := filepath.Join(dir, stem+suffix)
+		if err := os.WriteFile(path, []byte(fmt.Sprintf("-- %s\n", stem+suffix)), 0o644); err != nil {
+			return err
+		}
+		fmt.Println(path)
+	}
+	return nil
+}
+
+var versionPattern = regexp.MustCompile(`^(\d{4,})_`)
+
+func nextVersion(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("read %s: %w", dir, err)
+	}
+
+	versions := []int{0}
+	for _, e := range entries {
+		m := versionPattern.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		versions = append(versions, n)
+	}
+	sort.Ints(versions)
+	return versions[len(versions)-1] + 1, nil
+}
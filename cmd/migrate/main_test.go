@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNextVersionStartsAtOneForEmptyDir(t *testing.T) {
+	dir := t.TempDir()
+	got, err := nextVersion(dir)
+	if err != nil {
+		t.Fatalf("nextVersion() error = %v", err)
+	}
+	if got != 1 {
+		t.Errorf("nextVersion() = %d, want 1", got)
+	}
+}
+
+func TestNextVersionFollowsHighestExistingPrefix(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"0001_create_proxies.up.sql", "0001_create_proxies.down.sql", "0033_scheduler_jobs.up.sql"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := nextVersion(dir)
+	if err != nil {
+		t.Fatalf("nextVersion() error = %v", err)
+	}
+	if got != 34 {
+		t.Errorf("nextVersion() = %d, want 34", got)
+	}
+}
+
+func TestCreateWritesSanitizedUpAndDownFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "0005_existing.up.sql"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := create(dir, "Add Proxy Quarantine!"); err != nil {
+		t.Fatalf("create() error = %v", err)
+	}
+
+	for _, suffix := range []string{".up.sql", ".down.sql"} {
+		path := filepath.Join(dir, "0006_add_proxy_quarantine"+suffix)
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s to exist: %v", path, err)
+		}
+	}
+}
+
+func TestCreateRejectsNameWithNoAlphanumerics(t *testing.T) {
+	if err := create(t.TempDir(), "!!!"); err == nil {
+		t.Error("create() with an all-punctuation name should fail")
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// adminClient is a minimal HTTP client for admin-api and free-crawler's
+// operator-only endpoints. It's deliberately not part of pkg/client:
+// that SDK wraps services/api's customer-facing surface, authenticated
+// with a customer's JWT or API key, while admin-api authenticates with
+// a separately issued admin JWT that this tool never mints itself (see
+// Config.AdminToken's doc comment). Keeping the two separate mirrors
+// the services' own boundary.
+type adminClient struct {
+	httpClient *http.Client
+	adminToken string
+}
+
+func newAdminClient(adminToken string) *adminClient {
+	return &adminClient{httpClient: &http.Client{Timeout: 30 * time.Second}, adminToken: adminToken}
+}
+
+func (c *adminClient) do(ctx context.Context, method, url string, reqBody, out interface{}) error {
+	var bodyReader io.Reader
+	if reqBody != nil {
+		payload, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("proxyctl: failed to encode request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return fmt.Errorf("proxyctl: failed to build request: %w", err)
+	}
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.adminToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.adminToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("proxyctl: request to %s failed: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("proxyctl: failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("proxyctl: %s %s: status %d: %s", method, url, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, out)
+}
+
+// doRaw behaves like do, but returns the response body as a string
+// instead of decoding it as JSON. It's used against free-crawler's
+// start endpoint, which replies with a plain-text status message.
+func (c *adminClient) doRaw(ctx context.Context, method, url string, reqBody interface{}) (string, error) {
+	var bodyReader io.Reader
+	if reqBody != nil {
+		payload, err := json.Marshal(reqBody)
+		if err != nil {
+			return "", fmt.Errorf("proxyctl: failed to encode request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return "", fmt.Errorf("proxyctl: failed to build request: %w", err)
+	}
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.adminToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.adminToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("proxyctl: request to %s failed: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("proxyctl: failed to read response body: %w", err)
+	}
+	text := strings.TrimSpace(string(body))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("proxyctl: %s %s: status %d: %s", method, url, resp.StatusCode, text)
+	}
+	return text, nil
+}
+
+// stream issues a GET request and calls onLine for every line of the
+// response body as it arrives, until ctx is canceled or the server
+// closes the connection. It's used for admin-api's SSE stats stream,
+// which never sends a final response body to unmarshal.
+func (c *adminClient) stream(ctx context.Context, url string, onLine func(line string)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("proxyctl: failed to build request: %w", err)
+	}
+	if c.adminToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.adminToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("proxyctl: request to %s failed: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("proxyctl: GET %s: status %d: %s", url, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		onLine(scanner.Text())
+	}
+	return scanner.Err()
+}
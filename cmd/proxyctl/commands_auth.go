@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func cmdLogin(g globalFlags, args []string) error {
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	email := fs.String("email", "", "account email")
+	password := fs.String("password", "", "account password")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *email == "" || *password == "" {
+		return fmt.Errorf("login requires --email and --password")
+	}
+
+	return withConfig(g, func(ctx context.Context, cfg Config) (Config, error) {
+		c := newClient(cfg)
+		result, err := c.Login(ctx, *email, *password)
+		if err != nil {
+			return cfg, err
+		}
+		if result.TwoFactorRequired {
+			fmt.Fprintln(os.Stderr, "two-factor authentication is enabled on this account; proxyctl doesn't support verifying a code yet")
+			return cfg, fmt.Errorf("login requires two-factor verification")
+		}
+
+		cfg.AccessToken, cfg.RefreshToken = result.AccessToken, result.RefreshToken
+		fmt.Println("logged in")
+		return cfg, nil
+	})
+}
+
+func cmdRegister(g globalFlags, args []string) error {
+	fs := flag.NewFlagSet("register", flag.ContinueOnError)
+	email := fs.String("email", "", "account email")
+	password := fs.String("password", "", "account password")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *email == "" || *password == "" {
+		return fmt.Errorf("register requires --email and --password")
+	}
+
+	return withConfig(g, func(ctx context.Context, cfg Config) (Config, error) {
+		c := newClient(cfg)
+		result, err := c.Register(ctx, *email, *password)
+		if err != nil {
+			return cfg, err
+		}
+
+		cfg.AccessToken, cfg.RefreshToken = result.AccessToken, result.RefreshToken
+		cfg.APIKey = result.APIKey
+		fmt.Printf("registered and logged in; api key: %s\n", result.APIKey)
+		return cfg, nil
+	})
+}
+
+func cmdConfig(g globalFlags, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("config requires a subcommand: set or show")
+	}
+
+	switch args[0] {
+	case "show":
+		cfg, err := loadConfig(g.configPath)
+		if err != nil {
+			return err
+		}
+		return printJSON(os.Stdout, redactedConfig(cfg))
+	case "set":
+		return cmdConfigSet(g, args[1:])
+	default:
+		return fmt.Errorf("unknown config subcommand %q", args[0])
+	}
+}
+
+func cmdConfigSet(g globalFlags, args []string) error {
+	fs := flag.NewFlagSet("config set", flag.ContinueOnError)
+	baseURL := fs.String("base-url", "", "services/api base URL")
+	adminBaseURL := fs.String("admin-base-url", "", "admin-api base URL")
+	crawlerBaseURL := fs.String("crawler-base-url", "", "free-crawler base URL")
+	apiKey := fs.String("api-key", "", "customer API key, for proxies list")
+	adminToken := fs.String("admin-token", "", "admin JWT, for admin-only commands")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return withConfig(g, func(ctx context.Context, cfg Config) (Config, error) {
+		if *baseURL != "" {
+			cfg.BaseURL = *baseURL
+		}
+		if *adminBaseURL != "" {
+			cfg.AdminBaseURL = *adminBaseURL
+		}
+		if *crawlerBaseURL != "" {
+			cfg.CrawlerBaseURL = *crawlerBaseURL
+		}
+		if *apiKey != "" {
+			cfg.APIKey = *apiKey
+		}
+		if *adminToken != "" {
+			cfg.AdminToken = *adminToken
+		}
+		return cfg, nil
+	})
+}
+
+// redactedConfig masks credential fields before they're printed to a
+// terminal or captured in a shell history/log.
+func redactedConfig(cfg Config) Config {
+	redact := func(s string) string {
+		if s == "" {
+			return ""
+		}
+		return "***"
+	}
+	cfg.APIKey = redact(cfg.APIKey)
+	cfg.AccessToken = redact(cfg.AccessToken)
+	cfg.RefreshToken = redact(cfg.RefreshToken)
+	cfg.AdminToken = redact(cfg.AdminToken)
+	return cfg
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/client"
+)
+
+func cmdKeys(g globalFlags, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("keys requires a subcommand: list, create, revoke, or rotate")
+	}
+
+	switch args[0] {
+	case "list":
+		return cmdKeysList(g)
+	case "create":
+		return cmdKeysCreate(g, args[1:])
+	case "revoke":
+		return cmdKeysRevoke(g, args[1:])
+	case "rotate":
+		return cmdKeysRotate(g, args[1:])
+	default:
+		return fmt.Errorf("unknown keys subcommand %q", args[0])
+	}
+}
+
+func cmdKeysList(g globalFlags) error {
+	cfg, err := loadConfig(g.configPath)
+	if err != nil {
+		return err
+	}
+	keys, err := newClient(cfg).ListKeys(context.Background())
+	if err != nil {
+		return err
+	}
+	return printKeys(g.format, keys)
+}
+
+func cmdKeysCreate(g globalFlags, args []string) error {
+	fs := flag.NewFlagSet("keys create", flag.ContinueOnError)
+	name := fs.String("name", "", "key name")
+	permissions := fs.String("permissions", "", "comma-separated permission list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return fmt.Errorf("keys create requires --name")
+	}
+
+	params := client.CreateKeyParams{Name: *name}
+	if *permissions != "" {
+		params.Permissions = strings.Split(*permissions, ",")
+	}
+
+	cfg, err := loadConfig(g.configPath)
+	if err != nil {
+		return err
+	}
+	created, err := newClient(cfg).CreateKey(context.Background(), params)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("created key %s: %s\n(shown once; store it now)\n", created.ID, created.RawKey)
+	return printKeys(g.format, []client.Key{created.Key})
+}
+
+func cmdKeysRevoke(g globalFlags, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("keys revoke requires exactly one key id")
+	}
+	cfg, err := loadConfig(g.configPath)
+	if err != nil {
+		return err
+	}
+	if err := newClient(cfg).RevokeKey(context.Background(), args[0]); err != nil {
+		return err
+	}
+	fmt.Println("revoked")
+	return nil
+}
+
+func cmdKeysRotate(g globalFlags, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("keys rotate requires exactly one key id")
+	}
+	cfg, err := loadConfig(g.configPath)
+	if err != nil {
+		return err
+	}
+	created, err := newClient(cfg).RotateKey(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("rotated key %s: %s\n(shown once; store it now)\n", created.ID, created.RawKey)
+	return printKeys(g.format, []client.Key{created.Key})
+}
+
+func printKeys(format string, keys []client.Key) error {
+	if format == "json" {
+		return printJSON(os.Stdout, keys)
+	}
+
+	rows := make([][]string, len(keys))
+	for i, k := range keys {
+		rows[i] = []string{k.ID, k.Name, k.Status, k.Masked, k.RotationMode}
+	}
+	return printTable(os.Stdout, []string{"ID", "NAME", "STATUS", "MASKED", "ROTATION"}, rows)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+)
+
+func cmdCrawl(g globalFlags, args []string) error {
+	if len(args) == 0 || args[0] != "start" {
+		return fmt.Errorf("crawl requires a subcommand: start")
+	}
+
+	cfg, err := loadConfig(g.configPath)
+	if err != nil {
+		return err
+	}
+
+	// free-crawler's start endpoint is unauthenticated, so adminToken is
+	// left empty here.
+	admin := newAdminClient("")
+	body, err := admin.doRaw(context.Background(), "POST", cfg.CrawlerBaseURL+"/api/crawler/start", nil)
+	if err != nil {
+		return err
+	}
+	fmt.Println(body)
+	return nil
+}
+
+func cmdStats(g globalFlags, args []string) error {
+	if len(args) == 0 || args[0] != "stream" {
+		return fmt.Errorf("stats requires a subcommand: stream")
+	}
+
+	cfg, err := loadConfig(g.configPath)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	admin := newAdminClient(cfg.AdminToken)
+	err = admin.stream(ctx, cfg.AdminBaseURL+"/api/admin/stats/stream", func(line string) {
+		if line == "" {
+			return
+		}
+		fmt.Println(line)
+	})
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/client"
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+func cmdProxies(g globalFlags, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("proxies requires a subcommand: list, add, or check")
+	}
+
+	switch args[0] {
+	case "list":
+		return cmdProxiesList(g, args[1:])
+	case "add":
+		return cmdProxiesAdd(g, args[1:])
+	case "check":
+		return cmdProxiesCheck(g, args[1:])
+	default:
+		return fmt.Errorf("unknown proxies subcommand %q", args[0])
+	}
+}
+
+func cmdProxiesList(g globalFlags, args []string) error {
+	fs := flag.NewFlagSet("proxies list", flag.ContinueOnError)
+	country := fs.String("country", "", "filter by country code")
+	protocol := fs.String("protocol", "", "filter by protocol (http, https, socks4, socks5)")
+	minScore := fs.Float64("min-score", 0, "minimum proxy score")
+	count := fs.Int("count", 0, "how many proxies to request (clamped to the account's plan limit)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(g.configPath)
+	if err != nil {
+		return err
+	}
+	proxies, err := newClient(cfg).ListProxies(context.Background(), client.ListProxiesParams{
+		Country:  *country,
+		Protocol: proxy.Protocol(*protocol),
+		MinScore: *minScore,
+		Count:    *count,
+	})
+	if err != nil {
+		return err
+	}
+	return printProxies(g.format, proxies)
+}
+
+// cmdProxiesAdd is an admin-only action: it calls admin-api's
+// POST /api/admin/proxies directly (see adminclient.go's doc comment
+// for why this bypasses pkg/client), authenticated with Config.AdminToken.
+func cmdProxiesAdd(g globalFlags, args []string) error {
+	fs := flag.NewFlagSet("proxies add", flag.ContinueOnError)
+	host := fs.String("host", "", "proxy host")
+	port := fs.Int("port", 0, "proxy port")
+	protocol := fs.String("protocol", string(proxy.ProtocolHTTP), "protocol (http, https, socks4, socks5)")
+	country := fs.String("country", "", "country code")
+	provider := fs.String("provider", "", "upstream provider name")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *host == "" || *port == 0 {
+		return fmt.Errorf("proxies add requires --host and --port")
+	}
+
+	cfg, err := loadConfig(g.configPath)
+	if err != nil {
+		return err
+	}
+	admin := newAdminClient(cfg.AdminToken)
+
+	req := map[string]interface{}{
+		"host":     *host,
+		"port":     *port,
+		"protocol": *protocol,
+		"country":  *country,
+		"provider": *provider,
+	}
+	var created proxy.Proxy
+	if err := admin.do(context.Background(), "POST", cfg.AdminBaseURL+"/api/admin/proxies", req, &created); err != nil {
+		return err
+	}
+	return printProxies(g.format, []*proxy.Proxy{&created})
+}
+
+// cmdProxiesCheck is an admin-only action: POST
+// /api/admin/proxies/{id}/check triggers an immediate reachability
+// probe outside proxy-pool's periodic sweep.
+func cmdProxiesCheck(g globalFlags, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("proxies check requires exactly one proxy id")
+	}
+
+	cfg, err := loadConfig(g.configPath)
+	if err != nil {
+		return err
+	}
+	admin := newAdminClient(cfg.AdminToken)
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	url := cfg.AdminBaseURL + "/api/admin/proxies/" + args[0] + "/check"
+	if err := admin.do(context.Background(), "POST", url, nil, &result); err != nil {
+		return err
+	}
+	fmt.Println("success:", strconv.FormatBool(result.Success))
+	return nil
+}
+
+func printProxies(format string, proxies []*proxy.Proxy) error {
+	if format == "json" {
+		return printJSON(os.Stdout, proxies)
+	}
+
+	rows := make([][]string, len(proxies))
+	for i, p := range proxies {
+		rows[i] = []string{p.ID, p.Addr(), string(p.Protocol), p.Country, strconv.FormatFloat(p.Score, 'f', 2, 64), string(p.Status)}
+	}
+	return printTable(os.Stdout, []string{"ID", "ADDR", "PROTOCOL", "COUNTRY", "SCORE", "STATUS"}, rows)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/client"
+)
+
+func cmdUsage(g globalFlags, args []string) error {
+	cfg, err := loadConfig(g.configPath)
+	if err != nil {
+		return err
+	}
+	summary, err := newClient(cfg).UsageSummary(context.Background())
+	if err != nil {
+		return err
+	}
+	return printUsageSummary(g.format, summary)
+}
+
+func printUsageSummary(format string, summary *client.UsageSummary) error {
+	if format == "json" {
+		return printJSON(os.Stdout, summary)
+	}
+
+	rows := [][]string{
+		statsRow("today", summary.Today),
+		statsRow("month", summary.Month),
+	}
+	return printTable(os.Stdout, []string{"PERIOD", "REQUESTS", "BYTES IN", "BYTES OUT", "ERRORS"}, rows)
+}
+
+func statsRow(period string, s client.UsageStats) []string {
+	return []string{
+		period,
+		fmt.Sprintf("%d", s.RequestCount),
+		fmt.Sprintf("%d", s.BytesIn),
+		fmt.Sprintf("%d", s.BytesOut),
+		fmt.Sprintf("%d", s.ErrorCount),
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Config holds proxyctl's endpoints and stored credentials, persisted
+// as JSON at configPath (by default ~/.proxyctl.json, overridable with
+// --config or PROXYCTL_CONFIG). Login/Register fill in the customer
+// token pair; AdminToken and APIKey are set manually via "config set",
+// since admin-api and services/api's proxy listing don't issue
+// credentials through a login flow of their own.
+type Config struct {
+	BaseURL        string `json:"base_url"`
+	AdminBaseURL   string `json:"admin_base_url"`
+	CrawlerBaseURL string `json:"crawler_base_url"`
+	APIKey         string `json:"api_key,omitempty"`
+	AccessToken    string `json:"access_token,omitempty"`
+	RefreshToken   string `json:"refresh_token,omitempty"`
+	AdminToken     string `json:"admin_token,omitempty"`
+}
+
+// defaultConfig matches each service's default listen address from its
+// own cmd/main.go (API_ADDR, ADMIN_API_ADDR, FREE_CRAWLER_ADDR).
+func defaultConfig() Config {
+	return Config{
+		BaseURL:        "http://localhost:8084",
+		AdminBaseURL:   "http://localhost:8081",
+		CrawlerBaseURL: "http://localhost:8082",
+	}
+}
+
+// defaultConfigPath returns ~/.proxyctl.json, or "./.proxyctl.json" if
+// the home directory can't be resolved.
+func defaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".proxyctl.json"
+	}
+	return filepath.Join(home, ".proxyctl.json")
+}
+
+// loadConfig reads Config from path, returning defaultConfig() if the
+// file doesn't exist yet.
+func loadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return defaultConfig(), nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("proxyctl: failed to read config %s: %w", path, err)
+	}
+
+	cfg := defaultConfig()
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("proxyctl: failed to parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// saveConfig writes cfg to path as indented JSON, creating the file
+// with owner-only permissions since it holds tokens and keys.
+func saveConfig(path string, cfg Config) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("proxyctl: failed to encode config: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("proxyctl: failed to write config %s: %w", path, err)
+	}
+	return nil
+}
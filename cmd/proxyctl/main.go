@@ -0,0 +1,111 @@
+// Command proxyctl is the operator CLI for the platform: it wraps
+// pkg/client (services/api's customer SDK) for account-scoped actions
+// like logging in, listing proxies, and managing API keys, plus a
+// handful of direct calls to admin-api and free-crawler's existing
+// operator endpoints for fleet actions like adding proxies, triggering
+// a crawl, running an on-demand health check, and tailing live stats.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/client"
+)
+
+// globalFlags are accepted before or interspersed with a subcommand's
+// own flags, mirroring how `go`'s own subcommands handle -C/-C.
+type globalFlags struct {
+	configPath string
+	format     string
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "proxyctl:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("proxyctl", flag.ContinueOnError)
+	configPath := fs.String("config", defaultConfigPath(), "path to the proxyctl config file")
+	format := fs.String("format", "table", "output format: table or json")
+	fs.Parse(args)
+
+	rest := fs.Args()
+	if len(rest) == 0 {
+		printUsage()
+		return nil
+	}
+
+	g := globalFlags{configPath: *configPath, format: *format}
+	cmd, sub := rest[0], rest[1:]
+
+	switch cmd {
+	case "login":
+		return cmdLogin(g, sub)
+	case "register":
+		return cmdRegister(g, sub)
+	case "config":
+		return cmdConfig(g, sub)
+	case "keys":
+		return cmdKeys(g, sub)
+	case "proxies":
+		return cmdProxies(g, sub)
+	case "usage":
+		return cmdUsage(g, sub)
+	case "crawl":
+		return cmdCrawl(g, sub)
+	case "stats":
+		return cmdStats(g, sub)
+	case "help", "-h", "--help":
+		printUsage()
+		return nil
+	default:
+		printUsage()
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, `proxyctl [--config path] [--format table|json] <command> [args]
+
+Commands:
+  login --email E --password P      log in and store the token pair
+  register --email E --password P   create an account and log in
+  config set [--api-key K] [--admin-token T] [--base-url U] [--admin-base-url U] [--crawler-base-url U]
+  config show                       print the current config
+  keys list                         list the account's API keys
+  keys create --name N              create an API key
+  keys revoke <id>                  revoke an API key
+  keys rotate <id>                  rotate an API key
+  proxies list [--country C] [--protocol P] [--count N]
+  proxies add --host H --port P [--protocol P] [--country C] [--provider V]   (admin)
+  proxies check <id>                                                          (admin)
+  usage                             show the account's usage summary
+  crawl start                       trigger an on-demand free-crawler run     (admin)
+  stats stream                      tail admin-api's live stats SSE stream    (admin)`)
+}
+
+// newClient builds a pkg/client.Client from cfg, with its token/API key
+// state preloaded so a command can make authenticated calls right away.
+func newClient(cfg Config) *client.Client {
+	c := client.New(client.Config{BaseURL: cfg.BaseURL, APIKey: cfg.APIKey})
+	c.SetTokens(cfg.AccessToken, cfg.RefreshToken)
+	return c
+}
+
+func withConfig(g globalFlags, fn func(ctx context.Context, cfg Config) (Config, error)) error {
+	cfg, err := loadConfig(g.configPath)
+	if err != nil {
+		return err
+	}
+	updated, err := fn(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	return saveConfig(g.configPath, updated)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// printJSON writes v to w as indented JSON.
+func printJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printTable writes headers and rows to w as an aligned, tab-separated
+// table.
+func printTable(w io.Writer, headers []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, tabJoin(headers))
+	for _, row := range rows {
+		fmt.Fprintln(tw, tabJoin(row))
+	}
+	return tw.Flush()
+}
+
+func tabJoin(fields []string) string {
+	out := ""
+	for i, f := range fields {
+		if i > 0 {
+			out += "\t"
+		}
+		out += f
+	}
+	return out
+}
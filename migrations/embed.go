@@ -0,0 +1,10 @@
+package migrate
+
+import "embed"
+
+// FS embeds every migration file directly into the binary so release
+// builds don't need the migrations directory to exist on disk alongside
+// the executable.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,203 @@
+// Package migrate applies the platform's versioned SQL migrations, in
+// filename order, tracking what has already run in a schema_migrations
+// table. Migrations are embedded into the binary via FS so release
+// builds are self-contained and don't need the migrations directory to
+// exist on disk alongside the executable.
+//
+// Each version is a pair of files, "NNNN_name.up.sql" and
+// "NNNN_name.down.sql" (the numeric prefix gives filename order, and
+// thus apply order). Run applies every pending .up.sql; Down reverts
+// the most recently applied versions using their .down.sql pair. Use
+// the migrate command in cmd/migrate to scaffold a new pair.
+//
+// MySQL implicitly commits most DDL statements even inside a
+// transaction, so a migration that fails partway through can leave
+// real schema changes behind that a transaction rollback won't undo.
+// schema_migrations tracks this with a dirty flag: a version is marked
+// dirty before its SQL runs and clean only once it succeeds, so a
+// process that dies mid-migration leaves clear evidence of exactly
+// which version needs a human to look at the database and decide
+// whether to re-run or hand-fix it. Run and Down both refuse to
+// proceed while any version is dirty.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+const upSuffix = ".up.sql"
+const downSuffix = ".down.sql"
+
+const createTrackingTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    VARCHAR(255) NOT NULL PRIMARY KEY,
+    dirty      BOOLEAN      NOT NULL DEFAULT FALSE,
+    applied_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+
+// ErrDirty is returned by Run and Down when schema_migrations has a
+// version left marked dirty by a previous run that didn't complete.
+// Resolve the database by hand, then clear the flag with Force before
+// migrating again.
+var ErrDirty = errors.New("migrate: a previous migration is dirty; inspect the database, then call Force")
+
+// Run applies every version in source that has not already been
+// recorded in schema_migrations, in lexical filename order (hence the
+// numeric prefixes on migration files). Callers normally pass FS; a
+// plain fs.FS rooted elsewhere is accepted too, which is mainly useful
+// in tests.
+func Run(ctx context.Context, db *sql.DB, source fs.FS) error {
+	if _, err := db.ExecContext(ctx, createTrackingTable); err != nil {
+		return fmt.Errorf("migrate: create tracking table: %w", err)
+	}
+
+	state, err := loadState(ctx, db)
+	if err != nil {
+		return err
+	}
+	if state.dirtyVersion != "" {
+		return fmt.Errorf("%w: version %s", ErrDirty, state.dirtyVersion)
+	}
+
+	versions, err := listVersions(source)
+	if err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+		if state.applied[version] {
+			continue
+		}
+
+		contents, err := fs.ReadFile(source, version+upSuffix)
+		if err != nil {
+			return fmt.Errorf("migrate: read %s: %w", version+upSuffix, err)
+		}
+		if err := applyOne(ctx, db, version, string(contents)); err != nil {
+			return fmt.Errorf("migrate: apply %s: %w", version, err)
+		}
+	}
+
+	return nil
+}
+
+// Down reverts the most recently applied steps versions, in reverse
+// order, using each one's .down.sql file. steps must be at least 1.
+func Down(ctx context.Context, db *sql.DB, source fs.FS, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("migrate: steps must be at least 1, got %d", steps)
+	}
+	if _, err := db.ExecContext(ctx, createTrackingTable); err != nil {
+		return fmt.Errorf("migrate: create tracking table: %w", err)
+	}
+
+	state, err := loadState(ctx, db)
+	if err != nil {
+		return err
+	}
+	if state.dirtyVersion != "" {
+		return fmt.Errorf("%w: version %s", ErrDirty, state.dirtyVersion)
+	}
+
+	toRevert := state.appliedInOrder
+	if len(toRevert) > steps {
+		toRevert = toRevert[len(toRevert)-steps:]
+	}
+
+	for i := len(toRevert) - 1; i >= 0; i-- {
+		version := toRevert[i]
+		contents, err := fs.ReadFile(source, version+downSuffix)
+		if err != nil {
+			return fmt.Errorf("migrate: read %s: %w", version+downSuffix, err)
+		}
+		if err := revertOne(ctx, db, version, string(contents)); err != nil {
+			return fmt.Errorf("migrate: revert %s: %w", version, err)
+		}
+	}
+
+	return nil
+}
+
+// Force clears the dirty flag on version without re-running its SQL,
+// for the operator path out of ErrDirty: inspect the database by hand,
+// decide whether the version's SQL fully applied, then call Force so
+// Run and Down will proceed again.
+func Force(ctx context.Context, db *sql.DB, version string) error {
+	_, err := db.ExecContext(ctx, `UPDATE schema_migrations SET dirty = FALSE WHERE version = ?`, version)
+	return err
+}
+
+// listVersions returns every migration's version identifier (its
+// filename with the .up.sql suffix stripped), sorted by filename so
+// numeric prefixes keep migrations in apply order.
+func listVersions(source fs.FS) ([]string, error) {
+	entries, err := fs.Glob(source, "*"+upSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("migrate: list migrations: %w", err)
+	}
+	sort.Strings(entries)
+
+	versions := make([]string, len(entries))
+	for i, entry := range entries {
+		versions[i] = strings.TrimSuffix(entry, upSuffix)
+	}
+	return versions, nil
+}
+
+type migrationState struct {
+	applied        map[string]bool
+	appliedInOrder []string
+	dirtyVersion   string
+}
+
+func loadState(ctx context.Context, db *sql.DB) (migrationState, error) {
+	rows, err := db.QueryContext(ctx, `SELECT version, dirty FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return migrationState{}, fmt.Errorf("migrate: load applied versions: %w", err)
+	}
+	defer rows.Close()
+
+	state := migrationState{applied: make(map[string]bool)}
+	for rows.Next() {
+		var version string
+		var dirty bool
+		if err := rows.Scan(&version, &dirty); err != nil {
+			return migrationState{}, err
+		}
+		if dirty {
+			state.dirtyVersion = version
+			continue
+		}
+		state.applied[version] = true
+		state.appliedInOrder = append(state.appliedInOrder, version)
+	}
+	return state, rows.Err()
+}
+
+func applyOne(ctx context.Context, db *sql.DB, version, sqlText string) error {
+	if _, err := db.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES (?, TRUE)`, version); err != nil {
+		return err
+	}
+	if _, err := db.ExecContext(ctx, sqlText); err != nil {
+		return err
+	}
+	_, err := db.ExecContext(ctx, `UPDATE schema_migrations SET dirty = FALSE WHERE version = ?`, version)
+	return err
+}
+
+func revertOne(ctx context.Context, db *sql.DB, version, sqlText string) error {
+	if _, err := db.ExecContext(ctx, `UPDATE schema_migrations SET dirty = TRUE WHERE version = ?`, version); err != nil {
+		return err
+	}
+	if _, err := db.ExecContext(ctx, sqlText); err != nil {
+		return err
+	}
+	_, err := db.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = ?`, version)
+	return err
+}
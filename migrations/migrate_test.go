@@ -0,0 +1,29 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestListVersionsSortsByFilenameAndStripsUpSuffix(t *testing.T) {
+	source := fstest.MapFS{
+		"0002_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id INT);")},
+		"0002_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"0001_create_proxies.up.sql": {Data: []byte("CREATE TABLE proxies (id INT);")},
+	}
+
+	got, err := listVersions(source)
+	if err != nil {
+		t.Fatalf("listVersions() error = %v", err)
+	}
+
+	want := []string{"0001_create_proxies", "0002_create_users"}
+	if len(got) != len(want) {
+		t.Fatalf("listVersions() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("listVersions()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package alerting
+
+import (
+	"context"
+	"net/http"
+)
+
+// SlackNotifier posts alerts to a Slack incoming webhook URL.
+type SlackNotifier struct {
+	http *http.Client
+}
+
+// NewSlackNotifier builds a SlackNotifier.
+func NewSlackNotifier() *SlackNotifier {
+	return &SlackNotifier{http: &http.Client{Timeout: notifierTimeout}}
+}
+
+// Notify posts alert to a Slack incoming webhook at target.
+func (n *SlackNotifier) Notify(ctx context.Context, target string, alert Alert) error {
+	return postJSON(ctx, n.http, target, map[string]string{"text": alert.Message})
+}
+
+// DingTalkNotifier posts alerts to a DingTalk custom robot webhook URL.
+type DingTalkNotifier struct {
+	http *http.Client
+}
+
+// NewDingTalkNotifier builds a DingTalkNotifier.
+func NewDingTalkNotifier() *DingTalkNotifier {
+	return &DingTalkNotifier{http: &http.Client{Timeout: notifierTimeout}}
+}
+
+// Notify posts alert to a DingTalk custom robot webhook at target.
+func (n *DingTalkNotifier) Notify(ctx context.Context, target string, alert Alert) error {
+	return postJSON(ctx, n.http, target, map[string]interface{}{
+		"msgtype": "text",
+		"text":    map[string]string{"content": alert.Message},
+	})
+}
+
+// FeishuNotifier posts alerts to a Feishu (Lark) custom bot webhook URL.
+type FeishuNotifier struct {
+	http *http.Client
+}
+
+// NewFeishuNotifier builds a FeishuNotifier.
+func NewFeishuNotifier() *FeishuNotifier {
+	return &FeishuNotifier{http: &http.Client{Timeout: notifierTimeout}}
+}
+
+// Notify posts alert to a Feishu custom bot webhook at target.
+func (n *FeishuNotifier) Notify(ctx context.Context, target string, alert Alert) error {
+	return postJSON(ctx, n.http, target, map[string]interface{}{
+		"msg_type": "text",
+		"content":  map[string]string{"text": alert.Message},
+	})
+}
@@ -0,0 +1,86 @@
+package alerting
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// DAO manages alert rules in MySQL.
+type DAO struct {
+	db *sql.DB
+}
+
+// NewDAO wraps an existing *sql.DB handle.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db}
+}
+
+// List returns every configured alert rule.
+func (d *DAO) List(ctx context.Context) ([]*Rule, error) {
+	return d.list(ctx, `SELECT id, type, threshold, channel, target, enabled FROM alert_rules`)
+}
+
+// ListEnabled returns every alert rule the engine should evaluate.
+func (d *DAO) ListEnabled(ctx context.Context) ([]*Rule, error) {
+	return d.list(ctx, `SELECT id, type, threshold, channel, target, enabled FROM alert_rules WHERE enabled = TRUE`)
+}
+
+func (d *DAO) list(ctx context.Context, query string) ([]*Rule, error) {
+	rows, err := d.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Rule
+	for rows.Next() {
+		r, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// Insert creates a new alert rule, assigning it an ID.
+func (d *DAO) Insert(ctx context.Context, r *Rule) error {
+	r.ID = uuid.NewString()
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO alert_rules (id, type, threshold, channel, target, enabled)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		r.ID, string(r.Type), r.Threshold, string(r.Channel), r.Target, r.Enabled)
+	return err
+}
+
+// Update replaces an existing alert rule's fields.
+func (d *DAO) Update(ctx context.Context, r *Rule) error {
+	_, err := d.db.ExecContext(ctx,
+		`UPDATE alert_rules SET type = ?, threshold = ?, channel = ?, target = ?, enabled = ? WHERE id = ?`,
+		string(r.Type), r.Threshold, string(r.Channel), r.Target, r.Enabled, r.ID)
+	return err
+}
+
+// Delete removes an alert rule. It is a no-op if the rule doesn't
+// exist.
+func (d *DAO) Delete(ctx context.Context, id string) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM alert_rules WHERE id = ?`, id)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRule(row rowScanner) (*Rule, error) {
+	r := &Rule{}
+	var ruleType, channel string
+	if err := row.Scan(&r.ID, &ruleType, &r.Threshold, &channel, &r.Target, &r.Enabled); err != nil {
+		return nil, err
+	}
+	r.Type = RuleType(ruleType)
+	r.Channel = Channel(channel)
+	return r, nil
+}
@@ -0,0 +1,42 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// EmailNotifier delivers alerts over SMTP using net/smtp directly
+// rather than a third-party mail client, the same "stdlib over a
+// dependency" call made for pkg/objstore's S3 client and pkg/secrets's
+// Vault provider.
+type EmailNotifier struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewEmailNotifier builds an EmailNotifier that authenticates to the
+// SMTP server at addr (host:port) with username/password, if given, and
+// sends from the from address. An empty username disables AUTH, for
+// SMTP relays that only accept connections from trusted networks.
+func NewEmailNotifier(addr, username, password, from string) *EmailNotifier {
+	var auth smtp.Auth
+	if username != "" {
+		host := addr
+		if idx := strings.LastIndex(addr, ":"); idx != -1 {
+			host = addr[:idx]
+		}
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &EmailNotifier{addr: addr, auth: auth, from: from}
+}
+
+// Notify sends alert as a plain-text email to target. net/smtp has no
+// context-aware send, so ctx is not honored beyond this package's other
+// notifiers' shared signature.
+func (n *EmailNotifier) Notify(_ context.Context, target string, alert Alert) error {
+	msg := fmt.Sprintf("Subject: [alert] %s\r\nTo: %s\r\n\r\n%s\r\n", alert.Type, target, alert.Message)
+	return smtp.SendMail(n.addr, n.auth, n.from, []string{target}, []byte(msg))
+}
@@ -0,0 +1,228 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/pagination"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// healthWindow is how far back Engine looks when computing the current
+// health-check success rate for RuleTypeHealthSuccessRateDrop.
+const healthWindow = 24 * time.Hour
+
+// userPageSize bounds how many users Engine loads per page when
+// checking RuleTypeQuotaAtPercent, so a large user base doesn't load
+// into memory at once.
+const userPageSize = 200
+
+// Engine evaluates configured Rules against the platform's live state
+// and dispatches an Alert through each rule's configured Notifier when
+// its condition holds.
+type Engine struct {
+	rules        *DAO
+	hotPool      *redis.HotZSet
+	healthChecks *dao.ProxyHealthCheckDAO
+	users        *user.DAO
+	subs         *billing.SubscriptionDAO
+	plans        *billing.PlanDAO
+	rollups      *usage.RollupDAO
+	usageDAO     *usage.DAO
+	notifiers    map[Channel]Notifier
+}
+
+// New builds an Engine with the default notifier set (email, webhook,
+// Slack, DingTalk, Feishu), one per Channel.
+func New(
+	rules *DAO,
+	hotPool *redis.HotZSet,
+	healthChecks *dao.ProxyHealthCheckDAO,
+	users *user.DAO,
+	subs *billing.SubscriptionDAO,
+	plans *billing.PlanDAO,
+	rollups *usage.RollupDAO,
+	usageDAO *usage.DAO,
+	email *EmailNotifier,
+) *Engine {
+	return &Engine{
+		rules: rules, hotPool: hotPool, healthChecks: healthChecks, users: users,
+		subs: subs, plans: plans, rollups: rollups, usageDAO: usageDAO,
+		notifiers: map[Channel]Notifier{
+			ChannelEmail:    email,
+			ChannelWebhook:  NewWebhookNotifier(),
+			ChannelSlack:    NewSlackNotifier(),
+			ChannelDingTalk: NewDingTalkNotifier(),
+			ChannelFeishu:   NewFeishuNotifier(),
+		},
+	}
+}
+
+// Run evaluates rules on a ticker until ctx is canceled, logging (but
+// not stopping on) evaluation errors, the same pattern as
+// pkg/retention.Cleaner and usage.Aggregator's background loops.
+func (e *Engine) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.Evaluate(ctx); err != nil {
+				log.Printf("alerting: evaluation pass failed: %v", err)
+			}
+		}
+	}
+}
+
+// Evaluate checks every enabled rule and dispatches an Alert for each
+// one whose condition currently holds. A single rule failing to
+// evaluate or dispatch is logged and skipped, not fatal to the pass.
+func (e *Engine) Evaluate(ctx context.Context) error {
+	rules, err := e.rules.ListEnabled(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		alert, fire, err := e.check(ctx, rule)
+		if err != nil {
+			log.Printf("alerting: failed to evaluate rule %s (%s): %v", rule.ID, rule.Type, err)
+			continue
+		}
+		if !fire {
+			continue
+		}
+
+		notifier, ok := e.notifiers[rule.Channel]
+		if !ok {
+			log.Printf("alerting: rule %s has no notifier registered for channel %s", rule.ID, rule.Channel)
+			continue
+		}
+		if err := notifier.Notify(ctx, rule.Target, alert); err != nil {
+			log.Printf("alerting: failed to dispatch alert for rule %s via %s: %v", rule.ID, rule.Channel, err)
+		}
+	}
+	return nil
+}
+
+func (e *Engine) check(ctx context.Context, rule *Rule) (Alert, bool, error) {
+	switch rule.Type {
+	case RuleTypePoolSizeBelow:
+		return e.checkPoolSize(ctx, rule)
+	case RuleTypeHealthSuccessRateDrop:
+		return e.checkHealthSuccessRate(ctx, rule)
+	case RuleTypeQuotaAtPercent:
+		return e.checkQuota(ctx, rule)
+	case RuleTypeSubscriptionExpiring:
+		return e.checkSubscriptionExpiring(ctx, rule)
+	default:
+		return Alert{}, false, fmt.Errorf("alerting: unknown rule type %q", rule.Type)
+	}
+}
+
+func (e *Engine) alert(rule *Rule, message string) Alert {
+	return Alert{RuleID: rule.ID, Type: rule.Type, Message: message, FiredAt: time.Now().UTC()}
+}
+
+func (e *Engine) checkPoolSize(ctx context.Context, rule *Rule) (Alert, bool, error) {
+	size, err := e.hotPool.Size(ctx)
+	if err != nil {
+		return Alert{}, false, err
+	}
+	if float64(size) >= rule.Threshold {
+		return Alert{}, false, nil
+	}
+	return e.alert(rule, fmt.Sprintf("hot proxy pool size is %d, below threshold %.0f", size, rule.Threshold)), true, nil
+}
+
+func (e *Engine) checkHealthSuccessRate(ctx context.Context, rule *Rule) (Alert, bool, error) {
+	end := time.Now().UTC()
+	trend, err := e.healthChecks.DailySuccessRateTrend(ctx, end.Add(-healthWindow), end)
+	if err != nil {
+		return Alert{}, false, err
+	}
+	if len(trend) == 0 {
+		return Alert{}, false, nil
+	}
+
+	latest := trend[len(trend)-1]
+	if latest.TotalChecks == 0 {
+		return Alert{}, false, nil
+	}
+
+	rate := float64(latest.SuccessCount) / float64(latest.TotalChecks) * 100
+	if rate >= rule.Threshold {
+		return Alert{}, false, nil
+	}
+	return e.alert(rule, fmt.Sprintf("health-check success rate is %.1f%%, below threshold %.1f%%", rate, rule.Threshold)), true, nil
+}
+
+// checkQuota counts active users who have consumed at least
+// rule.Threshold percent of their plan's monthly request quota, firing
+// one summary alert (rather than one per user) if any have.
+func (e *Engine) checkQuota(ctx context.Context, rule *Rule) (Alert, bool, error) {
+	now := time.Now().UTC()
+	plans := map[user.Plan]*billing.Plan{}
+	var over int
+
+	for offset := 0; ; offset += userPageSize {
+		page, err := e.users.List(ctx, user.ListFilter{Status: user.StatusActive, Page: pagination.Params{Limit: userPageSize, Offset: offset}})
+		if err != nil {
+			return Alert{}, false, err
+		}
+		if len(page.Items) == 0 {
+			break
+		}
+
+		for _, u := range page.Items {
+			plan, ok := plans[u.Plan]
+			if !ok {
+				plan, err = e.plans.Get(ctx, u.Plan)
+				if err != nil {
+					return Alert{}, false, err
+				}
+				plans[u.Plan] = plan
+			}
+			if plan.QuotaRequests <= 0 {
+				continue
+			}
+
+			stats, err := e.rollups.MonthlyStats(ctx, e.usageDAO, u.ID, now)
+			if err != nil {
+				return Alert{}, false, err
+			}
+			if float64(stats.RequestCount)/float64(plan.QuotaRequests)*100 >= rule.Threshold {
+				over++
+			}
+		}
+
+		if len(page.Items) < userPageSize {
+			break
+		}
+	}
+
+	if over == 0 {
+		return Alert{}, false, nil
+	}
+	return e.alert(rule, fmt.Sprintf("%d user(s) have reached %.0f%% of their plan's monthly quota", over, rule.Threshold)), true, nil
+}
+
+func (e *Engine) checkSubscriptionExpiring(ctx context.Context, rule *Rule) (Alert, bool, error) {
+	cutoff := time.Now().UTC().Add(time.Duration(rule.Threshold) * 24 * time.Hour)
+	expiring, err := e.subs.ListExpiringBefore(ctx, cutoff)
+	if err != nil {
+		return Alert{}, false, err
+	}
+	if len(expiring) == 0 {
+		return Alert{}, false, nil
+	}
+	return e.alert(rule, fmt.Sprintf("%d subscription(s) renew within %.0f days", len(expiring), rule.Threshold)), true, nil
+}
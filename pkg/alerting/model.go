@@ -0,0 +1,59 @@
+// Package alerting evaluates operator-configured rules against the
+// platform's live state (hot pool size, health-check success rate,
+// quota usage, subscription expiry) and dispatches notifications
+// through a pluggable set of channels (email, webhook, Slack, DingTalk,
+// Feishu) when a rule's condition holds.
+package alerting
+
+import "time"
+
+// RuleType identifies which condition a Rule evaluates.
+type RuleType string
+
+const (
+	// RuleTypePoolSizeBelow fires when the hot proxy pool has fewer
+	// than Threshold proxies.
+	RuleTypePoolSizeBelow RuleType = "pool_size_below"
+	// RuleTypeHealthSuccessRateDrop fires when the trailing-day
+	// health-check success rate falls below Threshold percent.
+	RuleTypeHealthSuccessRateDrop RuleType = "health_success_rate_drop"
+	// RuleTypeQuotaAtPercent fires when one or more users have
+	// consumed at least Threshold percent of their plan's monthly
+	// request quota.
+	RuleTypeQuotaAtPercent RuleType = "quota_at_percent"
+	// RuleTypeSubscriptionExpiring fires when one or more active
+	// subscriptions renew within Threshold days.
+	RuleTypeSubscriptionExpiring RuleType = "subscription_expiring"
+)
+
+// Channel identifies which Notifier dispatches a Rule's alerts.
+type Channel string
+
+const (
+	ChannelEmail    Channel = "email"
+	ChannelWebhook  Channel = "webhook"
+	ChannelSlack    Channel = "slack"
+	ChannelDingTalk Channel = "dingtalk"
+	ChannelFeishu   Channel = "feishu"
+)
+
+// Rule is an alert condition configured by an operator: when Type's
+// check crosses Threshold, an Alert fires to Channel at Target (an
+// email address for ChannelEmail, a webhook URL for every other
+// channel).
+type Rule struct {
+	ID        string
+	Type      RuleType
+	Threshold float64
+	Channel   Channel
+	Target    string
+	Enabled   bool
+}
+
+// Alert is one notification produced by evaluating a Rule.
+type Alert struct {
+	RuleID  string
+	Type    RuleType
+	Message string
+	FiredAt time.Time
+}
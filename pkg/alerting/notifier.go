@@ -0,0 +1,10 @@
+package alerting
+
+import "context"
+
+// Notifier delivers an Alert to target, interpreted per-implementation:
+// an email address for EmailNotifier, a webhook URL for every other
+// implementation in this package.
+type Notifier interface {
+	Notify(ctx context.Context, target string, alert Alert) error
+}
@@ -0,0 +1,59 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// notifierTimeout bounds how long a chat/webhook notifier waits for the
+// remote endpoint, so a slow or unreachable webhook never blocks an
+// evaluation pass for long.
+const notifierTimeout = 10 * time.Second
+
+// WebhookNotifier POSTs an Alert as JSON to an arbitrary URL.
+type WebhookNotifier struct {
+	http *http.Client
+}
+
+// NewWebhookNotifier builds a WebhookNotifier.
+func NewWebhookNotifier() *WebhookNotifier {
+	return &WebhookNotifier{http: &http.Client{Timeout: notifierTimeout}}
+}
+
+// Notify posts alert as JSON to target.
+func (n *WebhookNotifier) Notify(ctx context.Context, target string, alert Alert) error {
+	return postJSON(ctx, n.http, target, alert)
+}
+
+// postJSON marshals payload and POSTs it to url, returning an error if
+// the request fails to send or the remote end responds with a non-2xx
+// status. It backs every webhook-style Notifier in this package (plain
+// webhooks and the Slack/DingTalk/Feishu chat notifiers), which differ
+// only in the payload shape they build.
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("alerting: webhook post to %s returned %s", url, resp.Status)
+	}
+	return nil
+}
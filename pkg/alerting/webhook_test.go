@@ -0,0 +1,58 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookNotifierPostsAlertJSON(t *testing.T) {
+	var received Alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier()
+	alert := Alert{RuleID: "rule-1", Type: RuleTypePoolSizeBelow, Message: "pool size is low"}
+	if err := notifier.Notify(context.Background(), server.URL, alert); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if received.Message != alert.Message {
+		t.Fatalf("expected message %q, got %q", alert.Message, received.Message)
+	}
+}
+
+func TestWebhookNotifierReturnsErrorOnNonSuccessStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier()
+	if err := notifier.Notify(context.Background(), server.URL, Alert{}); err == nil {
+		t.Fatal("expected an error for a 500 response, got nil")
+	}
+}
+
+func TestSlackNotifierSendsTextPayload(t *testing.T) {
+	var body map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotifier()
+	if err := notifier.Notify(context.Background(), server.URL, Alert{Message: "hot pool is low"}); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if body["text"] != "hot pool is low" {
+		t.Fatalf("expected text field with alert message, got %v", body)
+	}
+}
@@ -0,0 +1,114 @@
+// Package apierrors defines a small registry of typed API error codes
+// shared across services, each mapped to an HTTP status and a
+// localized canned message, so middleware and handlers can return a
+// consistent JSON error envelope instead of ad-hoc strings.
+package apierrors
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/locale"
+)
+
+// Code identifies a class of API error.
+type Code string
+
+const (
+	CodeInvalidRequest  Code = "invalid_request"
+	CodeUnauthenticated Code = "unauthenticated"
+	CodeForbidden       Code = "forbidden"
+	CodeNotFound        Code = "not_found"
+	CodeConflict        Code = "conflict"
+	CodeInternal        Code = "internal"
+	CodeReadOnly        Code = "read_only"
+)
+
+var statusByCode = map[Code]int{
+	CodeInvalidRequest:  http.StatusBadRequest,
+	CodeUnauthenticated: http.StatusUnauthorized,
+	CodeForbidden:       http.StatusForbidden,
+	CodeNotFound:        http.StatusNotFound,
+	CodeConflict:        http.StatusConflict,
+	CodeInternal:        http.StatusInternalServerError,
+	CodeReadOnly:        http.StatusServiceUnavailable,
+}
+
+// Status returns the HTTP status code a Code should be reported as,
+// defaulting to 500 for an unrecognized code.
+func (c Code) Status() int {
+	if status, ok := statusByCode[c]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+var messages = map[locale.Locale]map[Code]string{
+	locale.EN: {
+		CodeInvalidRequest:  "the request was invalid",
+		CodeUnauthenticated: "authentication is required",
+		CodeForbidden:       "you don't have permission to do that",
+		CodeNotFound:        "the requested resource was not found",
+		CodeConflict:        "the request conflicts with existing state",
+		CodeInternal:        "an internal error occurred",
+		CodeReadOnly:        "the platform is in maintenance mode and is temporarily read-only",
+	},
+	locale.ZH: {
+		CodeInvalidRequest:  "请求无效",
+		CodeUnauthenticated: "需要先进行身份验证",
+		CodeForbidden:       "你没有权限执行该操作",
+		CodeNotFound:        "未找到请求的资源",
+		CodeConflict:        "该请求与现有状态冲突",
+		CodeInternal:        "发生内部错误",
+		CodeReadOnly:        "平台处于维护模式，暂时只读",
+	},
+}
+
+// Error is a typed API error carrying a Code and an optional
+// caller-supplied Detail appended to the canned message.
+type Error struct {
+	Code   Code
+	Detail string
+}
+
+// New builds an Error. detail may be empty, in which case only the
+// canned message for code is ever shown.
+func New(code Code, detail string) *Error {
+	return &Error{Code: code, Detail: detail}
+}
+
+// Message renders the error in l, falling back to English for an
+// unrecognized locale.
+func (e *Error) Message(l locale.Locale) string {
+	set, ok := messages[l]
+	if !ok {
+		set = messages[locale.EN]
+	}
+	msg := set[e.Code]
+	if msg == "" {
+		msg = messages[locale.EN][CodeInternal]
+	}
+	if e.Detail == "" {
+		return msg
+	}
+	return msg + ": " + e.Detail
+}
+
+// Error implements the error interface with the English message.
+func (e *Error) Error() string {
+	return e.Message(locale.EN)
+}
+
+type body struct {
+	Code    Code   `json:"code"`
+	Message string `json:"message"`
+}
+
+// Write selects a locale from r's Accept-Language header and writes
+// err as a JSON envelope with the matching HTTP status.
+func Write(w http.ResponseWriter, r *http.Request, err *Error) {
+	l := locale.FromAcceptLanguage(r.Header.Get("Accept-Language"))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(err.Code.Status())
+	json.NewEncoder(w).Encode(body{Code: err.Code, Message: err.Message(l)})
+}
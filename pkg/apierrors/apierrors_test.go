@@ -0,0 +1,65 @@
+package apierrors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/locale"
+)
+
+func TestCodeStatus(t *testing.T) {
+	cases := map[Code]int{
+		CodeInvalidRequest:  http.StatusBadRequest,
+		CodeUnauthenticated: http.StatusUnauthorized,
+		CodeForbidden:       http.StatusForbidden,
+		CodeNotFound:        http.StatusNotFound,
+		CodeConflict:        http.StatusConflict,
+		CodeInternal:        http.StatusInternalServerError,
+		Code("bogus"):       http.StatusInternalServerError,
+	}
+	for code, want := range cases {
+		if got := code.Status(); got != want {
+			t.Errorf("Code(%q).Status() = %d, want %d", code, got, want)
+		}
+	}
+}
+
+func TestErrorMessageIncludesDetail(t *testing.T) {
+	err := New(CodeForbidden, "missing required permission: proxy:write")
+	msg := err.Message(locale.EN)
+	if msg != "you don't have permission to do that: missing required permission: proxy:write" {
+		t.Fatalf("unexpected message: %q", msg)
+	}
+}
+
+func TestErrorMessageLocalized(t *testing.T) {
+	err := New(CodeNotFound, "")
+	msg := err.Message(locale.ZH)
+	if msg != "未找到请求的资源" {
+		t.Fatalf("unexpected message: %q", msg)
+	}
+}
+
+func TestWriteSelectsLocaleFromAcceptLanguage(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Language", "zh-CN")
+	w := httptest.NewRecorder()
+
+	Write(w, r, New(CodeUnauthenticated, ""))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	var got body
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Code != CodeUnauthenticated {
+		t.Errorf("code = %q, want %q", got.Code, CodeUnauthenticated)
+	}
+	if got.Message != "需要先进行身份验证" {
+		t.Errorf("message = %q, want localized zh message", got.Message)
+	}
+}
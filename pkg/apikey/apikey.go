@@ -0,0 +1,384 @@
+// Package apikey manages the API keys gateway and customer-facing
+// clients authenticate with: generating them, looking them up by hash,
+// and listing/revoking/rotating a user's own keys.
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dbtx"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// ErrNotFound is returned when no active key matches.
+var ErrNotFound = errors.New("apikey: not found")
+
+// Key is a single API key record. UserID is who it belongs to; the raw
+// key value itself is never stored, only its hash and a short suffix
+// kept for display purposes. Permissions scopes what the key can
+// authorize independently of its owner's role, e.g. a read-only
+// integration key for an admin's account.
+type Key struct {
+	ID     string
+	UserID string
+	// OrgID, if non-empty, scopes this key to an organization
+	// (pkg/organization) rather than purely to UserID: any member of
+	// that org can be shown and use the key, not just the user who
+	// created it. UserID remains the creator for audit purposes either
+	// way.
+	OrgID                   string
+	Name                    string
+	Status                  string
+	Permissions             []string
+	KeySuffix               string
+	ExpiresAt               *time.Time
+	CreatedAt               time.Time
+	RotationMode            string
+	RotationIntervalSeconds int
+	// Plan is the owning user's subscription plan, used by callers that
+	// need plan-aware behavior such as tiered rate limits. It is only
+	// populated by lookups that already resolve the owner's plan as part
+	// of the same round trip (e.g. rpcclient.UserClient.Authorize); DAO
+	// lookups in this package leave it empty rather than joining against
+	// the users table for a field most callers don't need.
+	Plan user.Plan
+}
+
+const (
+	StatusActive  = "active"
+	StatusRevoked = "revoked"
+	StatusExpired = "expired"
+)
+
+// Rotation modes control how the gateway assigns upstream proxies to
+// requests authenticated with a given key. RotationModePerRequest picks
+// a fresh upstream every time; RotationModeSticky pins one upstream to
+// the key indefinitely (until it falls out of the hot set);
+// RotationModeInterval pins one upstream for RotationIntervalSeconds at
+// a time before forcing a new pick.
+const (
+	RotationModePerRequest = "per_request"
+	RotationModeSticky     = "sticky"
+	RotationModeInterval   = "interval"
+)
+
+// Masked returns the key for display in a list: its last few
+// characters, since the raw value itself was never persisted.
+func (k *Key) Masked() string {
+	return "****" + k.KeySuffix
+}
+
+// Hash returns the lookup hash for a raw API key value. Keys are
+// high-entropy random tokens, not user-chosen secrets, so a fast,
+// unsalted hash is sufficient here (unlike password hashing).
+func Hash(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// DAOInterface is the subset of DAO's behavior that service and handler
+// code depends on. It exists so those layers can be unit-tested against
+// daofake's in-memory fake instead of a real MySQL connection; see
+// pkg/daofake's conformance suite, which every implementation (DAO
+// included) must pass.
+type DAOInterface interface {
+	Generate(ctx context.Context, userID, orgID, name string, permissions []string, expiresAt *time.Time, rotationMode string, rotationIntervalSeconds int) (raw string, key *Key, err error)
+	List(ctx context.Context, userID string) ([]*Key, error)
+	ListForOrg(ctx context.Context, orgID string) ([]*Key, error)
+	Revoke(ctx context.Context, userID, keyID string) error
+	DeactivateExpired(ctx context.Context, cutoff time.Time, limit int) (int64, error)
+	Rotate(ctx context.Context, userID, keyID string) (raw string, key *Key, err error)
+	LookupByRawKey(ctx context.Context, raw string) (*Key, error)
+}
+
+// DAO manages API keys in MySQL.
+type DAO struct {
+	db dbtx.Queryer
+
+	// rawDB is set only when DAO was built via NewDAO, not WithTx, since
+	// a *sql.Tx can't itself start a nested transaction. Rotate uses it
+	// directly for its own atomic revoke-then-reissue.
+	rawDB *sql.DB
+}
+
+var _ DAOInterface = (*DAO)(nil)
+
+// NewDAO wraps an existing *sql.DB handle.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db, rawDB: db}
+}
+
+// WithTx returns a DAO whose operations run against tx instead of the
+// original *sql.DB, so callers can compose it with other DAOs inside a
+// dbtx.Run unit of work.
+func (d *DAO) WithTx(tx *sql.Tx) *DAO {
+	return &DAO{db: tx}
+}
+
+// Generate creates a new active key for userID with the given name,
+// permissions, optional expiry (nil for a key that never expires), and
+// proxy rotation policy. orgID, if non-empty, scopes the key to that
+// organization (see Key.OrgID) instead of purely to userID; pass "" for
+// an ordinary personal key. rotationMode should be one of the
+// RotationMode* constants; rotationIntervalSeconds is only meaningful
+// for RotationModeInterval. It returns the raw key value alongside its
+// record; the raw value is shown to the caller exactly once and is not
+// recoverable afterward.
+func (d *DAO) Generate(ctx context.Context, userID, orgID, name string, permissions []string, expiresAt *time.Time, rotationMode string, rotationIntervalSeconds int) (raw string, key *Key, err error) {
+	raw, err = newRawKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	encodedPermissions, err := json.Marshal(permissions)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if rotationMode == "" {
+		rotationMode = RotationModePerRequest
+	}
+
+	key = &Key{
+		ID:                      uuid.NewString(),
+		UserID:                  userID,
+		OrgID:                   orgID,
+		Name:                    name,
+		Status:                  StatusActive,
+		Permissions:             permissions,
+		KeySuffix:               raw[len(raw)-4:],
+		ExpiresAt:               expiresAt,
+		RotationMode:            rotationMode,
+		RotationIntervalSeconds: rotationIntervalSeconds,
+	}
+
+	_, err = d.db.ExecContext(ctx,
+		`INSERT INTO api_keys (id, user_id, org_id, key_hash, name, key_suffix, status, permissions, expires_at, rotation_mode, rotation_interval_seconds)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		key.ID, key.UserID, nullableString(key.OrgID), Hash(raw), key.Name, key.KeySuffix, key.Status, string(encodedPermissions), key.ExpiresAt, key.RotationMode, key.RotationIntervalSeconds)
+	if err != nil {
+		return "", nil, err
+	}
+	return raw, key, nil
+}
+
+// List returns every key belonging to userID, active or revoked, most
+// recently created first.
+func (d *DAO) List(ctx context.Context, userID string) ([]*Key, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT id, user_id, org_id, name, key_suffix, status, permissions, expires_at, created_at, rotation_mode, rotation_interval_seconds
+		 FROM api_keys WHERE user_id = ? ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Key
+	for rows.Next() {
+		k, err := scanKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, k)
+	}
+	return out, rows.Err()
+}
+
+// ListForOrg returns every key scoped to orgID, active or revoked, most
+// recently created first, for a member viewing their organization's
+// shared keys.
+func (d *DAO) ListForOrg(ctx context.Context, orgID string) ([]*Key, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT id, user_id, org_id, name, key_suffix, status, permissions, expires_at, created_at, rotation_mode, rotation_interval_seconds
+		 FROM api_keys WHERE org_id = ? ORDER BY created_at DESC`, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Key
+	for rows.Next() {
+		k, err := scanKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, k)
+	}
+	return out, rows.Err()
+}
+
+// Revoke marks a key owned by userID as revoked, so future
+// LookupByRawKey calls against it fail. It is a no-op if the key
+// doesn't exist or belongs to a different user.
+func (d *DAO) Revoke(ctx context.Context, userID, keyID string) error {
+	_, err := d.db.ExecContext(ctx,
+		`UPDATE api_keys SET status = ? WHERE id = ? AND user_id = ?`, StatusRevoked, keyID, userID)
+	return err
+}
+
+// DeactivateExpired marks up to limit active keys whose ExpiresAt has
+// passed cutoff as expired, returning how many rows it changed.
+// LookupByRawKey already rejects an expired key whose status is still
+// "active" (it checks ExpiresAt directly), so this doesn't change
+// authentication behavior; it exists so an expired key shows up as
+// such in the API and admin UI instead of looking active until
+// someone tries to use it. Callers loop on this in limit-sized chunks,
+// the same way the retention cleaner works through its backlogs.
+func (d *DAO) DeactivateExpired(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	result, err := d.db.ExecContext(ctx,
+		`UPDATE api_keys SET status = ? WHERE status = ? AND expires_at IS NOT NULL AND expires_at <= ? LIMIT ?`,
+		StatusExpired, StatusActive, cutoff, limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Rotate atomically revokes an existing key and issues a replacement
+// with the same name and permissions, so integrations can cycle
+// credentials without losing their configured scope.
+func (d *DAO) Rotate(ctx context.Context, userID, keyID string) (raw string, key *Key, err error) {
+	if d.rawDB == nil {
+		return "", nil, errors.New("apikey: DAO scoped to a transaction cannot start a nested transaction")
+	}
+	tx, err := d.rawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	defer tx.Rollback()
+
+	row := tx.QueryRowContext(ctx,
+		`SELECT org_id, name, permissions, expires_at, rotation_mode, rotation_interval_seconds FROM api_keys WHERE id = ? AND user_id = ?`, keyID, userID)
+	var orgID sql.NullString
+	var name string
+	var permissions sql.NullString
+	var expiresAt sql.NullTime
+	var rotationMode string
+	var rotationIntervalSeconds int
+	if err := row.Scan(&orgID, &name, &permissions, &expiresAt, &rotationMode, &rotationIntervalSeconds); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil, ErrNotFound
+		}
+		return "", nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE api_keys SET status = ? WHERE id = ? AND user_id = ?`, StatusRevoked, keyID, userID); err != nil {
+		return "", nil, err
+	}
+
+	raw, err = newRawKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	key = &Key{
+		ID:                      uuid.NewString(),
+		UserID:                  userID,
+		OrgID:                   orgID.String,
+		Name:                    name,
+		Status:                  StatusActive,
+		KeySuffix:               raw[len(raw)-4:],
+		RotationMode:            rotationMode,
+		RotationIntervalSeconds: rotationIntervalSeconds,
+	}
+	if permissions.Valid && permissions.String != "" {
+		if err := json.Unmarshal([]byte(permissions.String), &key.Permissions); err != nil {
+			return "", nil, err
+		}
+	}
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO api_keys (id, user_id, org_id, key_hash, name, key_suffix, status, permissions, expires_at, rotation_mode, rotation_interval_seconds)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		key.ID, key.UserID, nullableString(key.OrgID), Hash(raw), key.Name, key.KeySuffix, key.Status, permissions.String, key.ExpiresAt, key.RotationMode, key.RotationIntervalSeconds); err != nil {
+		return "", nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", nil, err
+	}
+	return raw, key, nil
+}
+
+// LookupByRawKey hashes raw and returns the matching active, unexpired
+// key.
+func (d *DAO) LookupByRawKey(ctx context.Context, raw string) (*Key, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT id, user_id, org_id, status, permissions, expires_at, rotation_mode, rotation_interval_seconds FROM api_keys WHERE key_hash = ?`, Hash(raw))
+
+	var k Key
+	var orgID sql.NullString
+	var permissions sql.NullString
+	var expiresAt sql.NullTime
+	if err := row.Scan(&k.ID, &k.UserID, &orgID, &k.Status, &permissions, &expiresAt, &k.RotationMode, &k.RotationIntervalSeconds); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	k.OrgID = orgID.String
+	if k.Status != StatusActive {
+		return nil, ErrNotFound
+	}
+	if expiresAt.Valid && time.Now().After(expiresAt.Time) {
+		return nil, ErrNotFound
+	}
+	if permissions.Valid && permissions.String != "" {
+		if err := json.Unmarshal([]byte(permissions.String), &k.Permissions); err != nil {
+			return nil, err
+		}
+	}
+	return &k, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanKey(row rowScanner) (*Key, error) {
+	k := &Key{}
+	var orgID sql.NullString
+	var permissions sql.NullString
+	var expiresAt sql.NullTime
+	if err := row.Scan(&k.ID, &k.UserID, &orgID, &k.Name, &k.KeySuffix, &k.Status, &permissions, &expiresAt, &k.CreatedAt, &k.RotationMode, &k.RotationIntervalSeconds); err != nil {
+		return nil, err
+	}
+	k.OrgID = orgID.String
+	if expiresAt.Valid {
+		k.ExpiresAt = &expiresAt.Time
+	}
+	if permissions.Valid && permissions.String != "" {
+		if err := json.Unmarshal([]byte(permissions.String), &k.Permissions); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+func newRawKey() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "sk_" + hex.EncodeToString(buf), nil
+}
+
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,16 @@
+//go:build integration
+
+package apikey_test
+
+import (
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	"github.com/XXXXD-cation/proxy-platform/pkg/daofake"
+	"github.com/XXXXD-cation/proxy-platform/pkg/testsupport"
+)
+
+// Run with: go test -tags=integration ./pkg/apikey/...
+func TestDAOConformsToDAOInterface(t *testing.T) {
+	daofake.ConformAPIKey(t, apikey.NewDAO(testsupport.GetTestDB(t)))
+}
@@ -0,0 +1,28 @@
+package apikey
+
+import "testing"
+
+func TestKeyMasked(t *testing.T) {
+	k := &Key{KeySuffix: "ab12"}
+	if got, want := k.Masked(), "****ab12"; got != want {
+		t.Fatalf("Masked() = %q, want %q", got, want)
+	}
+}
+
+func TestNewRawKeyIsPrefixedAndUnique(t *testing.T) {
+	a, err := newRawKey()
+	if err != nil {
+		t.Fatalf("newRawKey() error = %v", err)
+	}
+	b, err := newRawKey()
+	if err != nil {
+		t.Fatalf("newRawKey() error = %v", err)
+	}
+
+	if a == b {
+		t.Fatalf("newRawKey() produced identical values: %q", a)
+	}
+	if len(a) <= len("sk_") || a[:3] != "sk_" {
+		t.Fatalf("newRawKey() = %q, want sk_ prefix", a)
+	}
+}
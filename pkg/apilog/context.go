@@ -0,0 +1,29 @@
+package apilog
+
+import "context"
+
+type contextKey int
+
+const (
+	ctxKeyUserID contextKey = iota
+	ctxKeyAPIKeyID
+)
+
+// WithIdentity attaches the authenticated user and API key IDs for the
+// current request so the logging middleware can attribute it. Auth
+// middleware is expected to call this once it has identified the caller.
+func WithIdentity(ctx context.Context, userID, apiKeyID string) context.Context {
+	ctx = context.WithValue(ctx, ctxKeyUserID, userID)
+	ctx = context.WithValue(ctx, ctxKeyAPIKeyID, apiKeyID)
+	return ctx
+}
+
+func userIDFromContext(ctx context.Context) string {
+	v, _ := ctx.Value(ctxKeyUserID).(string)
+	return v
+}
+
+func apiKeyIDFromContext(ctx context.Context) string {
+	v, _ := ctx.Value(ctxKeyAPIKeyID).(string)
+	return v
+}
@@ -0,0 +1,103 @@
+package apilog
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/pagination"
+)
+
+// DAO persists and searches api_request_logs rows in MySQL.
+type DAO struct {
+	db *sql.DB
+}
+
+// NewDAO wraps an existing *sql.DB handle.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db}
+}
+
+// Insert writes a single request log entry. It satisfies Writer.
+func (d *DAO) Insert(entry Entry) error {
+	_, err := d.db.Exec(
+		`INSERT INTO api_request_logs (user_id, api_key_id, method, path, status_code, latency_ms, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		entry.UserID, entry.APIKeyID, entry.Method, entry.Path, entry.StatusCode, entry.LatencyMS, time.Now().UTC(),
+	)
+	return err
+}
+
+// SearchFilter narrows a Search call. Zero values are treated as
+// "no filter" for that field.
+type SearchFilter struct {
+	UserID     string
+	Path       string
+	StatusCode int
+	Cursor     string // opaque, from the previous page's NextCursor
+	Limit      int
+}
+
+// Search returns entries matching filter, newest first, along with a
+// cursor to fetch the next page (empty if there are no more results).
+func (d *DAO) Search(ctx context.Context, filter SearchFilter) ([]Entry, string, error) {
+	limit := filter.Limit
+	if limit <= 0 || limit > pagination.MaxLimit {
+		limit = pagination.DefaultLimit
+	}
+
+	query := `SELECT id, user_id, api_key_id, method, path, status_code, latency_ms, created_at
+	          FROM api_request_logs WHERE 1 = 1`
+	var args []interface{}
+
+	if filter.UserID != "" {
+		query += " AND user_id = ?"
+		args = append(args, filter.UserID)
+	}
+	if filter.Path != "" {
+		query += " AND path = ?"
+		args = append(args, filter.Path)
+	}
+	if filter.StatusCode != 0 {
+		query += " AND status_code = ?"
+		args = append(args, filter.StatusCode)
+	}
+	if filter.Cursor != "" {
+		lastID, err := pagination.DecodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("apilog: invalid cursor: %w", err)
+		}
+		query += " AND id < ?"
+		args = append(args, lastID)
+	}
+
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, limit+1)
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var entries []Entry
+	for rows.Next() {
+		var e Entry
+		if err := rows.Scan(&e.ID, &e.UserID, &e.APIKeyID, &e.Method, &e.Path, &e.StatusCode, &e.LatencyMS, &e.CreatedAt); err != nil {
+			return nil, "", err
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(entries) > limit {
+		entries = entries[:limit]
+		nextCursor = pagination.EncodeCursor(entries[len(entries)-1].ID)
+	}
+
+	return entries, nextCursor, nil
+}
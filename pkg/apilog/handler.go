@@ -0,0 +1,46 @@
+package apilog
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// searchResponse is the JSON body returned by SearchHandler.
+type searchResponse struct {
+	Entries    []Entry `json:"entries"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+// SearchHandler exposes DAO.Search over HTTP with cursor pagination.
+// Supported query parameters: user_id, path, status_code, cursor, limit.
+func SearchHandler(dao *DAO) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		filter := SearchFilter{
+			UserID: q.Get("user_id"),
+			Path:   q.Get("path"),
+			Cursor: q.Get("cursor"),
+		}
+		if v := q.Get("status_code"); v != "" {
+			if status, err := strconv.Atoi(v); err == nil {
+				filter.StatusCode = status
+			}
+		}
+		if v := q.Get("limit"); v != "" {
+			if limit, err := strconv.Atoi(v); err == nil {
+				filter.Limit = limit
+			}
+		}
+
+		entries, next, err := dao.Search(r.Context(), filter)
+		if err != nil {
+			http.Error(w, "failed to search request logs", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(searchResponse{Entries: entries, NextCursor: next})
+	}
+}
@@ -0,0 +1,53 @@
+package apilog
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// Writer persists request log entries. *DAO implements this; a noop or
+// fake implementation is useful in tests.
+type Writer interface {
+	Insert(entry Entry) error
+}
+
+// Middleware returns net/http middleware that records one Entry per
+// request: path, status, latency and the identity attached to the
+// request context by the auth layer. Writes are fire-and-forget so
+// logging never adds latency to the response path.
+func Middleware(w Writer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: rw, status: http.StatusOK}
+
+			next.ServeHTTP(sw, r)
+
+			entry := Entry{
+				UserID:     userIDFromContext(r.Context()),
+				APIKeyID:   apiKeyIDFromContext(r.Context()),
+				Method:     r.Method,
+				Path:       r.URL.Path,
+				StatusCode: sw.status,
+				LatencyMS:  time.Since(start).Milliseconds(),
+			}
+
+			go func() {
+				if err := w.Insert(entry); err != nil {
+					log.Printf("apilog: failed to persist request log: %v", err)
+				}
+			}()
+		})
+	}
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
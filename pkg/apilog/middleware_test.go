@@ -0,0 +1,42 @@
+package apilog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type fakeWriter struct {
+	entries chan Entry
+}
+
+func (f *fakeWriter) Insert(entry Entry) error {
+	f.entries <- entry
+	return nil
+}
+
+func TestMiddlewareRecordsStatusAndIdentity(t *testing.T) {
+	fw := &fakeWriter{entries: make(chan Entry, 1)}
+
+	handler := Middleware(fw)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/keys", nil)
+	req = req.WithContext(WithIdentity(context.Background(), "user-1", "key-1"))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	entry := <-fw.entries
+	if entry.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", entry.StatusCode)
+	}
+	if entry.UserID != "user-1" || entry.APIKeyID != "key-1" {
+		t.Fatalf("expected identity to be attributed, got %+v", entry)
+	}
+	if entry.Path != "/v1/keys" || entry.Method != http.MethodPost {
+		t.Fatalf("unexpected method/path: %+v", entry)
+	}
+}
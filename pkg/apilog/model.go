@@ -0,0 +1,25 @@
+// Package apilog records and serves per-user management-API request logs
+// (as opposed to proxied traffic, which is tracked by the usage-log
+// pipeline). Retention for these logs is intentionally shorter than usage
+// logs since they exist for support/debugging rather than billing.
+package apilog
+
+import "time"
+
+// RetentionDays is how long an api_request_logs row is kept before purge.
+// This is shorter than usage log retention since these logs back support
+// and abuse investigation rather than billing.
+const RetentionDays = 14
+
+// Entry is a single recorded call against a management API (admin or
+// user-facing), as opposed to proxied customer traffic.
+type Entry struct {
+	ID         int64
+	UserID     string
+	APIKeyID   string
+	Method     string
+	Path       string
+	StatusCode int
+	LatencyMS  int64
+	CreatedAt  time.Time
+}
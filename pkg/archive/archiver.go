@@ -0,0 +1,164 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/objstore"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+)
+
+// objectKeyLayout dates archive keys so they sort and browse naturally
+// in the bucket: usage-logs/2026/08/09/<archive-id>.ndjson.gz
+const objectKeyLayout = "2006/01/02"
+
+// Archiver exports old usage_logs rows to object storage and prunes
+// them from MySQL once the export is verified.
+type Archiver struct {
+	usageDAO   *usage.DAO
+	archiveDAO *ArchiveDAO
+	objects    *objstore.Client
+}
+
+// New builds an Archiver.
+func New(usageDAO *usage.DAO, archiveDAO *ArchiveDAO, objects *objstore.Client) *Archiver {
+	return &Archiver{usageDAO: usageDAO, archiveDAO: archiveDAO, objects: objects}
+}
+
+// RunOnce exports and removes up to chunkSize usage_logs rows older than
+// cutoff. It returns the archive record it created, or (nil, nil) if
+// there was nothing to archive.
+//
+// The sequence is export, verify, delete, record: the upload is read
+// back and its row count checked before anything is deleted from MySQL,
+// so a partial or corrupted upload never results in lost data.
+func (a *Archiver) RunOnce(ctx context.Context, cutoff time.Time, chunkSize int) (*Record, error) {
+	logs, err := a.usageDAO.SelectOldLogs(ctx, cutoff, chunkSize)
+	if err != nil {
+		return nil, fmt.Errorf("archive: select old logs: %w", err)
+	}
+	if len(logs) == 0 {
+		return nil, nil
+	}
+
+	payload, err := encodeNDJSONGzip(logs)
+	if err != nil {
+		return nil, fmt.Errorf("archive: encode export: %w", err)
+	}
+
+	key := objectKey(logs[0].CreatedAt)
+	if err := a.objects.PutObject(ctx, key, payload, "application/x-ndjson+gzip"); err != nil {
+		return nil, fmt.Errorf("archive: upload export: %w", err)
+	}
+
+	if err := a.verify(ctx, key, len(logs)); err != nil {
+		return nil, fmt.Errorf("archive: verify export %s: %w", key, err)
+	}
+
+	ids := make([]int64, len(logs))
+	for i, entry := range logs {
+		ids[i] = entry.ID
+	}
+	if _, err := a.usageDAO.DeleteByIDs(ctx, ids); err != nil {
+		return nil, fmt.Errorf("archive: delete exported rows: %w", err)
+	}
+
+	rec := &Record{
+		ObjectKey:    key,
+		RowCount:     len(logs),
+		MinCreatedAt: logs[0].CreatedAt,
+		MaxCreatedAt: logs[len(logs)-1].CreatedAt,
+	}
+	if err := a.archiveDAO.Insert(ctx, rec); err != nil {
+		return nil, fmt.Errorf("archive: record export: %w", err)
+	}
+	return rec, nil
+}
+
+// verify re-downloads the object just uploaded and checks it decodes to
+// exactly wantRows rows, so a truncated or corrupted upload is caught
+// before anything is deleted from MySQL.
+func (a *Archiver) verify(ctx context.Context, key string, wantRows int) error {
+	body, err := a.objects.GetObject(ctx, key)
+	if err != nil {
+		return err
+	}
+	logs, err := decodeNDJSONGzip(body)
+	if err != nil {
+		return err
+	}
+	if len(logs) != wantRows {
+		return fmt.Errorf("uploaded object has %d rows, expected %d", len(logs), wantRows)
+	}
+	return nil
+}
+
+// Restore downloads and decompresses the archive with the given ID,
+// reinserts its rows into usage_logs, and marks the archive restored.
+func (a *Archiver) Restore(ctx context.Context, archiveID string) ([]usage.Log, error) {
+	rec, err := a.archiveDAO.Get(ctx, archiveID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := a.objects.GetObject(ctx, rec.ObjectKey)
+	if err != nil {
+		return nil, fmt.Errorf("archive: download %s: %w", rec.ObjectKey, err)
+	}
+	logs, err := decodeNDJSONGzip(body)
+	if err != nil {
+		return nil, fmt.Errorf("archive: decode %s: %w", rec.ObjectKey, err)
+	}
+
+	if err := a.usageDAO.InsertBatch(ctx, logs); err != nil {
+		return nil, fmt.Errorf("archive: reinsert rows: %w", err)
+	}
+	if err := a.archiveDAO.MarkRestored(ctx, archiveID, time.Now().UTC()); err != nil {
+		return nil, fmt.Errorf("archive: mark restored: %w", err)
+	}
+	return logs, nil
+}
+
+func objectKey(t time.Time) string {
+	return fmt.Sprintf("usage-logs/%s/%s.ndjson.gz", t.UTC().Format(objectKeyLayout), uuid.NewString())
+}
+
+func encodeNDJSONGzip(logs []usage.Log) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(gz)
+	for _, entry := range logs {
+		if err := encoder.Encode(entry); err != nil {
+			return nil, err
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeNDJSONGzip(data []byte) ([]usage.Log, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var logs []usage.Log
+	decoder := json.NewDecoder(gz)
+	for decoder.More() {
+		var entry usage.Log
+		if err := decoder.Decode(&entry); err != nil {
+			return nil, err
+		}
+		logs = append(logs, entry)
+	}
+	return logs, nil
+}
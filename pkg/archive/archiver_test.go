@@ -0,0 +1,33 @@
+package archive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+)
+
+func TestEncodeDecodeNDJSONGzipRoundTrips(t *testing.T) {
+	logs := []usage.Log{
+		{ID: 1, UserID: "u1", ProxyAddr: "1.2.3.4:8080", CreatedAt: time.Unix(1000, 0).UTC()},
+		{ID: 2, UserID: "u2", ProxyAddr: "5.6.7.8:8080", CreatedAt: time.Unix(2000, 0).UTC()},
+	}
+
+	payload, err := encodeNDJSONGzip(logs)
+	if err != nil {
+		t.Fatalf("encodeNDJSONGzip: %v", err)
+	}
+
+	got, err := decodeNDJSONGzip(payload)
+	if err != nil {
+		t.Fatalf("decodeNDJSONGzip: %v", err)
+	}
+	if len(got) != len(logs) {
+		t.Fatalf("decodeNDJSONGzip() returned %d rows, want %d", len(got), len(logs))
+	}
+	for i, entry := range got {
+		if entry.ID != logs[i].ID || entry.UserID != logs[i].UserID {
+			t.Fatalf("row %d = %+v, want %+v", i, entry, logs[i])
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/pagination"
+)
+
+// Insert records a completed export. If id is empty, one is generated.
+func (d *ArchiveDAO) Insert(ctx context.Context, rec *Record) error {
+	if rec.ID == "" {
+		rec.ID = uuid.NewString()
+	}
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO usage_log_archives (id, object_key, row_count, min_created_at, max_created_at)
+		 VALUES (?, ?, ?, ?, ?)`,
+		rec.ID, rec.ObjectKey, rec.RowCount, rec.MinCreatedAt, rec.MaxCreatedAt,
+	)
+	return err
+}
+
+// List returns a page of archives, newest first, along with the total
+// number of archives across every page.
+func (d *ArchiveDAO) List(ctx context.Context, page pagination.Params) (pagination.Page[*Record], error) {
+	limit := page.Limit
+	if limit <= 0 {
+		limit = pagination.DefaultLimit
+	}
+
+	var total int
+	if err := d.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM usage_log_archives`).Scan(&total); err != nil {
+		return pagination.Page[*Record]{}, err
+	}
+
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT id, object_key, row_count, min_created_at, max_created_at, created_at, restored_at
+		  FROM usage_log_archives
+		 ORDER BY created_at DESC
+		 LIMIT ? OFFSET ?`, limit, page.Offset)
+	if err != nil {
+		return pagination.Page[*Record]{}, err
+	}
+	defer rows.Close()
+
+	var out []*Record
+	for rows.Next() {
+		rec, err := scanRecord(rows)
+		if err != nil {
+			return pagination.Page[*Record]{}, err
+		}
+		out = append(out, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return pagination.Page[*Record]{}, err
+	}
+
+	return pagination.Page[*Record]{Items: out, Total: total, Limit: limit, Offset: page.Offset}, nil
+}
+
+// Get returns a single archive by ID.
+func (d *ArchiveDAO) Get(ctx context.Context, id string) (*Record, error) {
+	row := d.db.QueryRowContext(ctx, `
+		SELECT id, object_key, row_count, min_created_at, max_created_at, created_at, restored_at
+		  FROM usage_log_archives
+		 WHERE id = ?`, id)
+	rec, err := scanRecord(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return rec, err
+}
+
+// MarkRestored stamps an archive as restored, once its rows have been
+// reinserted into usage_logs.
+func (d *ArchiveDAO) MarkRestored(ctx context.Context, id string, restoredAt time.Time) error {
+	result, err := d.db.ExecContext(ctx,
+		`UPDATE usage_log_archives SET restored_at = ? WHERE id = ?`, restoredAt, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRecord(row rowScanner) (*Record, error) {
+	rec := &Record{}
+	var restoredAt sql.NullTime
+	if err := row.Scan(&rec.ID, &rec.ObjectKey, &rec.RowCount, &rec.MinCreatedAt, &rec.MaxCreatedAt,
+		&rec.CreatedAt, &restoredAt); err != nil {
+		return nil, err
+	}
+	if restoredAt.Valid {
+		rec.RestoredAt = &restoredAt.Time
+	}
+	return rec, nil
+}
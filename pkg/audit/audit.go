@@ -0,0 +1,63 @@
+// Package audit records security-sensitive and administrative actions
+// for later review: admin API mutations, API key create/revoke, and
+// login events, each with who did it, from where, and (where the
+// caller has it) what changed.
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Entry is a single audited action.
+type Entry struct {
+	ID        int64     // set on rows returned by Search, ignored by Record
+	ActorID   string    // user ID of whoever performed the action
+	Action    string    // short machine-readable verb, e.g. "runbook.flush_redis"
+	Target    string    // what the action was performed on, if applicable
+	Detail    string    // free-form human-readable context
+	IP        string    // caller's source IP, if known
+	RequestID string    // correlates this entry with request logs and traces
+	Before    string    // JSON snapshot of the target before the change, if applicable
+	After     string    // JSON snapshot of the target after the change, if applicable
+	CreatedAt time.Time // set on rows returned by Search, ignored by Record
+}
+
+// Logger persists audit entries to MySQL.
+type Logger struct {
+	db *sql.DB
+}
+
+// NewLogger wraps an existing *sql.DB handle.
+func NewLogger(db *sql.DB) *Logger {
+	return &Logger{db: db}
+}
+
+// Record writes a single audit entry.
+func (l *Logger) Record(ctx context.Context, entry Entry) error {
+	_, err := l.db.ExecContext(ctx,
+		`INSERT INTO audit_log (actor_id, action, target, detail, ip_address, request_id, before_state, after_state, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.ActorID, entry.Action, entry.Target, entry.Detail, entry.IP, entry.RequestID, nullable(entry.Before), nullable(entry.After), time.Now().UTC())
+	return err
+}
+
+func nullable(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// ClientIP returns the caller's source IP for r, stripping the port
+// RemoteAddr carries alongside it.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// searchResponse is the JSON body returned by SearchHandler.
+type searchResponse struct {
+	Entries    []Entry `json:"entries"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+func filterFromQuery(r *http.Request) SearchFilter {
+	q := r.URL.Query()
+	filter := SearchFilter{
+		ActorID: q.Get("actor_id"),
+		Action:  q.Get("action"),
+		Target:  q.Get("target"),
+		Cursor:  q.Get("cursor"),
+	}
+	if v := q.Get("since"); v != "" {
+		if since, err := time.Parse(time.RFC3339, v); err == nil {
+			filter.Since = since
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		if limit, err := strconv.Atoi(v); err == nil {
+			filter.Limit = limit
+		}
+	}
+	return filter
+}
+
+// SearchHandler exposes Logger.Search over HTTP with cursor pagination.
+// Supported query parameters: actor_id, action, target, since (RFC3339),
+// cursor, limit.
+func SearchHandler(log *Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		entries, next, err := log.Search(r.Context(), filterFromQuery(r))
+		if err != nil {
+			http.Error(w, "failed to search audit log", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(searchResponse{Entries: entries, NextCursor: next})
+	}
+}
+
+// maxExportRows bounds how many rows ExportHandler will page through in
+// one request, so an unbounded filter can't turn an export into an
+// unbounded full-table scan.
+const maxExportRows = 10000
+
+// ExportHandler serves the same filters as SearchHandler but as a single
+// CSV download, paging through Search internally so an operator can
+// pull a full incident window in one request instead of stitching
+// together paginated JSON responses by hand.
+func ExportHandler(log *Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		filter := filterFromQuery(r)
+		filter.Limit = 500
+
+		var all []Entry
+		for len(all) < maxExportRows {
+			entries, next, err := log.Search(r.Context(), filter)
+			if err != nil {
+				http.Error(w, "failed to export audit log", http.StatusInternalServerError)
+				return
+			}
+			all = append(all, entries...)
+			if next == "" {
+				break
+			}
+			filter.Cursor = next
+		}
+
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="audit-log.csv"`)
+		writer := csv.NewWriter(w)
+		defer writer.Flush()
+
+		writer.Write([]string{"id", "created_at", "actor_id", "action", "target", "detail", "ip_address", "request_id", "before", "after"})
+		for _, e := range all {
+			writer.Write([]string{
+				strconv.FormatInt(e.ID, 10),
+				e.CreatedAt.Format(time.RFC3339),
+				e.ActorID,
+				e.Action,
+				e.Target,
+				e.Detail,
+				e.IP,
+				e.RequestID,
+				e.Before,
+				e.After,
+			})
+		}
+	}
+}
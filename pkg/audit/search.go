@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/pagination"
+)
+
+// SearchFilter narrows a Search call. Zero values are treated as "no
+// filter" for that field.
+type SearchFilter struct {
+	ActorID string
+	Action  string
+	Target  string
+	Since   time.Time
+	Cursor  string // opaque, from the previous page's NextCursor
+	Limit   int
+}
+
+// Search returns entries matching filter, newest first, along with a
+// cursor to fetch the next page (empty if there are no more results).
+func (l *Logger) Search(ctx context.Context, filter SearchFilter) ([]Entry, string, error) {
+	limit := filter.Limit
+	if limit <= 0 || limit > pagination.MaxLimit {
+		limit = pagination.DefaultLimit
+	}
+
+	query := `SELECT id, actor_id, action, target, detail, ip_address, request_id,
+	                 COALESCE(before_state, ''), COALESCE(after_state, ''), created_at
+	          FROM audit_log WHERE 1 = 1`
+	var args []interface{}
+
+	if filter.ActorID != "" {
+		query += " AND actor_id = ?"
+		args = append(args, filter.ActorID)
+	}
+	if filter.Action != "" {
+		query += " AND action = ?"
+		args = append(args, filter.Action)
+	}
+	if filter.Target != "" {
+		query += " AND target = ?"
+		args = append(args, filter.Target)
+	}
+	if !filter.Since.IsZero() {
+		query += " AND created_at >= ?"
+		args = append(args, filter.Since)
+	}
+	if filter.Cursor != "" {
+		lastID, err := pagination.DecodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("audit: invalid cursor: %w", err)
+		}
+		query += " AND id < ?"
+		args = append(args, lastID)
+	}
+
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, limit+1)
+
+	rows, err := l.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var entries []Entry
+	for rows.Next() {
+		var e Entry
+		if err := rows.Scan(&e.ID, &e.ActorID, &e.Action, &e.Target, &e.Detail, &e.IP, &e.RequestID, &e.Before, &e.After, &e.CreatedAt); err != nil {
+			return nil, "", err
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(entries) > limit {
+		entries = entries[:limit]
+		nextCursor = pagination.EncodeCursor(entries[len(entries)-1].ID)
+	}
+
+	return entries, nextCursor, nil
+}
@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	goredis "github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MinPasswordLength is the shortest password ValidatePassword accepts.
+const MinPasswordLength = 10
+
+// ResetTokenTTL is how long a password reset token remains valid.
+const ResetTokenTTL = 1 * time.Hour
+
+// VerificationTokenTTL is how long a self-registration email
+// verification token remains valid.
+const VerificationTokenTTL = 24 * time.Hour
+
+// argon2idPrefix marks a hash produced by HashPasswordArgon2id; bcrypt
+// hashes are recognized by their own "$2" prefix, so VerifyPassword can
+// tell the two apart without a separate stored flag.
+const argon2idPrefix = "$argon2id$"
+
+// argon2Params are the cost parameters used for new argon2id hashes,
+// the OWASP-recommended baseline for interactive login.
+var argon2Params = struct {
+	time, memory uint32
+	threads      uint8
+	keyLen       uint32
+	saltLen      uint32
+}{time: 1, memory: 64 * 1024, threads: 4, keyLen: 32, saltLen: 16}
+
+// ErrWeakPassword is returned when a candidate password fails policy.
+var ErrWeakPassword = errors.New("auth: password does not meet policy")
+
+// ErrPasswordMismatch is returned when a password fails verification
+// against its stored hash.
+var ErrPasswordMismatch = errors.New("auth: password does not match")
+
+// ErrResetTokenInvalid is returned for a reset token that's unknown,
+// expired, or already consumed.
+var ErrResetTokenInvalid = errors.New("auth: reset token invalid or expired")
+
+// ErrVerificationTokenInvalid is returned for an email verification
+// token that's unknown, expired, or already consumed.
+var ErrVerificationTokenInvalid = errors.New("auth: verification token invalid or expired")
+
+// ValidatePassword checks raw against the platform's password policy:
+// at least MinPasswordLength characters, containing both a letter and a
+// digit.
+func ValidatePassword(raw string) error {
+	if len(raw) < MinPasswordLength {
+		return fmt.Errorf("%w: must be at least %d characters", ErrWeakPassword, MinPasswordLength)
+	}
+	var hasLetter, hasDigit bool
+	for _, r := range raw {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return fmt.Errorf("%w: must contain a letter and a digit", ErrWeakPassword)
+	}
+	return nil
+}
+
+// HashPassword hashes raw with bcrypt, the default algorithm for newly
+// created or changed passwords.
+func HashPassword(raw string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(raw), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// HashPasswordArgon2id hashes raw with argon2id, for deployments that
+// prefer it over bcrypt. VerifyPassword recognizes either format, so
+// the two can coexist while accounts migrate.
+func HashPasswordArgon2id(raw string) (string, error) {
+	salt := make([]byte, argon2Params.saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	sum := argon2.IDKey([]byte(raw), salt, argon2Params.time, argon2Params.memory, argon2Params.threads, argon2Params.keyLen)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, argon2Params.memory, argon2Params.time, argon2Params.threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(sum)), nil
+}
+
+// VerifyPassword reports whether raw matches hash, whichever of bcrypt
+// or argon2id produced it, returning ErrPasswordMismatch if not. Both
+// paths compare in constant time.
+func VerifyPassword(hash, raw string) error {
+	if strings.HasPrefix(hash, argon2idPrefix) {
+		return verifyArgon2id(hash, raw)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(raw)); err != nil {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+func verifyArgon2id(hash, raw string) error {
+	fields := strings.Split(strings.TrimPrefix(hash, argon2idPrefix), "$")
+	if len(fields) != 4 {
+		return ErrPasswordMismatch
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(fields[0], "v=%d", &version); err != nil {
+		return ErrPasswordMismatch
+	}
+	var memory, iterations uint32
+	var parallelism uint8
+	if _, err := fmt.Sscanf(fields[1], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism); err != nil {
+		return ErrPasswordMismatch
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(fields[2])
+	if err != nil {
+		return ErrPasswordMismatch
+	}
+	want, err := base64.RawStdEncoding.DecodeString(fields[3])
+	if err != nil {
+		return ErrPasswordMismatch
+	}
+
+	got := argon2.IDKey([]byte(raw), salt, iterations, memory, parallelism, uint32(len(want)))
+	if subtle.ConstantTimeCompare(got, want) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+// CredentialService manages single-use password reset and email
+// verification tokens. Token state lives in Redis the same way
+// RefreshTokenService keeps sessions there, so a token survives a
+// service restart and Consume can be atomic.
+type CredentialService struct {
+	client goredis.UniversalClient
+}
+
+// NewCredentialService wraps an existing Redis client for reset-token
+// and verification-token storage.
+func NewCredentialService(client goredis.UniversalClient) *CredentialService {
+	return &CredentialService{client: client}
+}
+
+func resetTokenKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return "auth:pwreset:" + hex.EncodeToString(sum[:])
+}
+
+// IssueResetToken generates a single-use password reset token for
+// userID, valid for ResetTokenTTL. The raw token is meant to be
+// delivered out of band (e.g. emailed as a link); only its hash is ever
+// persisted.
+func (s *CredentialService) IssueResetToken(ctx context.Context, userID string) (string, error) {
+	raw, err := newRawToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.client.Set(ctx, resetTokenKey(raw), userID, ResetTokenTTL).Err(); err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+// ConsumeResetToken redeems rawToken for the userID it was issued to,
+// invalidating it in the same operation so it can't be replayed. It
+// fails with ErrResetTokenInvalid if the token is unknown, expired, or
+// was already consumed.
+func (s *CredentialService) ConsumeResetToken(ctx context.Context, rawToken string) (string, error) {
+	userID, err := s.client.GetDel(ctx, resetTokenKey(rawToken)).Result()
+	if errors.Is(err, goredis.Nil) {
+		return "", ErrResetTokenInvalid
+	}
+	if err != nil {
+		return "", err
+	}
+	return userID, nil
+}
+
+func verificationTokenKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return "auth:verify:" + hex.EncodeToString(sum[:])
+}
+
+// IssueVerificationToken generates a single-use email verification
+// token for userID, valid for VerificationTokenTTL. The raw token is
+// meant to be delivered out of band (e.g. emailed); only its hash is
+// ever persisted. Calling it again before the previous token expires
+// leaves the previous token valid too, so a user who requests a resend
+// can still redeem whichever email they open first.
+func (s *CredentialService) IssueVerificationToken(ctx context.Context, userID string) (string, error) {
+	raw, err := newRawToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.client.Set(ctx, verificationTokenKey(raw), userID, VerificationTokenTTL).Err(); err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+// ConsumeVerificationToken redeems rawToken for the userID it was
+// issued to, invalidating it in the same operation so it can't be
+// replayed. It fails with ErrVerificationTokenInvalid if the token is
+// unknown, expired, or was already consumed.
+func (s *CredentialService) ConsumeVerificationToken(ctx context.Context, rawToken string) (string, error) {
+	userID, err := s.client.GetDel(ctx, verificationTokenKey(rawToken)).Result()
+	if errors.Is(err, goredis.Nil) {
+		return "", ErrVerificationTokenInvalid
+	}
+	if err != nil {
+		return "", err
+	}
+	return userID, nil
+}
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidatePasswordRejectsShortPassword(t *testing.T) {
+	if err := ValidatePassword("short1"); !errors.Is(err, ErrWeakPassword) {
+		t.Fatalf("ValidatePassword() error = %v, want ErrWeakPassword", err)
+	}
+}
+
+func TestValidatePasswordRejectsMissingDigit(t *testing.T) {
+	if err := ValidatePassword("alllettersnodigits"); !errors.Is(err, ErrWeakPassword) {
+		t.Fatalf("ValidatePassword() error = %v, want ErrWeakPassword", err)
+	}
+}
+
+func TestValidatePasswordAcceptsCompliantPassword(t *testing.T) {
+	if err := ValidatePassword("correcthorse1"); err != nil {
+		t.Fatalf("ValidatePassword() error = %v, want nil", err)
+	}
+}
+
+func TestHashPasswordRoundTrip(t *testing.T) {
+	hash, err := HashPassword("correcthorse1")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	if err := VerifyPassword(hash, "correcthorse1"); err != nil {
+		t.Fatalf("VerifyPassword: %v", err)
+	}
+	if err := VerifyPassword(hash, "wrong-password"); !errors.Is(err, ErrPasswordMismatch) {
+		t.Fatalf("VerifyPassword() error = %v, want ErrPasswordMismatch", err)
+	}
+}
+
+func TestHashPasswordArgon2idRoundTrip(t *testing.T) {
+	hash, err := HashPasswordArgon2id("correcthorse1")
+	if err != nil {
+		t.Fatalf("HashPasswordArgon2id: %v", err)
+	}
+	if err := VerifyPassword(hash, "correcthorse1"); err != nil {
+		t.Fatalf("VerifyPassword: %v", err)
+	}
+	if err := VerifyPassword(hash, "wrong-password"); !errors.Is(err, ErrPasswordMismatch) {
+		t.Fatalf("VerifyPassword() error = %v, want ErrPasswordMismatch", err)
+	}
+}
@@ -0,0 +1,245 @@
+// Package auth issues and validates the JWTs used to authenticate
+// dashboard and admin users. API-key authentication for proxy traffic
+// lives separately in pkg/apikey.
+package auth
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Role identifies what an authenticated user is allowed to do.
+type Role string
+
+const (
+	RoleUser     Role = "user"
+	RoleOperator Role = "operator"
+	RoleAdmin    Role = "admin"
+)
+
+// DefaultTokenTTL is how long an issued access token remains valid.
+const DefaultTokenTTL = 1 * time.Hour
+
+// defaultKeyID is the kid NewJWTServiceFromString assigns its one key,
+// for callers that don't care about key rotation.
+const defaultKeyID = "default"
+
+// ErrInvalidToken is returned for any token that fails to parse, fails
+// signature verification, or has expired.
+var ErrInvalidToken = errors.New("auth: invalid token")
+
+// Claims is the payload carried by access tokens issued by JWTService.
+type Claims struct {
+	UserID string `json:"user_id"`
+	Role   Role   `json:"role"`
+
+	// TwoFactorPending marks a partial token issued by IssuePartial: the
+	// caller has passed password auth but still owes a valid TOTP or
+	// recovery code before being treated as fully authenticated.
+	// Middleware that authorizes requests must reject tokens carrying it.
+	TwoFactorPending bool `json:"two_factor_pending,omitempty"`
+
+	jwt.RegisteredClaims
+}
+
+// KeyAlgorithm identifies the signing algorithm a SigningKey uses.
+type KeyAlgorithm string
+
+const (
+	AlgHS256 KeyAlgorithm = "HS256"
+	AlgRS256 KeyAlgorithm = "RS256"
+	AlgES256 KeyAlgorithm = "ES256"
+)
+
+// SigningKey is one entry in a JWTService's keyring, identified by ID
+// (carried as "kid" in the token header) so a verifier can pick the
+// right key without trying all of them.
+type SigningKey struct {
+	ID        string
+	Algorithm KeyAlgorithm
+
+	// Secret is used for AlgHS256 keys.
+	Secret []byte
+
+	// PrivateKey and PublicKey are used for AlgRS256/AlgES256 keys
+	// (*rsa.PrivateKey/*rsa.PublicKey or *ecdsa.PrivateKey/*ecdsa.PublicKey
+	// respectively). A verifier that only holds the public half can
+	// leave PrivateKey nil.
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
+}
+
+func (k SigningKey) signingMethod() jwt.SigningMethod {
+	switch k.Algorithm {
+	case AlgRS256:
+		return jwt.SigningMethodRS256
+	case AlgES256:
+		return jwt.SigningMethodES256
+	default:
+		return jwt.SigningMethodHS256
+	}
+}
+
+func (k SigningKey) signingMaterial() (interface{}, error) {
+	switch k.Algorithm {
+	case AlgRS256, AlgES256:
+		if k.PrivateKey == nil {
+			return nil, fmt.Errorf("auth: signing key %q has no private key", k.ID)
+		}
+		return k.PrivateKey, nil
+	default:
+		return k.Secret, nil
+	}
+}
+
+func (k SigningKey) verificationMaterial() (interface{}, error) {
+	switch k.Algorithm {
+	case AlgRS256, AlgES256:
+		if k.PublicKey == nil {
+			return nil, fmt.Errorf("auth: signing key %q has no public key", k.ID)
+		}
+		return k.PublicKey, nil
+	default:
+		return k.Secret, nil
+	}
+}
+
+// JWTService signs and verifies access tokens against a keyring rather
+// than a single static secret, so a compromised or aging key can be
+// retired without breaking tokens already issued: Rotate introduces a
+// new signing key while every key still in the ring keeps validating
+// tokens bearing its kid.
+type JWTService struct {
+	mu           sync.RWMutex
+	keys         map[string]SigningKey
+	signingKeyID string
+	ttl          time.Duration
+}
+
+// NewJWTService builds a JWTService from an explicit keyring. The last
+// key in keys becomes the active signing key; all of them validate
+// incoming tokens. At least one key is required.
+func NewJWTService(keys ...SigningKey) (*JWTService, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("auth: at least one signing key is required")
+	}
+
+	s := &JWTService{keys: make(map[string]SigningKey, len(keys)), ttl: DefaultTokenTTL}
+	for _, k := range keys {
+		s.keys[k.ID] = k
+	}
+	s.signingKeyID = keys[len(keys)-1].ID
+	return s, nil
+}
+
+// NewJWTServiceFromString builds a JWTService with a single HS256
+// signing key, for callers that don't need rotation.
+func NewJWTServiceFromString(secret string) *JWTService {
+	svc, err := NewJWTService(SigningKey{ID: defaultKeyID, Algorithm: AlgHS256, Secret: []byte(secret)})
+	if err != nil {
+		// Unreachable: NewJWTService only errors on zero keys.
+		panic(err)
+	}
+	return svc
+}
+
+// Rotate adds newKey to the keyring and makes it the key used to sign
+// new tokens. Keys already in the ring — including the one newKey
+// replaces as active signer — keep validating tokens until the caller
+// removes them with RemoveKey, so tokens issued just before a rotation
+// remain valid through their natural expiry.
+func (s *JWTService) Rotate(newKey SigningKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[newKey.ID] = newKey
+	s.signingKeyID = newKey.ID
+}
+
+// RemoveKey drops a key from the ring so it can no longer sign or
+// validate tokens. Call it once a retired key's tokens are guaranteed
+// to have expired — at least DefaultTokenTTL after it stopped being the
+// active signing key.
+func (s *JWTService) RemoveKey(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, id)
+}
+
+// Issue signs a new access token for userID with the given role, using
+// the keyring's current active signing key.
+func (s *JWTService) Issue(userID string, role Role) (string, error) {
+	s.mu.RLock()
+	ttl := s.ttl
+	s.mu.RUnlock()
+	return s.issue(userID, role, ttl, false)
+}
+
+// PartialTokenTTL is how long a partial token issued by IssuePartial
+// remains valid for upgrading to a full token via a TOTP or recovery
+// code. It's intentionally much shorter than DefaultTokenTTL.
+const PartialTokenTTL = 5 * time.Minute
+
+// IssuePartial signs a short-lived token for userID marked
+// TwoFactorPending: it proves password auth succeeded but must still be
+// upgraded to a full token (e.g. via RefreshTokenService.IssuePair) by
+// presenting a valid TOTP or recovery code.
+func (s *JWTService) IssuePartial(userID string, role Role) (string, error) {
+	return s.issue(userID, role, PartialTokenTTL, true)
+}
+
+func (s *JWTService) issue(userID string, role Role, ttl time.Duration, twoFactorPending bool) (string, error) {
+	s.mu.RLock()
+	key, ok := s.keys[s.signingKeyID]
+	s.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("auth: no active signing key")
+	}
+
+	material, err := key.signingMaterial()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := Claims{
+		UserID:           userID,
+		Role:             role,
+		TwoFactorPending: twoFactorPending,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	token := jwt.NewWithClaims(key.signingMethod(), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(material)
+}
+
+// Parse validates tokenString against the keyring entry named by its
+// "kid" header and returns its claims.
+func (s *JWTService) Parse(tokenString string) (*Claims, error) {
+	var claims Claims
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+
+		s.mu.RLock()
+		key, ok := s.keys[kid]
+		s.mu.RUnlock()
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+		if t.Method.Alg() != key.signingMethod().Alg() {
+			return nil, ErrInvalidToken
+		}
+		return key.verificationMaterial()
+	})
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+	return &claims, nil
+}
@@ -0,0 +1,99 @@
+package auth
+
+import "testing"
+
+func TestJWTServiceIssueAndParseRoundTrip(t *testing.T) {
+	svc := NewJWTServiceFromString("test-secret")
+
+	token, err := svc.Issue("user-1", RoleAdmin)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	claims, err := svc.Parse(token)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if claims.UserID != "user-1" || claims.Role != RoleAdmin {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+}
+
+func TestJWTServiceRejectsWrongSecret(t *testing.T) {
+	issuer := NewJWTServiceFromString("secret-a")
+	verifier := NewJWTServiceFromString("secret-b")
+
+	token, err := issuer.Issue("user-1", RoleUser)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	if _, err := verifier.Parse(token); err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestJWTServiceRotateStillValidatesPreviousKey(t *testing.T) {
+	svc := NewJWTServiceFromString("secret-a")
+
+	oldToken, err := svc.Issue("user-1", RoleUser)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	svc.Rotate(SigningKey{ID: "2024-q2", Algorithm: AlgHS256, Secret: []byte("secret-b")})
+
+	if _, err := svc.Parse(oldToken); err != nil {
+		t.Fatalf("Parse(oldToken) after rotate: %v", err)
+	}
+
+	newToken, err := svc.Issue("user-2", RoleAdmin)
+	if err != nil {
+		t.Fatalf("Issue after rotate: %v", err)
+	}
+	claims, err := svc.Parse(newToken)
+	if err != nil {
+		t.Fatalf("Parse(newToken): %v", err)
+	}
+	if claims.UserID != "user-2" || claims.Role != RoleAdmin {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+}
+
+func TestJWTServiceRemoveKeyInvalidatesItsTokens(t *testing.T) {
+	svc := NewJWTServiceFromString("secret-a")
+
+	token, err := svc.Issue("user-1", RoleUser)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	svc.Rotate(SigningKey{ID: "2024-q2", Algorithm: AlgHS256, Secret: []byte("secret-b")})
+	svc.RemoveKey(defaultKeyID)
+
+	if _, err := svc.Parse(token); err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken for removed key, got %v", err)
+	}
+}
+
+func TestJWTServiceRejectsUnknownKeyID(t *testing.T) {
+	issuer := NewJWTServiceFromString("secret-a")
+	verifier := NewJWTServiceFromString("secret-a")
+	verifier.RemoveKey(defaultKeyID)
+	verifier.Rotate(SigningKey{ID: "other", Algorithm: AlgHS256, Secret: []byte("secret-a")})
+
+	token, err := issuer.Issue("user-1", RoleUser)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	if _, err := verifier.Parse(token); err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken for unknown kid, got %v", err)
+	}
+}
+
+func TestNewJWTServiceRequiresAtLeastOneKey(t *testing.T) {
+	if _, err := NewJWTService(); err == nil {
+		t.Fatal("expected error constructing JWTService with no keys")
+	}
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// LoginLockoutThreshold is how many consecutive failed login attempts
+// for the same email are allowed before LoginLockout reports it locked.
+const LoginLockoutThreshold = 5
+
+// LoginLockoutWindow is how long a run of failures (and the lockout it
+// triggers) stands before resetting.
+const LoginLockoutWindow = 15 * time.Minute
+
+// LoginLockout counts consecutive login failures per email in Redis,
+// the same fixed-window counting pkg/ratelimit uses for per-plan rate
+// limits, so a credential-stuffing burst against one address gets
+// throttled without touching any other account's ability to log in.
+type LoginLockout struct {
+	client goredis.UniversalClient
+}
+
+// NewLoginLockout wraps an existing Redis client.
+func NewLoginLockout(client goredis.UniversalClient) *LoginLockout {
+	return &LoginLockout{client: client}
+}
+
+func lockoutKey(email string) string {
+	return "auth:lockout:" + email
+}
+
+// RecordFailure increments email's failure count, resetting its
+// LoginLockoutWindow TTL, and reports whether the count has now reached
+// LoginLockoutThreshold.
+func (l *LoginLockout) RecordFailure(ctx context.Context, email string) (locked bool, err error) {
+	key := lockoutKey(email)
+
+	pipe := l.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, LoginLockoutWindow)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+
+	return incr.Val() >= LoginLockoutThreshold, nil
+}
+
+// Locked reports whether email currently has LoginLockoutThreshold or
+// more failures recorded against it within the current window.
+func (l *LoginLockout) Locked(ctx context.Context, email string) (bool, error) {
+	count, err := l.client.Get(ctx, lockoutKey(email)).Int64()
+	if err == goredis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return count >= LoginLockoutThreshold, nil
+}
+
+// Reset clears email's failure count, called after a successful login
+// so a legitimate user who mistyped their password a few times isn't
+// left one step from lockout.
+func (l *LoginLockout) Reset(ctx context.Context, email string) error {
+	return l.client.Del(ctx, lockoutKey(email)).Err()
+}
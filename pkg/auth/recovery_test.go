@@ -0,0 +1,30 @@
+package auth
+
+import "testing"
+
+func TestGenerateRecoveryCodesAreUniqueAndHashVerifiable(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("GenerateRecoveryCodes: %v", err)
+	}
+	if len(codes) != RecoveryCodeCount {
+		t.Fatalf("got %d codes, want %d", len(codes), RecoveryCodeCount)
+	}
+
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if seen[code] {
+			t.Fatalf("duplicate recovery code: %s", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestHashRecoveryCodeIsDeterministic(t *testing.T) {
+	if HashRecoveryCode("ABCDE-FGHJK") != HashRecoveryCode("ABCDE-FGHJK") {
+		t.Fatal("HashRecoveryCode should be deterministic for the same input")
+	}
+	if HashRecoveryCode("ABCDE-FGHJK") == HashRecoveryCode("ABCDE-FGHJ2") {
+		t.Fatal("HashRecoveryCode should differ for different input")
+	}
+}
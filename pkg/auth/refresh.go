@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// RefreshTokenTTL is how long an issued refresh token remains valid
+// without being rotated or revoked.
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+// ErrRefreshTokenRevoked is returned when a refresh token is unknown,
+// expired, or was explicitly revoked.
+var ErrRefreshTokenRevoked = errors.New("auth: refresh token revoked or unknown")
+
+// TokenPair is what's handed back to a client on login or refresh.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// session is the Redis-resident record backing a live refresh token.
+type session struct {
+	UserID string `json:"user_id"`
+	Role   Role   `json:"role"`
+	Device string `json:"device"`
+}
+
+// RefreshTokenService issues short-lived access tokens alongside
+// long-lived refresh tokens. Refresh tokens are opaque, single-use
+// values: redeeming one via Rotate invalidates it and returns a fresh
+// pair, so a stolen-and-replayed token is detectable the moment the
+// legitimate client tries to refresh again. Sessions live in Redis so
+// Revoke/RevokeAll can end them immediately, which a stateless JWT alone
+// can't support.
+type RefreshTokenService struct {
+	client goredis.UniversalClient
+	jwt    *JWTService
+	ttl    time.Duration
+}
+
+// NewRefreshTokenService wraps an existing Redis client and JWTService.
+func NewRefreshTokenService(client goredis.UniversalClient, jwt *JWTService) *RefreshTokenService {
+	return &RefreshTokenService{client: client, jwt: jwt, ttl: RefreshTokenTTL}
+}
+
+func refreshKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return "auth:refresh:" + hex.EncodeToString(sum[:])
+}
+
+func userSessionsKey(userID string) string {
+	return "auth:refresh:user:" + userID
+}
+
+func newRawToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// IssuePair issues a new access+refresh token pair for userID, recording
+// device as opaque client metadata (e.g. a user agent string) against
+// the session for later auditing or display.
+func (s *RefreshTokenService) IssuePair(ctx context.Context, userID string, role Role, device string) (TokenPair, error) {
+	access, err := s.jwt.Issue(userID, role)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	raw, err := newRawToken()
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if err := s.storeSession(ctx, raw, session{UserID: userID, Role: role, Device: device}); err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{AccessToken: access, RefreshToken: raw}, nil
+}
+
+func (s *RefreshTokenService) storeSession(ctx context.Context, raw string, sess session) error {
+	value, err := json.Marshal(sess)
+	if err != nil {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, refreshKey(raw), value, s.ttl)
+	pipe.SAdd(ctx, userSessionsKey(sess.UserID), refreshKey(raw))
+	pipe.Expire(ctx, userSessionsKey(sess.UserID), s.ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Rotate redeems rawRefreshToken for a fresh access+refresh pair,
+// invalidating rawRefreshToken in the same operation. It fails with
+// ErrRefreshTokenRevoked if the token is unknown, expired, or has
+// already been rotated or revoked.
+func (s *RefreshTokenService) Rotate(ctx context.Context, rawRefreshToken, device string) (TokenPair, error) {
+	key := refreshKey(rawRefreshToken)
+	raw, err := s.client.GetDel(ctx, key).Result()
+	if errors.Is(err, goredis.Nil) {
+		return TokenPair{}, ErrRefreshTokenRevoked
+	}
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	var sess session
+	if err := json.Unmarshal([]byte(raw), &sess); err != nil {
+		return TokenPair{}, err
+	}
+
+	// The GetDel above is what makes this single-use: only one of any
+	// concurrent replays of rawRefreshToken can receive the value, so
+	// only one can reach IssuePair below. This SRem just drops the
+	// now-dead key out of the user's session set.
+	if err := s.client.SRem(ctx, userSessionsKey(sess.UserID), key).Err(); err != nil {
+		return TokenPair{}, err
+	}
+
+	return s.IssuePair(ctx, sess.UserID, sess.Role, device)
+}
+
+// Revoke immediately invalidates a single refresh token, e.g. on logout.
+func (s *RefreshTokenService) Revoke(ctx context.Context, rawRefreshToken string) error {
+	key := refreshKey(rawRefreshToken)
+	raw, err := s.client.Get(ctx, key).Result()
+	if errors.Is(err, goredis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var sess session
+	if err := json.Unmarshal([]byte(raw), &sess); err != nil {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.SRem(ctx, userSessionsKey(sess.UserID), key)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// RevokeAll immediately invalidates every refresh token belonging to
+// userID, ending all of that user's sessions at once (e.g. on password
+// change or a reported compromise).
+func (s *RefreshTokenService) RevokeAll(ctx context.Context, userID string) error {
+	setKey := userSessionsKey(userID)
+	keys, err := s.client.SMembers(ctx, setKey).Result()
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, keys...)
+	pipe.Del(ctx, setKey)
+	_, err = pipe.Exec(ctx)
+	return err
+}
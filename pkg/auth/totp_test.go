@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateAndValidateTOTPCodeRoundTrip(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret: %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	code, err := GenerateTOTPCode(secret, now)
+	if err != nil {
+		t.Fatalf("GenerateTOTPCode: %v", err)
+	}
+
+	if !ValidateTOTPCode(secret, code, now) {
+		t.Fatal("ValidateTOTPCode rejected a freshly generated code")
+	}
+}
+
+func TestValidateTOTPCodeToleratesOneStepSkew(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret: %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	code, err := GenerateTOTPCode(secret, now)
+	if err != nil {
+		t.Fatalf("GenerateTOTPCode: %v", err)
+	}
+
+	if !ValidateTOTPCode(secret, code, now.Add(totpStep)) {
+		t.Fatal("ValidateTOTPCode rejected a code within the allowed skew")
+	}
+}
+
+func TestValidateTOTPCodeRejectsStaleCode(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret: %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	code, err := GenerateTOTPCode(secret, now)
+	if err != nil {
+		t.Fatalf("GenerateTOTPCode: %v", err)
+	}
+
+	if ValidateTOTPCode(secret, code, now.Add(10*totpStep)) {
+		t.Fatal("ValidateTOTPCode accepted a code well outside the allowed skew")
+	}
+}
+
+func TestTOTPProvisioningURIContainsSecretAndIssuer(t *testing.T) {
+	uri := TOTPProvisioningURI("proxy-platform", "admin@example.com", "JBSWY3DPEHPK3PXP")
+	for _, want := range []string{"otpauth://totp/", "secret=JBSWY3DPEHPK3PXP", "issuer=proxy-platform"} {
+		if !strings.Contains(uri, want) {
+			t.Fatalf("provisioning URI %q missing %q", uri, want)
+		}
+	}
+}
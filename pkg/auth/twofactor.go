@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrTwoFactorNotPending is returned when Enable is called for a subject
+// that hasn't called BeginSetup (or has already enabled 2FA).
+var ErrTwoFactorNotPending = errors.New("auth: two-factor setup not started")
+
+// ErrTwoFactorCodeInvalid is returned when a TOTP or recovery code fails
+// verification.
+var ErrTwoFactorCodeInvalid = errors.New("auth: two-factor code invalid")
+
+// TwoFactorDAO manages TOTP secrets and recovery codes in MySQL.
+// Subjects are identified by the ID carried in JWT claims (a user or
+// admin operator ID); this package doesn't otherwise model who that ID
+// belongs to.
+type TwoFactorDAO struct {
+	db *sql.DB
+}
+
+// NewTwoFactorDAO wraps an existing *sql.DB handle.
+func NewTwoFactorDAO(db *sql.DB) *TwoFactorDAO {
+	return &TwoFactorDAO{db: db}
+}
+
+// BeginSetup generates a new TOTP secret for subjectID and stores it as
+// pending (not yet enabled), replacing any previous pending secret. The
+// secret isn't active for verification until Enable confirms the caller
+// can produce a valid code for it.
+func (d *TwoFactorDAO) BeginSetup(ctx context.Context, subjectID string) (secret string, err error) {
+	secret, err = GenerateTOTPSecret()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	_, err = d.db.ExecContext(ctx, `
+		INSERT INTO two_factor_credentials (subject_id, secret, enabled, created_at, updated_at)
+		VALUES (?, ?, FALSE, ?, ?)
+		ON DUPLICATE KEY UPDATE secret = VALUES(secret), enabled = FALSE, updated_at = VALUES(updated_at)`,
+		subjectID, secret, now, now)
+	if err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// Enable confirms a pending setup by checking code against the secret
+// BeginSetup stored, then marks it enabled and issues a fresh batch of
+// recovery codes, returned once in the clear. Only their hashes are
+// stored, so a caller that loses them must regenerate via Disable then
+// BeginSetup/Enable again.
+func (d *TwoFactorDAO) Enable(ctx context.Context, subjectID, code string) (recoveryCodes []string, err error) {
+	secret, enabled, err := d.lookup(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if secret == "" || enabled {
+		return nil, ErrTwoFactorNotPending
+	}
+	if !ValidateTOTPCode(secret, code, time.Now()) {
+		return nil, ErrTwoFactorCodeInvalid
+	}
+
+	recoveryCodes, err = GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE two_factor_credentials SET enabled = TRUE, updated_at = ? WHERE subject_id = ?`,
+		time.Now().UTC(), subjectID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM two_factor_recovery_codes WHERE subject_id = ?`, subjectID); err != nil {
+		return nil, err
+	}
+	for _, raw := range recoveryCodes {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO two_factor_recovery_codes (id, subject_id, code_hash, created_at) VALUES (?, ?, ?, ?)`,
+			uuid.NewString(), subjectID, HashRecoveryCode(raw), time.Now().UTC()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// Disable removes subjectID's TOTP secret and recovery codes entirely,
+// turning two-factor auth off.
+func (d *TwoFactorDAO) Disable(ctx context.Context, subjectID string) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM two_factor_credentials WHERE subject_id = ?`, subjectID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM two_factor_recovery_codes WHERE subject_id = ?`, subjectID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// IsEnabled reports whether subjectID has two-factor auth enabled.
+func (d *TwoFactorDAO) IsEnabled(ctx context.Context, subjectID string) (bool, error) {
+	_, enabled, err := d.lookup(ctx, subjectID)
+	return enabled, err
+}
+
+// VerifyCode checks code against subjectID's TOTP secret, falling back
+// to an unused recovery code (which it then consumes) if the TOTP check
+// fails. It returns ErrTwoFactorCodeInvalid if neither matches.
+func (d *TwoFactorDAO) VerifyCode(ctx context.Context, subjectID, code string) error {
+	secret, enabled, err := d.lookup(ctx, subjectID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return ErrTwoFactorNotPending
+	}
+	if ValidateTOTPCode(secret, code, time.Now()) {
+		return nil
+	}
+	if d.consumeRecoveryCode(ctx, subjectID, code) {
+		return nil
+	}
+	return ErrTwoFactorCodeInvalid
+}
+
+func (d *TwoFactorDAO) consumeRecoveryCode(ctx context.Context, subjectID, raw string) bool {
+	result, err := d.db.ExecContext(ctx,
+		`UPDATE two_factor_recovery_codes SET used_at = ? WHERE subject_id = ? AND code_hash = ? AND used_at IS NULL`,
+		time.Now().UTC(), subjectID, HashRecoveryCode(raw))
+	if err != nil {
+		return false
+	}
+	affected, err := result.RowsAffected()
+	return err == nil && affected == 1
+}
+
+func (d *TwoFactorDAO) lookup(ctx context.Context, subjectID string) (secret string, enabled bool, err error) {
+	row := d.db.QueryRowContext(ctx, `SELECT secret, enabled FROM two_factor_credentials WHERE subject_id = ?`, subjectID)
+	if err := row.Scan(&secret, &enabled); err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return secret, enabled, nil
+}
@@ -0,0 +1,74 @@
+// Package bandwidth shapes a tunnel's throughput to its plan's cap. It
+// wraps the gateway's upstream/downstream copy loops with a token-bucket
+// byte limiter rather than policing bytes via Redis, since per-byte
+// round trips would add far more latency than the shaping is worth.
+package bandwidth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter is a local, single-process token-bucket byte-rate limiter:
+// WaitN blocks only long enough for the bucket to refill at
+// ratePerSecond bytes/sec, up to burst bytes banked ahead of time to
+// absorb short spikes (e.g. a TCP slow-start ramp) without stalling
+// them immediately.
+type Limiter struct {
+	ratePerSecond float64
+	burst         float64
+
+	mu       sync.Mutex
+	tokens   float64
+	lastFill time.Time
+}
+
+// NewLimiter builds a Limiter allowing ratePerSecond bytes/sec on
+// average, with up to burst bytes spendable at once.
+func NewLimiter(ratePerSecond, burst float64) *Limiter {
+	return &Limiter{ratePerSecond: ratePerSecond, burst: burst, tokens: burst, lastFill: time.Now()}
+}
+
+// WaitN reserves n bytes' worth of tokens and blocks until they would
+// have been earned at ratePerSecond (or ctx is canceled). The tokens
+// are spent up front, so a request larger than burst still completes
+// in one wait instead of looping forever waiting for a refill that
+// refill caps below n.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	l.mu.Lock()
+	l.refill()
+	var wait time.Duration
+	if l.tokens < float64(n) {
+		deficit := float64(n) - l.tokens
+		wait = time.Duration(deficit / l.ratePerSecond * float64(time.Second))
+	}
+	l.tokens -= float64(n)
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// refill credits tokens earned since the last call, capped at burst.
+// Callers must hold l.mu.
+func (l *Limiter) refill() {
+	now := time.Now()
+	elapsed := now.Sub(l.lastFill).Seconds()
+	l.lastFill = now
+
+	l.tokens += elapsed * l.ratePerSecond
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
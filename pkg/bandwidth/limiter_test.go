@@ -0,0 +1,63 @@
+package bandwidth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+func TestLimiterAllowsBurstImmediately(t *testing.T) {
+	l := NewLimiter(1000, 500)
+
+	start := time.Now()
+	if err := l.WaitN(context.Background(), 500); err != nil {
+		t.Fatalf("WaitN() returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("WaitN() for a burst-sized spend took %v, want near-instant", elapsed)
+	}
+}
+
+func TestLimiterBlocksBeyondBurst(t *testing.T) {
+	l := NewLimiter(1000, 100)
+
+	start := time.Now()
+	if err := l.WaitN(context.Background(), 200); err != nil {
+		t.Fatalf("WaitN() returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("WaitN() for a deficit of 100 bytes at 1000 bytes/sec returned in %v, want at least ~100ms", elapsed)
+	}
+}
+
+func TestLimiterWaitNRespectsContextCancellation(t *testing.T) {
+	l := NewLimiter(10, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.WaitN(ctx, 1000); err == nil {
+		t.Fatal("WaitN() returned nil error, want context deadline exceeded")
+	}
+}
+
+func TestRegistryReusesLimiterPerUserAndDirection(t *testing.T) {
+	r := NewRegistry()
+
+	up1 := r.ForUser("user-1", "up", user.PlanPro)
+	up2 := r.ForUser("user-1", "up", user.PlanPro)
+	down1 := r.ForUser("user-1", "down", user.PlanPro)
+	otherUser := r.ForUser("user-2", "up", user.PlanPro)
+
+	if up1 != up2 {
+		t.Fatal("ForUser() returned different Limiters for the same user and direction")
+	}
+	if up1 == down1 {
+		t.Fatal("ForUser() returned the same Limiter for different directions")
+	}
+	if up1 == otherUser {
+		t.Fatal("ForUser() returned the same Limiter for different users")
+	}
+}
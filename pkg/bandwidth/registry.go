@@ -0,0 +1,68 @@
+package bandwidth
+
+import (
+	"sync"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// MaxMbpsPerPlan is the sustained throughput cap, in megabits per
+// second, a plan's tunnels are shaped to in each direction. Plans not
+// listed here fall back to PlanFree's limit, mirroring
+// planlimits.MaxProxiesPerRequest.
+var MaxMbpsPerPlan = map[user.Plan]float64{
+	user.PlanFree:       10,
+	user.PlanPro:        100,
+	user.PlanEnterprise: 1000,
+}
+
+// BurstSeconds is how many seconds of a plan's cap a connection may
+// spend in a single burst before throttling catches up.
+const BurstSeconds = 2
+
+// MbpsFor returns plan's throughput cap in megabits per second.
+func MbpsFor(plan user.Plan) float64 {
+	mbps, ok := MaxMbpsPerPlan[plan]
+	if !ok {
+		mbps = MaxMbpsPerPlan[user.PlanFree]
+	}
+	return mbps
+}
+
+func bytesPerSecond(mbps float64) float64 {
+	return mbps * 1_000_000 / 8
+}
+
+// Registry hands out a shared Limiter per (user, direction) pair, so a
+// user's several concurrent tunnels are shaped against one aggregate
+// cap per direction rather than each getting its own full-rate bucket.
+type Registry struct {
+	mu       sync.Mutex
+	limiters map[string]*Limiter
+}
+
+// NewRegistry builds an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{limiters: make(map[string]*Limiter)}
+}
+
+// ForUser returns the shared Limiter for userID's direction ("up" or
+// "down"), sized to plan's cap. It is built once per (userID,
+// direction) and reused afterward; a plan change for a user already
+// holding a Limiter only takes effect on the gateway's next restart,
+// since bandwidth caps change rarely enough that live resizing isn't
+// worth the bookkeeping.
+func (r *Registry) ForUser(userID, direction string, plan user.Plan) *Limiter {
+	key := userID + ":" + direction
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if l, ok := r.limiters[key]; ok {
+		return l
+	}
+
+	rate := bytesPerSecond(MbpsFor(plan))
+	l := NewLimiter(rate, rate*BurstSeconds)
+	r.limiters[key] = l
+	return l
+}
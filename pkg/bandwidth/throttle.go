@@ -0,0 +1,31 @@
+package bandwidth
+
+import (
+	"context"
+	"io"
+)
+
+// ThrottledReader wraps r, spending from limiter after each Read so a
+// copy loop reading from it never sustains more than limiter's
+// configured rate.
+type ThrottledReader struct {
+	r       io.Reader
+	limiter *Limiter
+	ctx     context.Context
+}
+
+// NewThrottledReader wraps r with limiter, using ctx to unblock a wait
+// if the caller abandons the read (e.g. the connection closes).
+func NewThrottledReader(ctx context.Context, r io.Reader, limiter *Limiter) *ThrottledReader {
+	return &ThrottledReader{r: r, limiter: limiter, ctx: ctx}
+}
+
+func (t *ThrottledReader) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if n > 0 {
+		if werr := t.limiter.WaitN(t.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
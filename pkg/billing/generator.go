@@ -0,0 +1,79 @@
+package billing
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// Generator produces one Invoice per user for a billing period, from
+// their usage_logs and their plan's price and quota.
+type Generator struct {
+	users    *user.DAO
+	plans    *PlanDAO
+	invoices *InvoiceDAO
+	usage    *usage.DAO
+}
+
+// NewGenerator wires a Generator from its dependencies.
+func NewGenerator(users *user.DAO, plans *PlanDAO, invoices *InvoiceDAO, usageDAO *usage.DAO) *Generator {
+	return &Generator{users: users, plans: plans, invoices: invoices, usage: usageDAO}
+}
+
+// GenerateForPeriod invoices every user with usage in [periodStart,
+// periodEnd) against their plan's quota and overage rate, inserting one
+// Invoice per user. It is idempotent: re-running it for a period that
+// already has invoices leaves the existing rows untouched. It returns
+// how many invoices were generated.
+func (g *Generator) GenerateForPeriod(ctx context.Context, periodStart, periodEnd time.Time) (int, error) {
+	counts, err := g.usage.CountByUserInRange(ctx, periodStart, periodEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for userID, usageCount := range counts {
+		u, err := g.users.Get(ctx, userID)
+		if err != nil {
+			log.Printf("billing: skipping invoice for user %s: %v", userID, err)
+			continue
+		}
+
+		plan, err := g.plans.Get(ctx, u.Plan)
+		if err != nil {
+			log.Printf("billing: skipping invoice for user %s: no billing plan for %q: %v", userID, u.Plan, err)
+			continue
+		}
+
+		inv := buildInvoice(userID, plan, usageCount, periodStart, periodEnd)
+		if err := g.invoices.Insert(ctx, inv); err != nil {
+			return generated, err
+		}
+		generated++
+	}
+
+	return generated, nil
+}
+
+func buildInvoice(userID string, plan *Plan, usageCount int64, periodStart, periodEnd time.Time) *Invoice {
+	var overageCount int64
+	if usageCount > plan.QuotaRequests {
+		overageCount = usageCount - plan.QuotaRequests
+	}
+
+	return &Invoice{
+		UserID:        userID,
+		Plan:          plan.Plan,
+		PeriodStart:   periodStart,
+		PeriodEnd:     periodEnd,
+		UsageCount:    usageCount,
+		QuotaRequests: plan.QuotaRequests,
+		OverageCount:  overageCount,
+		BaseCents:     plan.PriceCents,
+		OverageCents:  overageCount * plan.OveragePriceCents,
+		TotalCents:    plan.PriceCents + overageCount*plan.OveragePriceCents,
+	}
+}
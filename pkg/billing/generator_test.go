@@ -0,0 +1,39 @@
+package billing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+func TestBuildInvoiceWithinQuotaHasNoOverage(t *testing.T) {
+	plan := &Plan{Plan: user.PlanPro, PriceCents: 4900, QuotaRequests: 100000, OveragePriceCents: 1}
+	start, end := time.Unix(0, 0), time.Unix(0, 0).AddDate(0, 1, 0)
+
+	inv := buildInvoice("user-1", plan, 50000, start, end)
+
+	if inv.OverageCount != 0 || inv.OverageCents != 0 {
+		t.Fatalf("expected no overage, got %+v", inv)
+	}
+	if inv.TotalCents != 4900 {
+		t.Fatalf("expected total of 4900, got %d", inv.TotalCents)
+	}
+}
+
+func TestBuildInvoiceOverQuotaChargesOverage(t *testing.T) {
+	plan := &Plan{Plan: user.PlanPro, PriceCents: 4900, QuotaRequests: 100000, OveragePriceCents: 2}
+	start, end := time.Unix(0, 0), time.Unix(0, 0).AddDate(0, 1, 0)
+
+	inv := buildInvoice("user-1", plan, 100500, start, end)
+
+	if inv.OverageCount != 500 {
+		t.Fatalf("expected overage count of 500, got %d", inv.OverageCount)
+	}
+	if inv.OverageCents != 1000 {
+		t.Fatalf("expected overage cost of 1000 cents, got %d", inv.OverageCents)
+	}
+	if inv.TotalCents != 5900 {
+		t.Fatalf("expected total of 5900, got %d", inv.TotalCents)
+	}
+}
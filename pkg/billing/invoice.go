@@ -0,0 +1,176 @@
+package billing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/pagination"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// ErrNotFound is returned when no invoice matches.
+var ErrNotFound = errors.New("billing: invoice not found")
+
+const (
+	InvoiceStatusUnpaid = "unpaid"
+	InvoiceStatusPaid   = "paid"
+)
+
+// Invoice is one user's bill for a single billing period, generated
+// from their usage_logs against their plan's quota.
+type Invoice struct {
+	ID            string
+	UserID        string
+	Plan          user.Plan
+	PeriodStart   time.Time
+	PeriodEnd     time.Time
+	UsageCount    int64
+	QuotaRequests int64
+	OverageCount  int64
+	BaseCents     int64
+	OverageCents  int64
+	TotalCents    int64
+	Status        string
+	CreatedAt     time.Time
+	PaidAt        *time.Time
+}
+
+// InvoiceDAO persists and retrieves invoices in MySQL.
+type InvoiceDAO struct {
+	db *sql.DB
+}
+
+// NewInvoiceDAO wraps an existing *sql.DB handle.
+func NewInvoiceDAO(db *sql.DB) *InvoiceDAO {
+	return &InvoiceDAO{db: db}
+}
+
+// Insert writes a new invoice. If an invoice for the same user and
+// period already exists, it is left untouched and no error is returned,
+// so invoice generation can be re-run safely.
+func (d *InvoiceDAO) Insert(ctx context.Context, inv *Invoice) error {
+	if inv.ID == "" {
+		inv.ID = uuid.NewString()
+	}
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO invoices
+		   (id, user_id, plan, period_start, period_end, usage_count, quota_requests, overage_count, base_cents, overage_cents, total_cents, status)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON DUPLICATE KEY UPDATE id = id`,
+		inv.ID, inv.UserID, string(inv.Plan), inv.PeriodStart, inv.PeriodEnd,
+		inv.UsageCount, inv.QuotaRequests, inv.OverageCount, inv.BaseCents, inv.OverageCents, inv.TotalCents,
+		InvoiceStatusUnpaid,
+	)
+	return err
+}
+
+// InvoiceSort whitelists the columns List callers may sort by.
+var InvoiceSort = pagination.SortWhitelist{
+	"period_start": "period_start",
+	"total_cents":  "total_cents",
+}
+
+// InvoiceFilter narrows List. Zero values mean "don't filter on this
+// field". Page controls paging and sorting; its SortBy should come from
+// resolving a caller's sort key through InvoiceSort.
+type InvoiceFilter struct {
+	UserID string
+	Status string
+	Page   pagination.Params
+}
+
+// List returns a page of invoices matching filter, along with the total
+// number of invoices matching it across every page.
+func (d *InvoiceDAO) List(ctx context.Context, filter InvoiceFilter) (pagination.Page[*Invoice], error) {
+	limit := filter.Page.Limit
+	if limit <= 0 {
+		limit = pagination.DefaultLimit
+	}
+	sortBy := filter.Page.SortBy
+	if sortBy == "" {
+		sortBy = "period_start"
+	}
+	order := "DESC"
+	if !filter.Page.SortDesc {
+		order = "ASC"
+	}
+
+	where := ` WHERE 1 = 1`
+	var args []interface{}
+	if filter.UserID != "" {
+		where += ` AND user_id = ?`
+		args = append(args, filter.UserID)
+	}
+	if filter.Status != "" {
+		where += ` AND status = ?`
+		args = append(args, filter.Status)
+	}
+
+	var total int
+	if err := d.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM invoices`+where, args...).Scan(&total); err != nil {
+		return pagination.Page[*Invoice]{}, err
+	}
+
+	query := `SELECT id, user_id, plan, period_start, period_end, usage_count, quota_requests, overage_count, base_cents, overage_cents, total_cents, status, created_at, paid_at
+	          FROM invoices` + where + ` ORDER BY ` + sortBy + ` ` + order + ` LIMIT ? OFFSET ?`
+	args = append(args, limit, filter.Page.Offset)
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return pagination.Page[*Invoice]{}, err
+	}
+	defer rows.Close()
+
+	var out []*Invoice
+	for rows.Next() {
+		inv, err := scanInvoice(rows)
+		if err != nil {
+			return pagination.Page[*Invoice]{}, err
+		}
+		out = append(out, inv)
+	}
+	if err := rows.Err(); err != nil {
+		return pagination.Page[*Invoice]{}, err
+	}
+
+	return pagination.Page[*Invoice]{Items: out, Total: total, Limit: limit, Offset: filter.Page.Offset}, nil
+}
+
+// MarkPaid transitions an invoice to paid, stamping paidAt.
+func (d *InvoiceDAO) MarkPaid(ctx context.Context, id string, paidAt time.Time) error {
+	result, err := d.db.ExecContext(ctx,
+		`UPDATE invoices SET status = ?, paid_at = ? WHERE id = ?`, InvoiceStatusPaid, paidAt, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func scanInvoice(row rowScanner) (*Invoice, error) {
+	inv := &Invoice{}
+	var plan string
+	var paidAt sql.NullTime
+	if err := row.Scan(
+		&inv.ID, &inv.UserID, &plan, &inv.PeriodStart, &inv.PeriodEnd,
+		&inv.UsageCount, &inv.QuotaRequests, &inv.OverageCount, &inv.BaseCents, &inv.OverageCents, &inv.TotalCents,
+		&inv.Status, &inv.CreatedAt, &paidAt,
+	); err != nil {
+		return nil, err
+	}
+	inv.Plan = user.Plan(plan)
+	if paidAt.Valid {
+		inv.PaidAt = &paidAt.Time
+	}
+	return inv, nil
+}
@@ -0,0 +1,157 @@
+package billing
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/eventbus"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// CheckoutSession is a hosted checkout page created by a PaymentProvider
+// for a user to complete a subscription purchase.
+type CheckoutSession struct {
+	ID  string
+	URL string
+}
+
+// WebhookEventType identifies the lifecycle event a payment provider
+// reported.
+type WebhookEventType string
+
+const (
+	// EventSubscriptionActivated fires when a checkout completes or a
+	// subscription renews successfully.
+	EventSubscriptionActivated WebhookEventType = "subscription.activated"
+	// EventSubscriptionPaymentFailed fires when a renewal charge fails.
+	EventSubscriptionPaymentFailed WebhookEventType = "subscription.payment_failed"
+	// EventSubscriptionCanceled fires when the subscription is canceled,
+	// either by the customer or the provider.
+	EventSubscriptionCanceled WebhookEventType = "subscription.canceled"
+)
+
+// WebhookEvent is a payment-provider webhook event normalized to what
+// Subscriber needs, independent of the provider's own payload shape.
+type WebhookEvent struct {
+	Type                   WebhookEventType
+	UserID                 string
+	Plan                   user.Plan
+	ProviderCustomerID     string
+	ProviderSubscriptionID string
+	CurrentPeriodEnd       time.Time
+}
+
+// PaymentProvider abstracts the external payment provider (Stripe in
+// production; see pkg/billing/stripe) so checkout creation and webhook
+// handling don't depend on a specific SDK or HTTP shape.
+type PaymentProvider interface {
+	// CreateCheckoutSession starts a hosted checkout flow for userID to
+	// purchase plan.
+	CreateCheckoutSession(ctx context.Context, userID string, plan user.Plan) (CheckoutSession, error)
+	// VerifyAndParseWebhook authenticates a raw webhook payload against
+	// signatureHeader and, if valid, normalizes it into a WebhookEvent.
+	VerifyAndParseWebhook(payload []byte, signatureHeader string) (WebhookEvent, error)
+}
+
+// Subscriber applies payment-provider webhook events to subscription
+// and user-plan state. It is provider-agnostic: it only depends on the
+// normalized WebhookEvent.
+type Subscriber struct {
+	subscriptions *SubscriptionDAO
+	users         *user.DAO
+	events        eventbus.Publisher
+}
+
+// NewSubscriber wires a Subscriber from its dependencies. events, if
+// non-nil, is notified with an eventbus.EventSubscriptionExpired event
+// whenever a subscription is canceled; this codebase has no separate
+// "expired" webhook of its own, so cancellation (the closest lifecycle
+// transition to losing an active subscription) is what drives it. A
+// nil events disables that.
+func NewSubscriber(subscriptions *SubscriptionDAO, users *user.DAO, events eventbus.Publisher) *Subscriber {
+	return &Subscriber{subscriptions: subscriptions, users: users, events: events}
+}
+
+// Apply updates subscription and user-plan state for a single webhook
+// event: activation upserts the subscription and raises the user's
+// plan, a failed renewal marks the subscription past_due, and
+// cancellation (or a renewal failure past its grace period) downgrades
+// the user back to the free plan.
+func (s *Subscriber) Apply(ctx context.Context, event WebhookEvent) error {
+	switch event.Type {
+	case EventSubscriptionActivated:
+		periodEnd := event.CurrentPeriodEnd
+		if err := s.subscriptions.Upsert(ctx, &Subscription{
+			UserID:                 event.UserID,
+			Plan:                   event.Plan,
+			Status:                 SubscriptionStatusActive,
+			ProviderCustomerID:     event.ProviderCustomerID,
+			ProviderSubscriptionID: event.ProviderSubscriptionID,
+			CurrentPeriodEnd:       &periodEnd,
+		}); err != nil {
+			return err
+		}
+		return s.users.UpdatePlan(ctx, event.UserID, event.Plan)
+
+	case EventSubscriptionPaymentFailed:
+		return s.subscriptions.UpdateStatus(ctx, event.UserID, SubscriptionStatusPastDue)
+
+	case EventSubscriptionCanceled:
+		if err := s.subscriptions.UpdateStatus(ctx, event.UserID, SubscriptionStatusCanceled); err != nil {
+			return err
+		}
+		if err := s.users.UpdatePlan(ctx, event.UserID, user.PlanFree); err != nil {
+			return err
+		}
+		s.publishExpired(ctx, event.UserID)
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+// DeactivateExpired downgrades every active subscription whose current
+// billing period ended before cutoff, up to limit subscriptions, back
+// to the free plan and marks it canceled, publishing
+// EventSubscriptionExpired for each. This is the sweep that catches a
+// subscription whose expiry was never reported by a provider webhook
+// (e.g. the provider's own renewal or cancellation notification was
+// lost); Apply's EventSubscriptionCanceled branch above remains the
+// primary path when a webhook does arrive.
+func (s *Subscriber) DeactivateExpired(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	expired, err := s.subscriptions.ListExpiringBefore(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	if len(expired) > limit {
+		expired = expired[:limit]
+	}
+
+	var count int
+	for _, sub := range expired {
+		if err := s.subscriptions.UpdateStatus(ctx, sub.UserID, SubscriptionStatusCanceled); err != nil {
+			return count, err
+		}
+		if err := s.users.UpdatePlan(ctx, sub.UserID, user.PlanFree); err != nil {
+			return count, err
+		}
+		s.publishExpired(ctx, sub.UserID)
+		count++
+	}
+	return count, nil
+}
+
+// publishExpired notifies s.events, if any, that userID's subscription
+// just ended. It's best-effort: a publish failure is logged but never
+// fails Apply itself.
+func (s *Subscriber) publishExpired(ctx context.Context, userID string) {
+	if s.events == nil {
+		return
+	}
+	event := eventbus.Event{Type: eventbus.EventSubscriptionExpired, Fields: map[string]string{"user_id": userID}}
+	if err := s.events.Publish(ctx, event); err != nil {
+		log.Printf("billing: failed to publish subscription.expired event: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+// Package billing tracks what each subscription plan costs and grants,
+// and generates per-user invoices from usage_logs against those plans.
+package billing
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// Plan is a billing definition for one of user.Plan's subscription
+// tiers: what it costs per period, how many requests it grants before
+// overage charges apply, and the per-request overage rate.
+type Plan struct {
+	Plan              user.Plan
+	Name              string
+	PriceCents        int64
+	QuotaRequests     int64
+	OveragePriceCents int64
+}
+
+// PlanDAO reads billing plan definitions from MySQL. Plans are seeded
+// and updated by migration, not through the API, since they change
+// rarely and changing one retroactively affects revenue.
+type PlanDAO struct {
+	db *sql.DB
+}
+
+// NewPlanDAO wraps an existing *sql.DB handle.
+func NewPlanDAO(db *sql.DB) *PlanDAO {
+	return &PlanDAO{db: db}
+}
+
+// Get loads a single plan's billing definition.
+func (d *PlanDAO) Get(ctx context.Context, plan user.Plan) (*Plan, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT plan, name, price_cents, quota_requests, overage_price_cents FROM billing_plans WHERE plan = ?`,
+		string(plan))
+	return scanPlan(row)
+}
+
+// List returns every billing plan definition.
+func (d *PlanDAO) List(ctx context.Context) ([]*Plan, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT plan, name, price_cents, quota_requests, overage_price_cents FROM billing_plans`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Plan
+	for rows.Next() {
+		p, err := scanPlan(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPlan(row rowScanner) (*Plan, error) {
+	p := &Plan{}
+	var plan string
+	if err := row.Scan(&plan, &p.Name, &p.PriceCents, &p.QuotaRequests, &p.OveragePriceCents); err != nil {
+		return nil, err
+	}
+	p.Plan = user.Plan(plan)
+	return p, nil
+}
@@ -0,0 +1,270 @@
+// Package stripe implements billing.PaymentProvider against Stripe's
+// REST API using net/http directly, rather than the official SDK, to
+// keep the module's dependency footprint small.
+package stripe
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+const apiBase = "https://api.stripe.com/v1"
+
+// webhookTolerance is how far a webhook's timestamp may drift from now
+// before its signature is rejected as stale, matching Stripe's own
+// recommended default.
+const webhookTolerance = 5 * time.Minute
+
+// ErrInvalidSignature is returned when a webhook payload's signature
+// doesn't match, or its timestamp is outside webhookTolerance.
+var ErrInvalidSignature = errors.New("stripe: invalid webhook signature")
+
+// ErrUnrecognizedEvent is returned by VerifyAndParseWebhook for event
+// types Client doesn't translate into a billing.WebhookEvent.
+var ErrUnrecognizedEvent = errors.New("stripe: unrecognized event type")
+
+// PriceIDs maps a user.Plan to the Stripe Price ID that charges for it.
+type PriceIDs map[user.Plan]string
+
+// Client implements billing.PaymentProvider against Stripe's REST API.
+type Client struct {
+	secretKey     string
+	webhookSecret string
+	prices        PriceIDs
+	successURL    string
+	cancelURL     string
+	httpClient    *http.Client
+}
+
+// New builds a Client. secretKey authenticates outbound API calls,
+// webhookSecret verifies inbound webhook signatures, and prices maps
+// plans to the Stripe Price IDs configured for them.
+func New(secretKey, webhookSecret string, prices PriceIDs, successURL, cancelURL string) *Client {
+	return &Client{
+		secretKey:     secretKey,
+		webhookSecret: webhookSecret,
+		prices:        prices,
+		successURL:    successURL,
+		cancelURL:     cancelURL,
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+var _ billing.PaymentProvider = (*Client)(nil)
+
+// CreateCheckoutSession creates a Stripe Checkout Session for userID to
+// subscribe to plan, identifying the resulting subscription by
+// client_reference_id so the webhook handler can map it back to userID.
+func (c *Client) CreateCheckoutSession(ctx context.Context, userID string, plan user.Plan) (billing.CheckoutSession, error) {
+	priceID, ok := c.prices[plan]
+	if !ok {
+		return billing.CheckoutSession{}, fmt.Errorf("stripe: no price configured for plan %q", plan)
+	}
+
+	form := url.Values{
+		"mode":                    {"subscription"},
+		"client_reference_id":     {userID},
+		"success_url":             {c.successURL},
+		"cancel_url":              {c.cancelURL},
+		"line_items[0][price]":    {priceID},
+		"line_items[0][quantity]": {"1"},
+	}
+
+	var out struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	if err := c.post(ctx, "/checkout/sessions", form, &out); err != nil {
+		return billing.CheckoutSession{}, err
+	}
+	return billing.CheckoutSession{ID: out.ID, URL: out.URL}, nil
+}
+
+func (c *Client) post(ctx context.Context, path string, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiBase+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.secretKey, "")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("stripe: %s returned %d", path, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// VerifyAndParseWebhook checks payload against the "t=...,v1=..."
+// signature in signatureHeader and, if valid, translates the event into
+// a billing.WebhookEvent.
+func (c *Client) VerifyAndParseWebhook(payload []byte, signatureHeader string) (billing.WebhookEvent, error) {
+	if err := c.verifySignature(payload, signatureHeader); err != nil {
+		return billing.WebhookEvent{}, err
+	}
+
+	var raw struct {
+		Type string `json:"type"`
+		Data struct {
+			Object json.RawMessage `json:"object"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return billing.WebhookEvent{}, err
+	}
+
+	switch raw.Type {
+	case "checkout.session.completed":
+		return parseCheckoutCompleted(raw.Data.Object)
+	case "customer.subscription.updated":
+		return parseSubscriptionUpdated(raw.Data.Object)
+	case "customer.subscription.deleted":
+		return parseSubscriptionDeleted(raw.Data.Object)
+	case "invoice.payment_failed":
+		return parsePaymentFailed(raw.Data.Object)
+	default:
+		return billing.WebhookEvent{}, ErrUnrecognizedEvent
+	}
+}
+
+func (c *Client) verifySignature(payload []byte, header string) error {
+	var timestamp, signature string
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			timestamp = kv[1]
+		case "v1":
+			signature = kv[1]
+		}
+	}
+	if timestamp == "" || signature == "" {
+		return ErrInvalidSignature
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if age := time.Since(time.Unix(ts, 0)); age > webhookTolerance || age < -webhookTolerance {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(c.webhookSecret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func parseCheckoutCompleted(object json.RawMessage) (billing.WebhookEvent, error) {
+	var session struct {
+		ClientReferenceID string `json:"client_reference_id"`
+		Customer          string `json:"customer"`
+		Subscription      string `json:"subscription"`
+	}
+	if err := json.Unmarshal(object, &session); err != nil {
+		return billing.WebhookEvent{}, err
+	}
+	return billing.WebhookEvent{
+		Type:                   billing.EventSubscriptionActivated,
+		UserID:                 session.ClientReferenceID,
+		ProviderCustomerID:     session.Customer,
+		ProviderSubscriptionID: session.Subscription,
+		CurrentPeriodEnd:       time.Now().Add(30 * 24 * time.Hour),
+	}, nil
+}
+
+func parseSubscriptionUpdated(object json.RawMessage) (billing.WebhookEvent, error) {
+	var sub struct {
+		ID               string `json:"id"`
+		Customer         string `json:"customer"`
+		Status           string `json:"status"`
+		CurrentPeriodEnd int64  `json:"current_period_end"`
+		Metadata         struct {
+			UserID string `json:"user_id"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(object, &sub); err != nil {
+		return billing.WebhookEvent{}, err
+	}
+	if sub.Status != "active" {
+		return billing.WebhookEvent{
+			Type:                   billing.EventSubscriptionPaymentFailed,
+			UserID:                 sub.Metadata.UserID,
+			ProviderCustomerID:     sub.Customer,
+			ProviderSubscriptionID: sub.ID,
+		}, nil
+	}
+	return billing.WebhookEvent{
+		Type:                   billing.EventSubscriptionActivated,
+		UserID:                 sub.Metadata.UserID,
+		ProviderCustomerID:     sub.Customer,
+		ProviderSubscriptionID: sub.ID,
+		CurrentPeriodEnd:       time.Unix(sub.CurrentPeriodEnd, 0),
+	}, nil
+}
+
+func parseSubscriptionDeleted(object json.RawMessage) (billing.WebhookEvent, error) {
+	var sub struct {
+		ID       string `json:"id"`
+		Customer string `json:"customer"`
+		Metadata struct {
+			UserID string `json:"user_id"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(object, &sub); err != nil {
+		return billing.WebhookEvent{}, err
+	}
+	return billing.WebhookEvent{
+		Type:                   billing.EventSubscriptionCanceled,
+		UserID:                 sub.Metadata.UserID,
+		ProviderCustomerID:     sub.Customer,
+		ProviderSubscriptionID: sub.ID,
+	}, nil
+}
+
+func parsePaymentFailed(object json.RawMessage) (billing.WebhookEvent, error) {
+	var invoice struct {
+		Customer     string `json:"customer"`
+		Subscription string `json:"subscription"`
+		Metadata     struct {
+			UserID string `json:"user_id"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(object, &invoice); err != nil {
+		return billing.WebhookEvent{}, err
+	}
+	return billing.WebhookEvent{
+		Type:                   billing.EventSubscriptionPaymentFailed,
+		UserID:                 invoice.Metadata.UserID,
+		ProviderCustomerID:     invoice.Customer,
+		ProviderSubscriptionID: invoice.Subscription,
+	}, nil
+}
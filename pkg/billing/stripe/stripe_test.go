@@ -0,0 +1,58 @@
+package stripe
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func signedHeader(secret string, timestamp time.Time, payload []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySignatureAccepted(t *testing.T) {
+	client := New("sk_test", "whsec_test", nil, "", "")
+	payload := []byte(`{"type":"checkout.session.completed"}`)
+
+	if err := client.verifySignature(payload, signedHeader("whsec_test", time.Now(), payload)); err != nil {
+		t.Fatalf("verifySignature() = %v, want nil", err)
+	}
+}
+
+func TestVerifySignatureRejectsWrongSecret(t *testing.T) {
+	client := New("sk_test", "whsec_test", nil, "", "")
+	payload := []byte(`{"type":"checkout.session.completed"}`)
+
+	err := client.verifySignature(payload, signedHeader("whsec_other", time.Now(), payload))
+	if err != ErrInvalidSignature {
+		t.Fatalf("verifySignature() = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestVerifySignatureRejectsStaleTimestamp(t *testing.T) {
+	client := New("sk_test", "whsec_test", nil, "", "")
+	payload := []byte(`{"type":"checkout.session.completed"}`)
+
+	err := client.verifySignature(payload, signedHeader("whsec_test", time.Now().Add(-time.Hour), payload))
+	if err != ErrInvalidSignature {
+		t.Fatalf("verifySignature() = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestVerifyAndParseWebhookUnrecognizedType(t *testing.T) {
+	client := New("sk_test", "whsec_test", nil, "", "")
+	payload := []byte(`{"type":"customer.created","data":{"object":{}}}`)
+
+	_, err := client.VerifyAndParseWebhook(payload, signedHeader("whsec_test", time.Now(), payload))
+	if err != ErrUnrecognizedEvent {
+		t.Fatalf("VerifyAndParseWebhook() err = %v, want ErrUnrecognizedEvent", err)
+	}
+}
@@ -0,0 +1,141 @@
+package billing
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dbtx"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+const (
+	SubscriptionStatusActive   = "active"
+	SubscriptionStatusPastDue  = "past_due"
+	SubscriptionStatusCanceled = "canceled"
+)
+
+// Subscription tracks a user's plan as driven by the payment provider:
+// what they're currently subscribed to, and the provider-side IDs
+// needed to reconcile future webhook events against it.
+type Subscription struct {
+	UserID                 string
+	Plan                   user.Plan
+	Status                 string
+	ProviderCustomerID     string
+	ProviderSubscriptionID string
+	CurrentPeriodEnd       *time.Time
+	UpdatedAt              time.Time
+}
+
+// SubscriptionDAOInterface is the subset of SubscriptionDAO's behavior
+// that service and handler code depends on. It exists so those layers
+// can be unit-tested against daofake's in-memory fake instead of a real
+// MySQL connection; see pkg/daofake's conformance suite, which every
+// implementation (SubscriptionDAO included) must pass.
+type SubscriptionDAOInterface interface {
+	Get(ctx context.Context, userID string) (*Subscription, error)
+	GetByProviderSubscriptionID(ctx context.Context, providerSubscriptionID string) (*Subscription, error)
+	ListExpiringBefore(ctx context.Context, cutoff time.Time) ([]*Subscription, error)
+	Upsert(ctx context.Context, sub *Subscription) error
+	UpdateStatus(ctx context.Context, userID, status string) error
+}
+
+// SubscriptionDAO persists subscriptions in MySQL.
+type SubscriptionDAO struct {
+	db dbtx.Queryer
+}
+
+var _ SubscriptionDAOInterface = (*SubscriptionDAO)(nil)
+
+// NewSubscriptionDAO wraps an existing *sql.DB handle.
+func NewSubscriptionDAO(db *sql.DB) *SubscriptionDAO {
+	return &SubscriptionDAO{db: db}
+}
+
+// WithTx returns a SubscriptionDAO whose operations run against tx
+// instead of the original *sql.DB, so callers can compose it with other
+// DAOs inside a dbtx.Run unit of work.
+func (d *SubscriptionDAO) WithTx(tx *sql.Tx) *SubscriptionDAO {
+	return &SubscriptionDAO{db: tx}
+}
+
+// Get loads a user's subscription, or sql.ErrNoRows if they don't have
+// one yet.
+func (d *SubscriptionDAO) Get(ctx context.Context, userID string) (*Subscription, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT user_id, plan, status, provider_customer_id, provider_subscription_id, current_period_end, updated_at
+		 FROM subscriptions WHERE user_id = ?`, userID)
+	return scanSubscription(row)
+}
+
+// GetByProviderSubscriptionID looks up the subscription a webhook event
+// refers to.
+func (d *SubscriptionDAO) GetByProviderSubscriptionID(ctx context.Context, providerSubscriptionID string) (*Subscription, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT user_id, plan, status, provider_customer_id, provider_subscription_id, current_period_end, updated_at
+		 FROM subscriptions WHERE provider_subscription_id = ?`, providerSubscriptionID)
+	return scanSubscription(row)
+}
+
+// ListExpiringBefore returns active subscriptions whose current billing
+// period ends before cutoff, for the alerting subsystem's
+// subscription-expiring rule.
+func (d *SubscriptionDAO) ListExpiringBefore(ctx context.Context, cutoff time.Time) ([]*Subscription, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT user_id, plan, status, provider_customer_id, provider_subscription_id, current_period_end, updated_at
+		 FROM subscriptions WHERE status = ? AND current_period_end IS NOT NULL AND current_period_end < ?`,
+		SubscriptionStatusActive, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Subscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sub)
+	}
+	return out, rows.Err()
+}
+
+// Upsert creates or fully replaces a user's subscription record, used
+// when a checkout completes or a subscription is renewed.
+func (d *SubscriptionDAO) Upsert(ctx context.Context, sub *Subscription) error {
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO subscriptions (user_id, plan, status, provider_customer_id, provider_subscription_id, current_period_end)
+		 VALUES (?, ?, ?, ?, ?, ?)
+		 ON DUPLICATE KEY UPDATE
+		   plan = VALUES(plan),
+		   status = VALUES(status),
+		   provider_customer_id = VALUES(provider_customer_id),
+		   provider_subscription_id = VALUES(provider_subscription_id),
+		   current_period_end = VALUES(current_period_end)`,
+		sub.UserID, string(sub.Plan), sub.Status, sub.ProviderCustomerID, sub.ProviderSubscriptionID, sub.CurrentPeriodEnd)
+	return err
+}
+
+// UpdateStatus transitions an existing subscription's status, e.g. to
+// past_due after a failed renewal or canceled after provider
+// cancellation.
+func (d *SubscriptionDAO) UpdateStatus(ctx context.Context, userID, status string) error {
+	_, err := d.db.ExecContext(ctx, `UPDATE subscriptions SET status = ? WHERE user_id = ?`, status, userID)
+	return err
+}
+
+func scanSubscription(row rowScanner) (*Subscription, error) {
+	sub := &Subscription{}
+	var plan string
+	var currentPeriodEnd sql.NullTime
+	if err := row.Scan(&sub.UserID, &plan, &sub.Status, &sub.ProviderCustomerID, &sub.ProviderSubscriptionID, &currentPeriodEnd, &sub.UpdatedAt); err != nil {
+		return nil, err
+	}
+	sub.Plan = user.Plan(plan)
+	if currentPeriodEnd.Valid {
+		sub.CurrentPeriodEnd = &currentPeriodEnd.Time
+	}
+	return sub, nil
+}
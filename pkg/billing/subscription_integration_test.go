@@ -0,0 +1,16 @@
+//go:build integration
+
+package billing_test
+
+import (
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/daofake"
+	"github.com/XXXXD-cation/proxy-platform/pkg/testsupport"
+)
+
+// Run with: go test -tags=integration ./pkg/billing/...
+func TestSubscriptionDAOConformsToSubscriptionDAOInterface(t *testing.T) {
+	daofake.ConformSubscription(t, billing.NewSubscriptionDAO(testsupport.GetTestDB(t)))
+}
@@ -0,0 +1,175 @@
+// Package blocklist rejects gateway requests aimed at known
+// malware/phishing hosts. Entries come from two sources kept in sync by
+// Refresher: a local file maintained by the operator, and a remote feed
+// refreshed on a schedule. Enterprise-plan users may override a specific
+// listed pattern for their own account, e.g. when a host is miscategorized.
+package blocklist
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SourceLocal  = "local"
+	SourceRemote = "remote"
+)
+
+// Entry is a single blocked host pattern. Pattern follows the same
+// leading-wildcard convention as pkg/routing and pkg/targetpolicy: an
+// exact host ("evil.example.com") or a glob ("*.evil.example.com",
+// which also matches "evil.example.com" itself).
+type Entry struct {
+	ID        string
+	Pattern   string
+	Category  string
+	Source    string
+	CreatedAt time.Time
+}
+
+// Override lets one enterprise user exempt themselves from a specific
+// listed Pattern, e.g. because it was miscategorized for their use case.
+type Override struct {
+	ID        string
+	UserID    string
+	Pattern   string
+	CreatedAt time.Time
+}
+
+// Decision is the outcome of evaluating a host against the blocklist.
+type Decision struct {
+	Blocked bool
+	// Category explains a block, suitable for both the HTTP response
+	// and UsageLog.DenialReason; empty when Blocked is false.
+	Category string
+}
+
+// Decide evaluates host against entries, skipping any entry whose
+// Pattern appears in overrides. The first matching, non-overridden
+// entry wins.
+func Decide(entries []*Entry, overrides []*Override, host string) Decision {
+	overridden := make(map[string]bool, len(overrides))
+	for _, o := range overrides {
+		overridden[o.Pattern] = true
+	}
+	for _, e := range entries {
+		if overridden[e.Pattern] {
+			continue
+		}
+		if matchesPattern(e.Pattern, host) {
+			return Decision{Blocked: true, Category: e.Category}
+		}
+	}
+	return Decision{}
+}
+
+// matchesPattern reports whether host satisfies pattern, using the same
+// leading-wildcard convention as pkg/routing's rules.
+func matchesPattern(pattern, host string) bool {
+	pattern = strings.ToLower(pattern)
+	host = strings.ToLower(host)
+
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		return host == suffix || strings.HasSuffix(host, "."+suffix)
+	}
+	return host == pattern
+}
+
+// DAO manages blocklist entries and per-user overrides in MySQL.
+type DAO struct {
+	db *sql.DB
+}
+
+// NewDAO wraps an existing *sql.DB handle.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db}
+}
+
+// List returns every configured blocklist entry.
+func (d *DAO) List(ctx context.Context) ([]*Entry, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT id, pattern, category, source, created_at FROM blocklist_entries ORDER BY pattern`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Entry
+	for rows.Next() {
+		e := &Entry{}
+		if err := rows.Scan(&e.ID, &e.Pattern, &e.Category, &e.Source, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// Upsert adds pattern to the blocklist, or updates its category and
+// source if it's already listed. It's how Refresher reconciles a feed's
+// current contents into the table.
+func (d *DAO) Upsert(ctx context.Context, pattern, category, source string) error {
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO blocklist_entries (id, pattern, category, source) VALUES (?, ?, ?, ?)
+		 ON DUPLICATE KEY UPDATE category = VALUES(category), source = VALUES(source)`,
+		uuid.NewString(), pattern, category, source)
+	return err
+}
+
+// ListOverrides returns every override userID has configured.
+func (d *DAO) ListOverrides(ctx context.Context, userID string) ([]*Override, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT id, user_id, pattern, created_at FROM blocklist_overrides WHERE user_id = ? ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Override
+	for rows.Next() {
+		o := &Override{}
+		if err := rows.Scan(&o.ID, &o.UserID, &o.Pattern, &o.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	return out, rows.Err()
+}
+
+// AddOverride exempts userID from pattern. Callers are responsible for
+// checking that userID's plan is allowed to override (see
+// services/api/internal/handlers, which gates this to enterprise plans).
+func (d *DAO) AddOverride(ctx context.Context, userID, pattern string) (*Override, error) {
+	o := &Override{ID: uuid.NewString(), UserID: userID, Pattern: pattern}
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO blocklist_overrides (id, user_id, pattern) VALUES (?, ?, ?)`, o.ID, o.UserID, o.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// DeleteOverride removes an override scoped to userID. It is a no-op if
+// the override doesn't exist or belongs to a different user.
+func (d *DAO) DeleteOverride(ctx context.Context, userID, id string) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM blocklist_overrides WHERE id = ? AND user_id = ?`, id, userID)
+	return err
+}
+
+// Evaluate loads the blocklist and userID's overrides and decides
+// whether host may be proxied to, per Decide.
+func (d *DAO) Evaluate(ctx context.Context, userID, host string) (Decision, error) {
+	entries, err := d.List(ctx)
+	if err != nil {
+		return Decision{}, err
+	}
+	overrides, err := d.ListOverrides(ctx, userID)
+	if err != nil {
+		return Decision{}, err
+	}
+	return Decide(entries, overrides, host), nil
+}
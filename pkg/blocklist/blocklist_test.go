@@ -0,0 +1,70 @@
+package blocklist
+
+import "testing"
+
+func TestDecide(t *testing.T) {
+	cases := []struct {
+		name      string
+		entries   []*Entry
+		overrides []*Override
+		host      string
+		want      Decision
+	}{
+		{
+			name: "no match allows",
+			entries: []*Entry{
+				{Pattern: "*.evil.example.com", Category: "malware"},
+			},
+			host: "example.com",
+			want: Decision{},
+		},
+		{
+			name: "matching entry blocks",
+			entries: []*Entry{
+				{Pattern: "*.evil.example.com", Category: "malware"},
+			},
+			host: "c2.evil.example.com",
+			want: Decision{Blocked: true, Category: "malware"},
+		},
+		{
+			name: "override exempts a specific pattern",
+			entries: []*Entry{
+				{Pattern: "*.evil.example.com", Category: "malware"},
+			},
+			overrides: []*Override{
+				{UserID: "u1", Pattern: "*.evil.example.com"},
+			},
+			host: "c2.evil.example.com",
+			want: Decision{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := Decide(c.entries, c.overrides, c.host)
+			if got != c.want {
+				t.Errorf("Decide() = %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestMatchesPattern(t *testing.T) {
+	cases := []struct {
+		pattern, host string
+		want          bool
+	}{
+		{"*.evil.example.com", "c2.evil.example.com", true},
+		{"*.evil.example.com", "evil.example.com", true},
+		{"*.evil.example.com", "notevil.example.com", false},
+		{"evil.example.com", "evil.example.com", true},
+		{"evil.example.com", "www.evil.example.com", false},
+		{"Evil.example.com", "EVIL.EXAMPLE.COM", true},
+	}
+
+	for _, c := range cases {
+		if got := matchesPattern(c.pattern, c.host); got != c.want {
+			t.Errorf("matchesPattern(%q, %q) = %v, want %v", c.pattern, c.host, got, c.want)
+		}
+	}
+}
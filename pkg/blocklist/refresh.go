@@ -0,0 +1,134 @@
+package blocklist
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultRefreshInterval is how often Run re-fetches the configured
+// sources when the caller doesn't need a different cadence.
+const DefaultRefreshInterval = time.Hour
+
+// httpGetter is satisfied by *http.Client; kept as an interface so
+// Refresher is testable without a real network call.
+type httpGetter interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Refresher keeps a DAO's blocklist_entries table in sync with a local
+// file and/or a remote feed, both in the simple "pattern,category"
+// per-line format. Either source may be left empty to skip it.
+type Refresher struct {
+	dao       *DAO
+	localPath string
+	feedURL   string
+	client    httpGetter
+}
+
+// NewRefresher builds a Refresher. localPath and feedURL may each be
+// empty to disable that source.
+func NewRefresher(dao *DAO, localPath, feedURL string) *Refresher {
+	return &Refresher{dao: dao, localPath: localPath, feedURL: feedURL, client: http.DefaultClient}
+}
+
+// Run calls Refresh once immediately, then again every interval until
+// ctx is cancelled, logging (rather than propagating) any error so one
+// bad feed fetch doesn't bring down the refresh loop.
+func (r *Refresher) Run(ctx context.Context, interval time.Duration) {
+	if err := r.Refresh(ctx); err != nil {
+		log.Printf("blocklist: initial refresh failed: %v", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil {
+				log.Printf("blocklist: refresh failed: %v", err)
+			}
+		}
+	}
+}
+
+// Refresh re-reads every configured source and upserts their entries
+// into dao. It returns the first error encountered but still attempts
+// both sources.
+func (r *Refresher) Refresh(ctx context.Context) error {
+	var firstErr error
+
+	if r.localPath != "" {
+		if err := r.refreshLocal(ctx); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("blocklist: refresh local file: %w", err)
+		}
+	}
+	if r.feedURL != "" {
+		if err := r.refreshRemote(ctx); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("blocklist: refresh remote feed: %w", err)
+		}
+	}
+	return firstErr
+}
+
+func (r *Refresher) refreshLocal(ctx context.Context) error {
+	f, err := os.Open(r.localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return r.upsertLines(ctx, f, SourceLocal)
+}
+
+func (r *Refresher) refreshRemote(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.feedURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, r.feedURL)
+	}
+	return r.upsertLines(ctx, resp.Body, SourceRemote)
+}
+
+// upsertLines parses "pattern,category" lines from src, skipping blanks
+// and "#"-prefixed comments, and upserts each into dao.
+func (r *Refresher) upsertLines(ctx context.Context, src io.Reader, source string) error {
+	scanner := bufio.NewScanner(src)
+	var lastErr error
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pattern, category, ok := strings.Cut(line, ",")
+		if !ok {
+			continue
+		}
+		pattern = strings.TrimSpace(pattern)
+		category = strings.TrimSpace(category)
+		if pattern == "" || category == "" {
+			continue
+		}
+		if err := r.dao.Upsert(ctx, pattern, category, source); err != nil {
+			lastErr = err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return lastErr
+}
@@ -0,0 +1,210 @@
+// Package circuitbreaker tracks per-upstream failure streaks in memory
+// and stops routing to an upstream as soon as it looks broken, rather
+// than waiting for the next periodic health-check sweep to catch it.
+// It complements, rather than replaces, proxy-pool's MySQL-backed
+// scoring: that feedback loop is authoritative but only turns over on
+// the next sweep, while a Registry reacts within the same request that
+// observed the failure.
+package circuitbreaker
+
+import (
+	"sync"
+	"time"
+)
+
+// State is where a tracked upstream sits in the breaker state machine.
+type State int
+
+const (
+	// Closed is the normal state: requests are allowed through and
+	// failures are counted.
+	Closed State = iota
+	// Open rejects every request until OpenDuration has elapsed since
+	// the breaker tripped.
+	Open
+	// HalfOpen allows exactly one probe request through to test
+	// whether the upstream has recovered, rejecting the rest until
+	// that probe reports its outcome.
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultFailureThreshold is how many consecutive failures trip the
+// breaker if Config doesn't say otherwise.
+const DefaultFailureThreshold = 5
+
+// DefaultOpenDuration is how long a tripped breaker stays Open before
+// allowing a half-open probe, if Config doesn't say otherwise.
+const DefaultOpenDuration = 30 * time.Second
+
+// Config bounds a Registry's trip and recovery behavior. A zero Config
+// falls back to the Default* constants.
+type Config struct {
+	// FailureThreshold is how many consecutive failures trip the
+	// breaker. Zero or negative uses DefaultFailureThreshold.
+	FailureThreshold int
+	// OpenDuration is how long a tripped breaker stays Open before a
+	// half-open probe is allowed through. Zero or negative uses
+	// DefaultOpenDuration.
+	OpenDuration time.Duration
+}
+
+func (c Config) failureThreshold() int {
+	if c.FailureThreshold <= 0 {
+		return DefaultFailureThreshold
+	}
+	return c.FailureThreshold
+}
+
+func (c Config) openDuration() time.Duration {
+	if c.OpenDuration <= 0 {
+		return DefaultOpenDuration
+	}
+	return c.OpenDuration
+}
+
+// DefaultConfig returns the breaker's built-in failure threshold and
+// open duration.
+func DefaultConfig() Config {
+	return Config{FailureThreshold: DefaultFailureThreshold, OpenDuration: DefaultOpenDuration}
+}
+
+// breaker tracks one upstream's state.
+type breaker struct {
+	state               State
+	consecutiveFailures int
+	openedAt            time.Time
+	probeInFlight       bool
+}
+
+// Registry holds one breaker per upstream ID, keyed on first use. The
+// zero value is not usable; construct with New.
+type Registry struct {
+	cfg Config
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+
+	hooksMu sync.Mutex
+	hooks   []func(upstreamID string)
+}
+
+// New creates a Registry from cfg.
+func New(cfg Config) *Registry {
+	return &Registry{cfg: cfg, breakers: make(map[string]*breaker)}
+}
+
+// OnOpen registers a callback invoked whenever an upstream's breaker
+// trips from Closed (or fails its half-open probe) to Open. Callers use
+// this for local logging or metrics; the scorer feedback loop itself
+// runs independently via the existing report-outcome path.
+func (r *Registry) OnOpen(fn func(upstreamID string)) {
+	r.hooksMu.Lock()
+	defer r.hooksMu.Unlock()
+	r.hooks = append(r.hooks, fn)
+}
+
+func (r *Registry) notifyOpen(upstreamID string) {
+	r.hooksMu.Lock()
+	hooks := append([]func(string){}, r.hooks...)
+	r.hooksMu.Unlock()
+	for _, fn := range hooks {
+		fn(upstreamID)
+	}
+}
+
+func (r *Registry) breakerFor(upstreamID string) *breaker {
+	b, ok := r.breakers[upstreamID]
+	if !ok {
+		b = &breaker{}
+		r.breakers[upstreamID] = b
+	}
+	return b
+}
+
+// Allow reports whether a request may be routed to upstreamID: true if
+// its breaker is Closed, or if it's Open and OpenDuration has elapsed
+// (which also transitions it to HalfOpen and reserves the one allowed
+// probe). An upstream never seen before is Closed by default.
+func (r *Registry) Allow(upstreamID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := r.breakerFor(upstreamID)
+
+	switch b.state {
+	case Closed:
+		return true
+	case Open:
+		if time.Since(b.openedAt) < r.cfg.openDuration() {
+			return false
+		}
+		b.state = HalfOpen
+		b.probeInFlight = true
+		return true
+	case HalfOpen:
+		return false
+	default:
+		return false
+	}
+}
+
+// RecordSuccess clears upstreamID's failure streak. A successful
+// half-open probe closes the breaker; a success while Closed just
+// resets the streak.
+func (r *Registry) RecordSuccess(upstreamID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := r.breakerFor(upstreamID)
+	b.state = Closed
+	b.consecutiveFailures = 0
+	b.probeInFlight = false
+}
+
+// RecordFailure counts a failure against upstreamID, tripping the
+// breaker to Open once FailureThreshold consecutive failures have
+// accumulated. A failed half-open probe reopens the breaker
+// immediately, restarting OpenDuration.
+func (r *Registry) RecordFailure(upstreamID string) {
+	r.mu.Lock()
+	b := r.breakerFor(upstreamID)
+
+	wasOpen := b.state == Open
+	switch b.state {
+	case HalfOpen:
+		b.state = Open
+		b.openedAt = time.Now()
+		b.probeInFlight = false
+	case Closed:
+		b.consecutiveFailures++
+		if b.consecutiveFailures >= r.cfg.failureThreshold() {
+			b.state = Open
+			b.openedAt = time.Now()
+		}
+	}
+	tripped := b.state == Open && !wasOpen
+	r.mu.Unlock()
+
+	if tripped {
+		r.notifyOpen(upstreamID)
+	}
+}
+
+// State returns upstreamID's current breaker state, for status/health
+// reporting. An upstream never seen before is Closed.
+func (r *Registry) State(upstreamID string) State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.breakerFor(upstreamID).state
+}
@@ -0,0 +1,97 @@
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAllowClosedByDefault(t *testing.T) {
+	r := New(DefaultConfig())
+	if !r.Allow("p1") {
+		t.Fatal("expected an upstream never seen before to be allowed")
+	}
+}
+
+func TestOpensAfterThreshold(t *testing.T) {
+	r := New(Config{FailureThreshold: 3, OpenDuration: time.Minute})
+
+	r.RecordFailure("p1")
+	r.RecordFailure("p1")
+	if !r.Allow("p1") {
+		t.Fatal("expected breaker to stay closed below the failure threshold")
+	}
+
+	r.RecordFailure("p1")
+	if r.Allow("p1") {
+		t.Fatal("expected breaker to open once the failure threshold is reached")
+	}
+	if r.State("p1") != Open {
+		t.Fatalf("expected state Open, got %v", r.State("p1"))
+	}
+}
+
+func TestOnOpenFiresOnceWhenTripped(t *testing.T) {
+	r := New(Config{FailureThreshold: 1, OpenDuration: time.Minute})
+	var opened []string
+	r.OnOpen(func(upstreamID string) { opened = append(opened, upstreamID) })
+
+	r.RecordFailure("p1")
+	r.RecordFailure("p1") // already open; must not fire again
+
+	if len(opened) != 1 || opened[0] != "p1" {
+		t.Fatalf("expected exactly one OnOpen notification for p1, got %v", opened)
+	}
+}
+
+func TestHalfOpenAllowsOneProbeAfterOpenDuration(t *testing.T) {
+	r := New(Config{FailureThreshold: 1, OpenDuration: 10 * time.Millisecond})
+	r.RecordFailure("p1")
+
+	if r.Allow("p1") {
+		t.Fatal("expected breaker to reject while still within OpenDuration")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if !r.Allow("p1") {
+		t.Fatal("expected the first request after OpenDuration to be let through as a probe")
+	}
+	if r.State("p1") != HalfOpen {
+		t.Fatalf("expected state HalfOpen after the probe is allowed through, got %v", r.State("p1"))
+	}
+	if r.Allow("p1") {
+		t.Fatal("expected a second concurrent request to be rejected while the probe is in flight")
+	}
+}
+
+func TestSuccessfulProbeCloses(t *testing.T) {
+	r := New(Config{FailureThreshold: 1, OpenDuration: 10 * time.Millisecond})
+	r.RecordFailure("p1")
+	time.Sleep(20 * time.Millisecond)
+	r.Allow("p1") // reserves the probe, transitions to HalfOpen
+
+	r.RecordSuccess("p1")
+
+	if r.State("p1") != Closed {
+		t.Fatalf("expected state Closed after a successful probe, got %v", r.State("p1"))
+	}
+	if !r.Allow("p1") {
+		t.Fatal("expected requests to be allowed again once closed")
+	}
+}
+
+func TestFailedProbeReopens(t *testing.T) {
+	r := New(Config{FailureThreshold: 1, OpenDuration: 10 * time.Millisecond})
+	r.RecordFailure("p1")
+	time.Sleep(20 * time.Millisecond)
+	r.Allow("p1") // reserves the probe, transitions to HalfOpen
+
+	r.RecordFailure("p1")
+
+	if r.State("p1") != Open {
+		t.Fatalf("expected state Open after a failed probe, got %v", r.State("p1"))
+	}
+	if r.Allow("p1") {
+		t.Fatal("expected the reopened breaker to reject immediately")
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// TokenPair is an access+refresh token pair, mirroring services/api's
+// tokenPairResponse JSON shape.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// loginResult mirrors services/api's loginResponse, including the
+// two-factor fields so VerifyTwoFactor callers can detect a partial
+// login the same way the server's own clients do.
+type loginResult struct {
+	TokenPair
+	PartialToken      string `json:"partial_token,omitempty"`
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+}
+
+// LoginResult is the outcome of Login. If TwoFactorRequired is true,
+// AccessToken/RefreshToken are empty and PartialToken must be passed to
+// VerifyTwoFactor to complete the login.
+type LoginResult struct {
+	TokenPair
+	PartialToken      string
+	TwoFactorRequired bool
+}
+
+// Login authenticates against POST /api/auth/login. On success (and no
+// two-factor challenge), the returned token pair is also stored on the
+// client so subsequent Bearer-authenticated calls use it automatically.
+func (c *Client) Login(ctx context.Context, email, password string) (*LoginResult, error) {
+	req := map[string]string{"email": email, "password": password}
+	var resp loginResult
+	if err := c.do(ctx, http.MethodPost, "/api/auth/login", req, &resp, authNone); err != nil {
+		return nil, err
+	}
+
+	result := &LoginResult{TokenPair: resp.TokenPair, PartialToken: resp.PartialToken, TwoFactorRequired: resp.TwoFactorRequired}
+	if !result.TwoFactorRequired {
+		c.SetTokens(result.AccessToken, result.RefreshToken)
+	}
+	return result, nil
+}
+
+// VerifyTwoFactor upgrades the partial token from a two-factor-pending
+// Login into a full token pair via POST /api/auth/2fa/verify, storing
+// it on the client.
+func (c *Client) VerifyTwoFactor(ctx context.Context, partialToken, code string) (*TokenPair, error) {
+	req := map[string]string{"partial_token": partialToken, "code": code}
+	var resp TokenPair
+	if err := c.do(ctx, http.MethodPost, "/api/auth/2fa/verify", req, &resp, authNone); err != nil {
+		return nil, err
+	}
+	c.SetTokens(resp.AccessToken, resp.RefreshToken)
+	return &resp, nil
+}
+
+// RegisterResult is the outcome of Register: a token pair for the new
+// account plus the default API key onboarding provisions for it.
+type RegisterResult struct {
+	TokenPair
+	APIKey string `json:"api_key"`
+}
+
+// Register creates a new account via POST /api/auth/register and stores
+// the returned token pair on the client.
+func (c *Client) Register(ctx context.Context, email, password string) (*RegisterResult, error) {
+	req := map[string]string{"email": email, "password": password}
+	var resp RegisterResult
+	if err := c.do(ctx, http.MethodPost, "/api/auth/register", req, &resp, authNone); err != nil {
+		return nil, err
+	}
+	c.SetTokens(resp.AccessToken, resp.RefreshToken)
+	return &resp, nil
+}
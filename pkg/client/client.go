@@ -0,0 +1,296 @@
+// Package client is a typed Go SDK for the platform's customer-facing
+// REST API (services/api): authentication, proxy acquisition, API key
+// management, and usage stats retrieval. Requests that come back
+// rate-limited or with a transient server error are retried with
+// exponential backoff, honoring Retry-After/X-RateLimit-Reset response
+// headers when the server sends them.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMaxRetries is how many times a request is retried after a
+// rate-limited (429) or transient (5xx) response before do's caller
+// gets the error back.
+const DefaultMaxRetries = 3
+
+// DefaultTimeout bounds how long a single HTTP round trip may take when
+// Config.HTTPClient is left unset.
+const DefaultTimeout = 30 * time.Second
+
+// Config holds the settings for a Client.
+type Config struct {
+	// BaseURL is the platform API's base URL, e.g.
+	// "https://api.example.com". Required.
+	BaseURL string
+	// HTTPClient is the underlying HTTP client used for requests.
+	// Defaults to a client with a DefaultTimeout timeout.
+	HTTPClient *http.Client
+	// APIKey, if set, is sent as X-API-Key on calls that authenticate
+	// that way (currently just ListProxies). It can also be set later
+	// with SetAPIKey.
+	APIKey string
+	// MaxRetries is how many times a request is retried after a
+	// rate-limited or transient response. Defaults to
+	// DefaultMaxRetries; a negative value disables retries.
+	MaxRetries int
+}
+
+// Client is a typed client for the platform's customer-facing REST API.
+// It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	maxRetries int
+
+	mu           sync.RWMutex
+	apiKey       string
+	accessToken  string
+	refreshToken string
+}
+
+// New builds a Client from cfg.
+func New(cfg Config) *Client {
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultTimeout}
+	}
+	maxRetries := cfg.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	}
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		httpClient: httpClient,
+		maxRetries: maxRetries,
+		apiKey:     cfg.APIKey,
+	}
+}
+
+// SetAPIKey overrides the API key used for X-API-Key authenticated
+// calls.
+func (c *Client) SetAPIKey(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.apiKey = key
+}
+
+// SetTokens overrides the access/refresh token pair used for
+// Bearer-authenticated calls, e.g. after a caller refreshes them out of
+// band.
+func (c *Client) SetTokens(accessToken, refreshToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accessToken = accessToken
+	c.refreshToken = refreshToken
+}
+
+// AccessToken returns the access token currently held by the client, or
+// "" if Login/Register hasn't been called (and SetTokens hasn't been).
+func (c *Client) AccessToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.accessToken
+}
+
+// RefreshToken returns the refresh token currently held by the client.
+func (c *Client) RefreshToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.refreshToken
+}
+
+// authMode selects which credential a request is authenticated with.
+type authMode int
+
+const (
+	authNone authMode = iota
+	authBearer
+	authAPIKey
+)
+
+// APIError is returned when the platform API responds with a non-2xx
+// status. Message is the server's error message if it returned the
+// {code, message} JSON envelope pkg/apierrors writes, or the raw
+// response body otherwise, since not every handler in services/api
+// uses that envelope yet.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("client: %s (%s, status %d)", e.Message, e.Code, e.StatusCode)
+	}
+	return fmt.Sprintf("client: %s (status %d)", e.Message, e.StatusCode)
+}
+
+type errorEnvelope struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func parseAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Message: strings.TrimSpace(string(body))}
+	var envelope errorEnvelope
+	if json.Unmarshal(body, &envelope) == nil && envelope.Message != "" {
+		apiErr.Code = envelope.Code
+		apiErr.Message = envelope.Message
+	}
+	return apiErr
+}
+
+// do sends a request with the given auth mode, retrying on a
+// rate-limited or transient response, and decodes a JSON response body
+// into out (ignored if nil). reqBody, if non-nil, is marshaled as the
+// JSON request body.
+func (c *Client) do(ctx context.Context, method, path string, reqBody, out interface{}, mode authMode) error {
+	var payload []byte
+	if reqBody != nil {
+		var err error
+		payload, err = json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("client: failed to encode request body: %w", err)
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, path, payload, mode)
+		if err != nil {
+			if attempt == c.maxRetries {
+				return err
+			}
+			if waitErr := waitBackoff(ctx, nil, attempt); waitErr != nil {
+				return waitErr
+			}
+			continue
+		}
+
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			readErr = fmt.Errorf("client: failed to read response body: %w", readErr)
+			if attempt == c.maxRetries {
+				return readErr
+			}
+			if waitErr := waitBackoff(ctx, nil, attempt); waitErr != nil {
+				return waitErr
+			}
+			continue
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			if out == nil || len(body) == 0 {
+				return nil
+			}
+			if err := json.Unmarshal(body, out); err != nil {
+				return fmt.Errorf("client: failed to decode response body: %w", err)
+			}
+			return nil
+		}
+
+		apiErr := parseAPIError(resp.StatusCode, body)
+		if !retryable(resp.StatusCode) || attempt == c.maxRetries {
+			return apiErr
+		}
+		if waitErr := waitBackoff(ctx, resp, attempt); waitErr != nil {
+			return waitErr
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, path string, payload []byte, mode authMode) (*http.Response, error) {
+	var bodyReader io.Reader
+	if payload != nil {
+		bodyReader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to build request: %w", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	c.mu.RLock()
+	accessToken, apiKey := c.accessToken, c.apiKey
+	c.mu.RUnlock()
+
+	switch mode {
+	case authBearer:
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	case authAPIKey:
+		req.Header.Set("X-API-Key", apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("client: request failed: %w", err)
+	}
+	return resp, nil
+}
+
+// retryable reports whether a response status warrants a retry: a
+// rate-limit response, or a transient server error.
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// waitBackoff sleeps for retryDelay(resp, attempt), returning early
+// with ctx.Err() if ctx is canceled first.
+func waitBackoff(ctx context.Context, resp *http.Response, attempt int) error {
+	timer := time.NewTimer(retryDelay(resp, attempt))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryDelay computes how long to wait before the next attempt. It
+// honors the server's Retry-After header (seconds, the common case for
+// this kind of client) or X-RateLimit-Reset (Unix seconds) if present,
+// defensively, since services/api doesn't emit either today; otherwise
+// it falls back to exponential backoff with jitter, the same 1<<attempt
+// shape pkg/notify's outbox retry uses. resp is nil for a network-level
+// failure, which always falls back to the exponential case.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if resp != nil {
+		if v := resp.Header.Get("Retry-After"); v != "" {
+			if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+		if v := resp.Header.Get("X-RateLimit-Reset"); v != "" {
+			if unix, err := strconv.ParseInt(v, 10, 64); err == nil {
+				if delay := time.Until(time.Unix(unix, 0)); delay > 0 {
+					return delay
+				}
+			}
+		}
+	}
+
+	base := time.Duration(1<<uint(attempt)) * 200 * time.Millisecond
+	jitter := time.Duration(rand.Int63n(int64(base) + 1))
+	return base + jitter
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) (*Client, *httptest.Server) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return New(Config{BaseURL: srv.URL}), srv
+}
+
+func TestLoginStoresTokenPair(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/auth/login" || r.Method != http.MethodPost {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "at-1", "refresh_token": "rt-1"})
+	}))
+
+	result, err := c.Login(context.Background(), "user@example.com", "hunter2")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if result.AccessToken != "at-1" || result.RefreshToken != "rt-1" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if c.AccessToken() != "at-1" || c.RefreshToken() != "rt-1" {
+		t.Fatalf("expected tokens to be stored on client, got access=%q refresh=%q", c.AccessToken(), c.RefreshToken())
+	}
+}
+
+func TestLoginTwoFactorRequiredDoesNotStoreTokens(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"partial_token": "partial-1", "two_factor_required": true})
+	}))
+
+	result, err := c.Login(context.Background(), "user@example.com", "hunter2")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if !result.TwoFactorRequired || result.PartialToken != "partial-1" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if c.AccessToken() != "" {
+		t.Fatalf("expected no access token to be stored, got %q", c.AccessToken())
+	}
+}
+
+func TestKeyLifecycle(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer at-1" {
+			t.Fatalf("expected bearer auth, got %q", r.Header.Get("Authorization"))
+		}
+
+		switch {
+		case r.URL.Path == "/api/v1/keys" && r.Method == http.MethodPost:
+			var req CreateKeyParams
+			json.NewDecoder(r.Body).Decode(&req)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "key-1", "name": req.Name, "status": "active", "key": "raw-key-1"})
+		case r.URL.Path == "/api/v1/keys" && r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode([]map[string]string{{"id": "key-1", "name": "ci", "status": "active"}})
+		case r.URL.Path == "/api/v1/keys/key-1" && r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	c.SetTokens("at-1", "rt-1")
+
+	created, err := c.CreateKey(context.Background(), CreateKeyParams{Name: "ci"})
+	if err != nil {
+		t.Fatalf("CreateKey: %v", err)
+	}
+	if created.ID != "key-1" || created.RawKey != "raw-key-1" {
+		t.Fatalf("unexpected created key: %+v", created)
+	}
+
+	keys, err := c.ListKeys(context.Background())
+	if err != nil {
+		t.Fatalf("ListKeys: %v", err)
+	}
+	if len(keys) != 1 || keys[0].ID != "key-1" {
+		t.Fatalf("unexpected keys: %+v", keys)
+	}
+
+	if err := c.RevokeKey(context.Background(), "key-1"); err != nil {
+		t.Fatalf("RevokeKey: %v", err)
+	}
+}
+
+func TestListProxiesUsesAPIKeyAuth(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "key-123" {
+			t.Fatalf("expected X-API-Key header, got %q", r.Header.Get("X-API-Key"))
+		}
+		if got := r.URL.Query().Get("country"); got != "US" {
+			t.Fatalf("expected country=US, got %q", got)
+		}
+		json.NewEncoder(w).Encode([]map[string]interface{}{{"ID": "p1", "Host": "1.2.3.4", "Port": 8080}})
+	}))
+	c.SetAPIKey("key-123")
+
+	proxies, err := c.ListProxies(context.Background(), ListProxiesParams{Country: "US"})
+	if err != nil {
+		t.Fatalf("ListProxies: %v", err)
+	}
+	if len(proxies) != 1 || proxies[0].Host != "1.2.3.4" {
+		t.Fatalf("unexpected proxies: %+v", proxies)
+	}
+}
+
+func TestUsageSummary(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/usage/summary" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(UsageSummary{Today: UsageStats{RequestCount: 10}, Month: UsageStats{RequestCount: 200}})
+	}))
+	c.SetTokens("at-1", "rt-1")
+
+	summary, err := c.UsageSummary(context.Background())
+	if err != nil {
+		t.Fatalf("UsageSummary: %v", err)
+	}
+	if summary.Today.RequestCount != 10 || summary.Month.RequestCount != 200 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+}
+
+func TestRetriesOnRateLimitThenSucceeds(t *testing.T) {
+	attempts := 0
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(UsageSummary{})
+	}))
+	c.SetTokens("at-1", "rt-1")
+
+	if _, err := c.UsageSummary(context.Background()); err != nil {
+		t.Fatalf("UsageSummary: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestNonRetryableErrorSurfacesAPIError(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"code": "unauthenticated", "message": "authentication is required"})
+	}))
+
+	_, err := c.ListKeys(context.Background())
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusUnauthorized || apiErr.Code != "unauthenticated" {
+		t.Fatalf("unexpected error: %+v", apiErr)
+	}
+}
+
+func TestRetriesGiveUpAfterMaxRetries(t *testing.T) {
+	attempts := 0
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	c.maxRetries = 2
+	c.SetTokens("at-1", "rt-1")
+
+	_, err := c.UsageSummary(context.Background())
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("unexpected error: %+v", apiErr)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 1 initial attempt + 2 retries = 3, got %d", attempts)
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Key is the JSON shape returned for an API key the caller already has,
+// mirroring services/api/internal/handlers.keyView: it never carries
+// the raw key value, only a masked suffix.
+type Key struct {
+	ID                      string     `json:"id"`
+	Name                    string     `json:"name"`
+	Status                  string     `json:"status"`
+	Permissions             []string   `json:"permissions"`
+	Masked                  string     `json:"masked"`
+	ExpiresAt               *time.Time `json:"expires_at,omitempty"`
+	CreatedAt               time.Time  `json:"created_at,omitempty"`
+	RotationMode            string     `json:"rotation_mode"`
+	RotationIntervalSeconds int        `json:"rotation_interval_seconds,omitempty"`
+}
+
+// CreatedKey additionally carries the raw key value, shown exactly once
+// by CreateKey/RotateKey.
+type CreatedKey struct {
+	Key
+	RawKey string `json:"key"`
+}
+
+// CreateKeyParams is the request body for CreateKey.
+type CreateKeyParams struct {
+	Name                    string     `json:"name"`
+	Permissions             []string   `json:"permissions,omitempty"`
+	ExpiresAt               *time.Time `json:"expires_at,omitempty"`
+	RotationMode            string     `json:"rotation_mode,omitempty"`
+	RotationIntervalSeconds int        `json:"rotation_interval_seconds,omitempty"`
+}
+
+// ListKeys calls GET /api/v1/keys.
+func (c *Client) ListKeys(ctx context.Context) ([]Key, error) {
+	var keys []Key
+	if err := c.do(ctx, http.MethodGet, "/api/v1/keys", nil, &keys, authBearer); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// CreateKey calls POST /api/v1/keys.
+func (c *Client) CreateKey(ctx context.Context, params CreateKeyParams) (*CreatedKey, error) {
+	var created CreatedKey
+	if err := c.do(ctx, http.MethodPost, "/api/v1/keys", params, &created, authBearer); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// RevokeKey calls DELETE /api/v1/keys/{id}.
+func (c *Client) RevokeKey(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/keys/%s", id), nil, nil, authBearer)
+}
+
+// RotateKey calls POST /api/v1/keys/{id}/rotate.
+func (c *Client) RotateKey(ctx context.Context, id string) (*CreatedKey, error) {
+	var created CreatedKey
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/api/v1/keys/%s/rotate", id), nil, &created, authBearer); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
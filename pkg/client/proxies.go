@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// ListProxiesParams filters GET /api/v1/proxies the same way
+// services/api/internal/handlers.ProxyHandlers.List does; zero values
+// mean "don't filter on this". Count is clamped server-side to the
+// caller's plan limit.
+type ListProxiesParams struct {
+	Country      string
+	Protocol     proxy.Protocol
+	MinScore     float64
+	MaxLatencyMS int
+	Count        int
+}
+
+// ListProxies calls GET /api/v1/proxies, authenticating with the
+// client's API key.
+func (c *Client) ListProxies(ctx context.Context, params ListProxiesParams) ([]*proxy.Proxy, error) {
+	q := url.Values{}
+	if params.Country != "" {
+		q.Set("country", params.Country)
+	}
+	if params.Protocol != "" {
+		q.Set("protocol", string(params.Protocol))
+	}
+	if params.MinScore != 0 {
+		q.Set("min_score", strconv.FormatFloat(params.MinScore, 'f', -1, 64))
+	}
+	if params.MaxLatencyMS != 0 {
+		q.Set("max_latency_ms", strconv.Itoa(params.MaxLatencyMS))
+	}
+	if params.Count != 0 {
+		q.Set("count", strconv.Itoa(params.Count))
+	}
+
+	var proxies []*proxy.Proxy
+	path := "/api/v1/proxies"
+	if encoded := q.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+	if err := c.do(ctx, http.MethodGet, path, nil, &proxies, authAPIKey); err != nil {
+		return nil, err
+	}
+	return proxies, nil
+}
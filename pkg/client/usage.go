@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// UsageStats is one usage bucket (a day or a month), mirroring
+// services/api/internal/handlers.usageStatsView.
+type UsageStats struct {
+	RequestCount int64 `json:"request_count"`
+	BytesIn      int64 `json:"bytes_in"`
+	BytesOut     int64 `json:"bytes_out"`
+	ErrorCount   int64 `json:"error_count"`
+}
+
+// UsageSummary is the response from GET /api/v1/usage/summary.
+type UsageSummary struct {
+	Today UsageStats `json:"today"`
+	Month UsageStats `json:"month"`
+}
+
+// UsageSummary calls GET /api/v1/usage/summary.
+func (c *Client) UsageSummary(ctx context.Context) (*UsageSummary, error) {
+	var summary UsageSummary
+	if err := c.do(ctx, http.MethodGet, "/api/v1/usage/summary", nil, &summary, authBearer); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
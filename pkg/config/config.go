@@ -0,0 +1,54 @@
+// Package config holds the platform's hot-reloadable runtime settings:
+// knobs like log level, rate limits, security policy, and scoring
+// weights that operators should be able to tune without a restart.
+// Everything else (DSNs, ports, secrets) stays in environment variables
+// read once at startup, as the rest of the codebase already does;
+// config.Config is only for values worth changing live.
+package config
+
+import "time"
+
+// Security holds policy enforced at the edge, e.g. the admin API.
+type Security struct {
+	// AdminIPAllowlist restricts /api/admin/* to these CIDRs or IPs. An
+	// empty list means no IP restriction is enforced.
+	AdminIPAllowlist []string `json:"admin_ip_allowlist"`
+}
+
+// Config is the full set of hot-reloadable settings, loaded as JSON.
+type Config struct {
+	// LogLevel is one of "debug", "info", "warn", "error".
+	LogLevel string `json:"log_level"`
+	// RateLimitPerMinute caps requests per API key per minute; zero
+	// disables the limit.
+	RateLimitPerMinute int `json:"rate_limit_per_minute"`
+	// Security holds edge-enforcement policy.
+	Security Security `json:"security"`
+	// ScorerWeights are the named weights proxy-pool's scorer combines
+	// into a proxy's overall score. A name absent from the map keeps
+	// its built-in default; see scorer.WeightsFromMap.
+	ScorerWeights map[string]float64 `json:"scorer_weights"`
+	// ScorerLatencyCeilingMS is the latency, in milliseconds, at or
+	// above which the scorer's latency component bottoms out at zero.
+	// Zero or negative uses the scorer's built-in default.
+	ScorerLatencyCeilingMS int `json:"scorer_latency_ceiling_ms"`
+	// ScorerDecayHalfLifeSeconds is how long, in seconds, it takes a
+	// proxy's score to fade to half its freshly-checked value once it
+	// stops being rechecked. Zero disables decay.
+	ScorerDecayHalfLifeSeconds int `json:"scorer_decay_half_life_seconds"`
+}
+
+// Default returns the settings used when no config file is present, so
+// every service has sane behavior before an operator drops one in.
+func Default() *Config {
+	return &Config{
+		LogLevel:           "info",
+		RateLimitPerMinute: 0,
+		ScorerWeights:      map[string]float64{},
+	}
+}
+
+// defaultDebounce is how long the watcher waits after a write event
+// before reloading, so a multi-write editor save doesn't trigger
+// several reloads in a row.
+const defaultDebounce = 200 * time.Millisecond
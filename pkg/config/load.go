@@ -0,0 +1,33 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// fileName is the config file LoadFromDir looks for within dir.
+const fileName = "config.json"
+
+// LoadFromDir reads dir/config.json and returns it merged over Default,
+// so a partial file only overrides the settings it sets. A missing file
+// is not an error: it returns Default unchanged, since a freshly
+// deployed service may not have one yet.
+func LoadFromDir(dir string) (*Config, error) {
+	cfg := Default()
+
+	path := filepath.Join(dir, fileName)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: read %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("config: parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFromDirMissingFileReturnsDefault(t *testing.T) {
+	cfg, err := LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadFromDir() error = %v", err)
+	}
+	if cfg.LogLevel != Default().LogLevel {
+		t.Errorf("LogLevel = %q, want default %q", cfg.LogLevel, Default().LogLevel)
+	}
+}
+
+func TestLoadFromDirMergesOverDefault(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, `{"log_level": "debug"}`)
+
+	cfg, err := LoadFromDir(dir)
+	if err != nil {
+		t.Fatalf("LoadFromDir() error = %v", err)
+	}
+	if cfg.LogLevel != "debug" {
+		t.Errorf("LogLevel = %q, want %q", cfg.LogLevel, "debug")
+	}
+	if cfg.RateLimitPerMinute != Default().RateLimitPerMinute {
+		t.Errorf("RateLimitPerMinute = %d, want untouched default %d", cfg.RateLimitPerMinute, Default().RateLimitPerMinute)
+	}
+}
+
+func TestLoadFromDirRejectsInvalidJSON(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, `not json`)
+
+	if _, err := LoadFromDir(dir); err == nil {
+		t.Fatal("LoadFromDir() error = nil, want an error for invalid JSON")
+	}
+}
+
+func write(t *testing.T, dir, contents string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, fileName), []byte(contents), 0o644); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+}
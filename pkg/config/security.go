@@ -0,0 +1,47 @@
+package config
+
+import "net/netip"
+
+// Allows reports whether ip (a bare IPv4 or IPv6 address, no port) is
+// permitted by AdminIPAllowlist. An empty allowlist permits everything,
+// matching its documented "no IP restriction enforced" default. Entries
+// may be a bare address (matched exactly, across families via
+// netip.Addr's normal comparison) or a CIDR ("10.0.0.0/8",
+// "2001:db8::/32"); a malformed entry is skipped rather than failing
+// the whole check, since one bad entry in an operator-edited list
+// shouldn't lock everyone out.
+func (s Security) Allows(ip string) bool {
+	if len(s.AdminIPAllowlist) == 0 {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+
+	for _, entry := range s.AdminIPAllowlist {
+		prefix, err := parseAllowlistEntry(entry)
+		if err != nil {
+			continue
+		}
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAllowlistEntry accepts either a bare IP (treated as a
+// single-address prefix) or a CIDR range.
+func parseAllowlistEntry(entry string) (netip.Prefix, error) {
+	if prefix, err := netip.ParsePrefix(entry); err == nil {
+		return prefix, nil
+	}
+
+	addr, err := netip.ParseAddr(entry)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
@@ -0,0 +1,57 @@
+package config
+
+import "testing"
+
+func TestSecurityAllowsEmptyListPermitsEverything(t *testing.T) {
+	s := Security{}
+	if !s.Allows("203.0.113.5") {
+		t.Error("Allows() = false, want true for an empty allowlist")
+	}
+}
+
+func TestSecurityAllowsExactMatch(t *testing.T) {
+	s := Security{AdminIPAllowlist: []string{"203.0.113.5"}}
+	if !s.Allows("203.0.113.5") {
+		t.Error("Allows() = false, want true for a listed exact IP")
+	}
+	if s.Allows("203.0.113.6") {
+		t.Error("Allows() = true, want false for an unlisted IP")
+	}
+}
+
+func TestSecurityAllowsCIDRMatch(t *testing.T) {
+	s := Security{AdminIPAllowlist: []string{"10.0.0.0/8"}}
+	if !s.Allows("10.1.2.3") {
+		t.Error("Allows() = false, want true for an IP inside the CIDR")
+	}
+	if s.Allows("11.0.0.1") {
+		t.Error("Allows() = true, want false for an IP outside the CIDR")
+	}
+}
+
+func TestSecurityAllowsIPv6(t *testing.T) {
+	s := Security{AdminIPAllowlist: []string{"2001:db8::/32"}}
+	if !s.Allows("2001:db8::1") {
+		t.Error("Allows() = false, want true for an IPv6 address inside the CIDR")
+	}
+	if s.Allows("2001:db9::1") {
+		t.Error("Allows() = true, want false for an IPv6 address outside the CIDR")
+	}
+}
+
+func TestSecurityAllowsSkipsMalformedEntries(t *testing.T) {
+	s := Security{AdminIPAllowlist: []string{"not-an-ip", "203.0.113.5"}}
+	if !s.Allows("203.0.113.5") {
+		t.Error("Allows() = false, want true: a malformed entry shouldn't block matching on a later valid one")
+	}
+	if s.Allows("203.0.113.6") {
+		t.Error("Allows() = true, want false for an IP matching neither entry")
+	}
+}
+
+func TestSecurityAllowsRejectsUnparseableIP(t *testing.T) {
+	s := Security{AdminIPAllowlist: []string{"203.0.113.5"}}
+	if s.Allows("not-an-ip") {
+		t.Error("Allows() = true, want false for an unparseable client IP")
+	}
+}
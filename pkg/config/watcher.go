@@ -0,0 +1,125 @@
+package config
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watcher reloads a directory's config.json on change — either a
+// filesystem write caught by fsnotify, or a manual call to Reload from
+// an admin endpoint — and notifies subscribers registered via OnChange,
+// mirroring the callback-registry pattern pkg/loadshed.Shedder uses for
+// its own state transitions.
+type Watcher struct {
+	dir string
+
+	current atomic.Pointer[Config]
+
+	hooksMu sync.Mutex
+	hooks   []func(*Config)
+
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// NewWatcher loads dir's current config and starts watching it for
+// changes. Callers should defer Close.
+func NewWatcher(dir string) (*Watcher, error) {
+	cfg, err := LoadFromDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := fsw.Add(dir); err != nil {
+		fsw.Close()
+		return nil, err
+	}
+
+	w := &Watcher{dir: dir, watcher: fsw, done: make(chan struct{})}
+	w.current.Store(cfg)
+
+	go w.run()
+	return w, nil
+}
+
+// Get returns the most recently loaded config. The returned value is
+// never mutated in place, so callers can hold onto it safely.
+func (w *Watcher) Get() *Config {
+	return w.current.Load()
+}
+
+// OnChange registers fn to run, with the newly loaded config, every
+// time Reload (directly or via a filesystem event) picks up a change.
+// fn is not called for the config loaded at construction time.
+func (w *Watcher) OnChange(fn func(*Config)) {
+	w.hooksMu.Lock()
+	defer w.hooksMu.Unlock()
+	w.hooks = append(w.hooks, fn)
+}
+
+// Reload re-reads the config file immediately, notifying subscribers.
+// It's what the admin reload endpoint calls, and what the filesystem
+// watch loop calls internally on a debounced write event.
+func (w *Watcher) Reload() (*Config, error) {
+	cfg, err := LoadFromDir(w.dir)
+	if err != nil {
+		return nil, err
+	}
+	w.current.Store(cfg)
+
+	w.hooksMu.Lock()
+	hooks := append([]func(*Config){}, w.hooks...)
+	w.hooksMu.Unlock()
+
+	for _, hook := range hooks {
+		hook(cfg)
+	}
+	return cfg, nil
+}
+
+// Close stops the filesystem watch goroutine.
+func (w *Watcher) Close() error {
+	close(w.done)
+	return w.watcher.Close()
+}
+
+func (w *Watcher) run() {
+	var debounce *time.Timer
+	for {
+		select {
+		case <-w.done:
+			if debounce != nil {
+				debounce.Stop()
+			}
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+				continue
+			}
+			if debounce != nil {
+				debounce.Stop()
+			}
+			debounce = time.AfterFunc(defaultDebounce, func() {
+				if _, err := w.Reload(); err != nil {
+					log.Printf("config: reload after filesystem change failed: %v", err)
+				}
+			})
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("config: watch error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// ProxyHealthCheck is a single recorded probe result against a proxy.
+type ProxyHealthCheck struct {
+	ID      int64
+	ProxyID string
+	// GatewayID identifies the gateway that observed this check, for
+	// checks recorded from live request outcomes rather than the
+	// centralized health-check sweep (which always leaves this blank,
+	// having no particular vantage point of its own).
+	GatewayID string
+	Protocol  string
+	Success   bool
+	LatencyMS int
+	Error     string
+	CheckedAt time.Time
+}
+
+// ProxyHealthCheckDAO persists individual probe results to MySQL, giving
+// operators a per-proxy history beyond the rolled-up latency/success-rate
+// fields on the proxies table itself.
+type ProxyHealthCheckDAO struct {
+	db *sql.DB
+}
+
+// NewProxyHealthCheckDAO wraps an existing *sql.DB handle.
+func NewProxyHealthCheckDAO(db *sql.DB) *ProxyHealthCheckDAO {
+	return &ProxyHealthCheckDAO{db: db}
+}
+
+// Insert records the outcome of a single probe.
+func (d *ProxyHealthCheckDAO) Insert(ctx context.Context, check *ProxyHealthCheck) error {
+	_, err := d.db.ExecContext(ctx, `
+		INSERT INTO proxy_health_checks (proxy_id, gateway_id, protocol, success, latency_ms, error, checked_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		check.ProxyID, check.GatewayID, check.Protocol, check.Success, check.LatencyMS, check.Error, check.CheckedAt)
+	return err
+}
+
+// DailySuccessRate summarizes, for each day in [start, end), the number
+// of checks run and how many succeeded, across all proxies. This backs
+// the admin dashboard's health-check success trend.
+type DailySuccessRate struct {
+	Date         time.Time
+	TotalChecks  int64
+	SuccessCount int64
+}
+
+// DailySuccessRateTrend returns the daily check volume and success
+// count for [start, end), ordered by day.
+func (d *ProxyHealthCheckDAO) DailySuccessRateTrend(ctx context.Context, start, end time.Time) ([]DailySuccessRate, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT DATE(checked_at), COUNT(*), SUM(CASE WHEN success THEN 1 ELSE 0 END)
+		  FROM proxy_health_checks
+		 WHERE checked_at >= ? AND checked_at < ?
+		 GROUP BY DATE(checked_at)
+		 ORDER BY DATE(checked_at)`, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []DailySuccessRate
+	for rows.Next() {
+		var r DailySuccessRate
+		if err := rows.Scan(&r.Date, &r.TotalChecks, &r.SuccessCount); err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// DeleteOldChecks hard-deletes up to limit check records older than
+// cutoff, returning how many rows it removed. Callers loop on this to
+// work through a large backlog in limit-sized chunks instead of one
+// long-running DELETE, since this table grows unbounded with every
+// health-check sweep.
+func (d *ProxyHealthCheckDAO) DeleteOldChecks(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	result, err := d.db.ExecContext(ctx,
+		`DELETE FROM proxy_health_checks WHERE checked_at < ? LIMIT ?`, cutoff, limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,958 @@
+// Package dao contains the data-access layer backed by MySQL, the
+// platform's system of record for proxies, users and usage data.
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+const proxyColumns = "id, host, port, protocol, status, score, source, country, city, asn, provider, reputation_score, exit_ip, duplicate_of, blacklisted, pool_id, stage, stage_changed_at, probation_checks"
+
+// MaxConsecutiveFailures is the number of consecutive failed health
+// checks after which a proxy is automatically deactivated.
+const MaxConsecutiveFailures = 5
+
+// ProxyDAOInterface is the subset of ProxyDAO's behavior that service and
+// handler code depends on. It exists so those layers can be unit-tested
+// against daofake's in-memory fake instead of a real MySQL connection;
+// see pkg/daofake's conformance suite, which every implementation
+// (ProxyDAO included) must pass.
+type ProxyDAOInterface interface {
+	Get(ctx context.Context, id string) (*proxy.Proxy, error)
+	GetByIPPort(ctx context.Context, host string, port int) (*proxy.Proxy, error)
+	ListHealthy(ctx context.Context) ([]*proxy.Proxy, error)
+	ListActive(ctx context.Context) ([]*proxy.Proxy, error)
+	CountActiveBySource(ctx context.Context) (map[proxy.Source]int64, error)
+	CountActiveByCountry(ctx context.Context) (map[string]int64, error)
+	CountActiveByProvider(ctx context.Context) (map[string]int64, error)
+	ListMissingGeo(ctx context.Context) ([]*proxy.Proxy, error)
+	UpdateGeo(ctx context.Context, id, country, city string, asn int) error
+	UpdateLatency(ctx context.Context, id string, latencyMS int) error
+	UpdateSuccessRate(ctx context.Context, id string, rate float64) error
+	UpdateAnonymityLevel(ctx context.Context, id string, level proxy.AnonymityLevel) error
+	UpdateReputation(ctx context.Context, id string, score float64, blacklisted bool) error
+	UpdateExitIP(ctx context.Context, id, exitIP string) error
+	FlagDuplicate(ctx context.Context, id, canonicalID string) error
+	ClearDuplicate(ctx context.Context, id string) error
+	GroupByExitIP(ctx context.Context) ([]ExitIPGroup, error)
+	UpdateScore(ctx context.Context, id string, score float64) error
+	AssignPool(ctx context.Context, id, poolID string) error
+	ListByPool(ctx context.Context, poolID string) ([]*proxy.Proxy, error)
+	MarkAsChecked(ctx context.Context, id string, success bool, checkedAt time.Time) (deactivated bool, err error)
+	IncrementProbationChecks(ctx context.Context, id string, success bool) (count int, err error)
+	TransitionStage(ctx context.Context, id string, to proxy.Stage, reason string) error
+	StageHistory(ctx context.Context, proxyID string) ([]*ProxyStageTransition, error)
+	BulkUpsert(ctx context.Context, proxies []*proxy.Proxy) (inserted, updated int, err error)
+	SoftDelete(ctx context.Context, id string) error
+	PurgeDeleted(ctx context.Context, cutoff time.Time, limit int) (int64, error)
+	Create(ctx context.Context, p *proxy.Proxy) error
+	SetStatus(ctx context.Context, id string, status proxy.Status) error
+	Search(ctx context.Context, filter ProxyFilter) (proxies []*proxy.Proxy, total int64, nextCursor string, err error)
+	GetByGeo(ctx context.Context, filter GeoFilter) ([]*proxy.Proxy, error)
+}
+
+// ProxyDAO reads and writes the canonical `proxies` table in MySQL.
+type ProxyDAO struct {
+	db *sql.DB
+}
+
+var _ ProxyDAOInterface = (*ProxyDAO)(nil)
+
+// NewProxyDAO wraps an existing *sql.DB handle.
+func NewProxyDAO(db *sql.DB) *ProxyDAO {
+	return &ProxyDAO{db: db}
+}
+
+// Get loads a single proxy by ID.
+func (d *ProxyDAO) Get(ctx context.Context, id string) (*proxy.Proxy, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT `+proxyColumns+` FROM proxies WHERE id = ?`, id)
+	return scanProxy(row)
+}
+
+// GetByIPPort looks up a proxy by its host and port, used by the crawler
+// pipelines to dedup a freshly discovered candidate against what is
+// already known.
+func (d *ProxyDAO) GetByIPPort(ctx context.Context, host string, port int) (*proxy.Proxy, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT `+proxyColumns+` FROM proxies WHERE host = ? AND port = ?`, host, port)
+	return scanProxy(row)
+}
+
+// ListHealthy returns every proxy whose status is "healthy" and whose
+// stage isn't "probation", i.e. the set that should be present in the
+// Redis hot state. A probationary proxy can be perfectly healthy and
+// still excluded here: it hasn't yet earned unrestricted traffic, so it
+// stays out of the hot set and is only ever reached through the
+// probation-sampling path in proxy-pool's Acquire.
+func (d *ProxyDAO) ListHealthy(ctx context.Context) ([]*proxy.Proxy, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT `+proxyColumns+` FROM proxies WHERE status = ? AND stage != ?`,
+		string(proxy.StatusHealthy), string(proxy.StageProbation))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*proxy.Proxy
+	for rows.Next() {
+		p, err := scanProxy(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// ListActive returns every proxy eligible for health checking, i.e. not
+// already dead or banned.
+func (d *ProxyDAO) ListActive(ctx context.Context) ([]*proxy.Proxy, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT `+proxyColumns+` FROM proxies WHERE status IN (?, ?)`,
+		string(proxy.StatusPending), string(proxy.StatusHealthy))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*proxy.Proxy
+	for rows.Next() {
+		p, err := scanProxy(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// CountActiveBySource returns the number of active (pending or healthy)
+// proxies for each Source, keyed by source. This backs the admin
+// dashboard's proxy-inventory breakdown.
+func (d *ProxyDAO) CountActiveBySource(ctx context.Context) (map[proxy.Source]int64, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT source, COUNT(*) FROM proxies WHERE status IN (?, ?) GROUP BY source`,
+		string(proxy.StatusPending), string(proxy.StatusHealthy))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[proxy.Source]int64)
+	for rows.Next() {
+		var source string
+		var count int64
+		if err := rows.Scan(&source, &count); err != nil {
+			return nil, err
+		}
+		counts[proxy.Source(source)] = count
+	}
+	return counts, rows.Err()
+}
+
+// CountActiveByCountry returns the number of active (pending or
+// healthy) proxies for each country, keyed by ISO country code. Proxies
+// with no country yet assigned are keyed under the empty string.
+func (d *ProxyDAO) CountActiveByCountry(ctx context.Context) (map[string]int64, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT country, COUNT(*) FROM proxies WHERE status IN (?, ?) GROUP BY country`,
+		string(proxy.StatusPending), string(proxy.StatusHealthy))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var country string
+		var count int64
+		if err := rows.Scan(&country, &count); err != nil {
+			return nil, err
+		}
+		counts[country] = count
+	}
+	return counts, rows.Err()
+}
+
+// CountActiveByProvider returns the number of active (pending or
+// healthy) proxies for each provider, keyed by provider name. Proxies
+// with no provider (e.g. SourceFree) are keyed under the empty string.
+// pkg/stats uses this alongside GroupByExitIP to report what fraction of
+// each provider's inventory turned out to be a reseller duplicate of
+// another provider's exit.
+func (d *ProxyDAO) CountActiveByProvider(ctx context.Context) (map[string]int64, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT provider, COUNT(*) FROM proxies WHERE status IN (?, ?) GROUP BY provider`,
+		string(proxy.StatusPending), string(proxy.StatusHealthy))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var provider string
+		var count int64
+		if err := rows.Scan(&provider, &count); err != nil {
+			return nil, err
+		}
+		counts[provider] = count
+	}
+	return counts, rows.Err()
+}
+
+// ListMissingGeo returns active proxies that have not yet been enriched
+// with a country, for the geo enrichment worker to backfill.
+func (d *ProxyDAO) ListMissingGeo(ctx context.Context) ([]*proxy.Proxy, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT `+proxyColumns+` FROM proxies WHERE country = '' AND status IN (?, ?)`,
+		string(proxy.StatusPending), string(proxy.StatusHealthy))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*proxy.Proxy
+	for rows.Next() {
+		p, err := scanProxy(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// UpdateGeo records a proxy's GeoIP enrichment: country, city and ASN.
+func (d *ProxyDAO) UpdateGeo(ctx context.Context, id, country, city string, asn int) error {
+	_, err := d.db.ExecContext(ctx,
+		`UPDATE proxies SET country = ?, city = ?, asn = ? WHERE id = ?`, country, city, asn, id)
+	return err
+}
+
+// UpdateLatency records the most recent probe latency for a proxy.
+func (d *ProxyDAO) UpdateLatency(ctx context.Context, id string, latencyMS int) error {
+	_, err := d.db.ExecContext(ctx, `UPDATE proxies SET latency_ms = ? WHERE id = ?`, latencyMS, id)
+	return err
+}
+
+// UpdateSuccessRate records a proxy's rolling health-check success rate,
+// a value in [0, 1].
+func (d *ProxyDAO) UpdateSuccessRate(ctx context.Context, id string, rate float64) error {
+	_, err := d.db.ExecContext(ctx, `UPDATE proxies SET success_rate = ? WHERE id = ?`, rate, id)
+	return err
+}
+
+// UpdateAnonymityLevel records a proxy's most recently detected
+// anonymity level.
+func (d *ProxyDAO) UpdateAnonymityLevel(ctx context.Context, id string, level proxy.AnonymityLevel) error {
+	_, err := d.db.ExecContext(ctx, `UPDATE proxies SET anonymity_level = ? WHERE id = ?`, string(level), id)
+	return err
+}
+
+// UpdateReputation records a proxy's most recently computed DNSBL/
+// reputation-API standing: the fraction of sources that listed it, and
+// whether that crossed the checker's listing threshold.
+func (d *ProxyDAO) UpdateReputation(ctx context.Context, id string, score float64, blacklisted bool) error {
+	_, err := d.db.ExecContext(ctx,
+		`UPDATE proxies SET reputation_score = ?, blacklisted = ? WHERE id = ?`, score, blacklisted, id)
+	return err
+}
+
+// UpdateExitIP records the external IP a judge-endpoint probe most
+// recently saw a proxy egress traffic from; see
+// services/proxy-pool/internal/dedup, which reads this column back
+// across every proxy to find cross-provider duplicates.
+func (d *ProxyDAO) UpdateExitIP(ctx context.Context, id, exitIP string) error {
+	_, err := d.db.ExecContext(ctx, `UPDATE proxies SET exit_ip = ? WHERE id = ?`, exitIP, id)
+	return err
+}
+
+// UpdateScore records a proxy's most recently computed quality score.
+// AssignPool moves a proxy into the named pool, or unassigns it if
+// poolID is "".
+func (d *ProxyDAO) AssignPool(ctx context.Context, id, poolID string) error {
+	_, err := d.db.ExecContext(ctx, `UPDATE proxies SET pool_id = ? WHERE id = ?`, poolID, id)
+	return err
+}
+
+// ListByPool returns every proxy assigned to poolID, highest score
+// first, for the pool manager to apply MaxProxies/MinQualityScore
+// against.
+func (d *ProxyDAO) ListByPool(ctx context.Context, poolID string) ([]*proxy.Proxy, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT `+proxyColumns+` FROM proxies WHERE pool_id = ? ORDER BY score DESC`, poolID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*proxy.Proxy
+	for rows.Next() {
+		p, err := scanProxy(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+func (d *ProxyDAO) UpdateScore(ctx context.Context, id string, score float64) error {
+	_, err := d.db.ExecContext(ctx, `UPDATE proxies SET score = ? WHERE id = ?`, score, id)
+	return err
+}
+
+// MarkAsChecked records that a proxy was just probed, updating its
+// consecutive-failure streak and timestamps. Once the streak reaches
+// MaxConsecutiveFailures, the proxy's status is flipped to dead so it
+// drops out of ListHealthy (and, via the reconciler, out of the Redis hot
+// set) without operator intervention. It returns whether this check
+// caused the proxy to be deactivated.
+func (d *ProxyDAO) MarkAsChecked(ctx context.Context, id string, success bool, checkedAt time.Time) (deactivated bool, err error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var consecutiveFailures int
+	var status string
+	if err := tx.QueryRowContext(ctx, `SELECT consecutive_failures, status FROM proxies WHERE id = ? FOR UPDATE`, id).
+		Scan(&consecutiveFailures, &status); err != nil {
+		return false, err
+	}
+
+	if success {
+		consecutiveFailures = 0
+		status = string(proxy.StatusHealthy)
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE proxies SET consecutive_failures = ?, status = ?, last_checked_at = ?, last_success_at = ? WHERE id = ?`,
+			consecutiveFailures, status, checkedAt, checkedAt, id); err != nil {
+			return false, err
+		}
+	} else {
+		consecutiveFailures++
+		if consecutiveFailures >= MaxConsecutiveFailures {
+			status = string(proxy.StatusDead)
+			deactivated = true
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE proxies SET consecutive_failures = ?, status = ?, last_checked_at = ? WHERE id = ?`,
+			consecutiveFailures, status, checkedAt, id); err != nil {
+			return false, err
+		}
+	}
+
+	return deactivated, tx.Commit()
+}
+
+// ProxyStageTransition is one row of a proxy's stage-change history, as
+// recorded by TransitionStage.
+type ProxyStageTransition struct {
+	ID        string
+	ProxyID   string
+	FromStage proxy.Stage
+	ToStage   proxy.Stage
+	Reason    string
+	CreatedAt time.Time
+}
+
+// IncrementProbationChecks records one more consecutive successful
+// check for a proxy on probation and returns its new count, or resets
+// the count to 0 if success is false. It is a no-op (but still returns
+// the current count) for a proxy that isn't in StageProbation, since
+// only probation cares about this counter.
+func (d *ProxyDAO) IncrementProbationChecks(ctx context.Context, id string, success bool) (count int, err error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var stage string
+	var checks int
+	if err := tx.QueryRowContext(ctx, `SELECT stage, probation_checks FROM proxies WHERE id = ? FOR UPDATE`, id).
+		Scan(&stage, &checks); err != nil {
+		return 0, err
+	}
+	if proxy.Stage(stage) != proxy.StageProbation {
+		return checks, tx.Commit()
+	}
+
+	if success {
+		checks++
+	} else {
+		checks = 0
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE proxies SET probation_checks = ? WHERE id = ?`, checks, id); err != nil {
+		return 0, err
+	}
+	return checks, tx.Commit()
+}
+
+// TransitionStage moves a proxy to a new lifecycle stage and appends a
+// row to proxy_stage_transitions recording why, so an operator can see
+// how a proxy arrived at its current stage. Moving into StageProbation
+// resets probation_checks so a demoted proxy has to re-earn its way
+// back out from zero.
+func (d *ProxyDAO) TransitionStage(ctx context.Context, id string, to proxy.Stage, reason string) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var from string
+	if err := tx.QueryRowContext(ctx, `SELECT stage FROM proxies WHERE id = ? FOR UPDATE`, id).Scan(&from); err != nil {
+		return err
+	}
+
+	probationChecks := "probation_checks"
+	if to == proxy.StageProbation {
+		probationChecks = "0"
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE proxies SET stage = ?, stage_changed_at = ?, probation_checks = `+probationChecks+` WHERE id = ?`,
+		string(to), time.Now().UTC(), id); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO proxy_stage_transitions (id, proxy_id, from_stage, to_stage, reason) VALUES (?, ?, ?, ?, ?)`,
+		uuid.NewString(), id, from, string(to), reason); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// StageHistory returns a proxy's stage transitions, most recent first.
+func (d *ProxyDAO) StageHistory(ctx context.Context, proxyID string) ([]*ProxyStageTransition, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT id, proxy_id, from_stage, to_stage, reason, created_at FROM proxy_stage_transitions WHERE proxy_id = ? ORDER BY created_at DESC`,
+		proxyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*ProxyStageTransition
+	for rows.Next() {
+		t := &ProxyStageTransition{}
+		var from, to string
+		if err := rows.Scan(&t.ID, &t.ProxyID, &from, &to, &t.Reason, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		t.FromStage = proxy.Stage(from)
+		t.ToStage = proxy.Stage(to)
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// FlagDuplicate marks id as an exit-IP duplicate of canonicalID, so
+// selection and inventory reporting can treat it as a reseller alias
+// rather than an independent exit. canonicalID is the proxy the dedup
+// worker chose to keep as the group's representative, typically its
+// earliest-seen or highest-scoring member.
+func (d *ProxyDAO) FlagDuplicate(ctx context.Context, id, canonicalID string) error {
+	_, err := d.db.ExecContext(ctx, `UPDATE proxies SET duplicate_of = ? WHERE id = ?`, canonicalID, id)
+	return err
+}
+
+// ClearDuplicate un-flags id, e.g. once its exit IP no longer matches
+// the proxy it was previously flagged against.
+func (d *ProxyDAO) ClearDuplicate(ctx context.Context, id string) error {
+	_, err := d.db.ExecContext(ctx, `UPDATE proxies SET duplicate_of = NULL WHERE id = ?`, id)
+	return err
+}
+
+// ExitIPMember is one proxy seen at an ExitIPGroup's shared exit IP.
+type ExitIPMember struct {
+	ID       string
+	Provider string
+	Score    float64
+}
+
+// ExitIPGroup is the set of proxies observed sharing one exit IP across
+// two or more distinct providers.
+type ExitIPGroup struct {
+	ExitIP  string
+	Members []ExitIPMember
+}
+
+// GroupByExitIP returns every exit IP currently shared by proxies from
+// two or more distinct providers, with each member's ID, provider and
+// score. This is the raw material services/proxy-pool/internal/dedup
+// groups into duplicate flags and pkg/stats turns into per-provider
+// overlap percentages.
+func (d *ProxyDAO) GroupByExitIP(ctx context.Context) ([]ExitIPGroup, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT exit_ip, id, provider, score FROM proxies WHERE exit_ip != '' AND provider != '' ORDER BY exit_ip`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byExitIP := make(map[string][]ExitIPMember)
+	var order []string
+	for rows.Next() {
+		var exitIP string
+		var m ExitIPMember
+		if err := rows.Scan(&exitIP, &m.ID, &m.Provider, &m.Score); err != nil {
+			return nil, err
+		}
+		if _, seen := byExitIP[exitIP]; !seen {
+			order = append(order, exitIP)
+		}
+		byExitIP[exitIP] = append(byExitIP[exitIP], m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var groups []ExitIPGroup
+	for _, exitIP := range order {
+		members := byExitIP[exitIP]
+		providers := make(map[string]struct{}, len(members))
+		for _, m := range members {
+			providers[m.Provider] = struct{}{}
+		}
+		if len(providers) < 2 {
+			continue
+		}
+		groups = append(groups, ExitIPGroup{ExitIP: exitIP, Members: members})
+	}
+	return groups, nil
+}
+
+// bulkUpsertChunkSize caps how many rows go into a single INSERT ...
+// ON DUPLICATE KEY UPDATE transaction, so a crawler run importing many
+// thousands of candidates doesn't hold one giant transaction open.
+const bulkUpsertChunkSize = 500
+
+// BulkUpsert inserts newly discovered proxies, or refreshes the
+// protocol/status/source/geo/provider fields of ones that already exist
+// at the same host:port (touching updated_at, which doubles as a
+// "last seen" marker). It is used by the crawler pipelines to import a
+// batch of candidates, chunked into bulkUpsertChunkSize-sized
+// transactions so large runs don't hold one giant transaction open. It
+// returns how many rows were newly inserted versus how many already
+// existed and were updated.
+func (d *ProxyDAO) BulkUpsert(ctx context.Context, proxies []*proxy.Proxy) (inserted, updated int, err error) {
+	for start := 0; start < len(proxies); start += bulkUpsertChunkSize {
+		end := start + bulkUpsertChunkSize
+		if end > len(proxies) {
+			end = len(proxies)
+		}
+		chunkInserted, chunkUpdated, err := d.bulkUpsertChunk(ctx, proxies[start:end])
+		if err != nil {
+			return inserted, updated, err
+		}
+		inserted += chunkInserted
+		updated += chunkUpdated
+	}
+	return inserted, updated, nil
+}
+
+func (d *ProxyDAO) bulkUpsertChunk(ctx context.Context, proxies []*proxy.Proxy) (inserted, updated int, err error) {
+	if len(proxies) == 0 {
+		return 0, 0, nil
+	}
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO proxies (id, host, port, protocol, status, score, source, country, city, asn, provider, stage)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			protocol   = VALUES(protocol),
+			status     = VALUES(status),
+			source     = VALUES(source),
+			country    = VALUES(country),
+			city       = VALUES(city),
+			asn        = VALUES(asn),
+			provider   = VALUES(provider),
+			updated_at = CURRENT_TIMESTAMP`)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer stmt.Close()
+
+	for _, p := range proxies {
+		if p.ID == "" {
+			p.ID = uuid.NewString()
+		}
+		if p.Stage == "" {
+			// A newly discovered proxy hasn't earned traffic yet; an
+			// existing one (this is an ON DUPLICATE KEY UPDATE, so
+			// "stage" is deliberately absent from the update clause
+			// above) keeps whatever stage it's already in.
+			p.Stage = proxy.StageProbation
+		}
+		result, err := stmt.ExecContext(ctx, p.ID, p.Host, p.Port, string(p.Protocol), string(p.Status), p.Score, string(p.Source), p.Country, p.City, p.ASN, p.Provider, string(p.Stage))
+		if err != nil {
+			return 0, 0, fmt.Errorf("dao: bulk upsert proxy %s:%d: %w", p.Host, p.Port, err)
+		}
+		// MySQL reports 1 row affected for an INSERT and 2 for a row
+		// that hit ON DUPLICATE KEY UPDATE, letting us tell the two
+		// apart without a separate lookup per row.
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, 0, err
+		}
+		switch affected {
+		case 1:
+			inserted++
+		default:
+			updated++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return inserted, updated, nil
+}
+
+// SoftDelete marks a proxy deleted without removing its row, so it drops
+// out of every active/healthy query immediately but stays available for
+// audit until the retention purge job hard-deletes it.
+func (d *ProxyDAO) SoftDelete(ctx context.Context, id string) error {
+	_, err := d.db.ExecContext(ctx, `UPDATE proxies SET status = ? WHERE id = ?`, string(proxy.StatusDeleted), id)
+	return err
+}
+
+// PurgeDeleted hard-deletes up to limit proxies that were soft-deleted
+// before cutoff, returning how many rows it removed. Callers loop on
+// this (the retention cleaner does) to work through a large backlog in
+// limit-sized chunks rather than one long-running DELETE.
+func (d *ProxyDAO) PurgeDeleted(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	result, err := d.db.ExecContext(ctx,
+		`DELETE FROM proxies WHERE status = ? AND updated_at < ? LIMIT ?`,
+		string(proxy.StatusDeleted), cutoff, limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Create inserts a single, operator-added proxy. It is a thin wrapper
+// around BulkUpsert for the single-proxy case used by the admin API.
+func (d *ProxyDAO) Create(ctx context.Context, p *proxy.Proxy) error {
+	_, _, err := d.BulkUpsert(ctx, []*proxy.Proxy{p})
+	return err
+}
+
+// SetStatus transitions a proxy to a new lifecycle status, e.g. an
+// operator manually disabling it.
+func (d *ProxyDAO) SetStatus(ctx context.Context, id string, status proxy.Status) error {
+	result, err := d.db.ExecContext(ctx, `UPDATE proxies SET status = ? WHERE id = ?`, string(status), id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ProxyFilter narrows Search by any combination of criteria. Zero values
+// mean "don't filter on this field". It is shared by every caller that
+// needs combined, multi-dimension filtering — the customer-facing proxy
+// list, the admin inventory search, and proxy-pool's acquisition
+// lookups — so they stay on one query-building code path.
+type ProxyFilter struct {
+	Provider           string
+	Country            string   // single-country filter; combinable with Countries
+	Countries          []string // matches any of these countries if non-empty
+	Protocol           proxy.Protocol
+	Source             proxy.Source
+	Status             proxy.Status
+	Active             *bool       // non-nil restricts to pending/healthy (true) or dead/banned (false)
+	Stage              proxy.Stage // non-empty restricts to this lifecycle stage
+	ExcludeProbation   bool        // true excludes StageProbation regardless of Stage
+	MinScore           float64
+	MaxScore           float64 // 0 means "no upper bound"
+	MaxLatencyMS       int     // 0 means "no upper bound"
+	LastCheckedBefore  *time.Time
+	ExcludeBlacklisted bool
+
+	// Cursor, if set, resumes a previous Search call from its
+	// NextCursor and takes precedence over Offset. Offset remains for
+	// callers that only need simple, non-cursor paging.
+	Cursor string
+	Limit  int
+	Offset int
+}
+
+const defaultProxyListLimit = 50
+
+// Search returns proxies matching any combination of filter criteria,
+// highest score first, along with the total number of matches (ignoring
+// pagination) and a cursor for the next page, empty once results are
+// exhausted. Combining Countries, Active and LastCheckedBefore with the
+// other criteria lets both the health-check scheduler and the admin UI
+// express arbitrarily specific queries through the same method.
+func (d *ProxyDAO) Search(ctx context.Context, filter ProxyFilter) (proxies []*proxy.Proxy, total int64, nextCursor string, err error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultProxyListLimit
+	}
+
+	where := ` WHERE 1 = 1`
+	var args []interface{}
+	if filter.Provider != "" {
+		where += ` AND provider = ?`
+		args = append(args, filter.Provider)
+	}
+	if filter.Country != "" {
+		where += ` AND country = ?`
+		args = append(args, filter.Country)
+	}
+	if len(filter.Countries) > 0 {
+		where += ` AND country IN (?` + strings.Repeat(`, ?`, len(filter.Countries)-1) + `)`
+		for _, c := range filter.Countries {
+			args = append(args, c)
+		}
+	}
+	if filter.Protocol != "" {
+		where += ` AND protocol = ?`
+		args = append(args, string(filter.Protocol))
+	}
+	if filter.Source != "" {
+		where += ` AND source = ?`
+		args = append(args, string(filter.Source))
+	}
+	if filter.Status != "" {
+		where += ` AND status = ?`
+		args = append(args, string(filter.Status))
+	}
+	if filter.Active != nil {
+		if *filter.Active {
+			where += ` AND status IN (?, ?)`
+			args = append(args, string(proxy.StatusPending), string(proxy.StatusHealthy))
+		} else {
+			where += ` AND status IN (?, ?)`
+			args = append(args, string(proxy.StatusDead), string(proxy.StatusBanned))
+		}
+	}
+	if filter.MinScore > 0 {
+		where += ` AND score >= ?`
+		args = append(args, filter.MinScore)
+	}
+	if filter.MaxScore > 0 {
+		where += ` AND score <= ?`
+		args = append(args, filter.MaxScore)
+	}
+	if filter.MaxLatencyMS > 0 {
+		where += ` AND latency_ms <= ?`
+		args = append(args, filter.MaxLatencyMS)
+	}
+	if filter.LastCheckedBefore != nil {
+		where += ` AND last_checked_at < ?`
+		args = append(args, *filter.LastCheckedBefore)
+	}
+	if filter.Stage != "" {
+		where += ` AND stage = ?`
+		args = append(args, string(filter.Stage))
+	}
+	if filter.ExcludeProbation {
+		where += ` AND stage != ?`
+		args = append(args, string(proxy.StageProbation))
+	}
+	if filter.ExcludeBlacklisted {
+		where += ` AND blacklisted = FALSE`
+	}
+
+	if err := d.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM proxies`+where, args...).Scan(&total); err != nil {
+		return nil, 0, "", err
+	}
+
+	query := `SELECT ` + proxyColumns + ` FROM proxies` + where
+	pageArgs := append([]interface{}{}, args...)
+
+	if filter.Cursor != "" {
+		lastScore, lastID, err := decodeProxyCursor(filter.Cursor)
+		if err != nil {
+			return nil, 0, "", fmt.Errorf("dao: invalid cursor: %w", err)
+		}
+		query += ` AND (score < ? OR (score = ? AND id > ?)) ORDER BY score DESC, id ASC LIMIT ?`
+		pageArgs = append(pageArgs, lastScore, lastScore, lastID, limit+1)
+	} else {
+		query += ` ORDER BY score DESC, id ASC LIMIT ? OFFSET ?`
+		pageArgs = append(pageArgs, limit+1, filter.Offset)
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, pageArgs...)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	defer rows.Close()
+
+	var out []*proxy.Proxy
+	for rows.Next() {
+		p, err := scanProxy(rows)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, "", err
+	}
+
+	if len(out) > limit {
+		out = out[:limit]
+		last := out[len(out)-1]
+		nextCursor = encodeProxyCursor(last.Score, last.ID)
+	}
+
+	return out, total, nextCursor, nil
+}
+
+func encodeProxyCursor(score float64, id string) string {
+	raw := strconv.FormatFloat(score, 'g', -1, 64) + "|" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeProxyCursor(cursor string) (score float64, id string, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, "", err
+	}
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return 0, "", fmt.Errorf("malformed cursor")
+	}
+	score, err = strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, "", err
+	}
+	return score, parts[1], nil
+}
+
+// GeoFilter narrows GetByGeo by any combination of location criteria.
+// Zero values mean "don't filter on this field".
+type GeoFilter struct {
+	Country            string
+	City               string
+	ASN                int
+	Protocol           proxy.Protocol
+	Stage              proxy.Stage // non-empty restricts to this lifecycle stage
+	ExcludeProbation   bool        // true excludes StageProbation regardless of Stage
+	MinScore           float64
+	ExcludeBlacklisted bool
+	Limit              int
+	Offset             int
+}
+
+// GetByGeo returns healthy proxies matching filter's location criteria,
+// highest score first, backed by idx_proxies_geo. It is used to satisfy
+// requests for exit nodes from a specific country, city, or ASN.
+func (d *ProxyDAO) GetByGeo(ctx context.Context, filter GeoFilter) ([]*proxy.Proxy, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultProxyListLimit
+	}
+
+	query := `SELECT ` + proxyColumns + ` FROM proxies WHERE status = ?`
+	args := []interface{}{string(proxy.StatusHealthy)}
+	if filter.Country != "" {
+		query += ` AND country = ?`
+		args = append(args, filter.Country)
+	}
+	if filter.City != "" {
+		query += ` AND city = ?`
+		args = append(args, filter.City)
+	}
+	if filter.ASN != 0 {
+		query += ` AND asn = ?`
+		args = append(args, filter.ASN)
+	}
+	if filter.Protocol != "" {
+		query += ` AND protocol = ?`
+		args = append(args, string(filter.Protocol))
+	}
+	if filter.MinScore > 0 {
+		query += ` AND score >= ?`
+		args = append(args, filter.MinScore)
+	}
+	if filter.Stage != "" {
+		query += ` AND stage = ?`
+		args = append(args, string(filter.Stage))
+	}
+	if filter.ExcludeProbation {
+		query += ` AND stage != ?`
+		args = append(args, string(proxy.StageProbation))
+	}
+	if filter.ExcludeBlacklisted {
+		query += ` AND blacklisted = FALSE`
+	}
+	query += ` ORDER BY score DESC LIMIT ? OFFSET ?`
+	args = append(args, limit, filter.Offset)
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*proxy.Proxy
+	for rows.Next() {
+		p, err := scanProxy(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProxy(row rowScanner) (*proxy.Proxy, error) {
+	p := &proxy.Proxy{}
+	var protocol, status, source, stage string
+	var stageChangedAt sql.NullTime
+	var duplicateOf sql.NullString
+	if err := row.Scan(&p.ID, &p.Host, &p.Port, &protocol, &status, &p.Score, &source, &p.Country, &p.City, &p.ASN, &p.Provider, &p.ReputationScore, &p.ExitIP, &duplicateOf, &p.Blacklisted, &p.PoolID, &stage, &stageChangedAt, &p.ProbationChecks); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("dao: scan proxy: %w", err)
+	}
+	p.Protocol = proxy.Protocol(protocol)
+	p.Status = proxy.Status(status)
+	p.Source = proxy.Source(source)
+	p.Stage = proxy.Stage(stage)
+	if stageChangedAt.Valid {
+		p.StageChangedAt = stageChangedAt.Time
+	}
+	if duplicateOf.Valid {
+		p.DuplicateOf = duplicateOf.String
+	}
+	return p, nil
+}
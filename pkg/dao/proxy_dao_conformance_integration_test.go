@@ -0,0 +1,16 @@
+//go:build integration
+
+package dao_test
+
+import (
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/daofake"
+	"github.com/XXXXD-cation/proxy-platform/pkg/testsupport"
+)
+
+// Run with: go test -tags=integration ./pkg/dao/...
+func TestProxyDAOConformsToProxyDAOInterface(t *testing.T) {
+	daofake.ConformProxy(t, dao.NewProxyDAO(testsupport.GetTestDB(t)))
+}
@@ -0,0 +1,55 @@
+//go:build integration
+
+package dao_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/testsupport"
+)
+
+// Run with: go test -tags=integration ./pkg/dao/...
+// Needs a Docker (or Docker-compatible) daemon unless TEST_MYSQL_DSN
+// points at an already-running MySQL; see pkg/testsupport.
+func TestProxyDAOBulkUpsertThenGet(t *testing.T) {
+	db := testsupport.GetTestDB(t)
+	proxyDAO := dao.NewProxyDAO(db)
+
+	p := &proxy.Proxy{
+		ID:       uuid.NewString(),
+		Host:     "203.0.113.10",
+		Port:     8080,
+		Protocol: proxy.ProtocolHTTP,
+		Status:   proxy.StatusPending,
+		Source:   proxy.SourceManual,
+	}
+
+	inserted, updated, err := proxyDAO.BulkUpsert(context.Background(), []*proxy.Proxy{p})
+	if err != nil {
+		t.Fatalf("BulkUpsert() error = %v", err)
+	}
+	if inserted != 1 || updated != 0 {
+		t.Fatalf("BulkUpsert() = (inserted=%d, updated=%d), want (1, 0)", inserted, updated)
+	}
+
+	got, err := proxyDAO.Get(context.Background(), p.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Host != p.Host || got.Port != p.Port {
+		t.Errorf("Get() = %+v, want host/port %s:%d", got, p.Host, p.Port)
+	}
+
+	_, updated, err = proxyDAO.BulkUpsert(context.Background(), []*proxy.Proxy{p})
+	if err != nil {
+		t.Fatalf("BulkUpsert() (second call) error = %v", err)
+	}
+	if updated != 1 {
+		t.Errorf("BulkUpsert() (second call) updated = %d, want 1", updated)
+	}
+}
@@ -0,0 +1,182 @@
+package daofake
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+)
+
+// APIKey is an in-memory apikey.DAOInterface.
+type APIKey struct {
+	mu sync.Mutex
+	// keys holds every key record, including revoked/expired ones, so
+	// List keeps returning a user's full history the way the real
+	// api_keys table does.
+	keys map[string]*apikey.Key
+	// hashByID maps a key's ID to apikey.Hash of its raw value, since
+	// apikey.Key itself never carries the raw value or its hash.
+	hashByID map[string]string
+}
+
+var _ apikey.DAOInterface = (*APIKey)(nil)
+
+// NewAPIKey returns an empty fake.
+func NewAPIKey() *APIKey {
+	return &APIKey{keys: make(map[string]*apikey.Key), hashByID: make(map[string]string)}
+}
+
+func (f *APIKey) Generate(ctx context.Context, userID, orgID, name string, permissions []string, expiresAt *time.Time, rotationMode string, rotationIntervalSeconds int) (string, *apikey.Key, error) {
+	raw, err := randomRawKey()
+	if err != nil {
+		return "", nil, err
+	}
+	if rotationMode == "" {
+		rotationMode = apikey.RotationModePerRequest
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := &apikey.Key{
+		ID:                      uuid.NewString(),
+		UserID:                  userID,
+		OrgID:                   orgID,
+		Name:                    name,
+		Status:                  apikey.StatusActive,
+		Permissions:             append([]string(nil), permissions...),
+		KeySuffix:               raw[len(raw)-4:],
+		ExpiresAt:               expiresAt,
+		CreatedAt:               time.Now().UTC(),
+		RotationMode:            rotationMode,
+		RotationIntervalSeconds: rotationIntervalSeconds,
+	}
+	f.keys[key.ID] = key
+	f.hashByID[key.ID] = apikey.Hash(raw)
+	return raw, cloneKey(key), nil
+}
+
+func (f *APIKey) List(ctx context.Context, userID string) ([]*apikey.Key, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []*apikey.Key
+	for _, k := range f.keys {
+		if k.UserID == userID {
+			out = append(out, cloneKey(k))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (f *APIKey) ListForOrg(ctx context.Context, orgID string) ([]*apikey.Key, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []*apikey.Key
+	for _, k := range f.keys {
+		if k.OrgID == orgID {
+			out = append(out, cloneKey(k))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (f *APIKey) Revoke(ctx context.Context, userID, keyID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	k, ok := f.keys[keyID]
+	if !ok || k.UserID != userID {
+		// Matches the real DAO: an UPDATE matching zero rows isn't an
+		// error.
+		return nil
+	}
+	k.Status = apikey.StatusRevoked
+	return nil
+}
+
+func (f *APIKey) DeactivateExpired(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var n int64
+	for _, k := range f.keys {
+		if n >= int64(limit) {
+			break
+		}
+		if k.Status == apikey.StatusActive && k.ExpiresAt != nil && !k.ExpiresAt.After(cutoff) {
+			k.Status = apikey.StatusExpired
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (f *APIKey) Rotate(ctx context.Context, userID, keyID string) (string, *apikey.Key, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	old, ok := f.keys[keyID]
+	if !ok || old.UserID != userID {
+		return "", nil, apikey.ErrNotFound
+	}
+	old.Status = apikey.StatusRevoked
+
+	raw, err := randomRawKey()
+	if err != nil {
+		return "", nil, err
+	}
+	key := &apikey.Key{
+		ID:                      uuid.NewString(),
+		UserID:                  userID,
+		OrgID:                   old.OrgID,
+		Name:                    old.Name,
+		Status:                  apikey.StatusActive,
+		Permissions:             append([]string(nil), old.Permissions...),
+		KeySuffix:               raw[len(raw)-4:],
+		ExpiresAt:               old.ExpiresAt,
+		CreatedAt:               time.Now().UTC(),
+		RotationMode:            old.RotationMode,
+		RotationIntervalSeconds: old.RotationIntervalSeconds,
+	}
+	f.keys[key.ID] = key
+	f.hashByID[key.ID] = apikey.Hash(raw)
+	return raw, cloneKey(key), nil
+}
+
+func (f *APIKey) LookupByRawKey(ctx context.Context, raw string) (*apikey.Key, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	hash := apikey.Hash(raw)
+	for id, h := range f.hashByID {
+		if h != hash {
+			continue
+		}
+		k := f.keys[id]
+		if k.Status != apikey.StatusActive {
+			return nil, apikey.ErrNotFound
+		}
+		if k.ExpiresAt != nil && time.Now().After(*k.ExpiresAt) {
+			return nil, apikey.ErrNotFound
+		}
+		return cloneKey(k), nil
+	}
+	return nil, apikey.ErrNotFound
+}
+
+func cloneKey(k *apikey.Key) *apikey.Key {
+	cp := *k
+	cp.Permissions = append([]string(nil), k.Permissions...)
+	return &cp
+}
+
+func randomRawKey() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "sk_" + hex.EncodeToString(buf), nil
+}
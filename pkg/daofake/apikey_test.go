@@ -0,0 +1,7 @@
+package daofake
+
+import "testing"
+
+func TestAPIKeyConformsToAPIKeyDAOInterface(t *testing.T) {
+	ConformAPIKey(t, NewAPIKey())
+}
@@ -0,0 +1,541 @@
+package daofake
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// ConformUser runs a fixed sequence of operations against d, failing t if
+// its behavior doesn't match what user.DAO documents. Both this
+// package's fake and the real user.DAO (against a live MySQL; see
+// pkg/user's integration test) must pass it unmodified.
+func ConformUser(t *testing.T, d user.DAOInterface) {
+	t.Helper()
+	ctx := context.Background()
+
+	u, err := d.Create(ctx, "conform@example.com", "hash1", user.PlanFree)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if u.ID == "" {
+		t.Fatal("Create() returned a user with no ID")
+	}
+
+	got, err := d.Get(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Email != u.Email {
+		t.Errorf("Get().Email = %q, want %q", got.Email, u.Email)
+	}
+
+	if _, err := d.Get(ctx, "does-not-exist"); !errors.Is(err, user.ErrNotFound) {
+		t.Errorf("Get() of a missing user error = %v, want user.ErrNotFound", err)
+	}
+
+	byEmail, err := d.GetByEmail(ctx, u.Email)
+	if err != nil {
+		t.Fatalf("GetByEmail() error = %v", err)
+	}
+	if byEmail.ID != u.ID {
+		t.Errorf("GetByEmail().ID = %q, want %q", byEmail.ID, u.ID)
+	}
+
+	if err := d.UpdatePlan(ctx, u.ID, user.PlanPro); err != nil {
+		t.Fatalf("UpdatePlan() error = %v", err)
+	}
+	if err := d.UpdateStatus(ctx, u.ID, user.StatusSuspended); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	got, err = d.Get(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("Get() after updates error = %v", err)
+	}
+	if got.Plan != user.PlanPro || got.Status != user.StatusSuspended {
+		t.Errorf("Get() after updates = %+v, want plan=%s status=%s", got, user.PlanPro, user.StatusSuspended)
+	}
+
+	page, err := d.List(ctx, user.ListFilter{Status: user.StatusSuspended})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if page.Total != 1 || len(page.Items) != 1 || page.Items[0].ID != u.ID {
+		t.Errorf("List(Status=suspended) = %+v, want exactly %s", page, u.ID)
+	}
+
+	counts, err := d.CountByStatus(ctx)
+	if err != nil {
+		t.Fatalf("CountByStatus() error = %v", err)
+	}
+	if counts[user.StatusSuspended] != 1 {
+		t.Errorf("CountByStatus()[suspended] = %d, want 1", counts[user.StatusSuspended])
+	}
+
+	if err := d.SetPasswordHash(ctx, u.ID, "hash2"); err != nil {
+		t.Fatalf("SetPasswordHash() error = %v", err)
+	}
+	if _, err := d.ResetPassword(ctx, u.ID); err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+
+	if err := d.SoftDelete(ctx, u.ID); err != nil {
+		t.Fatalf("SoftDelete() error = %v", err)
+	}
+	got, err = d.Get(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("Get() after SoftDelete error = %v", err)
+	}
+	if got.Status != user.StatusDeleted {
+		t.Errorf("Get().Status after SoftDelete = %s, want %s", got.Status, user.StatusDeleted)
+	}
+}
+
+// ConformAPIKey runs a fixed sequence of operations against d, failing t
+// if its behavior doesn't match what apikey.DAO documents.
+func ConformAPIKey(t *testing.T, d apikey.DAOInterface) {
+	t.Helper()
+	ctx := context.Background()
+
+	raw, key, err := d.Generate(ctx, "user-1", "", "ci key", []string{"read"}, nil, "", 0)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if raw == "" || key.ID == "" {
+		t.Fatal("Generate() returned an empty raw key or ID")
+	}
+
+	found, err := d.LookupByRawKey(ctx, raw)
+	if err != nil {
+		t.Fatalf("LookupByRawKey() error = %v", err)
+	}
+	if found.ID != key.ID {
+		t.Errorf("LookupByRawKey().ID = %q, want %q", found.ID, key.ID)
+	}
+
+	keys, err := d.List(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(keys) != 1 || keys[0].ID != key.ID {
+		t.Errorf("List() = %+v, want exactly %s", keys, key.ID)
+	}
+
+	rawRotated, rotated, err := d.Rotate(ctx, "user-1", key.ID)
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if rotated.ID == key.ID {
+		t.Error("Rotate() returned the same key ID as before rotation")
+	}
+	if _, err := d.LookupByRawKey(ctx, raw); !errors.Is(err, apikey.ErrNotFound) {
+		t.Errorf("LookupByRawKey() of the pre-rotation raw key error = %v, want apikey.ErrNotFound", err)
+	}
+	if _, err := d.LookupByRawKey(ctx, rawRotated); err != nil {
+		t.Errorf("LookupByRawKey() of the post-rotation raw key error = %v", err)
+	}
+
+	if err := d.Revoke(ctx, "user-1", rotated.ID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if _, err := d.LookupByRawKey(ctx, rawRotated); !errors.Is(err, apikey.ErrNotFound) {
+		t.Errorf("LookupByRawKey() of a revoked key error = %v, want apikey.ErrNotFound", err)
+	}
+
+	expiresAt := time.Now().Add(-time.Hour)
+	if _, _, err := d.Generate(ctx, "user-1", "", "already expired", nil, &expiresAt, "", 0); err != nil {
+		t.Fatalf("Generate() (expired) error = %v", err)
+	}
+	n, err := d.DeactivateExpired(ctx, time.Now(), 10)
+	if err != nil {
+		t.Fatalf("DeactivateExpired() error = %v", err)
+	}
+	if n != 1 {
+		t.Errorf("DeactivateExpired() = %d, want 1", n)
+	}
+}
+
+// ConformSubscription runs a fixed sequence of operations against d,
+// failing t if its behavior doesn't match what billing.SubscriptionDAO
+// documents.
+func ConformSubscription(t *testing.T, d billing.SubscriptionDAOInterface) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := d.Get(ctx, "no-such-user"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Get() of a missing subscription error = %v, want sql.ErrNoRows", err)
+	}
+
+	periodEnd := time.Now().Add(24 * time.Hour)
+	sub := &billing.Subscription{
+		UserID:                 "user-1",
+		Plan:                   user.PlanPro,
+		Status:                 billing.SubscriptionStatusActive,
+		ProviderCustomerID:     "cus_1",
+		ProviderSubscriptionID: "sub_1",
+		CurrentPeriodEnd:       &periodEnd,
+	}
+	if err := d.Upsert(ctx, sub); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	got, err := d.Get(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.ProviderSubscriptionID != sub.ProviderSubscriptionID {
+		t.Errorf("Get().ProviderSubscriptionID = %q, want %q", got.ProviderSubscriptionID, sub.ProviderSubscriptionID)
+	}
+
+	byProvider, err := d.GetByProviderSubscriptionID(ctx, "sub_1")
+	if err != nil {
+		t.Fatalf("GetByProviderSubscriptionID() error = %v", err)
+	}
+	if byProvider.UserID != "user-1" {
+		t.Errorf("GetByProviderSubscriptionID().UserID = %q, want user-1", byProvider.UserID)
+	}
+
+	expiring, err := d.ListExpiringBefore(ctx, time.Now().Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("ListExpiringBefore() error = %v", err)
+	}
+	if len(expiring) != 1 || expiring[0].UserID != "user-1" {
+		t.Errorf("ListExpiringBefore() = %+v, want exactly user-1", expiring)
+	}
+
+	if err := d.UpdateStatus(ctx, "user-1", billing.SubscriptionStatusCanceled); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	got, err = d.Get(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Get() after UpdateStatus error = %v", err)
+	}
+	if got.Status != billing.SubscriptionStatusCanceled {
+		t.Errorf("Get().Status after UpdateStatus = %s, want %s", got.Status, billing.SubscriptionStatusCanceled)
+	}
+}
+
+// ConformUsageLog runs a fixed sequence of operations against d, failing
+// t if its behavior doesn't match what usage.DAO documents.
+func ConformUsageLog(t *testing.T, d usage.DAOInterface) {
+	t.Helper()
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	if err := d.Insert(ctx, usage.Log{UserID: "user-1", TargetHost: "example.com", BytesIn: 10}); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	if err := d.InsertBatch(ctx, []usage.Log{
+		{UserID: "user-1", TargetHost: "example.com", BytesIn: 20, ProxyID: "proxy-1", StatusCode: 200},
+		{UserID: "user-2", TargetHost: "other.example", BytesIn: 30, ProxyID: "proxy-1", StatusCode: 502},
+	}); err != nil {
+		t.Fatalf("InsertBatch() error = %v", err)
+	}
+
+	counts, err := d.CountByUserInRange(ctx, now.Add(-time.Hour), now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("CountByUserInRange() error = %v", err)
+	}
+	if counts["user-1"] != 2 {
+		t.Errorf("CountByUserInRange()[user-1] = %d, want 2", counts["user-1"])
+	}
+
+	top, err := d.TopTargetHosts(ctx, now.Add(-time.Hour), now.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("TopTargetHosts() error = %v", err)
+	}
+	if len(top) == 0 || top[0].TargetHost != "example.com" || top[0].Count != 2 {
+		t.Errorf("TopTargetHosts() = %+v, want example.com first with count 2", top)
+	}
+
+	userTop, err := d.TopTargetHostsForUser(ctx, "user-1", now.Add(-time.Hour), now.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("TopTargetHostsForUser() error = %v", err)
+	}
+	if len(userTop) != 1 || userTop[0].TargetHost != "example.com" || userTop[0].Count != 2 {
+		t.Errorf("TopTargetHostsForUser() = %+v, want example.com with count 2", userTop)
+	}
+
+	proxyStats, err := d.GetStatsByProxyID(ctx, "proxy-1", now.Add(-time.Hour), now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetStatsByProxyID() error = %v", err)
+	}
+	if proxyStats.Requests != 2 || proxyStats.Errors != 1 {
+		t.Errorf("GetStatsByProxyID() = %+v, want 2 requests, 1 error", proxyStats)
+	}
+
+	topProxies, err := d.TopProxiesByErrors(ctx, now.Add(-time.Hour), now.Add(time.Hour), 1, 10)
+	if err != nil {
+		t.Fatalf("TopProxiesByErrors() error = %v", err)
+	}
+	if len(topProxies) != 1 || topProxies[0].ProxyID != "proxy-1" || topProxies[0].Errors != 1 {
+		t.Errorf("TopProxiesByErrors() = %+v, want exactly proxy-1 with 1 error", topProxies)
+	}
+
+	forExport, err := d.SelectForExport(ctx, "user-1", now.Add(-time.Hour), now.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("SelectForExport() error = %v", err)
+	}
+	if len(forExport) != 2 {
+		t.Errorf("SelectForExport() returned %d rows, want 2", len(forExport))
+	}
+
+	old, err := d.SelectOldLogs(ctx, now.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("SelectOldLogs() error = %v", err)
+	}
+	if len(old) != 3 {
+		t.Fatalf("SelectOldLogs() returned %d logs, want 3", len(old))
+	}
+
+	deleted, err := d.DeleteByIDs(ctx, []int64{old[0].ID})
+	if err != nil {
+		t.Fatalf("DeleteByIDs() error = %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("DeleteByIDs() = %d, want 1", deleted)
+	}
+
+	n, err := d.DeleteOldLogs(ctx, now.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("DeleteOldLogs() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("DeleteOldLogs() = %d, want 2 (the remaining rows)", n)
+	}
+}
+
+// ConformProxy runs a fixed sequence of operations against d, failing t
+// if its behavior doesn't match what dao.ProxyDAO documents.
+func ConformProxy(t *testing.T, d dao.ProxyDAOInterface) {
+	t.Helper()
+	ctx := context.Background()
+
+	p := &proxy.Proxy{Host: "203.0.113.1", Port: 8080, Protocol: proxy.ProtocolHTTP, Status: proxy.StatusPending, Source: proxy.SourceManual}
+	inserted, updated, err := d.BulkUpsert(ctx, []*proxy.Proxy{p})
+	if err != nil {
+		t.Fatalf("BulkUpsert() error = %v", err)
+	}
+	if inserted != 1 || updated != 0 {
+		t.Fatalf("BulkUpsert() = (%d, %d), want (1, 0)", inserted, updated)
+	}
+	if p.ID == "" {
+		t.Fatal("BulkUpsert() did not assign an ID")
+	}
+
+	_, updated, err = d.BulkUpsert(ctx, []*proxy.Proxy{p})
+	if err != nil {
+		t.Fatalf("BulkUpsert() (second call) error = %v", err)
+	}
+	if updated != 1 {
+		t.Errorf("BulkUpsert() (second call) updated = %d, want 1", updated)
+	}
+
+	got, err := d.Get(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Host != p.Host {
+		t.Errorf("Get().Host = %q, want %q", got.Host, p.Host)
+	}
+
+	byAddr, err := d.GetByIPPort(ctx, p.Host, p.Port)
+	if err != nil {
+		t.Fatalf("GetByIPPort() error = %v", err)
+	}
+	if byAddr.ID != p.ID {
+		t.Errorf("GetByIPPort().ID = %q, want %q", byAddr.ID, p.ID)
+	}
+
+	if err := d.UpdateScore(ctx, p.ID, 0.9); err != nil {
+		t.Fatalf("UpdateScore() error = %v", err)
+	}
+	if err := d.AssignPool(ctx, p.ID, "pool-1"); err != nil {
+		t.Fatalf("AssignPool() error = %v", err)
+	}
+	byPool, err := d.ListByPool(ctx, "pool-1")
+	if err != nil {
+		t.Fatalf("ListByPool() error = %v", err)
+	}
+	if len(byPool) != 1 || byPool[0].ID != p.ID {
+		t.Errorf("ListByPool() = %+v, want exactly %s", byPool, p.ID)
+	}
+
+	if err := d.UpdateGeo(ctx, p.ID, "US", "Ashburn", 14618); err != nil {
+		t.Fatalf("UpdateGeo() error = %v", err)
+	}
+	counts, err := d.CountActiveByCountry(ctx)
+	if err != nil {
+		t.Fatalf("CountActiveByCountry() error = %v", err)
+	}
+	if counts["US"] != 1 {
+		t.Errorf("CountActiveByCountry()[US] = %d, want 1", counts["US"])
+	}
+
+	byProvider, err := d.CountActiveByProvider(ctx)
+	if err != nil {
+		t.Fatalf("CountActiveByProvider() error = %v", err)
+	}
+	if byProvider[""] != 1 {
+		t.Errorf("CountActiveByProvider()[\"\"] = %d, want 1", byProvider[""])
+	}
+
+	if _, err := d.MarkAsChecked(ctx, p.ID, true, time.Now()); err != nil {
+		t.Fatalf("MarkAsChecked(success) error = %v", err)
+	}
+
+	// BulkUpsert defaulted p to StageProbation, so it's excluded from
+	// ListHealthy despite being healthy until it graduates.
+	healthy, err := d.ListHealthy(ctx)
+	if err != nil {
+		t.Fatalf("ListHealthy() error = %v", err)
+	}
+	if len(healthy) != 0 {
+		t.Errorf("ListHealthy() while on probation = %+v, want none", healthy)
+	}
+
+	count, err := d.IncrementProbationChecks(ctx, p.ID, true)
+	if err != nil {
+		t.Fatalf("IncrementProbationChecks() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("IncrementProbationChecks() = %d, want 1", count)
+	}
+
+	if err := d.TransitionStage(ctx, p.ID, proxy.StageActive, "graduated probation"); err != nil {
+		t.Fatalf("TransitionStage() error = %v", err)
+	}
+	got, err = d.Get(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("Get() after TransitionStage error = %v", err)
+	}
+	if got.Stage != proxy.StageActive {
+		t.Errorf("Get().Stage after TransitionStage = %s, want %s", got.Stage, proxy.StageActive)
+	}
+
+	history, err := d.StageHistory(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("StageHistory() error = %v", err)
+	}
+	if len(history) != 1 || history[0].ToStage != proxy.StageActive || history[0].FromStage != proxy.StageProbation {
+		t.Errorf("StageHistory() = %+v, want one probation->active transition", history)
+	}
+
+	healthy, err = d.ListHealthy(ctx)
+	if err != nil {
+		t.Fatalf("ListHealthy() error = %v", err)
+	}
+	if len(healthy) != 1 || healthy[0].ID != p.ID {
+		t.Errorf("ListHealthy() after graduating = %+v, want exactly %s", healthy, p.ID)
+	}
+
+	if err := d.UpdateExitIP(ctx, p.ID, "198.51.100.9"); err != nil {
+		t.Fatalf("UpdateExitIP() error = %v", err)
+	}
+	other := &proxy.Proxy{Host: "203.0.113.2", Port: 8080, Protocol: proxy.ProtocolHTTP, Status: proxy.StatusPending, Source: proxy.SourceProvider, Provider: "other-provider"}
+	if _, _, err := d.BulkUpsert(ctx, []*proxy.Proxy{other}); err != nil {
+		t.Fatalf("BulkUpsert(other) error = %v", err)
+	}
+	if err := d.UpdateExitIP(ctx, other.ID, "198.51.100.9"); err != nil {
+		t.Fatalf("UpdateExitIP(other) error = %v", err)
+	}
+	// GroupByExitIP only considers proxies with a provider recorded, so
+	// exercise it with a pair of its own rather than p, which was
+	// created as SourceManual and deliberately left without one.
+	mirrorA := &proxy.Proxy{Host: "203.0.113.3", Port: 8080, Protocol: proxy.ProtocolHTTP, Status: proxy.StatusPending, Source: proxy.SourceProvider, Provider: "mirror-a"}
+	mirrorB := &proxy.Proxy{Host: "203.0.113.4", Port: 8080, Protocol: proxy.ProtocolHTTP, Status: proxy.StatusPending, Source: proxy.SourceProvider, Provider: "mirror-b"}
+	if _, _, err := d.BulkUpsert(ctx, []*proxy.Proxy{mirrorA, mirrorB}); err != nil {
+		t.Fatalf("BulkUpsert(mirrors) error = %v", err)
+	}
+	if err := d.UpdateExitIP(ctx, mirrorA.ID, "198.51.100.42"); err != nil {
+		t.Fatalf("UpdateExitIP(mirrorA) error = %v", err)
+	}
+	if err := d.UpdateExitIP(ctx, mirrorB.ID, "198.51.100.42"); err != nil {
+		t.Fatalf("UpdateExitIP(mirrorB) error = %v", err)
+	}
+
+	groups, err := d.GroupByExitIP(ctx)
+	if err != nil {
+		t.Fatalf("GroupByExitIP() error = %v", err)
+	}
+	var found *dao.ExitIPGroup
+	for i := range groups {
+		if groups[i].ExitIP == "198.51.100.42" {
+			found = &groups[i]
+		}
+	}
+	if found == nil || len(found.Members) != 2 {
+		t.Errorf("GroupByExitIP() = %+v, want a group of two members at 198.51.100.42", groups)
+	}
+
+	if err := d.FlagDuplicate(ctx, other.ID, p.ID); err != nil {
+		t.Fatalf("FlagDuplicate() error = %v", err)
+	}
+	got, err = d.Get(ctx, other.ID)
+	if err != nil {
+		t.Fatalf("Get() after FlagDuplicate error = %v", err)
+	}
+	if got.DuplicateOf != p.ID {
+		t.Errorf("Get().DuplicateOf after FlagDuplicate = %q, want %q", got.DuplicateOf, p.ID)
+	}
+
+	if err := d.ClearDuplicate(ctx, other.ID); err != nil {
+		t.Fatalf("ClearDuplicate() error = %v", err)
+	}
+	got, err = d.Get(ctx, other.ID)
+	if err != nil {
+		t.Fatalf("Get() after ClearDuplicate error = %v", err)
+	}
+	if got.DuplicateOf != "" {
+		t.Errorf("Get().DuplicateOf after ClearDuplicate = %q, want empty", got.DuplicateOf)
+	}
+
+	var deactivated bool
+	for i := 0; i < dao.MaxConsecutiveFailures; i++ {
+		deactivated, err = d.MarkAsChecked(ctx, p.ID, false, time.Now())
+		if err != nil {
+			t.Fatalf("MarkAsChecked(failure #%d) error = %v", i, err)
+		}
+	}
+	if !deactivated {
+		t.Errorf("MarkAsChecked() after %d consecutive failures did not report deactivation", dao.MaxConsecutiveFailures)
+	}
+
+	results, total, _, err := d.Search(ctx, dao.ProxyFilter{Country: "US"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if total != 1 || len(results) != 1 || results[0].ID != p.ID {
+		t.Errorf("Search(Country=US) = (total=%d, results=%+v), want exactly %s", total, results, p.ID)
+	}
+
+	byGeo, err := d.GetByGeo(ctx, dao.GeoFilter{Country: "US"})
+	if err != nil {
+		t.Fatalf("GetByGeo() error = %v", err)
+	}
+	// The proxy was marked dead by the failure streak above, so it no
+	// longer satisfies GetByGeo's implicit "healthy" requirement.
+	if len(byGeo) != 0 {
+		t.Errorf("GetByGeo() = %+v, want none (proxy is dead)", byGeo)
+	}
+
+	if err := d.SoftDelete(ctx, p.ID); err != nil {
+		t.Fatalf("SoftDelete() error = %v", err)
+	}
+	got, err = d.Get(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("Get() after SoftDelete error = %v", err)
+	}
+	if got.Status != proxy.StatusDeleted {
+		t.Errorf("Get().Status after SoftDelete = %s, want %s", got.Status, proxy.StatusDeleted)
+	}
+}
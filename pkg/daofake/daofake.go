@@ -0,0 +1,10 @@
+// Package daofake provides in-memory fakes for this repo's DAO
+// interfaces (pkg/user.DAOInterface, pkg/apikey.DAOInterface,
+// pkg/billing.SubscriptionDAOInterface, pkg/usage.DAOInterface and
+// pkg/dao.ProxyDAOInterface), so service and handler code can be
+// unit-tested without a database. Each fake is safe for concurrent use
+// and is exercised by the same conformance suite the corresponding real
+// DAO is run against (see this package's *_test.go files and the
+// integration-tagged tests alongside each real DAO), so behavior can't
+// silently drift between the two.
+package daofake
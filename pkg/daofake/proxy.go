@@ -0,0 +1,530 @@
+package daofake
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// Proxy is an in-memory dao.ProxyDAOInterface.
+type Proxy struct {
+	mu          sync.Mutex
+	proxies     map[string]*proxy.Proxy
+	transitions map[string][]*dao.ProxyStageTransition
+}
+
+var _ dao.ProxyDAOInterface = (*Proxy)(nil)
+
+// NewProxy returns an empty fake.
+func NewProxy() *Proxy {
+	return &Proxy{
+		proxies:     make(map[string]*proxy.Proxy),
+		transitions: make(map[string][]*dao.ProxyStageTransition),
+	}
+}
+
+func (f *Proxy) Get(ctx context.Context, id string) (*proxy.Proxy, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.proxies[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return cloneProxy(p), nil
+}
+
+func (f *Proxy) GetByIPPort(ctx context.Context, host string, port int) (*proxy.Proxy, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, p := range f.proxies {
+		if p.Host == host && p.Port == port {
+			return cloneProxy(p), nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (f *Proxy) ListHealthy(ctx context.Context) ([]*proxy.Proxy, error) {
+	return f.listWhere(func(p *proxy.Proxy) bool {
+		return p.Status == proxy.StatusHealthy && p.Stage != proxy.StageProbation
+	}), nil
+}
+
+func (f *Proxy) ListActive(ctx context.Context) ([]*proxy.Proxy, error) {
+	return f.listWhere(isActive), nil
+}
+
+func (f *Proxy) CountActiveBySource(ctx context.Context) (map[proxy.Source]int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	counts := make(map[proxy.Source]int64)
+	for _, p := range f.proxies {
+		if isActive(p) {
+			counts[p.Source]++
+		}
+	}
+	return counts, nil
+}
+
+func (f *Proxy) CountActiveByCountry(ctx context.Context) (map[string]int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	counts := make(map[string]int64)
+	for _, p := range f.proxies {
+		if isActive(p) {
+			counts[p.Country]++
+		}
+	}
+	return counts, nil
+}
+
+func (f *Proxy) CountActiveByProvider(ctx context.Context) (map[string]int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	counts := make(map[string]int64)
+	for _, p := range f.proxies {
+		if isActive(p) {
+			counts[p.Provider]++
+		}
+	}
+	return counts, nil
+}
+
+func (f *Proxy) ListMissingGeo(ctx context.Context) ([]*proxy.Proxy, error) {
+	return f.listWhere(func(p *proxy.Proxy) bool { return p.Country == "" && isActive(p) }), nil
+}
+
+func (f *Proxy) UpdateGeo(ctx context.Context, id, country, city string, asn int) error {
+	return f.mutate(id, func(p *proxy.Proxy) {
+		p.Country, p.City, p.ASN = country, city, asn
+	})
+}
+
+func (f *Proxy) UpdateLatency(ctx context.Context, id string, latencyMS int) error {
+	return f.mutate(id, func(p *proxy.Proxy) { p.LatencyMS = latencyMS })
+}
+
+func (f *Proxy) UpdateSuccessRate(ctx context.Context, id string, rate float64) error {
+	return f.mutate(id, func(p *proxy.Proxy) { p.SuccessRate = rate })
+}
+
+func (f *Proxy) UpdateAnonymityLevel(ctx context.Context, id string, level proxy.AnonymityLevel) error {
+	return f.mutate(id, func(p *proxy.Proxy) { p.AnonymityLevel = level })
+}
+
+func (f *Proxy) UpdateReputation(ctx context.Context, id string, score float64, blacklisted bool) error {
+	return f.mutate(id, func(p *proxy.Proxy) { p.ReputationScore, p.Blacklisted = score, blacklisted })
+}
+
+func (f *Proxy) UpdateExitIP(ctx context.Context, id, exitIP string) error {
+	return f.mutate(id, func(p *proxy.Proxy) { p.ExitIP = exitIP })
+}
+
+func (f *Proxy) FlagDuplicate(ctx context.Context, id, canonicalID string) error {
+	return f.mutate(id, func(p *proxy.Proxy) { p.DuplicateOf = canonicalID })
+}
+
+func (f *Proxy) ClearDuplicate(ctx context.Context, id string) error {
+	return f.mutate(id, func(p *proxy.Proxy) { p.DuplicateOf = "" })
+}
+
+func (f *Proxy) GroupByExitIP(ctx context.Context) ([]dao.ExitIPGroup, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	byExitIP := make(map[string][]dao.ExitIPMember)
+	var order []string
+	for _, p := range f.proxies {
+		if p.ExitIP == "" || p.Provider == "" {
+			continue
+		}
+		if _, seen := byExitIP[p.ExitIP]; !seen {
+			order = append(order, p.ExitIP)
+		}
+		byExitIP[p.ExitIP] = append(byExitIP[p.ExitIP], dao.ExitIPMember{ID: p.ID, Provider: p.Provider, Score: p.Score})
+	}
+	sort.Strings(order)
+
+	var groups []dao.ExitIPGroup
+	for _, exitIP := range order {
+		members := byExitIP[exitIP]
+		providers := make(map[string]struct{}, len(members))
+		for _, m := range members {
+			providers[m.Provider] = struct{}{}
+		}
+		if len(providers) < 2 {
+			continue
+		}
+		groups = append(groups, dao.ExitIPGroup{ExitIP: exitIP, Members: members})
+	}
+	return groups, nil
+}
+
+func (f *Proxy) UpdateScore(ctx context.Context, id string, score float64) error {
+	return f.mutate(id, func(p *proxy.Proxy) { p.Score = score })
+}
+
+func (f *Proxy) AssignPool(ctx context.Context, id, poolID string) error {
+	return f.mutate(id, func(p *proxy.Proxy) { p.PoolID = poolID })
+}
+
+func (f *Proxy) ListByPool(ctx context.Context, poolID string) ([]*proxy.Proxy, error) {
+	out := f.listWhere(func(p *proxy.Proxy) bool { return p.PoolID == poolID })
+	sort.Slice(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	return out, nil
+}
+
+func (f *Proxy) MarkAsChecked(ctx context.Context, id string, success bool, checkedAt time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.proxies[id]
+	if !ok {
+		return false, sql.ErrNoRows
+	}
+
+	var deactivated bool
+	if success {
+		p.ConsecutiveFailures = 0
+		p.Status = proxy.StatusHealthy
+		p.LastCheckedAt = checkedAt
+		p.LastSuccessAt = checkedAt
+	} else {
+		p.ConsecutiveFailures++
+		if p.ConsecutiveFailures >= dao.MaxConsecutiveFailures {
+			p.Status = proxy.StatusDead
+			deactivated = true
+		}
+		p.LastCheckedAt = checkedAt
+	}
+	return deactivated, nil
+}
+
+func (f *Proxy) IncrementProbationChecks(ctx context.Context, id string, success bool) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.proxies[id]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+	if p.Stage != proxy.StageProbation {
+		return p.ProbationChecks, nil
+	}
+	if success {
+		p.ProbationChecks++
+	} else {
+		p.ProbationChecks = 0
+	}
+	return p.ProbationChecks, nil
+}
+
+func (f *Proxy) TransitionStage(ctx context.Context, id string, to proxy.Stage, reason string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.proxies[id]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	from := p.Stage
+	p.Stage = to
+	p.StageChangedAt = time.Now().UTC()
+	if to == proxy.StageProbation {
+		p.ProbationChecks = 0
+	}
+	f.transitions[id] = append(f.transitions[id], &dao.ProxyStageTransition{
+		ID:        uuid.NewString(),
+		ProxyID:   id,
+		FromStage: from,
+		ToStage:   to,
+		Reason:    reason,
+		CreatedAt: p.StageChangedAt,
+	})
+	return nil
+}
+
+func (f *Proxy) StageHistory(ctx context.Context, proxyID string) ([]*dao.ProxyStageTransition, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	history := f.transitions[proxyID]
+	out := make([]*dao.ProxyStageTransition, len(history))
+	for i, t := range history {
+		// Most recent first, mirroring ProxyDAO's ORDER BY created_at DESC.
+		out[len(history)-1-i] = t
+	}
+	return out, nil
+}
+
+func (f *Proxy) BulkUpsert(ctx context.Context, proxies []*proxy.Proxy) (inserted, updated int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, p := range proxies {
+		if p.ID == "" {
+			p.ID = uuid.NewString()
+		}
+		if existing, ok := f.proxies[p.ID]; ok {
+			existing.Protocol, existing.Status, existing.Source = p.Protocol, p.Status, p.Source
+			existing.Country, existing.City, existing.ASN, existing.Provider = p.Country, p.City, p.ASN, p.Provider
+			updated++
+			continue
+		}
+		if p.Stage == "" {
+			p.Stage = proxy.StageProbation
+		}
+		f.proxies[p.ID] = cloneProxy(p)
+		inserted++
+	}
+	return inserted, updated, nil
+}
+
+func (f *Proxy) SoftDelete(ctx context.Context, id string) error {
+	return f.mutate(id, func(p *proxy.Proxy) { p.Status = proxy.StatusDeleted })
+}
+
+func (f *Proxy) PurgeDeleted(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var purged int64
+	for id, p := range f.proxies {
+		if purged >= int64(limit) {
+			break
+		}
+		if p.Status == proxy.StatusDeleted && p.LastCheckedAt.Before(cutoff) {
+			delete(f.proxies, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (f *Proxy) Create(ctx context.Context, p *proxy.Proxy) error {
+	_, _, err := f.BulkUpsert(ctx, []*proxy.Proxy{p})
+	return err
+}
+
+func (f *Proxy) SetStatus(ctx context.Context, id string, status proxy.Status) error {
+	return f.mutate(id, func(p *proxy.Proxy) { p.Status = status })
+}
+
+func (f *Proxy) Search(ctx context.Context, filter dao.ProxyFilter) ([]*proxy.Proxy, int64, string, error) {
+	f.mu.Lock()
+	matched := f.listWhereLocked(func(p *proxy.Proxy) bool { return matchesFilter(p, filter) })
+	f.mu.Unlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Score != matched[j].Score {
+			return matched[i].Score > matched[j].Score
+		}
+		return matched[i].ID < matched[j].ID
+	})
+
+	total := int64(len(matched))
+	start := 0
+	if filter.Cursor != "" {
+		lastScore, lastID, err := decodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, 0, "", fmt.Errorf("daofake: invalid cursor: %w", err)
+		}
+		start = sort.Search(len(matched), func(i int) bool {
+			p := matched[i]
+			return p.Score < lastScore || (p.Score == lastScore && p.ID > lastID)
+		})
+	} else if filter.Offset > 0 {
+		start = filter.Offset
+		if start > len(matched) {
+			start = len(matched)
+		}
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	end := start + limit
+	fetchedExtra := end+1 <= len(matched)
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	page := matched[start:end]
+	var nextCursor string
+	if fetchedExtra {
+		last := page[len(page)-1]
+		nextCursor = encodeCursor(last.Score, last.ID)
+	}
+
+	out := make([]*proxy.Proxy, len(page))
+	for i, p := range page {
+		out[i] = cloneProxy(p)
+	}
+	return out, total, nextCursor, nil
+}
+
+func (f *Proxy) GetByGeo(ctx context.Context, filter dao.GeoFilter) ([]*proxy.Proxy, error) {
+	out := f.listWhere(func(p *proxy.Proxy) bool {
+		if p.Status != proxy.StatusHealthy {
+			return false
+		}
+		if filter.Country != "" && p.Country != filter.Country {
+			return false
+		}
+		if filter.City != "" && p.City != filter.City {
+			return false
+		}
+		if filter.ASN != 0 && p.ASN != filter.ASN {
+			return false
+		}
+		if filter.Protocol != "" && p.Protocol != filter.Protocol {
+			return false
+		}
+		if filter.MinScore > 0 && p.Score < filter.MinScore {
+			return false
+		}
+		if filter.Stage != "" && p.Stage != filter.Stage {
+			return false
+		}
+		if filter.ExcludeProbation && p.Stage == proxy.StageProbation {
+			return false
+		}
+		if filter.ExcludeBlacklisted && p.Blacklisted {
+			return false
+		}
+		return true
+	})
+	sort.Slice(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	start := filter.Offset
+	if start > len(out) {
+		start = len(out)
+	}
+	end := start + limit
+	if end > len(out) {
+		end = len(out)
+	}
+	return out[start:end], nil
+}
+
+func matchesFilter(p *proxy.Proxy, filter dao.ProxyFilter) bool {
+	if filter.Provider != "" && p.Provider != filter.Provider {
+		return false
+	}
+	if filter.Country != "" && p.Country != filter.Country {
+		return false
+	}
+	if len(filter.Countries) > 0 {
+		found := false
+		for _, c := range filter.Countries {
+			if p.Country == c {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if filter.Protocol != "" && p.Protocol != filter.Protocol {
+		return false
+	}
+	if filter.Source != "" && p.Source != filter.Source {
+		return false
+	}
+	if filter.Status != "" && p.Status != filter.Status {
+		return false
+	}
+	if filter.Active != nil && isActive(p) != *filter.Active {
+		return false
+	}
+	if filter.Stage != "" && p.Stage != filter.Stage {
+		return false
+	}
+	if filter.ExcludeProbation && p.Stage == proxy.StageProbation {
+		return false
+	}
+	if filter.MinScore > 0 && p.Score < filter.MinScore {
+		return false
+	}
+	if filter.MaxScore > 0 && p.Score > filter.MaxScore {
+		return false
+	}
+	if filter.MaxLatencyMS > 0 && p.LatencyMS > filter.MaxLatencyMS {
+		return false
+	}
+	if filter.LastCheckedBefore != nil && !p.LastCheckedAt.Before(*filter.LastCheckedBefore) {
+		return false
+	}
+	if filter.ExcludeBlacklisted && p.Blacklisted {
+		return false
+	}
+	return true
+}
+
+func isActive(p *proxy.Proxy) bool {
+	return p.Status == proxy.StatusPending || p.Status == proxy.StatusHealthy
+}
+
+func (f *Proxy) listWhere(match func(*proxy.Proxy) bool) []*proxy.Proxy {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.listWhereLocked(match)
+}
+
+func (f *Proxy) listWhereLocked(match func(*proxy.Proxy) bool) []*proxy.Proxy {
+	var out []*proxy.Proxy
+	for _, p := range f.proxies {
+		if match(p) {
+			out = append(out, cloneProxy(p))
+		}
+	}
+	return out
+}
+
+func (f *Proxy) mutate(id string, apply func(*proxy.Proxy)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.proxies[id]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	apply(p)
+	return nil
+}
+
+func cloneProxy(p *proxy.Proxy) *proxy.Proxy {
+	cp := *p
+	return &cp
+}
+
+func encodeCursor(score float64, id string) string {
+	raw := strconv.FormatFloat(score, 'g', -1, 64) + "|" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(cursor string) (score float64, id string, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, "", err
+	}
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return 0, "", fmt.Errorf("malformed cursor")
+	}
+	score, err = strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, "", err
+	}
+	return score, parts[1], nil
+}
@@ -0,0 +1,7 @@
+package daofake
+
+import "testing"
+
+func TestProxyConformsToProxyDAOInterface(t *testing.T) {
+	ConformProxy(t, NewProxy())
+}
@@ -0,0 +1,86 @@
+package daofake
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing"
+)
+
+// Subscription is an in-memory billing.SubscriptionDAOInterface, keyed
+// by user ID to match the real `subscriptions` table's one-row-per-user
+// shape.
+type Subscription struct {
+	mu   sync.Mutex
+	subs map[string]*billing.Subscription
+}
+
+var _ billing.SubscriptionDAOInterface = (*Subscription)(nil)
+
+// NewSubscription returns an empty fake.
+func NewSubscription() *Subscription {
+	return &Subscription{subs: make(map[string]*billing.Subscription)}
+}
+
+func (f *Subscription) Get(ctx context.Context, userID string) (*billing.Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sub, ok := f.subs[userID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return cloneSubscription(sub), nil
+}
+
+func (f *Subscription) GetByProviderSubscriptionID(ctx context.Context, providerSubscriptionID string) (*billing.Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, sub := range f.subs {
+		if sub.ProviderSubscriptionID == providerSubscriptionID {
+			return cloneSubscription(sub), nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (f *Subscription) ListExpiringBefore(ctx context.Context, cutoff time.Time) ([]*billing.Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []*billing.Subscription
+	for _, sub := range f.subs {
+		if sub.Status == billing.SubscriptionStatusActive && sub.CurrentPeriodEnd != nil && sub.CurrentPeriodEnd.Before(cutoff) {
+			out = append(out, cloneSubscription(sub))
+		}
+	}
+	return out, nil
+}
+
+func (f *Subscription) Upsert(ctx context.Context, sub *billing.Subscription) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cp := *sub
+	cp.UpdatedAt = time.Now().UTC()
+	f.subs[sub.UserID] = &cp
+	return nil
+}
+
+func (f *Subscription) UpdateStatus(ctx context.Context, userID, status string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sub, ok := f.subs[userID]
+	if !ok {
+		// Matches the real DAO: an UPDATE matching zero rows isn't an
+		// error.
+		return nil
+	}
+	sub.Status = status
+	sub.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func cloneSubscription(sub *billing.Subscription) *billing.Subscription {
+	cp := *sub
+	return &cp
+}
@@ -0,0 +1,7 @@
+package daofake
+
+import "testing"
+
+func TestSubscriptionConformsToSubscriptionDAOInterface(t *testing.T) {
+	ConformSubscription(t, NewSubscription())
+}
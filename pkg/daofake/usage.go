@@ -0,0 +1,254 @@
+package daofake
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/envelope"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+)
+
+// UsageLog is an in-memory usage.DAOInterface. It never encrypts
+// TargetHost, since field-level encryption is an implementation detail
+// of usage.NewEncryptingDAO rather than part of the DAOInterface
+// contract.
+type UsageLog struct {
+	mu     sync.Mutex
+	logs   []usage.Log
+	nextID int64
+}
+
+var _ usage.DAOInterface = (*UsageLog)(nil)
+
+// NewUsageLog returns an empty fake.
+func NewUsageLog() *UsageLog {
+	return &UsageLog{nextID: 1}
+}
+
+func (f *UsageLog) Insert(ctx context.Context, log usage.Log) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.insertLocked(log)
+	return nil
+}
+
+func (f *UsageLog) InsertBatch(ctx context.Context, logs []usage.Log) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, log := range logs {
+		f.insertLocked(log)
+	}
+	return nil
+}
+
+func (f *UsageLog) insertLocked(log usage.Log) {
+	log.ID = f.nextID
+	f.nextID++
+	if log.TargetHostHash == "" {
+		log.TargetHostHash = envelope.HashDomain(log.TargetHost)
+	}
+	log.CreatedAt = time.Now().UTC()
+	f.logs = append(f.logs, log)
+}
+
+func (f *UsageLog) CountByUserInRange(ctx context.Context, start, end time.Time) (map[string]int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	counts := make(map[string]int64)
+	for _, log := range f.logs {
+		if !log.CreatedAt.Before(start) && log.CreatedAt.Before(end) {
+			counts[log.UserID]++
+		}
+	}
+	return counts, nil
+}
+
+func (f *UsageLog) TopTargetHosts(ctx context.Context, start, end time.Time, limit int) ([]usage.TargetHostCount, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	counts := make(map[string]int64)
+	for _, log := range f.logs {
+		if log.TargetHost == "" {
+			continue
+		}
+		if !log.CreatedAt.Before(start) && log.CreatedAt.Before(end) {
+			counts[log.TargetHost]++
+		}
+	}
+	out := make([]usage.TargetHostCount, 0, len(counts))
+	for host, count := range counts {
+		out = append(out, usage.TargetHostCount{TargetHost: host, Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Count > out[j].Count })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (f *UsageLog) TopTargetHostsForUser(ctx context.Context, userID string, start, end time.Time, limit int) ([]usage.TargetHostCount, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	counts := make(map[string]int64)
+	for _, log := range f.logs {
+		if log.TargetHost == "" || log.UserID != userID {
+			continue
+		}
+		if !log.CreatedAt.Before(start) && log.CreatedAt.Before(end) {
+			counts[log.TargetHost]++
+		}
+	}
+	out := make([]usage.TargetHostCount, 0, len(counts))
+	for host, count := range counts {
+		out = append(out, usage.TargetHostCount{TargetHost: host, Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Count > out[j].Count })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (f *UsageLog) GetStatsByProxyID(ctx context.Context, proxyID string, start, end time.Time) (usage.ProxyUsageStats, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stats := usage.ProxyUsageStats{ProxyID: proxyID}
+	for _, log := range f.logs {
+		if log.ProxyID != proxyID {
+			continue
+		}
+		if log.CreatedAt.Before(start) || !log.CreatedAt.Before(end) {
+			continue
+		}
+		stats.Requests++
+		if log.StatusCode >= 400 || log.DenialReason != "" {
+			stats.Errors++
+		}
+		stats.BytesIn += log.BytesIn
+		stats.BytesOut += log.BytesOut
+	}
+	return stats, nil
+}
+
+func (f *UsageLog) TopProxiesByErrors(ctx context.Context, start, end time.Time, minRequests int64, limit int) ([]usage.ProxyUsageStats, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	byProxy := make(map[string]*usage.ProxyUsageStats)
+	for _, log := range f.logs {
+		if log.ProxyID == "" {
+			continue
+		}
+		if log.CreatedAt.Before(start) || !log.CreatedAt.Before(end) {
+			continue
+		}
+		s, ok := byProxy[log.ProxyID]
+		if !ok {
+			s = &usage.ProxyUsageStats{ProxyID: log.ProxyID}
+			byProxy[log.ProxyID] = s
+		}
+		s.Requests++
+		if log.StatusCode >= 400 || log.DenialReason != "" {
+			s.Errors++
+		}
+		s.BytesIn += log.BytesIn
+		s.BytesOut += log.BytesOut
+	}
+
+	out := make([]usage.ProxyUsageStats, 0, len(byProxy))
+	for _, s := range byProxy {
+		if s.Requests < minRequests {
+			continue
+		}
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Errors > out[j].Errors })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (f *UsageLog) SelectForExport(ctx context.Context, userID string, start, end time.Time, limit int) ([]usage.Log, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sorted := append([]usage.Log(nil), f.logs...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].ID < sorted[j].ID
+		}
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	var out []usage.Log
+	for _, log := range sorted {
+		if log.UserID != userID {
+			continue
+		}
+		if !log.CreatedAt.Before(start) && log.CreatedAt.Before(end) {
+			out = append(out, log)
+			if limit > 0 && len(out) >= limit {
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *UsageLog) DeleteOldLogs(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var kept []usage.Log
+	var deleted int64
+	for _, log := range f.logs {
+		if log.CreatedAt.Before(cutoff) && deleted < int64(limit) {
+			deleted++
+			continue
+		}
+		kept = append(kept, log)
+	}
+	f.logs = kept
+	return deleted, nil
+}
+
+func (f *UsageLog) SelectOldLogs(ctx context.Context, cutoff time.Time, limit int) ([]usage.Log, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sorted := append([]usage.Log(nil), f.logs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	var out []usage.Log
+	for _, log := range sorted {
+		if log.CreatedAt.Before(cutoff) {
+			out = append(out, log)
+			if len(out) >= limit {
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *UsageLog) DeleteByIDs(ctx context.Context, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	remove := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		remove[id] = true
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var kept []usage.Log
+	var deleted int64
+	for _, log := range f.logs {
+		if remove[log.ID] {
+			deleted++
+			continue
+		}
+		kept = append(kept, log)
+	}
+	f.logs = kept
+	return deleted, nil
+}
@@ -0,0 +1,7 @@
+package daofake
+
+import "testing"
+
+func TestUsageLogConformsToUsageDAOInterface(t *testing.T) {
+	ConformUsageLog(t, NewUsageLog())
+}
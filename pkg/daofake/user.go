@@ -0,0 +1,205 @@
+package daofake
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/pagination"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// User is an in-memory user.DAOInterface.
+type User struct {
+	mu    sync.Mutex
+	users map[string]*user.User
+}
+
+var _ user.DAOInterface = (*User)(nil)
+
+// NewUser returns an empty fake.
+func NewUser() *User {
+	return &User{users: make(map[string]*user.User)}
+}
+
+func (f *User) List(ctx context.Context, filter user.ListFilter) (pagination.Page[*user.User], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var matched []*user.User
+	for _, u := range f.users {
+		if filter.Status != "" && u.Status != filter.Status {
+			continue
+		}
+		if filter.Plan != "" && u.Plan != filter.Plan {
+			continue
+		}
+		matched = append(matched, u)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].CreatedAt.Before(matched[j].CreatedAt) })
+	if filter.Page.SortDesc {
+		for i, j := 0, len(matched)-1; i < j; i, j = i+1, j-1 {
+			matched[i], matched[j] = matched[j], matched[i]
+		}
+	}
+
+	limit := filter.Page.Limit
+	if limit <= 0 {
+		limit = pagination.DefaultLimit
+	}
+	total := len(matched)
+	start := filter.Page.Offset
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+
+	out := make([]*user.User, end-start)
+	for i, u := range matched[start:end] {
+		out[i] = cloneUser(u)
+	}
+	return pagination.Page[*user.User]{Items: out, Total: total, Limit: limit, Offset: filter.Page.Offset}, nil
+}
+
+func (f *User) Get(ctx context.Context, id string) (*user.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[id]
+	if !ok {
+		return nil, user.ErrNotFound
+	}
+	return cloneUser(u), nil
+}
+
+func (f *User) GetByEmail(ctx context.Context, email string) (*user.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, u := range f.users {
+		if u.Email == email {
+			return cloneUser(u), nil
+		}
+	}
+	return nil, user.ErrNotFound
+}
+
+func (f *User) Create(ctx context.Context, email, passwordHash string, plan user.Plan) (*user.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now().UTC()
+	u := &user.User{
+		ID:           uuid.NewString(),
+		Email:        email,
+		PasswordHash: passwordHash,
+		Status:       user.StatusActive,
+		Plan:         plan,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	f.users[u.ID] = u
+	return cloneUser(u), nil
+}
+
+func (f *User) CreateSubAccount(ctx context.Context, parentUserID, email, passwordHash string, plan user.Plan) (*user.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now().UTC()
+	u := &user.User{
+		ID:           uuid.NewString(),
+		Email:        email,
+		PasswordHash: passwordHash,
+		Status:       user.StatusActive,
+		Plan:         plan,
+		ParentUserID: parentUserID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	f.users[u.ID] = u
+	return cloneUser(u), nil
+}
+
+func (f *User) ListByParent(ctx context.Context, parentUserID string) ([]*user.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []*user.User
+	for _, u := range f.users {
+		if u.ParentUserID == parentUserID {
+			out = append(out, cloneUser(u))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (f *User) CountByStatus(ctx context.Context) (map[user.Status]int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	counts := make(map[user.Status]int64)
+	for _, u := range f.users {
+		counts[u.Status]++
+	}
+	return counts, nil
+}
+
+func (f *User) UpdateStatus(ctx context.Context, id string, status user.Status) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[id]
+	if !ok {
+		return user.ErrNotFound
+	}
+	u.Status = status
+	u.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (f *User) UpdatePlan(ctx context.Context, id string, plan user.Plan) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[id]
+	if !ok {
+		return user.ErrNotFound
+	}
+	u.Plan = plan
+	u.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (f *User) SoftDelete(ctx context.Context, id string) error {
+	return f.UpdateStatus(ctx, id, user.StatusDeleted)
+}
+
+func (f *User) ResetPassword(ctx context.Context, id string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[id]
+	if !ok {
+		return "", user.ErrNotFound
+	}
+	raw := uuid.NewString()
+	u.PasswordHash = "fake-hash:" + raw
+	u.UpdatedAt = time.Now().UTC()
+	return raw, nil
+}
+
+func (f *User) SetPasswordHash(ctx context.Context, id, passwordHash string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[id]
+	if !ok {
+		return user.ErrNotFound
+	}
+	u.PasswordHash = passwordHash
+	u.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func cloneUser(u *user.User) *user.User {
+	cp := *u
+	return &cp
+}
@@ -0,0 +1,7 @@
+package daofake
+
+import "testing"
+
+func TestUserConformsToUserDAOInterface(t *testing.T) {
+	ConformUser(t, NewUser())
+}
@@ -0,0 +1,36 @@
+// Package dbtx lets DAOs run either standalone against a *sql.DB or
+// inside a shared transaction, so a service layer composing several
+// DAOs can make a multi-step operation atomic without each DAO knowing
+// about transactions itself.
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Queryer is the subset of *sql.DB's API a DAO needs. Both *sql.DB and
+// *sql.Tx satisfy it, so a DAO built around Queryer works unchanged
+// whether it's given the pool directly or a transaction via WithTx.
+type Queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Run is a unit-of-work helper: it begins a transaction on db, calls fn
+// with it, and commits if fn returns nil or rolls back otherwise. Pass
+// the *sql.Tx to each DAO's WithTx so every operation inside fn shares
+// it.
+func Run(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,14 @@
+package envelope
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashDomain derives a stable, non-reversible identifier for a domain so
+// aggregate reporting (top targets, per-domain counts) can run over
+// encrypted usage logs without ever decrypting TargetHost.
+func HashDomain(domain string) string {
+	sum := sha256.Sum256([]byte(domain))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,104 @@
+// Package envelope implements envelope encryption for tenant-sensitive
+// fields: each tenant gets its own data key, which is itself encrypted
+// ("wrapped") under a single master key so only the wrapped form needs
+// to be stored at rest.
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+// ErrCiphertextTooShort is returned when decrypting a blob that is
+// missing its nonce.
+var ErrCiphertextTooShort = errors.New("envelope: ciphertext too short")
+
+// MasterKeyProvider supplies the root key used to wrap/unwrap tenant
+// data keys. A real deployment backs this with a KMS; pkg/secrets is
+// expected to provide that once it exists.
+type MasterKeyProvider interface {
+	MasterKey() ([]byte, error)
+}
+
+// StaticMasterKey is a MasterKeyProvider backed by a fixed key, useful
+// for local development and tests.
+type StaticMasterKey []byte
+
+func (k StaticMasterKey) MasterKey() ([]byte, error) { return []byte(k), nil }
+
+// GenerateDataKey creates a new random 256-bit data key and returns both
+// its plaintext (to use immediately) and its ciphertext wrapped under
+// the master key (to persist).
+func GenerateDataKey(master MasterKeyProvider) (plaintext, wrapped []byte, err error) {
+	plaintext = make([]byte, 32)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, nil, err
+	}
+	wrapped, err = wrap(master, plaintext)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plaintext, wrapped, nil
+}
+
+// UnwrapDataKey decrypts a wrapped data key using the master key.
+func UnwrapDataKey(master MasterKeyProvider, wrapped []byte) ([]byte, error) {
+	key, err := master.MasterKey()
+	if err != nil {
+		return nil, err
+	}
+	return aesGCMOpen(key, wrapped)
+}
+
+func wrap(master MasterKeyProvider, plaintext []byte) ([]byte, error) {
+	key, err := master.MasterKey()
+	if err != nil {
+		return nil, err
+	}
+	return aesGCMSeal(key, plaintext)
+}
+
+// Encrypt encrypts plaintext under a tenant's data key.
+func Encrypt(dataKey, plaintext []byte) ([]byte, error) {
+	return aesGCMSeal(dataKey, plaintext)
+}
+
+// Decrypt decrypts a blob previously produced by Encrypt.
+func Decrypt(dataKey, ciphertext []byte) ([]byte, error) {
+	return aesGCMOpen(dataKey, ciphertext)
+}
+
+func aesGCMSeal(key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func aesGCMOpen(key, ciphertext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, ErrCiphertextTooShort
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, sealed, nil)
+}
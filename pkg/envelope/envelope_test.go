@@ -0,0 +1,54 @@
+package envelope
+
+import "testing"
+
+func TestGenerateDataKeyRoundTrip(t *testing.T) {
+	master := StaticMasterKey(make([]byte, 32))
+
+	plaintext, wrapped, err := GenerateDataKey(master)
+	if err != nil {
+		t.Fatalf("GenerateDataKey: %v", err)
+	}
+
+	unwrapped, err := UnwrapDataKey(master, wrapped)
+	if err != nil {
+		t.Fatalf("UnwrapDataKey: %v", err)
+	}
+
+	if string(unwrapped) != string(plaintext) {
+		t.Fatal("unwrapped data key does not match original")
+	}
+}
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	master := StaticMasterKey(make([]byte, 32))
+	dataKey, _, err := GenerateDataKey(master)
+	if err != nil {
+		t.Fatalf("GenerateDataKey: %v", err)
+	}
+
+	ciphertext, err := Encrypt(dataKey, []byte("example.com"))
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if string(ciphertext) == "example.com" {
+		t.Fatal("expected ciphertext to differ from plaintext")
+	}
+
+	plaintext, err := Decrypt(dataKey, ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if string(plaintext) != "example.com" {
+		t.Fatalf("expected example.com, got %q", plaintext)
+	}
+}
+
+func TestHashDomainIsStable(t *testing.T) {
+	if HashDomain("example.com") != HashDomain("example.com") {
+		t.Fatal("expected HashDomain to be deterministic")
+	}
+	if HashDomain("example.com") == HashDomain("example.org") {
+		t.Fatal("expected different domains to hash differently")
+	}
+}
@@ -0,0 +1,126 @@
+package envelope
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+)
+
+// Manager issues and caches per-tenant data keys backed by a
+// tenant_data_keys table, unwrapping them with a MasterKeyProvider on
+// first use.
+type Manager struct {
+	db     *sql.DB
+	master MasterKeyProvider
+
+	mu    sync.RWMutex
+	cache map[string][]byte // tenantID -> plaintext data key
+}
+
+// NewManager creates a Manager over db, using master to wrap/unwrap
+// tenant data keys.
+func NewManager(db *sql.DB, master MasterKeyProvider) *Manager {
+	return &Manager{db: db, master: master, cache: make(map[string][]byte)}
+}
+
+// DataKey returns the plaintext data key for tenantID, creating one (and
+// persisting its wrapped form) if this is the tenant's first use.
+func (m *Manager) DataKey(ctx context.Context, tenantID string) ([]byte, error) {
+	m.mu.RLock()
+	key, ok := m.cache[tenantID]
+	m.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	wrapped, err := m.fetchWrappedKey(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if wrapped == nil {
+		key, wrapped, err = GenerateDataKey(m.master)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := m.db.ExecContext(ctx,
+			`INSERT INTO tenant_data_keys (tenant_id, wrapped_key) VALUES (?, ?)`,
+			tenantID, wrapped); err != nil {
+			if !isDuplicateKeyErr(err) {
+				return nil, err
+			}
+			// Another concurrent first-ever DataKey call for tenantID won
+			// the insert race; re-read its row instead of failing ours.
+			wrapped, err = m.fetchWrappedKey(ctx, tenantID)
+			if err != nil {
+				return nil, err
+			}
+			if wrapped == nil {
+				return nil, sql.ErrNoRows
+			}
+			key, err = UnwrapDataKey(m.master, wrapped)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		key, err = UnwrapDataKey(m.master, wrapped)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m.mu.Lock()
+	m.cache[tenantID] = key
+	m.mu.Unlock()
+	return key, nil
+}
+
+// fetchWrappedKey returns tenantID's wrapped key, or nil (with no
+// error) if it has none yet.
+func (m *Manager) fetchWrappedKey(ctx context.Context, tenantID string) ([]byte, error) {
+	row := m.db.QueryRowContext(ctx,
+		`SELECT wrapped_key FROM tenant_data_keys WHERE tenant_id = ?`, tenantID)
+	var wrapped []byte
+	switch err := row.Scan(&wrapped); err {
+	case nil:
+		return wrapped, nil
+	case sql.ErrNoRows:
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+// isDuplicateKeyErr reports whether err is a MySQL duplicate-key
+// violation, matched on the driver's error text the same way
+// pkg/ipallowlist does, rather than its *mysql.MySQLError type.
+func isDuplicateKeyErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "Duplicate entry")
+}
+
+// TenantEncryptionDAO tracks which tenants have opted into field-level
+// encryption for enterprise contracts.
+type TenantEncryptionDAO struct {
+	db *sql.DB
+}
+
+// NewTenantEncryptionDAO wraps an existing *sql.DB handle.
+func NewTenantEncryptionDAO(db *sql.DB) *TenantEncryptionDAO {
+	return &TenantEncryptionDAO{db: db}
+}
+
+// IsEnabled reports whether tenantID has field-level encryption enabled.
+func (d *TenantEncryptionDAO) IsEnabled(ctx context.Context, tenantID string) (bool, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT 1 FROM tenant_encryption_settings WHERE tenant_id = ? AND enabled = TRUE`, tenantID)
+	var exists int
+	switch err := row.Scan(&exists); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -0,0 +1,70 @@
+// Package eventbus lets services publish and subscribe to lightweight
+// domain events (a proxy was discovered, a user registered, ...)
+// instead of polling each other's tables, so the crawler, notifier,
+// and scorer can react to what happened elsewhere without depending on
+// each other directly.
+//
+// Publisher and Subscriber are backend-agnostic; the only
+// implementation today is RedisBus, built on Redis Streams consumer
+// groups, rather than Kafka or NSQ as this ticket first proposed: this
+// module vendors neither client library, and adding one just for this
+// would be a new third-party dependency for a single ticket. Redis
+// Streams gives the same at-least-once, consumer-group delivery
+// semantics on top of the Redis deployment every service already
+// depends on (pkg/redis). Swapping in a Kafka or NSQ backend later only
+// means adding another Publisher/Subscriber implementation; no caller
+// would need to change.
+package eventbus
+
+import "context"
+
+// Type identifies what a domain event describes.
+type Type string
+
+const (
+	// EventProxyDiscovered fires when a new proxy is added to the pool,
+	// whether by the free-crawler, a paid-provider sync, or a manual
+	// admin import.
+	EventProxyDiscovered Type = "proxy.discovered"
+	// EventProxyDeactivated fires when a proxy is marked dead after
+	// exceeding its consecutive-failure threshold.
+	EventProxyDeactivated Type = "proxy.deactivated"
+	// EventUserRegistered fires when a new account is created.
+	EventUserRegistered Type = "user.registered"
+	// EventSubscriptionExpired fires when a paid subscription ends and
+	// the user's plan is downgraded back to free.
+	EventSubscriptionExpired Type = "subscription.expired"
+	// EventUsageRecorded fires when a batch of usage logs is flushed to
+	// MySQL, summarizing the batch rather than firing once per request.
+	EventUsageRecorded Type = "usage.recorded"
+)
+
+// Event is a single domain event. Fields carries whatever scalar data
+// subscribers need (IDs, counts, plan names); both Publisher and
+// Subscriber implementations round-trip it as strings, so callers
+// should format and parse values themselves rather than relying on a
+// particular backend preserving richer types.
+type Event struct {
+	Type   Type
+	Fields map[string]string
+}
+
+// Publisher emits domain events for subscribers to react to.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Handler processes a single delivered Event. Returning an error leaves
+// the event unacknowledged so a Subscriber redelivers it on a later
+// poll, typically to a different consumer in the same group.
+type Handler func(ctx context.Context, event Event) error
+
+// Subscriber delivers events of eventType to handler, one at a time,
+// until ctx is canceled or an unrecoverable error occurs. group
+// identifies this subscriber's consumer group: every distinct group
+// subscribed to eventType receives every event independently, but
+// within one group an event is delivered to exactly one member, so
+// several instances of the same service can share the load.
+type Subscriber interface {
+	Subscribe(ctx context.Context, eventType Type, group string, handler Handler) error
+}
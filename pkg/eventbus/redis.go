@@ -0,0 +1,133 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// DefaultStreamMaxLen bounds how many entries a RedisBus lets each
+// event-type stream grow to; XADD trims older entries once it's
+// exceeded. Entries are acked and consumed promptly under normal
+// operation, so this only guards against an unbounded stream when no
+// subscriber is running.
+const DefaultStreamMaxLen = 100_000
+
+// pollBlockDuration is how long a single XReadGroup call waits for a
+// new entry before returning empty, bounding how long Subscribe takes
+// to notice ctx has been canceled.
+const pollBlockDuration = 5 * time.Second
+
+// pollBatchSize bounds how many undelivered entries Subscribe claims
+// per XReadGroup call.
+const pollBatchSize = 50
+
+// RedisBus implements Publisher and Subscriber on top of Redis Streams:
+// each Type gets its own stream, and each subscriber group gets its own
+// consumer group on that stream.
+type RedisBus struct {
+	client goredis.UniversalClient
+}
+
+// NewRedisBus builds a RedisBus backed by client.
+func NewRedisBus(client goredis.UniversalClient) *RedisBus {
+	return &RedisBus{client: client}
+}
+
+func streamKey(t Type) string {
+	return "events:" + string(t)
+}
+
+// Publish appends event to its type's stream.
+func (b *RedisBus) Publish(ctx context.Context, event Event) error {
+	values := make([]interface{}, 0, len(event.Fields)*2)
+	for k, v := range event.Fields {
+		values = append(values, k, v)
+	}
+	return b.client.XAdd(ctx, &goredis.XAddArgs{
+		Stream: streamKey(event.Type),
+		MaxLen: DefaultStreamMaxLen,
+		Approx: true,
+		Values: values,
+	}).Err()
+}
+
+// Subscribe creates group on eventType's stream if it doesn't already
+// exist, starting from entries published after this call, then polls
+// for and delivers entries to handler until ctx is canceled.
+func (b *RedisBus) Subscribe(ctx context.Context, eventType Type, group string, handler Handler) error {
+	stream := streamKey(eventType)
+	if err := b.client.XGroupCreateMkStream(ctx, stream, group, "$").Err(); err != nil && !isBusyGroupErr(err) {
+		return fmt.Errorf("eventbus: create consumer group %s on %s: %w", group, stream, err)
+	}
+
+	consumer := consumerName()
+	for {
+		streams, err := b.client.XReadGroup(ctx, &goredis.XReadGroupArgs{
+			Group:    group,
+			Consumer: consumer,
+			Streams:  []string{stream, ">"},
+			Count:    pollBatchSize,
+			Block:    pollBlockDuration,
+		}).Result()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, goredis.Nil) {
+				continue
+			}
+			log.Printf("eventbus: read from %s failed: %v", stream, err)
+			continue
+		}
+
+		for _, s := range streams {
+			for _, msg := range s.Messages {
+				event := Event{Type: eventType, Fields: toFields(msg.Values)}
+				if err := handler(ctx, event); err != nil {
+					log.Printf("eventbus: handler for %s failed, leaving %s unacked: %v", eventType, msg.ID, err)
+					continue
+				}
+				if err := b.client.XAck(ctx, stream, group, msg.ID).Err(); err != nil {
+					log.Printf("eventbus: failed to ack %s on %s: %v", msg.ID, stream, err)
+				}
+			}
+		}
+	}
+}
+
+// isBusyGroupErr reports whether err is Redis's "BUSYGROUP" response to
+// XGROUP CREATE on a group that already exists, which Subscribe treats
+// as success rather than a failure.
+func isBusyGroupErr(err error) bool {
+	return strings.Contains(err.Error(), "BUSYGROUP")
+}
+
+// consumerName builds a consumer identity unique to this process, so
+// multiple instances of the same service reading the same group don't
+// collide.
+func consumerName() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return host + "-" + strconv.Itoa(os.Getpid())
+}
+
+// toFields converts the map[string]interface{} go-redis decodes a
+// stream entry's values into, back to the map[string]string an Event
+// carries.
+func toFields(values map[string]interface{}) map[string]string {
+	fields := make(map[string]string, len(values))
+	for k, v := range values {
+		fields[k] = fmt.Sprintf("%v", v)
+	}
+	return fields
+}
@@ -0,0 +1,30 @@
+package eventbus
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestStreamKey(t *testing.T) {
+	if got, want := streamKey(EventProxyDiscovered), "events:proxy.discovered"; got != want {
+		t.Errorf("streamKey(%q) = %q, want %q", EventProxyDiscovered, got, want)
+	}
+}
+
+func TestIsBusyGroupErr(t *testing.T) {
+	if !isBusyGroupErr(errors.New("BUSYGROUP Consumer Group name already exists")) {
+		t.Error("isBusyGroupErr = false for a BUSYGROUP error")
+	}
+	if isBusyGroupErr(errors.New("connection refused")) {
+		t.Error("isBusyGroupErr = true for an unrelated error")
+	}
+}
+
+func TestToFields(t *testing.T) {
+	got := toFields(map[string]interface{}{"proxy_id": "abc123", "count": int64(5)})
+	want := map[string]string{"proxy_id": "abc123", "count": "5"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("toFields = %#v, want %#v", got, want)
+	}
+}
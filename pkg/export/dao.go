@@ -0,0 +1,135 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotFound is returned when the requested export job doesn't exist.
+var ErrNotFound = errors.New("export: job not found")
+
+// DAO persists export jobs in MySQL.
+type DAO struct {
+	db *sql.DB
+}
+
+// NewDAO wraps an existing *sql.DB handle.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db}
+}
+
+// Enqueue queues job for processing, assigning it an ID and pending
+// status.
+func (d *DAO) Enqueue(ctx context.Context, job *Job) error {
+	job.ID = uuid.NewString()
+	job.Status = StatusPending
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO usage_export_jobs (id, user_id, format, range_start, range_end, status)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		job.ID, job.UserID, string(job.Format), job.RangeStart, job.RangeEnd, string(StatusPending))
+	return err
+}
+
+// Get returns a single export job by ID, or ErrNotFound if it doesn't
+// exist.
+func (d *DAO) Get(ctx context.Context, id string) (*Job, error) {
+	row := d.db.QueryRowContext(ctx, `
+		SELECT id, user_id, format, range_start, range_end, status, object_key, row_count, size_bytes, error, created_at, completed_at
+		  FROM usage_export_jobs
+		 WHERE id = ?`, id)
+	job, err := scanJob(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return job, err
+}
+
+// ListByUser returns a user's export jobs, newest first.
+func (d *DAO) ListByUser(ctx context.Context, userID string) ([]*Job, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT id, user_id, format, range_start, range_end, status, object_key, row_count, size_bytes, error, created_at, completed_at
+		  FROM usage_export_jobs
+		 WHERE user_id = ?
+		 ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, job)
+	}
+	return out, rows.Err()
+}
+
+// ClaimBatch returns up to limit pending jobs, oldest first, for the
+// worker to process.
+func (d *DAO) ClaimBatch(ctx context.Context, limit int) ([]*Job, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT id, user_id, format, range_start, range_end, status, object_key, row_count, size_bytes, error, created_at, completed_at
+		  FROM usage_export_jobs
+		 WHERE status = ?
+		 ORDER BY id
+		 LIMIT ?`, string(StatusPending), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, job)
+	}
+	return out, rows.Err()
+}
+
+// MarkComplete records a successful export.
+func (d *DAO) MarkComplete(ctx context.Context, id, objectKey string, rowCount int, sizeBytes int64) error {
+	_, err := d.db.ExecContext(ctx,
+		`UPDATE usage_export_jobs
+		    SET status = ?, object_key = ?, row_count = ?, size_bytes = ?, completed_at = ?
+		  WHERE id = ?`,
+		string(StatusComplete), objectKey, rowCount, sizeBytes, time.Now().UTC(), id)
+	return err
+}
+
+// MarkFailed records why a job couldn't be processed.
+func (d *DAO) MarkFailed(ctx context.Context, id, errMsg string) error {
+	_, err := d.db.ExecContext(ctx,
+		`UPDATE usage_export_jobs SET status = ?, error = ?, completed_at = ? WHERE id = ?`,
+		string(StatusFailed), errMsg, time.Now().UTC(), id)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanJob(row rowScanner) (*Job, error) {
+	j := &Job{}
+	var format, status string
+	var completedAt sql.NullTime
+	if err := row.Scan(&j.ID, &j.UserID, &format, &j.RangeStart, &j.RangeEnd, &status,
+		&j.ObjectKey, &j.RowCount, &j.SizeBytes, &j.Error, &j.CreatedAt, &completedAt); err != nil {
+		return nil, err
+	}
+	j.Format = Format(format)
+	j.Status = Status(status)
+	if completedAt.Valid {
+		j.CompletedAt = &completedAt.Time
+	}
+	return j, nil
+}
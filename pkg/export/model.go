@@ -0,0 +1,45 @@
+// Package export lets a user pull their own usage_logs rows out as a
+// downloadable file. A request enqueues a Job in MySQL; a separate
+// Worker (mirroring pkg/notify's outbox/worker split) claims pending
+// jobs, streams the matching rows to object storage, and records where
+// they landed, so the HTTP request that created the job never blocks on
+// a potentially large export.
+package export
+
+import "time"
+
+// Format is the file format a Job is rendered as.
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// Status is a Job's processing state.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusComplete Status = "complete"
+	StatusFailed   Status = "failed"
+)
+
+// Job is one user's request to export their usage_logs rows in
+// [RangeStart, RangeEnd) as Format. ObjectKey, RowCount and SizeBytes
+// are populated once Worker finishes processing it; Error is populated
+// if processing failed.
+type Job struct {
+	ID          string
+	UserID      string
+	Format      Format
+	RangeStart  time.Time
+	RangeEnd    time.Time
+	Status      Status
+	ObjectKey   string
+	RowCount    int
+	SizeBytes   int64
+	Error       string
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
@@ -0,0 +1,157 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/objstore"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+)
+
+// batchSize bounds how many pending jobs Worker claims per pass.
+const batchSize = 10
+
+var csvHeader = []string{"id", "target_host", "protocol", "bytes_in", "bytes_out", "status_code", "duration_ms", "created_at"}
+
+// Worker drains pending export jobs: for each, it streams the matching
+// usage_logs rows from MySQL, renders them in the requested format, and
+// uploads the result to object storage.
+type Worker struct {
+	jobs    *DAO
+	usage   *usage.DAO
+	objects *objstore.Client
+	// maxRows bounds how many rows a single job reads. The caller that
+	// enqueued the job has already checked this against the user's plan
+	// limit; this is a backstop against a job that slipped through with
+	// no limit applied.
+	maxRows int
+}
+
+// NewWorker builds a Worker. maxRows bounds how many rows any single job
+// reads, regardless of plan.
+func NewWorker(jobs *DAO, usageDAO *usage.DAO, objects *objstore.Client, maxRows int) *Worker {
+	return &Worker{jobs: jobs, usage: usageDAO, objects: objects, maxRows: maxRows}
+}
+
+// Run drains pending jobs on a ticker until ctx is canceled, logging
+// (but not stopping on) per-pass errors.
+func (w *Worker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.ProcessBatch(ctx); err != nil {
+				log.Printf("export: pass failed: %v", err)
+			}
+		}
+	}
+}
+
+// ProcessBatch claims up to batchSize pending jobs and processes each,
+// marking it complete or failed.
+func (w *Worker) ProcessBatch(ctx context.Context) error {
+	jobs, err := w.jobs.ClaimBatch(ctx, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if err := w.process(ctx, job); err != nil {
+			log.Printf("export: job %s failed: %v", job.ID, err)
+			if markErr := w.jobs.MarkFailed(ctx, job.ID, err.Error()); markErr != nil {
+				log.Printf("export: failed to record failure for %s: %v", job.ID, markErr)
+			}
+		}
+	}
+	return nil
+}
+
+func (w *Worker) process(ctx context.Context, job *Job) error {
+	logs, err := w.usage.SelectForExport(ctx, job.UserID, job.RangeStart, job.RangeEnd, w.maxRows)
+	if err != nil {
+		return fmt.Errorf("select rows: %w", err)
+	}
+
+	var (
+		payload     []byte
+		contentType string
+	)
+	switch job.Format {
+	case FormatCSV:
+		payload, err = encodeCSV(logs)
+		contentType = "text/csv"
+	case FormatNDJSON:
+		payload, err = encodeNDJSON(logs)
+		contentType = "application/x-ndjson"
+	default:
+		return fmt.Errorf("unsupported format %q", job.Format)
+	}
+	if err != nil {
+		return fmt.Errorf("encode export: %w", err)
+	}
+
+	key := objectKey(job)
+	if err := w.objects.PutObject(ctx, key, payload, contentType); err != nil {
+		return fmt.Errorf("upload export: %w", err)
+	}
+
+	if err := w.jobs.MarkComplete(ctx, job.ID, key, len(logs), int64(len(payload))); err != nil {
+		return fmt.Errorf("record completion: %w", err)
+	}
+	return nil
+}
+
+func objectKey(job *Job) string {
+	ext := "csv"
+	if job.Format == FormatNDJSON {
+		ext = "ndjson"
+	}
+	return fmt.Sprintf("usage-exports/%s/%s.%s", job.UserID, job.ID, ext)
+}
+
+func encodeCSV(logs []usage.Log) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	for _, entry := range logs {
+		if err := writer.Write([]string{
+			strconv.FormatInt(entry.ID, 10),
+			entry.TargetHost,
+			entry.Protocol,
+			strconv.FormatInt(entry.BytesIn, 10),
+			strconv.FormatInt(entry.BytesOut, 10),
+			strconv.Itoa(entry.StatusCode),
+			strconv.FormatInt(entry.DurationMS, 10),
+			entry.CreatedAt.UTC().Format(time.RFC3339),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeNDJSON(logs []usage.Log) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range logs {
+		if err := encoder.Encode(entry); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
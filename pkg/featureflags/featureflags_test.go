@@ -0,0 +1,45 @@
+package featureflags
+
+import "testing"
+
+func TestBucketIsDeterministic(t *testing.T) {
+	a := bucket("new_scheduler", "user-123")
+	b := bucket("new_scheduler", "user-123")
+	if a != b {
+		t.Errorf("bucket returned different values for the same input: %d != %d", a, b)
+	}
+	if a < 0 || a >= 100 {
+		t.Errorf("bucket = %d, want [0, 100)", a)
+	}
+}
+
+func TestBucketVariesByFlagName(t *testing.T) {
+	userID := "user-123"
+	same := true
+	for _, name := range []string{"flag_a", "flag_b", "flag_c", "flag_d"} {
+		if bucket(name, userID) != bucket("flag_a", userID) {
+			same = false
+		}
+	}
+	if same {
+		t.Error("bucket returned the same value for every flag name; rollouts would be perfectly correlated")
+	}
+}
+
+func TestRolloutBoundaries(t *testing.T) {
+	f := &Flag{Name: "x", RolloutPercent: 0}
+	if rollout(f, "anyone") {
+		t.Error("rollout at 0% enabled a user")
+	}
+
+	f.RolloutPercent = 100
+	if !rollout(f, "anyone") {
+		t.Error("rollout at 100% excluded a user")
+	}
+}
+
+func TestOverrideKeyIsNamespaced(t *testing.T) {
+	if got, want := overrideKey("new_scheduler", "user-1"), "featureflags:override:new_scheduler:user-1"; got != want {
+		t.Errorf("overrideKey = %q, want %q", got, want)
+	}
+}
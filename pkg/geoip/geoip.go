@@ -0,0 +1,92 @@
+// Package geoip resolves country, city, ASN and ISP data for an IP
+// address, for enriching the proxy inventory with geo metadata.
+package geoip
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/oschwald/geoip2-golang"
+)
+
+// ErrNotFound is returned when a lookup has no data for the given IP.
+var ErrNotFound = errors.New("geoip: no record for ip")
+
+// Record is the geo data enrichment cares about for a single IP.
+type Record struct {
+	CountryCode string // ISO 3166-1 alpha-2
+	City        string
+	ASN         int
+	ISP         string
+}
+
+// Lookuper resolves geo data for an IP address. Reader (a local MaxMind
+// database) and OnlineClient (an HTTP fallback) both implement it, so
+// the enrichment worker can fall back to one when the other misses.
+type Lookuper interface {
+	Lookup(ctx context.Context, ip net.IP) (Record, error)
+}
+
+// Reader resolves geo data from local MaxMind City and ASN databases.
+type Reader struct {
+	city *geoip2.Reader
+	asn  *geoip2.Reader
+}
+
+var _ Lookuper = (*Reader)(nil)
+
+// Open loads MaxMind City and ASN databases from disk. asnDBPath may be
+// empty, in which case Lookup returns country/city only.
+func Open(cityDBPath, asnDBPath string) (*Reader, error) {
+	city, err := geoip2.Open(cityDBPath)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Reader{city: city}
+	if asnDBPath != "" {
+		asn, err := geoip2.Open(asnDBPath)
+		if err != nil {
+			city.Close()
+			return nil, err
+		}
+		r.asn = asn
+	}
+	return r, nil
+}
+
+// Close releases the underlying database files.
+func (r *Reader) Close() error {
+	if r.asn != nil {
+		r.asn.Close()
+	}
+	return r.city.Close()
+}
+
+// Lookup returns country/city (and ASN/ISP, if an ASN database was
+// opened) for ip. ctx is accepted only to satisfy Lookuper; local
+// database reads are synchronous and never block on it.
+func (r *Reader) Lookup(ctx context.Context, ip net.IP) (Record, error) {
+	city, err := r.city.City(ip)
+	if err != nil {
+		return Record{}, err
+	}
+	if city.Country.IsoCode == "" {
+		return Record{}, ErrNotFound
+	}
+
+	rec := Record{
+		CountryCode: city.Country.IsoCode,
+		City:        city.City.Names["en"],
+	}
+
+	if r.asn != nil {
+		if asn, err := r.asn.ASN(ip); err == nil {
+			rec.ASN = int(asn.AutonomousSystemNumber)
+			rec.ISP = asn.AutonomousSystemOrganization
+		}
+	}
+
+	return rec, nil
+}
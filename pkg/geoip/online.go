@@ -0,0 +1,78 @@
+package geoip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// DefaultOnlineBaseURL is ip-api.com's free geolocation endpoint, used
+// as a fallback when the local MaxMind database has no record for an
+// IP (e.g. it's stale, or the IP was allocated after the database was
+// built).
+const DefaultOnlineBaseURL = "http://ip-api.com/json"
+
+// OnlineClient is an HTTP-backed Lookuper.
+type OnlineClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+var _ Lookuper = (*OnlineClient)(nil)
+
+// NewOnlineClient builds an OnlineClient against DefaultOnlineBaseURL.
+func NewOnlineClient() *OnlineClient {
+	return &OnlineClient{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    DefaultOnlineBaseURL,
+	}
+}
+
+type onlineLookupResponse struct {
+	Status      string `json:"status"`
+	CountryCode string `json:"countryCode"`
+	City        string `json:"city"`
+	As          string `json:"as"`
+	ISP         string `json:"isp"`
+}
+
+// Lookup queries the configured online geolocation API for ip.
+func (c *OnlineClient) Lookup(ctx context.Context, ip net.IP) (Record, error) {
+	url := fmt.Sprintf("%s/%s?fields=status,countryCode,city,as,isp", c.baseURL, ip.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Record{}, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return Record{}, err
+	}
+	defer resp.Body.Close()
+
+	var out onlineLookupResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return Record{}, err
+	}
+	if out.Status != "success" {
+		return Record{}, ErrNotFound
+	}
+
+	return Record{
+		CountryCode: out.CountryCode,
+		City:        out.City,
+		ASN:         parseASN(out.As),
+		ISP:         out.ISP,
+	}, nil
+}
+
+// parseASN extracts the numeric ASN from a string like
+// "AS3320 Deutsche Telekom AG"; it returns 0 if no ASN prefix is found.
+func parseASN(as string) int {
+	var n int
+	fmt.Sscanf(as, "AS%d", &n)
+	return n
+}
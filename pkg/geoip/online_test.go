@@ -0,0 +1,21 @@
+package geoip
+
+import "testing"
+
+func TestParseASN(t *testing.T) {
+	cases := []struct {
+		as   string
+		want int
+	}{
+		{"AS3320 Deutsche Telekom AG", 3320},
+		{"AS15169 Google LLC", 15169},
+		{"", 0},
+		{"not an asn", 0},
+	}
+
+	for _, c := range cases {
+		if got := parseASN(c.as); got != c.want {
+			t.Errorf("parseASN(%q) = %d, want %d", c.as, got, c.want)
+		}
+	}
+}
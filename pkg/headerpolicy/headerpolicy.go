@@ -0,0 +1,160 @@
+// Package headerpolicy lets operators configure, per user, how the
+// gateway rewrites a request's headers before it reaches the upstream
+// proxy: which headers to strip, which to inject, and whether to
+// randomize User-Agent from a fixed pool. It only applies to plain HTTP
+// forwarding (services/gateway/internal/engine/forward.go); a CONNECT
+// tunnel is opaque bytes once established and can't have its headers
+// rewritten.
+package headerpolicy
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+)
+
+// ErrNotFound is returned by Get when the user has no configured
+// header policy.
+var ErrNotFound = errors.New("headerpolicy: not found")
+
+// Policy describes how a user's outbound requests should be rewritten.
+// A zero Policy changes nothing.
+type Policy struct {
+	UserID string
+	// StripHeaders lists header names (e.g. "Via", "X-Forwarded-For")
+	// removed from the outbound request before it's forwarded.
+	StripHeaders []string
+	// InjectHeaders are set on the outbound request, overwriting
+	// whatever value the client sent (if any).
+	InjectHeaders map[string]string
+	// RandomizeUserAgent, if true, replaces the outbound User-Agent
+	// with one drawn at random from Pool.
+	RandomizeUserAgent bool
+}
+
+// Pool is the fixed set of User-Agent strings RandomizeUserAgent draws
+// from, covering a spread of common desktop and mobile browsers so
+// randomized traffic doesn't cluster on one fingerprint.
+var Pool = []string{
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15",
+	"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:125.0) Gecko/20100101 Firefox/125.0",
+	"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1",
+	"Mozilla/5.0 (Linux; Android 14) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36",
+}
+
+// RandomUserAgent returns a random entry from Pool.
+func RandomUserAgent() string {
+	return Pool[rand.Intn(len(Pool))]
+}
+
+// Apply rewrites req's headers in place per p: stripping StripHeaders,
+// setting InjectHeaders, and randomizing User-Agent if requested. A nil
+// p leaves req untouched.
+func Apply(req *http.Request, p *Policy) {
+	if p == nil {
+		return
+	}
+	for _, name := range p.StripHeaders {
+		req.Header.Del(name)
+	}
+	for name, value := range p.InjectHeaders {
+		req.Header.Set(name, value)
+	}
+	if p.RandomizeUserAgent {
+		req.Header.Set("User-Agent", RandomUserAgent())
+	}
+}
+
+// DAO manages header policies in MySQL, one row per user.
+type DAO struct {
+	db *sql.DB
+}
+
+// NewDAO wraps an existing *sql.DB handle.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db}
+}
+
+// Get returns userID's header policy, or ErrNotFound if none is
+// configured.
+func (d *DAO) Get(ctx context.Context, userID string) (*Policy, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT user_id, strip_headers, inject_headers, randomize_user_agent FROM header_policies WHERE user_id = ?`, userID)
+	return scanPolicy(row)
+}
+
+// List returns every configured header policy.
+func (d *DAO) List(ctx context.Context) ([]*Policy, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT user_id, strip_headers, inject_headers, randomize_user_agent FROM header_policies ORDER BY user_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Policy
+	for rows.Next() {
+		p, err := scanPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// Upsert creates or replaces p's user's header policy.
+func (d *DAO) Upsert(ctx context.Context, p *Policy) error {
+	strip, err := json.Marshal(p.StripHeaders)
+	if err != nil {
+		return err
+	}
+	inject, err := json.Marshal(p.InjectHeaders)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.ExecContext(ctx,
+		`INSERT INTO header_policies (user_id, strip_headers, inject_headers, randomize_user_agent)
+		 VALUES (?, ?, ?, ?)
+		 ON DUPLICATE KEY UPDATE strip_headers = VALUES(strip_headers), inject_headers = VALUES(inject_headers), randomize_user_agent = VALUES(randomize_user_agent)`,
+		p.UserID, string(strip), string(inject), p.RandomizeUserAgent)
+	return err
+}
+
+// Delete removes userID's header policy. It is a no-op if none exists.
+func (d *DAO) Delete(ctx context.Context, userID string) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM header_policies WHERE user_id = ?`, userID)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPolicy(row rowScanner) (*Policy, error) {
+	p := &Policy{}
+	var strip, inject sql.NullString
+	if err := row.Scan(&p.UserID, &strip, &inject, &p.RandomizeUserAgent); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if strip.Valid && strip.String != "" {
+		if err := json.Unmarshal([]byte(strip.String), &p.StripHeaders); err != nil {
+			return nil, err
+		}
+	}
+	if inject.Valid && inject.String != "" {
+		if err := json.Unmarshal([]byte(inject.String), &p.InjectHeaders); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,58 @@
+package headerpolicy
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestApplyStripsAndInjectsHeaders(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.Header.Set("Via", "1.1 somehost")
+	req.Header.Set("X-Forwarded-For", "203.0.113.5")
+	req.Header.Set("X-Keep-Me", "yes")
+
+	Apply(req, &Policy{
+		StripHeaders:  []string{"Via", "X-Forwarded-For"},
+		InjectHeaders: map[string]string{"X-Custom": "injected"},
+	})
+
+	if req.Header.Get("Via") != "" || req.Header.Get("X-Forwarded-For") != "" {
+		t.Fatal("expected stripped headers to be removed")
+	}
+	if req.Header.Get("X-Keep-Me") != "yes" {
+		t.Fatal("expected an unrelated header to be left alone")
+	}
+	if req.Header.Get("X-Custom") != "injected" {
+		t.Fatal("expected the injected header to be set")
+	}
+}
+
+func TestApplyRandomizesUserAgentFromPool(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.Header.Set("User-Agent", "original-agent")
+
+	Apply(req, &Policy{RandomizeUserAgent: true})
+
+	got := req.Header.Get("User-Agent")
+	found := false
+	for _, ua := range Pool {
+		if ua == got {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Fatalf("expected User-Agent %q to come from Pool", got)
+	}
+}
+
+func TestApplyNilPolicyIsNoop(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.Header.Set("Via", "1.1 somehost")
+
+	Apply(req, nil)
+
+	if req.Header.Get("Via") != "1.1 somehost" {
+		t.Fatal("expected a nil policy to leave headers untouched")
+	}
+}
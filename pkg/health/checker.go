@@ -0,0 +1,16 @@
+// Package health coordinates liveness checking of proxies, including both
+// the regular sweep schedule and out-of-band priority rechecks.
+package health
+
+import (
+	"context"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// Checker performs a single liveness probe against a proxy. Implementations
+// are expected to dial through the proxy and verify it can reach the
+// public internet.
+type Checker interface {
+	Check(ctx context.Context, p *proxy.Proxy) error
+}
@@ -0,0 +1,109 @@
+package health
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// Evictor removes a proxy from the hot pool once it is confirmed dead.
+type Evictor interface {
+	Evict(id string)
+}
+
+// DefaultFailureThreshold is the number of consecutive real-traffic
+// failures reported for a proxy before a priority recheck is triggered.
+const DefaultFailureThreshold = 3
+
+// FeedbackRecorder turns failure reports from live customer traffic (the
+// feedback API) into priority health rechecks, bypassing the regular sweep
+// schedule so a dead proxy can be evicted within seconds instead of
+// waiting for the next pass.
+type FeedbackRecorder struct {
+	checker   Checker
+	evictor   Evictor
+	threshold int
+
+	queue chan *proxy.Proxy
+
+	mu       sync.Mutex
+	failures map[string]int
+}
+
+// NewFeedbackRecorder creates a recorder that triggers a priority recheck
+// once a proxy has accumulated threshold consecutive failures. A
+// threshold <= 0 uses DefaultFailureThreshold.
+func NewFeedbackRecorder(checker Checker, evictor Evictor, threshold int) *FeedbackRecorder {
+	if threshold <= 0 {
+		threshold = DefaultFailureThreshold
+	}
+	return &FeedbackRecorder{
+		checker:   checker,
+		evictor:   evictor,
+		threshold: threshold,
+		queue:     make(chan *proxy.Proxy, 256),
+		failures:  make(map[string]int),
+	}
+}
+
+// Run processes queued priority rechecks until ctx is cancelled. It is
+// meant to be started once, in its own goroutine, per recorder.
+func (r *FeedbackRecorder) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case p := <-r.queue:
+			r.recheck(ctx, p)
+		}
+	}
+}
+
+// ReportFailure records a failure observed on real customer traffic for p.
+// Once ConsecutiveFailures since the last success reaches the configured
+// threshold, a priority recheck is enqueued immediately.
+func (r *FeedbackRecorder) ReportFailure(p *proxy.Proxy) {
+	r.mu.Lock()
+	r.failures[p.ID]++
+	n := r.failures[p.ID]
+	r.mu.Unlock()
+
+	if n < r.threshold {
+		return
+	}
+
+	select {
+	case r.queue <- p:
+	default:
+		log.Printf("health: priority recheck queue full, dropping recheck for proxy %s", p.ID)
+	}
+}
+
+// ReportSuccess clears any accumulated failure count for p, since it has
+// just served real traffic successfully.
+func (r *FeedbackRecorder) ReportSuccess(p *proxy.Proxy) {
+	r.mu.Lock()
+	delete(r.failures, p.ID)
+	r.mu.Unlock()
+}
+
+// recheck runs an out-of-band check and, if it fails, evicts the proxy
+// from the hot set right away rather than waiting for the next sweep.
+func (r *FeedbackRecorder) recheck(ctx context.Context, p *proxy.Proxy) {
+	defer func() {
+		r.mu.Lock()
+		delete(r.failures, p.ID)
+		r.mu.Unlock()
+	}()
+
+	if err := r.checker.Check(ctx, p); err != nil {
+		p.Status = proxy.StatusDead
+		r.evictor.Evict(p.ID)
+		log.Printf("health: proxy %s confirmed dead by priority recheck, evicted: %v", p.ID, err)
+		return
+	}
+
+	p.Status = proxy.StatusHealthy
+}
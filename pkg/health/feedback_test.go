@@ -0,0 +1,71 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+type fakeChecker struct{ err error }
+
+func (f *fakeChecker) Check(ctx context.Context, p *proxy.Proxy) error { return f.err }
+
+type fakeEvictor struct{ evicted chan string }
+
+func (f *fakeEvictor) Evict(id string) { f.evicted <- id }
+
+func TestFeedbackRecorderTriggersPriorityRecheckAndEvicts(t *testing.T) {
+	checker := &fakeChecker{err: errors.New("connection refused")}
+	evictor := &fakeEvictor{evicted: make(chan string, 1)}
+	r := NewFeedbackRecorder(checker, evictor, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Run(ctx)
+
+	p := &proxy.Proxy{ID: "p1", Status: proxy.StatusHealthy}
+	r.ReportFailure(p)
+	r.ReportFailure(p)
+
+	select {
+	case id := <-evictor.evicted:
+		t.Fatalf("proxy evicted before reaching threshold: %s", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	r.ReportFailure(p)
+
+	select {
+	case id := <-evictor.evicted:
+		if id != "p1" {
+			t.Fatalf("evicted wrong proxy: %s", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected proxy to be evicted after reaching failure threshold")
+	}
+
+	if p.Status != proxy.StatusDead {
+		t.Fatalf("expected proxy status dead, got %s", p.Status)
+	}
+}
+
+func TestFeedbackRecorderSuccessResetsFailureCount(t *testing.T) {
+	checker := &fakeChecker{err: nil}
+	evictor := &fakeEvictor{evicted: make(chan string, 1)}
+	r := NewFeedbackRecorder(checker, evictor, 2)
+
+	p := &proxy.Proxy{ID: "p1", Status: proxy.StatusHealthy}
+	r.ReportFailure(p)
+	r.ReportSuccess(p)
+
+	r.mu.Lock()
+	n := r.failures[p.ID]
+	r.mu.Unlock()
+
+	if n != 0 {
+		t.Fatalf("expected failure count reset to 0, got %d", n)
+	}
+}
@@ -0,0 +1,78 @@
+// Package http2upstream configures whether the gateway is allowed to
+// negotiate HTTP/2 with an upstream proxy (pkg/proxy.Proxy), per
+// provider. HTTPS upstreams negotiate h2 via ALPN with a fallback to
+// HTTP/1.1 when the upstream doesn't offer it; plain-TCP upstreams
+// speak h2c (HTTP/2 without TLS) instead, since ALPN has nothing to
+// negotiate over. A provider whose proxies return malformed or
+// stalled HTTP/2 frames can be disabled here without code changes.
+package http2upstream
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var ErrNotFound = errors.New("http2upstream: not found")
+
+// Policy says whether HTTP/2 is allowed for Provider's upstreams
+// (pkg/proxy.Proxy.Provider).
+type Policy struct {
+	Provider string
+	Disabled bool
+}
+
+// DAO persists per-provider Policy rows in the http2_upstream_policies
+// table.
+type DAO struct {
+	db *sql.DB
+}
+
+// NewDAO builds a DAO backed by db.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db}
+}
+
+func (d *DAO) Get(ctx context.Context, provider string) (*Policy, error) {
+	row := d.db.QueryRowContext(ctx, `SELECT provider, disabled FROM http2_upstream_policies WHERE provider = ?`, provider)
+	p := &Policy{}
+	if err := row.Scan(&p.Provider, &p.Disabled); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+func (d *DAO) List(ctx context.Context) ([]*Policy, error) {
+	rows, err := d.db.QueryContext(ctx, `SELECT provider, disabled FROM http2_upstream_policies ORDER BY provider`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Policy
+	for rows.Next() {
+		p := &Policy{}
+		if err := rows.Scan(&p.Provider, &p.Disabled); err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+func (d *DAO) Upsert(ctx context.Context, p *Policy) error {
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO http2_upstream_policies (provider, disabled)
+		 VALUES (?, ?)
+		 ON DUPLICATE KEY UPDATE disabled = VALUES(disabled)`,
+		p.Provider, p.Disabled)
+	return err
+}
+
+func (d *DAO) Delete(ctx context.Context, provider string) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM http2_upstream_policies WHERE provider = ?`, provider)
+	return err
+}
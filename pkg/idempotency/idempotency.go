@@ -0,0 +1,99 @@
+// Package idempotency lets a POST/PUT handler be safely retried: a
+// client-supplied Idempotency-Key is used to cache the first response
+// in Redis and replay it on retries, and a short-lived lock prevents a
+// retry that arrives while the original request is still in flight
+// from running the handler a second time concurrently.
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// ErrInProgress is returned by Reserve when another request with the
+// same key is currently being handled.
+var ErrInProgress = errors.New("idempotency: request with this key is already in progress")
+
+// lockTTL bounds how long a lock survives if the handler it was
+// guarding never finishes (e.g. the process crashed mid-request), so a
+// stuck lock doesn't wedge retries forever.
+const lockTTL = 30 * time.Second
+
+func lockKey(key string) string { return "idempotency:lock:" + key }
+
+func responseKey(key string) string { return "idempotency:response:" + key }
+
+// Response is the cached result of the first successful run of an
+// idempotent request, replayed verbatim on retries.
+type Response struct {
+	Status int                 `json:"status"`
+	Header map[string][]string `json:"header"`
+	Body   []byte              `json:"body"`
+}
+
+// Store is the Redis-backed idempotency key cache.
+type Store struct {
+	client goredis.UniversalClient
+}
+
+// NewStore wraps an existing Redis client.
+func NewStore(client goredis.UniversalClient) *Store {
+	return &Store{client: client}
+}
+
+// Load returns the cached response for key, if the request has already
+// completed once.
+func (s *Store) Load(ctx context.Context, key string) (*Response, bool, error) {
+	data, err := s.client.Get(ctx, responseKey(key)).Bytes()
+	if err == goredis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var resp Response
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, false, err
+	}
+	return &resp, true, nil
+}
+
+// Reserve claims key for the caller's in-flight request. It returns
+// ErrInProgress if another request is already running under the same
+// key; the lock self-expires after lockTTL so a crashed handler doesn't
+// lock the key out permanently.
+func (s *Store) Reserve(ctx context.Context, key string) error {
+	ok, err := s.client.SetNX(ctx, lockKey(key), "1", lockTTL).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInProgress
+	}
+	return nil
+}
+
+// Save stores resp as the final result for key for ttl, so subsequent
+// retries replay it, and releases the in-flight lock.
+func (s *Store) Save(ctx context.Context, key string, resp Response, ttl time.Duration) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, responseKey(key), data, ttl)
+	pipe.Del(ctx, lockKey(key))
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Release drops the in-flight lock for key without caching a response,
+// for use when the handler failed and the request should be retryable
+// immediately rather than waiting out lockTTL.
+func (s *Store) Release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, lockKey(key)).Err()
+}
@@ -0,0 +1,26 @@
+package idempotency
+
+import (
+	"testing"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func TestKeysAreNamespaced(t *testing.T) {
+	if got := lockKey("abc"); got != "idempotency:lock:abc" {
+		t.Errorf("lockKey = %q", got)
+	}
+	if got := responseKey("abc"); got != "idempotency:response:abc" {
+		t.Errorf("responseKey = %q", got)
+	}
+}
+
+func TestNewStoreWrapsClient(t *testing.T) {
+	client := goredis.NewClient(&goredis.Options{Addr: "127.0.0.1:0"})
+	defer client.Close()
+
+	store := NewStore(client)
+	if store.client != client {
+		t.Fatal("NewStore did not wrap the given client")
+	}
+}
@@ -0,0 +1,52 @@
+package ipallowlist
+
+import (
+	"context"
+	"errors"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// ErrCacheMiss is returned when ip has no cached owner. It does not
+// mean ip is disallowed, only that the caller must fall back to DAO
+// Lookup to be sure.
+var ErrCacheMiss = errors.New("ipallowlist: cache miss")
+
+func cacheKey(ip string) string { return "allowedip:" + ip }
+
+// Cache is the Redis-backed read path for gateway IP authentication: it
+// mirrors MySQL's ip_allowlist_entries table in a flat key-value index
+// so a connection's source IP resolves to its owning user without a
+// round trip to MySQL on every request. Writes are write-through,
+// updating Redis synchronously alongside the DAO call, since entries
+// are written rarely and read on essentially every connection.
+type Cache struct {
+	client goredis.UniversalClient
+}
+
+// NewCache wraps an existing Redis client.
+func NewCache(client goredis.UniversalClient) *Cache {
+	return &Cache{client: client}
+}
+
+// Set records that ip belongs to userID.
+func (c *Cache) Set(ctx context.Context, ip, userID string) error {
+	return c.client.Set(ctx, cacheKey(ip), userID, 0).Err()
+}
+
+// Remove drops ip from the cache.
+func (c *Cache) Remove(ctx context.Context, ip string) error {
+	return c.client.Del(ctx, cacheKey(ip)).Err()
+}
+
+// Lookup returns the user ID ip is cached against, or ErrCacheMiss.
+func (c *Cache) Lookup(ctx context.Context, ip string) (string, error) {
+	userID, err := c.client.Get(ctx, cacheKey(ip)).Result()
+	if err == goredis.Nil {
+		return "", ErrCacheMiss
+	}
+	if err != nil {
+		return "", err
+	}
+	return userID, nil
+}
@@ -0,0 +1,127 @@
+// Package ipallowlist lets a user authorize proxy connections by source
+// IP instead of (or alongside) an API key, for customers whose traffic
+// always originates from a small set of known addresses. Only exact IP
+// matches are supported, not CIDR ranges, so lookups stay a plain
+// key-value check both in MySQL and in the Redis cache the gateway
+// consults on every connection.
+package ipallowlist
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotFound is returned when no allowlist entry matches.
+var ErrNotFound = errors.New("ipallowlist: not found")
+
+// ErrDuplicateIP is returned by Insert when the IP is already allowlisted,
+// for this user or another one.
+var ErrDuplicateIP = errors.New("ipallowlist: ip already allowlisted")
+
+// Entry is a single allowlisted source IP.
+type Entry struct {
+	ID        string
+	UserID    string
+	IPAddress string
+	Label     string
+	CreatedAt time.Time
+}
+
+// DAO manages IP allowlist entries in MySQL.
+type DAO struct {
+	db *sql.DB
+}
+
+// NewDAO wraps an existing *sql.DB handle.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db}
+}
+
+// List returns every entry belonging to userID, most recently created
+// first.
+func (d *DAO) List(ctx context.Context, userID string) ([]*Entry, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT id, user_id, ip_address, label, created_at FROM ip_allowlist_entries WHERE user_id = ? ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Entry
+	for rows.Next() {
+		e, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// Insert adds ip to userID's allowlist. ip is unique across all users,
+// since a single cache lookup needs to resolve it to exactly one owner;
+// a duplicate reports ErrDuplicateIP rather than silently reassigning
+// it.
+func (d *DAO) Insert(ctx context.Context, userID, ip, label string) (*Entry, error) {
+	e := &Entry{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		IPAddress: ip,
+		Label:     label,
+	}
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO ip_allowlist_entries (id, user_id, ip_address, label) VALUES (?, ?, ?, ?)`,
+		e.ID, e.UserID, e.IPAddress, e.Label)
+	if isDuplicateKeyErr(err) {
+		return nil, ErrDuplicateIP
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Delete removes an entry owned by userID. It is a no-op if the entry
+// doesn't exist or belongs to a different user.
+func (d *DAO) Delete(ctx context.Context, userID, id string) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM ip_allowlist_entries WHERE id = ? AND user_id = ?`, id, userID)
+	return err
+}
+
+// Lookup returns the entry allowlisting ip, regardless of owner. The
+// gateway's Redis cache should be consulted before falling back to
+// this, since it runs on every proxy connection.
+func (d *DAO) Lookup(ctx context.Context, ip string) (*Entry, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT id, user_id, ip_address, label, created_at FROM ip_allowlist_entries WHERE ip_address = ?`, ip)
+	e, err := scanEntry(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return e, err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEntry(row rowScanner) (*Entry, error) {
+	e := &Entry{}
+	if err := row.Scan(&e.ID, &e.UserID, &e.IPAddress, &e.Label, &e.CreatedAt); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// isDuplicateKeyErr reports whether err is a MySQL duplicate-key
+// violation, matched on the driver's error text rather than its
+// *mysql.MySQLError type, since no DAO in this package otherwise needs
+// the driver package.
+func isDuplicateKeyErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "Duplicate entry")
+}
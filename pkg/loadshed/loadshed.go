@@ -0,0 +1,184 @@
+// Package loadshed watches coarse load signals (CPU, goroutine count,
+// queue depth) against configured watermarks and, once crossed, sheds
+// low-priority work so the whole service degrades gracefully instead of
+// falling over.
+package loadshed
+
+import (
+	"context"
+	"log"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Config holds the watermarks that trigger shedding. A zero value for a
+// watermark disables that particular check.
+type Config struct {
+	// CPUWatermark is a fraction (0-1) of CPUUsageFunc's return value
+	// above which shedding engages.
+	CPUWatermark float64
+	// GoroutineWatermark is the goroutine count above which shedding
+	// engages.
+	GoroutineWatermark int
+	// QueueDepthWatermark is the pending-work queue depth above which
+	// shedding engages.
+	QueueDepthWatermark int
+	// PollInterval is how often watermarks are re-evaluated.
+	PollInterval time.Duration
+
+	// CPUUsageFunc reports current CPU utilization as a 0-1 fraction.
+	// Optional; if nil, CPU is never considered in the decision.
+	CPUUsageFunc func() float64
+	// QueueDepthFunc reports the current depth of whatever work queue
+	// the caller wants watched (e.g. the gateway's inbound connection
+	// queue). Optional; if nil, queue depth is never considered.
+	QueueDepthFunc func() int
+}
+
+func (c Config) pollInterval() time.Duration {
+	if c.PollInterval <= 0 {
+		return time.Second
+	}
+	return c.PollInterval
+}
+
+// Status is a point-in-time snapshot of the shedder's state, suitable
+// for exposing on a status/health API.
+type Status struct {
+	Shedding       bool
+	CPUUsage       float64
+	GoroutineCount int
+	QueueDepth     int
+}
+
+// Metrics counts cumulative shedding activity for observability.
+type Metrics struct {
+	Engagements   int64 // number of times shedding turned on
+	RejectedTotal int64 // sandbox requests rejected while shedding
+}
+
+// Shedder evaluates watermarks on a timer and exposes the current
+// shedding state to rate limiters, samplers and the gateway.
+type Shedder struct {
+	cfg Config
+
+	shedding int32        // atomic bool
+	status   atomic.Value // Status
+
+	metrics Metrics
+
+	hooksMu sync.Mutex
+	hooks   []func(shedding bool)
+}
+
+// OnTransition registers a callback invoked whenever shedding engages or
+// disengages. Rate limiters and debug samplers use this to tighten or
+// relax themselves without the shedder needing to know they exist.
+func (s *Shedder) OnTransition(fn func(shedding bool)) {
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	s.hooks = append(s.hooks, fn)
+}
+
+func (s *Shedder) notify(shedding bool) {
+	s.hooksMu.Lock()
+	hooks := append([]func(bool){}, s.hooks...)
+	s.hooksMu.Unlock()
+	for _, fn := range hooks {
+		fn(shedding)
+	}
+}
+
+// New creates a Shedder from cfg. Call Run in its own goroutine to start
+// evaluating watermarks.
+func New(cfg Config) *Shedder {
+	s := &Shedder{cfg: cfg}
+	s.status.Store(Status{})
+	return s
+}
+
+// Run polls the configured signals until ctx is cancelled, toggling
+// shedding state as watermarks are crossed or cleared.
+func (s *Shedder) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.pollInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.evaluate()
+		}
+	}
+}
+
+func (s *Shedder) evaluate() {
+	var cpu float64
+	if s.cfg.CPUUsageFunc != nil {
+		cpu = s.cfg.CPUUsageFunc()
+	}
+	goroutines := runtime.NumGoroutine()
+	var queueDepth int
+	if s.cfg.QueueDepthFunc != nil {
+		queueDepth = s.cfg.QueueDepthFunc()
+	}
+
+	s.status.Store(Status{
+		Shedding:       s.Shedding(),
+		CPUUsage:       cpu,
+		GoroutineCount: goroutines,
+		QueueDepth:     queueDepth,
+	})
+
+	crossed := (s.cfg.CPUWatermark > 0 && cpu >= s.cfg.CPUWatermark) ||
+		(s.cfg.GoroutineWatermark > 0 && goroutines >= s.cfg.GoroutineWatermark) ||
+		(s.cfg.QueueDepthWatermark > 0 && queueDepth >= s.cfg.QueueDepthWatermark)
+
+	was := atomic.SwapInt32(&s.shedding, boolToInt32(crossed)) == 1
+
+	if crossed && !was {
+		atomic.AddInt64(&s.metrics.Engagements, 1)
+		log.Printf("loadshed: engaging load shedding (cpu=%.2f goroutines=%d queue=%d)", cpu, goroutines, queueDepth)
+		s.notify(true)
+	} else if !crossed && was {
+		log.Printf("loadshed: disengaging load shedding")
+		s.notify(false)
+	}
+}
+
+// Shedding reports whether the shedder is currently in shedding mode.
+func (s *Shedder) Shedding() bool {
+	return atomic.LoadInt32(&s.shedding) == 1
+}
+
+// Status returns the most recent watermark snapshot.
+func (s *Shedder) Status() Status {
+	st, _ := s.status.Load().(Status)
+	st.Shedding = s.Shedding()
+	return st
+}
+
+// RecordRejection increments the rejected-sandbox-traffic counter.
+// Callers (e.g. the sandbox-traffic middleware) call this each time they
+// reject a request because shedding is active.
+func (s *Shedder) RecordRejection() {
+	atomic.AddInt64(&s.metrics.RejectedTotal, 1)
+}
+
+// Metrics returns a snapshot of cumulative shedding activity.
+func (s *Shedder) Metrics() Metrics {
+	return Metrics{
+		Engagements:   atomic.LoadInt64(&s.metrics.Engagements),
+		RejectedTotal: atomic.LoadInt64(&s.metrics.RejectedTotal),
+	}
+}
+
+func boolToInt32(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,24 @@
+package loadshed
+
+import "testing"
+
+func TestShedderEngagesAndNotifiesOnWatermarkCross(t *testing.T) {
+	s := New(Config{
+		GoroutineWatermark: 1, // always crossed, keeps the test deterministic
+	})
+
+	var transitions []bool
+	s.OnTransition(func(shedding bool) { transitions = append(transitions, shedding) })
+
+	s.evaluate()
+	if !s.Shedding() {
+		t.Fatal("expected shedder to engage once goroutine watermark is crossed")
+	}
+	if len(transitions) != 1 || transitions[0] != true {
+		t.Fatalf("expected a single engage transition, got %v", transitions)
+	}
+
+	if s.Metrics().Engagements != 1 {
+		t.Fatalf("expected 1 engagement recorded, got %d", s.Metrics().Engagements)
+	}
+}
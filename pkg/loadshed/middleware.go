@@ -0,0 +1,23 @@
+package loadshed
+
+import "net/http"
+
+// SandboxHeader marks a request as sandbox/low-priority traffic, eligible
+// to be rejected outright while the shedder is engaged.
+const SandboxHeader = "X-Sandbox-Request"
+
+// RejectSandboxTraffic returns middleware that responds 503 to sandbox
+// traffic while s is shedding, leaving normal customer traffic
+// untouched.
+func RejectSandboxTraffic(s *Shedder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(SandboxHeader) == "true" && s.Shedding() {
+				s.RecordRejection()
+				http.Error(w, "service under load, sandbox traffic temporarily rejected", http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// Package locale selects the display language for user-facing error and
+// validation messages across API handlers. It only distinguishes
+// English and Simplified Chinese, since those are the only languages
+// this deployment serves.
+package locale
+
+import "strings"
+
+// Locale is a supported message language.
+type Locale string
+
+const (
+	EN Locale = "en"
+	ZH Locale = "zh"
+)
+
+// FromAcceptLanguage maps an Accept-Language header value to a
+// supported Locale, defaulting to English. It only checks whether the
+// header mentions "zh" rather than fully parsing RFC 7231 quality
+// values, since the only choice that matters here is en vs. zh.
+func FromAcceptLanguage(header string) Locale {
+	if strings.Contains(header, "zh") {
+		return ZH
+	}
+	return EN
+}
@@ -0,0 +1,81 @@
+// Package maintenance coordinates platform-wide maintenance mode across
+// services via a single Redis key, so enabling it from admin-api takes
+// effect on every gateway and admin-api instance without a restart or a
+// config rollout.
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const stateKey = "maintenance:state"
+
+// State describes whether maintenance mode is on and, if so, why and
+// until when new sessions were expected to finish draining. A
+// DrainDeadline is advisory: this package has no registry of open
+// tunnels to force-close, so existing connections simply run to
+// completion on their own regardless of the deadline.
+type State struct {
+	Enabled       bool      `json:"enabled"`
+	Reason        string    `json:"reason,omitempty"`
+	DrainDeadline time.Time `json:"drain_deadline,omitempty"`
+}
+
+// Controller reads and writes the shared maintenance State in Redis.
+type Controller struct {
+	client goredis.UniversalClient
+}
+
+// NewController wraps an existing Redis client.
+func NewController(client goredis.UniversalClient) *Controller {
+	return &Controller{client: client}
+}
+
+// Enable turns maintenance mode on with reason, recording a
+// DrainDeadline drainFor from now for operators and status pages to
+// display. Call Disable once drained; Enable does not expire on its
+// own.
+func (c *Controller) Enable(ctx context.Context, reason string, drainFor time.Duration) error {
+	state := State{Enabled: true, Reason: reason, DrainDeadline: time.Now().Add(drainFor)}
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, stateKey, encoded, 0).Err()
+}
+
+// Disable turns maintenance mode off.
+func (c *Controller) Disable(ctx context.Context) error {
+	return c.client.Del(ctx, stateKey).Err()
+}
+
+// Status returns the current maintenance State. A missing key (the
+// common case) reports a zero State, i.e. maintenance off.
+func (c *Controller) Status(ctx context.Context) (State, error) {
+	encoded, err := c.client.Get(ctx, stateKey).Bytes()
+	if err == goredis.Nil {
+		return State{}, nil
+	}
+	if err != nil {
+		return State{}, err
+	}
+	var state State
+	if err := json.Unmarshal(encoded, &state); err != nil {
+		return State{}, err
+	}
+	return state, nil
+}
+
+// Enabled is a convenience wrapper around Status for callers that only
+// care whether maintenance mode is currently on.
+func (c *Controller) Enabled(ctx context.Context) (bool, error) {
+	state, err := c.Status(ctx)
+	if err != nil {
+		return false, err
+	}
+	return state.Enabled, nil
+}
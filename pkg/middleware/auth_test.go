@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	"github.com/XXXXD-cation/proxy-platform/pkg/auth"
+)
+
+func TestAuthenticateAcceptsBearerJWT(t *testing.T) {
+	jwtService := auth.NewJWTServiceFromString("test-secret")
+	token, err := jwtService.Issue("user-1", auth.RoleAdmin)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	identity, err := authenticate(r, jwtService, apikey.NewDAO(nil))
+	if err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+	if identity.UserID != "user-1" || identity.Role != auth.RoleAdmin {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+}
+
+func TestAuthenticateRejectsMissingCredentials(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	_, err := authenticate(r, auth.NewJWTServiceFromString("test-secret"), apikey.NewDAO(nil))
+	if err != ErrNoCredentials {
+		t.Fatalf("expected ErrNoCredentials, got %v", err)
+	}
+}
+
+func TestAuthenticateRejectsTwoFactorPendingJWT(t *testing.T) {
+	jwtService := auth.NewJWTServiceFromString("test-secret")
+	token, err := jwtService.IssuePartial("user-1", auth.RoleAdmin)
+	if err != nil {
+		t.Fatalf("IssuePartial: %v", err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	if _, err := authenticate(r, jwtService, apikey.NewDAO(nil)); err != auth.ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestAuthenticateRejectsInvalidJWT(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer not-a-real-token")
+
+	_, err := authenticate(r, auth.NewJWTServiceFromString("test-secret"), apikey.NewDAO(nil))
+	if err != auth.ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/validate"
+)
+
+// validationErrorResponse is the consistent 422 payload BindAndValidate
+// writes for a failed decode or validation: one entry per offending
+// field, in the locale picked from the request's Accept-Language
+// header.
+type validationErrorResponse struct {
+	Errors []validate.FieldError `json:"errors"`
+}
+
+// BindAndValidate decodes r's JSON body into a new T and validates it
+// against T's `validate` struct tags (see pkg/validate). On success it
+// returns the decoded value and true. On a decode error or a failed
+// validation rule, it writes a 422 with a consistent {"errors": [...]}
+// body (localized per the request's Accept-Language header) and returns
+// the zero value and false; the caller should return immediately.
+func BindAndValidate[T any](w http.ResponseWriter, r *http.Request) (T, bool) {
+	var body T
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeValidationError(w, []validate.FieldError{{Field: "body", Rule: "json", Message: "request body must be valid JSON"}})
+		var zero T
+		return zero, false
+	}
+
+	locale := validate.LocaleFromAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err := validate.StructLocale(&body, locale); err != nil {
+		writeValidationError(w, err.(*validate.Errors).Fields)
+		var zero T
+		return zero, false
+	}
+
+	return body, true
+}
+
+func writeValidationError(w http.ResponseWriter, fields []validate.FieldError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(validationErrorResponse{Errors: fields})
+}
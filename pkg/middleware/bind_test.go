@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type bindTestRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+func TestBindAndValidateSuccess(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":"a@example.com"}`))
+	w := httptest.NewRecorder()
+
+	body, ok := BindAndValidate[bindTestRequest](w, r)
+	if !ok {
+		t.Fatalf("BindAndValidate() ok = false, want true (status %d)", w.Code)
+	}
+	if body.Email != "a@example.com" {
+		t.Errorf("Email = %q, want a@example.com", body.Email)
+	}
+}
+
+func TestBindAndValidateRejectsInvalidJSON(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`not json`))
+	w := httptest.NewRecorder()
+
+	_, ok := BindAndValidate[bindTestRequest](w, r)
+	if ok {
+		t.Fatal("BindAndValidate() ok = true, want false for invalid JSON")
+	}
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestBindAndValidateRejectsFailedRule(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":"not-an-email"}`))
+	w := httptest.NewRecorder()
+
+	_, ok := BindAndValidate[bindTestRequest](w, r)
+	if ok {
+		t.Fatal("BindAndValidate() ok = true, want false for invalid email")
+	}
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	if !strings.Contains(w.Body.String(), "valid email") {
+		t.Errorf("body = %q, want it to mention the email rule", w.Body.String())
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apierrors"
+	"github.com/XXXXD-cation/proxy-platform/pkg/featureflags"
+)
+
+// RequireFlag wraps a handler so it only runs for callers the named
+// flag is enabled for, per resolver.Enabled, responding with a
+// structured 404 otherwise so a flagged-off route looks like it doesn't
+// exist rather than hinting at a gate a caller could poke at. It must
+// run after Auth, since gating is keyed to the caller's identity.
+func RequireFlag(resolver *featureflags.Resolver, name string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, ok := IdentityFromContext(r.Context())
+			if !ok || !resolver.Enabled(r.Context(), name, identity.UserID) {
+				apierrors.Write(w, r, apierrors.New(apierrors.CodeNotFound, "not found"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/featureflags"
+)
+
+func TestRequireFlagRejectsUnauthenticatedRequest(t *testing.T) {
+	resolver := featureflags.NewResolver(nil, nil)
+	handler := RequireFlag(resolver, "new_scheduler")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestRequireFlagRejectsUnconfiguredFlag(t *testing.T) {
+	resolver := featureflags.NewResolver(nil, nil)
+	handler := RequireFlag(resolver, "new_scheduler")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := withIdentity(httptest.NewRequest(http.MethodGet, "/", nil), Identity{UserID: "u1"})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a flag with no loaded snapshot, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apierrors"
+	"github.com/XXXXD-cation/proxy-platform/pkg/idempotency"
+)
+
+// responseTTL is how long a completed response stays cached for replay
+// on retries after the request finished.
+const responseTTL = 24 * time.Hour
+
+// recorder buffers a handler's response so it can both be written to
+// the real ResponseWriter and cached for idempotent replay.
+type recorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+// cacheKey scopes raw to the caller and the specific route it was sent
+// to, so the same client-chosen Idempotency-Key value reused against
+// two different endpoints (or the same endpoint via different HTTP
+// methods) can't have one's cached response replayed for the other.
+func cacheKey(r *http.Request, raw string) string {
+	key := r.Method + " " + r.URL.Path + ":" + raw
+	if identity, ok := IdentityFromContext(r.Context()); ok {
+		key = identity.UserID + ":" + key
+	}
+	return key
+}
+
+// Idempotency makes POST/PUT handlers safe to retry: a client that sets
+// the Idempotency-Key header gets the same cached response replayed if
+// it retries after the original request completed, and a 409 if the
+// retry arrives while the original is still in flight. Requests without
+// the header pass through unguarded, since there's no key to dedupe on.
+// It must run after Auth, since it scopes the key to the caller's
+// identity so two different callers can't collide on the same
+// client-chosen key.
+func Idempotency(store *idempotency.Store) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw := r.Header.Get("Idempotency-Key")
+			if raw == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			key := cacheKey(r, raw)
+
+			if cached, ok, err := store.Load(r.Context(), key); err == nil && ok {
+				for name, values := range cached.Header {
+					for _, v := range values {
+						w.Header().Add(name, v)
+					}
+				}
+				w.WriteHeader(cached.Status)
+				w.Write(cached.Body)
+				return
+			}
+
+			if err := store.Reserve(r.Context(), key); err != nil {
+				if err == idempotency.ErrInProgress {
+					apierrors.Write(w, r, apierrors.New(apierrors.CodeConflict, "a request with this idempotency key is already in progress"))
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			resp := idempotency.Response{
+				Status: rec.status,
+				Header: map[string][]string(w.Header()),
+				Body:   rec.body.Bytes(),
+			}
+			if err := store.Save(r.Context(), key, resp, responseTTL); err != nil {
+				store.Release(r.Context(), key)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/idempotency"
+)
+
+func TestIdempotencyPassesThroughWithoutHeader(t *testing.T) {
+	h := Idempotency(idempotency.NewStore(nil))(okHandler())
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestCacheKeyDiffersAcrossRoutes(t *testing.T) {
+	r1 := httptest.NewRequest(http.MethodPost, "/api/v1/keys", nil)
+	r2 := httptest.NewRequest(http.MethodPost, "/api/v1/checkout-session", nil)
+
+	if cacheKey(r1, "same-key") == cacheKey(r2, "same-key") {
+		t.Fatal("expected the same Idempotency-Key value to produce different cache keys on different routes")
+	}
+}
+
+func TestCacheKeyDiffersAcrossMethods(t *testing.T) {
+	get := httptest.NewRequest(http.MethodGet, "/api/v1/keys", nil)
+	post := httptest.NewRequest(http.MethodPost, "/api/v1/keys", nil)
+
+	if cacheKey(get, "same-key") == cacheKey(post, "same-key") {
+		t.Fatal("expected the same Idempotency-Key value to produce different cache keys on different methods")
+	}
+}
+
+func TestCacheKeyScopedToIdentity(t *testing.T) {
+	plain := httptest.NewRequest(http.MethodPost, "/api/v1/keys", nil)
+	withID := withIdentity(plain, Identity{UserID: "u1"})
+
+	if cacheKey(plain, "same-key") == cacheKey(withID, "same-key") {
+		t.Fatal("expected an authenticated request's cache key to differ from an unauthenticated one")
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apierrors"
+	"github.com/XXXXD-cation/proxy-platform/pkg/auth"
+)
+
+// Permission is a single fine-grained capability that RequirePermission
+// can gate a handler on.
+type Permission string
+
+const (
+	PermProxyRead  Permission = "proxy:read"
+	PermProxyWrite Permission = "proxy:write"
+	PermUserManage Permission = "user:manage"
+	PermStatsRead  Permission = "stats:read"
+)
+
+// rolePermissions is what a JWT-authenticated identity is granted by
+// virtue of its role. API-key identities are scoped by the key's own
+// Permissions instead, since a key may intentionally have narrower
+// access than its owner's role would otherwise allow.
+var rolePermissions = map[auth.Role][]Permission{
+	auth.RoleUser:     {PermProxyRead, PermStatsRead},
+	auth.RoleOperator: {PermProxyRead, PermProxyWrite, PermStatsRead},
+	auth.RoleAdmin:    {PermProxyRead, PermProxyWrite, PermUserManage, PermStatsRead},
+}
+
+// DefaultPermissions returns the permissions a role carries absent any
+// narrower, explicitly-granted set (as an API key has). Auth uses this
+// for JWT-authenticated identities; other services that mint their own
+// Identity for a role they already trust (e.g. admin-api, which only
+// ever deals with RoleAdmin) reuse it rather than duplicating the map.
+func DefaultPermissions(role auth.Role) []string {
+	perms := rolePermissions[role]
+	out := make([]string, len(perms))
+	for i, p := range perms {
+		out[i] = string(p)
+	}
+	return out
+}
+
+// HasPermission reports whether the identity was granted perm.
+func (i Identity) HasPermission(perm Permission) bool {
+	for _, p := range i.Permissions {
+		if p == string(perm) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequirePermission wraps a handler so it only runs for callers whose
+// Identity (attached earlier by Auth) was granted perm, responding with
+// a structured 403 otherwise.
+func RequirePermission(perm Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, ok := IdentityFromContext(r.Context())
+			if !ok || !identity.HasPermission(perm) {
+				apierrors.Write(w, r, apierrors.New(apierrors.CodeForbidden, "missing required permission: "+string(perm)))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/auth"
+)
+
+func withIdentity(r *http.Request, identity Identity) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), identityContextKey, identity))
+}
+
+func TestRequirePermissionAllowsGrantedIdentity(t *testing.T) {
+	identity := Identity{UserID: "u1", Role: auth.RoleAdmin, Permissions: DefaultPermissions(auth.RoleAdmin)}
+
+	handler := RequirePermission(PermUserManage)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := withIdentity(httptest.NewRequest(http.MethodGet, "/", nil), identity)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+}
+
+func TestRequirePermissionRejectsUnauthorizedIdentity(t *testing.T) {
+	identity := Identity{UserID: "u1", Role: auth.RoleUser, Permissions: DefaultPermissions(auth.RoleUser)}
+
+	handler := RequirePermission(PermUserManage)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := withIdentity(httptest.NewRequest(http.MethodGet, "/", nil), identity)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rec.Code)
+	}
+}
+
+func TestRequirePermissionRejectsUnauthenticatedRequest(t *testing.T) {
+	handler := RequirePermission(PermProxyRead)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rec.Code)
+	}
+}
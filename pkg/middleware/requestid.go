@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type requestIDContextKey int
+
+const requestIDKey requestIDContextKey = iota
+
+// RequestID assigns every request a unique ID, attaching it to the
+// request context and echoing it back via the X-Request-Id response
+// header, so a caller and the audit trail can correlate a request
+// across logs. It reuses an incoming X-Request-Id if the caller (or an
+// upstream proxy) already set one, rather than generating a second ID
+// for the same request.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-Id")
+		if id == "" {
+			id = uuid.NewString()
+		}
+
+		w.Header().Set("X-Request-Id", id)
+		ctx := context.WithValue(r.Context(), requestIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext returns the ID RequestID attached to ctx, or ""
+// if the request wasn't routed through it.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
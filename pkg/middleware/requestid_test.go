@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestIDGeneratesWhenMissing(t *testing.T) {
+	var gotID string
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID = RequestIDFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if gotID == "" {
+		t.Fatal("expected a generated request ID in context, got empty string")
+	}
+	if w.Header().Get("X-Request-Id") != gotID {
+		t.Fatalf("X-Request-Id header = %q, want %q", w.Header().Get("X-Request-Id"), gotID)
+	}
+}
+
+func TestRequestIDReusesIncomingHeader(t *testing.T) {
+	var gotID string
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID = RequestIDFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Request-Id", "caller-supplied-id")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if gotID != "caller-supplied-id" {
+		t.Fatalf("request ID = %q, want %q", gotID, "caller-supplied-id")
+	}
+	if w.Header().Get("X-Request-Id") != "caller-supplied-id" {
+		t.Fatalf("X-Request-Id header = %q, want %q", w.Header().Get("X-Request-Id"), "caller-supplied-id")
+	}
+}
+
+func TestRequestIDFromContextReturnsEmptyWhenNotSet(t *testing.T) {
+	if id := RequestIDFromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context()); id != "" {
+		t.Fatalf("request ID = %q, want empty", id)
+	}
+}
@@ -0,0 +1,228 @@
+// Package mysql wraps the platform's MySQL connections: a primary for
+// all writes and every existing DAO, plus optional read replicas that
+// read-heavy callers can opt into for queries that don't need
+// read-your-writes consistency, such as the admin dashboard's
+// aggregate stats. DAOs themselves are unchanged; they still accept a
+// plain *sql.DB, which DB.Primary and DB.Reader both hand out.
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultMaxReplicationLag is how far behind the primary a replica can
+// fall before Reader stops routing to it.
+const DefaultMaxReplicationLag = 10 * time.Second
+
+// DefaultLagPollInterval is how often MonitorReplicas checks each
+// replica's replication lag.
+const DefaultLagPollInterval = 5 * time.Second
+
+// Config holds the DSNs DB is built from. ReplicaDSNs is optional; a
+// deployment with none gets read/write routing that always resolves to
+// the primary, so callers can adopt DB ahead of actually provisioning
+// replicas.
+type Config struct {
+	PrimaryDSN  string
+	ReplicaDSNs []string
+	// MaxReplicationLag is how far behind the primary a replica may be
+	// and still be considered healthy. Zero uses DefaultMaxReplicationLag.
+	MaxReplicationLag time.Duration
+}
+
+func (c Config) maxLag() time.Duration {
+	if c.MaxReplicationLag <= 0 {
+		return DefaultMaxReplicationLag
+	}
+	return c.MaxReplicationLag
+}
+
+type replica struct {
+	db      *sql.DB
+	healthy atomic.Bool
+	lag     atomic.Int64 // nanoseconds, via time.Duration
+}
+
+// DB is a primary MySQL pool plus zero or more read replicas. All
+// writes and every DAO that needs read-your-writes consistency (i.e.
+// nearly all of them) go through Primary; read-only, latency-tolerant
+// callers go through Reader.
+type DB struct {
+	primary  *sql.DB
+	replicas []*replica
+	maxLag   time.Duration
+	next     atomic.Uint64
+}
+
+// Open dials the primary and every configured replica. A replica that
+// fails to open or ping is logged and excluded rather than failing
+// Open outright — same as this codebase's other optional-dependency
+// setup (e.g. a nil events publisher) — since read/write splitting is
+// an optimization, not something request handling can't function
+// without; Reader simply falls back to the primary. Open fails only if
+// the primary itself can't be reached.
+func Open(ctx context.Context, cfg Config) (*DB, error) {
+	primary, err := sql.Open("mysql", cfg.PrimaryDSN)
+	if err != nil {
+		return nil, err
+	}
+	if err := primary.PingContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db := &DB{primary: primary, maxLag: cfg.maxLag()}
+	for _, dsn := range cfg.ReplicaDSNs {
+		conn, err := sql.Open("mysql", dsn)
+		if err != nil {
+			log.Printf("mysql: failed to open replica, excluding it: %v", err)
+			continue
+		}
+		r := &replica{db: conn}
+		if err := conn.PingContext(ctx); err != nil {
+			log.Printf("mysql: replica unreachable, excluding it until the next lag check: %v", err)
+		} else {
+			r.healthy.Store(true)
+		}
+		db.replicas = append(db.replicas, r)
+	}
+	return db, nil
+}
+
+// Primary returns the primary pool. Every write, and any read that
+// needs read-your-writes consistency, should use this — it's the same
+// *sql.DB every DAO constructor in this codebase already accepts.
+func (d *DB) Primary() *sql.DB {
+	return d.primary
+}
+
+// Reader returns a pool suitable for a read-only, latency-tolerant
+// query: a healthy replica chosen round-robin, or the primary if no
+// replica is configured or none is currently within MaxReplicationLag.
+func (d *DB) Reader() *sql.DB {
+	if len(d.replicas) == 0 {
+		return d.primary
+	}
+	start := d.next.Add(1)
+	for i := uint64(0); i < uint64(len(d.replicas)); i++ {
+		r := d.replicas[(start+i)%uint64(len(d.replicas))]
+		if r.healthy.Load() {
+			return r.db
+		}
+	}
+	return d.primary
+}
+
+// MonitorReplicas periodically checks each replica's replication lag
+// via SHOW REPLICA STATUS (falling back to the pre-8.0.22 SHOW SLAVE
+// STATUS on syntax error) and marks it healthy or unhealthy for Reader
+// accordingly. It's meant to be started once, in its own goroutine, via
+// pkg/server.Runner.Go, and runs until ctx is cancelled.
+func (d *DB) MonitorReplicas(ctx context.Context, interval time.Duration) {
+	if len(d.replicas) == 0 {
+		return
+	}
+	if interval <= 0 {
+		interval = DefaultLagPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		d.checkReplicas(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *DB) checkReplicas(ctx context.Context) {
+	for _, r := range d.replicas {
+		lag, err := replicationLag(ctx, r.db)
+		if err != nil {
+			r.healthy.Store(false)
+			log.Printf("mysql: failed to read replica lag, marking unhealthy: %v", err)
+			continue
+		}
+		r.lag.Store(int64(lag))
+		r.healthy.Store(lag <= d.maxLag)
+	}
+}
+
+var errNoReplicationRow = errors.New("mysql: SHOW REPLICA STATUS returned no row")
+
+// replicationLag queries a replica's own replication status and
+// returns how far behind its source it is. MySQL renamed SHOW SLAVE
+// STATUS's "Seconds_Behind_Master" column to "Seconds_Behind_Source"
+// under SHOW REPLICA STATUS in 8.0.22; both are tried so this works
+// against older and newer servers.
+func replicationLag(ctx context.Context, conn *sql.DB) (time.Duration, error) {
+	seconds, err := secondsBehind(ctx, conn, "SHOW REPLICA STATUS", "Seconds_Behind_Source")
+	if err != nil {
+		seconds, err = secondsBehind(ctx, conn, "SHOW SLAVE STATUS", "Seconds_Behind_Master")
+	}
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func secondsBehind(ctx context.Context, conn *sql.DB, query, column string) (int64, error) {
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	if !rows.Next() {
+		return 0, errNoReplicationRow
+	}
+
+	dest := make([]interface{}, len(cols))
+	scanned := make([]sql.NullString, len(cols))
+	for i := range dest {
+		dest[i] = &scanned[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return 0, err
+	}
+
+	for i, col := range cols {
+		if col != column {
+			continue
+		}
+		if !scanned[i].Valid {
+			// NULL means replication is stopped or was never started;
+			// treat it as maximally behind rather than in-sync.
+			return int64(DefaultMaxReplicationLag/time.Second) + 1, nil
+		}
+		var seconds int64
+		if _, err := fmt.Sscanf(scanned[i].String, "%d", &seconds); err != nil {
+			return 0, err
+		}
+		return seconds, nil
+	}
+	return 0, fmt.Errorf("mysql: column %s not found in %s output", column, query)
+}
+
+// Close closes the primary and every replica connection pool.
+func (d *DB) Close() error {
+	err := d.primary.Close()
+	for _, r := range d.replicas {
+		if cerr := r.db.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestConfigMaxLagDefaultsWhenUnset(t *testing.T) {
+	if got := (Config{}).maxLag(); got != DefaultMaxReplicationLag {
+		t.Errorf("maxLag() = %v, want default %v", got, DefaultMaxReplicationLag)
+	}
+	if got := (Config{MaxReplicationLag: 30 * time.Second}).maxLag(); got != 30*time.Second {
+		t.Errorf("maxLag() = %v, want 30s", got)
+	}
+}
+
+func newFakeReplica(healthy bool) *replica {
+	r := &replica{db: &sql.DB{}}
+	r.healthy.Store(healthy)
+	return r
+}
+
+func TestDBReaderFallsBackToPrimaryWhenNoReplicasConfigured(t *testing.T) {
+	primary := &sql.DB{}
+	db := &DB{primary: primary}
+	if db.Reader() != primary {
+		t.Error("Reader() should return the primary when no replicas are configured")
+	}
+}
+
+func TestDBReaderFallsBackToPrimaryWhenNoReplicaIsHealthy(t *testing.T) {
+	primary := &sql.DB{}
+	db := &DB{primary: primary, replicas: []*replica{newFakeReplica(false), newFakeReplica(false)}}
+	if db.Reader() != primary {
+		t.Error("Reader() should fall back to the primary when every replica is unhealthy")
+	}
+}
+
+func TestDBReaderSkipsUnhealthyReplicas(t *testing.T) {
+	healthy := newFakeReplica(true)
+	db := &DB{primary: &sql.DB{}, replicas: []*replica{newFakeReplica(false), healthy, newFakeReplica(false)}}
+
+	for i := 0; i < 5; i++ {
+		if got := db.Reader(); got != healthy.db {
+			t.Errorf("Reader() = %p, want the sole healthy replica %p", got, healthy.db)
+		}
+	}
+}
+
+func TestDBReaderRoundRobinsAcrossHealthyReplicas(t *testing.T) {
+	a, b := newFakeReplica(true), newFakeReplica(true)
+	db := &DB{primary: &sql.DB{}, replicas: []*replica{a, b}}
+
+	seen := map[*sql.DB]bool{}
+	for i := 0; i < 4; i++ {
+		seen[db.Reader()] = true
+	}
+	if !seen[a.db] || !seen[b.db] {
+		t.Error("Reader() should eventually pick every healthy replica")
+	}
+}
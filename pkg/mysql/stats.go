@@ -0,0 +1,36 @@
+package mysql
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ReplicaStats is a point-in-time read of one replica's pool and
+// replication health.
+type ReplicaStats struct {
+	Healthy bool          `json:"healthy"`
+	Lag     time.Duration `json:"lag_ns"`
+	Pool    sql.DBStats   `json:"pool"`
+}
+
+// Stats is a point-in-time read of every pool DB manages, so operators
+// can see whether reads are actually landing on replicas and whether
+// any pool is saturated.
+type Stats struct {
+	Primary  sql.DBStats    `json:"primary"`
+	Replicas []ReplicaStats `json:"replicas"`
+}
+
+// Stats reports the current pool and replication-lag state of the
+// primary and every replica.
+func (d *DB) Stats() Stats {
+	s := Stats{Primary: d.primary.Stats()}
+	for _, r := range d.replicas {
+		s.Replicas = append(s.Replicas, ReplicaStats{
+			Healthy: r.healthy.Load(),
+			Lag:     time.Duration(r.lag.Load()),
+			Pool:    r.db.Stats(),
+		})
+	}
+	return s
+}
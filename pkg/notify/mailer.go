@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// Mailer sends transactional emails over SMTP using net/smtp directly,
+// the same stdlib-first approach as pkg/alerting.EmailNotifier. It's
+// kept separate from that type because outbox entries each carry their
+// own subject, where alerting's notifier always sends a fixed
+// "[alert] <rule type>" subject line.
+type Mailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewMailer builds a Mailer that authenticates to the SMTP server at
+// addr (host:port) with username/password, if given, and sends from
+// the from address. An empty username disables AUTH, for SMTP relays
+// that only accept connections from trusted networks.
+func NewMailer(addr, username, password, from string) *Mailer {
+	var auth smtp.Auth
+	if username != "" {
+		host := addr
+		if idx := strings.LastIndex(addr, ":"); idx != -1 {
+			host = addr[:idx]
+		}
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &Mailer{addr: addr, auth: auth, from: from}
+}
+
+// Send sends a plain-text email to to. net/smtp has no context-aware
+// send, so ctx is accepted only for signature consistency with the
+// rest of this package.
+func (m *Mailer) Send(_ context.Context, to, subject, body string) error {
+	msg := fmt.Sprintf("Subject: %s\r\nTo: %s\r\n\r\n%s\r\n", subject, to, body)
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{to}, []byte(msg))
+}
@@ -0,0 +1,66 @@
+// Package notify sends transactional emails to individual users
+// (subscription expiry reminders, quota warnings) through a durable
+// outbox: producers enqueue rows describing what to send, and a
+// separate worker drains the outbox and retries failed sends, so a
+// transient SMTP failure never silently drops a notification.
+package notify
+
+import "time"
+
+// Type identifies which template and dedupe scheme a Notification uses.
+type Type string
+
+const (
+	// TypeSubscriptionExpiring warns a user their subscription renews
+	// soon.
+	TypeSubscriptionExpiring Type = "subscription_expiring"
+	// TypeQuota80 warns a user they've used 80% of their monthly
+	// request quota.
+	TypeQuota80 Type = "quota_80"
+	// TypeQuota100 warns a user they've used 100% of their monthly
+	// request quota.
+	TypeQuota100 Type = "quota_100"
+	// TypeEmailVerification delivers a newly self-registered account's
+	// email verification token.
+	TypeEmailVerification Type = "email_verification"
+	// TypePasswordReset delivers a forgot-password token.
+	TypePasswordReset Type = "password_reset"
+)
+
+// Status is an OutboxEntry's delivery state.
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusSent    Status = "sent"
+	StatusDead    Status = "dead"
+)
+
+// OutboxEntry is one queued notification. DedupeKey scopes re-sends:
+// a producer re-scanning the same condition (e.g. the same user still
+// over quota this month) enqueues with the same (UserID, Type,
+// DedupeKey) and the unique constraint on that triple makes the second
+// enqueue a no-op, so a notification fires once per period rather than
+// once per scan.
+type OutboxEntry struct {
+	ID            string
+	UserID        string
+	Type          Type
+	DedupeKey     string
+	Subject       string
+	Body          string
+	Status        Status
+	Attempts      int
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// Preferences controls which notification types a user receives. Zero
+// value (as returned for a user with no row yet) means everything is
+// enabled, so opting out is explicit rather than silent-by-default.
+type Preferences struct {
+	UserID             string
+	SubscriptionExpiry bool
+	QuotaWarnings      bool
+}
@@ -0,0 +1,111 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxAttempts is how many times the worker retries a failed send before
+// giving up and marking the entry dead.
+const maxAttempts = 5
+
+// OutboxDAO persists queued notifications in MySQL.
+type OutboxDAO struct {
+	db *sql.DB
+}
+
+// NewOutboxDAO wraps an existing *sql.DB handle.
+func NewOutboxDAO(db *sql.DB) *OutboxDAO {
+	return &OutboxDAO{db: db}
+}
+
+// Enqueue queues entry for delivery, assigning it an ID. If an entry
+// with the same (UserID, Type, DedupeKey) already exists, this is a
+// no-op: the notification has already been queued or sent for that
+// period.
+func (d *OutboxDAO) Enqueue(ctx context.Context, entry *OutboxEntry) error {
+	entry.ID = uuid.NewString()
+	_, err := d.db.ExecContext(ctx,
+		`INSERT IGNORE INTO notification_outbox
+		   (id, user_id, type, dedupe_key, subject, body, status, attempts, next_attempt_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, 0, ?)`,
+		entry.ID, entry.UserID, string(entry.Type), entry.DedupeKey, entry.Subject, entry.Body,
+		string(StatusPending), time.Now().UTC())
+	return err
+}
+
+// ClaimBatch returns up to limit pending entries whose next attempt is
+// due, ordered oldest first, for the worker to send.
+func (d *OutboxDAO) ClaimBatch(ctx context.Context, limit int) ([]*OutboxEntry, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT id, user_id, type, dedupe_key, subject, body, status, attempts, next_attempt_at, created_at, sent_at
+		  FROM notification_outbox
+		 WHERE status = ? AND next_attempt_at <= ?
+		 ORDER BY id
+		 LIMIT ?`, string(StatusPending), time.Now().UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*OutboxEntry
+	for rows.Next() {
+		entry, err := scanOutboxEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, entry)
+	}
+	return out, rows.Err()
+}
+
+// MarkSent records that entry was delivered successfully.
+func (d *OutboxDAO) MarkSent(ctx context.Context, id string) error {
+	_, err := d.db.ExecContext(ctx,
+		`UPDATE notification_outbox SET status = ?, sent_at = ? WHERE id = ?`,
+		string(StatusSent), time.Now().UTC(), id)
+	return err
+}
+
+// MarkFailed records a failed delivery attempt. Once attempts reaches
+// maxAttempts the entry is marked dead instead of retried again;
+// otherwise it's rescheduled after backoff, an exponentially increasing
+// delay (1, 2, 4, 8, 16 minutes) so a persistently failing send doesn't
+// hammer the SMTP server or webhook.
+func (d *OutboxDAO) MarkFailed(ctx context.Context, id string, attempts int) error {
+	if attempts >= maxAttempts {
+		_, err := d.db.ExecContext(ctx,
+			`UPDATE notification_outbox SET status = ?, attempts = ? WHERE id = ?`,
+			string(StatusDead), attempts, id)
+		return err
+	}
+
+	backoff := time.Duration(1<<uint(attempts)) * time.Minute
+	_, err := d.db.ExecContext(ctx,
+		`UPDATE notification_outbox SET attempts = ?, next_attempt_at = ? WHERE id = ?`,
+		attempts, time.Now().UTC().Add(backoff), id)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanOutboxEntry(row rowScanner) (*OutboxEntry, error) {
+	e := &OutboxEntry{}
+	var entryType, status string
+	var sentAt sql.NullTime
+	if err := row.Scan(&e.ID, &e.UserID, &entryType, &e.DedupeKey, &e.Subject, &e.Body, &status,
+		&e.Attempts, &e.NextAttemptAt, &e.CreatedAt, &sentAt); err != nil {
+		return nil, err
+	}
+	e.Type = Type(entryType)
+	e.Status = Status(status)
+	if sentAt.Valid {
+		e.SentAt = &sentAt.Time
+	}
+	return e, nil
+}
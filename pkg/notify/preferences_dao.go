@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+)
+
+// PreferencesDAO persists per-user notification preferences in MySQL.
+type PreferencesDAO struct {
+	db *sql.DB
+}
+
+// NewPreferencesDAO wraps an existing *sql.DB handle.
+func NewPreferencesDAO(db *sql.DB) *PreferencesDAO {
+	return &PreferencesDAO{db: db}
+}
+
+// Get returns userID's preferences, or the all-enabled default if they
+// haven't set any.
+func (d *PreferencesDAO) Get(ctx context.Context, userID string) (Preferences, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT user_id, subscription_expiry, quota_warnings FROM notification_preferences WHERE user_id = ?`, userID)
+
+	var p Preferences
+	err := row.Scan(&p.UserID, &p.SubscriptionExpiry, &p.QuotaWarnings)
+	if err == sql.ErrNoRows {
+		return Preferences{UserID: userID, SubscriptionExpiry: true, QuotaWarnings: true}, nil
+	}
+	if err != nil {
+		return Preferences{}, err
+	}
+	return p, nil
+}
+
+// Upsert creates or replaces userID's preferences.
+func (d *PreferencesDAO) Upsert(ctx context.Context, p Preferences) error {
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO notification_preferences (user_id, subscription_expiry, quota_warnings)
+		 VALUES (?, ?, ?)
+		 ON DUPLICATE KEY UPDATE
+		   subscription_expiry = VALUES(subscription_expiry),
+		   quota_warnings = VALUES(quota_warnings)`,
+		p.UserID, p.SubscriptionExpiry, p.QuotaWarnings)
+	return err
+}
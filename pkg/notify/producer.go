@@ -0,0 +1,198 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/pagination"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// expiryWindow is how far ahead Producer looks for renewing
+// subscriptions when deciding whether to warn a user.
+const expiryWindow = 7 * 24 * time.Hour
+
+// userPageSize bounds how many users Producer loads per page when
+// scanning for quota warnings.
+const userPageSize = 200
+
+// Producer scans subscriptions and usage for conditions that warrant a
+// user-facing notification and enqueues one to the outbox. Enqueuing is
+// idempotent per period via OutboxEntry.DedupeKey, so running this on a
+// ticker doesn't re-notify a user every pass.
+type Producer struct {
+	subs    *billing.SubscriptionDAO
+	plans   *billing.PlanDAO
+	users   *user.DAO
+	rollups *usage.RollupDAO
+	usage   *usage.DAO
+	prefs   *PreferencesDAO
+	outbox  *OutboxDAO
+}
+
+// NewProducer builds a Producer.
+func NewProducer(subs *billing.SubscriptionDAO, plans *billing.PlanDAO, users *user.DAO, rollups *usage.RollupDAO, usageDAO *usage.DAO, prefs *PreferencesDAO, outbox *OutboxDAO) *Producer {
+	return &Producer{subs: subs, plans: plans, users: users, rollups: rollups, usage: usageDAO, prefs: prefs, outbox: outbox}
+}
+
+// Run scans on a ticker until ctx is canceled, logging (but not
+// stopping on) per-pass errors.
+func (p *Producer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.ScanOnce(ctx); err != nil {
+				log.Printf("notify: scan pass failed: %v", err)
+			}
+		}
+	}
+}
+
+// ScanOnce runs both the subscription-expiry and quota-warning scans
+// once.
+func (p *Producer) ScanOnce(ctx context.Context) error {
+	if err := p.scanExpiringSubscriptions(ctx); err != nil {
+		return fmt.Errorf("notify: expiring subscriptions scan: %w", err)
+	}
+	if err := p.scanQuotaWarnings(ctx); err != nil {
+		return fmt.Errorf("notify: quota warnings scan: %w", err)
+	}
+	return nil
+}
+
+func (p *Producer) scanExpiringSubscriptions(ctx context.Context) error {
+	now := time.Now().UTC()
+	expiring, err := p.subs.ListExpiringBefore(ctx, now.Add(expiryWindow))
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range expiring {
+		prefs, err := p.prefs.Get(ctx, sub.UserID)
+		if err != nil {
+			return err
+		}
+		if !prefs.SubscriptionExpiry {
+			continue
+		}
+
+		subject, body, err := Render(TypeSubscriptionExpiring, SubscriptionExpiringData{
+			Plan:          string(sub.Plan),
+			DaysRemaining: int(sub.CurrentPeriodEnd.Sub(now).Hours() / 24),
+		})
+		if err != nil {
+			return err
+		}
+
+		err = p.outbox.Enqueue(ctx, &OutboxEntry{
+			UserID:    sub.UserID,
+			Type:      TypeSubscriptionExpiring,
+			DedupeKey: sub.CurrentPeriodEnd.Format("2006-01-02"),
+			Subject:   subject,
+			Body:      body,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Producer) scanQuotaWarnings(ctx context.Context) error {
+	now := time.Now().UTC()
+	month := now.Format("2006-01")
+	plans := map[user.Plan]*billing.Plan{}
+
+	for offset := 0; ; offset += userPageSize {
+		page, err := p.users.List(ctx, user.ListFilter{Status: user.StatusActive, Page: pagination.Params{Limit: userPageSize, Offset: offset}})
+		if err != nil {
+			return err
+		}
+		if len(page.Items) == 0 {
+			break
+		}
+
+		for _, u := range page.Items {
+			plan, ok := plans[u.Plan]
+			if !ok {
+				plan, err = p.plans.Get(ctx, u.Plan)
+				if err != nil {
+					return err
+				}
+				plans[u.Plan] = plan
+			}
+			if plan.QuotaRequests <= 0 {
+				continue
+			}
+
+			if err := p.checkUserQuota(ctx, u, plan, month, now); err != nil {
+				return err
+			}
+		}
+
+		if len(page.Items) < userPageSize {
+			break
+		}
+	}
+	return nil
+}
+
+func (p *Producer) checkUserQuota(ctx context.Context, u *user.User, plan *billing.Plan, month string, now time.Time) error {
+	prefs, err := p.prefs.Get(ctx, u.ID)
+	if err != nil {
+		return err
+	}
+	if !prefs.QuotaWarnings {
+		return nil
+	}
+
+	stats, err := p.rollups.MonthlyStats(ctx, p.usage, u.ID, now)
+	if err != nil {
+		return err
+	}
+	percent := float64(stats.RequestCount) / float64(plan.QuotaRequests) * 100
+
+	notifyType, dedupeSuffix := quotaNotification(percent)
+	if notifyType == "" {
+		return nil
+	}
+
+	subject, body, err := Render(notifyType, QuotaWarningData{
+		Plan:            string(u.Plan),
+		UsedRequests:    stats.RequestCount,
+		QuotaRequests:   plan.QuotaRequests,
+		PercentConsumed: percent,
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.outbox.Enqueue(ctx, &OutboxEntry{
+		UserID:    u.ID,
+		Type:      notifyType,
+		DedupeKey: month + ":" + dedupeSuffix,
+		Subject:   subject,
+		Body:      body,
+	})
+}
+
+// quotaNotification picks the most severe quota warning percent
+// crosses, or "" if none applies.
+func quotaNotification(percent float64) (Type, string) {
+	switch {
+	case percent >= 100:
+		return TypeQuota100, "100"
+	case percent >= 80:
+		return TypeQuota80, "80"
+	default:
+		return "", ""
+	}
+}
@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// SubscriptionExpiringData fills the TypeSubscriptionExpiring template.
+type SubscriptionExpiringData struct {
+	Plan          string
+	DaysRemaining int
+}
+
+// QuotaWarningData fills the TypeQuota80/TypeQuota100 templates.
+type QuotaWarningData struct {
+	Plan            string
+	UsedRequests    int64
+	QuotaRequests   int64
+	PercentConsumed float64
+}
+
+// EmailVerificationData fills the TypeEmailVerification template.
+type EmailVerificationData struct {
+	Token        string
+	ExpiresHours int
+}
+
+// PasswordResetData fills the TypePasswordReset template.
+type PasswordResetData struct {
+	Token        string
+	ExpiresHours int
+}
+
+var templates = map[Type]*template.Template{
+	TypeSubscriptionExpiring: template.Must(template.New("subscription_expiring").Parse(
+		"Your {{.Plan}} subscription renews in {{.DaysRemaining}} day(s). " +
+			"No action is needed if your payment method is up to date.")),
+	TypeQuota80: template.Must(template.New("quota_80").Parse(
+		"You've used {{.UsedRequests}} of your {{.Plan}} plan's {{.QuotaRequests}} monthly requests " +
+			"({{printf \"%.0f\" .PercentConsumed}}%). Consider upgrading if you expect to exceed your quota.")),
+	TypeQuota100: template.Must(template.New("quota_100").Parse(
+		"You've used all {{.QuotaRequests}} of your {{.Plan}} plan's monthly requests. " +
+			"Further requests this period may incur overage charges or be rejected.")),
+	TypeEmailVerification: template.Must(template.New("email_verification").Parse(
+		"Use this code to verify your email address: {{.Token}}\n\n" +
+			"This code expires in {{.ExpiresHours}} hour(s). If you didn't create an account, you can ignore this email.")),
+	TypePasswordReset: template.Must(template.New("password_reset").Parse(
+		"Use this code to reset your password: {{.Token}}\n\n" +
+			"This code expires in {{.ExpiresHours}} hour(s). If you didn't request this, you can ignore this email and your password will stay unchanged.")),
+}
+
+var subjects = map[Type]string{
+	TypeSubscriptionExpiring: "Your subscription renews soon",
+	TypeQuota80:              "You're nearing your monthly request quota",
+	TypeQuota100:             "You've reached your monthly request quota",
+	TypeEmailVerification:    "Verify your email address",
+	TypePasswordReset:        "Reset your password",
+}
+
+// Render builds the subject and body for a notification of type t from
+// data, which must match the struct the template expects
+// (SubscriptionExpiringData for TypeSubscriptionExpiring,
+// QuotaWarningData for TypeQuota80/TypeQuota100).
+func Render(t Type, data interface{}) (subject, body string, err error) {
+	tmpl, ok := templates[t]
+	if !ok {
+		return "", "", fmt.Errorf("notify: no template registered for type %q", t)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", "", err
+	}
+	return subjects[t], buf.String(), nil
+}
@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// batchSize bounds how many outbox entries the worker claims per pass.
+const batchSize = 100
+
+// Worker drains the notification outbox, emailing each pending entry
+// and retrying failed sends with backoff (see OutboxDAO.MarkFailed)
+// until it succeeds or exhausts its attempts.
+type Worker struct {
+	outbox *OutboxDAO
+	users  *user.DAO
+	mailer *Mailer
+}
+
+// NewWorker builds a Worker.
+func NewWorker(outbox *OutboxDAO, users *user.DAO, mailer *Mailer) *Worker {
+	return &Worker{outbox: outbox, users: users, mailer: mailer}
+}
+
+// Run drains the outbox on a ticker until ctx is canceled, logging (but
+// not stopping on) per-pass errors.
+func (w *Worker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.ProcessBatch(ctx); err != nil {
+				log.Printf("notify: outbox pass failed: %v", err)
+			}
+		}
+	}
+}
+
+// ProcessBatch claims up to batchSize pending entries and attempts to
+// deliver each, marking it sent or rescheduling it on failure.
+func (w *Worker) ProcessBatch(ctx context.Context) error {
+	entries, err := w.outbox.ClaimBatch(ctx, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := w.deliver(ctx, entry); err != nil {
+			log.Printf("notify: failed to deliver %s to user %s: %v", entry.Type, entry.UserID, err)
+			if markErr := w.outbox.MarkFailed(ctx, entry.ID, entry.Attempts+1); markErr != nil {
+				log.Printf("notify: failed to record delivery failure for %s: %v", entry.ID, markErr)
+			}
+			continue
+		}
+		if err := w.outbox.MarkSent(ctx, entry.ID); err != nil {
+			log.Printf("notify: failed to mark %s sent: %v", entry.ID, err)
+		}
+	}
+	return nil
+}
+
+func (w *Worker) deliver(ctx context.Context, entry *OutboxEntry) error {
+	u, err := w.users.Get(ctx, entry.UserID)
+	if err != nil {
+		return err
+	}
+	return w.mailer.Send(ctx, u.Email, entry.Subject, entry.Body)
+}
@@ -0,0 +1,250 @@
+// Package oauth2 implements the authorization-code flow for the
+// third-party identity providers dashboard users can log in with
+// (Google, GitHub). It's deliberately dependency-free, the same way
+// pkg/secrets.VaultProvider talks to Vault directly: the platform only
+// needs to exchange a code for a token and fetch one user-info response,
+// not the full breadth of golang.org/x/oauth2.
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// requestTimeout bounds how long a single call to a provider may take.
+const requestTimeout = 10 * time.Second
+
+// Identity is what a provider tells us about the user who authorized
+// the login, normalized across providers.
+type Identity struct {
+	ProviderUserID string
+	Email          string
+	// EmailVerified reports whether the provider itself has confirmed
+	// Email belongs to its holder. Callers must not treat Email as
+	// proof of ownership of an existing platform account unless this
+	// is true, since a provider that doesn't require verification (or
+	// a transitional unverified state) would otherwise let anyone who
+	// merely claims an email take over an account that uses it.
+	EmailVerified bool
+}
+
+// Provider holds one OAuth2/OIDC identity provider's endpoints and this
+// app's registered client credentials for it.
+type Provider struct {
+	Name         string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+
+	// parseIdentity normalizes the user-info response into an Identity.
+	// It receives ctx/client/accessToken, not just body, because GitHub's
+	// /user endpoint doesn't report whether the returned email is
+	// verified: parseGitHubIdentity needs to make a second authenticated
+	// call to /user/emails to find that out.
+	parseIdentity func(ctx context.Context, client *http.Client, accessToken string, body []byte) (Identity, error)
+	client        *http.Client
+}
+
+// NewGoogleProvider configures the Google OIDC authorization-code flow.
+func NewGoogleProvider(clientID, clientSecret, redirectURL string) Provider {
+	return Provider{
+		Name:          "google",
+		AuthURL:       "https://accounts.google.com/o/oauth2/v2/auth",
+		TokenURL:      "https://oauth2.googleapis.com/token",
+		UserInfoURL:   "https://openidconnect.googleapis.com/v1/userinfo",
+		ClientID:      clientID,
+		ClientSecret:  clientSecret,
+		RedirectURL:   redirectURL,
+		Scopes:        []string{"openid", "email"},
+		parseIdentity: parseGoogleIdentity,
+		client:        &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// NewGitHubProvider configures the GitHub OAuth2 authorization-code
+// flow.
+func NewGitHubProvider(clientID, clientSecret, redirectURL string) Provider {
+	return Provider{
+		Name:          "github",
+		AuthURL:       "https://github.com/login/oauth/authorize",
+		TokenURL:      "https://github.com/login/oauth/access_token",
+		UserInfoURL:   "https://api.github.com/user",
+		ClientID:      clientID,
+		ClientSecret:  clientSecret,
+		RedirectURL:   redirectURL,
+		Scopes:        []string{"read:user", "user:email"},
+		parseIdentity: parseGitHubIdentity,
+		client:        &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// AuthCodeURL builds the URL to redirect the browser to, carrying state
+// for Callback to verify came from a request this app actually issued.
+func (p Provider) AuthCodeURL(state string) string {
+	q := url.Values{
+		"client_id":     {p.ClientID},
+		"redirect_uri":  {p.RedirectURL},
+		"response_type": {"code"},
+		"scope":         {strings.Join(p.Scopes, " ")},
+		"state":         {state},
+	}
+	return p.AuthURL + "?" + q.Encode()
+}
+
+// Exchange trades an authorization code for an access token.
+func (p Provider) Exchange(ctx context.Context, code string) (accessToken string, err error) {
+	form := url.Values{
+		"client_id":     {p.ClientID},
+		"client_secret": {p.ClientSecret},
+		"code":          {code},
+		"redirect_uri":  {p.RedirectURL},
+		"grant_type":    {"authorization_code"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("oauth2: %s token exchange: %w", p.Name, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("oauth2: %s token exchange returned %s: %s", p.Name, resp.Status, body)
+	}
+
+	var payload struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", fmt.Errorf("oauth2: decode %s token response: %w", p.Name, err)
+	}
+	if payload.AccessToken == "" {
+		return "", fmt.Errorf("oauth2: %s token response had no access_token", p.Name)
+	}
+	return payload.AccessToken, nil
+}
+
+// FetchIdentity calls the provider's user-info endpoint and normalizes
+// the result into an Identity.
+func (p Provider) FetchIdentity(ctx context.Context, accessToken string) (Identity, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.UserInfoURL, nil)
+	if err != nil {
+		return Identity{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return Identity{}, fmt.Errorf("oauth2: %s user info: %w", p.Name, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Identity{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Identity{}, fmt.Errorf("oauth2: %s user info returned %s: %s", p.Name, resp.Status, body)
+	}
+	return p.parseIdentity(ctx, p.client, accessToken, body)
+}
+
+// parseGoogleIdentity trusts Google's own email_verified claim, which
+// its OIDC userinfo endpoint always includes alongside email.
+func parseGoogleIdentity(_ context.Context, _ *http.Client, _ string, body []byte) (Identity, error) {
+	var payload struct {
+		Sub           string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return Identity{}, fmt.Errorf("oauth2: decode google user info: %w", err)
+	}
+	return Identity{ProviderUserID: payload.Sub, Email: payload.Email, EmailVerified: payload.EmailVerified}, nil
+}
+
+// parseGitHubIdentity can't read verification status off the /user
+// response itself, so it looks the returned email up in /user/emails,
+// which reports a verified flag per address.
+func parseGitHubIdentity(ctx context.Context, client *http.Client, accessToken string, body []byte) (Identity, error) {
+	var payload struct {
+		ID    int    `json:"id"`
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return Identity{}, fmt.Errorf("oauth2: decode github user info: %w", err)
+	}
+	identity := Identity{ProviderUserID: fmt.Sprintf("%d", payload.ID), Email: payload.Email}
+	if identity.Email == "" {
+		return identity, nil
+	}
+
+	verified, err := githubEmailVerified(ctx, client, accessToken, identity.Email)
+	if err != nil {
+		return Identity{}, err
+	}
+	identity.EmailVerified = verified
+	return identity, nil
+}
+
+// githubEmailVerified looks up email in the caller's GitHub
+// /user/emails list (requires the user:email scope, already requested
+// by NewGitHubProvider) and reports whether GitHub has it marked
+// verified. An email absent from that list is treated as unverified.
+func githubEmailVerified(ctx context.Context, client *http.Client, accessToken, email string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user/emails", nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("oauth2: github user emails: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("oauth2: github user emails returned %s: %s", resp.Status, body)
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Verified bool   `json:"verified"`
+	}
+	if err := json.Unmarshal(body, &emails); err != nil {
+		return false, fmt.Errorf("oauth2: decode github user emails: %w", err)
+	}
+	for _, e := range emails {
+		if e.Email == email {
+			return e.Verified, nil
+		}
+	}
+	return false, nil
+}
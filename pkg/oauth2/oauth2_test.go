@@ -0,0 +1,97 @@
+package oauth2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuthCodeURLIncludesClientAndState(t *testing.T) {
+	p := NewGoogleProvider("client-id", "client-secret", "https://example.com/callback")
+	url := p.AuthCodeURL("some-state")
+
+	for _, want := range []string{
+		"https://accounts.google.com/o/oauth2/v2/auth?",
+		"client_id=client-id",
+		"state=some-state",
+		"redirect_uri=https%3A%2F%2Fexample.com%2Fcallback",
+	} {
+		if !strings.Contains(url, want) {
+			t.Fatalf("AuthCodeURL() = %q, missing %q", url, want)
+		}
+	}
+}
+
+func TestParseGoogleIdentity(t *testing.T) {
+	body := []byte(`{"sub":"1234","email":"user@example.com","email_verified":true}`)
+	identity, err := parseGoogleIdentity(context.Background(), nil, "", body)
+	if err != nil {
+		t.Fatalf("parseGoogleIdentity: %v", err)
+	}
+	if identity.ProviderUserID != "1234" || identity.Email != "user@example.com" || !identity.EmailVerified {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+}
+
+func TestParseGoogleIdentityUnverified(t *testing.T) {
+	body := []byte(`{"sub":"1234","email":"user@example.com","email_verified":false}`)
+	identity, err := parseGoogleIdentity(context.Background(), nil, "", body)
+	if err != nil {
+		t.Fatalf("parseGoogleIdentity: %v", err)
+	}
+	if identity.EmailVerified {
+		t.Fatalf("expected EmailVerified to be false, got true")
+	}
+}
+
+func TestParseGitHubIdentityVerified(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"email":"user@example.com","verified":true},{"email":"other@example.com","verified":false}]`))
+	}))
+	defer srv.Close()
+
+	identity := fetchGitHubIdentityFromTestServer(t, srv, `{"id":5678,"email":"user@example.com"}`)
+	if identity.ProviderUserID != "5678" || identity.Email != "user@example.com" || !identity.EmailVerified {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+}
+
+func TestParseGitHubIdentityUnverified(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"email":"user@example.com","verified":false}]`))
+	}))
+	defer srv.Close()
+
+	identity := fetchGitHubIdentityFromTestServer(t, srv, `{"id":5678,"email":"user@example.com"}`)
+	if identity.EmailVerified {
+		t.Fatalf("expected EmailVerified to be false, got true")
+	}
+}
+
+// fetchGitHubIdentityFromTestServer calls parseGitHubIdentity against
+// body with githubEmailVerified's request redirected to srv, since
+// parseGitHubIdentity always targets the real api.github.com host.
+func fetchGitHubIdentityFromTestServer(t *testing.T, srv *httptest.Server, body string) Identity {
+	t.Helper()
+	client := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		redirected, err := http.NewRequestWithContext(req.Context(), req.Method, srv.URL, req.Body)
+		if err != nil {
+			return nil, err
+		}
+		redirected.Header = req.Header
+		return http.DefaultTransport.RoundTrip(redirected)
+	})}
+	identity, err := parseGitHubIdentity(context.Background(), client, "token", []byte(body))
+	if err != nil {
+		t.Fatalf("parseGitHubIdentity: %v", err)
+	}
+	return identity
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,60 @@
+package oauth2
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// StateTTL is how long an issued state/nonce value remains valid for
+// ConsumeState to redeem during the provider redirect round trip.
+const StateTTL = 10 * time.Minute
+
+// ErrStateInvalid is returned for a state value that's unknown, expired,
+// or already consumed, which rejects both forged callbacks and replay of
+// a legitimate one.
+var ErrStateInvalid = errors.New("oauth2: state invalid or expired")
+
+// StateStore issues and redeems single-use CSRF state values for the
+// authorization-code round trip, the same way auth.CredentialService
+// manages single-use password reset tokens in Redis.
+type StateStore struct {
+	client goredis.UniversalClient
+}
+
+// NewStateStore wraps an existing Redis client.
+func NewStateStore(client goredis.UniversalClient) *StateStore {
+	return &StateStore{client: client}
+}
+
+func stateKey(raw string) string {
+	return "oauth2:state:" + raw
+}
+
+// IssueState generates a new state value and records it as pending.
+func (s *StateStore) IssueState(ctx context.Context) (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	state := hex.EncodeToString(buf)
+	if err := s.client.Set(ctx, stateKey(state), "1", StateTTL).Err(); err != nil {
+		return "", err
+	}
+	return state, nil
+}
+
+// ConsumeState redeems state, invalidating it in the same operation so
+// it can't be replayed. It returns ErrStateInvalid if state is unknown,
+// expired, or was already consumed.
+func (s *StateStore) ConsumeState(ctx context.Context, state string) error {
+	_, err := s.client.GetDel(ctx, stateKey(state)).Result()
+	if errors.Is(err, goredis.Nil) {
+		return ErrStateInvalid
+	}
+	return err
+}
@@ -0,0 +1,322 @@
+// Package objstore is a minimal client for S3-compatible object storage
+// (AWS S3, MinIO, etc.), used to archive data too large to keep in
+// MySQL indefinitely. It is deliberately dependency-free: the platform
+// only needs to put, get and list objects in one bucket, not the full
+// AWS SDK, so it signs requests with AWS Signature Version 4 directly
+// over net/http, mirroring pkg/secrets.VaultProvider's approach to
+// talking to an external service without pulling in its client library.
+package objstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clientTimeout bounds a single request against the object store, so an
+// unreachable endpoint can't hang an archival pass indefinitely.
+const clientTimeout = 30 * time.Second
+
+// Client talks to a single bucket on an S3-compatible endpoint.
+type Client struct {
+	endpoint  string // e.g. "https://s3.us-east-1.amazonaws.com" or a MinIO URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	http      *http.Client
+}
+
+// NewClient builds a Client. endpoint is the service root (no bucket or
+// key suffix); path-style addressing (endpoint/bucket/key) is used so
+// the same client works against MinIO and other non-AWS endpoints that
+// don't support virtual-hosted buckets.
+func NewClient(endpoint, region, bucket, accessKey, secretKey string) *Client {
+	return &Client{
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		http:      &http.Client{Timeout: clientTimeout},
+	}
+}
+
+// PutObject uploads body under key, overwriting any existing object at
+// that key.
+func (c *Client) PutObject(ctx context.Context, key string, body []byte, contentType string) error {
+	req, err := c.newSignedRequest(ctx, http.MethodPut, key, nil, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("objstore: put %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("objstore: put %s: %s", key, statusWithBody(resp))
+	}
+	return nil
+}
+
+// GetObject downloads the object at key.
+func (c *Client) GetObject(ctx context.Context, key string) ([]byte, error) {
+	req, err := c.newSignedRequest(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("objstore: get %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("objstore: get %s: %s", key, statusWithBody(resp))
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// ErrNotFound is returned when the requested object doesn't exist.
+var ErrNotFound = fmt.Errorf("objstore: object not found")
+
+// Object describes a single entry returned by ListObjects.
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+}
+
+// ListObjects returns every object whose key starts with prefix.
+func (c *Client) ListObjects(ctx context.Context, prefix string) ([]Object, error) {
+	query := url.Values{"list-type": {"2"}}
+	if prefix != "" {
+		query.Set("prefix", prefix)
+	}
+	req, err := c.newSignedRequest(ctx, http.MethodGet, "", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("objstore: list %s: %w", prefix, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("objstore: list %s: %s", prefix, statusWithBody(resp))
+	}
+
+	var parsed listBucketResult
+	if err := xml.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("objstore: decode list response: %w", err)
+	}
+
+	out := make([]Object, 0, len(parsed.Contents))
+	for _, entry := range parsed.Contents {
+		out = append(out, Object{Key: entry.Key, Size: entry.Size, LastModified: entry.LastModified})
+	}
+	return out, nil
+}
+
+// PresignGetURL returns a URL that grants time-limited, unauthenticated
+// read access to key, valid for expires from now. Unlike PutObject/
+// GetObject, which sign a request this client sends itself, this signs
+// the query string of a URL meant to be handed to someone else (e.g. an
+// HTTP response), following the same SigV4 query-string-signing scheme
+// S3 presigned URLs use.
+func (c *Client) PresignGetURL(objectKey string, expires time.Duration) (string, error) {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+	credentialScope := fmt.Sprintf("%s/%s/s3/aws4_request", dateStamp, c.region)
+
+	canonicalURI := "/" + c.bucket + "/" + encodePath(objectKey)
+	parsedEndpoint, err := url.Parse(c.endpoint)
+	if err != nil {
+		return "", fmt.Errorf("objstore: parse endpoint: %w", err)
+	}
+	host := parsedEndpoint.Host
+
+	query := url.Values{
+		"X-Amz-Algorithm":     {"AWS4-HMAC-SHA256"},
+		"X-Amz-Credential":    {c.accessKey + "/" + credentialScope},
+		"X-Amz-Date":          {amzDate},
+		"X-Amz-Expires":       {strconv.Itoa(int(expires.Seconds()))},
+		"X-Amz-SignedHeaders": {"host"},
+	}
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		canonicalURI,
+		canonicalQueryString(query),
+		"host:" + host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	derivedKey := signingKey(c.secretKey, dateStamp, c.region, "s3")
+	signature := hex.EncodeToString(hmacSHA256(derivedKey, stringToSign))
+	query.Set("X-Amz-Signature", signature)
+
+	return c.endpoint + canonicalURI + "?" + query.Encode(), nil
+}
+
+func statusWithBody(resp *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return resp.Status + ": " + string(body)
+}
+
+// newSignedRequest builds an HTTP request against key (empty for a
+// bucket-level operation like ListObjects) with an AWS Signature
+// Version 4 Authorization header.
+func (c *Client) newSignedRequest(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Request, error) {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+
+	canonicalURI := "/" + c.bucket
+	if key != "" {
+		canonicalURI += "/" + encodePath(key)
+	}
+
+	rawURL := c.endpoint + canonicalURI
+	if len(query) > 0 {
+		rawURL += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	payloadHash := sha256Hex(body)
+	host := req.URL.Host
+
+	req.Header.Set("Host", host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders, canonicalHeaders := canonicalizeHeaders(req.Header, host)
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonicalURI,
+		canonicalQueryString(query),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	credentialScope := fmt.Sprintf("%s/%s/s3/aws4_request", dateStamp, c.region)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := signingKey(c.secretKey, dateStamp, c.region, "s3")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	authHeader := fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.accessKey, credentialScope, signedHeaders, signature)
+	req.Header.Set("Authorization", authHeader)
+
+	return req, nil
+}
+
+func canonicalizeHeaders(header http.Header, host string) (signedHeaders, canonicalHeaders string) {
+	headers := map[string]string{
+		"host":                 host,
+		"x-amz-content-sha256": header.Get("X-Amz-Content-Sha256"),
+		"x-amz-date":           header.Get("X-Amz-Date"),
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name)
+		canonical.WriteByte(':')
+		canonical.WriteString(headers[name])
+		canonical.WriteByte('\n')
+	}
+	return strings.Join(names, ";"), canonical.String()
+}
+
+func canonicalQueryString(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, url.QueryEscape(k)+"="+url.QueryEscape(query.Get(k)))
+	}
+	return strings.Join(parts, "&")
+}
+
+func encodePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func signingKey(secretKey, dateStamp, region, service string) []byte {
+	kDate := hmacSHA256([]byte("AWS4"+secretKey), dateStamp)
+	kRegion := hmacSHA256(kDate, region)
+	kService := hmacSHA256(kRegion, service)
+	return hmacSHA256(kService, "aws4_request")
+}
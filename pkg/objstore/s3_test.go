@@ -0,0 +1,36 @@
+package objstore
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestCanonicalQueryStringSortsKeys(t *testing.T) {
+	query := url.Values{"prefix": {"usage-logs/"}, "list-type": {"2"}}
+	got := canonicalQueryString(query)
+	want := "list-type=2&prefix=usage-logs%2F"
+	if got != want {
+		t.Fatalf("canonicalQueryString() = %q, want %q", got, want)
+	}
+}
+
+func TestSigningKeyIsDeterministic(t *testing.T) {
+	a := signingKey("secret", "20260809", "us-east-1", "s3")
+	b := signingKey("secret", "20260809", "us-east-1", "s3")
+	if string(a) != string(b) {
+		t.Fatal("signingKey() is not deterministic for the same inputs")
+	}
+
+	c := signingKey("other-secret", "20260809", "us-east-1", "s3")
+	if string(a) == string(c) {
+		t.Fatal("signingKey() should differ for different secret keys")
+	}
+}
+
+func TestEncodePathEscapesSegmentsNotSlashes(t *testing.T) {
+	got := encodePath("usage-logs/2026/08/09/archive one.ndjson.gz")
+	want := "usage-logs/2026/08/09/archive%20one.ndjson.gz"
+	if got != want {
+		t.Fatalf("encodePath() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,110 @@
+// Package onboarding composes the multi-DAO work of setting up a new
+// account across two steps: CreateAccount creates the user row itself
+// in a pending-verification state, and VerifyAccount — once the
+// registrant redeems their email verification token — activates it and
+// provisions its default subscription and starter API key, as a single
+// atomic operation, so a failure partway through can't leave an account
+// half-provisioned.
+package onboarding
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/dbtx"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// defaultAPIKeyName is what the starter key created on verification is
+// called.
+const defaultAPIKeyName = "default"
+
+// Result is what a successful VerifyAccount produces.
+type Result struct {
+	User   *user.User
+	APIKey string // raw value; shown once, like apikey.DAO.Generate's return
+}
+
+// Service provisions new accounts across the user, billing, and apikey
+// DAOs in one transaction.
+type Service struct {
+	db            *sql.DB
+	users         *user.DAO
+	subscriptions *billing.SubscriptionDAO
+	keys          *apikey.DAO
+}
+
+// NewService wires a Service from its dependencies. db must be the same
+// *sql.DB each DAO was built from, since Service opens its own
+// transaction and rescopes the DAOs onto it via WithTx.
+func NewService(db *sql.DB, users *user.DAO, subscriptions *billing.SubscriptionDAO, keys *apikey.DAO) *Service {
+	return &Service{db: db, users: users, subscriptions: subscriptions, keys: keys}
+}
+
+// CreateAccount creates a new account with the given email and
+// already-hashed password on the free plan, in
+// user.StatusPendingVerification. It has no subscription or API key
+// yet — those aren't provisioned until VerifyAccount succeeds, so an
+// address that never verifies doesn't consume either.
+func (s *Service) CreateAccount(ctx context.Context, email, passwordHash string) (*user.User, error) {
+	var created *user.User
+	err := dbtx.Run(ctx, s.db, func(tx *sql.Tx) error {
+		users := s.users.WithTx(tx)
+
+		u, err := users.Create(ctx, email, passwordHash, user.PlanFree)
+		if err != nil {
+			return err
+		}
+		if err := users.UpdateStatus(ctx, u.ID, user.StatusPendingVerification); err != nil {
+			return err
+		}
+		u.Status = user.StatusPendingVerification
+		created = u
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// VerifyAccount activates userID's account and provisions its active
+// free subscription and starter API key, atomically.
+func (s *Service) VerifyAccount(ctx context.Context, userID string) (*Result, error) {
+	var result Result
+	err := dbtx.Run(ctx, s.db, func(tx *sql.Tx) error {
+		users := s.users.WithTx(tx)
+		subscriptions := s.subscriptions.WithTx(tx)
+		keys := s.keys.WithTx(tx)
+
+		if err := users.UpdateStatus(ctx, userID, user.StatusActive); err != nil {
+			return err
+		}
+		activated, err := users.Get(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := subscriptions.Upsert(ctx, &billing.Subscription{
+			UserID: activated.ID,
+			Plan:   user.PlanFree,
+			Status: billing.SubscriptionStatusActive,
+		}); err != nil {
+			return err
+		}
+
+		raw, _, err := keys.Generate(ctx, activated.ID, "", defaultAPIKeyName, nil, nil, apikey.RotationModePerRequest, 0)
+		if err != nil {
+			return err
+		}
+
+		result = Result{User: activated, APIKey: raw}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,50 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Handler serves b's accumulated routes as application/json, suitable
+// for mounting at /openapi.json.
+func Handler(b *Builder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := b.JSON()
+		if err != nil {
+			http.Error(w, "failed to build openapi spec", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}
+}
+
+// swaggerUITemplate loads Swagger UI from a CDN rather than vendoring
+// its (multi-megabyte) static assets into this repo; specPath is
+// substituted with fmt.Sprintf, not html/template, so it must come from
+// a trusted, build-time value (a route constant), never a request.
+const swaggerUITemplate = `<!DOCTYPE html>
+<html>
+<head>
+  <title>API docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => SwaggerUIBundle({url: %q, dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`
+
+// UIHandler serves a Swagger UI page that renders the spec served at
+// specPath (typically "/openapi.json").
+func UIHandler(specPath string) http.HandlerFunc {
+	page := []byte(fmt.Sprintf(swaggerUITemplate, specPath))
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	}
+}
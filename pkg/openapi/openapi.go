@@ -0,0 +1,165 @@
+// Package openapi is a small, dependency-free OpenAPI 3 document builder.
+// Services declare their routes programmatically with Builder.Add and
+// serve the result with Handler; there's no reflection over handler
+// signatures or struct tags, so a route's documentation only exists if
+// a service author wrote it down here.
+package openapi
+
+import "encoding/json"
+
+// Info describes the service being documented, shown at the top of the
+// generated spec and the Swagger UI page.
+type Info struct {
+	Title       string
+	Version     string
+	Description string
+}
+
+// Schema is a (deliberately small) subset of the OpenAPI schema object:
+// enough to describe the JSON bodies and query parameters this codebase
+// actually uses, not a general-purpose JSON Schema implementation.
+type Schema struct {
+	Type   string   `json:"type,omitempty"`
+	Format string   `json:"format,omitempty"`
+	Enum   []string `json:"enum,omitempty"`
+	Items  *Schema  `json:"items,omitempty"`
+}
+
+// Parameter documents a single path, query, or header parameter.
+type Parameter struct {
+	Name        string
+	In          string // "path", "query", or "header"
+	Description string
+	Required    bool
+	Schema      Schema
+}
+
+// RequestBody documents a JSON request body.
+type RequestBody struct {
+	Description string
+	Required    bool
+	Schema      Schema
+}
+
+// Response documents a single status code's response.
+type Response struct {
+	Description string
+	Schema      Schema // zero value omits the content object entirely
+}
+
+// Operation documents a single method on a path.
+type Operation struct {
+	Summary     string
+	Description string
+	Tags        []string
+	Parameters  []Parameter
+	RequestBody *RequestBody
+	// Responses is keyed by status code, e.g. "200", "404".
+	Responses map[string]Response
+}
+
+// Builder accumulates routes into an OpenAPI 3 document.
+type Builder struct {
+	info  Info
+	paths map[string]map[string]Operation // path -> lowercase method -> Operation
+}
+
+// NewBuilder starts a Builder for the given service.
+func NewBuilder(info Info) *Builder {
+	return &Builder{info: info, paths: make(map[string]map[string]Operation)}
+}
+
+// Add documents method (e.g. "GET", "POST") on path. It returns b so
+// calls can be chained.
+func (b *Builder) Add(path, method string, op Operation) *Builder {
+	methods, ok := b.paths[path]
+	if !ok {
+		methods = make(map[string]Operation)
+		b.paths[path] = methods
+	}
+	methods[lower(method)] = op
+	return b
+}
+
+// JSON renders the accumulated routes as an OpenAPI 3.0.3 document.
+func (b *Builder) JSON() ([]byte, error) {
+	paths := make(map[string]interface{}, len(b.paths))
+	for path, methods := range b.paths {
+		ops := make(map[string]interface{}, len(methods))
+		for method, op := range methods {
+			ops[method] = operationJSON(op)
+		}
+		paths[path] = ops
+	}
+
+	doc := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       b.info.Title,
+			"version":     b.info.Version,
+			"description": b.info.Description,
+		},
+		"paths": paths,
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func operationJSON(op Operation) map[string]interface{} {
+	out := map[string]interface{}{
+		"summary":     op.Summary,
+		"description": op.Description,
+	}
+	if len(op.Tags) > 0 {
+		out["tags"] = op.Tags
+	}
+
+	if len(op.Parameters) > 0 {
+		params := make([]map[string]interface{}, len(op.Parameters))
+		for i, p := range op.Parameters {
+			params[i] = map[string]interface{}{
+				"name":        p.Name,
+				"in":          p.In,
+				"description": p.Description,
+				"required":    p.Required,
+				"schema":      p.Schema,
+			}
+		}
+		out["parameters"] = params
+	}
+
+	if op.RequestBody != nil {
+		out["requestBody"] = map[string]interface{}{
+			"description": op.RequestBody.Description,
+			"required":    op.RequestBody.Required,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": op.RequestBody.Schema},
+			},
+		}
+	}
+
+	responses := make(map[string]interface{}, len(op.Responses))
+	for code, resp := range op.Responses {
+		r := map[string]interface{}{"description": resp.Description}
+		if resp.Schema.Type != "" {
+			r["content"] = map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": resp.Schema},
+			}
+		}
+		responses[code] = r
+	}
+	if len(responses) > 0 {
+		out["responses"] = responses
+	}
+
+	return out
+}
+
+func lower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + ('a' - 'A')
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,66 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBuilderJSONIncludesAddedRoutes(t *testing.T) {
+	b := NewBuilder(Info{Title: "test-api", Version: "1.0.0"})
+	b.Add("/widgets", "GET", Operation{
+		Summary: "List widgets",
+		Tags:    []string{"widgets"},
+		Responses: map[string]Response{
+			"200": {Description: "a page of widgets"},
+		},
+	})
+
+	body, err := b.JSON()
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("unmarshal spec: %v", err)
+	}
+	if doc["openapi"] != "3.0.3" {
+		t.Errorf("openapi version = %v, want 3.0.3", doc["openapi"])
+	}
+
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("paths missing or wrong type: %v", doc["paths"])
+	}
+	widget, ok := paths["/widgets"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("/widgets path missing: %v", paths)
+	}
+	get, ok := widget["get"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("GET /widgets missing: %v", widget)
+	}
+	if get["summary"] != "List widgets" {
+		t.Errorf("summary = %v, want %q", get["summary"], "List widgets")
+	}
+}
+
+func TestBuilderAddIsCaseInsensitiveOnMethod(t *testing.T) {
+	b := NewBuilder(Info{Title: "test-api", Version: "1.0.0"})
+	b.Add("/widgets", "post", Operation{Summary: "Create a widget"})
+
+	body, err := b.JSON()
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("unmarshal spec: %v", err)
+	}
+	paths := doc["paths"].(map[string]interface{})
+	widget := paths["/widgets"].(map[string]interface{})
+	if _, ok := widget["post"]; !ok {
+		t.Errorf("expected lowercase 'post' key, got %v", widget)
+	}
+}
@@ -0,0 +1,309 @@
+// Package organization lets multiple users share a team account: an
+// Organization owns API keys and (eventually) billing, and its Members
+// each hold a Role controlling what they can do within it. It does not
+// yet change how quotas or usage are scoped — see Member's doc comment
+// for the current boundary.
+package organization
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dbtx"
+)
+
+// ErrNotFound is returned when no organization matches the given ID.
+var ErrNotFound = errors.New("organization: not found")
+
+// ErrNotMember is returned when a user has no membership in an
+// organization.
+var ErrNotMember = errors.New("organization: not a member")
+
+// ErrLastOwner is returned by RemoveMember and UpdateMemberRole when
+// the change would leave an organization with no RoleOwner member.
+var ErrLastOwner = errors.New("organization: organization must keep at least one owner")
+
+// Organization is a team account: API keys can be issued under it
+// (apikey.Key.OrgID) instead of directly under a single user.
+type Organization struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Role controls what a Member may do within their Organization.
+type Role string
+
+const (
+	// RoleOwner can manage billing, invite/remove members of any role,
+	// and everything RoleAdmin can.
+	RoleOwner Role = "owner"
+	// RoleAdmin can invite/remove RoleMember members and manage the
+	// org's API keys, but not billing or other owners/admins.
+	RoleAdmin Role = "admin"
+	// RoleMember can use the org's API keys but not manage membership.
+	RoleMember Role = "member"
+)
+
+// Member is one user's membership in one Organization. Quotas and rate
+// limits are still resolved per-user (pkg/ratelimit) or per-key
+// (pkg/bandwidth), not per-organization; an org-level quota that pools
+// usage across every member's keys is out of scope here and would need
+// its own follow-up once this membership model is in place.
+type Member struct {
+	OrgID    string
+	UserID   string
+	Role     Role
+	JoinedAt time.Time
+}
+
+// DAOInterface is the subset of DAO's behavior that service and handler
+// code depends on. It exists so those layers can be unit-tested against
+// daofake's in-memory fake instead of a real MySQL connection; see
+// pkg/daofake's conformance suite, which every implementation (DAO
+// included) must pass.
+type DAOInterface interface {
+	Create(ctx context.Context, name, ownerUserID string) (*Organization, error)
+	Get(ctx context.Context, id string) (*Organization, error)
+	ListForUser(ctx context.Context, userID string) ([]*Organization, error)
+	Rename(ctx context.Context, id, name string) error
+	Delete(ctx context.Context, id string) error
+	AddMember(ctx context.Context, orgID, userID string, role Role) error
+	RemoveMember(ctx context.Context, orgID, userID string) error
+	UpdateMemberRole(ctx context.Context, orgID, userID string, role Role) error
+	ListMembers(ctx context.Context, orgID string) ([]*Member, error)
+	GetMembership(ctx context.Context, orgID, userID string) (*Member, error)
+}
+
+// DAO persists organizations and their membership in MySQL.
+type DAO struct {
+	db dbtx.Queryer
+
+	// rawDB is set only when DAO was built via NewDAO, not WithTx, since
+	// a *sql.Tx can't itself start a nested transaction. Create uses it
+	// directly to insert the organization and its founding owner
+	// atomically.
+	rawDB *sql.DB
+}
+
+var _ DAOInterface = (*DAO)(nil)
+
+// NewDAO wraps an existing *sql.DB handle.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db, rawDB: db}
+}
+
+// WithTx returns a DAO whose operations run against tx instead of the
+// original *sql.DB, so callers can compose it with other DAOs inside a
+// dbtx.Run unit of work.
+func (d *DAO) WithTx(tx *sql.Tx) *DAO {
+	return &DAO{db: tx}
+}
+
+// Create inserts a new Organization and adds ownerUserID as its first
+// member with RoleOwner, atomically.
+func (d *DAO) Create(ctx context.Context, name, ownerUserID string) (*Organization, error) {
+	if d.rawDB == nil {
+		return nil, errors.New("organization: DAO scoped to a transaction cannot start a nested transaction")
+	}
+	tx, err := d.rawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	org := &Organization{ID: uuid.NewString(), Name: name, CreatedAt: now, UpdatedAt: now}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO organizations (id, name, created_at, updated_at) VALUES (?, ?, ?, ?)`,
+		org.ID, org.Name, org.CreatedAt, org.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO organization_members (org_id, user_id, role, joined_at) VALUES (?, ?, ?, ?)`,
+		org.ID, ownerUserID, string(RoleOwner), now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// Get loads a single organization by ID.
+func (d *DAO) Get(ctx context.Context, id string) (*Organization, error) {
+	row := d.db.QueryRowContext(ctx, `SELECT id, name, created_at, updated_at FROM organizations WHERE id = ?`, id)
+	return scanOrganization(row)
+}
+
+// ListForUser returns every organization userID is a member of.
+func (d *DAO) ListForUser(ctx context.Context, userID string) ([]*Organization, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT o.id, o.name, o.created_at, o.updated_at
+		 FROM organizations o
+		 JOIN organization_members m ON m.org_id = o.id
+		 WHERE m.user_id = ?
+		 ORDER BY o.created_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Organization
+	for rows.Next() {
+		org, err := scanOrganization(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, org)
+	}
+	return out, rows.Err()
+}
+
+// Rename changes an organization's display name.
+func (d *DAO) Rename(ctx context.Context, id, name string) error {
+	result, err := d.db.ExecContext(ctx, `UPDATE organizations SET name = ?, updated_at = ? WHERE id = ?`, name, time.Now().UTC(), id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes an organization and its memberships. API keys issued
+// under it are left in place with their OrgID now dangling, the same
+// way user.DAO.SoftDelete leaves a deleted user's usage history intact;
+// callers that care should revoke those keys first.
+func (d *DAO) Delete(ctx context.Context, id string) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM organizations WHERE id = ?`, id)
+	return err
+}
+
+// AddMember adds userID to orgID with role, or updates their role if
+// they're already a member.
+func (d *DAO) AddMember(ctx context.Context, orgID, userID string, role Role) error {
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO organization_members (org_id, user_id, role, joined_at) VALUES (?, ?, ?, ?)
+		 ON DUPLICATE KEY UPDATE role = VALUES(role)`,
+		orgID, userID, string(role), time.Now().UTC())
+	return err
+}
+
+// RemoveMember removes userID's membership in orgID. It refuses to
+// remove an organization's last remaining RoleOwner, since that would
+// leave the organization with no one able to manage it.
+func (d *DAO) RemoveMember(ctx context.Context, orgID, userID string) error {
+	member, err := d.GetMembership(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role == RoleOwner {
+		if sole, err := d.isSoleOwner(ctx, orgID, userID); err != nil {
+			return err
+		} else if sole {
+			return ErrLastOwner
+		}
+	}
+
+	_, err = d.db.ExecContext(ctx, `DELETE FROM organization_members WHERE org_id = ? AND user_id = ?`, orgID, userID)
+	return err
+}
+
+// UpdateMemberRole changes userID's role within orgID. It refuses to
+// demote an organization's last remaining RoleOwner.
+func (d *DAO) UpdateMemberRole(ctx context.Context, orgID, userID string, role Role) error {
+	member, err := d.GetMembership(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role == RoleOwner && role != RoleOwner {
+		if sole, err := d.isSoleOwner(ctx, orgID, userID); err != nil {
+			return err
+		} else if sole {
+			return ErrLastOwner
+		}
+	}
+
+	_, err = d.db.ExecContext(ctx, `UPDATE organization_members SET role = ? WHERE org_id = ? AND user_id = ?`, string(role), orgID, userID)
+	return err
+}
+
+// ListMembers returns every member of orgID.
+func (d *DAO) ListMembers(ctx context.Context, orgID string) ([]*Member, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT org_id, user_id, role, joined_at FROM organization_members WHERE org_id = ? ORDER BY joined_at`, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Member
+	for rows.Next() {
+		m, err := scanMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// GetMembership returns userID's membership in orgID, or ErrNotMember
+// if they don't belong to it.
+func (d *DAO) GetMembership(ctx context.Context, orgID, userID string) (*Member, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT org_id, user_id, role, joined_at FROM organization_members WHERE org_id = ? AND user_id = ?`, orgID, userID)
+	m, err := scanMember(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotMember
+	}
+	return m, err
+}
+
+func (d *DAO) isSoleOwner(ctx context.Context, orgID, userID string) (bool, error) {
+	var count int
+	err := d.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM organization_members WHERE org_id = ? AND role = ? AND user_id != ?`,
+		orgID, string(RoleOwner), userID).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count == 0, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanOrganization(row rowScanner) (*Organization, error) {
+	org := &Organization{}
+	if err := row.Scan(&org.ID, &org.Name, &org.CreatedAt, &org.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return org, nil
+}
+
+func scanMember(row rowScanner) (*Member, error) {
+	m := &Member{}
+	var role string
+	if err := row.Scan(&m.OrgID, &m.UserID, &role, &m.JoinedAt); err != nil {
+		return nil, err
+	}
+	m.Role = Role(role)
+	return m, nil
+}
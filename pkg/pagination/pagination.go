@@ -0,0 +1,104 @@
+// Package pagination centralizes this codebase's two list-pagination
+// idioms so list endpoints handle page size, sorting, and totals the
+// same way: offset/limit paging for admin-facing resource lists (with a
+// total row count, so a caller can render "page N of M"), and cursor
+// paging for high-volume, append-only tables like pkg/apilog and
+// pkg/audit (where a total count would mean scanning the whole table).
+package pagination
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strconv"
+)
+
+// DefaultLimit is used when a caller doesn't specify a page size.
+const DefaultLimit = 50
+
+// MaxLimit caps how many rows a single page can return, regardless of
+// what the caller asks for.
+const MaxLimit = 500
+
+// SortWhitelist maps a caller-facing sort key (as accepted in a "sort"
+// query parameter) to the SQL column it's allowed to sort by. Only keys
+// present in the map are honored, so a query parameter never reaches an
+// ORDER BY clause unescaped.
+type SortWhitelist map[string]string
+
+// Params is a parsed, bounds-checked offset/limit page request,
+// optionally combined with a sort column resolved through a
+// SortWhitelist.
+type Params struct {
+	Limit    int
+	Offset   int
+	SortBy   string // SQL column, already resolved through a SortWhitelist; "" if none applies
+	SortDesc bool
+}
+
+// Parse reads limit, offset, sort and order from q. limit is clamped to
+// [1, MaxLimit] and defaults to DefaultLimit. sort is resolved through
+// whitelist, falling back to defaultSort (which must itself be a key of
+// whitelist) when the caller omits it or names an unknown column; pass
+// a nil whitelist for endpoints with no caller-selectable sort. order
+// defaults to descending; pass order=asc for ascending.
+func Parse(q url.Values, whitelist SortWhitelist, defaultSort string) Params {
+	limit := DefaultLimit
+	if v := q.Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	offset := 0
+	if v := q.Get("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			offset = n
+		}
+	}
+
+	var sortBy string
+	if whitelist != nil {
+		sortBy = whitelist[q.Get("sort")]
+		if sortBy == "" {
+			sortBy = whitelist[defaultSort]
+		}
+	}
+
+	return Params{
+		Limit:    limit,
+		Offset:   offset,
+		SortBy:   sortBy,
+		SortDesc: q.Get("order") != "asc",
+	}
+}
+
+// Page wraps a single page of offset/limit results together with the
+// total number of rows matching the query, across every page.
+type Page[T any] struct {
+	Items  []T `json:"items"`
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// EncodeCursor and DecodeCursor implement the opaque last-row-ID cursor
+// shared by this codebase's cursor-paginated Search methods (see
+// pkg/apilog and pkg/audit): a page fetches limit+1 rows ordered by id
+// DESC, and if that extra row exists, its id becomes the next cursor.
+
+// EncodeCursor opaquely encodes a row ID as a page cursor.
+func EncodeCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+// DecodeCursor reverses EncodeCursor.
+func DecodeCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(raw), 10, 64)
+}
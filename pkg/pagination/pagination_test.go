@@ -0,0 +1,85 @@
+package pagination
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestParseDefaults(t *testing.T) {
+	p := Parse(url.Values{}, nil, "")
+
+	if p.Limit != DefaultLimit {
+		t.Errorf("Limit = %d, want %d", p.Limit, DefaultLimit)
+	}
+	if p.Offset != 0 {
+		t.Errorf("Offset = %d, want 0", p.Offset)
+	}
+	if !p.SortDesc {
+		t.Error("SortDesc = false, want true by default")
+	}
+}
+
+func TestParseClampsLimitToMax(t *testing.T) {
+	q := url.Values{"limit": {"999999"}}
+	p := Parse(q, nil, "")
+
+	if p.Limit != MaxLimit {
+		t.Errorf("Limit = %d, want %d", p.Limit, MaxLimit)
+	}
+}
+
+func TestParseIgnoresInvalidLimitAndOffset(t *testing.T) {
+	q := url.Values{"limit": {"not-a-number"}, "offset": {"-5"}}
+	p := Parse(q, nil, "")
+
+	if p.Limit != DefaultLimit {
+		t.Errorf("Limit = %d, want %d", p.Limit, DefaultLimit)
+	}
+	if p.Offset != 0 {
+		t.Errorf("Offset = %d, want 0", p.Offset)
+	}
+}
+
+func TestParseResolvesSortThroughWhitelist(t *testing.T) {
+	whitelist := SortWhitelist{"email": "email", "created_at": "created_at"}
+
+	p := Parse(url.Values{"sort": {"email"}}, whitelist, "created_at")
+	if p.SortBy != "email" {
+		t.Errorf("SortBy = %q, want %q", p.SortBy, "email")
+	}
+
+	p = Parse(url.Values{"sort": {"'; DROP TABLE users; --"}}, whitelist, "created_at")
+	if p.SortBy != "created_at" {
+		t.Errorf("SortBy = %q, want default %q for unrecognized sort key", p.SortBy, "created_at")
+	}
+}
+
+func TestParseOrderDirection(t *testing.T) {
+	p := Parse(url.Values{"order": {"asc"}}, nil, "")
+	if p.SortDesc {
+		t.Error("SortDesc = true, want false for order=asc")
+	}
+
+	p = Parse(url.Values{"order": {"desc"}}, nil, "")
+	if !p.SortDesc {
+		t.Error("SortDesc = false, want true for order=desc")
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := EncodeCursor(42)
+
+	id, err := DecodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor: %v", err)
+	}
+	if id != 42 {
+		t.Errorf("id = %d, want 42", id)
+	}
+}
+
+func TestDecodeCursorRejectsGarbage(t *testing.T) {
+	if _, err := DecodeCursor("not-a-valid-cursor!!"); err == nil {
+		t.Error("expected an error decoding a malformed cursor, got nil")
+	}
+}
@@ -0,0 +1,92 @@
+// Package pool lets operators group proxies into named pools with their
+// own capacity and quality policy ("EU-premium: up to 200 proxies,
+// score >= 0.8") and a scheduling priority, so proxy-pool's capacity
+// manager and request-time selection can both honor pool membership
+// instead of treating every proxy as part of one undifferentiated set.
+package pool
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// Pool is a named group of proxies with its own capacity and quality
+// policy. MaxProxies <= 0 means unlimited; MinQualityScore <= 0 means no
+// floor. Among pools competing for the same scheduling slot, the one
+// with the highest Priority wins, mirroring routing.Rule's convention.
+type Pool struct {
+	ID              string
+	Name            string
+	MaxProxies      int
+	MinQualityScore float64
+	Priority        int
+}
+
+// DAO manages proxy pools in MySQL.
+type DAO struct {
+	db *sql.DB
+}
+
+// NewDAO wraps an existing *sql.DB handle.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db}
+}
+
+// List returns every configured pool, highest priority first.
+func (d *DAO) List(ctx context.Context) ([]*Pool, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT id, name, max_proxies, min_quality_score, priority FROM proxy_pools ORDER BY priority DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Pool
+	for rows.Next() {
+		p, err := scanPool(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// Get loads a single pool by ID.
+func (d *DAO) Get(ctx context.Context, id string) (*Pool, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT id, name, max_proxies, min_quality_score, priority FROM proxy_pools WHERE id = ?`, id)
+	return scanPool(row)
+}
+
+// Insert creates a new pool, assigning it an ID.
+func (d *DAO) Insert(ctx context.Context, p *Pool) error {
+	p.ID = uuid.NewString()
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO proxy_pools (id, name, max_proxies, min_quality_score, priority)
+		 VALUES (?, ?, ?, ?, ?)`,
+		p.ID, p.Name, p.MaxProxies, p.MinQualityScore, p.Priority)
+	return err
+}
+
+// Delete removes a pool. It is a no-op if the pool doesn't exist.
+// Member proxies are not deleted; callers should unassign them first if
+// that's the desired behavior.
+func (d *DAO) Delete(ctx context.Context, id string) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM proxy_pools WHERE id = ?`, id)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPool(row rowScanner) (*Pool, error) {
+	p := &Pool{}
+	if err := row.Scan(&p.ID, &p.Name, &p.MaxProxies, &p.MinQualityScore, &p.Priority); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,147 @@
+// Package proxy defines the core proxy data model shared across services.
+package proxy
+
+import (
+	"strconv"
+	"time"
+)
+
+// Protocol identifies the wire protocol a proxy speaks.
+type Protocol string
+
+const (
+	ProtocolHTTP   Protocol = "http"
+	ProtocolHTTPS  Protocol = "https"
+	ProtocolSOCKS4 Protocol = "socks4"
+	ProtocolSOCKS5 Protocol = "socks5"
+)
+
+// Status is the lifecycle state of a proxy within the pool.
+type Status string
+
+const (
+	StatusPending Status = "pending" // newly imported, not yet validated
+	StatusHealthy Status = "healthy" // passing checks, eligible for the hot set
+	StatusDead    Status = "dead"    // failed checks, evicted from the hot set
+	StatusBanned  Status = "banned"  // manually or automatically banned
+	StatusDeleted Status = "deleted" // soft-deleted by an operator, awaiting the retention purge job
+)
+
+// Stage is a proxy's position in the probation lifecycle: how much its
+// track record has been trusted with paying traffic, independent of
+// Status (which governs hot-set eligibility on its own, harsher
+// schedule). A newly discovered proxy starts on probation and only
+// samples a trickle of traffic until it earns its way to active; it can
+// later be knocked down to degraded or retired without ever leaving
+// Status healthy.
+type Stage string
+
+const (
+	// StageProbation is a newly discovered proxy that hasn't yet earned
+	// full traffic; see services/proxy-pool/internal/healthcheck's
+	// probation sampling and graduation logic.
+	StageProbation Stage = "probation"
+	// StageActive is a proven proxy, eligible for the hot set and full
+	// paying traffic.
+	StageActive Stage = "active"
+	// StageDegraded is an active proxy that started failing checks
+	// again; it keeps serving traffic while under closer watch, rather
+	// than dropping out outright.
+	StageDegraded Stage = "degraded"
+	// StageRetired is a proxy whose probation or degraded run ended
+	// badly enough that it's permanently excluded from selection,
+	// independent of whatever Status later does with it.
+	StageRetired Stage = "retired"
+)
+
+// Source identifies where a proxy was discovered.
+type Source string
+
+const (
+	SourceFree     Source = "free"     // scraped from free public proxy lists
+	SourceProvider Source = "provider" // purchased from a paid upstream provider
+	SourceManual   Source = "manual"   // added by an operator via the admin API
+)
+
+// AnonymityLevel is how much of the original client a proxy leaks to the
+// upstream it forwards to, as determined by a judge-endpoint probe.
+type AnonymityLevel string
+
+const (
+	AnonymityUnknown     AnonymityLevel = ""            // not yet probed
+	AnonymityTransparent AnonymityLevel = "transparent" // forwards the real client IP
+	AnonymityAnonymous   AnonymityLevel = "anonymous"   // hides the client IP but identifies itself as a proxy
+	AnonymityElite       AnonymityLevel = "elite"       // hides the client IP and sends no proxy-identifying headers
+)
+
+// Proxy is a single proxy endpoint tracked by the platform.
+type Proxy struct {
+	ID       string
+	Host     string
+	Port     int
+	Protocol Protocol
+	Status   Status
+	Score    float64
+	Source   Source
+
+	AnonymityLevel AnonymityLevel
+	Country        string // ISO 3166-1 alpha-2, if known
+	City           string // free-text city name, if known
+	ASN            int    // origin autonomous system number, 0 if unknown
+	Provider       string // upstream provider name for Source == SourceProvider
+
+	ConsecutiveFailures int
+	LastCheckedAt       time.Time
+	LastSuccessAt       time.Time
+	LatencyMS           int
+	SuccessRate         float64
+
+	// P50LatencyMS and P95LatencyMS are percentile latencies estimated
+	// from this proxy's recent health-check history, backed by a Redis
+	// histogram (pkg/redis.LatencyHistogram) rather than MySQL: they
+	// capture tail latency that LatencyMS's single most recent sample
+	// can't. Both are 0 until the histogram has observations, and are
+	// only populated by code paths that deliberately consult Redis for
+	// them (the health-check worker and the admin proxy detail
+	// endpoint); a Proxy loaded from ProxyDAO alone leaves them zero.
+	P50LatencyMS int
+	P95LatencyMS int
+
+	// ReputationScore is the fraction of consulted DNSBLs/reputation
+	// APIs that listed this proxy's IP, in [0, 1]; 0 means clean.
+	ReputationScore float64
+	// Blacklisted is true once ReputationScore crosses the reputation
+	// checker's listing threshold; pool policies use it to exclude the
+	// proxy from premium-plan selection.
+	Blacklisted bool
+
+	// PoolID is the pkg/pool.Pool this proxy is assigned to, or "" if
+	// unassigned.
+	PoolID string
+
+	// Stage is this proxy's probation-lifecycle position; see Stage's
+	// doc comment. StageChangedAt is when it last moved, and
+	// ProbationChecks counts the consecutive successful checks it has
+	// accumulated since entering StageProbation, reset on any failure.
+	Stage           Stage
+	StageChangedAt  time.Time
+	ProbationChecks int
+
+	// ExitIP is the external IP this proxy was last observed egressing
+	// traffic from, as seen by a judge-endpoint probe (see
+	// services/proxy-pool/validator.AnonymityDetector.ExitIP); empty
+	// until the first probe completes. Resellers sometimes front several
+	// advertised host:port endpoints, even across different Provider
+	// names, with the same exit, which ExitIP is what actually exposes.
+	ExitIP string
+	// DuplicateOf is the ID of the canonical proxy this one was flagged
+	// as an exit-IP duplicate of, or "" if this proxy is unique or is
+	// itself the canonical one; see
+	// services/proxy-pool/internal/dedup.
+	DuplicateOf string
+}
+
+// Addr returns the host:port dial address for the proxy.
+func (p *Proxy) Addr() string {
+	return p.Host + ":" + strconv.Itoa(p.Port)
+}
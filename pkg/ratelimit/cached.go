@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CacheConfig controls how a cached Limiter trades accuracy for
+// latency: it only consults the underlying Limiter once every
+// SyncEvery requests or SyncInterval, whichever comes first, serving
+// every other Allow call from its last synced verdict instead of
+// round-tripping to Redis. The zero value disables caching (every call
+// syncs).
+type CacheConfig struct {
+	SyncEvery    int
+	SyncInterval time.Duration
+}
+
+// cacheEntry is a key's approximate local state between syncs.
+type cacheEntry struct {
+	count    int
+	syncedAt time.Time
+	allowed  bool
+}
+
+// cached wraps a Limiter with an in-process approximation of its
+// verdict, so most Allow calls under high QPS are served from memory
+// instead of round-tripping to Redis. Its verdicts are approximate: a
+// key can run over or under its true limit by up to SyncEvery requests
+// or SyncInterval, whichever elapses first, before the next sync
+// reconciles it against the authoritative count.
+type cached struct {
+	underlying Limiter
+	cfg        CacheConfig
+
+	mu    sync.Mutex
+	state map[string]*cacheEntry
+}
+
+// NewCached wraps underlying with an in-process cache per cfg.
+func NewCached(underlying Limiter, cfg CacheConfig) Limiter {
+	return &cached{underlying: underlying, cfg: cfg, state: make(map[string]*cacheEntry)}
+}
+
+// Allow reports whether key is within its limit, syncing against the
+// underlying Limiter when key's local state is stale and otherwise
+// returning its last synced verdict.
+func (c *cached) Allow(ctx context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	entry, ok := c.state[key]
+	if !ok {
+		entry = &cacheEntry{}
+		c.state[key] = entry
+	}
+	entry.count++
+	stale := entry.syncedAt.IsZero() ||
+		(c.cfg.SyncEvery > 0 && entry.count >= c.cfg.SyncEvery) ||
+		(c.cfg.SyncInterval > 0 && time.Since(entry.syncedAt) >= c.cfg.SyncInterval)
+	if !stale {
+		allowed := entry.allowed
+		c.mu.Unlock()
+		return allowed, nil
+	}
+	c.mu.Unlock()
+
+	allowed, err := c.underlying.Allow(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	entry.count = 0
+	entry.syncedAt = time.Now()
+	entry.allowed = allowed
+	c.mu.Unlock()
+	return allowed, nil
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingLimiter is a test double that records how many times Allow
+// was called and always returns verdict.
+type countingLimiter struct {
+	calls   int
+	verdict bool
+	err     error
+}
+
+func (c *countingLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	c.calls++
+	return c.verdict, c.err
+}
+
+func TestCachedSyncsEveryNRequests(t *testing.T) {
+	underlying := &countingLimiter{verdict: true}
+	limiter := NewCached(underlying, CacheConfig{SyncEvery: 3})
+
+	for i := 0; i < 9; i++ {
+		if _, err := limiter.Allow(context.Background(), "k1"); err != nil {
+			t.Fatalf("Allow() returned error: %v", err)
+		}
+	}
+
+	if underlying.calls != 3 {
+		t.Errorf("underlying.calls = %d, want 3 (one per 3 requests)", underlying.calls)
+	}
+}
+
+func TestCachedSyncsOnStaleInterval(t *testing.T) {
+	underlying := &countingLimiter{verdict: true}
+	limiter := NewCached(underlying, CacheConfig{SyncInterval: time.Millisecond})
+
+	if _, err := limiter.Allow(context.Background(), "k1"); err != nil {
+		t.Fatalf("Allow() returned error: %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if _, err := limiter.Allow(context.Background(), "k1"); err != nil {
+		t.Fatalf("Allow() returned error: %v", err)
+	}
+
+	if underlying.calls != 2 {
+		t.Errorf("underlying.calls = %d, want 2 (stale after SyncInterval)", underlying.calls)
+	}
+}
+
+func TestCachedScopedByKey(t *testing.T) {
+	underlying := &countingLimiter{verdict: true}
+	limiter := NewCached(underlying, CacheConfig{SyncEvery: 10})
+
+	limiter.Allow(context.Background(), "k1")
+	limiter.Allow(context.Background(), "k2")
+
+	if underlying.calls != 2 {
+		t.Errorf("underlying.calls = %d, want 2 (each key syncs independently)", underlying.calls)
+	}
+}
+
+func TestCachedPropagatesUnderlyingError(t *testing.T) {
+	wantErr := errors.New("redis down")
+	underlying := &countingLimiter{err: wantErr}
+	limiter := NewCached(underlying, CacheConfig{})
+
+	if _, err := limiter.Allow(context.Background(), "k1"); !errors.Is(err, wantErr) {
+		t.Errorf("Allow() error = %v, want %v", err, wantErr)
+	}
+}
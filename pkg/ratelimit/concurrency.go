@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// MaxConcurrentConnections is how many simultaneous tunnels a plan may
+// hold open at once, independent of its request-rate limit. Plans not
+// listed here fall back to PlanFree's limit, mirroring
+// planlimits.MaxProxiesPerRequest.
+var MaxConcurrentConnections = map[user.Plan]int{
+	user.PlanFree:       10,
+	user.PlanPro:        50,
+	user.PlanEnterprise: 500,
+}
+
+// MaxConcurrentFor returns the concurrent-connection limit for plan.
+func MaxConcurrentFor(plan user.Plan) int {
+	limit, ok := MaxConcurrentConnections[plan]
+	if !ok {
+		limit = MaxConcurrentConnections[user.PlanFree]
+	}
+	return limit
+}
+
+func concurrencyKey(key string) string { return "concurrency:" + key }
+
+// concurrencyScript atomically increments key's active count and
+// reports whether it's within limit; if not, it decrements back out
+// immediately so a rejected Acquire doesn't leak a slot.
+var concurrencyScript = goredis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count > tonumber(ARGV[1]) then
+    redis.call("DECR", KEYS[1])
+    return 0
+end
+return 1
+`)
+
+// ConcurrencyLimiter caps how many connections a key (a user or API key
+// ID) may hold open at once. Unlike the request-rate Limiter
+// implementations, a rejected Acquire has nothing to undo, but an
+// accepted one MUST be paired with a Release when the connection
+// closes or the slot leaks until the count is reset (e.g. by a TTL on
+// the underlying Redis key, which this type doesn't set, so callers
+// are responsible for always releasing what they acquire).
+type ConcurrencyLimiter struct {
+	client goredis.UniversalClient
+}
+
+// NewConcurrencyLimiter wraps an existing Redis client.
+func NewConcurrencyLimiter(client goredis.UniversalClient) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{client: client}
+}
+
+// Acquire reports whether key is within limit concurrent connections,
+// atomically reserving a slot if so. A non-positive limit disables
+// enforcement. Every successful Acquire must be matched with a
+// Release, typically via defer, when the connection closes.
+func (c *ConcurrencyLimiter) Acquire(ctx context.Context, key string, limit int) (bool, error) {
+	if limit <= 0 {
+		return true, nil
+	}
+	result, err := concurrencyScript.Run(ctx, c.client, []string{concurrencyKey(key)}, limit).Int()
+	if err != nil {
+		return false, err
+	}
+	return result == 1, nil
+}
+
+// Release frees the slot a successful Acquire reserved for key.
+func (c *ConcurrencyLimiter) Release(ctx context.Context, key string) error {
+	return c.client.Decr(ctx, concurrencyKey(key)).Err()
+}
+
+// Active returns how many connections key currently holds open.
+func (c *ConcurrencyLimiter) Active(ctx context.Context, key string) (int64, error) {
+	count, err := c.client.Get(ctx, concurrencyKey(key)).Int64()
+	if err == goredis.Nil {
+		return 0, nil
+	}
+	return count, err
+}
@@ -0,0 +1,40 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// fixedWindow counts requests in a single counter per (key, window
+// bucket), reset by TTL.
+type fixedWindow struct {
+	client goredis.UniversalClient
+	cfg    Config
+}
+
+func newFixedWindow(client goredis.UniversalClient, cfg Config) *fixedWindow {
+	return &fixedWindow{client: client, cfg: cfg}
+}
+
+// fixedWindowKey buckets at into a window-sized slot so every request
+// in the same slot shares a counter.
+func fixedWindowKey(key string, window time.Duration, at time.Time) string {
+	bucket := at.UnixNano() / window.Nanoseconds()
+	return "ratelimit:fixed:" + key + ":" + strconv.FormatInt(bucket, 10)
+}
+
+func (l *fixedWindow) Allow(ctx context.Context, key string) (bool, error) {
+	redisKey := fixedWindowKey(key, l.cfg.Window, time.Now())
+
+	pipe := l.client.TxPipeline()
+	incr := pipe.Incr(ctx, redisKey)
+	pipe.Expire(ctx, redisKey, l.cfg.Window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+
+	return incr.Val() <= int64(l.cfg.Limit), nil
+}
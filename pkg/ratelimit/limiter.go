@@ -0,0 +1,66 @@
+// Package ratelimit implements per-key request-rate limiting backed by
+// Redis, with a choice of algorithm behind a single Limiter interface
+// so a caller (currently the gateway, limiting per API key) can pick
+// the accuracy/memory tradeoff that fits its limit size:
+//
+//   - Sliding window is the most accurate — no burst across a window
+//     boundary — but costs one ZSET member per request allowed within
+//     the window, so a large Limit means a large ZSET.
+//   - Fixed window is O(1) memory (a single counter per key) but can
+//     allow up to 2x Limit requests across a window boundary (a burst
+//     at the end of one window followed by a burst at the start of the
+//     next).
+//   - Token bucket is also O(1) memory and smooths bursts better than
+//     fixed window, refilling continuously instead of resetting at a
+//     boundary, at the cost of a Lua script for atomicity.
+//
+// See ratelimit_bench_test.go for benchmarks of each algorithm's pure
+// key/scoring overhead.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Algorithm selects which rate-limiting strategy a Limiter uses.
+type Algorithm string
+
+const (
+	AlgorithmTokenBucket   Algorithm = "token_bucket"
+	AlgorithmFixedWindow   Algorithm = "fixed_window"
+	AlgorithmSlidingWindow Algorithm = "sliding_window"
+)
+
+// Config configures a Limiter: at most Limit requests per Window,
+// enforced using Algorithm.
+type Config struct {
+	Algorithm Algorithm
+	Limit     int
+	Window    time.Duration
+}
+
+// Limiter decides whether a request identified by key (e.g. an API key
+// ID) is within its configured rate limit.
+type Limiter interface {
+	// Allow reports whether the request identified by key is within
+	// the configured limit, consuming one unit of quota if so.
+	Allow(ctx context.Context, key string) (bool, error)
+}
+
+// New builds a Limiter for cfg's algorithm.
+func New(client goredis.UniversalClient, cfg Config) (Limiter, error) {
+	switch cfg.Algorithm {
+	case AlgorithmTokenBucket:
+		return newTokenBucket(client, cfg), nil
+	case AlgorithmFixedWindow:
+		return newFixedWindow(client, cfg), nil
+	case AlgorithmSlidingWindow:
+		return newSlidingWindow(client, cfg), nil
+	default:
+		return nil, fmt.Errorf("ratelimit: unknown algorithm %q", cfg.Algorithm)
+	}
+}
@@ -0,0 +1,196 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// ErrPolicyNotFound is returned by PolicyDAO.Get when plan has no
+// configured policy.
+var ErrPolicyNotFound = errors.New("ratelimit: no policy for plan")
+
+// PlanPolicy is the rate limit configured for one subscription plan.
+type PlanPolicy struct {
+	Plan      user.Plan
+	Algorithm Algorithm
+	Limit     int
+	Window    time.Duration
+}
+
+// PolicyDAO manages per-plan rate limit policies in MySQL, letting
+// operators retune a plan's limit without a gateway rebuild.
+type PolicyDAO struct {
+	db *sql.DB
+}
+
+// NewPolicyDAO wraps an existing *sql.DB handle.
+func NewPolicyDAO(db *sql.DB) *PolicyDAO {
+	return &PolicyDAO{db: db}
+}
+
+// List returns every configured plan policy.
+func (d *PolicyDAO) List(ctx context.Context) ([]*PlanPolicy, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT plan, algorithm, limit_per_window, window_seconds FROM plan_rate_limits`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*PlanPolicy
+	for rows.Next() {
+		p, err := scanPlanPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// Get returns plan's configured policy, or ErrPolicyNotFound if it has
+// none.
+func (d *PolicyDAO) Get(ctx context.Context, plan user.Plan) (*PlanPolicy, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT plan, algorithm, limit_per_window, window_seconds FROM plan_rate_limits WHERE plan = ?`,
+		string(plan))
+	p, err := scanPlanPolicy(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPolicyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Upsert creates or replaces plan's policy.
+func (d *PolicyDAO) Upsert(ctx context.Context, p *PlanPolicy) error {
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO plan_rate_limits (plan, algorithm, limit_per_window, window_seconds)
+		 VALUES (?, ?, ?, ?)
+		 ON DUPLICATE KEY UPDATE algorithm = VALUES(algorithm),
+		   limit_per_window = VALUES(limit_per_window), window_seconds = VALUES(window_seconds)`,
+		string(p.Plan), string(p.Algorithm), p.Limit, int(p.Window/time.Second))
+	return err
+}
+
+// Delete removes plan's configured policy. It is a no-op if none
+// exists.
+func (d *PolicyDAO) Delete(ctx context.Context, plan user.Plan) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM plan_rate_limits WHERE plan = ?`, string(plan))
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPlanPolicy(row rowScanner) (*PlanPolicy, error) {
+	p := &PlanPolicy{}
+	var plan, algorithm string
+	var windowSeconds int
+	if err := row.Scan(&plan, &algorithm, &p.Limit, &windowSeconds); err != nil {
+		return nil, err
+	}
+	p.Plan = user.Plan(plan)
+	p.Algorithm = Algorithm(algorithm)
+	p.Window = time.Duration(windowSeconds) * time.Second
+	return p, nil
+}
+
+// PlanLimiter enforces a rate limit that varies by the caller's
+// subscription plan.
+type PlanLimiter interface {
+	Allow(ctx context.Context, plan user.Plan, key string) (bool, error)
+}
+
+// PolicyResolver is a PlanLimiter that resolves the effective Config
+// for a caller's plan from a PolicyDAO and enforces it, so callers such
+// as the gateway engine don't need per-route or per-plan constants of
+// their own. A plan without a configured policy falls back to Default,
+// mirroring how planlimits.MaxProxiesPerRequest falls back to
+// PlanFree's limit. Default.Limit <= 0 disables the fallback (plans
+// without a DB policy are unlimited). If Cache is non-zero, each plan's
+// Limiter is wrapped with NewCached so most requests are served from an
+// in-process approximation instead of round-tripping to Redis.
+type PolicyResolver struct {
+	client  goredis.UniversalClient
+	dao     *PolicyDAO
+	Default Config
+	Cache   CacheConfig
+
+	mu       sync.Mutex
+	limiters map[user.Plan]resolvedLimiter
+}
+
+// resolvedLimiter is a plan's last-built Limiter, kept alongside the
+// Config it was built from so limiterFor can tell whether a DB policy
+// change requires rebuilding it (and, for a cached Limiter, discarding
+// its accumulated local state).
+type resolvedLimiter struct {
+	cfg     Config
+	limiter Limiter
+}
+
+// NewPolicyResolver builds a PolicyResolver backed by dao, falling back
+// to fallback for plans with no configured policy.
+func NewPolicyResolver(client goredis.UniversalClient, dao *PolicyDAO, fallback Config) *PolicyResolver {
+	return &PolicyResolver{client: client, dao: dao, Default: fallback}
+}
+
+// Allow reports whether a request identified by key, made under plan,
+// is within that plan's configured rate limit.
+func (r *PolicyResolver) Allow(ctx context.Context, plan user.Plan, key string) (bool, error) {
+	cfg := r.Default
+	policy, err := r.dao.Get(ctx, plan)
+	if err != nil && !errors.Is(err, ErrPolicyNotFound) {
+		return false, err
+	}
+	if policy != nil {
+		cfg = Config{Algorithm: policy.Algorithm, Limit: policy.Limit, Window: policy.Window}
+	}
+	if cfg.Limit <= 0 {
+		return true, nil
+	}
+
+	limiter, err := r.limiterFor(plan, cfg)
+	if err != nil {
+		return false, err
+	}
+	return limiter.Allow(ctx, key)
+}
+
+// limiterFor returns plan's cached Limiter if it was already built for
+// cfg, so a cached Limiter's local sync state survives across calls;
+// otherwise it builds a new one (re-wrapping with NewCached if Cache is
+// configured) and caches it under cfg.
+func (r *PolicyResolver) limiterFor(plan user.Plan, cfg Config) (Limiter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.limiters[plan]; ok && existing.cfg == cfg {
+		return existing.limiter, nil
+	}
+
+	limiter, err := New(r.client, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if r.Cache != (CacheConfig{}) {
+		limiter = NewCached(limiter, r.Cache)
+	}
+
+	if r.limiters == nil {
+		r.limiters = make(map[user.Plan]resolvedLimiter)
+	}
+	r.limiters[plan] = resolvedLimiter{cfg: cfg, limiter: limiter}
+	return limiter, nil
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewUnknownAlgorithm(t *testing.T) {
+	if _, err := New(nil, Config{Algorithm: "bogus", Limit: 10, Window: time.Minute}); err == nil {
+		t.Error("New() with an unknown algorithm should return an error")
+	}
+}
+
+func TestFixedWindowKeyBucketsByWindow(t *testing.T) {
+	window := time.Minute
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	same := fixedWindowKey("k1", window, base.Add(30*time.Second))
+	if got := fixedWindowKey("k1", window, base); got != same {
+		t.Errorf("fixedWindowKey should be stable within a window: %q != %q", got, same)
+	}
+
+	next := fixedWindowKey("k1", window, base.Add(window))
+	if next == same {
+		t.Errorf("fixedWindowKey should change across a window boundary, got %q for both", next)
+	}
+}
+
+func TestFixedWindowKeyScopedByKey(t *testing.T) {
+	at := time.Now()
+	if fixedWindowKey("a", time.Minute, at) == fixedWindowKey("b", time.Minute, at) {
+		t.Error("fixedWindowKey should differ between distinct rate-limit keys")
+	}
+}
+
+func TestSlidingWindowKeyScopedByKey(t *testing.T) {
+	if slidingWindowKey("a") == slidingWindowKey("b") {
+		t.Error("slidingWindowKey should differ between distinct rate-limit keys")
+	}
+}
+
+func TestTokenBucketKeyScopedByKey(t *testing.T) {
+	if tokenBucketKey("a") == tokenBucketKey("b") {
+		t.Error("tokenBucketKey should differ between distinct rate-limit keys")
+	}
+}
+
+// BenchmarkFixedWindowKey and the benchmarks below measure each
+// algorithm's pure per-request key-construction overhead — the part
+// that doesn't depend on a live Redis instance. Sliding window's extra
+// uuid generation per allowed request (see slidingWindow.Allow) is the
+// main CPU cost the ZSET approach pays for its memory cost; fixed
+// window and token bucket only format a string.
+func BenchmarkFixedWindowKey(b *testing.B) {
+	at := time.Now()
+	for i := 0; i < b.N; i++ {
+		fixedWindowKey("benchmark-key", time.Minute, at)
+	}
+}
+
+func BenchmarkSlidingWindowKey(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		slidingWindowKey("benchmark-key")
+	}
+}
+
+func BenchmarkTokenBucketKey(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		tokenBucketKey("benchmark-key")
+	}
+}
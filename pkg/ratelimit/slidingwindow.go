@@ -0,0 +1,55 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// slidingWindow tracks each allowed request as a scored member of a
+// ZSET (score = its timestamp), trimming anything older than Window on
+// every check.
+type slidingWindow struct {
+	client goredis.UniversalClient
+	cfg    Config
+}
+
+func newSlidingWindow(client goredis.UniversalClient, cfg Config) *slidingWindow {
+	return &slidingWindow{client: client, cfg: cfg}
+}
+
+func slidingWindowKey(key string) string {
+	return "ratelimit:sliding:" + key
+}
+
+func (l *slidingWindow) Allow(ctx context.Context, key string) (bool, error) {
+	redisKey := slidingWindowKey(key)
+	now := time.Now()
+	cutoff := now.Add(-l.cfg.Window)
+
+	if err := l.client.ZRemRangeByScore(ctx, redisKey, "-inf", strconv.FormatInt(cutoff.UnixNano(), 10)).Err(); err != nil {
+		return false, err
+	}
+
+	count, err := l.client.ZCard(ctx, redisKey).Result()
+	if err != nil {
+		return false, err
+	}
+	if count >= int64(l.cfg.Limit) {
+		return false, nil
+	}
+
+	// member includes a uuid so two requests landing in the same
+	// nanosecond don't collide and silently overwrite one another's
+	// ZSET entry.
+	member := strconv.FormatInt(now.UnixNano(), 10) + ":" + uuid.NewString()
+
+	pipe := l.client.TxPipeline()
+	pipe.ZAdd(ctx, redisKey, goredis.Z{Score: float64(now.UnixNano()), Member: member})
+	pipe.Expire(ctx, redisKey, l.cfg.Window)
+	_, err = pipe.Exec(ctx)
+	return true, err
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript atomically refills a bucket based on elapsed time
+// since its last refill and consumes one token if available. KEYS[1]
+// is the bucket's hash key (fields "tokens" and "refilled_at");
+// ARGV[1] is the bucket capacity, ARGV[2] is the refill rate in
+// tokens/second, ARGV[3] is the current time in nanoseconds, and
+// ARGV[4] is the key's TTL in seconds, so an idle bucket doesn't linger
+// in Redis forever.
+var tokenBucketScript = goredis.NewScript(`
+local key = KEYS[1]
+local capacity = tonumber(ARGV[1])
+local refill_rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local tokens = tonumber(redis.call("HGET", key, "tokens"))
+local refilled_at = tonumber(redis.call("HGET", key, "refilled_at"))
+if tokens == nil then
+	tokens = capacity
+	refilled_at = now
+end
+
+local elapsed = math.max(0, now - refilled_at) / 1e9
+tokens = math.min(capacity, tokens + elapsed * refill_rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HSET", key, "tokens", tokens, "refilled_at", now)
+redis.call("EXPIRE", key, ttl)
+return allowed
+`)
+
+// tokenBucket refills at Limit/Window tokens per second and allows a
+// request when at least one token is available.
+type tokenBucket struct {
+	client goredis.UniversalClient
+	cfg    Config
+}
+
+func newTokenBucket(client goredis.UniversalClient, cfg Config) *tokenBucket {
+	return &tokenBucket{client: client, cfg: cfg}
+}
+
+func tokenBucketKey(key string) string {
+	return "ratelimit:bucket:" + key
+}
+
+func (l *tokenBucket) Allow(ctx context.Context, key string) (bool, error) {
+	refillRate := float64(l.cfg.Limit) / l.cfg.Window.Seconds()
+	ttlSeconds := int64(l.cfg.Window.Seconds() * 2)
+	if ttlSeconds < 1 {
+		ttlSeconds = 1
+	}
+
+	result, err := tokenBucketScript.Run(ctx, l.client, []string{tokenBucketKey(key)},
+		l.cfg.Limit, refillRate, time.Now().UnixNano(), ttlSeconds).Int()
+	if err != nil {
+		return false, err
+	}
+	return result == 1, nil
+}
@@ -0,0 +1,188 @@
+// Package reconcile keeps the Redis hot state in sync with MySQL, which
+// is the platform's system of record. Redis is treated as a derived
+// cache: whenever the two disagree, MySQL wins.
+package reconcile
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+)
+
+// AlertThreshold is the number of drifted records in a single pass above
+// which Reconciler logs a high-severity alert instead of a routine
+// metric line.
+const AlertThreshold = 50
+
+// DriftReport summarizes the differences found between Redis and MySQL
+// in a single reconciliation pass.
+type DriftReport struct {
+	// MissingFromRedis are proxies healthy in MySQL but absent from the
+	// Redis hot set.
+	MissingFromRedis []*proxy.Proxy
+	// StaleInRedis are proxy IDs present in Redis but not healthy (or not
+	// present at all) in MySQL.
+	StaleInRedis []string
+	// Mismatched are proxies present in both stores with different
+	// field values.
+	Mismatched []*proxy.Proxy
+}
+
+// Total returns the number of drifted records across all categories.
+func (r *DriftReport) Total() int {
+	return len(r.MissingFromRedis) + len(r.StaleInRedis) + len(r.Mismatched)
+}
+
+// Metrics tracks cumulative drift counters for observability.
+type Metrics struct {
+	Repaired int64
+	Passes   int64
+}
+
+func (m *Metrics) Snapshot() Metrics {
+	return Metrics{
+		Repaired: atomic.LoadInt64(&m.Repaired),
+		Passes:   atomic.LoadInt64(&m.Passes),
+	}
+}
+
+// Reconciler diffs and repairs drift between the Redis hot state and the
+// MySQL proxies table.
+type Reconciler struct {
+	hotState *redis.HotZSet
+	proxyDAO *dao.ProxyDAO
+	metrics  Metrics
+}
+
+// New creates a Reconciler over the given Redis hot state and MySQL DAO.
+func New(hotState *redis.HotZSet, proxyDAO *dao.ProxyDAO) *Reconciler {
+	return &Reconciler{hotState: hotState, proxyDAO: proxyDAO}
+}
+
+// Metrics returns the reconciler's cumulative drift metrics.
+func (r *Reconciler) Metrics() Metrics {
+	return r.metrics.Snapshot()
+}
+
+// Diff compares MySQL (truth) against Redis (cache) and reports drift
+// without repairing anything.
+func (r *Reconciler) Diff(ctx context.Context) (*DriftReport, error) {
+	healthy, err := r.proxyDAO.ListHealthy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	truth := make(map[string]*proxy.Proxy, len(healthy))
+	for _, p := range healthy {
+		truth[p.ID] = p
+	}
+
+	hotIDs, err := r.hotState.ListIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hot := make(map[string]bool, len(hotIDs))
+	for _, id := range hotIDs {
+		hot[id] = true
+	}
+
+	report := &DriftReport{}
+
+	for id, p := range truth {
+		if !hot[id] {
+			report.MissingFromRedis = append(report.MissingFromRedis, p)
+			continue
+		}
+		cached, err := r.hotState.GetProxy(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if cached.Status != p.Status || cached.Score != p.Score || cached.Host != p.Host || cached.Port != p.Port {
+			report.Mismatched = append(report.Mismatched, p)
+		}
+	}
+
+	for id := range hot {
+		if _, ok := truth[id]; !ok {
+			report.StaleInRedis = append(report.StaleInRedis, id)
+		}
+	}
+
+	return report, nil
+}
+
+// Repair applies MySQL-wins precedence: missing and mismatched proxies
+// are (re)written into Redis from MySQL, and stale Redis-only entries
+// are evicted.
+func (r *Reconciler) Repair(ctx context.Context, report *DriftReport) error {
+	for _, p := range report.MissingFromRedis {
+		if err := r.hotState.AddProxy(ctx, p); err != nil {
+			return err
+		}
+	}
+	for _, p := range report.Mismatched {
+		if err := r.hotState.AddProxy(ctx, p); err != nil {
+			return err
+		}
+	}
+	for _, id := range report.StaleInRedis {
+		if err := r.hotState.RemoveProxy(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	atomic.AddInt64(&r.metrics.Repaired, int64(report.Total()))
+	atomic.AddInt64(&r.metrics.Passes, 1)
+
+	if report.Total() >= AlertThreshold {
+		log.Printf("reconcile: ALERT high drift between redis and mysql: %d records repaired", report.Total())
+	} else if report.Total() > 0 {
+		log.Printf("reconcile: repaired %d drifted records (missing=%d stale=%d mismatched=%d)",
+			report.Total(), len(report.MissingFromRedis), len(report.StaleInRedis), len(report.Mismatched))
+	}
+
+	return nil
+}
+
+// RunOnce diffs and repairs in one pass, returning the drift found.
+func (r *Reconciler) RunOnce(ctx context.Context) (*DriftReport, error) {
+	report, err := r.Diff(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Repair(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// RebuildFromMySQL wipes the Redis hot state and repopulates it entirely
+// from MySQL. This is the admin "force full rebuild" command, used when
+// drift is too severe to trust incremental repair.
+func (r *Reconciler) RebuildFromMySQL(ctx context.Context) (int, error) {
+	existing, err := r.hotState.ListIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range existing {
+		if err := r.hotState.RemoveProxy(ctx, id); err != nil {
+			return 0, err
+		}
+	}
+
+	healthy, err := r.proxyDAO.ListHealthy(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range healthy {
+		if err := r.hotState.AddProxy(ctx, p); err != nil {
+			return 0, err
+		}
+	}
+
+	log.Printf("reconcile: full rebuild complete, %d proxies loaded from mysql", len(healthy))
+	return len(healthy), nil
+}
@@ -0,0 +1,90 @@
+// Package redis wraps the Redis client used to hold the platform's hot
+// state: the hot proxy set, bans and sessions. MySQL remains the system
+// of record; Redis is a cache/index optimized for fast lookups on the
+// request path.
+package redis
+
+import (
+	"strings"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/tracing"
+)
+
+// Config holds connection settings for the Redis client. By default it
+// describes a single node (Addr). Setting SentinelAddrs switches to a
+// Sentinel-monitored failover client addressed by MasterName; setting
+// ClusterAddrs (with more than one address) switches to a Redis
+// Cluster client. Addr, SentinelAddrs, and ClusterAddrs are mutually
+// exclusive; callers configure whichever one topology applies.
+type Config struct {
+	// Addr is a single node's host:port. Used when SentinelAddrs and
+	// ClusterAddrs are both empty.
+	Addr string
+
+	// SentinelAddrs is a seed list of Sentinel host:port addresses.
+	// When set, MasterName must also be set, and NewClient returns a
+	// failover client that tracks Sentinel for the current master.
+	SentinelAddrs []string
+	// MasterName is the name Sentinel was configured with for the
+	// monitored master. Required when SentinelAddrs is set.
+	MasterName string
+
+	// ClusterAddrs is a seed list of cluster node host:port addresses.
+	// When it has more than one entry, NewClient returns a cluster
+	// client that discovers the rest of the topology from the seeds.
+	ClusterAddrs []string
+
+	Password string
+	// DB selects a logical database. Ignored by cluster clients, which
+	// don't support SELECT.
+	DB int
+}
+
+// NewClient creates a go-redis client from cfg, picking a single-node,
+// Sentinel failover, or Cluster client based on which of Addr,
+// SentinelAddrs, or ClusterAddrs is set. The returned
+// goredis.UniversalClient is satisfied by all three concrete types, so
+// callers and middleware write against one interface regardless of
+// deployment topology. Every command issued through it is traced via
+// pkg/tracing's Hook, so callers don't need to install it themselves.
+func NewClient(cfg Config) goredis.UniversalClient {
+	opts := &goredis.UniversalOptions{
+		Password:   cfg.Password,
+		DB:         cfg.DB,
+		MasterName: cfg.MasterName,
+	}
+
+	switch {
+	case cfg.MasterName != "":
+		opts.Addrs = cfg.SentinelAddrs
+	case len(cfg.ClusterAddrs) > 1:
+		opts.Addrs = cfg.ClusterAddrs
+	default:
+		opts.Addrs = []string{cfg.Addr}
+	}
+
+	client := goredis.NewUniversalClient(opts)
+	client.AddHook(tracing.NewRedisHook())
+	return client
+}
+
+// SplitAddrs parses a comma-separated list of host:port addresses, as
+// used by the REDIS_SENTINEL_ADDRS and REDIS_CLUSTER_ADDRS environment
+// variables. An empty csv returns nil, so it composes directly with
+// Config's zero value for deployments that don't use Sentinel or
+// Cluster.
+func SplitAddrs(csv string) []string {
+	if strings.TrimSpace(csv) == "" {
+		return nil
+	}
+	parts := strings.Split(csv, ",")
+	addrs := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			addrs = append(addrs, p)
+		}
+	}
+	return addrs
+}
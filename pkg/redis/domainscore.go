@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"context"
+	"strings"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func domainScoreKey(domain string) string {
+	return "domainscore:" + strings.ToLower(domain)
+}
+
+// DomainScore tracks, per target domain, which proxies have actually
+// worked against it: a proxy can pass the general health-check sweep
+// and still be blocked by a specific site (e.g. on amazon.com's IP
+// blocklist), so this is consulted in addition to a proxy's general
+// score when a caller names a target domain.
+//
+// Each domain is a ZSET of proxy IDs scored by a running success minus
+// failure tally, so a handful of failures against an otherwise-good
+// proxy doesn't erase a long track record, and a proxy with no
+// recorded outcome for the domain simply isn't a member yet.
+type DomainScore struct {
+	client goredis.UniversalClient
+}
+
+// NewDomainScore wraps an existing Redis client.
+func NewDomainScore(client goredis.UniversalClient) *DomainScore {
+	return &DomainScore{client: client}
+}
+
+// RecordOutcome adjusts proxyID's track record against domain: a
+// success increments its score, a failure decrements it.
+func (d *DomainScore) RecordOutcome(ctx context.Context, domain, proxyID string, success bool) error {
+	delta := float64(1)
+	if !success {
+		delta = -1
+	}
+	return d.client.ZIncrBy(ctx, domainScoreKey(domain), delta, proxyID).Err()
+}
+
+// Best returns the proxy ID with the strongest recorded track record
+// against domain, or ErrNotFound if no proxy has a positive score for
+// it yet.
+func (d *DomainScore) Best(ctx context.Context, domain string) (string, error) {
+	results, err := d.client.ZRevRangeWithScores(ctx, domainScoreKey(domain), 0, 0).Result()
+	if err != nil {
+		return "", err
+	}
+	if len(results) == 0 || results[0].Score <= 0 {
+		return "", ErrNotFound
+	}
+	member, ok := results[0].Member.(string)
+	if !ok {
+		return "", ErrNotFound
+	}
+	return member, nil
+}
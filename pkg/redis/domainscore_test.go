@@ -0,0 +1,21 @@
+package redis
+
+import "testing"
+
+func TestDomainScoreKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain string
+		want   string
+	}{
+		{"lowercase domain", "amazon.com", "domainscore:amazon.com"},
+		{"mixed case is normalized", "Amazon.COM", "domainscore:amazon.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := domainScoreKey(tt.domain); got != tt.want {
+				t.Errorf("domainScoreKey(%q) = %q, want %q", tt.domain, got, tt.want)
+			}
+		})
+	}
+}
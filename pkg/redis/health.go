@@ -0,0 +1,34 @@
+package redis
+
+import (
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// ConnectionStats is a point-in-time read of a Redis client's
+// connection pool, exposed so operators can tell a healthy pool from
+// one that's thrashing (high TotalConns relative to IdleConns, a
+// climbing StaleConns count) without reaching for redis-cli. It works
+// the same way regardless of whether the client is a single node, a
+// Sentinel failover client, or a Cluster client, since all three
+// implement goredis.UniversalClient's PoolStats method.
+type ConnectionStats struct {
+	Hits       uint32 `json:"hits"`
+	Misses     uint32 `json:"misses"`
+	Timeouts   uint32 `json:"timeouts"`
+	TotalConns uint32 `json:"total_conns"`
+	IdleConns  uint32 `json:"idle_conns"`
+	StaleConns uint32 `json:"stale_conns"`
+}
+
+// ConnStats reads client's current connection pool statistics.
+func ConnStats(client goredis.UniversalClient) ConnectionStats {
+	s := client.PoolStats()
+	return ConnectionStats{
+		Hits:       s.Hits,
+		Misses:     s.Misses,
+		Timeouts:   s.Timeouts,
+		TotalConns: s.TotalConns,
+		IdleConns:  s.IdleConns,
+		StaleConns: s.StaleConns,
+	}
+}
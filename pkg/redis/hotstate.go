@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// ErrNotFound is returned when a proxy is not present in the hot state.
+var ErrNotFound = errors.New("redis: proxy not found")
+
+const hotSetKey = "hotset"
+
+func proxyKey(id string) string { return "proxy:" + id }
+
+// HotState is the Redis-backed view of the hot proxy pool. It is the
+// read path for request-time proxy selection; MySQL remains the durable
+// system of record that HotState is reconciled against.
+type HotState struct {
+	client goredis.UniversalClient
+}
+
+// NewHotState wraps an existing Redis client.
+func NewHotState(client goredis.UniversalClient) *HotState {
+	return &HotState{client: client}
+}
+
+// AddProxy marks p as hot, storing its fields in a hash and its ID in the
+// hot set membership index.
+func (h *HotState) AddProxy(ctx context.Context, p *proxy.Proxy) error {
+	pipe := h.client.TxPipeline()
+	pipe.SAdd(ctx, hotSetKey, p.ID)
+	pipe.HSet(ctx, proxyKey(p.ID), map[string]interface{}{
+		"host":     p.Host,
+		"port":     strconv.Itoa(p.Port),
+		"protocol": string(p.Protocol),
+		"status":   string(p.Status),
+		"score":    strconv.FormatFloat(p.Score, 'f', -1, 64),
+		"country":  p.Country,
+	})
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RemoveProxy drops a proxy from the hot set entirely.
+func (h *HotState) RemoveProxy(ctx context.Context, id string) error {
+	pipe := h.client.TxPipeline()
+	pipe.SRem(ctx, hotSetKey, id)
+	pipe.Del(ctx, proxyKey(id))
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ListIDs returns the IDs of every proxy currently in the hot set.
+func (h *HotState) ListIDs(ctx context.Context) ([]string, error) {
+	return h.client.SMembers(ctx, hotSetKey).Result()
+}
+
+// Size returns how many proxies are currently in the hot pool.
+func (h *HotState) Size(ctx context.Context) (int64, error) {
+	return h.client.SCard(ctx, hotSetKey).Result()
+}
+
+// GetProxy loads a single proxy's hot-state fields.
+func (h *HotState) GetProxy(ctx context.Context, id string) (*proxy.Proxy, error) {
+	fields, err := h.client.HGetAll(ctx, proxyKey(id)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, ErrNotFound
+	}
+
+	port, _ := strconv.Atoi(fields["port"])
+	score, _ := strconv.ParseFloat(fields["score"], 64)
+
+	return &proxy.Proxy{
+		ID:       id,
+		Host:     fields["host"],
+		Port:     port,
+		Protocol: proxy.Protocol(fields["protocol"]),
+		Status:   proxy.Status(fields["status"]),
+		Score:    score,
+		Country:  fields["country"],
+	}, nil
+}
@@ -0,0 +1,143 @@
+package redis
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// anyBucket is the key segment used for a ZSET bucket dimension that
+// wasn't restricted to a specific value, e.g. "any country".
+const anyBucket = "*"
+
+func zsetKey(country string, protocol proxy.Protocol) string {
+	c := country
+	if c == "" {
+		c = anyBucket
+	}
+	pr := string(protocol)
+	if pr == "" {
+		pr = anyBucket
+	}
+	return "hotzset:" + c + ":" + pr
+}
+
+// HotZSet is the Redis-backed hot proxy pool used for request-time
+// selection: proxies are scored members of ZSETs keyed by (country,
+// protocol), so the scheduler can pick among a filtered, quality-ranked
+// subset in O(log n) via ZRANGEBYSCORE rather than scanning MySQL on
+// every request. It also maintains an (any, any) bucket so callers with
+// no geo/protocol preference can still pick from the full hot pool.
+//
+// It is kept in sync by the scorer and health checker (each call to
+// AddProxy re-scores a proxy in every bucket it belongs to) and
+// rebuilt wholesale from MySQL by pkg/reconcile when drift is detected.
+type HotZSet struct {
+	client goredis.UniversalClient
+	state  *HotState
+}
+
+// NewHotZSet wraps an existing Redis client.
+func NewHotZSet(client goredis.UniversalClient) *HotZSet {
+	return &HotZSet{client: client, state: NewHotState(client)}
+}
+
+// buckets returns every ZSET a proxy with the given country/protocol
+// belongs to: the exact (country, protocol) pair, each dimension alone,
+// and the fully unfiltered pool.
+func buckets(country string, protocol proxy.Protocol) []string {
+	return []string{
+		zsetKey(country, protocol),
+		zsetKey(country, ""),
+		zsetKey("", protocol),
+		zsetKey("", ""),
+	}
+}
+
+// AddProxy marks p as hot: its fields are stored (as with HotState) and
+// it is scored into every ZSET bucket it belongs to. Called again with
+// an updated score, it re-scores the proxy in place.
+func (h *HotZSet) AddProxy(ctx context.Context, p *proxy.Proxy) error {
+	if err := h.state.AddProxy(ctx, p); err != nil {
+		return err
+	}
+
+	pipe := h.client.TxPipeline()
+	for _, key := range buckets(p.Country, p.Protocol) {
+		pipe.ZAdd(ctx, key, goredis.Z{Score: p.Score, Member: p.ID})
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RemoveProxy drops a proxy from every bucket and from the hot state
+// entirely. It is a no-op if the proxy isn't currently hot.
+func (h *HotZSet) RemoveProxy(ctx context.Context, id string) error {
+	p, err := h.state.GetProxy(ctx, id)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	pipe := h.client.TxPipeline()
+	for _, key := range buckets(p.Country, p.Protocol) {
+		pipe.ZRem(ctx, key, id)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	return h.state.RemoveProxy(ctx, id)
+}
+
+// ListIDs returns the IDs of every proxy currently in the hot pool.
+func (h *HotZSet) ListIDs(ctx context.Context) ([]string, error) {
+	return h.state.ListIDs(ctx)
+}
+
+// Size returns how many proxies are currently in the hot pool.
+func (h *HotZSet) Size(ctx context.Context) (int64, error) {
+	return h.state.Size(ctx)
+}
+
+// GetProxy loads a single proxy's hot-state fields.
+func (h *HotZSet) GetProxy(ctx context.Context, id string) (*proxy.Proxy, error) {
+	return h.state.GetProxy(ctx, id)
+}
+
+// CandidatesAbove returns the IDs of every proxy scoring at least
+// minScore in the (country, protocol) bucket. Either field may be left
+// empty to mean "any". It returns an empty slice, not ErrNotFound, when
+// nothing qualifies, since callers (PickAbove and region-latency
+// ranking) each have their own notion of what an empty candidate set
+// means.
+func (h *HotZSet) CandidatesAbove(ctx context.Context, country string, protocol proxy.Protocol, minScore float64) ([]string, error) {
+	key := zsetKey(country, protocol)
+	return h.client.ZRangeByScore(ctx, key, &goredis.ZRangeBy{
+		Min: strconv.FormatFloat(minScore, 'f', -1, 64),
+		Max: "+inf",
+	}).Result()
+}
+
+// PickAbove returns a randomly selected proxy scoring at least minScore
+// from the (country, protocol) bucket, or ErrNotFound if none qualify.
+// Among equally-easy ways to pick, a random choice among every
+// qualifying member (rather than always the top score) spreads load
+// instead of hammering a single proxy.
+func (h *HotZSet) PickAbove(ctx context.Context, country string, protocol proxy.Protocol, minScore float64) (*proxy.Proxy, error) {
+	ids, err := h.CandidatesAbove(ctx, country, protocol, minScore)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, ErrNotFound
+	}
+
+	id := ids[rand.Intn(len(ids))]
+	return h.state.GetProxy(ctx, id)
+}
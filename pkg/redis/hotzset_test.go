@@ -0,0 +1,42 @@
+package redis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+func TestZsetKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		country  string
+		protocol proxy.Protocol
+		want     string
+	}{
+		{"exact pair", "US", proxy.ProtocolHTTP, "hotzset:US:http"},
+		{"any country", "", proxy.ProtocolHTTP, "hotzset:*:http"},
+		{"any protocol", "US", "", "hotzset:US:*"},
+		{"any both", "", "", "hotzset:*:*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := zsetKey(tt.country, tt.protocol); got != tt.want {
+				t.Errorf("zsetKey(%q, %q) = %q, want %q", tt.country, tt.protocol, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuckets(t *testing.T) {
+	got := buckets("US", proxy.ProtocolHTTP)
+	want := []string{
+		"hotzset:US:http",
+		"hotzset:US:*",
+		"hotzset:*:http",
+		"hotzset:*:*",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buckets() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,109 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// latencyBucketBoundsMS are the fixed upper bounds (inclusive, in
+// milliseconds) of each histogram bucket. An observation above the
+// final bound falls into an implicit overflow bucket. Fixed buckets
+// keep each observation an O(1) HINCRBY instead of the append-and-sort
+// a raw sample list or a t-digest would need, at the cost of bounding
+// percentile resolution to these edges.
+var latencyBucketBoundsMS = []int{10, 25, 50, 100, 250, 500, 1000, 1500, 2000, 3000, 5000, 10000}
+
+func latencyHistKey(proxyID string) string {
+	return "latencyhist:" + proxyID
+}
+
+// latencyBucket returns the index into latencyBucketBoundsMS that
+// latencyMS falls into, or len(latencyBucketBoundsMS) for the overflow
+// bucket.
+func latencyBucket(latencyMS int) int {
+	for i, bound := range latencyBucketBoundsMS {
+		if latencyMS <= bound {
+			return i
+		}
+	}
+	return len(latencyBucketBoundsMS)
+}
+
+// bucketUpperBoundMS returns the latency, in milliseconds, used to
+// estimate any observation that fell into bucket idx: its upper bound,
+// or the final bound for the overflow bucket.
+func bucketUpperBoundMS(idx int) float64 {
+	if idx >= len(latencyBucketBoundsMS) {
+		return float64(latencyBucketBoundsMS[len(latencyBucketBoundsMS)-1])
+	}
+	return float64(latencyBucketBoundsMS[idx])
+}
+
+// LatencyHistogram tracks, per proxy, a fixed-bucket histogram of
+// observed health-check latencies, so scoring and the admin API can
+// read tail latency (p95) instead of only a running average that a
+// handful of fast checks can mask.
+type LatencyHistogram struct {
+	client goredis.UniversalClient
+}
+
+// NewLatencyHistogram wraps an existing Redis client.
+func NewLatencyHistogram(client goredis.UniversalClient) *LatencyHistogram {
+	return &LatencyHistogram{client: client}
+}
+
+// Record adds one latency observation for proxyID.
+func (l *LatencyHistogram) Record(ctx context.Context, proxyID string, latencyMS int) error {
+	field := strconv.Itoa(latencyBucket(latencyMS))
+	return l.client.HIncrBy(ctx, latencyHistKey(proxyID), field, 1).Err()
+}
+
+// Percentiles estimates proxyID's p50 and p95 latency, in milliseconds,
+// from its recorded histogram. It returns ErrNotFound if no
+// observations have been recorded yet.
+func (l *LatencyHistogram) Percentiles(ctx context.Context, proxyID string) (p50, p95 float64, err error) {
+	fields, err := l.client.HGetAll(ctx, latencyHistKey(proxyID)).Result()
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(fields) == 0 {
+		return 0, 0, ErrNotFound
+	}
+
+	counts := make(map[int]int64, len(fields))
+	var total int64
+	for k, v := range fields {
+		idx, convErr := strconv.Atoi(k)
+		if convErr != nil {
+			continue
+		}
+		n, convErr := strconv.ParseInt(v, 10, 64)
+		if convErr != nil {
+			continue
+		}
+		counts[idx] = n
+		total += n
+	}
+	if total == 0 {
+		return 0, 0, ErrNotFound
+	}
+
+	return percentileFromCounts(counts, total, 0.50), percentileFromCounts(counts, total, 0.95), nil
+}
+
+// percentileFromCounts estimates the p-th percentile (0 < p <= 1) of a
+// histogram given per-bucket counts and their total, using the upper
+// bound of the bucket containing the p-th observation.
+func percentileFromCounts(counts map[int]int64, total int64, p float64) float64 {
+	target := p * float64(total)
+	var cumulative int64
+	for idx := 0; idx <= len(latencyBucketBoundsMS); idx++ {
+		cumulative += counts[idx]
+		if float64(cumulative) >= target {
+			return bucketUpperBoundMS(idx)
+		}
+	}
+	return bucketUpperBoundMS(len(latencyBucketBoundsMS))
+}
@@ -0,0 +1,52 @@
+package redis
+
+import "testing"
+
+func TestLatencyBucket(t *testing.T) {
+	tests := []struct {
+		name      string
+		latencyMS int
+		want      int
+	}{
+		{"falls on first bound", 10, 0},
+		{"between bounds rounds up", 11, 1},
+		{"zero latency", 0, 0},
+		{"above every bound overflows", 50000, len(latencyBucketBoundsMS)},
+		{"exactly the last bound", latencyBucketBoundsMS[len(latencyBucketBoundsMS)-1], len(latencyBucketBoundsMS) - 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := latencyBucket(tt.latencyMS); got != tt.want {
+				t.Errorf("latencyBucket(%d) = %d, want %d", tt.latencyMS, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercentileFromCounts(t *testing.T) {
+	// 100 observations, 90 in bucket 0 (<=10ms), 10 in the overflow
+	// bucket: p50 should land in the dense low bucket, p95 should spill
+	// into the overflow bucket.
+	counts := map[int]int64{
+		0:                          90,
+		len(latencyBucketBoundsMS): 10,
+	}
+	var total int64 = 100
+
+	if got, want := percentileFromCounts(counts, total, 0.50), bucketUpperBoundMS(0); got != want {
+		t.Errorf("p50 = %v, want %v", got, want)
+	}
+	if got, want := percentileFromCounts(counts, total, 0.95), bucketUpperBoundMS(len(latencyBucketBoundsMS)); got != want {
+		t.Errorf("p95 = %v, want %v", got, want)
+	}
+}
+
+func TestPercentileFromCountsAllInOneBucket(t *testing.T) {
+	counts := map[int]int64{3: 5}
+	got50 := percentileFromCounts(counts, 5, 0.50)
+	got95 := percentileFromCounts(counts, 5, 0.95)
+	want := bucketUpperBoundMS(3)
+	if got50 != want || got95 != want {
+		t.Errorf("p50=%v p95=%v, want both %v when every observation is in one bucket", got50, got95, want)
+	}
+}
@@ -0,0 +1,144 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Live request/failure counters are kept in per-second buckets rather
+// than a single running total, so a reader can derive a requests/sec
+// rate directly instead of sampling a cumulative counter twice and
+// subtracting. Buckets expire on their own shortly after the second
+// they count, so the keyspace doesn't grow unbounded.
+const (
+	liveRequestsPrefix            = "metrics:live:requests:"
+	liveFailuresPrefix            = "metrics:live:failures:"
+	liveConcurrencyRejectedPrefix = "metrics:live:concurrency_rejected:"
+	liveBytesPrefix               = "metrics:live:bytes:"
+	liveBucketTTL                 = 10 * time.Second
+)
+
+func liveBucketKey(prefix string, at time.Time) string {
+	return prefix + strconv.FormatInt(at.Unix(), 10)
+}
+
+// IncrLiveRequest records one gateway request in the current second's
+// bucket, for the admin dashboard's live requests/sec stream.
+func IncrLiveRequest(ctx context.Context, client goredis.UniversalClient) error {
+	return incrLiveBucket(ctx, client, liveRequestsPrefix, time.Now())
+}
+
+// IncrLiveFailure records one failed upstream outcome in the current
+// second's bucket, for the admin dashboard's live failures/sec stream.
+func IncrLiveFailure(ctx context.Context, client goredis.UniversalClient) error {
+	return incrLiveBucket(ctx, client, liveFailuresPrefix, time.Now())
+}
+
+// IncrLiveConcurrencyRejection records one connection rejected for
+// exceeding its plan's concurrent-connection limit, for the admin
+// dashboard's live concurrency-rejections/sec stream.
+func IncrLiveConcurrencyRejection(ctx context.Context, client goredis.UniversalClient) error {
+	return incrLiveBucket(ctx, client, liveConcurrencyRejectedPrefix, time.Now())
+}
+
+// IncrLiveBytes records n bytes transferred (either direction, summed)
+// in the current second's bucket, for the admin dashboard's live
+// throughput stream.
+func IncrLiveBytes(ctx context.Context, client goredis.UniversalClient, n int64) error {
+	key := liveBucketKey(liveBytesPrefix, time.Now())
+	pipe := client.TxPipeline()
+	pipe.IncrBy(ctx, key, n)
+	pipe.Expire(ctx, key, liveBucketTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func incrLiveBucket(ctx context.Context, client goredis.UniversalClient, prefix string, at time.Time) error {
+	key := liveBucketKey(prefix, at)
+	pipe := client.TxPipeline()
+	pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, liveBucketTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RequestsPerSecond returns the number of requests counted in the most
+// recently completed one-second bucket. The current, still-accumulating
+// second is skipped so the count isn't read mid-update.
+func RequestsPerSecond(ctx context.Context, client goredis.UniversalClient) (int64, error) {
+	return liveRate(ctx, client, liveRequestsPrefix)
+}
+
+// FailuresPerSecond returns the number of failed upstream outcomes
+// counted in the most recently completed one-second bucket.
+func FailuresPerSecond(ctx context.Context, client goredis.UniversalClient) (int64, error) {
+	return liveRate(ctx, client, liveFailuresPrefix)
+}
+
+// ConcurrencyRejectionsPerSecond returns the number of connections
+// rejected for exceeding their plan's concurrent-connection limit,
+// counted in the most recently completed one-second bucket.
+func ConcurrencyRejectionsPerSecond(ctx context.Context, client goredis.UniversalClient) (int64, error) {
+	return liveRate(ctx, client, liveConcurrencyRejectedPrefix)
+}
+
+// BytesPerSecond returns the number of bytes transferred, in either
+// direction, in the most recently completed one-second bucket.
+func BytesPerSecond(ctx context.Context, client goredis.UniversalClient) (int64, error) {
+	return liveRate(ctx, client, liveBytesPrefix)
+}
+
+func liveRate(ctx context.Context, client goredis.UniversalClient, prefix string) (int64, error) {
+	key := liveBucketKey(prefix, time.Now().Add(-time.Second))
+	n, err := client.Get(ctx, key).Int64()
+	if err == goredis.Nil {
+		return 0, nil
+	}
+	return n, err
+}
+
+// LiveSnapshot is one point-in-time reading of the gateway's live
+// traffic counters, used by the admin dashboard's streaming endpoint so
+// it doesn't have to poll /api/admin/stats.
+type LiveSnapshot struct {
+	Timestamp                 time.Time       `json:"timestamp"`
+	RequestsPerSecond         int64           `json:"requests_per_second"`
+	FailuresPerSecond         int64           `json:"failures_per_second"`
+	ConcurrencyRejectedPerSec int64           `json:"concurrency_rejections_per_second"`
+	BytesPerSecond            int64           `json:"bytes_per_second"`
+	ActiveSessions            int64           `json:"active_sessions"`
+	PoolSize                  int64           `json:"pool_size"`
+	RedisConnections          ConnectionStats `json:"redis_connections"`
+}
+
+// Snapshot assembles a LiveSnapshot from the live request/failure
+// counters, the current hot pool size, and the number of pinned
+// sessions.
+func Snapshot(ctx context.Context, client goredis.UniversalClient, pool *HotZSet, sessions *SessionPins) (LiveSnapshot, error) {
+	snap := LiveSnapshot{Timestamp: time.Now().UTC()}
+
+	var err error
+	if snap.RequestsPerSecond, err = RequestsPerSecond(ctx, client); err != nil {
+		return LiveSnapshot{}, err
+	}
+	if snap.FailuresPerSecond, err = FailuresPerSecond(ctx, client); err != nil {
+		return LiveSnapshot{}, err
+	}
+	if snap.ConcurrencyRejectedPerSec, err = ConcurrencyRejectionsPerSecond(ctx, client); err != nil {
+		return LiveSnapshot{}, err
+	}
+	if snap.BytesPerSecond, err = BytesPerSecond(ctx, client); err != nil {
+		return LiveSnapshot{}, err
+	}
+	if snap.ActiveSessions, err = sessions.Count(ctx); err != nil {
+		return LiveSnapshot{}, err
+	}
+	if snap.PoolSize, err = pool.Size(ctx); err != nil {
+		return LiveSnapshot{}, err
+	}
+	snap.RedisConnections = ConnStats(client)
+	return snap, nil
+}
@@ -0,0 +1,38 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+const anonymityMetricsKey = "metrics:anonymity"
+
+// IncrAnonymityLevel bumps the running count for a detected anonymity
+// level, giving operators a live breakdown of the pool's composition
+// without a MySQL aggregate query.
+func IncrAnonymityLevel(ctx context.Context, client goredis.UniversalClient, level proxy.AnonymityLevel) error {
+	return client.HIncrBy(ctx, anonymityMetricsKey, string(level), 1).Err()
+}
+
+// AnonymityLevelCounts returns the current running counts per anonymity
+// level.
+func AnonymityLevelCounts(ctx context.Context, client goredis.UniversalClient) (map[string]int64, error) {
+	raw, err := client.HGetAll(ctx, anonymityMetricsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(raw))
+	for level, value := range raw {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		counts[level] = n
+	}
+	return counts, nil
+}
@@ -0,0 +1,38 @@
+package redis
+
+import (
+	"context"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// FlushNamespace deletes every key matching the "prefix:*" pattern. It is
+// used by operator tooling to clear a single logical namespace (e.g.
+// "session" or "ratelimit") without touching the rest of the keyspace, and
+// walks the keyspace with SCAN so it stays safe to run against a large,
+// live Redis instance.
+func FlushNamespace(ctx context.Context, client goredis.UniversalClient, prefix string) (int, error) {
+	var (
+		cursor uint64
+		keys   []string
+	)
+	for {
+		batch, next, err := client.Scan(ctx, cursor, prefix+":*", 100).Result()
+		if err != nil {
+			return 0, err
+		}
+		keys = append(keys, batch...)
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	if err := client.Del(ctx, keys...).Err(); err != nil {
+		return 0, err
+	}
+	return len(keys), nil
+}
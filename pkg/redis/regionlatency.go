@@ -0,0 +1,72 @@
+package redis
+
+import (
+	"context"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func regionLatencyKey(gatewayID string) string {
+	return "regionlatency:" + gatewayID
+}
+
+// RegionLatency tracks, per requesting gateway, each proxy's most
+// recently observed latency from that gateway's vantage point. The
+// central health-check sweep only measures latency from wherever
+// proxy-pool itself runs, which doesn't reflect what a gateway in a
+// different region actually sees, so this is instead populated from
+// live request outcomes the gateway itself reports (see
+// grpcserver.Server.Report).
+//
+// Each gateway is a ZSET of proxy IDs scored by their latest observed
+// latency in milliseconds: lower is better, and a fresh observation
+// overwrites the old one rather than averaging, so a gateway's ranking
+// always reflects current network conditions instead of smoothing over
+// a since-resolved route change.
+type RegionLatency struct {
+	client goredis.UniversalClient
+}
+
+// NewRegionLatency wraps an existing Redis client.
+func NewRegionLatency(client goredis.UniversalClient) *RegionLatency {
+	return &RegionLatency{client: client}
+}
+
+// Record stores proxyID's latest observed latency, in milliseconds, as
+// seen from gatewayID.
+func (r *RegionLatency) Record(ctx context.Context, gatewayID, proxyID string, latencyMS int) error {
+	return r.client.ZAdd(ctx, regionLatencyKey(gatewayID), goredis.Z{Score: float64(latencyMS), Member: proxyID}).Err()
+}
+
+// BestOf returns, of candidateIDs, whichever has the lowest latency
+// recorded from gatewayID's vantage point, or ErrNotFound if none of
+// them have been measured from it yet. A candidate with no recorded
+// score comes back as 0 from ZMScore, indistinguishable from a
+// (vanishingly unlikely) true 0ms latency; either way it isn't worth
+// preferring over a candidate with a real measurement, so both are
+// treated as unmeasured.
+func (r *RegionLatency) BestOf(ctx context.Context, gatewayID string, candidateIDs []string) (string, error) {
+	if len(candidateIDs) == 0 {
+		return "", ErrNotFound
+	}
+
+	scores, err := r.client.ZMScore(ctx, regionLatencyKey(gatewayID), candidateIDs...).Result()
+	if err != nil {
+		return "", err
+	}
+
+	best := -1
+	var bestScore float64
+	for i, score := range scores {
+		if score == 0 {
+			continue
+		}
+		if best == -1 || score < bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best == -1 {
+		return "", ErrNotFound
+	}
+	return candidateIDs[best], nil
+}
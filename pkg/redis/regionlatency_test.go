@@ -0,0 +1,9 @@
+package redis
+
+import "testing"
+
+func TestRegionLatencyKey(t *testing.T) {
+	if got, want := regionLatencyKey("us-east-gw1"), "regionlatency:us-east-gw1"; got != want {
+		t.Errorf("regionLatencyKey(%q) = %q, want %q", "us-east-gw1", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// ErrSessionNotPinned is returned when a session has no pinned proxy, or
+// its pin has expired.
+var ErrSessionNotPinned = errors.New("redis: session not pinned")
+
+func sessionKey(sessionID string) string { return "session:" + sessionID }
+
+// SessionPins tracks which upstream proxy a sticky client session is
+// pinned to, so scrapers that need the same exit IP across a session
+// keep getting it. Pins expire on their own via TTL rather than being
+// explicitly released, since the gateway has no reliable "session
+// ended" signal.
+type SessionPins struct {
+	client goredis.UniversalClient
+}
+
+// NewSessionPins wraps an existing Redis client.
+func NewSessionPins(client goredis.UniversalClient) *SessionPins {
+	return &SessionPins{client: client}
+}
+
+// Pin records that sessionID is pinned to proxyID, extending the pin's
+// TTL. Call this on every request that uses the pin, not just the
+// first, so an active session doesn't expire mid-use.
+func (s *SessionPins) Pin(ctx context.Context, sessionID, proxyID string, ttl time.Duration) error {
+	return s.client.Set(ctx, sessionKey(sessionID), proxyID, ttl).Err()
+}
+
+// Get returns the proxy ID sessionID is currently pinned to, or
+// ErrSessionNotPinned if it has none (or the pin expired).
+func (s *SessionPins) Get(ctx context.Context, sessionID string) (string, error) {
+	proxyID, err := s.client.Get(ctx, sessionKey(sessionID)).Result()
+	if err == goredis.Nil {
+		return "", ErrSessionNotPinned
+	}
+	if err != nil {
+		return "", err
+	}
+	return proxyID, nil
+}
+
+// Count returns how many sessions currently have an active pin. It
+// scans the keyspace rather than maintaining a separate counter, since
+// pins expire on their own (see Pin) and there's no event to decrement
+// on.
+func (s *SessionPins) Count(ctx context.Context) (int64, error) {
+	var count int64
+	var cursor uint64
+	for {
+		keys, next, err := s.client.Scan(ctx, cursor, sessionKey("*"), 1000).Result()
+		if err != nil {
+			return 0, err
+		}
+		count += int64(len(keys))
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,169 @@
+// Package reseller lets a parent account allocate a share of its
+// traffic to the sub-accounts it creates (see user.User.ParentUserID
+// and user.DAO.CreateSubAccount): each sub-account can be given a
+// monthly request allocation, enforced by Enforcer at the same point
+// every other credential is authorized, and revoked by suspending the
+// sub-account the ordinary way (user.DAO.UpdateStatus).
+package reseller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dbtx"
+)
+
+// ErrNoAllocation is returned by DAO.Get when subUserID has no quota
+// allocation, meaning it is unlimited.
+var ErrNoAllocation = errors.New("reseller: no quota allocation")
+
+// QuotaAllocation is the monthly request allowance a parent has given
+// one of its sub-accounts.
+type QuotaAllocation struct {
+	SubUserID         string
+	ParentUserID      string
+	AllocatedRequests int64
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// DAOInterface is the subset of DAO's behavior that service and handler
+// code depends on. It exists so those layers can be unit-tested against
+// daofake's in-memory fake instead of a real MySQL connection; see
+// pkg/daofake's conformance suite, which every implementation (DAO
+// included) must pass.
+type DAOInterface interface {
+	Allocate(ctx context.Context, parentUserID, subUserID string, allocatedRequests int64) (*QuotaAllocation, error)
+	Get(ctx context.Context, subUserID string) (*QuotaAllocation, error)
+	ListForParent(ctx context.Context, parentUserID string) ([]*QuotaAllocation, error)
+	Delete(ctx context.Context, subUserID string) error
+}
+
+// DAO persists quota allocations in MySQL.
+type DAO struct {
+	db dbtx.Queryer
+}
+
+var _ DAOInterface = (*DAO)(nil)
+
+// NewDAO wraps an existing *sql.DB handle.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db}
+}
+
+// WithTx returns a DAO whose operations run against tx instead of the
+// original *sql.DB, so callers can compose it with other DAOs inside a
+// dbtx.Run unit of work.
+func (d *DAO) WithTx(tx *sql.Tx) *DAO {
+	return &DAO{db: tx}
+}
+
+// Allocate creates or replaces subUserID's quota allocation.
+func (d *DAO) Allocate(ctx context.Context, parentUserID, subUserID string, allocatedRequests int64) (*QuotaAllocation, error) {
+	now := time.Now().UTC()
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO sub_account_quotas (sub_user_id, parent_user_id, allocated_requests, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?)
+		 ON DUPLICATE KEY UPDATE allocated_requests = VALUES(allocated_requests), updated_at = VALUES(updated_at)`,
+		subUserID, parentUserID, allocatedRequests, now, now)
+	if err != nil {
+		return nil, err
+	}
+	return d.Get(ctx, subUserID)
+}
+
+// Get returns subUserID's quota allocation, or ErrNoAllocation if its
+// parent hasn't set one (meaning it's unlimited).
+func (d *DAO) Get(ctx context.Context, subUserID string) (*QuotaAllocation, error) {
+	row := d.db.QueryRowContext(ctx,
+		`SELECT sub_user_id, parent_user_id, allocated_requests, created_at, updated_at FROM sub_account_quotas WHERE sub_user_id = ?`, subUserID)
+	q, err := scanQuota(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNoAllocation
+	}
+	return q, err
+}
+
+// ListForParent returns every quota allocation parentUserID has made.
+func (d *DAO) ListForParent(ctx context.Context, parentUserID string) ([]*QuotaAllocation, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT sub_user_id, parent_user_id, allocated_requests, created_at, updated_at FROM sub_account_quotas WHERE parent_user_id = ? ORDER BY created_at`, parentUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*QuotaAllocation
+	for rows.Next() {
+		q, err := scanQuota(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, q)
+	}
+	return out, rows.Err()
+}
+
+// Delete removes subUserID's quota allocation, making it unlimited
+// again. It is a no-op if none exists.
+func (d *DAO) Delete(ctx context.Context, subUserID string) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM sub_account_quotas WHERE sub_user_id = ?`, subUserID)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanQuota(row rowScanner) (*QuotaAllocation, error) {
+	q := &QuotaAllocation{}
+	if err := row.Scan(&q.SubUserID, &q.ParentUserID, &q.AllocatedRequests, &q.CreatedAt, &q.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// monthlyWindow is how long a sub-account's counted request total
+// stands before resetting, mirroring a monthly billing quota
+// (pkg/billing.Plan.QuotaRequests) rather than a short rate-limit
+// window.
+const monthlyWindow = 32 * 24 * time.Hour
+
+// Enforcer counts each sub-account's requests in Redis and compares the
+// running total against its allocation, the same fixed-window counting
+// pkg/ratelimit uses for per-plan rate limits, just bucketed by
+// calendar month instead of a short window.
+type Enforcer struct {
+	client goredis.UniversalClient
+}
+
+// NewEnforcer wraps a Redis client.
+func NewEnforcer(client goredis.UniversalClient) *Enforcer {
+	return &Enforcer{client: client}
+}
+
+// Allow increments subUserID's request counter for the current calendar
+// month and reports whether it is still within allocatedRequests.
+func (e *Enforcer) Allow(ctx context.Context, subUserID string, allocatedRequests int64) (bool, error) {
+	key := monthKey(subUserID, time.Now())
+
+	pipe := e.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, monthlyWindow)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+
+	return incr.Val() <= allocatedRequests, nil
+}
+
+// monthKey buckets at into its calendar month, so every request in the
+// same month shares a counter that resets naturally at the boundary.
+func monthKey(subUserID string, at time.Time) string {
+	return "reseller:quota:" + subUserID + ":" + strconv.Itoa(at.Year()) + "-" + strconv.Itoa(int(at.Month()))
+}
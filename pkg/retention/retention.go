@@ -0,0 +1,173 @@
+// Package retention runs the platform's data-retention purge job: it
+// hard-deletes proxies that were soft-deleted a while ago and prunes
+// append-only history tables (proxy health checks, usage logs) past
+// their configured retention windows, so these tables don't grow
+// unbounded.
+package retention
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+)
+
+// defaultChunkSize caps how many rows a single purge DELETE removes, so
+// a large backlog is worked off in several short-lived statements
+// instead of one long-running one that would hold locks.
+const defaultChunkSize = 500
+
+// Config controls how long each category of data is kept before it's
+// purged. Zero durations disable purging for that category.
+type Config struct {
+	// ProxySoftDeleteRetention is how long a soft-deleted proxy stays
+	// around (for audit/undo) before it's hard-deleted.
+	ProxySoftDeleteRetention time.Duration
+	// HealthCheckRetention is how long proxy_health_checks rows are kept.
+	HealthCheckRetention time.Duration
+	// UsageLogRetention is how long usage_logs rows are kept.
+	UsageLogRetention time.Duration
+	// ChunkSize caps rows removed per DELETE. A value <= 0 uses
+	// defaultChunkSize.
+	ChunkSize int
+}
+
+func (c Config) chunkSize() int {
+	if c.ChunkSize <= 0 {
+		return defaultChunkSize
+	}
+	return c.ChunkSize
+}
+
+// Stats reports what a single RunOnce pass purged.
+type Stats struct {
+	PurgedProxies      int64
+	PurgedHealthChecks int64
+	PurgedUsageLogs    int64
+}
+
+// Metrics tracks cumulative purge counters for observability.
+type Metrics struct {
+	PurgedProxies      int64
+	PurgedHealthChecks int64
+	PurgedUsageLogs    int64
+	Passes             int64
+}
+
+// Snapshot returns a consistent point-in-time copy of m.
+func (m *Metrics) Snapshot() Metrics {
+	return Metrics{
+		PurgedProxies:      atomic.LoadInt64(&m.PurgedProxies),
+		PurgedHealthChecks: atomic.LoadInt64(&m.PurgedHealthChecks),
+		PurgedUsageLogs:    atomic.LoadInt64(&m.PurgedUsageLogs),
+		Passes:             atomic.LoadInt64(&m.Passes),
+	}
+}
+
+// Cleaner periodically purges soft-deleted proxies and old history rows
+// according to Config's retention windows.
+type Cleaner struct {
+	proxyDAO       *dao.ProxyDAO
+	healthCheckDAO *dao.ProxyHealthCheckDAO
+	usageDAO       *usage.DAO
+	config         Config
+	metrics        Metrics
+}
+
+// New creates a Cleaner over the given DAOs and retention config.
+func New(proxyDAO *dao.ProxyDAO, healthCheckDAO *dao.ProxyHealthCheckDAO, usageDAO *usage.DAO, config Config) *Cleaner {
+	return &Cleaner{proxyDAO: proxyDAO, healthCheckDAO: healthCheckDAO, usageDAO: usageDAO, config: config}
+}
+
+// Metrics returns the cleaner's cumulative purge metrics.
+func (c *Cleaner) Metrics() Metrics {
+	return c.metrics.Snapshot()
+}
+
+// Run purges every `interval` until ctx is cancelled.
+func (c *Cleaner) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats, err := c.RunOnce(ctx)
+			if err != nil {
+				log.Printf("retention: purge pass failed: %v", err)
+				continue
+			}
+			log.Printf("retention: purged %d deleted proxies, %d health checks, %d usage logs",
+				stats.PurgedProxies, stats.PurgedHealthChecks, stats.PurgedUsageLogs)
+		}
+	}
+}
+
+// RunOnce runs a single purge pass across every configured category and
+// returns how much it removed.
+func (c *Cleaner) RunOnce(ctx context.Context) (Stats, error) {
+	var stats Stats
+	now := time.Now().UTC()
+
+	if c.config.ProxySoftDeleteRetention > 0 {
+		purged, err := purgeChunked(ctx, c.config.chunkSize(), func(limit int) (int64, error) {
+			return c.proxyDAO.PurgeDeleted(ctx, now.Add(-c.config.ProxySoftDeleteRetention), limit)
+		})
+		if err != nil {
+			return stats, err
+		}
+		stats.PurgedProxies = purged
+	}
+
+	if c.config.HealthCheckRetention > 0 {
+		purged, err := purgeChunked(ctx, c.config.chunkSize(), func(limit int) (int64, error) {
+			return c.healthCheckDAO.DeleteOldChecks(ctx, now.Add(-c.config.HealthCheckRetention), limit)
+		})
+		if err != nil {
+			return stats, err
+		}
+		stats.PurgedHealthChecks = purged
+	}
+
+	if c.config.UsageLogRetention > 0 {
+		purged, err := purgeChunked(ctx, c.config.chunkSize(), func(limit int) (int64, error) {
+			return c.usageDAO.DeleteOldLogs(ctx, now.Add(-c.config.UsageLogRetention), limit)
+		})
+		if err != nil {
+			return stats, err
+		}
+		stats.PurgedUsageLogs = purged
+	}
+
+	atomic.AddInt64(&c.metrics.PurgedProxies, stats.PurgedProxies)
+	atomic.AddInt64(&c.metrics.PurgedHealthChecks, stats.PurgedHealthChecks)
+	atomic.AddInt64(&c.metrics.PurgedUsageLogs, stats.PurgedUsageLogs)
+	atomic.AddInt64(&c.metrics.Passes, 1)
+
+	return stats, nil
+}
+
+// purgeChunked repeatedly calls del with limit until it reports deleting
+// fewer rows than requested (i.e. the backlog is exhausted) or ctx is
+// cancelled, returning the total rows deleted.
+func purgeChunked(ctx context.Context, limit int, del func(limit int) (int64, error)) (int64, error) {
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		n, err := del(limit)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		if n < int64(limit) {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Package routing lets operators configure per-target-domain rules
+// ("requests to *.amazon.com must use US residential proxies") that the
+// gateway consults when picking an upstream proxy for a request.
+package routing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// ErrNoMatch is returned by Match when no rule applies to a host, so
+// the caller should fall back to its default proxy selection.
+var ErrNoMatch = errors.New("routing: no rule matches host")
+
+// Rule constrains which proxies may serve requests to targets matching
+// Pattern. Pattern is either an exact host ("example.com") or a
+// leading-wildcard glob ("*.example.com", which also matches
+// "example.com" itself). An empty Country or Protocol means "any".
+// Among rules that match the same host, the one with the highest
+// Priority wins.
+type Rule struct {
+	ID       string
+	Pattern  string
+	Country  string
+	Protocol proxy.Protocol
+	MinScore float64
+	Priority int
+}
+
+// DAO manages routing rules in MySQL.
+type DAO struct {
+	db *sql.DB
+}
+
+// NewDAO wraps an existing *sql.DB handle.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db}
+}
+
+// List returns every configured rule, highest priority first.
+func (d *DAO) List(ctx context.Context) ([]*Rule, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT id, pattern, country, protocol, min_score, priority FROM routing_rules ORDER BY priority DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Rule
+	for rows.Next() {
+		r, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// Insert creates a new routing rule, assigning it an ID.
+func (d *DAO) Insert(ctx context.Context, r *Rule) error {
+	r.ID = uuid.NewString()
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO routing_rules (id, pattern, country, protocol, min_score, priority)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		r.ID, r.Pattern, r.Country, string(r.Protocol), r.MinScore, r.Priority)
+	return err
+}
+
+// Delete removes a routing rule. It is a no-op if the rule doesn't
+// exist.
+func (d *DAO) Delete(ctx context.Context, id string) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM routing_rules WHERE id = ?`, id)
+	return err
+}
+
+// Match returns the highest-priority rule whose Pattern matches host,
+// or ErrNoMatch if none do.
+func (d *DAO) Match(ctx context.Context, host string) (*Rule, error) {
+	rules, err := d.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rules {
+		if matchesPattern(r.Pattern, host) {
+			return r, nil
+		}
+	}
+	return nil, ErrNoMatch
+}
+
+// matchesPattern reports whether host satisfies pattern. A pattern
+// starting with "*." matches that suffix or the bare domain itself;
+// any other pattern must match host exactly (case-insensitively).
+func matchesPattern(pattern, host string) bool {
+	pattern = strings.ToLower(pattern)
+	host = strings.ToLower(host)
+
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		return host == suffix || strings.HasSuffix(host, "."+suffix)
+	}
+	return host == pattern
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRule(row rowScanner) (*Rule, error) {
+	r := &Rule{}
+	var protocol string
+	if err := row.Scan(&r.ID, &r.Pattern, &r.Country, &protocol, &r.MinScore, &r.Priority); err != nil {
+		return nil, err
+	}
+	r.Protocol = proxy.Protocol(protocol)
+	return r, nil
+}
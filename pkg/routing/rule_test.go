@@ -0,0 +1,24 @@
+package routing
+
+import "testing"
+
+func TestMatchesPattern(t *testing.T) {
+	cases := []struct {
+		pattern, host string
+		want          bool
+	}{
+		{"*.amazon.com", "www.amazon.com", true},
+		{"*.amazon.com", "amazon.com", true},
+		{"*.amazon.com", "notamazon.com", false},
+		{"*.amazon.com", "amazon.co.uk", false},
+		{"example.com", "example.com", true},
+		{"example.com", "www.example.com", false},
+		{"Example.com", "EXAMPLE.COM", true},
+	}
+
+	for _, c := range cases {
+		if got := matchesPattern(c.pattern, c.host); got != c.want {
+			t.Errorf("matchesPattern(%q, %q) = %v, want %v", c.pattern, c.host, got, c.want)
+		}
+	}
+}
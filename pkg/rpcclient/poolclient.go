@@ -0,0 +1,108 @@
+package rpcclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	proxypoolv1 "github.com/XXXXD-cation/proxy-platform/pkg/rpc/proxypoolv1"
+)
+
+// AcquireParams describes what kind of proxy a caller wants. A pinned ID
+// is tried first, falling back to the other fields (empty/zero meaning
+// "don't filter on this") if it no longer qualifies.
+type AcquireParams struct {
+	Country  string
+	City     string
+	ASN      int
+	Protocol proxy.Protocol
+	MinScore float64
+	PinnedID string
+	// TargetDomain, if set, is tried before the rest of the criteria: a
+	// proxy with a proven track record against it is preferred over the
+	// general-purpose pick.
+	TargetDomain string
+	// ExcludeBlacklisted, if set, excludes proxies flagged by the
+	// reputation checker from selection.
+	ExcludeBlacklisted bool
+	// GatewayID identifies the region-scoped gateway making the
+	// request, if any. When set, Acquire prefers whichever qualifying
+	// candidate has the lowest latency recorded from that gateway's own
+	// vantage point over proxy-pool's vantage-point-agnostic score.
+	GatewayID string
+}
+
+// PoolClient is a pooled client for proxy-pool's ProxyPoolService.
+type PoolClient struct {
+	client proxypoolv1.ProxyPoolServiceClient
+}
+
+// NewPoolClient wraps a connection dialed with Dial.
+func NewPoolClient(conn *grpc.ClientConn) *PoolClient {
+	return &PoolClient{client: proxypoolv1.NewProxyPoolServiceClient(conn)}
+}
+
+// Acquire selects a proxy matching params.
+func (c *PoolClient) Acquire(ctx context.Context, params AcquireParams) (*proxy.Proxy, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultCallTimeout)
+	defer cancel()
+
+	resp, err := c.client.Acquire(ctx, &proxypoolv1.AcquireRequest{
+		Country:            params.Country,
+		City:               params.City,
+		Asn:                int32(params.ASN),
+		Protocol:           string(params.Protocol),
+		MinScore:           params.MinScore,
+		PinnedId:           params.PinnedID,
+		TargetDomain:       params.TargetDomain,
+		ExcludeBlacklisted: params.ExcludeBlacklisted,
+		GatewayId:          params.GatewayID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromProto(resp.Proxy), nil
+}
+
+// Release signals that the caller is done with the given proxy.
+func (c *PoolClient) Release(ctx context.Context, proxyID string) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultCallTimeout)
+	defer cancel()
+
+	_, err := c.client.Release(ctx, &proxypoolv1.ReleaseRequest{ProxyId: proxyID})
+	return err
+}
+
+// Report records the outcome of a single use of a proxy. targetDomain,
+// if non-empty, is recorded alongside the proxy's general health so the
+// scheduler can later prefer proxies proven to work against it.
+// gatewayID, if non-empty, records this latency as that gateway's own
+// vantage-point observation, for future Acquire calls from it to rank
+// candidates on.
+func (c *PoolClient) Report(ctx context.Context, proxyID string, success bool, latencyMS int, targetDomain, gatewayID string) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultCallTimeout)
+	defer cancel()
+
+	_, err := c.client.Report(ctx, &proxypoolv1.ReportRequest{
+		ProxyId:      proxyID,
+		Success:      success,
+		LatencyMs:    int32(latencyMS),
+		TargetDomain: targetDomain,
+		GatewayId:    gatewayID,
+	})
+	return err
+}
+
+func fromProto(p *proxypoolv1.Proxy) *proxy.Proxy {
+	return &proxy.Proxy{
+		ID:       p.Id,
+		Host:     p.Host,
+		Port:     int(p.Port),
+		Protocol: proxy.Protocol(p.Protocol),
+		Country:  p.Country,
+		City:     p.City,
+		ASN:      int(p.Asn),
+		Score:    p.Score,
+	}
+}
@@ -0,0 +1,39 @@
+// Package rpcclient provides pooled gRPC clients for the platform's
+// internal services (proxy-pool's ProxyPoolService, api's UserService),
+// so callers like the gateway don't need to depend on MySQL or Redis
+// directly just to reach another service's data.
+package rpcclient
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/tracing"
+)
+
+// DefaultDialTimeout bounds how long Dial waits for the initial
+// connection before giving up.
+const DefaultDialTimeout = 5 * time.Second
+
+// DefaultCallTimeout bounds any single RPC made through a client in this
+// package, so a slow or wedged internal service can't stall the caller
+// indefinitely.
+const DefaultCallTimeout = 2 * time.Second
+
+// Dial opens a pooled, reusable connection to an internal service at
+// addr. gRPC multiplexes concurrent RPCs over a single *grpc.ClientConn
+// (it dials and load-balances sub-connections internally), so callers
+// should dial once at startup and share the connection, not dial per
+// request.
+func Dial(addr string) (*grpc.ClientConn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultDialTimeout)
+	defer cancel()
+	return grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+		tracing.DialOption(),
+	)
+}
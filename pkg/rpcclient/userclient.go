@@ -0,0 +1,78 @@
+package rpcclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	userv1 "github.com/XXXXD-cation/proxy-platform/pkg/rpc/userv1"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// UserClient is a pooled client for api's UserService.
+type UserClient struct {
+	client userv1.UserServiceClient
+}
+
+// NewUserClient wraps a connection dialed with Dial.
+func NewUserClient(conn *grpc.ClientConn) *UserClient {
+	return &UserClient{client: userv1.NewUserServiceClient(conn)}
+}
+
+// Authorize validates a raw API key and resolves it to a *apikey.Key,
+// matching apikey.DAO.LookupByRawKey's own signature so it can be used
+// as a drop-in replacement by callers that previously looked the key up
+// against MySQL directly. It returns apikey.ErrNotFound both when the
+// key doesn't exist and when the service rejects it outright.
+func (c *UserClient) Authorize(ctx context.Context, rawKey string) (*apikey.Key, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultCallTimeout)
+	defer cancel()
+
+	resp, err := c.client.Authorize(ctx, &userv1.AuthorizeRequest{
+		Credential: &userv1.AuthorizeRequest_ApiKey{ApiKey: rawKey},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Allowed {
+		return nil, apikey.ErrNotFound
+	}
+
+	return &apikey.Key{
+		ID:                      resp.ApiKeyId,
+		UserID:                  resp.UserId,
+		Permissions:             resp.Permissions,
+		RotationMode:            resp.RotationMode,
+		RotationIntervalSeconds: int(resp.RotationIntervalSeconds),
+		Plan:                    user.Plan(resp.Plan),
+	}, nil
+}
+
+// AuthorizeByIP resolves a connection's source IP to the user who
+// allowlisted it, for customers who authorize by IP instead of an API
+// key. It returns apikey.ErrNotFound both when ip isn't allowlisted and
+// when the service rejects it outright, matching Authorize's own
+// convention. The returned Key has no ID, permissions, or rotation
+// policy, since it was never issued one; callers that depend on those
+// fields should treat an IP-authorized connection the same as an
+// unscoped, per-request key.
+func (c *UserClient) AuthorizeByIP(ctx context.Context, ip string) (*apikey.Key, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultCallTimeout)
+	defer cancel()
+
+	resp, err := c.client.Authorize(ctx, &userv1.AuthorizeRequest{
+		Credential: &userv1.AuthorizeRequest_ClientIp{ClientIp: ip},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Allowed {
+		return nil, apikey.ErrNotFound
+	}
+
+	return &apikey.Key{
+		UserID: resp.UserId,
+		Plan:   user.Plan(resp.Plan),
+	}, nil
+}
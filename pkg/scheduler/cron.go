@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed standard five-field cron expression (minute
+// hour day-of-month month day-of-week), evaluated against a time by
+// Matches rather than by computing the next run directly: the
+// Scheduler ticks once a minute and asks every job's Schedule whether
+// that minute matches, which also makes the per-minute distributed
+// lock key (job name + truncated minute) a natural dedup boundary
+// across instances.
+type Schedule struct {
+	minute fieldSet
+	hour   fieldSet
+	dom    fieldSet
+	month  fieldSet
+	dow    fieldSet
+	raw    string
+}
+
+// fieldSet is the set of values a single cron field accepts; nil means
+// "every value in range", i.e. the field was "*".
+type fieldSet map[int]struct{}
+
+func (f fieldSet) matches(v int) bool {
+	if f == nil {
+		return true
+	}
+	_, ok := f[v]
+	return ok
+}
+
+// ParseSchedule parses a standard five-field cron expression: minute
+// (0-59), hour (0-23), day-of-month (1-31), month (1-12), and
+// day-of-week (0-6, Sunday = 0). Each field accepts "*", "*/N", a
+// comma-separated list, a "N-M" range, or a plain number.
+func ParseSchedule(expr string) (Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("scheduler: cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+
+	minute, err := parseField(fields[0], 0, 59)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("scheduler: minute field: %w", err)
+	}
+	hour, err := parseField(fields[1], 0, 23)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("scheduler: hour field: %w", err)
+	}
+	dom, err := parseField(fields[2], 1, 31)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("scheduler: day-of-month field: %w", err)
+	}
+	month, err := parseField(fields[3], 1, 12)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("scheduler: month field: %w", err)
+	}
+	dow, err := parseField(fields[4], 0, 6)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("scheduler: day-of-week field: %w", err)
+	}
+
+	return Schedule{minute: minute, hour: hour, dom: dom, month: month, dow: dow, raw: expr}, nil
+}
+
+// String returns the cron expression Schedule was parsed from.
+func (s Schedule) String() string {
+	return s.raw
+}
+
+// Matches reports whether t, truncated to the minute, falls on this
+// schedule.
+func (s Schedule) Matches(t time.Time) bool {
+	return s.minute.matches(t.Minute()) &&
+		s.hour.matches(t.Hour()) &&
+		s.dom.matches(t.Day()) &&
+		s.month.matches(int(t.Month())) &&
+		s.dow.matches(int(t.Weekday()))
+}
+
+// parseField parses a single cron field, whose values must fall within
+// [min, max].
+func parseField(field string, min, max int) (fieldSet, error) {
+	if field == "*" {
+		return nil, nil
+	}
+
+	set := make(fieldSet)
+	for _, part := range strings.Split(field, ",") {
+		if err := parsePart(part, min, max, set); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// parsePart parses one comma-separated element of a cron field
+// ("*/N", "N-M", or "N") and adds the values it denotes to set.
+func parsePart(part string, min, max int, set fieldSet) error {
+	step := 1
+	base := part
+	if before, after, ok := strings.Cut(part, "/"); ok {
+		base = before
+		n, err := strconv.Atoi(after)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid step %q", after)
+		}
+		step = n
+	}
+
+	lo, hi := min, max
+	if base != "*" {
+		if before, after, ok := strings.Cut(base, "-"); ok {
+			loN, err := strconv.Atoi(before)
+			if err != nil {
+				return fmt.Errorf("invalid range start %q", before)
+			}
+			hiN, err := strconv.Atoi(after)
+			if err != nil {
+				return fmt.Errorf("invalid range end %q", after)
+			}
+			lo, hi = loN, hiN
+		} else {
+			n, err := strconv.Atoi(base)
+			if err != nil {
+				return fmt.Errorf("invalid value %q", base)
+			}
+			lo, hi = n, n
+		}
+	}
+	if lo < min || hi > max || lo > hi {
+		return fmt.Errorf("value %q out of range [%d, %d]", part, min, max)
+	}
+
+	for v := lo; v <= hi; v += step {
+		set[v] = struct{}{}
+	}
+	return nil
+}
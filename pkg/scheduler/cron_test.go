@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, expr string) Schedule {
+	t.Helper()
+	s, err := ParseSchedule(expr)
+	if err != nil {
+		t.Fatalf("ParseSchedule(%q) failed: %v", expr, err)
+	}
+	return s
+}
+
+func TestScheduleMatchesEveryMinute(t *testing.T) {
+	s := mustParse(t, "* * * * *")
+	if !s.Matches(time.Date(2026, 8, 9, 3, 17, 0, 0, time.UTC)) {
+		t.Error("expected * * * * * to match any minute")
+	}
+}
+
+func TestScheduleMatchesHourly(t *testing.T) {
+	s := mustParse(t, "0 * * * *")
+	if !s.Matches(time.Date(2026, 8, 9, 3, 0, 0, 0, time.UTC)) {
+		t.Error("expected 0 * * * * to match minute 0")
+	}
+	if s.Matches(time.Date(2026, 8, 9, 3, 1, 0, 0, time.UTC)) {
+		t.Error("expected 0 * * * * not to match minute 1")
+	}
+}
+
+func TestScheduleMatchesStep(t *testing.T) {
+	s := mustParse(t, "*/15 * * * *")
+	for _, m := range []int{0, 15, 30, 45} {
+		if !s.Matches(time.Date(2026, 8, 9, 3, m, 0, 0, time.UTC)) {
+			t.Errorf("expected */15 to match minute %d", m)
+		}
+	}
+	if s.Matches(time.Date(2026, 8, 9, 3, 20, 0, 0, time.UTC)) {
+		t.Error("expected */15 not to match minute 20")
+	}
+}
+
+func TestScheduleMatchesRangeAndList(t *testing.T) {
+	s := mustParse(t, "0 9-17 * * 1-5")
+	if !s.Matches(time.Date(2026, 8, 10, 12, 0, 0, 0, time.UTC)) { // Monday
+		t.Error("expected weekday business hour to match")
+	}
+	if s.Matches(time.Date(2026, 8, 8, 12, 0, 0, 0, time.UTC)) { // Saturday
+		t.Error("expected Saturday not to match weekday schedule")
+	}
+}
+
+func TestParseScheduleRejectsWrongFieldCount(t *testing.T) {
+	if _, err := ParseSchedule("* * * *"); err == nil {
+		t.Error("expected error for 4-field expression")
+	}
+}
+
+func TestParseScheduleRejectsOutOfRange(t *testing.T) {
+	if _, err := ParseSchedule("60 * * * *"); err == nil {
+		t.Error("expected error for minute 60")
+	}
+}
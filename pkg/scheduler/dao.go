@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Run records a single execution of a job.
+type Run struct {
+	ID         int64
+	JobName    string
+	StartedAt  time.Time
+	FinishedAt *time.Time
+	Success    bool
+	Error      string
+}
+
+// DAO persists job run history and per-job pause state to MySQL.
+type DAO struct {
+	db *sql.DB
+}
+
+// NewDAO builds a DAO over db.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db}
+}
+
+// RecordRun inserts run and returns it with its assigned ID.
+func (d *DAO) RecordRun(ctx context.Context, run Run) (Run, error) {
+	result, err := d.db.ExecContext(ctx,
+		`INSERT INTO scheduler_job_runs (job_name, started_at, finished_at, success, error) VALUES (?, ?, ?, ?, ?)`,
+		run.JobName, run.StartedAt, run.FinishedAt, run.Success, nullableString(run.Error))
+	if err != nil {
+		return Run{}, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return Run{}, err
+	}
+	run.ID = id
+	return run, nil
+}
+
+// ListRuns returns a job's most recent runs, most recent first, up to
+// limit.
+func (d *DAO) ListRuns(ctx context.Context, jobName string, limit int) ([]Run, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT id, job_name, started_at, finished_at, success, error FROM scheduler_job_runs WHERE job_name = ? ORDER BY started_at DESC LIMIT ?`,
+		jobName, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []Run
+	for rows.Next() {
+		var run Run
+		var finishedAt sql.NullTime
+		var errMsg sql.NullString
+		if err := rows.Scan(&run.ID, &run.JobName, &run.StartedAt, &finishedAt, &run.Success, &errMsg); err != nil {
+			return nil, err
+		}
+		if finishedAt.Valid {
+			run.FinishedAt = &finishedAt.Time
+		}
+		run.Error = errMsg.String
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+// SetPaused upserts jobName's pause state.
+func (d *DAO) SetPaused(ctx context.Context, jobName string, paused bool) error {
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO scheduler_job_state (job_name, paused) VALUES (?, ?)
+		 ON DUPLICATE KEY UPDATE paused = VALUES(paused)`,
+		jobName, paused)
+	return err
+}
+
+// PausedJobs returns the set of job names currently paused.
+func (d *DAO) PausedJobs(ctx context.Context) (map[string]bool, error) {
+	rows, err := d.db.QueryContext(ctx, `SELECT job_name FROM scheduler_job_state WHERE paused = TRUE`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	paused := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		paused[name] = true
+	}
+	return paused, rows.Err()
+}
+
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// releaseScript deletes lockKey only if it still holds the token this
+// process set, so a lock that outlived its TTL and was reacquired by
+// another instance is never torn down out from under it.
+const releaseScript = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`
+
+// lock is a short-lived, single-Redis-node mutual-exclusion lock used
+// to ensure only one instance of a multi-instance service runs a given
+// job in a given scheduling window. It isn't a full Redlock
+// implementation: pkg/redis only wraps a single node today (Sentinel
+// and Cluster support is tracked separately), so a single SET NX PX is
+// the right amount of locking for what's actually deployed.
+type lock struct {
+	client goredis.UniversalClient
+}
+
+func newLock(client goredis.UniversalClient) *lock {
+	return &lock{client: client}
+}
+
+// tryAcquire attempts to claim key for ttl, returning the token to
+// release it with and true on success, or an empty token and false if
+// another instance already holds it.
+func (l *lock) tryAcquire(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	ok, err := l.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return false, err
+	}
+	return ok, nil
+}
+
+// release frees key, but only if it still holds token.
+func (l *lock) release(ctx context.Context, key, token string) error {
+	return l.client.Eval(ctx, releaseScript, []string{key}, token).Err()
+}
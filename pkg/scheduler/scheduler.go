@@ -0,0 +1,246 @@
+// Package scheduler runs named, cron-scheduled background jobs exactly
+// once per scheduling window even when a service is deployed with
+// multiple instances: each job's window is guarded by a short-lived
+// Redis lock, and every run (scheduled or manually triggered) is
+// persisted so an operator can see what ran, when, and whether it
+// succeeded.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// defaultLockTTL bounds how long a job may hold its per-window lock.
+// It's generous relative to how often jobs in this codebase run
+// (hourly purges, minute-scale scans) so a slow run isn't mistaken for
+// a dead instance and double-run, while still releasing promptly if
+// the holder crashes mid-run.
+const defaultLockTTL = 10 * time.Minute
+
+// JobFunc is the work a scheduled job performs on a single run.
+type JobFunc func(ctx context.Context) error
+
+// job is a registered JobFunc together with its schedule and current
+// pause state.
+type job struct {
+	name     string
+	schedule Schedule
+	fn       JobFunc
+}
+
+// JobInfo summarizes a registered job for the admin API.
+type JobInfo struct {
+	Name     string
+	Schedule string
+	Paused   bool
+	LastRun  *Run
+}
+
+// Scheduler evaluates its registered jobs' schedules once a minute and
+// runs whichever are due, each behind a distributed lock keyed to that
+// job and minute so only one instance of the owning service actually
+// executes it.
+type Scheduler struct {
+	dao  *DAO
+	lock *lock
+
+	mu     sync.Mutex
+	jobs   []*job
+	paused map[string]bool
+}
+
+// New builds a Scheduler. redisClient backs the per-job distributed
+// lock.
+func New(dao *DAO, redisClient goredis.UniversalClient) *Scheduler {
+	return &Scheduler{
+		dao:    dao,
+		lock:   newLock(redisClient),
+		paused: make(map[string]bool),
+	}
+}
+
+// Register adds a job under name, due whenever cronExpr matches. It
+// must be called before Run starts; Register is not safe to call
+// concurrently with a running Scheduler.
+func (s *Scheduler) Register(name, cronExpr string, fn JobFunc) error {
+	schedule, err := ParseSchedule(cronExpr)
+	if err != nil {
+		return fmt.Errorf("scheduler: register %q: %w", name, err)
+	}
+	s.jobs = append(s.jobs, &job{name: name, schedule: schedule, fn: fn})
+	return nil
+}
+
+// Run ticks once a minute until ctx is cancelled, executing whichever
+// registered jobs are due and not paused.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.refreshPaused(ctx)
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.refreshPaused(ctx)
+			s.tick(ctx, now.Truncate(time.Minute))
+		}
+	}
+}
+
+// tick runs every due, unpaused job for minute, each in its own
+// goroutine so a slow job doesn't delay the next minute's evaluation.
+func (s *Scheduler) tick(ctx context.Context, minute time.Time) {
+	for _, j := range s.jobs {
+		if !j.schedule.Matches(minute) {
+			continue
+		}
+		if s.isPaused(j.name) {
+			continue
+		}
+		go s.runLocked(ctx, j, minute)
+	}
+}
+
+// runLocked attempts to claim j's lock for window, and if successful,
+// runs it and records the outcome.
+func (s *Scheduler) runLocked(ctx context.Context, j *job, window time.Time) {
+	key := fmt.Sprintf("scheduler:lock:%s:%d", j.name, window.Unix())
+	token := fmt.Sprintf("%d", time.Now().UnixNano())
+	acquired, err := s.lock.tryAcquire(ctx, key, token, defaultLockTTL)
+	if err != nil {
+		log.Printf("scheduler: failed to acquire lock for job %s: %v", j.name, err)
+		return
+	}
+	if !acquired {
+		return // another instance already has this window
+	}
+	defer func() {
+		if err := s.lock.release(ctx, key, token); err != nil {
+			log.Printf("scheduler: failed to release lock for job %s: %v", j.name, err)
+		}
+	}()
+
+	s.execute(ctx, j)
+}
+
+// Trigger runs job name immediately, bypassing its schedule but still
+// behind its distributed lock, so a manual trigger from the admin API
+// can't run concurrently with a scheduled firing of the same job.
+func (s *Scheduler) Trigger(ctx context.Context, name string) error {
+	j := s.find(name)
+	if j == nil {
+		return fmt.Errorf("scheduler: unknown job %q", name)
+	}
+	key := fmt.Sprintf("scheduler:lock:%s:manual:%d", j.name, time.Now().UnixNano())
+	token := "manual"
+	acquired, err := s.lock.tryAcquire(ctx, key, token, defaultLockTTL)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return fmt.Errorf("scheduler: job %q is already running", name)
+	}
+	defer func() {
+		if err := s.lock.release(ctx, key, token); err != nil {
+			log.Printf("scheduler: failed to release lock for job %s: %v", j.name, err)
+		}
+	}()
+
+	s.execute(ctx, j)
+	return nil
+}
+
+// execute runs j once and persists the outcome.
+func (s *Scheduler) execute(ctx context.Context, j *job) {
+	started := time.Now().UTC()
+	err := j.fn(ctx)
+	finished := time.Now().UTC()
+
+	errMsg := ""
+	if err != nil {
+		errMsg = err.Error()
+		log.Printf("scheduler: job %s failed: %v", j.name, err)
+	}
+	if _, recErr := s.dao.RecordRun(ctx, Run{
+		JobName:    j.name,
+		StartedAt:  started,
+		FinishedAt: &finished,
+		Success:    err == nil,
+		Error:      errMsg,
+	}); recErr != nil {
+		log.Printf("scheduler: failed to record run history for job %s: %v", j.name, recErr)
+	}
+}
+
+// Pause sets name's pause state, persisted so it survives a restart
+// and is shared across every instance.
+func (s *Scheduler) Pause(ctx context.Context, name string, paused bool) error {
+	if s.find(name) == nil {
+		return fmt.Errorf("scheduler: unknown job %q", name)
+	}
+	if err := s.dao.SetPaused(ctx, name, paused); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.paused[name] = paused
+	s.mu.Unlock()
+	return nil
+}
+
+// List returns every registered job's schedule, pause state, and most
+// recent run.
+func (s *Scheduler) List(ctx context.Context) ([]JobInfo, error) {
+	infos := make([]JobInfo, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		runs, err := s.dao.ListRuns(ctx, j.name, 1)
+		if err != nil {
+			return nil, err
+		}
+		var last *Run
+		if len(runs) > 0 {
+			last = &runs[0]
+		}
+		infos = append(infos, JobInfo{
+			Name:     j.name,
+			Schedule: j.schedule.String(),
+			Paused:   s.isPaused(j.name),
+			LastRun:  last,
+		})
+	}
+	return infos, nil
+}
+
+func (s *Scheduler) find(name string) *job {
+	for _, j := range s.jobs {
+		if j.name == name {
+			return j
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) isPaused(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.paused[name]
+}
+
+func (s *Scheduler) refreshPaused(ctx context.Context) {
+	paused, err := s.dao.PausedJobs(ctx)
+	if err != nil {
+		log.Printf("scheduler: failed to refresh paused job state: %v", err)
+		return
+	}
+	s.mu.Lock()
+	s.paused = paused
+	s.mu.Unlock()
+}
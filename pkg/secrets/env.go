@@ -0,0 +1,24 @@
+package secrets
+
+import (
+	"context"
+	"os"
+)
+
+// EnvProvider resolves secrets from environment variables, matching how
+// every service reads its credentials today. It's always the last link
+// in NewDefaultResolver's chain, so existing deployments keep working
+// unchanged.
+type EnvProvider struct{}
+
+// NewEnvProvider builds an EnvProvider.
+func NewEnvProvider() EnvProvider { return EnvProvider{} }
+
+// Get returns the value of the environment variable named key.
+func (EnvProvider) Get(_ context.Context, key string) (string, error) {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
@@ -0,0 +1,35 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileProvider resolves secrets from files mounted under a directory,
+// one file per key, matching the layout Kubernetes and Docker secrets
+// mounts use (dir/<key>, file contents are the raw secret value).
+type FileProvider struct {
+	dir string
+}
+
+// NewFileProvider builds a FileProvider rooted at dir.
+func NewFileProvider(dir string) FileProvider {
+	return FileProvider{dir: dir}
+}
+
+// Get reads dir/key and returns its contents with surrounding
+// whitespace trimmed, since mounted secret files are commonly written
+// with a trailing newline.
+func (f FileProvider) Get(_ context.Context, key string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(f.dir, key))
+	if os.IsNotExist(err) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("secrets: read %s: %w", key, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,73 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Resolver tries a list of providers in order and returns the first
+// value found. This lets a deployment layer secrets sources — Vault,
+// then file-mounted fallbacks, then plain environment variables for
+// local development — without any call site needing to know which one
+// actually served the value.
+type Resolver struct {
+	providers []Provider
+}
+
+// NewResolver builds a Resolver that tries providers in the given
+// order.
+func NewResolver(providers ...Provider) *Resolver {
+	return &Resolver{providers: providers}
+}
+
+// NewDefaultResolver builds the Resolver every service main uses: Vault
+// first if VAULT_ADDR is set, then file-mounted secrets if SECRETS_DIR
+// is set, then environment variables last, so deployments that have
+// configured neither keep resolving credentials exactly as before.
+func NewDefaultResolver() *Resolver {
+	var providers []Provider
+
+	if addr := os.Getenv("VAULT_ADDR"); addr != "" {
+		mount := os.Getenv("VAULT_MOUNT")
+		if mount == "" {
+			mount = "secret"
+		}
+		providers = append(providers, NewVaultProvider(addr, os.Getenv("VAULT_TOKEN"), mount))
+	}
+	if dir := os.Getenv("SECRETS_DIR"); dir != "" {
+		providers = append(providers, NewFileProvider(dir))
+	}
+	providers = append(providers, NewEnvProvider())
+
+	return NewResolver(providers...)
+}
+
+// Get resolves key by asking each provider in turn, returning the first
+// value found. It returns an error wrapping ErrNotFound if no provider
+// has a value for key.
+func (r *Resolver) Get(ctx context.Context, key string) (string, error) {
+	for _, p := range r.providers {
+		v, err := p.Get(ctx, key)
+		if err == nil {
+			return v, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrNotFound, key)
+}
+
+// MustGet resolves key like Get, but returns fallback instead of an
+// error when no provider has a value — for settings like DSNs where
+// tests and local development rely on an empty-string default rather
+// than a hard failure at startup.
+func (r *Resolver) MustGet(ctx context.Context, key, fallback string) string {
+	v, err := r.Get(ctx, key)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
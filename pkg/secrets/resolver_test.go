@@ -0,0 +1,87 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type stubProvider map[string]string
+
+func (s stubProvider) Get(_ context.Context, key string) (string, error) {
+	v, ok := s[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+func TestResolverFallsThroughToNextProvider(t *testing.T) {
+	r := NewResolver(stubProvider{}, stubProvider{"DSN": "from-second"})
+
+	v, err := r.Get(context.Background(), "DSN")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if v != "from-second" {
+		t.Errorf("Get() = %q, want %q", v, "from-second")
+	}
+}
+
+func TestResolverGetReturnsNotFound(t *testing.T) {
+	r := NewResolver(stubProvider{})
+
+	_, err := r.Get(context.Background(), "MISSING")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestResolverMustGetReturnsFallback(t *testing.T) {
+	r := NewResolver(stubProvider{})
+
+	v := r.MustGet(context.Background(), "MISSING", "fallback")
+	if v != "fallback" {
+		t.Errorf("MustGet() = %q, want %q", v, "fallback")
+	}
+}
+
+func TestFileProviderReadsAndTrimsValue(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "MYSQL_DSN"), []byte("user:pass@tcp(db:3306)/app\n"), 0o600); err != nil {
+		t.Fatalf("write secret file: %v", err)
+	}
+
+	p := NewFileProvider(dir)
+	v, err := p.Get(context.Background(), "MYSQL_DSN")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if v != "user:pass@tcp(db:3306)/app" {
+		t.Errorf("Get() = %q, want trimmed DSN", v)
+	}
+}
+
+func TestFileProviderMissingFileIsNotFound(t *testing.T) {
+	p := NewFileProvider(t.TempDir())
+
+	_, err := p.Get(context.Background(), "MISSING")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestEnvProviderReadsEnvVar(t *testing.T) {
+	t.Setenv("SECRETS_TEST_KEY", "env-value")
+
+	p := NewEnvProvider()
+	v, err := p.Get(context.Background(), "SECRETS_TEST_KEY")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if v != "env-value" {
+		t.Errorf("Get() = %q, want %q", v, "env-value")
+	}
+}
@@ -0,0 +1,19 @@
+// Package secrets resolves credentials — DSNs, Redis passwords, JWT
+// signing keys — from pluggable backends, so a value can move from a
+// plain environment variable in development to a file-mounted secret or
+// Vault in production without any call site changing.
+package secrets
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by a Provider when it has no value for a key,
+// letting a Resolver fall through to the next provider in its chain.
+var ErrNotFound = errors.New("secrets: not found")
+
+// Provider resolves a single named secret.
+type Provider interface {
+	Get(ctx context.Context, key string) (string, error)
+}
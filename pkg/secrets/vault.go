@@ -0,0 +1,74 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// vaultTimeout bounds how long a single Vault lookup may take, so a
+// slow or unreachable Vault server can't hang service startup.
+const vaultTimeout = 5 * time.Second
+
+// VaultProvider resolves secrets from a HashiCorp Vault KV v2 mount
+// over its HTTP API. It's deliberately dependency-free: the platform
+// only needs to read a handful of string values, not the full Vault
+// client SDK.
+type VaultProvider struct {
+	addr   string
+	token  string
+	mount  string
+	client *http.Client
+}
+
+// NewVaultProvider builds a VaultProvider against a Vault server at
+// addr, authenticating with token and reading secrets from the KV v2
+// mount named mount (e.g. "secret").
+func NewVaultProvider(addr, token, mount string) VaultProvider {
+	return VaultProvider{addr: addr, token: token, mount: mount, client: &http.Client{Timeout: vaultTimeout}}
+}
+
+// vaultKVv2Response is the envelope Vault wraps a KV v2 read in. Each
+// secret is expected to store its value under a field named "value",
+// keeping the calling convention identical across every Provider: one
+// key in, one string out.
+type vaultKVv2Response struct {
+	Data struct {
+		Data map[string]string `json:"data"`
+	} `json:"data"`
+}
+
+// Get fetches data/<key> from the KV v2 mount.
+func (v VaultProvider) Get(ctx context.Context, key string) (string, error) {
+	url := fmt.Sprintf("%s/v1/%s/data/%s", v.addr, v.mount, key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("secrets: vault request for %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("secrets: vault returned %s for %s", resp.Status, key)
+	}
+
+	var body vaultKVv2Response
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("secrets: decode vault response for %s: %w", key, err)
+	}
+	value, ok := body.Data.Data["value"]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
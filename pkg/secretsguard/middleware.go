@@ -0,0 +1,142 @@
+// Package secretsguard is a last-line-of-defense response filter that
+// scans outgoing admin/user API JSON for accidentally-leaked sensitive
+// fields and strips them before they reach the client.
+package secretsguard
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// sensitiveFields are field names that should never appear in a JSON
+// response body. Matching is case-insensitive and recursive through
+// nested objects and arrays.
+var sensitiveFields = map[string]bool{
+	"password":            true,
+	"password_hash":       true,
+	"key":                 true,
+	"api_key":             true,
+	"key_hash":            true,
+	"upstream_username":   true,
+	"upstream_password":   true,
+	"upstream_credential": true,
+	"client_secret":       true,
+	"totp_secret":         true,
+}
+
+// AllowPath marks a path (typically a key-creation endpoint) as allowed
+// to return a field that would otherwise be stripped, such as the one
+// and only time a raw API key is shown in full.
+type AllowPath func(path string, field string) bool
+
+// SkipPath marks a path as exempt from secretsguard entirely. It exists
+// for handlers that stream their response (e.g. SSE) rather than
+// returning it in one shot: buffering the body the way this middleware
+// does would both break the handler's http.Flusher type assertion and
+// hold the whole stream in memory until the client disconnects.
+type SkipPath func(path string) bool
+
+// Middleware buffers the JSON response body, strips any sensitive field
+// not explicitly allowed for this path, and logs a high-severity warning
+// whenever it has to redact something, since that indicates a bug
+// upstream rather than expected behavior. Paths matched by skip are
+// passed through untouched.
+func Middleware(allow AllowPath, skip SkipPath) func(http.Handler) http.Handler {
+	if allow == nil {
+		allow = func(string, string) bool { return false }
+	}
+	if skip == nil {
+		skip = func(string) bool { return false }
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if skip(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			buf := &bufferingWriter{ResponseWriter: w, body: &bytes.Buffer{}, status: http.StatusOK}
+			next.ServeHTTP(buf, r)
+
+			contentType := buf.Header().Get("Content-Type")
+			if !isJSON(contentType) || buf.body.Len() == 0 {
+				w.WriteHeader(buf.status)
+				w.Write(buf.body.Bytes())
+				return
+			}
+
+			var payload interface{}
+			if err := json.Unmarshal(buf.body.Bytes(), &payload); err != nil {
+				// Not valid JSON despite the content type; pass through
+				// unmodified rather than risk corrupting the response.
+				w.WriteHeader(buf.status)
+				w.Write(buf.body.Bytes())
+				return
+			}
+
+			redacted := 0
+			sanitized := scrub(r.URL.Path, payload, allow, &redacted)
+			if redacted > 0 {
+				log.Printf("secretsguard: SEVERITY=high redacted %d sensitive field(s) from response for %s", redacted, r.URL.Path)
+			}
+
+			out, err := json.Marshal(sanitized)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.WriteHeader(buf.status)
+			w.Write(out)
+		})
+	}
+}
+
+func isJSON(contentType string) bool {
+	return len(contentType) >= 16 && contentType[:16] == "application/json"
+}
+
+func scrub(path string, v interface{}, allow AllowPath, redacted *int) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, child := range val {
+			if sensitiveFields[lower(k)] && !allow(path, k) {
+				*redacted++
+				continue
+			}
+			out[k] = scrub(path, child, allow, redacted)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, child := range val {
+			out[i] = scrub(path, child, allow, redacted)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func lower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + ('a' - 'A')
+		}
+	}
+	return string(b)
+}
+
+type bufferingWriter struct {
+	http.ResponseWriter
+	body   *bytes.Buffer
+	status int
+}
+
+func (b *bufferingWriter) WriteHeader(status int) { b.status = status }
+
+func (b *bufferingWriter) Write(p []byte) (int, error) { return b.body.Write(p) }
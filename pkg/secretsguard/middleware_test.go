@@ -0,0 +1,83 @@
+package secretsguard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewareRedactsSensitiveFields(t *testing.T) {
+	handler := Middleware(nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"u1","password_hash":"$2a$...","profile":{"key_hash":"abc"}}`))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/u1", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	if strings.Contains(body, "password_hash") || strings.Contains(body, "key_hash") {
+		t.Fatalf("expected sensitive fields to be stripped, got: %s", body)
+	}
+	if !strings.Contains(body, `"id":"u1"`) {
+		t.Fatalf("expected non-sensitive fields preserved, got: %s", body)
+	}
+}
+
+func TestMiddlewareRedactsAPIKeyFields(t *testing.T) {
+	handler := Middleware(nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"k1","key":"sk_live_abc","api_key":"sk_live_def"}`))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/keys/k1", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	if strings.Contains(body, "sk_live_abc") || strings.Contains(body, "sk_live_def") {
+		t.Fatalf("expected key/api_key fields to be stripped, got: %s", body)
+	}
+	if !strings.Contains(body, `"id":"k1"`) {
+		t.Fatalf("expected non-sensitive fields preserved, got: %s", body)
+	}
+}
+
+func TestMiddlewareAllowsExplicitlyPermittedField(t *testing.T) {
+	allow := func(path, field string) bool {
+		return path == "/v1/keys" && field == "client_secret"
+	}
+	handler := Middleware(allow, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"client_secret":"sk_live_once"}`))
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/keys", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if !strings.Contains(rec.Body.String(), "sk_live_once") {
+		t.Fatalf("expected allowed field to pass through, got: %s", rec.Body.String())
+	}
+}
+
+func TestMiddlewareSkipsStreamingPaths(t *testing.T) {
+	skip := func(path string) bool { return path == "/v1/stats/stream" }
+	handler := Middleware(nil, skip)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := w.(http.Flusher); !ok {
+			t.Fatal("expected the skipped handler to see the underlying ResponseWriter, not the buffering wrapper")
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(`{"password":"leaked-but-irrelevant-here"}`))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/stats/stream", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if !strings.Contains(rec.Body.String(), "leaked-but-irrelevant-here") {
+		t.Fatalf("expected a skipped path's body to pass through untouched, got: %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+)
+
+// HTTPCloser adapts (*http.Server).Shutdown to the func(context.Context)
+// error signature OnShutdown expects.
+func HTTPCloser(s *http.Server) func(context.Context) error {
+	return s.Shutdown
+}
+
+// GRPCCloser gracefully stops s, falling back to an immediate Stop if
+// the context is done before the drain finishes; grpc.Server.GracefulStop
+// doesn't take a context itself, so this races it against one.
+func GRPCCloser(s *grpc.Server) func(context.Context) error {
+	return func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			s.GracefulStop()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			s.Stop()
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRunnerRunsClosersInReverseOrder(t *testing.T) {
+	ctx, stop := context.WithCancel(context.Background())
+	r := newRunner("test", ctx, stop)
+
+	var order []string
+	r.OnShutdown("first", func(context.Context) error {
+		order = append(order, "first")
+		return nil
+	})
+	r.OnShutdown("second", func(context.Context) error {
+		order = append(order, "second")
+		return nil
+	})
+
+	stop()
+	r.Wait()
+
+	want := []string{"second", "first"}
+	if len(order) != len(want) || order[0] != want[0] || order[1] != want[1] {
+		t.Errorf("closer order = %v, want %v", order, want)
+	}
+}
+
+func TestRunnerReadyFlipsOnShutdown(t *testing.T) {
+	ctx, stop := context.WithCancel(context.Background())
+	r := newRunner("test", ctx, stop)
+
+	if !r.Ready() {
+		t.Fatal("Ready() = false before shutdown, want true")
+	}
+
+	rec := httptest.NewRecorder()
+	r.ReadyHandler()(rec, httptest.NewRequest("GET", "/readyz", nil))
+	if rec.Code != 200 {
+		t.Errorf("ReadyHandler before shutdown = %d, want 200", rec.Code)
+	}
+
+	stop()
+	r.Wait()
+
+	if r.Ready() {
+		t.Fatal("Ready() = true after shutdown, want false")
+	}
+
+	rec = httptest.NewRecorder()
+	r.ReadyHandler()(rec, httptest.NewRequest("GET", "/readyz", nil))
+	if rec.Code != 503 {
+		t.Errorf("ReadyHandler after shutdown = %d, want 503", rec.Code)
+	}
+}
+
+func TestReadyHandlerReportsDependencyStatuses(t *testing.T) {
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	r := newRunner("test", ctx, stop)
+
+	r.RegisterDependency("mysql", func(context.Context) error { return nil })
+	r.RegisterDependency("redis", func(context.Context) error { return errors.New("connection refused") })
+
+	rec := httptest.NewRecorder()
+	r.ReadyHandler()(rec, httptest.NewRequest("GET", "/readyz", nil))
+
+	if rec.Code != 503 {
+		t.Errorf("ReadyHandler with a failing dependency = %d, want 503", rec.Code)
+	}
+
+	var report ReadinessReport
+	if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if report.Ready {
+		t.Error("report.Ready = true, want false")
+	}
+	if len(report.Dependencies) != 2 {
+		t.Fatalf("len(Dependencies) = %d, want 2", len(report.Dependencies))
+	}
+	if report.Dependencies[0].Name != "mysql" || report.Dependencies[0].Status != "ok" {
+		t.Errorf("Dependencies[0] = %+v, want mysql/ok", report.Dependencies[0])
+	}
+	if report.Dependencies[1].Name != "redis" || report.Dependencies[1].Status != "error" {
+		t.Errorf("Dependencies[1] = %+v, want redis/error", report.Dependencies[1])
+	}
+}
+
+func TestRunnerWaitsForBackgroundGoroutines(t *testing.T) {
+	ctx, stop := context.WithCancel(context.Background())
+	r := newRunner("test", ctx, stop)
+
+	done := false
+	r.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		done = true
+	})
+
+	stop()
+	r.Wait()
+
+	if !done {
+		t.Error("Wait() returned before background goroutine finished")
+	}
+}
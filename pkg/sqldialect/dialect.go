@@ -0,0 +1,89 @@
+// Package sqldialect is the seam between this codebase's DAOs/migrations
+// and a second SQL backend. Every DAO in this repo writes MySQL-flavoured
+// SQL directly: "?" placeholders, INSERT ... ON DUPLICATE KEY UPDATE
+// upserts, AUTO_INCREMENT columns, and inline INDEX clauses in CREATE
+// TABLE. None of that is GORM, and this package does not add GORM —
+// there is no such dependency vendored in this module, and adding one
+// would mean fetching a new package this sandbox has no network access
+// to verify. What this package does provide is the one piece of dialect
+// translation that's both mechanical and dialect-agnostic: placeholder
+// syntax. Postgres drivers (lib/pq, pgx) expect "$1", "$2", ... instead
+// of "?", and every one of this repo's hand-written queries can be
+// translated by Rewrite without touching call sites.
+//
+// Getting the rest of the way to real Postgres support — picking a
+// driver, replacing every "ON DUPLICATE KEY UPDATE ... VALUES(col)"
+// upsert with "ON CONFLICT (...) DO UPDATE SET col = EXCLUDED.col" (the
+// conflict target isn't recoverable from the MySQL form, so each of the
+// ten call sites needs a human to name it), and switching AUTO_INCREMENT
+// primary keys to SERIAL/IDENTITY in the migrations — is real work this
+// package intentionally leaves undone rather than faking.
+package sqldialect
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect identifies which SQL backend a query is being prepared for.
+type Dialect string
+
+const (
+	// MySQL is this platform's only currently-supported backend.
+	MySQL Dialect = "mysql"
+	// Postgres is accepted by Parse so callers can fail fast and
+	// clearly on an unimplemented backend instead of silently running
+	// MySQL-flavoured SQL against a Postgres connection.
+	Postgres Dialect = "postgres"
+)
+
+// Parse parses a DB_DRIVER-style config value. It is case-insensitive
+// and defaults to MySQL on an empty string, so existing deployments that
+// don't set the var at all keep working unchanged.
+func Parse(s string) (Dialect, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "mysql":
+		return MySQL, nil
+	case "postgres", "postgresql":
+		return Postgres, nil
+	default:
+		return "", fmt.Errorf("sqldialect: unsupported driver %q (supported: mysql, postgres)", s)
+	}
+}
+
+// Rewrite translates a query written with MySQL's "?" placeholders into
+// d's placeholder syntax. MySQL queries are returned unchanged. A "?"
+// inside a single-quoted string literal (with ” as the escape for a
+// literal quote, same as MySQL) is left alone rather than renumbered,
+// since it isn't a parameter.
+func (d Dialect) Rewrite(query string) string {
+	if d != Postgres || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	inString := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'':
+			inString = !inString
+			b.WriteByte(c)
+		case c == '?' && !inString:
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// String implements fmt.Stringer.
+func (d Dialect) String() string {
+	return string(d)
+}
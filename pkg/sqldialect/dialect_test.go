@@ -0,0 +1,60 @@
+package sqldialect
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	cases := map[string]Dialect{
+		"":           MySQL,
+		"mysql":      MySQL,
+		"MySQL":      MySQL,
+		"postgres":   Postgres,
+		"PostgreSQL": Postgres,
+		" postgres ": Postgres,
+	}
+	for in, want := range cases {
+		got, err := Parse(in)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", in, err)
+		}
+		if got != want {
+			t.Errorf("Parse(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseRejectsUnknownDriver(t *testing.T) {
+	if _, err := Parse("sqlite"); err == nil {
+		t.Error("Parse(\"sqlite\") should return an error")
+	}
+}
+
+func TestRewriteLeavesMySQLUnchanged(t *testing.T) {
+	query := "SELECT * FROM proxies WHERE id = ? AND status = ?"
+	if got := MySQL.Rewrite(query); got != query {
+		t.Errorf("MySQL.Rewrite() = %q, want unchanged %q", got, query)
+	}
+}
+
+func TestRewriteNumbersPostgresPlaceholders(t *testing.T) {
+	got := Postgres.Rewrite("SELECT * FROM proxies WHERE id = ? AND status = ?")
+	want := "SELECT * FROM proxies WHERE id = $1 AND status = $2"
+	if got != want {
+		t.Errorf("Postgres.Rewrite() = %q, want %q", got, want)
+	}
+}
+
+func TestRewriteSkipsPlaceholdersInsideStringLiterals(t *testing.T) {
+	got := Postgres.Rewrite("SELECT * FROM notes WHERE body LIKE '%?%' AND id = ?")
+	want := "SELECT * FROM notes WHERE body LIKE '%?%' AND id = $1"
+	if got != want {
+		t.Errorf("Postgres.Rewrite() = %q, want %q", got, want)
+	}
+}
+
+func TestRewriteHandlesEscapedQuoteInStringLiteral(t *testing.T) {
+	got := Postgres.Rewrite("SELECT * FROM notes WHERE body = 'it''s a ? test' AND id = ?")
+	want := "SELECT * FROM notes WHERE body = 'it''s a ? test' AND id = $1"
+	if got != want {
+		t.Errorf("Postgres.Rewrite() = %q, want %q", got, want)
+	}
+}
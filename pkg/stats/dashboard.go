@@ -0,0 +1,159 @@
+// Package stats assembles the admin dashboard's aggregate view of the
+// platform: user counts, proxy inventory, traffic trends and
+// health-check trends, each read from the DAO that already owns that
+// data rather than querying raw tables directly.
+package stats
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+const defaultTopTargetHosts = 10
+
+// Dashboard is the admin dashboard's aggregate view over a time range.
+type Dashboard struct {
+	RangeStart time.Time
+	RangeEnd   time.Time
+
+	TotalUsers  int64
+	ActiveUsers int64
+
+	ActiveProxiesBySource  map[string]int64
+	ActiveProxiesByCountry map[string]int64
+
+	// ProviderOverlap reports, per provider, what fraction of its active
+	// inventory was flagged by the dedup worker as sharing an exit IP
+	// with another provider, i.e. likely the same reseller capacity sold
+	// twice. Providers with no active proxies are omitted.
+	ProviderOverlap []ProviderOverlap
+
+	Traffic         []usage.TimeBucketStats
+	TotalRequests   int64
+	TotalErrors     int64
+	ErrorRate       float64
+	TopTargetHosts  []usage.TargetHostCount
+	HealthCheckDays []dao.DailySuccessRate
+}
+
+// ProviderOverlap is one provider's share of cross-provider exit-IP
+// duplicates, as found by services/proxy-pool/internal/dedup.
+type ProviderOverlap struct {
+	Provider         string
+	TotalProxies     int64
+	DuplicateProxies int64
+	OverlapPct       float64
+}
+
+// Service assembles Dashboard from the platform's existing DAOs.
+type Service struct {
+	users        *user.DAO
+	proxies      *dao.ProxyDAO
+	usage        *usage.DAO
+	rollups      *usage.RollupDAO
+	healthChecks *dao.ProxyHealthCheckDAO
+}
+
+// New builds a Service from its dependencies.
+func New(users *user.DAO, proxies *dao.ProxyDAO, usageDAO *usage.DAO, rollups *usage.RollupDAO, healthChecks *dao.ProxyHealthCheckDAO) *Service {
+	return &Service{users: users, proxies: proxies, usage: usageDAO, rollups: rollups, healthChecks: healthChecks}
+}
+
+// Dashboard assembles the dashboard view for [start, end).
+func (s *Service) Dashboard(ctx context.Context, start, end time.Time) (*Dashboard, error) {
+	d := &Dashboard{RangeStart: start, RangeEnd: end}
+
+	userCounts, err := s.users.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for status, count := range userCounts {
+		d.TotalUsers += count
+		if status == user.StatusActive {
+			d.ActiveUsers = count
+		}
+	}
+
+	bySource, err := s.proxies.CountActiveBySource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.ActiveProxiesBySource = make(map[string]int64, len(bySource))
+	for source, count := range bySource {
+		d.ActiveProxiesBySource[string(source)] = count
+	}
+
+	d.ActiveProxiesByCountry, err = s.proxies.CountActiveByCountry(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byProvider, err := s.proxies.CountActiveByProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exitIPGroups, err := s.proxies.GroupByExitIP(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.ProviderOverlap = providerOverlap(byProvider, exitIPGroups)
+
+	d.Traffic, err = s.rollups.PlatformHourly(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, bucket := range d.Traffic {
+		d.TotalRequests += bucket.Stats.RequestCount
+		d.TotalErrors += bucket.Stats.ErrorCount
+	}
+	if d.TotalRequests > 0 {
+		d.ErrorRate = float64(d.TotalErrors) / float64(d.TotalRequests)
+	}
+
+	d.TopTargetHosts, err = s.usage.TopTargetHosts(ctx, start, end, defaultTopTargetHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	d.HealthCheckDays, err = s.healthChecks.DailySuccessRateTrend(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// providerOverlap turns each provider's active proxy count and the
+// cross-provider exit-IP groups the dedup worker last computed into a
+// per-provider overlap percentage: every proxy that's a member of a
+// group (canonical or not) shares its exit with at least one other
+// provider, so it counts against that provider's total.
+func providerOverlap(totals map[string]int64, groups []dao.ExitIPGroup) []ProviderOverlap {
+	duplicated := make(map[string]int64, len(totals))
+	for _, group := range groups {
+		for _, m := range group.Members {
+			duplicated[m.Provider]++
+		}
+	}
+
+	out := make([]ProviderOverlap, 0, len(totals))
+	for provider, total := range totals {
+		if provider == "" || total == 0 {
+			continue
+		}
+		dup := duplicated[provider]
+		out = append(out, ProviderOverlap{
+			Provider:         provider,
+			TotalProxies:     total,
+			DuplicateProxies: dup,
+			OverlapPct:       float64(dup) / float64(total),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Provider < out[j].Provider })
+	return out
+}
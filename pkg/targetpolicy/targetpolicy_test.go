@@ -0,0 +1,74 @@
+package targetpolicy
+
+import "testing"
+
+func TestDecide(t *testing.T) {
+	cases := []struct {
+		name    string
+		entries []*Entry
+		host    string
+		want    Decision
+	}{
+		{
+			name: "no policy allows everything",
+			host: "example.com",
+			want: Decision{Allowed: true},
+		},
+		{
+			name: "deny wins even when also allowed",
+			entries: []*Entry{
+				{Mode: ModeAllow, Pattern: "*.example.com"},
+				{Mode: ModeDeny, Pattern: "ads.example.com", Reason: "malware category"},
+			},
+			host: "ads.example.com",
+			want: Decision{Reason: "malware category"},
+		},
+		{
+			name:    "deny without a reason falls back to a default message",
+			entries: []*Entry{{Mode: ModeDeny, Pattern: "*.example.com"}},
+			host:    "www.example.com",
+			want:    Decision{Reason: "target domain is blocked by this key's policy"},
+		},
+		{
+			name:    "allowlist permits a matching host",
+			entries: []*Entry{{Mode: ModeAllow, Pattern: "*.example.com"}},
+			host:    "www.example.com",
+			want:    Decision{Allowed: true},
+		},
+		{
+			name:    "allowlist rejects a non-matching host",
+			entries: []*Entry{{Mode: ModeAllow, Pattern: "*.example.com"}},
+			host:    "other.com",
+			want:    Decision{Reason: "target domain is not in this key's allowlist"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := Decide(c.entries, c.host)
+			if got != c.want {
+				t.Errorf("Decide() = %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestMatchesPattern(t *testing.T) {
+	cases := []struct {
+		pattern, host string
+		want          bool
+	}{
+		{"*.amazon.com", "www.amazon.com", true},
+		{"*.amazon.com", "amazon.com", true},
+		{"*.amazon.com", "notamazon.com", false},
+		{"example.com", "example.com", true},
+		{"example.com", "www.example.com", false},
+		{"Example.com", "EXAMPLE.COM", true},
+	}
+
+	for _, c := range cases {
+		if got := matchesPattern(c.pattern, c.host); got != c.want {
+			t.Errorf("matchesPattern(%q, %q) = %v, want %v", c.pattern, c.host, got, c.want)
+		}
+	}
+}
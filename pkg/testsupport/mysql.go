@@ -0,0 +1,98 @@
+// Package testsupport starts ephemeral MySQL and Redis instances for
+// integration tests via testcontainers-go, applying this repo's own
+// migrations to the MySQL instance so a test runs against the real
+// schema instead of a hand-maintained fixture. Set TEST_MYSQL_DSN or
+// TEST_REDIS_ADDR to point at an already-running instance instead — a
+// developer's local MySQL/Redis, or a CI service container — and
+// container startup is skipped entirely, since testcontainers-go needs
+// a Docker (or Docker-compatible) daemon that isn't available in every
+// environment this repo's tests run in.
+//
+// Every exported function here calls testing.T.Fatal on failure and
+// registers its own teardown via t.Cleanup, matching how this repo's
+// DAO tests would have called a getTestDB/setupTestRedis helper had
+// one existed before this package.
+package testsupport
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/testcontainers/testcontainers-go"
+	tcmysql "github.com/testcontainers/testcontainers-go/modules/mysql"
+
+	"github.com/XXXXD-cation/proxy-platform/migrations"
+)
+
+// MySQLDSNEnv, when set, is used as the test MySQL DSN directly instead
+// of starting a container.
+const MySQLDSNEnv = "TEST_MYSQL_DSN"
+
+// GetTestDB returns a *sql.DB with every migration in the migrations
+// package applied, against TEST_MYSQL_DSN if set, or else a MySQL
+// container started for the duration of t. Both the database handle
+// and (if one was started) the container are torn down via t.Cleanup.
+func GetTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv(MySQLDSNEnv)
+	if dsn == "" {
+		dsn = startMySQLContainer(t)
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("testsupport: open mysql connection: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := waitForPing(db); err != nil {
+		t.Fatalf("testsupport: mysql never became ready: %v", err)
+	}
+	if err := migrate.Run(context.Background(), db, migrate.FS); err != nil {
+		t.Fatalf("testsupport: apply migrations: %v", err)
+	}
+	return db
+}
+
+func startMySQLContainer(t *testing.T) string {
+	t.Helper()
+	ctx := context.Background()
+
+	container, err := tcmysql.RunContainer(ctx,
+		testcontainers.WithImage("mysql:8.0"),
+		tcmysql.WithDatabase("proxy_platform_test"),
+		tcmysql.WithUsername("root"),
+		tcmysql.WithPassword("test"),
+	)
+	if err != nil {
+		t.Fatalf("testsupport: start mysql container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(context.Background()); err != nil {
+			t.Logf("testsupport: terminate mysql container: %v", err)
+		}
+	})
+
+	dsn, err := container.ConnectionString(ctx, "parseTime=true")
+	if err != nil {
+		t.Fatalf("testsupport: build mysql connection string: %v", err)
+	}
+	return dsn
+}
+
+func waitForPing(db *sql.DB) error {
+	deadline := time.Now().Add(30 * time.Second)
+	var err error
+	for time.Now().Before(deadline) {
+		if err = db.Ping(); err == nil {
+			return nil
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package testsupport
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/testcontainers/testcontainers-go"
+	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+// RedisAddrEnv, when set, is used as the test Redis address directly
+// instead of starting a container.
+const RedisAddrEnv = "TEST_REDIS_ADDR"
+
+// GetTestRedis returns a client against TEST_REDIS_ADDR if set, or
+// else a Redis container started for the duration of t. Both the
+// client and (if one was started) the container are torn down via
+// t.Cleanup.
+func GetTestRedis(t *testing.T) *goredis.Client {
+	t.Helper()
+
+	addr := os.Getenv(RedisAddrEnv)
+	if addr == "" {
+		addr = startRedisContainer(t)
+	}
+
+	client := goredis.NewClient(&goredis.Options{Addr: addr})
+	t.Cleanup(func() { client.Close() })
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Fatalf("testsupport: redis never became ready: %v", err)
+	}
+	return client
+}
+
+func startRedisContainer(t *testing.T) string {
+	t.Helper()
+	ctx := context.Background()
+
+	container, err := tcredis.RunContainer(ctx, testcontainers.WithImage("redis:7"))
+	if err != nil {
+		t.Fatalf("testsupport: start redis container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(context.Background()); err != nil {
+			t.Logf("testsupport: terminate redis container: %v", err)
+		}
+	})
+
+	addr, err := container.Endpoint(ctx, "")
+	if err != nil {
+		t.Fatalf("testsupport: redis endpoint: %v", err)
+	}
+	return addr
+}
@@ -0,0 +1,23 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+// DBSpan starts a span named "db.<operation>" around a database/sql
+// call. There's no ORM in this codebase to hook into, so call sites on a
+// traced request path (proxy selection today) wrap their DAO calls with
+// this instead of a framework-level hook. end must be called with the
+// call's error, if any, once the query returns.
+func DBSpan(ctx context.Context, operation string) (spanCtx context.Context, end func(error)) {
+	spanCtx, span := Tracer("db").Start(ctx, "db."+operation)
+	return spanCtx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,20 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+// DialOption propagates the caller's trace context over an internal gRPC
+// call, so a proxy-pool or api span nests under the gateway request that
+// triggered it. Pass it to grpc.DialContext alongside pkg/rpcclient's
+// other dial options.
+func DialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(otelgrpc.NewClientHandler())
+}
+
+// ServerOption extracts an incoming trace context and starts a span for
+// each RPC a service handles. Pass it to grpc.NewServer.
+func ServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler())
+}
@@ -0,0 +1,15 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Middleware wraps next so every request starts a span named operation,
+// continuing the trace a client propagated via standard W3C trace
+// context headers if present. It composes with the rest of the repo's
+// func(http.Handler) http.Handler middleware, e.g. pkg/middleware.Auth.
+func Middleware(operation string, next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, operation)
+}
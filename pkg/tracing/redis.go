@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"context"
+	"net"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// redisHook traces every command (and pipeline) issued through a client
+// it's installed on, so a hot-pool ZADD or session pin shows up as a
+// child span of whatever request triggered it.
+type redisHook struct{}
+
+// NewRedisHook returns a go-redis v9 Hook that traces commands. Install
+// it once per client with client.AddHook(tracing.NewRedisHook()); every
+// service constructs its Redis client through pkg/redis.NewClient, which
+// does this for all of them.
+func NewRedisHook() goredis.Hook {
+	return redisHook{}
+}
+
+func (redisHook) DialHook(next goredis.DialHook) goredis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (redisHook) ProcessHook(next goredis.ProcessHook) goredis.ProcessHook {
+	return func(ctx context.Context, cmd goredis.Cmder) error {
+		ctx, span := Tracer("redis").Start(ctx, "redis."+cmd.Name())
+		defer span.End()
+
+		err := next(ctx, cmd)
+		if err != nil && err != goredis.Nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	}
+}
+
+func (redisHook) ProcessPipelineHook(next goredis.ProcessPipelineHook) goredis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []goredis.Cmder) error {
+		ctx, span := Tracer("redis").Start(ctx, "redis.pipeline")
+		defer span.End()
+		span.SetAttributes(attribute.Int("redis.pipeline.size", len(cmds)))
+
+		err := next(ctx, cmds)
+		if err != nil && err != goredis.Nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	}
+}
@@ -0,0 +1,81 @@
+// Package tracing wires the platform's services into a shared
+// OpenTelemetry trace pipeline: a request entering at the gateway can be
+// followed through proxy selection, the datastore calls each service
+// makes along the way, and the upstream proxy request itself, with every
+// span exported to an OTLP collector.
+package tracing
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// shutdownTimeout bounds how long Init's returned Shutdown waits for
+// buffered spans to flush.
+const shutdownTimeout = 5 * time.Second
+
+// Shutdown flushes any buffered spans and stops the exporter started by
+// Init. Callers should defer it after checking Init's error.
+type Shutdown func(context.Context) error
+
+// noopShutdown is returned when tracing is disabled, so callers can
+// defer Init's result unconditionally.
+func noopShutdown(context.Context) error { return nil }
+
+// Init configures the global TracerProvider for serviceName, exporting
+// spans over OTLP/gRPC to the collector named by the
+// OTEL_EXPORTER_OTLP_ENDPOINT environment variable (e.g.
+// "localhost:4317"). If that variable is unset, tracing is disabled and
+// Init returns a no-op Shutdown, so every service can call Init
+// unconditionally without requiring a collector in dev or tests.
+func Init(ctx context.Context, serviceName string) (Shutdown, error) {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if endpoint == "" {
+		return noopShutdown, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(endpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return func(shutdownCtx context.Context) error {
+		shutdownCtx, cancel := context.WithTimeout(shutdownCtx, shutdownTimeout)
+		defer cancel()
+		return provider.Shutdown(shutdownCtx)
+	}, nil
+}
+
+// Tracer returns the named tracer, for starting spans outside of the
+// HTTP middleware, gRPC interceptors and Redis hook this package also
+// provides.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
@@ -0,0 +1,123 @@
+// Package upstreamtls configures how the gateway validates the TLS
+// certificate an upstream proxy presents when it speaks HTTPS
+// (pkg/proxy.Proxy with Protocol == proxy.ProtocolHTTPS): a custom CA
+// bundle to trust in addition to the system root pool, or skipping
+// verification altogether, per upstream provider. A plain-TCP upstream
+// never consults this package at all.
+package upstreamtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+)
+
+var ErrNotFound = errors.New("upstreamtls: not found")
+
+// Policy is how the gateway should validate TLS certificates from
+// upstream proxies sourced from Provider (pkg/proxy.Proxy.Provider).
+type Policy struct {
+	Provider   string
+	CABundle   []byte // PEM-encoded, trusted in addition to the system root pool; empty means system roots only
+	SkipVerify bool
+}
+
+// Config builds the *tls.Config to use when dialing an upstream proxy
+// at serverName, applying p. A nil p trusts the system root pool like
+// any other outbound TLS connection, which is also what a provider with
+// no configured policy gets.
+func Config(serverName string, p *Policy) *tls.Config {
+	cfg := &tls.Config{ServerName: serverName}
+	if p == nil {
+		return cfg
+	}
+	if p.SkipVerify {
+		cfg.InsecureSkipVerify = true
+		return cfg
+	}
+	if len(p.CABundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		pool.AppendCertsFromPEM(p.CABundle)
+		cfg.RootCAs = pool
+	}
+	return cfg
+}
+
+// DAO persists per-provider Policy rows in the upstream_tls_policies
+// table.
+type DAO struct {
+	db *sql.DB
+}
+
+// NewDAO builds a DAO backed by db.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db}
+}
+
+func (d *DAO) Get(ctx context.Context, provider string) (*Policy, error) {
+	row := d.db.QueryRowContext(ctx, `SELECT provider, ca_bundle, skip_verify FROM upstream_tls_policies WHERE provider = ?`, provider)
+	return scanPolicy(row)
+}
+
+func (d *DAO) List(ctx context.Context) ([]*Policy, error) {
+	rows, err := d.db.QueryContext(ctx, `SELECT provider, ca_bundle, skip_verify FROM upstream_tls_policies ORDER BY provider`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Policy
+	for rows.Next() {
+		p, err := scanPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+func (d *DAO) Upsert(ctx context.Context, p *Policy) error {
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO upstream_tls_policies (provider, ca_bundle, skip_verify)
+		 VALUES (?, ?, ?)
+		 ON DUPLICATE KEY UPDATE ca_bundle = VALUES(ca_bundle), skip_verify = VALUES(skip_verify)`,
+		p.Provider, nullableBundle(p.CABundle), p.SkipVerify)
+	return err
+}
+
+func (d *DAO) Delete(ctx context.Context, provider string) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM upstream_tls_policies WHERE provider = ?`, provider)
+	return err
+}
+
+func nullableBundle(b []byte) interface{} {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPolicy(row rowScanner) (*Policy, error) {
+	p := &Policy{}
+	var bundle sql.NullString
+	if err := row.Scan(&p.Provider, &bundle, &p.SkipVerify); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if bundle.Valid {
+		p.CABundle = []byte(bundle.String)
+	}
+	return p, nil
+}
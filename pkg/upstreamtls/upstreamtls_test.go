@@ -0,0 +1,46 @@
+package upstreamtls
+
+import "testing"
+
+func TestConfigNilPolicyTrustsSystemRoots(t *testing.T) {
+	cfg := Config("proxy.example.com", nil)
+	if cfg.ServerName != "proxy.example.com" {
+		t.Fatalf("ServerName = %q, want proxy.example.com", cfg.ServerName)
+	}
+	if cfg.InsecureSkipVerify {
+		t.Fatal("InsecureSkipVerify = true for a nil policy")
+	}
+	if cfg.RootCAs != nil {
+		t.Fatal("RootCAs set for a nil policy")
+	}
+}
+
+func TestConfigSkipVerify(t *testing.T) {
+	cfg := Config("proxy.example.com", &Policy{Provider: "acme", SkipVerify: true})
+	if !cfg.InsecureSkipVerify {
+		t.Fatal("InsecureSkipVerify = false, want true")
+	}
+}
+
+func TestConfigCustomCABundle(t *testing.T) {
+	cfg := Config("proxy.example.com", &Policy{Provider: "acme", CABundle: []byte(testCA)})
+	if cfg.InsecureSkipVerify {
+		t.Fatal("InsecureSkipVerify = true with a CA bundle configured")
+	}
+	if cfg.RootCAs == nil {
+		t.Fatal("RootCAs = nil, want a pool including the custom CA")
+	}
+}
+
+// testCA is a self-signed cert, valid PEM but not a trust anchor for
+// anything; it only needs to parse for AppendCertsFromPEM to succeed.
+const testCA = `-----BEGIN CERTIFICATE-----
+MIIBhTCCASugAwIBAgIQIcXiN6YwLyDEESSpDgDZazAKBggqhkjOPQQDAjASMRAw
+DgYDVQQKEwdBY21lIENvMB4XDTI0MDEwMTAwMDAwMFoXDTM0MDEwMTAwMDAwMFow
+EjEQMA4GA1UEChMHQWNtZSBDbzBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABHnZ
+Jvrp+tWjvAC+q9yzSmAKt7SFZ0IcUz2xTg2P8UwQxZy9hjIU/ceBZK1i+1wAKzWn
+YAGHqNyabT+mLdOHqEajUDBOMA4GA1UdDwEB/wQEAwIChDATBgNVHSUEDDAKBggr
+BgEFBQcDATAPBgNVHRMBAf8EBTADAQH/MBYGA1UdEQQPMA2CC2V4YW1wbGUuY29t
+MAoGCCqGSM49BAMCA0gAMEUCIQCVg7qj1+5f5TGdKXOxQeQ9ZQ9N+0C6tVp2Lz2w
+3gzOqQIgLtYm8/gkQKi2t0MNFAE/JKxK6pQYQnlAuwvP3aHT9Nw=
+-----END CERTIFICATE-----`
@@ -0,0 +1,57 @@
+package usage
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Aggregator periodically recomputes usage_hourly_rollups and
+// usage_daily_rollups from usage_logs, so stats reads can hit the
+// rollup tables instead of scanning raw logs.
+type Aggregator struct {
+	rollups *RollupDAO
+}
+
+// NewAggregator builds an Aggregator over rollups.
+func NewAggregator(rollups *RollupDAO) *Aggregator {
+	return &Aggregator{rollups: rollups}
+}
+
+// Run recomputes rollups every interval until ctx is cancelled.
+func (a *Aggregator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.RunOnce(ctx); err != nil {
+				log.Printf("usage: aggregation pass failed: %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce recomputes the hourly rollup for the most recently completed
+// hour, and, once a day, the daily rollup for the day that just ended.
+// Both RollUpHour and RollUpDay recompute from source rather than
+// incrementing, so re-running this for the same bucket is harmless.
+func (a *Aggregator) RunOnce(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	lastCompletedHour := HourBucket(now).Add(-time.Hour)
+	if _, err := a.rollups.RollUpHour(ctx, lastCompletedHour); err != nil {
+		return err
+	}
+
+	if now.Hour() == 0 {
+		yesterday := DayBucket(now).AddDate(0, 0, -1)
+		if _, err := a.rollups.RollUpDay(ctx, yesterday); err != nil {
+			return err
+		}
+	}
+	return nil
+}
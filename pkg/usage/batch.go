@@ -0,0 +1,151 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/eventbus"
+)
+
+// DefaultFlushInterval and DefaultFlushSize tune how often, and how
+// large, a BatchWriter flushes buffered usage events to MySQL.
+const (
+	DefaultFlushInterval = 2 * time.Second
+	DefaultFlushSize     = 200
+	defaultBufferSize    = 4096
+)
+
+// ErrBufferFull is returned by Record when the in-memory event buffer is
+// saturated, i.e. MySQL can't keep up with the inbound rate. Callers on
+// the request path should drop the event rather than block on it.
+var ErrBufferFull = errors.New("usage: event buffer full")
+
+// BatchWriter buffers usage log events in memory and flushes them to
+// MySQL in batches, trading a small amount of durability (buffered
+// events are lost on crash) for throughput: a proxied request no longer
+// pays for a synchronous MySQL round trip.
+type BatchWriter struct {
+	dao           *DAO
+	flushInterval time.Duration
+	flushSize     int
+	events        chan Log
+	done          chan struct{}
+	wg            sync.WaitGroup
+	publisher     eventbus.Publisher
+}
+
+// NewBatchWriter builds a BatchWriter over dao. A flushInterval or
+// flushSize of zero falls back to the package defaults. publisher, if
+// non-nil, is notified with an eventbus.EventUsageRecorded event after
+// each successful flush, summarizing the batch rather than firing once
+// per request; a nil publisher disables that entirely.
+func NewBatchWriter(dao *DAO, flushInterval time.Duration, flushSize int, publisher eventbus.Publisher) *BatchWriter {
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+	if flushSize <= 0 {
+		flushSize = DefaultFlushSize
+	}
+	return &BatchWriter{
+		dao:           dao,
+		flushInterval: flushInterval,
+		flushSize:     flushSize,
+		events:        make(chan Log, defaultBufferSize),
+		done:          make(chan struct{}),
+		publisher:     publisher,
+	}
+}
+
+// Record enqueues entry for the next flush. It never blocks: if the
+// buffer is full it returns ErrBufferFull immediately so the caller
+// (e.g. the gateway's request path) can drop the event instead of
+// stalling the proxied request on it.
+func (b *BatchWriter) Record(entry Log) error {
+	select {
+	case b.events <- entry:
+		return nil
+	default:
+		return ErrBufferFull
+	}
+}
+
+// Run flushes buffered events every flushInterval, or as soon as
+// flushSize events have accumulated, until ctx is cancelled or Close is
+// called. It blocks, so callers should run it in its own goroutine.
+func (b *BatchWriter) Run(ctx context.Context) {
+	b.wg.Add(1)
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Log, 0, b.flushSize)
+	for {
+		select {
+		case entry := <-b.events:
+			batch = append(batch, entry)
+			if len(batch) >= b.flushSize {
+				batch = b.flush(ctx, batch)
+			}
+		case <-ticker.C:
+			batch = b.flush(ctx, batch)
+		case <-ctx.Done():
+			b.shutdown(batch)
+			return
+		case <-b.done:
+			b.shutdown(batch)
+			return
+		}
+	}
+}
+
+// shutdown drains whatever is still sitting in the event channel and
+// performs one last flush, so a graceful shutdown doesn't lose events
+// that were enqueued but not yet written.
+func (b *BatchWriter) shutdown(batch []Log) {
+	for {
+		select {
+		case entry := <-b.events:
+			batch = append(batch, entry)
+		default:
+			b.flush(context.Background(), batch)
+			return
+		}
+	}
+}
+
+func (b *BatchWriter) flush(ctx context.Context, batch []Log) []Log {
+	if len(batch) == 0 {
+		return batch[:0]
+	}
+	if err := b.dao.InsertBatch(ctx, batch); err != nil {
+		log.Printf("usage: failed to flush %d usage log(s): %v", len(batch), err)
+		return batch[:0]
+	}
+	b.publishRecorded(ctx, len(batch))
+	return batch[:0]
+}
+
+// publishRecorded notifies b.publisher, if any, that count usage logs
+// were just flushed. It's best-effort: a publish failure is logged but
+// never blocks or fails the flush itself.
+func (b *BatchWriter) publishRecorded(ctx context.Context, count int) {
+	if b.publisher == nil {
+		return
+	}
+	event := eventbus.Event{Type: eventbus.EventUsageRecorded, Fields: map[string]string{"count": strconv.Itoa(count)}}
+	if err := b.publisher.Publish(ctx, event); err != nil {
+		log.Printf("usage: failed to publish usage.recorded event: %v", err)
+	}
+}
+
+// Close stops Run after it performs a final flush, and blocks until
+// that flush has completed.
+func (b *BatchWriter) Close() {
+	close(b.done)
+	b.wg.Wait()
+}
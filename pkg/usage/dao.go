@@ -0,0 +1,437 @@
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/envelope"
+)
+
+// DAOInterface is the subset of DAO's behavior that service and handler
+// code depends on. It exists so those layers can be unit-tested against
+// daofake's in-memory fake instead of a real MySQL connection; see
+// pkg/daofake's conformance suite, which every implementation (DAO
+// included) must pass. The fake never encrypts TargetHost, since
+// encryption is an implementation detail of NewEncryptingDAO rather than
+// part of this contract.
+type DAOInterface interface {
+	Insert(ctx context.Context, log Log) error
+	InsertBatch(ctx context.Context, logs []Log) error
+	CountByUserInRange(ctx context.Context, start, end time.Time) (map[string]int64, error)
+	TopTargetHosts(ctx context.Context, start, end time.Time, limit int) ([]TargetHostCount, error)
+	TopTargetHostsForUser(ctx context.Context, userID string, start, end time.Time, limit int) ([]TargetHostCount, error)
+	GetStatsByProxyID(ctx context.Context, proxyID string, start, end time.Time) (ProxyUsageStats, error)
+	TopProxiesByErrors(ctx context.Context, start, end time.Time, minRequests int64, limit int) ([]ProxyUsageStats, error)
+	SelectForExport(ctx context.Context, userID string, start, end time.Time, limit int) ([]Log, error)
+	DeleteOldLogs(ctx context.Context, cutoff time.Time, limit int) (int64, error)
+	SelectOldLogs(ctx context.Context, cutoff time.Time, limit int) ([]Log, error)
+	DeleteByIDs(ctx context.Context, ids []int64) (int64, error)
+}
+
+// DAO persists usage logs to MySQL. Tenant field-level encryption is
+// optional: a DAO created with NewDAO stores TargetHost in the clear,
+// while one created with NewEncryptingDAO checks each tenant's
+// preference and, if enabled, encrypts it under that tenant's envelope
+// data key instead.
+type DAO struct {
+	db      *sql.DB
+	enc     *envelope.Manager
+	tenants *envelope.TenantEncryptionDAO
+}
+
+var _ DAOInterface = (*DAO)(nil)
+
+// NewDAO wraps an existing *sql.DB handle with no field encryption.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db}
+}
+
+// NewEncryptingDAO wraps db and, for any tenant with field-level
+// encryption enabled, encrypts TargetHost before it is persisted.
+// Aggregate reporting falls back to TargetHostHash, which is always
+// stored regardless of the tenant's encryption preference.
+func NewEncryptingDAO(db *sql.DB, enc *envelope.Manager, tenants *envelope.TenantEncryptionDAO) *DAO {
+	return &DAO{db: db, enc: enc, tenants: tenants}
+}
+
+// Insert writes a single usage log row.
+func (d *DAO) Insert(ctx context.Context, log Log) error {
+	targetHost, targetHostEnc, targetHostHash, err := d.prepareTarget(ctx, log)
+	if err != nil {
+		return err
+	}
+	timingJSON, err := marshalTiming(log.Timing)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.ExecContext(ctx,
+		`INSERT INTO usage_logs
+		   (user_id, api_key_id, proxy_addr, proxy_id, target_host, target_host_enc, target_host_hash, protocol, bytes_in, bytes_out, status_code, denial_reason, duration_ms, trace_id, timing_json, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		log.UserID, log.APIKeyID, log.ProxyAddr, log.ProxyID, targetHost, targetHostEnc, targetHostHash, log.Protocol,
+		log.BytesIn, log.BytesOut, log.StatusCode, log.DenialReason, log.DurationMS, log.TraceID, timingJSON, time.Now().UTC(),
+	)
+	return err
+}
+
+// InsertBatch writes multiple usage log rows in a single transaction.
+// BatchWriter uses this to amortize MySQL round trips across many
+// proxied requests instead of one Insert per request.
+func (d *DAO) InsertBatch(ctx context.Context, logs []Log) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO usage_logs
+		   (user_id, api_key_id, proxy_addr, proxy_id, target_host, target_host_enc, target_host_hash, protocol, bytes_in, bytes_out, status_code, denial_reason, duration_ms, trace_id, timing_json, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, entry := range logs {
+		targetHost, targetHostEnc, targetHostHash, err := d.prepareTarget(ctx, entry)
+		if err != nil {
+			return fmt.Errorf("usage: prepare batch entry: %w", err)
+		}
+		timingJSON, err := marshalTiming(entry.Timing)
+		if err != nil {
+			return fmt.Errorf("usage: marshal batch entry timing: %w", err)
+		}
+		if _, err := stmt.ExecContext(ctx,
+			entry.UserID, entry.APIKeyID, entry.ProxyAddr, entry.ProxyID, targetHost, targetHostEnc, targetHostHash, entry.Protocol,
+			entry.BytesIn, entry.BytesOut, entry.StatusCode, entry.DenialReason, entry.DurationMS, entry.TraceID, timingJSON, time.Now().UTC(),
+		); err != nil {
+			return fmt.Errorf("usage: insert batch entry: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CountByUserInRange returns how many usage_logs rows fall in
+// [start, end) for each user, keyed by user ID. It backs billing's
+// monthly invoice generation.
+func (d *DAO) CountByUserInRange(ctx context.Context, start, end time.Time) (map[string]int64, error) {
+	rows, err := d.db.QueryContext(ctx,
+		`SELECT user_id, COUNT(*) FROM usage_logs WHERE created_at >= ? AND created_at < ? GROUP BY user_id`,
+		start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var userID string
+		var count int64
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, err
+		}
+		counts[userID] = count
+	}
+	return counts, rows.Err()
+}
+
+// TargetHostCount is one target domain's request volume over a range.
+type TargetHostCount struct {
+	TargetHost string
+	Count      int64
+}
+
+// TopTargetHosts returns the most-requested target domains in
+// [start, end), most-requested first. Rows where TargetHost was
+// encrypted at write time (see prepareTarget) have no plaintext to
+// group by and are excluded; admin dashboards reading this should note
+// that tenants with field-level encryption enabled won't appear here.
+func (d *DAO) TopTargetHosts(ctx context.Context, start, end time.Time, limit int) ([]TargetHostCount, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT target_host, COUNT(*) AS c
+		  FROM usage_logs
+		 WHERE created_at >= ? AND created_at < ? AND target_host != ''
+		 GROUP BY target_host
+		 ORDER BY c DESC
+		 LIMIT ?`, start, end, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []TargetHostCount
+	for rows.Next() {
+		var t TargetHostCount
+		if err := rows.Scan(&t.TargetHost, &t.Count); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// TopTargetHostsForUser returns a single user's most-requested target
+// domains in [start, end), most-requested first. Same encrypted-target
+// caveat as TopTargetHosts: rows with no plaintext TargetHost are
+// excluded.
+func (d *DAO) TopTargetHostsForUser(ctx context.Context, userID string, start, end time.Time, limit int) ([]TargetHostCount, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT target_host, COUNT(*) AS c
+		  FROM usage_logs
+		 WHERE user_id = ? AND created_at >= ? AND created_at < ? AND target_host != ''
+		 GROUP BY target_host
+		 ORDER BY c DESC
+		 LIMIT ?`, userID, start, end, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []TargetHostCount
+	for rows.Next() {
+		var t TargetHostCount
+		if err := rows.Scan(&t.TargetHost, &t.Count); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// ProxyUsageStats summarizes a window of usage_logs attributed to one
+// upstream proxy (see Log.ProxyID), for reports that guide pool pruning.
+type ProxyUsageStats struct {
+	ProxyID  string
+	Requests int64
+	Errors   int64
+	BytesIn  int64
+	BytesOut int64
+}
+
+// proxyErrorCase is the SQL fragment both proxy-stats queries use to
+// count a row as an error: a 4xx/5xx response, or a request the gateway
+// denied before forwarding (DenialReason set, StatusCode 0).
+const proxyErrorCase = `CASE WHEN status_code >= 400 OR denial_reason != '' THEN 1 ELSE 0 END`
+
+// GetStatsByProxyID summarizes one proxy's usage_logs rows in
+// [start, end). Rows with no ProxyID (requests denied before an
+// upstream was chosen) are never attributed to any proxy.
+func (d *DAO) GetStatsByProxyID(ctx context.Context, proxyID string, start, end time.Time) (ProxyUsageStats, error) {
+	stats := ProxyUsageStats{ProxyID: proxyID}
+	row := d.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(`+proxyErrorCase+`), 0), COALESCE(SUM(bytes_in), 0), COALESCE(SUM(bytes_out), 0)
+		  FROM usage_logs
+		 WHERE proxy_id = ? AND created_at >= ? AND created_at < ?`, proxyID, start, end)
+	if err := row.Scan(&stats.Requests, &stats.Errors, &stats.BytesIn, &stats.BytesOut); err != nil {
+		return ProxyUsageStats{}, err
+	}
+	return stats, nil
+}
+
+// TopProxiesByErrors returns the proxies with the most errors in
+// [start, end), most errors first, excluding any proxy with fewer than
+// minRequests total requests so a single-digit-volume proxy with one
+// failure doesn't dominate the report. This backs the pool-pruning
+// report: an admin can pull this list to find upstreams worth
+// disabling.
+func (d *DAO) TopProxiesByErrors(ctx context.Context, start, end time.Time, minRequests int64, limit int) ([]ProxyUsageStats, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT proxy_id, COUNT(*) AS requests, SUM(`+proxyErrorCase+`) AS errors, COALESCE(SUM(bytes_in), 0), COALESCE(SUM(bytes_out), 0)
+		  FROM usage_logs
+		 WHERE created_at >= ? AND created_at < ? AND proxy_id != ''
+		 GROUP BY proxy_id
+		HAVING COUNT(*) >= ?
+		 ORDER BY errors DESC
+		 LIMIT ?`, start, end, minRequests, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ProxyUsageStats
+	for rows.Next() {
+		var s ProxyUsageStats
+		if err := rows.Scan(&s.ProxyID, &s.Requests, &s.Errors, &s.BytesIn, &s.BytesOut); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+// SelectForExport returns up to limit of a single user's usage_logs rows
+// in [start, end), ordered oldest first. pkg/export's Worker uses this
+// to stream a user's own export rather than DAO's archival queries,
+// which aren't scoped to a user.
+func (d *DAO) SelectForExport(ctx context.Context, userID string, start, end time.Time, limit int) ([]Log, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT id, user_id, api_key_id, proxy_addr, proxy_id, target_host, target_host_hash, protocol, bytes_in, bytes_out, status_code, denial_reason, duration_ms, trace_id, timing_json, created_at
+		  FROM usage_logs
+		 WHERE user_id = ? AND created_at >= ? AND created_at < ?
+		 ORDER BY created_at, id
+		 LIMIT ?`, userID, start, end, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []Log
+	for rows.Next() {
+		var entry Log
+		var timingJSON sql.NullString
+		if err := rows.Scan(&entry.ID, &entry.UserID, &entry.APIKeyID, &entry.ProxyAddr, &entry.ProxyID, &entry.TargetHost,
+			&entry.TargetHostHash, &entry.Protocol, &entry.BytesIn, &entry.BytesOut, &entry.StatusCode, &entry.DenialReason,
+			&entry.DurationMS, &entry.TraceID, &timingJSON, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		entry.Timing, err = unmarshalTiming(timingJSON)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, entry)
+	}
+	return logs, rows.Err()
+}
+
+// DeleteOldLogs hard-deletes up to limit usage_logs rows older than
+// cutoff, returning how many rows it removed. Callers loop on this to
+// work through a large backlog in limit-sized chunks instead of one
+// long-running DELETE, since this table grows with every proxied
+// request.
+func (d *DAO) DeleteOldLogs(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	result, err := d.db.ExecContext(ctx,
+		`DELETE FROM usage_logs WHERE created_at < ? LIMIT ?`, cutoff, limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// SelectOldLogs returns up to limit usage_logs rows older than cutoff,
+// ordered by id so repeated calls with the same cutoff make steady
+// progress through the backlog. The archiver uses this, rather than
+// DeleteOldLogs, when it needs the row contents (to export) as well as
+// their IDs (to delete exactly what it successfully exported).
+func (d *DAO) SelectOldLogs(ctx context.Context, cutoff time.Time, limit int) ([]Log, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT id, user_id, api_key_id, proxy_addr, proxy_id, target_host, target_host_hash, protocol, bytes_in, bytes_out, status_code, denial_reason, duration_ms, trace_id, timing_json, created_at
+		  FROM usage_logs
+		 WHERE created_at < ?
+		 ORDER BY id
+		 LIMIT ?`, cutoff, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []Log
+	for rows.Next() {
+		var entry Log
+		var timingJSON sql.NullString
+		if err := rows.Scan(&entry.ID, &entry.UserID, &entry.APIKeyID, &entry.ProxyAddr, &entry.ProxyID, &entry.TargetHost,
+			&entry.TargetHostHash, &entry.Protocol, &entry.BytesIn, &entry.BytesOut, &entry.StatusCode, &entry.DenialReason,
+			&entry.DurationMS, &entry.TraceID, &timingJSON, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		entry.Timing, err = unmarshalTiming(timingJSON)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, entry)
+	}
+	return logs, rows.Err()
+}
+
+// DeleteByIDs hard-deletes exactly the given usage_logs rows, returning
+// how many it removed. The archiver uses this after confirming an
+// export succeeded, rather than a cutoff-based delete, so a row written
+// to usage_logs after the export snapshot was taken is never lost.
+func (d *DAO) DeleteByIDs(ctx context.Context, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	placeholders := strings.Repeat("?,", len(ids))
+	placeholders = placeholders[:len(placeholders)-1]
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	result, err := d.db.ExecContext(ctx,
+		fmt.Sprintf(`DELETE FROM usage_logs WHERE id IN (%s)`, placeholders), args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// prepareTarget computes the target-host columns for log, encrypting
+// TargetHost under the tenant's envelope data key if both an encryption
+// manager is configured and the tenant has opted in.
+func (d *DAO) prepareTarget(ctx context.Context, log Log) (targetHost string, targetHostEnc []byte, targetHostHash string, err error) {
+	targetHostHash = log.TargetHostHash
+	if targetHostHash == "" {
+		targetHostHash = envelope.HashDomain(log.TargetHost)
+	}
+	targetHost = log.TargetHost
+
+	if d.enc == nil || d.tenants == nil {
+		return targetHost, nil, targetHostHash, nil
+	}
+
+	enabled, err := d.tenants.IsEnabled(ctx, log.UserID)
+	if err != nil {
+		return "", nil, "", err
+	}
+	if !enabled {
+		return targetHost, nil, targetHostHash, nil
+	}
+
+	dataKey, err := d.enc.DataKey(ctx, log.UserID)
+	if err != nil {
+		return "", nil, "", err
+	}
+	targetHostEnc, err = envelope.Encrypt(dataKey, []byte(log.TargetHost))
+	if err != nil {
+		return "", nil, "", err
+	}
+	return "", targetHostEnc, targetHostHash, nil
+}
+
+// marshalTiming JSON-encodes t for the timing_json column, or returns a
+// NULL value if t is nil.
+func marshalTiming(t *Timing) (sql.NullString, error) {
+	if t == nil {
+		return sql.NullString{}, nil
+	}
+	encoded, err := json.Marshal(t)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(encoded), Valid: true}, nil
+}
+
+// unmarshalTiming decodes a timing_json column value, returning nil if
+// it was NULL or empty.
+func unmarshalTiming(s sql.NullString) (*Timing, error) {
+	if !s.Valid || s.String == "" {
+		return nil, nil
+	}
+	var t Timing
+	if err := json.Unmarshal([]byte(s.String), &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,16 @@
+//go:build integration
+
+package usage_test
+
+import (
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/daofake"
+	"github.com/XXXXD-cation/proxy-platform/pkg/testsupport"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+)
+
+// Run with: go test -tags=integration ./pkg/usage/...
+func TestDAOConformsToDAOInterface(t *testing.T) {
+	daofake.ConformUsageLog(t, usage.NewDAO(testsupport.GetTestDB(t)))
+}
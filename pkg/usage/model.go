@@ -0,0 +1,45 @@
+// Package usage records proxied-traffic usage logs, one row per request
+// forwarded through the gateway. This is distinct from pkg/apilog, which
+// covers calls against the platform's own management APIs.
+package usage
+
+import "time"
+
+// Log is a single proxied request, recorded by the gateway after the
+// connection/tunnel it describes has finished.
+type Log struct {
+	ID        int64
+	UserID    string
+	APIKeyID  string
+	ProxyAddr string
+	// ProxyID is the proxies.id (pkg/proxy) of the upstream this request
+	// was forwarded through, so usage can be joined to proxy metadata
+	// without relying on ProxyAddr (a "host:port" string that can be
+	// reused over time as proxies churn). Empty for requests that never
+	// picked an upstream, e.g. one denied before forwarding.
+	ProxyID    string
+	TargetHost string
+	// TargetHostHash is a one-way hash of TargetHost, always populated
+	// regardless of whether the tenant has field-level encryption
+	// enabled, so aggregate reporting never needs the plaintext.
+	TargetHostHash string
+	Protocol       string // "http", "https" (CONNECT), "socks5", ...
+	BytesIn        int64
+	BytesOut       int64
+	StatusCode     int // 0 for tunneled (CONNECT) traffic with no single status
+	// DenialReason is set when the gateway blocked this request under a
+	// target-domain policy (pkg/targetpolicy) instead of forwarding it;
+	// empty for every successfully proxied request.
+	DenialReason string
+	DurationMS   int64
+	// TraceID is the W3C trace ID (pkg/tracing) of the span covering
+	// this request, if tracing is enabled, so support can pull the full
+	// distributed trace for a slow or failed session. Empty when tracing
+	// is disabled (see tracing.Init).
+	TraceID string
+	// Timing is the request's latency breakdown (pkg/usage.Timing), or
+	// nil for a request with nothing to measure, e.g. one denied before
+	// the gateway picked an upstream.
+	Timing    *Timing
+	CreatedAt time.Time
+}
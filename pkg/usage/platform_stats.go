@@ -0,0 +1,39 @@
+package usage
+
+import (
+	"context"
+	"time"
+)
+
+// TimeBucketStats is aggregate usage across all users for one hourly
+// bucket, used by the admin dashboard's platform-wide traffic and
+// error-rate trends.
+type TimeBucketStats struct {
+	BucketStart time.Time
+	Stats       Stats
+}
+
+// PlatformHourly returns platform-wide usage, summed across all users,
+// for each hour in [start, end) that has a rollup.
+func (d *RollupDAO) PlatformHourly(ctx context.Context, start, end time.Time) ([]TimeBucketStats, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT bucket_start, SUM(request_count), SUM(bytes_in), SUM(bytes_out), SUM(error_count)
+		  FROM usage_hourly_rollups
+		 WHERE bucket_start >= ? AND bucket_start < ?
+		 GROUP BY bucket_start
+		 ORDER BY bucket_start`, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []TimeBucketStats
+	for rows.Next() {
+		var b TimeBucketStats
+		if err := rows.Scan(&b.BucketStart, &b.Stats.RequestCount, &b.Stats.BytesIn, &b.Stats.BytesOut, &b.Stats.ErrorCount); err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,223 @@
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Stats summarizes request volume and errors over some time range,
+// regardless of whether it was computed from rollups or raw logs.
+type Stats struct {
+	RequestCount int64
+	BytesIn      int64
+	BytesOut     int64
+	ErrorCount   int64
+}
+
+func (s *Stats) add(other Stats) {
+	s.RequestCount += other.RequestCount
+	s.BytesIn += other.BytesIn
+	s.BytesOut += other.BytesOut
+	s.ErrorCount += other.ErrorCount
+}
+
+// HourlyRollup is one user's request volume for a single hour bucket.
+type HourlyRollup struct {
+	UserID      string
+	BucketStart time.Time
+	Stats       Stats
+}
+
+// DailyRollup is one user's request volume for a single day bucket.
+type DailyRollup struct {
+	UserID     string
+	BucketDate time.Time
+	Stats      Stats
+}
+
+// RollupDAO reads and writes the pre-aggregated usage_hourly_rollups and
+// usage_daily_rollups tables, so dashboard and stats reads don't have to
+// scan usage_logs directly.
+type RollupDAO struct {
+	db *sql.DB
+}
+
+// NewRollupDAO wraps an existing *sql.DB handle.
+func NewRollupDAO(db *sql.DB) *RollupDAO {
+	return &RollupDAO{db: db}
+}
+
+// HourBucket truncates t to the start of its hour, in UTC — the bucket
+// key used by usage_hourly_rollups.
+func HourBucket(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Hour)
+}
+
+// DayBucket truncates t to the start of its day, in UTC — the bucket
+// key used by usage_daily_rollups.
+func DayBucket(t time.Time) time.Time {
+	u := t.UTC()
+	return time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// RollUpHour replaces the hourly rollup for [bucketStart, bucketStart+1h)
+// with stats aggregated fresh from usage_logs. It's safe to re-run for
+// the same hour: the aggregator always recomputes from source rather
+// than incrementing, so a retried or overlapping pass can't double-count.
+func (d *RollupDAO) RollUpHour(ctx context.Context, bucketStart time.Time) (int64, error) {
+	bucketStart = HourBucket(bucketStart)
+	result, err := d.db.ExecContext(ctx, `
+		INSERT INTO usage_hourly_rollups (user_id, bucket_start, request_count, bytes_in, bytes_out, error_count)
+		SELECT user_id, ?, COUNT(*), COALESCE(SUM(bytes_in), 0), COALESCE(SUM(bytes_out), 0),
+		       SUM(CASE WHEN status_code >= 500 THEN 1 ELSE 0 END)
+		  FROM usage_logs
+		 WHERE created_at >= ? AND created_at < ?
+		 GROUP BY user_id
+		 ON DUPLICATE KEY UPDATE
+		   request_count = VALUES(request_count),
+		   bytes_in      = VALUES(bytes_in),
+		   bytes_out     = VALUES(bytes_out),
+		   error_count   = VALUES(error_count)`,
+		bucketStart, bucketStart, bucketStart.Add(time.Hour))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// RollUpDay replaces the daily rollup for bucketDate with stats summed
+// from the completed hourly rollups for that day.
+func (d *RollupDAO) RollUpDay(ctx context.Context, bucketDate time.Time) (int64, error) {
+	bucketDate = DayBucket(bucketDate)
+	result, err := d.db.ExecContext(ctx, `
+		INSERT INTO usage_daily_rollups (user_id, bucket_date, request_count, bytes_in, bytes_out, error_count)
+		SELECT user_id, ?, SUM(request_count), SUM(bytes_in), SUM(bytes_out), SUM(error_count)
+		  FROM usage_hourly_rollups
+		 WHERE bucket_start >= ? AND bucket_start < ?
+		 GROUP BY user_id
+		 ON DUPLICATE KEY UPDATE
+		   request_count = VALUES(request_count),
+		   bytes_in      = VALUES(bytes_in),
+		   bytes_out     = VALUES(bytes_out),
+		   error_count   = VALUES(error_count)`,
+		bucketDate, bucketDate, bucketDate.AddDate(0, 0, 1))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// HourlyRange returns a user's hourly rollups in [start, end).
+func (d *RollupDAO) HourlyRange(ctx context.Context, userID string, start, end time.Time) ([]HourlyRollup, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT bucket_start, request_count, bytes_in, bytes_out, error_count
+		  FROM usage_hourly_rollups
+		 WHERE user_id = ? AND bucket_start >= ? AND bucket_start < ?
+		 ORDER BY bucket_start`, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []HourlyRollup
+	for rows.Next() {
+		r := HourlyRollup{UserID: userID}
+		if err := rows.Scan(&r.BucketStart, &r.Stats.RequestCount, &r.Stats.BytesIn, &r.Stats.BytesOut, &r.Stats.ErrorCount); err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// DailyRange returns a user's daily rollups in [start, end).
+func (d *RollupDAO) DailyRange(ctx context.Context, userID string, start, end time.Time) ([]DailyRollup, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT bucket_date, request_count, bytes_in, bytes_out, error_count
+		  FROM usage_daily_rollups
+		 WHERE user_id = ? AND bucket_date >= ? AND bucket_date < ?
+		 ORDER BY bucket_date`, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []DailyRollup
+	for rows.Next() {
+		r := DailyRollup{UserID: userID}
+		if err := rows.Scan(&r.BucketDate, &r.Stats.RequestCount, &r.Stats.BytesIn, &r.Stats.BytesOut, &r.Stats.ErrorCount); err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// StatsInRange aggregates raw usage_logs for a user in [start, end)
+// directly, bypassing rollups. RollupDAO's TodayStats/MonthlyStats use
+// this as the fallback for the most recent, not-yet-rolled-up bucket.
+func (d *DAO) StatsInRange(ctx context.Context, userID string, start, end time.Time) (Stats, error) {
+	var s Stats
+	err := d.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(bytes_in), 0), COALESCE(SUM(bytes_out), 0),
+		       SUM(CASE WHEN status_code >= 500 THEN 1 ELSE 0 END)
+		  FROM usage_logs
+		 WHERE user_id = ? AND created_at >= ? AND created_at < ?`,
+		userID, start, end,
+	).Scan(&s.RequestCount, &s.BytesIn, &s.BytesOut, &s.ErrorCount)
+	return s, err
+}
+
+// TodayStats returns a user's request volume since the start of today
+// (UTC), reading completed hours from usage_hourly_rollups and falling
+// back to a raw usage_logs scan for the current, not-yet-rolled-up hour.
+func (d *RollupDAO) TodayStats(ctx context.Context, usageDAO *DAO, userID string, now time.Time) (Stats, error) {
+	now = now.UTC()
+	dayStart := DayBucket(now)
+	currentHour := HourBucket(now)
+
+	hourly, err := d.HourlyRange(ctx, userID, dayStart, currentHour)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	var total Stats
+	for _, r := range hourly {
+		total.add(r.Stats)
+	}
+
+	partial, err := usageDAO.StatsInRange(ctx, userID, currentHour, now)
+	if err != nil {
+		return Stats{}, err
+	}
+	total.add(partial)
+	return total, nil
+}
+
+// MonthlyStats returns a user's request volume since the start of the
+// current calendar month (UTC), reading completed days from
+// usage_daily_rollups and falling back to a raw usage_logs scan for
+// today, which hasn't been rolled up into usage_daily_rollups yet.
+func (d *RollupDAO) MonthlyStats(ctx context.Context, usageDAO *DAO, userID string, now time.Time) (Stats, error) {
+	now = now.UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	today := DayBucket(now)
+
+	daily, err := d.DailyRange(ctx, userID, monthStart, today)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	var total Stats
+	for _, r := range daily {
+		total.add(r.Stats)
+	}
+
+	partial, err := usageDAO.StatsInRange(ctx, userID, today, now)
+	if err != nil {
+		return Stats{}, err
+	}
+	total.add(partial)
+	return total, nil
+}
@@ -0,0 +1,34 @@
+package usage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHourBucketTruncatesToHourUTC(t *testing.T) {
+	in := time.Date(2026, 8, 9, 14, 37, 52, 0, time.FixedZone("TST", 3600))
+	got := HourBucket(in)
+	want := time.Date(2026, 8, 9, 13, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("HourBucket(%v) = %v, want %v", in, got, want)
+	}
+}
+
+func TestDayBucketTruncatesToDayUTC(t *testing.T) {
+	in := time.Date(2026, 8, 9, 23, 59, 0, 0, time.UTC)
+	got := DayBucket(in)
+	want := time.Date(2026, 8, 9, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("DayBucket(%v) = %v, want %v", in, got, want)
+	}
+}
+
+func TestStatsAddSumsFields(t *testing.T) {
+	s := Stats{RequestCount: 1, BytesIn: 2, BytesOut: 3, ErrorCount: 4}
+	s.add(Stats{RequestCount: 10, BytesIn: 20, BytesOut: 30, ErrorCount: 40})
+
+	want := Stats{RequestCount: 11, BytesIn: 22, BytesOut: 33, ErrorCount: 44}
+	if s != want {
+		t.Fatalf("add() = %+v, want %+v", s, want)
+	}
+}
@@ -0,0 +1,15 @@
+package usage
+
+// Timing is a per-request latency breakdown captured by the gateway,
+// stored as JSON in Log.Timing. A phase the gateway couldn't measure
+// (e.g. ProxyToTargetMS for a plain HTTP forward, where the transport
+// can't distinguish "proxy talking to target" from the rest of TTFB, or
+// any phase at all for a request denied before it picked an upstream)
+// is left at zero rather than omitted.
+type Timing struct {
+	DNSMS            int64 `json:"dns_ms"`
+	ConnectToProxyMS int64 `json:"connect_to_proxy_ms"`
+	ProxyToTargetMS  int64 `json:"proxy_to_target_ms"`
+	TTFBMS           int64 `json:"ttfb_ms"`
+	TotalMS          int64 `json:"total_ms"`
+}
@@ -0,0 +1,329 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/auth"
+	"github.com/XXXXD-cation/proxy-platform/pkg/dbtx"
+	"github.com/XXXXD-cation/proxy-platform/pkg/pagination"
+)
+
+// ErrNotFound is returned when no user matches the given ID.
+var ErrNotFound = errors.New("user: not found")
+
+const userColumns = "id, email, password_hash, status, plan, parent_user_id, created_at, updated_at"
+
+// ListSort whitelists the columns List callers may sort by.
+var ListSort = pagination.SortWhitelist{
+	"created_at": "created_at",
+	"email":      "email",
+}
+
+// ListFilter narrows DAO.List by account status and/or plan. Zero values
+// mean "don't filter on this field". Page controls paging and sorting;
+// its SortBy should come from resolving a caller's sort key through
+// ListSort.
+type ListFilter struct {
+	Status Status
+	Plan   Plan
+	Page   pagination.Params
+}
+
+// DAOInterface is the subset of DAO's behavior that service and handler
+// code depends on. It exists so those layers can be unit-tested against
+// daofake's in-memory fake instead of a real MySQL connection; see
+// pkg/daofake's conformance suite, which every implementation (DAO
+// included) must pass.
+type DAOInterface interface {
+	List(ctx context.Context, filter ListFilter) (pagination.Page[*User], error)
+	Get(ctx context.Context, id string) (*User, error)
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	Create(ctx context.Context, email, passwordHash string, plan Plan) (*User, error)
+	CreateSubAccount(ctx context.Context, parentUserID, email, passwordHash string, plan Plan) (*User, error)
+	ListByParent(ctx context.Context, parentUserID string) ([]*User, error)
+	CountByStatus(ctx context.Context) (map[Status]int64, error)
+	UpdateStatus(ctx context.Context, id string, status Status) error
+	UpdatePlan(ctx context.Context, id string, plan Plan) error
+	SoftDelete(ctx context.Context, id string) error
+	ResetPassword(ctx context.Context, id string) (string, error)
+	SetPasswordHash(ctx context.Context, id, passwordHash string) error
+}
+
+// DAO reads and writes the canonical `users` table in MySQL.
+type DAO struct {
+	db dbtx.Queryer
+
+	// rawDB is set only when DAO was built via NewDAO, not WithTx, since
+	// a *sql.Tx can't itself start a nested transaction. Methods that
+	// need their own transaction (e.g. CreateFromOAuth) use it directly.
+	rawDB *sql.DB
+}
+
+var _ DAOInterface = (*DAO)(nil)
+
+// NewDAO wraps an existing *sql.DB handle.
+func NewDAO(db *sql.DB) *DAO {
+	return &DAO{db: db, rawDB: db}
+}
+
+// WithTx returns a DAO whose operations run against tx instead of the
+// original *sql.DB, so callers can compose it with other DAOs inside a
+// dbtx.Run unit of work.
+func (d *DAO) WithTx(tx *sql.Tx) *DAO {
+	return &DAO{db: tx}
+}
+
+// List returns a page of accounts matching filter, along with the total
+// number of accounts matching it across every page.
+func (d *DAO) List(ctx context.Context, filter ListFilter) (pagination.Page[*User], error) {
+	limit := filter.Page.Limit
+	if limit <= 0 {
+		limit = pagination.DefaultLimit
+	}
+	sortBy := filter.Page.SortBy
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	order := "DESC"
+	if !filter.Page.SortDesc {
+		order = "ASC"
+	}
+
+	where := ` WHERE 1 = 1`
+	var args []interface{}
+	if filter.Status != "" {
+		where += ` AND status = ?`
+		args = append(args, string(filter.Status))
+	}
+	if filter.Plan != "" {
+		where += ` AND plan = ?`
+		args = append(args, string(filter.Plan))
+	}
+
+	var total int
+	if err := d.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users`+where, args...).Scan(&total); err != nil {
+		return pagination.Page[*User]{}, err
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users` + where + ` ORDER BY ` + sortBy + ` ` + order + ` LIMIT ? OFFSET ?`
+	args = append(args, limit, filter.Page.Offset)
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return pagination.Page[*User]{}, err
+	}
+	defer rows.Close()
+
+	var out []*User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return pagination.Page[*User]{}, err
+		}
+		out = append(out, u)
+	}
+	if err := rows.Err(); err != nil {
+		return pagination.Page[*User]{}, err
+	}
+
+	return pagination.Page[*User]{Items: out, Total: total, Limit: limit, Offset: filter.Page.Offset}, nil
+}
+
+// Get loads a single account by ID.
+func (d *DAO) Get(ctx context.Context, id string) (*User, error) {
+	row := d.db.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE id = ?`, id)
+	return scanUser(row)
+}
+
+// GetByEmail loads a single account by email, for login.
+func (d *DAO) GetByEmail(ctx context.Context, email string) (*User, error) {
+	row := d.db.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE email = ?`, email)
+	return scanUser(row)
+}
+
+// Create inserts a new account. passwordHash is a hash already produced
+// by auth.HashPassword (or auth.HashPasswordArgon2id); this package
+// never sees the raw password.
+func (d *DAO) Create(ctx context.Context, email, passwordHash string, plan Plan) (*User, error) {
+	now := time.Now().UTC()
+	u := &User{
+		ID:           uuid.NewString(),
+		Email:        email,
+		PasswordHash: passwordHash,
+		Status:       StatusActive,
+		Plan:         plan,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	_, err := d.db.ExecContext(ctx, `
+		INSERT INTO users (id, email, password_hash, status, plan, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		u.ID, u.Email, u.PasswordHash, string(u.Status), string(u.Plan), u.CreatedAt, u.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("user: create %s: %w", email, err)
+	}
+	return u, nil
+}
+
+// CreateSubAccount inserts a new account owned by parentUserID, the way
+// Create inserts an ordinary one. The parent is responsible for
+// allocating it a traffic quota afterward via pkg/reseller.DAO.Allocate;
+// a sub-account with no allocation is unlimited, mirroring how a
+// PolicyResolver with no configured policy falls back to unlimited.
+func (d *DAO) CreateSubAccount(ctx context.Context, parentUserID, email, passwordHash string, plan Plan) (*User, error) {
+	now := time.Now().UTC()
+	u := &User{
+		ID:           uuid.NewString(),
+		Email:        email,
+		PasswordHash: passwordHash,
+		Status:       StatusActive,
+		Plan:         plan,
+		ParentUserID: parentUserID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	_, err := d.db.ExecContext(ctx, `
+		INSERT INTO users (id, email, password_hash, status, plan, parent_user_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		u.ID, u.Email, u.PasswordHash, string(u.Status), string(u.Plan), u.ParentUserID, u.CreatedAt, u.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("user: create sub-account %s: %w", email, err)
+	}
+	return u, nil
+}
+
+// ListByParent returns every sub-account parentUserID has created.
+func (d *DAO) ListByParent(ctx context.Context, parentUserID string) ([]*User, error) {
+	rows, err := d.db.QueryContext(ctx, `SELECT `+userColumns+` FROM users WHERE parent_user_id = ? ORDER BY created_at`, parentUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, u)
+	}
+	return out, rows.Err()
+}
+
+// CountByStatus returns the number of accounts in each status, keyed by
+// Status. Statuses with no accounts are simply absent from the map.
+// This backs the admin dashboard's total/active user counts.
+func (d *DAO) CountByStatus(ctx context.Context) (map[Status]int64, error) {
+	rows, err := d.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM users GROUP BY status`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[Status]int64)
+	for rows.Next() {
+		var status string
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[Status(status)] = count
+	}
+	return counts, rows.Err()
+}
+
+// UpdateStatus transitions an account to a new status (e.g. suspending or
+// reactivating it).
+func (d *DAO) UpdateStatus(ctx context.Context, id string, status Status) error {
+	return d.exec(ctx, `UPDATE users SET status = ?, updated_at = ? WHERE id = ?`, string(status), id)
+}
+
+// UpdatePlan changes an account's subscription plan.
+func (d *DAO) UpdatePlan(ctx context.Context, id string, plan Plan) error {
+	return d.exec(ctx, `UPDATE users SET plan = ?, updated_at = ? WHERE id = ?`, string(plan), id)
+}
+
+// SoftDelete marks an account deleted without removing its row, so usage
+// history and billing records remain intact.
+func (d *DAO) SoftDelete(ctx context.Context, id string) error {
+	return d.exec(ctx, `UPDATE users SET status = ?, updated_at = ? WHERE id = ?`, string(StatusDeleted), id)
+}
+
+// ResetPassword assigns a new random password to the account and returns
+// it in the clear so an operator can relay it to the user out of band.
+// Only its bcrypt hash is ever stored.
+func (d *DAO) ResetPassword(ctx context.Context, id string) (string, error) {
+	raw, err := randomPassword()
+	if err != nil {
+		return "", err
+	}
+	hash, err := auth.HashPassword(raw)
+	if err != nil {
+		return "", err
+	}
+	return raw, d.exec(ctx, `UPDATE users SET password_hash = ?, updated_at = ? WHERE id = ?`, hash, id)
+}
+
+// SetPasswordHash overwrites the account's stored password hash, for a
+// self-service password change or reset-token redemption where the
+// caller (not this package) already produced the hash via
+// auth.HashPassword.
+func (d *DAO) SetPasswordHash(ctx context.Context, id, passwordHash string) error {
+	return d.exec(ctx, `UPDATE users SET password_hash = ?, updated_at = ? WHERE id = ?`, passwordHash, id)
+}
+
+// exec runs an UPDATE of the form "... SET <col> = ?, updated_at = ?
+// WHERE id = ?" and reports ErrNotFound if id didn't match a row.
+func (d *DAO) exec(ctx context.Context, query, value, id string) error {
+	result, err := d.db.ExecContext(ctx, query, value, time.Now().UTC(), id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func randomPassword() (string, error) {
+	buf := make([]byte, 18)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanUser(row rowScanner) (*User, error) {
+	u := &User{}
+	var status, plan string
+	var parentUserID sql.NullString
+	if err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &status, &plan, &parentUserID, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("user: scan: %w", err)
+	}
+	u.Status = Status(status)
+	u.Plan = Plan(plan)
+	u.ParentUserID = parentUserID.String
+	return u, nil
+}
@@ -0,0 +1,16 @@
+//go:build integration
+
+package user_test
+
+import (
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/daofake"
+	"github.com/XXXXD-cation/proxy-platform/pkg/testsupport"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// Run with: go test -tags=integration ./pkg/user/...
+func TestDAOConformsToDAOInterface(t *testing.T) {
+	daofake.ConformUser(t, user.NewDAO(testsupport.GetTestDB(t)))
+}
@@ -0,0 +1,91 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/auth"
+)
+
+// userColumnsQualified is userColumns with each column prefixed by the
+// users table alias, for the GetByOAuthIdentity join where an
+// unqualified "id" would be ambiguous against user_oauth_identities.id.
+const userColumnsQualified = "u.id, u.email, u.password_hash, u.status, u.plan, u.created_at, u.updated_at"
+
+// GetByOAuthIdentity loads the account linked to a given provider's
+// providerUserID, or ErrNotFound if no account has linked it yet.
+func (d *DAO) GetByOAuthIdentity(ctx context.Context, provider, providerUserID string) (*User, error) {
+	row := d.db.QueryRowContext(ctx, `
+		SELECT `+userColumnsQualified+`
+		FROM users u
+		JOIN user_oauth_identities i ON i.user_id = u.id
+		WHERE i.provider = ? AND i.provider_user_id = ?`,
+		provider, providerUserID)
+	return scanUser(row)
+}
+
+// LinkOAuthIdentity records that provider's providerUserID maps to an
+// existing account, e.g. when a user who already registered with a
+// password connects a social login afterward.
+func (d *DAO) LinkOAuthIdentity(ctx context.Context, userID, provider, providerUserID string) error {
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO user_oauth_identities (id, user_id, provider, provider_user_id, created_at) VALUES (?, ?, ?, ?, ?)`,
+		uuid.NewString(), userID, provider, providerUserID, time.Now().UTC())
+	return err
+}
+
+// CreateFromOAuth auto-provisions a new free-plan account for a first-
+// time OAuth login and links it to provider/providerUserID in the same
+// transaction. The account gets an unusable random password hash since
+// it has no password of its own; ResetPassword or a future "set
+// password" flow can give it one later.
+func (d *DAO) CreateFromOAuth(ctx context.Context, email, provider, providerUserID string) (*User, error) {
+	raw, err := randomPassword()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := auth.HashPassword(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.rawDB == nil {
+		return nil, errors.New("user: DAO scoped to a transaction cannot start a nested transaction")
+	}
+	tx, err := d.rawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	u := &User{
+		ID:           uuid.NewString(),
+		Email:        email,
+		PasswordHash: hash,
+		Status:       StatusActive,
+		Plan:         PlanFree,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO users (id, email, password_hash, status, plan, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		u.ID, u.Email, u.PasswordHash, string(u.Status), string(u.Plan), u.CreatedAt, u.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("user: create from oauth %s: %w", email, err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO user_oauth_identities (id, user_id, provider, provider_user_id, created_at) VALUES (?, ?, ?, ?, ?)`,
+		uuid.NewString(), u.ID, provider, providerUserID, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
@@ -0,0 +1,82 @@
+// Package user defines the platform's end-user account model, used by
+// admin-api for account management and by the gateway for authorization.
+package user
+
+import "time"
+
+// Status is the lifecycle state of a user account.
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusSuspended Status = "suspended"
+	StatusDeleted   Status = "deleted"
+	// StatusPendingVerification is a newly self-registered account that
+	// hasn't redeemed its email verification token yet. It can't log in
+	// (see services/api/internal/handlers.AuthHandlers.Login) and has no
+	// subscription or API key provisioned until it does.
+	StatusPendingVerification Status = "pending_verification"
+)
+
+// Plan is the subscription tier controlling a user's rate limits and
+// feature access.
+type Plan string
+
+const (
+	PlanFree       Plan = "free"
+	PlanPro        Plan = "pro"
+	PlanEnterprise Plan = "enterprise"
+)
+
+// IsPremium reports whether p is a paid tier, used to gate features
+// reserved for paying customers (e.g. excluding blacklisted proxies).
+func (p Plan) IsPremium() bool {
+	return p == PlanPro || p == PlanEnterprise
+}
+
+// AllowsUDPAssociate reports whether p may use the gateway's SOCKS5
+// UDP ASSOCIATE support, reserved for paying customers the same way
+// IsPremium gates other heavier-weight features.
+func (p Plan) AllowsUDPAssociate() bool {
+	return p.IsPremium()
+}
+
+// QoSClass is a plan's scheduling priority under proxy contention: which
+// class of request gets first access to the best-scoring proxies when
+// there aren't enough of them to go around.
+type QoSClass string
+
+const (
+	// QoSStandard draws from the general pool with no score preference.
+	QoSStandard QoSClass = "standard"
+	// QoSPriority gets priority access to top-scored proxies.
+	QoSPriority QoSClass = "priority"
+)
+
+// QoSClass maps p to its scheduling priority class: only the top
+// (enterprise) tier gets priority access to top-scored proxies, so a
+// pro-plan customer sees no change in scheduling from today.
+func (p Plan) QoSClass() QoSClass {
+	if p == PlanEnterprise {
+		return QoSPriority
+	}
+	return QoSStandard
+}
+
+// User is a single platform account.
+type User struct {
+	ID           string
+	Email        string
+	PasswordHash string
+	Status       Status
+	Plan         Plan
+	// ParentUserID, if non-empty, marks this account as a reseller
+	// sub-account created and managed by the account it names: the
+	// parent allocates it a share of traffic via
+	// pkg/reseller.DAO.Allocate, can view its usage rollups, and can
+	// suspend it by calling UpdateStatus the same way it would for any
+	// other account.
+	ParentUserID string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,47 @@
+package validate
+
+import "github.com/XXXXD-cation/proxy-platform/pkg/locale"
+
+// Locale selects the language of a validation failure message. It's an
+// alias of pkg/locale.Locale so validate and pkg/apierrors share one
+// definition of which languages this deployment serves.
+type Locale = locale.Locale
+
+const (
+	LocaleEN = locale.EN
+	LocaleZH = locale.ZH
+)
+
+// LocaleFromAcceptLanguage maps an Accept-Language header value to a
+// supported Locale; see locale.FromAcceptLanguage.
+func LocaleFromAcceptLanguage(header string) Locale {
+	return locale.FromAcceptLanguage(header)
+}
+
+type messageSet struct {
+	required string
+	email    string
+	min      string
+	max      string
+	len      string
+	oneof    string
+}
+
+var messages = map[Locale]messageSet{
+	LocaleEN: {
+		required: "is required",
+		email:    "must be a valid email address",
+		min:      "must be at least %d",
+		max:      "must be at most %d",
+		len:      "must be exactly %d",
+		oneof:    "must be one of: %s",
+	},
+	LocaleZH: {
+		required: "为必填项",
+		email:    "必须是有效的邮箱地址",
+		min:      "不能小于 %d",
+		max:      "不能大于 %d",
+		len:      "长度必须为 %d",
+		oneof:    "必须是以下之一：%s",
+	},
+}
@@ -0,0 +1,184 @@
+// Package validate implements a minimal, struct-tag-driven request body
+// validator in the style of github.com/go-playground/validator's
+// `validate` tag. It's hand-rolled rather than vendored because this
+// module has no access to that dependency; it covers the handful of
+// rules this codebase's handlers actually need (required, email, min,
+// max, len, oneof) rather than the full upstream rule set.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FieldError describes a single failed validation rule on one field of
+// a request body.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Error satisfies the error interface, joining every FieldError's
+// message. Callers that need the individual failures should type-assert
+// to *Errors rather than parsing this string.
+type Errors struct {
+	Fields []FieldError
+}
+
+func (e *Errors) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		messages[i] = f.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+var emailPattern = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+// Struct validates v against the `validate` tags on its fields, with
+// messages in English. It's equivalent to StructLocale(v, LocaleEN).
+func Struct(v interface{}) error {
+	return StructLocale(v, LocaleEN)
+}
+
+// StructLocale validates v, which must be a pointer to a struct,
+// against the `validate` tags on its fields, producing messages in the
+// given locale (falling back to English for an unrecognized locale).
+// It returns a *Errors (satisfying error) listing every failed rule, or
+// nil if v is valid. Fields without a `validate` tag are not checked.
+func StructLocale(v interface{}, locale Locale) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("validate: Struct requires a pointer to a struct, got %T", v)
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	var errs []FieldError
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag := field.Tag.Get("validate")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		name := jsonFieldName(field)
+		value := rv.Field(i)
+
+		for _, rule := range strings.Split(tag, ",") {
+			rule = strings.TrimSpace(rule)
+			if rule == "" {
+				continue
+			}
+			if msg, ok := checkRule(value, rule, locale); !ok {
+				errs = append(errs, FieldError{Field: name, Rule: ruleName(rule), Message: fmt.Sprintf("%s %s", name, msg)})
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return &Errors{Fields: errs}
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+func ruleName(rule string) string {
+	name, _, _ := strings.Cut(rule, "=")
+	return name
+}
+
+func checkRule(value reflect.Value, rule string, locale Locale) (message string, ok bool) {
+	name, param, _ := strings.Cut(rule, "=")
+	t, known := messages[locale]
+	if !known {
+		t = messages[LocaleEN]
+	}
+
+	switch name {
+	case "required":
+		if isZero(value) {
+			return t.required, false
+		}
+	case "email":
+		if value.Kind() == reflect.String && value.String() != "" && !emailPattern.MatchString(value.String()) {
+			return t.email, false
+		}
+	case "min":
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return "", true
+		}
+		if length(value) < n {
+			return fmt.Sprintf(t.min, n), false
+		}
+	case "max":
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return "", true
+		}
+		if length(value) > n {
+			return fmt.Sprintf(t.max, n), false
+		}
+	case "len":
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return "", true
+		}
+		if length(value) != n {
+			return fmt.Sprintf(t.len, n), false
+		}
+	case "oneof":
+		options := strings.Fields(param)
+		if value.Kind() == reflect.String {
+			v := value.String()
+			for _, o := range options {
+				if v == o {
+					return "", true
+				}
+			}
+			return fmt.Sprintf(t.oneof, strings.Join(options, ", ")), false
+		}
+	}
+	return "", true
+}
+
+func isZero(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String() == ""
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	default:
+		return value.IsZero()
+	}
+}
+
+// length returns the length used by min/max/len rules: string length,
+// or numeric value for numeric kinds, or slice/map length.
+func length(value reflect.Value) int {
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return value.Len()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(value.Uint())
+	default:
+		return 0
+	}
+}
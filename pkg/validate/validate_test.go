@@ -0,0 +1,64 @@
+package validate
+
+import "testing"
+
+type signupRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8"`
+	Plan     string `json:"plan" validate:"oneof=free pro enterprise"`
+}
+
+func TestStructPasses(t *testing.T) {
+	req := signupRequest{Email: "a@example.com", Password: "hunter22", Plan: "pro"}
+	if err := Struct(&req); err != nil {
+		t.Fatalf("Struct() = %v, want nil", err)
+	}
+}
+
+func TestStructReportsEachFailure(t *testing.T) {
+	req := signupRequest{Email: "not-an-email", Password: "short", Plan: "trial"}
+	err := Struct(&req)
+	if err == nil {
+		t.Fatal("Struct() = nil, want error")
+	}
+	fields := err.(*Errors).Fields
+	if len(fields) != 3 {
+		t.Fatalf("got %d field errors, want 3: %+v", len(fields), fields)
+	}
+}
+
+func TestStructRequiredCatchesEmptyString(t *testing.T) {
+	req := signupRequest{Password: "hunter22", Plan: "free"}
+	err := Struct(&req)
+	if err == nil {
+		t.Fatal("Struct() = nil, want error for missing required email")
+	}
+	fields := err.(*Errors).Fields
+	if len(fields) != 1 || fields[0].Field != "email" || fields[0].Rule != "required" {
+		t.Fatalf("got %+v, want a single required error on email", fields)
+	}
+}
+
+func TestStructLocaleZH(t *testing.T) {
+	req := signupRequest{Password: "hunter22", Plan: "free"}
+	err := StructLocale(&req, LocaleZH)
+	if err == nil {
+		t.Fatal("StructLocale() = nil, want error")
+	}
+	fields := err.(*Errors).Fields
+	if len(fields) != 1 || fields[0].Message != "email 为必填项" {
+		t.Fatalf("got %+v, want a localized Chinese message", fields)
+	}
+}
+
+func TestLocaleFromAcceptLanguage(t *testing.T) {
+	if got := LocaleFromAcceptLanguage("zh-CN,zh;q=0.9"); got != LocaleZH {
+		t.Errorf("LocaleFromAcceptLanguage(zh-CN) = %v, want %v", got, LocaleZH)
+	}
+	if got := LocaleFromAcceptLanguage("en-US,en;q=0.9"); got != LocaleEN {
+		t.Errorf("LocaleFromAcceptLanguage(en-US) = %v, want %v", got, LocaleEN)
+	}
+	if got := LocaleFromAcceptLanguage(""); got != LocaleEN {
+		t.Errorf("LocaleFromAcceptLanguage(\"\") = %v, want %v", got, LocaleEN)
+	}
+}
@@ -0,0 +1,453 @@
+// Command admin-api serves operator and administrative endpoints: user
+// management, proxy inventory control, and the runbook automation in this
+// package. Most admin surfaces are still stubs pending later backlog
+// items; see internal/runbook for what's implemented today.
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+
+	"github.com/XXXXD-cation/proxy-platform/migrations"
+	"github.com/XXXXD-cation/proxy-platform/pkg/alerting"
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	"github.com/XXXXD-cation/proxy-platform/pkg/archive"
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/auth"
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/config"
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/eventbus"
+	"github.com/XXXXD-cation/proxy-platform/pkg/export"
+	"github.com/XXXXD-cation/proxy-platform/pkg/featureflags"
+	"github.com/XXXXD-cation/proxy-platform/pkg/headerpolicy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/idempotency"
+	"github.com/XXXXD-cation/proxy-platform/pkg/maintenance"
+	commonmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/mysql"
+	"github.com/XXXXD-cation/proxy-platform/pkg/notify"
+	"github.com/XXXXD-cation/proxy-platform/pkg/objstore"
+	"github.com/XXXXD-cation/proxy-platform/pkg/openapi"
+	"github.com/XXXXD-cation/proxy-platform/pkg/pool"
+	"github.com/XXXXD-cation/proxy-platform/pkg/ratelimit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/reconcile"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	"github.com/XXXXD-cation/proxy-platform/pkg/retention"
+	"github.com/XXXXD-cation/proxy-platform/pkg/routing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/scheduler"
+	"github.com/XXXXD-cation/proxy-platform/pkg/secrets"
+	"github.com/XXXXD-cation/proxy-platform/pkg/secretsguard"
+	"github.com/XXXXD-cation/proxy-platform/pkg/server"
+	"github.com/XXXXD-cation/proxy-platform/pkg/sqldialect"
+	"github.com/XXXXD-cation/proxy-platform/pkg/stats"
+	"github.com/XXXXD-cation/proxy-platform/pkg/upstreamtls"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/handlers"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/openapispec"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/runbook"
+)
+
+// RetentionPurgeCron, APIKeyExpirySweepCron, and
+// SubscriptionExpirySweepCron schedule the cleanup jobs registered
+// with pkg/scheduler below. The retention purge keeps its previous
+// hourly cadence; the two expiry sweeps run more often since they're
+// cheap, bounded queries rather than chunked DELETEs.
+const (
+	RetentionPurgeCron          = "0 * * * *"
+	APIKeyExpirySweepCron       = "*/15 * * * *"
+	SubscriptionExpirySweepCron = "*/15 * * * *"
+)
+
+// schedulerSweepLimit bounds how many rows the API key and subscription
+// expiry sweeps touch per run. It's generous relative to how many keys
+// or subscriptions actually expire in a 15-minute window, so in
+// practice each run clears its entire backlog without needing the
+// chunked-retry loop the (much larger, hourly) retention purge uses.
+const schedulerSweepLimit = 1000
+
+// Default retention windows for the purge job; see pkg/retention.Config.
+const (
+	proxySoftDeleteRetention = 30 * 24 * time.Hour
+	healthCheckRetention     = 14 * 24 * time.Hour
+	usageLogRetention        = 90 * 24 * time.Hour
+)
+
+// ArchiveInterval is how often the usage-log archiver exports and
+// prunes old rows, and archiveAge is how old a row must be before it's
+// eligible for export. This runs well ahead of usageLogRetention so
+// rows are archived to object storage before the retention purge job
+// would otherwise hard-delete them.
+const (
+	ArchiveInterval  = 1 * time.Hour
+	archiveAge       = 30 * 24 * time.Hour
+	archiveChunkSize = 5000
+)
+
+// AggregationInterval is how often the usage aggregator recomputes
+// usage_hourly_rollups/usage_daily_rollups. It's frequent relative to
+// an hour so a bucket is reflected soon after it completes, not just
+// once near the top of the next hour.
+const AggregationInterval = 10 * time.Minute
+
+// AlertEvaluationInterval is how often the alerting engine checks
+// configured rules against the platform's live state.
+const AlertEvaluationInterval = 5 * time.Minute
+
+// NotificationScanInterval is how often the notification producer scans
+// for subscriptions nearing renewal and users approaching their quota.
+// NotificationSendInterval is how often the notification worker drains
+// the outbox of pending emails.
+const (
+	NotificationScanInterval = 1 * time.Hour
+	NotificationSendInterval = 1 * time.Minute
+)
+
+// ExportWorkerInterval is how often the export worker drains pending
+// usage log export jobs. exportMaxRows backstops a job that somehow
+// slipped through without the enqueueing request's own plan-based row
+// limit applied, capped at the highest plan tier's limit.
+const (
+	ExportWorkerInterval = 1 * time.Minute
+	exportMaxRows        = 5_000_000
+)
+
+func main() {
+	run := server.New("admin-api")
+
+	secretsResolver := secrets.NewDefaultResolver()
+
+	dialect, err := sqldialect.Parse(secretsResolver.MustGet(run.Context(), "DB_DRIVER", ""))
+	if err != nil {
+		log.Fatalf("admin-api: %v", err)
+	}
+	if dialect != sqldialect.MySQL {
+		log.Fatalf("admin-api: DB_DRIVER=%s is not yet supported: every DAO and migration in this repo is MySQL-flavoured SQL, and this build doesn't vendor a Postgres driver", dialect)
+	}
+
+	var replicaDSNs []string
+	if v := secretsResolver.MustGet(run.Context(), "MYSQL_REPLICA_DSNS", ""); v != "" {
+		replicaDSNs = strings.Split(v, ",")
+	}
+	mysqlDB, err := mysql.Open(run.Context(), mysql.Config{
+		PrimaryDSN:  secretsResolver.MustGet(run.Context(), "MYSQL_DSN", ""),
+		ReplicaDSNs: replicaDSNs,
+	})
+	if err != nil {
+		log.Fatalf("admin-api: failed to open mysql connection: %v", err)
+	}
+	run.OnShutdown("mysql", func(context.Context) error { return mysqlDB.Close() })
+	run.Go(func(ctx context.Context) { mysqlDB.MonitorReplicas(ctx, mysql.DefaultLagPollInterval) })
+	db := mysqlDB.Primary()
+	run.RegisterDependency("mysql", db.PingContext)
+
+	if err := migrate.Run(run.Context(), db, migrate.FS); err != nil {
+		log.Fatalf("admin-api: failed to apply migrations: %v", err)
+	}
+
+	redisClient := redis.NewClient(redis.Config{
+		Addr:          secretsResolver.MustGet(run.Context(), "REDIS_ADDR", ""),
+		SentinelAddrs: redis.SplitAddrs(secretsResolver.MustGet(run.Context(), "REDIS_SENTINEL_ADDRS", "")),
+		MasterName:    secretsResolver.MustGet(run.Context(), "REDIS_MASTER_NAME", ""),
+		ClusterAddrs:  redis.SplitAddrs(secretsResolver.MustGet(run.Context(), "REDIS_CLUSTER_ADDRS", "")),
+		Password:      secretsResolver.MustGet(run.Context(), "REDIS_PASSWORD", ""),
+	})
+	run.OnShutdown("redis", func(context.Context) error { return redisClient.Close() })
+	run.RegisterDependency("redis", func(ctx context.Context) error { return redisClient.Ping(ctx).Err() })
+	hotState := redis.NewHotZSet(redisClient)
+	sessionPins := redis.NewSessionPins(redisClient)
+	latencyHistogram := redis.NewLatencyHistogram(redisClient)
+	eventBus := eventbus.NewRedisBus(redisClient)
+	proxyDAO := dao.NewProxyDAO(db)
+	reconciler := reconcile.New(hotState, proxyDAO)
+	auditLog := audit.NewLogger(db)
+	userDAO := user.NewDAO(db)
+	jwtService := auth.NewJWTServiceFromString(secretsResolver.MustGet(run.Context(), "ADMIN_JWT_SECRET", ""))
+	requireAdminAuth := middleware.RequireAdmin(jwtService)
+	idempotent := commonmiddleware.Idempotency(idempotency.NewStore(redisClient))
+	twoFactorDAO := auth.NewTwoFactorDAO(db)
+
+	invoiceDAO := billing.NewInvoiceDAO(db)
+	planDAO := billing.NewPlanDAO(db)
+	usageDAO := usage.NewDAO(db)
+	invoiceGenerator := billing.NewGenerator(userDAO, planDAO, invoiceDAO, usageDAO)
+
+	healthCheckDAO := dao.NewProxyHealthCheckDAO(db)
+	cleaner := retention.New(proxyDAO, healthCheckDAO, usageDAO, retention.Config{
+		ProxySoftDeleteRetention: proxySoftDeleteRetention,
+		HealthCheckRetention:     healthCheckRetention,
+		UsageLogRetention:        usageLogRetention,
+	})
+
+	archiveDAO := archive.NewArchiveDAO(db)
+	var archiver *archive.Archiver
+	if bucket := secretsResolver.MustGet(run.Context(), "USAGE_ARCHIVE_BUCKET", ""); bucket != "" {
+		objectStore := objstore.NewClient(
+			secretsResolver.MustGet(run.Context(), "USAGE_ARCHIVE_ENDPOINT", ""),
+			secretsResolver.MustGet(run.Context(), "USAGE_ARCHIVE_REGION", ""),
+			bucket,
+			secretsResolver.MustGet(run.Context(), "USAGE_ARCHIVE_ACCESS_KEY", ""),
+			secretsResolver.MustGet(run.Context(), "USAGE_ARCHIVE_SECRET_KEY", ""),
+		)
+		archiver = archive.New(usageDAO, archiveDAO, objectStore)
+		run.Go(func(ctx context.Context) {
+			ticker := time.NewTicker(ArchiveInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					rec, err := archiver.RunOnce(ctx, time.Now().UTC().Add(-archiveAge), archiveChunkSize)
+					if err != nil {
+						log.Printf("admin-api: usage log archive pass failed: %v", err)
+						continue
+					}
+					if rec != nil {
+						log.Printf("admin-api: archived %d usage logs to %s", rec.RowCount, rec.ObjectKey)
+					}
+				}
+			}
+		})
+	} else {
+		log.Printf("admin-api: USAGE_ARCHIVE_BUCKET not configured, usage log archival disabled")
+	}
+
+	exportDAO := export.NewDAO(db)
+	if bucket := secretsResolver.MustGet(run.Context(), "USAGE_EXPORT_BUCKET", ""); bucket != "" {
+		exportObjectStore := objstore.NewClient(
+			secretsResolver.MustGet(run.Context(), "USAGE_EXPORT_ENDPOINT", ""),
+			secretsResolver.MustGet(run.Context(), "USAGE_EXPORT_REGION", ""),
+			bucket,
+			secretsResolver.MustGet(run.Context(), "USAGE_EXPORT_ACCESS_KEY", ""),
+			secretsResolver.MustGet(run.Context(), "USAGE_EXPORT_SECRET_KEY", ""),
+		)
+		exportWorker := export.NewWorker(exportDAO, usageDAO, exportObjectStore, exportMaxRows)
+		run.Go(func(ctx context.Context) { exportWorker.Run(ctx, ExportWorkerInterval) })
+	} else {
+		log.Printf("admin-api: USAGE_EXPORT_BUCKET not configured, usage log export disabled")
+	}
+
+	rollupDAO := usage.NewRollupDAO(db)
+	aggregator := usage.NewAggregator(rollupDAO)
+	run.Go(func(ctx context.Context) { aggregator.Run(ctx, AggregationInterval) })
+
+	// The dashboard reads its own DAO instances, backed by a read
+	// replica when one is configured, since its aggregate queries run
+	// on every /api/admin/stats request and don't need to see a write
+	// that just landed on the primary a moment ago.
+	dashboard := stats.New(userDAO, proxyDAO, usage.NewDAO(mysqlDB.Reader()), usage.NewRollupDAO(mysqlDB.Reader()), healthCheckDAO)
+
+	alertRuleDAO := alerting.NewDAO(db)
+	emailNotifier := alerting.NewEmailNotifier(
+		secretsResolver.MustGet(run.Context(), "ALERT_SMTP_ADDR", ""),
+		secretsResolver.MustGet(run.Context(), "ALERT_SMTP_USERNAME", ""),
+		secretsResolver.MustGet(run.Context(), "ALERT_SMTP_PASSWORD", ""),
+		secretsResolver.MustGet(run.Context(), "ALERT_SMTP_FROM", ""),
+	)
+	subscriptionDAO := billing.NewSubscriptionDAO(db)
+	alertEngine := alerting.New(alertRuleDAO, hotState, healthCheckDAO, userDAO, subscriptionDAO, planDAO, rollupDAO, usageDAO, emailNotifier)
+	run.Go(func(ctx context.Context) { alertEngine.Run(ctx, AlertEvaluationInterval) })
+
+	keysDAO := apikey.NewDAO(db)
+	billingSubscriber := billing.NewSubscriber(subscriptionDAO, userDAO, eventBus)
+
+	jobScheduler := scheduler.New(scheduler.NewDAO(db), redisClient)
+	if err := jobScheduler.Register("retention-purge", RetentionPurgeCron, func(ctx context.Context) error {
+		stats, err := cleaner.RunOnce(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("admin-api: retention-purge removed %d proxies, %d health checks, %d usage logs",
+			stats.PurgedProxies, stats.PurgedHealthChecks, stats.PurgedUsageLogs)
+		return nil
+	}); err != nil {
+		log.Fatalf("admin-api: failed to register retention-purge job: %v", err)
+	}
+	if err := jobScheduler.Register("apikey-expiry-sweep", APIKeyExpirySweepCron, func(ctx context.Context) error {
+		deactivated, err := keysDAO.DeactivateExpired(ctx, time.Now().UTC(), schedulerSweepLimit)
+		if err != nil {
+			return err
+		}
+		log.Printf("admin-api: apikey-expiry-sweep deactivated %d expired api keys", deactivated)
+		return nil
+	}); err != nil {
+		log.Fatalf("admin-api: failed to register apikey-expiry-sweep job: %v", err)
+	}
+	if err := jobScheduler.Register("subscription-expiry-sweep", SubscriptionExpirySweepCron, func(ctx context.Context) error {
+		deactivated, err := billingSubscriber.DeactivateExpired(ctx, time.Now().UTC(), schedulerSweepLimit)
+		if err != nil {
+			return err
+		}
+		log.Printf("admin-api: subscription-expiry-sweep deactivated %d expired subscriptions", deactivated)
+		return nil
+	}); err != nil {
+		log.Fatalf("admin-api: failed to register subscription-expiry-sweep job: %v", err)
+	}
+	run.Go(func(ctx context.Context) { jobScheduler.Run(ctx) })
+
+	notificationOutbox := notify.NewOutboxDAO(db)
+	notificationPrefs := notify.NewPreferencesDAO(db)
+	mailer := notify.NewMailer(
+		secretsResolver.MustGet(run.Context(), "ALERT_SMTP_ADDR", ""),
+		secretsResolver.MustGet(run.Context(), "ALERT_SMTP_USERNAME", ""),
+		secretsResolver.MustGet(run.Context(), "ALERT_SMTP_PASSWORD", ""),
+		secretsResolver.MustGet(run.Context(), "ALERT_SMTP_FROM", ""),
+	)
+	notificationProducer := notify.NewProducer(subscriptionDAO, planDAO, userDAO, rollupDAO, usageDAO, notificationPrefs, notificationOutbox)
+	notificationWorker := notify.NewWorker(notificationOutbox, userDAO, mailer)
+	run.Go(func(ctx context.Context) { notificationProducer.Run(ctx, NotificationScanInterval) })
+	run.Go(func(ctx context.Context) { notificationWorker.Run(ctx, NotificationSendInterval) })
+
+	configDir := os.Getenv("CONFIG_DIR")
+	if configDir == "" {
+		configDir = "."
+	}
+	configWatcher, err := config.NewWatcher(configDir)
+	if err != nil {
+		log.Fatalf("admin-api: failed to start config watcher on %s: %v", configDir, err)
+	}
+	run.OnShutdown("config watcher", func(context.Context) error { return configWatcher.Close() })
+	configWatcher.OnChange(func(cfg *config.Config) {
+		log.Printf("admin-api: config reloaded, log_level=%s rate_limit_per_minute=%d", cfg.LogLevel, cfg.RateLimitPerMinute)
+	})
+	requireAllowedIP := middleware.RequireAllowedIP(configWatcher)
+	maintenanceCtl := maintenance.NewController(redisClient)
+	rejectWritesInMaintenance := middleware.RejectWritesInMaintenance(maintenanceCtl)
+	// requireAdmin is used for every route except maintenance's own
+	// enable/disable, which must keep working while maintenance mode is
+	// on so an operator can turn it back off.
+	requireAdmin := func(next http.Handler) http.Handler {
+		return requireAllowedIP(requireAdminAuth(rejectWritesInMaintenance(next)))
+	}
+	requireAdminDuringMaintenance := func(next http.Handler) http.Handler { return requireAllowedIP(requireAdminAuth(next)) }
+
+	runbookHandlers := runbook.NewHandlers(auditLog, redisClient, reconciler, nil, nil)
+	userHandlers := handlers.NewUserHandlers(userDAO, auditLog)
+	proxyHandlers := handlers.NewProxyHandlers(proxyDAO, usageDAO, latencyHistogram, auditLog, eventBus)
+	invoiceHandlers := handlers.NewInvoiceHandlers(invoiceDAO, invoiceGenerator, auditLog)
+	routingRuleHandlers := handlers.NewRoutingRuleHandlers(routing.NewDAO(db), auditLog)
+	poolHandlers := handlers.NewPoolHandlers(pool.NewDAO(db), auditLog)
+	configHandlers := handlers.NewConfigHandlers(configWatcher, auditLog)
+	twoFactorHandlers := handlers.NewTwoFactorHandlers(twoFactorDAO, auditLog)
+	usageArchiveHandlers := handlers.NewUsageArchiveHandlers(archiveDAO, archiver, auditLog)
+	statsHandlers := handlers.NewStatsHandlers(dashboard)
+	liveStatsHandlers := handlers.NewLiveStatsHandlers(redisClient, hotState, sessionPins)
+	alertRuleHandlers := handlers.NewAlertRuleHandlers(alertRuleDAO, auditLog)
+	planRateLimitHandlers := handlers.NewPlanRateLimitHandlers(ratelimit.NewPolicyDAO(db), auditLog)
+	headerPolicyHandlers := handlers.NewHeaderPolicyHandlers(headerpolicy.NewDAO(db), auditLog)
+	upstreamTLSPolicyHandlers := handlers.NewUpstreamTLSPolicyHandlers(upstreamtls.NewDAO(db), auditLog)
+	schedulerJobHandlers := handlers.NewSchedulerJobHandlers(jobScheduler, auditLog)
+	dbPoolHandlers := handlers.NewDBPoolHandlers(mysqlDB)
+	featureFlagHandlers := handlers.NewFeatureFlagHandlers(featureflags.NewDAO(db), auditLog)
+	maintenanceHandlers := handlers.NewMaintenanceHandlers(maintenanceCtl, auditLog)
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/admin/runbook/confirm", requireAdmin(http.HandlerFunc(runbookHandlers.Confirm)))
+	mux.Handle("/api/admin/runbook/flush-redis-namespace", requireAdmin(http.HandlerFunc(runbookHandlers.FlushRedisNamespace)))
+	mux.Handle("/api/admin/runbook/rebuild-hot-set", requireAdmin(http.HandlerFunc(runbookHandlers.RebuildHotSet)))
+	mux.Handle("/api/admin/runbook/resync-provider", requireAdmin(http.HandlerFunc(runbookHandlers.ResyncProvider)))
+	mux.Handle("/api/admin/runbook/restart-worker", requireAdmin(http.HandlerFunc(runbookHandlers.RestartWorker)))
+
+	requireUserManage := commonmiddleware.RequirePermission(commonmiddleware.PermUserManage)
+	mux.Handle("/api/admin/users", requireAdmin(requireUserManage(http.HandlerFunc(userHandlers.Collection))))
+	mux.Handle("/api/admin/users/", requireAdmin(requireUserManage(http.HandlerFunc(userHandlers.Item))))
+
+	requireProxyByMethod := middleware.RequirePermissionByMethod(commonmiddleware.PermProxyRead, commonmiddleware.PermProxyWrite)
+	requireProxyWrite := commonmiddleware.RequirePermission(commonmiddleware.PermProxyWrite)
+	requireProxyRead := commonmiddleware.RequirePermission(commonmiddleware.PermProxyRead)
+	mux.Handle("/api/admin/proxies", requireAdmin(requireProxyByMethod(http.HandlerFunc(proxyHandlers.Collection))))
+	mux.Handle("/api/admin/proxies/import", requireAdmin(requireProxyWrite(http.HandlerFunc(proxyHandlers.Import))))
+	mux.Handle("/api/admin/proxies/usage-report", requireAdmin(requireProxyRead(http.HandlerFunc(proxyHandlers.UsageReport))))
+	mux.Handle("/api/admin/proxies/", requireAdmin(requireProxyByMethod(http.HandlerFunc(proxyHandlers.Item))))
+
+	mux.Handle("/api/admin/invoices", requireAdmin(http.HandlerFunc(invoiceHandlers.Collection)))
+	mux.Handle("/api/admin/invoices/generate", requireAdmin(idempotent(http.HandlerFunc(invoiceHandlers.Generate))))
+	mux.Handle("/api/admin/invoices/", requireAdmin(http.HandlerFunc(invoiceHandlers.Item)))
+
+	mux.Handle("/api/admin/routing-rules", requireAdmin(http.HandlerFunc(routingRuleHandlers.Collection)))
+	mux.Handle("/api/admin/routing-rules/", requireAdmin(http.HandlerFunc(routingRuleHandlers.Item)))
+
+	mux.Handle("/api/admin/pools", requireAdmin(http.HandlerFunc(poolHandlers.Collection)))
+	mux.Handle("/api/admin/pools/", requireAdmin(http.HandlerFunc(poolHandlers.Item)))
+
+	mux.Handle("/api/admin/config/reload", requireAdmin(http.HandlerFunc(configHandlers.Reload)))
+
+	mux.Handle("/api/admin/2fa/setup", requireAdmin(http.HandlerFunc(twoFactorHandlers.Setup)))
+	mux.Handle("/api/admin/2fa/enable", requireAdmin(http.HandlerFunc(twoFactorHandlers.Enable)))
+	mux.Handle("/api/admin/2fa/disable", requireAdmin(http.HandlerFunc(twoFactorHandlers.Disable)))
+
+	mux.Handle("/api/admin/usage-archives", requireAdmin(http.HandlerFunc(usageArchiveHandlers.Collection)))
+	mux.Handle("/api/admin/usage-archives/", requireAdmin(http.HandlerFunc(usageArchiveHandlers.Item)))
+
+	mux.Handle("/api/admin/audit-logs", requireAdmin(audit.SearchHandler(auditLog)))
+	mux.Handle("/api/admin/audit-logs/export", requireAdmin(audit.ExportHandler(auditLog)))
+
+	mux.Handle("/api/admin/stats", requireAdmin(commonmiddleware.RequirePermission(commonmiddleware.PermStatsRead)(http.HandlerFunc(statsHandlers.Dashboard))))
+	mux.Handle("/api/admin/stats/stream", requireAdmin(http.HandlerFunc(liveStatsHandlers.Stream)))
+
+	mux.Handle("/api/admin/alert-rules", requireAdmin(http.HandlerFunc(alertRuleHandlers.Collection)))
+	mux.Handle("/api/admin/alert-rules/", requireAdmin(http.HandlerFunc(alertRuleHandlers.Item)))
+
+	mux.Handle("/api/admin/plan-rate-limits", requireAdmin(http.HandlerFunc(planRateLimitHandlers.Collection)))
+	mux.Handle("/api/admin/plan-rate-limits/", requireAdmin(http.HandlerFunc(planRateLimitHandlers.Item)))
+
+	mux.Handle("/api/admin/header-policies", requireAdmin(http.HandlerFunc(headerPolicyHandlers.Collection)))
+	mux.Handle("/api/admin/header-policies/", requireAdmin(http.HandlerFunc(headerPolicyHandlers.Item)))
+
+	mux.Handle("/api/admin/upstream-tls-policies", requireAdmin(http.HandlerFunc(upstreamTLSPolicyHandlers.Collection)))
+	mux.Handle("/api/admin/upstream-tls-policies/", requireAdmin(http.HandlerFunc(upstreamTLSPolicyHandlers.Item)))
+
+	mux.Handle("/api/admin/scheduler-jobs", requireAdmin(http.HandlerFunc(schedulerJobHandlers.Collection)))
+	mux.Handle("/api/admin/scheduler-jobs/", requireAdmin(http.HandlerFunc(schedulerJobHandlers.Item)))
+
+	mux.Handle("/api/admin/db-pools", requireAdmin(http.HandlerFunc(dbPoolHandlers.Stats)))
+
+	mux.Handle("/api/admin/feature-flags", requireAdmin(http.HandlerFunc(featureFlagHandlers.Collection)))
+	mux.Handle("/api/admin/feature-flags/", requireAdmin(http.HandlerFunc(featureFlagHandlers.Item)))
+
+	mux.Handle("/api/admin/maintenance", requireAdminDuringMaintenance(http.HandlerFunc(maintenanceHandlers.Status)))
+	mux.Handle("/api/admin/maintenance/enable", requireAdminDuringMaintenance(http.HandlerFunc(maintenanceHandlers.Enable)))
+	mux.Handle("/api/admin/maintenance/disable", requireAdminDuringMaintenance(http.HandlerFunc(maintenanceHandlers.Disable)))
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/readyz", run.ReadyHandler())
+	mux.HandleFunc("/openapi.json", openapi.Handler(openapispec.Build()))
+	mux.HandleFunc("/docs", openapi.UIHandler("/openapi.json"))
+
+	var allowedOrigins []string
+	if v := os.Getenv("ADMIN_API_ALLOWED_ORIGINS"); v != "" {
+		allowedOrigins = strings.Split(v, ",")
+	}
+	verifyOrigin := commonmiddleware.VerifyOrigin(allowedOrigins)
+	// /api/admin/stats/stream is a long-lived SSE response: secretsguard's
+	// buffering writer would hold it in memory until disconnect and, since
+	// it doesn't implement http.Flusher, break the handler's streaming.
+	secretsGuardSkip := func(path string) bool { return path == "/api/admin/stats/stream" }
+	secretsGuard := secretsguard.Middleware(nil, secretsGuardSkip)
+
+	addr := os.Getenv("ADMIN_API_ADDR")
+	if addr == "" {
+		addr = ":8081"
+	}
+	httpServer := &http.Server{Addr: addr, Handler: verifyOrigin(commonmiddleware.RequestID(secretsGuard(mux)))}
+	run.OnShutdown("http server", server.HTTPCloser(httpServer))
+
+	go func() {
+		log.Printf("admin-api: listening on %s", addr)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("admin-api: server failed: %v", err)
+		}
+	}()
+
+	run.Wait()
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/alerting"
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+)
+
+// AlertRuleHandlers exposes CRUD over pkg/alerting.DAO.
+type AlertRuleHandlers struct {
+	rules    *alerting.DAO
+	auditLog *audit.Logger
+}
+
+// NewAlertRuleHandlers builds AlertRuleHandlers.
+func NewAlertRuleHandlers(rules *alerting.DAO, auditLog *audit.Logger) *AlertRuleHandlers {
+	return &AlertRuleHandlers{rules: rules, auditLog: auditLog}
+}
+
+// Collection handles /api/admin/alert-rules: GET lists rules, POST
+// creates one.
+func (h *AlertRuleHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *AlertRuleHandlers) list(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.rules.List(r.Context())
+	if err != nil {
+		http.Error(w, "failed to list alert rules", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, rules)
+}
+
+type alertRuleRequest struct {
+	Type      alerting.RuleType `json:"type" validate:"required"`
+	Threshold float64           `json:"threshold"`
+	Channel   alerting.Channel  `json:"channel" validate:"required"`
+	Target    string            `json:"target" validate:"required"`
+	Enabled   bool              `json:"enabled"`
+}
+
+func (h *AlertRuleHandlers) create(w http.ResponseWriter, r *http.Request) {
+	req, ok := authmiddleware.BindAndValidate[alertRuleRequest](w, r)
+	if !ok {
+		return
+	}
+
+	rule := &alerting.Rule{
+		Type:      req.Type,
+		Threshold: req.Threshold,
+		Channel:   req.Channel,
+		Target:    req.Target,
+		Enabled:   req.Enabled,
+	}
+	if err := h.rules.Insert(r.Context(), rule); err != nil {
+		http.Error(w, "failed to create alert rule", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.alert_rule.create", rule.ID, string(rule.Type))
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, rule)
+}
+
+// Item handles /api/admin/alert-rules/{id}: PUT updates the rule, DELETE
+// removes it.
+func (h *AlertRuleHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/admin/alert-rules/")
+	if id == "" {
+		http.Error(w, "alert rule id is required", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		h.update(w, r, id)
+	case http.MethodDelete:
+		h.delete(w, r, id)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *AlertRuleHandlers) update(w http.ResponseWriter, r *http.Request, id string) {
+	req, ok := authmiddleware.BindAndValidate[alertRuleRequest](w, r)
+	if !ok {
+		return
+	}
+
+	rule := &alerting.Rule{
+		ID:        id,
+		Type:      req.Type,
+		Threshold: req.Threshold,
+		Channel:   req.Channel,
+		Target:    req.Target,
+		Enabled:   req.Enabled,
+	}
+	if err := h.rules.Update(r.Context(), rule); err != nil {
+		http.Error(w, "failed to update alert rule", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.alert_rule.update", id, string(rule.Type))
+	writeJSON(w, rule)
+}
+
+func (h *AlertRuleHandlers) delete(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.rules.Delete(r.Context(), id); err != nil {
+		http.Error(w, "failed to delete alert rule", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "admin.alert_rule.delete", id, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AlertRuleHandlers) record(r *http.Request, action, target, detail string) {
+	entry := audit.Entry{
+		ActorID:   middleware.ActorID(r.Context()),
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("admin-api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
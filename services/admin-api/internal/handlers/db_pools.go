@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/mysql"
+)
+
+// DBPoolHandlers exposes the primary and replica MySQL connection pool
+// and replication-lag state pkg/mysql tracks, so operators can tell
+// whether reads are actually landing on replicas and whether any pool
+// is saturated.
+type DBPoolHandlers struct {
+	db *mysql.DB
+}
+
+// NewDBPoolHandlers builds DBPoolHandlers.
+func NewDBPoolHandlers(db *mysql.DB) *DBPoolHandlers {
+	return &DBPoolHandlers{db: db}
+}
+
+// Stats handles GET /api/admin/db-pools: returns the primary and every
+// replica's current pool and lag stats.
+func (h *DBPoolHandlers) Stats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, h.db.Stats())
+}
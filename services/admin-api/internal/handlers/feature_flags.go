@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/featureflags"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+)
+
+// FeatureFlagHandlers exposes CRUD over pkg/featureflags.DAO, the flags
+// every service's Resolver rolls out from.
+type FeatureFlagHandlers struct {
+	flags    *featureflags.DAO
+	auditLog *audit.Logger
+}
+
+// NewFeatureFlagHandlers builds FeatureFlagHandlers.
+func NewFeatureFlagHandlers(flags *featureflags.DAO, auditLog *audit.Logger) *FeatureFlagHandlers {
+	return &FeatureFlagHandlers{flags: flags, auditLog: auditLog}
+}
+
+// Collection handles /api/admin/feature-flags: GET lists flags, POST
+// creates or replaces one.
+func (h *FeatureFlagHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.upsert(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *FeatureFlagHandlers) list(w http.ResponseWriter, r *http.Request) {
+	flags, err := h.flags.List(r.Context())
+	if err != nil {
+		http.Error(w, "failed to list feature flags", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, flags)
+}
+
+type upsertFeatureFlagRequest struct {
+	Name           string `json:"name" validate:"required"`
+	Enabled        bool   `json:"enabled"`
+	RolloutPercent int    `json:"rollout_percent" validate:"min=0,max=100"`
+}
+
+func (h *FeatureFlagHandlers) upsert(w http.ResponseWriter, r *http.Request) {
+	req, ok := authmiddleware.BindAndValidate[upsertFeatureFlagRequest](w, r)
+	if !ok {
+		return
+	}
+
+	flag := &featureflags.Flag{
+		Name:           req.Name,
+		Enabled:        req.Enabled,
+		RolloutPercent: req.RolloutPercent,
+	}
+	if err := h.flags.Upsert(r.Context(), flag); err != nil {
+		http.Error(w, "failed to save feature flag", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.feature_flag.upsert", flag.Name, "")
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, flag)
+}
+
+// Item handles /api/admin/feature-flags/{name}: DELETE removes that
+// flag, reverting every caller to it being off.
+func (h *FeatureFlagHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/api/admin/feature-flags/")
+	if name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.flags.Delete(r.Context(), name); err != nil {
+		http.Error(w, "failed to delete feature flag", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "admin.feature_flag.delete", name, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *FeatureFlagHandlers) record(r *http.Request, action, target, detail string) {
+	entry := audit.Entry{
+		ActorID:   middleware.ActorID(r.Context()),
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("admin-api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
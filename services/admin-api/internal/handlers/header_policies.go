@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/headerpolicy"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+)
+
+// HeaderPolicyHandlers exposes CRUD over pkg/headerpolicy.DAO, the
+// per-user header rewrite rules the gateway's forwarding path applies.
+type HeaderPolicyHandlers struct {
+	policies *headerpolicy.DAO
+	auditLog *audit.Logger
+}
+
+// NewHeaderPolicyHandlers builds HeaderPolicyHandlers.
+func NewHeaderPolicyHandlers(policies *headerpolicy.DAO, auditLog *audit.Logger) *HeaderPolicyHandlers {
+	return &HeaderPolicyHandlers{policies: policies, auditLog: auditLog}
+}
+
+// Collection handles /api/admin/header-policies: GET lists policies,
+// POST creates or replaces one.
+func (h *HeaderPolicyHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.upsert(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *HeaderPolicyHandlers) list(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.policies.List(r.Context())
+	if err != nil {
+		http.Error(w, "failed to list header policies", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, policies)
+}
+
+type upsertHeaderPolicyRequest struct {
+	UserID             string            `json:"user_id" validate:"required"`
+	StripHeaders       []string          `json:"strip_headers"`
+	InjectHeaders      map[string]string `json:"inject_headers"`
+	RandomizeUserAgent bool              `json:"randomize_user_agent"`
+}
+
+func (h *HeaderPolicyHandlers) upsert(w http.ResponseWriter, r *http.Request) {
+	req, ok := authmiddleware.BindAndValidate[upsertHeaderPolicyRequest](w, r)
+	if !ok {
+		return
+	}
+
+	policy := &headerpolicy.Policy{
+		UserID:             req.UserID,
+		StripHeaders:       req.StripHeaders,
+		InjectHeaders:      req.InjectHeaders,
+		RandomizeUserAgent: req.RandomizeUserAgent,
+	}
+	if err := h.policies.Upsert(r.Context(), policy); err != nil {
+		http.Error(w, "failed to save header policy", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.header_policy.upsert", policy.UserID, "")
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, policy)
+}
+
+// Item handles /api/admin/header-policies/{user_id}: DELETE removes
+// that user's policy, reverting their traffic to unmodified headers.
+func (h *HeaderPolicyHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	userID := strings.TrimPrefix(r.URL.Path, "/api/admin/header-policies/")
+	if userID == "" {
+		http.Error(w, "user id is required", http.StatusBadRequest)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.policies.Delete(r.Context(), userID); err != nil {
+		http.Error(w, "failed to delete header policy", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "admin.header_policy.delete", userID, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HeaderPolicyHandlers) record(r *http.Request, action, target, detail string) {
+	entry := audit.Entry{
+		ActorID:   middleware.ActorID(r.Context()),
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("admin-api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
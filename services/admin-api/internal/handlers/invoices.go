@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/pagination"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+)
+
+// InvoiceHandlers exposes read, generation, and mark-paid operations
+// over pkg/billing.
+type InvoiceHandlers struct {
+	invoices  *billing.InvoiceDAO
+	generator *billing.Generator
+	auditLog  *audit.Logger
+}
+
+// NewInvoiceHandlers builds InvoiceHandlers.
+func NewInvoiceHandlers(invoices *billing.InvoiceDAO, generator *billing.Generator, auditLog *audit.Logger) *InvoiceHandlers {
+	return &InvoiceHandlers{invoices: invoices, generator: generator, auditLog: auditLog}
+}
+
+// Collection handles /api/admin/invoices: GET lists invoices, optionally
+// filtered by user_id and status.
+func (h *InvoiceHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	filter := billing.InvoiceFilter{
+		UserID: q.Get("user_id"),
+		Status: q.Get("status"),
+		Page:   pagination.Parse(q, billing.InvoiceSort, "period_start"),
+	}
+
+	page, err := h.invoices.List(r.Context(), filter)
+	if err != nil {
+		http.Error(w, "failed to list invoices", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, page)
+}
+
+type generateInvoicesRequest struct {
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+}
+
+// Generate handles POST /api/admin/invoices/generate: runs invoice
+// generation for an arbitrary period on demand, outside the regular
+// monthly schedule (e.g. to backfill or re-run after a billing plan
+// correction).
+func (h *InvoiceHandlers) Generate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[generateInvoicesRequest](w, r)
+	if !ok {
+		return
+	}
+	if !req.PeriodEnd.After(req.PeriodStart) {
+		http.Error(w, "period_end must be after period_start", http.StatusBadRequest)
+		return
+	}
+
+	generated, err := h.generator.GenerateForPeriod(r.Context(), req.PeriodStart, req.PeriodEnd)
+	if err != nil {
+		http.Error(w, "failed to generate invoices", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.invoice.generate", "", strconv.Itoa(generated))
+	writeJSON(w, map[string]int{"generated": generated})
+}
+
+// Item handles /api/admin/invoices/{id}/mark-paid.
+func (h *InvoiceHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/api/admin/invoices/")
+	id, action, _ := strings.Cut(rest, "/")
+	if id == "" {
+		http.Error(w, "invoice id is required", http.StatusBadRequest)
+		return
+	}
+
+	if action != "mark-paid" || r.Method != http.MethodPost {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	h.markPaid(w, r, id)
+}
+
+func (h *InvoiceHandlers) markPaid(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.invoices.MarkPaid(r.Context(), id, time.Now().UTC()); err != nil {
+		if err == billing.ErrNotFound {
+			http.Error(w, "invoice not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "failed to mark invoice paid", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "admin.invoice.mark_paid", id, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *InvoiceHandlers) record(r *http.Request, action, target, detail string) {
+	entry := audit.Entry{
+		ActorID:   middleware.ActorID(r.Context()),
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("admin-api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/maintenance"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+)
+
+// DefaultDrainDuration is used when an enable request doesn't specify
+// DrainSeconds.
+const DefaultDrainDuration = 5 * time.Minute
+
+// MaintenanceHandlers exposes pkg/maintenance's Controller, the single
+// switch every gateway and admin-api instance reads to decide whether
+// to reject new work.
+type MaintenanceHandlers struct {
+	maintenance *maintenance.Controller
+	auditLog    *audit.Logger
+}
+
+// NewMaintenanceHandlers builds MaintenanceHandlers.
+func NewMaintenanceHandlers(ctl *maintenance.Controller, auditLog *audit.Logger) *MaintenanceHandlers {
+	return &MaintenanceHandlers{maintenance: ctl, auditLog: auditLog}
+}
+
+// Status handles GET /api/admin/maintenance: it reports the current
+// maintenance State.
+func (h *MaintenanceHandlers) Status(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	state, err := h.maintenance.Status(r.Context())
+	if err != nil {
+		http.Error(w, "failed to read maintenance state", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, state)
+}
+
+type enableMaintenanceRequest struct {
+	Reason       string `json:"reason"`
+	DrainSeconds int    `json:"drain_seconds"`
+}
+
+// Enable handles POST /api/admin/maintenance/enable: it turns
+// maintenance mode on, rejecting new gateway sessions and making
+// admin-api's own mutating endpoints read-only until Disable is called.
+func (h *MaintenanceHandlers) Enable(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[enableMaintenanceRequest](w, r)
+	if !ok {
+		return
+	}
+
+	drainFor := DefaultDrainDuration
+	if req.DrainSeconds > 0 {
+		drainFor = time.Duration(req.DrainSeconds) * time.Second
+	}
+	if err := h.maintenance.Enable(r.Context(), req.Reason, drainFor); err != nil {
+		http.Error(w, "failed to enable maintenance mode", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.maintenance.enable", req.Reason)
+	state, err := h.maintenance.Status(r.Context())
+	if err != nil {
+		http.Error(w, "failed to read maintenance state", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, state)
+}
+
+// Disable handles POST /api/admin/maintenance/disable: it turns
+// maintenance mode off immediately.
+func (h *MaintenanceHandlers) Disable(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.maintenance.Disable(r.Context()); err != nil {
+		http.Error(w, "failed to disable maintenance mode", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "admin.maintenance.disable", "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *MaintenanceHandlers) record(r *http.Request, action, detail string) {
+	entry := audit.Entry{
+		ActorID:   middleware.ActorID(r.Context()),
+		Action:    action,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("admin-api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
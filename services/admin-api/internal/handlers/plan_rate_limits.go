@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/ratelimit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+)
+
+// PlanRateLimitHandlers exposes CRUD over pkg/ratelimit.PolicyDAO, the
+// per-plan rate limits the gateway's PolicyResolver enforces.
+type PlanRateLimitHandlers struct {
+	policies *ratelimit.PolicyDAO
+	auditLog *audit.Logger
+}
+
+// NewPlanRateLimitHandlers builds PlanRateLimitHandlers.
+func NewPlanRateLimitHandlers(policies *ratelimit.PolicyDAO, auditLog *audit.Logger) *PlanRateLimitHandlers {
+	return &PlanRateLimitHandlers{policies: policies, auditLog: auditLog}
+}
+
+// Collection handles /api/admin/plan-rate-limits: GET lists policies,
+// POST creates or replaces one.
+func (h *PlanRateLimitHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.upsert(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *PlanRateLimitHandlers) list(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.policies.List(r.Context())
+	if err != nil {
+		http.Error(w, "failed to list plan rate limits", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, policies)
+}
+
+type upsertPlanRateLimitRequest struct {
+	Plan          user.Plan           `json:"plan" validate:"required"`
+	Algorithm     ratelimit.Algorithm `json:"algorithm"`
+	Limit         int                 `json:"limit" validate:"min=1"`
+	WindowSeconds int                 `json:"window_seconds" validate:"min=1"`
+}
+
+func (h *PlanRateLimitHandlers) upsert(w http.ResponseWriter, r *http.Request) {
+	req, ok := authmiddleware.BindAndValidate[upsertPlanRateLimitRequest](w, r)
+	if !ok {
+		return
+	}
+
+	policy := &ratelimit.PlanPolicy{
+		Plan:      req.Plan,
+		Algorithm: req.Algorithm,
+		Limit:     req.Limit,
+		Window:    time.Duration(req.WindowSeconds) * time.Second,
+	}
+	if err := h.policies.Upsert(r.Context(), policy); err != nil {
+		http.Error(w, "failed to save plan rate limit", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.plan_rate_limit.upsert", string(policy.Plan), "")
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, policy)
+}
+
+// Item handles /api/admin/plan-rate-limits/{plan}: DELETE removes that
+// plan's policy, reverting it to the gateway's default limit.
+func (h *PlanRateLimitHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	plan := strings.TrimPrefix(r.URL.Path, "/api/admin/plan-rate-limits/")
+	if plan == "" {
+		http.Error(w, "plan is required", http.StatusBadRequest)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.policies.Delete(r.Context(), user.Plan(plan)); err != nil {
+		http.Error(w, "failed to delete plan rate limit", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "admin.plan_rate_limit.delete", plan, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PlanRateLimitHandlers) record(r *http.Request, action, target, detail string) {
+	entry := audit.Entry{
+		ActorID:   middleware.ActorID(r.Context()),
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("admin-api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
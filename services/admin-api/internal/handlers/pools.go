@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/pool"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+)
+
+// PoolHandlers exposes CRUD over pkg/pool.DAO.
+type PoolHandlers struct {
+	pools    *pool.DAO
+	auditLog *audit.Logger
+}
+
+// NewPoolHandlers builds PoolHandlers.
+func NewPoolHandlers(pools *pool.DAO, auditLog *audit.Logger) *PoolHandlers {
+	return &PoolHandlers{pools: pools, auditLog: auditLog}
+}
+
+// Collection handles /api/admin/pools: GET lists pools, POST creates one.
+func (h *PoolHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *PoolHandlers) list(w http.ResponseWriter, r *http.Request) {
+	pools, err := h.pools.List(r.Context())
+	if err != nil {
+		http.Error(w, "failed to list pools", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, pools)
+}
+
+type createPoolRequest struct {
+	Name            string  `json:"name" validate:"required"`
+	MaxProxies      int     `json:"max_proxies"`
+	MinQualityScore float64 `json:"min_quality_score"`
+	Priority        int     `json:"priority"`
+}
+
+func (h *PoolHandlers) create(w http.ResponseWriter, r *http.Request) {
+	req, ok := authmiddleware.BindAndValidate[createPoolRequest](w, r)
+	if !ok {
+		return
+	}
+
+	p := &pool.Pool{
+		Name:            req.Name,
+		MaxProxies:      req.MaxProxies,
+		MinQualityScore: req.MinQualityScore,
+		Priority:        req.Priority,
+	}
+	if err := h.pools.Insert(r.Context(), p); err != nil {
+		http.Error(w, "failed to create pool", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.pool.create", p.ID, p.Name)
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, p)
+}
+
+// Item handles /api/admin/pools/{id}: GET fetches, DELETE removes the
+// pool.
+func (h *PoolHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/admin/pools/")
+	if id == "" {
+		http.Error(w, "pool id is required", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.get(w, r, id)
+	case http.MethodDelete:
+		h.delete(w, r, id)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *PoolHandlers) get(w http.ResponseWriter, r *http.Request, id string) {
+	p, err := h.pools.Get(r.Context(), id)
+	if err != nil {
+		http.Error(w, "pool not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, p)
+}
+
+func (h *PoolHandlers) delete(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.pools.Delete(r.Context(), id); err != nil {
+		http.Error(w, "failed to delete pool", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "admin.pool.delete", id, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PoolHandlers) record(r *http.Request, action, target, detail string) {
+	entry := audit.Entry{
+		ActorID:   middleware.ActorID(r.Context()),
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("admin-api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
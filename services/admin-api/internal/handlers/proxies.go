@@ -0,0 +1,471 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/eventbus"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+)
+
+// quickCheckTimeout bounds the on-demand single-proxy check triggered
+// from the admin API. It only verifies TCP reachability; the full
+// protocol-aware probe runs in proxy-pool's periodic sweep.
+const quickCheckTimeout = 5 * time.Second
+
+// ProxyHandlers exposes inventory management over pkg/dao.ProxyDAO.
+type ProxyHandlers struct {
+	proxies          *dao.ProxyDAO
+	usage            *usage.DAO
+	latencyHistogram *redis.LatencyHistogram // may be nil; percentiles are omitted from proxy detail if so
+	auditLog         *audit.Logger
+	events           eventbus.Publisher
+}
+
+// NewProxyHandlers builds ProxyHandlers. latencyHistogram may be nil,
+// in which case proxy detail responses report zero percentiles instead
+// of querying Redis for them. events, if non-nil, is notified with an
+// eventbus.EventProxyDiscovered event whenever a proxy is added; a nil
+// events disables that.
+func NewProxyHandlers(proxies *dao.ProxyDAO, usageDAO *usage.DAO, latencyHistogram *redis.LatencyHistogram, auditLog *audit.Logger, events eventbus.Publisher) *ProxyHandlers {
+	return &ProxyHandlers{proxies: proxies, usage: usageDAO, latencyHistogram: latencyHistogram, auditLog: auditLog, events: events}
+}
+
+// Collection handles /api/admin/proxies: GET searches, POST adds one.
+func (h *ProxyHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.search(w, r)
+	case http.MethodPost:
+		h.add(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// searchResponse is the JSON body returned by search, with enough to
+// drive a paginated table: the page of matches, the total matching the
+// filter regardless of page, and a cursor for the next page.
+type searchResponse struct {
+	Proxies    []*proxy.Proxy `json:"proxies"`
+	Total      int64          `json:"total"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+func (h *ProxyHandlers) search(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := dao.ProxyFilter{
+		Provider: q.Get("provider"),
+		Country:  q.Get("country"),
+		Source:   proxy.Source(q.Get("source")),
+		Protocol: proxy.Protocol(q.Get("protocol")),
+		Stage:    proxy.Stage(q.Get("stage")),
+		Cursor:   q.Get("cursor"),
+	}
+	if v := q.Get("exclude_probation"); v != "" {
+		if exclude, err := strconv.ParseBool(v); err == nil {
+			filter.ExcludeProbation = exclude
+		}
+	}
+	if v := q.Get("countries"); v != "" {
+		filter.Countries = strings.Split(v, ",")
+	}
+	if v := q.Get("active"); v != "" {
+		if active, err := strconv.ParseBool(v); err == nil {
+			filter.Active = &active
+		}
+	}
+	if v := q.Get("last_checked_before"); v != "" {
+		if before, err := time.Parse(time.RFC3339, v); err == nil {
+			filter.LastCheckedBefore = &before
+		}
+	}
+	if v := q.Get("min_score"); v != "" {
+		if score, err := strconv.ParseFloat(v, 64); err == nil {
+			filter.MinScore = score
+		}
+	}
+	if v := q.Get("max_score"); v != "" {
+		if score, err := strconv.ParseFloat(v, 64); err == nil {
+			filter.MaxScore = score
+		}
+	}
+	if v := q.Get("max_latency_ms"); v != "" {
+		if latency, err := strconv.Atoi(v); err == nil {
+			filter.MaxLatencyMS = latency
+		}
+	}
+	if v := q.Get("exclude_blacklisted"); v != "" {
+		if exclude, err := strconv.ParseBool(v); err == nil {
+			filter.ExcludeBlacklisted = exclude
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		if limit, err := strconv.Atoi(v); err == nil {
+			filter.Limit = limit
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if offset, err := strconv.Atoi(v); err == nil {
+			filter.Offset = offset
+		}
+	}
+
+	proxies, total, next, err := h.proxies.Search(r.Context(), filter)
+	if err != nil {
+		http.Error(w, "failed to search proxies", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, searchResponse{Proxies: proxies, Total: total, NextCursor: next})
+}
+
+type addProxyRequest struct {
+	Host     string         `json:"host" validate:"required"`
+	Port     int            `json:"port" validate:"min=1,max=65535"`
+	Protocol proxy.Protocol `json:"protocol"`
+	Country  string         `json:"country"`
+	Provider string         `json:"provider"`
+}
+
+func (h *ProxyHandlers) add(w http.ResponseWriter, r *http.Request) {
+	req, ok := authmiddleware.BindAndValidate[addProxyRequest](w, r)
+	if !ok {
+		return
+	}
+
+	p := &proxy.Proxy{
+		Host:     req.Host,
+		Port:     req.Port,
+		Protocol: req.Protocol,
+		Status:   proxy.StatusPending,
+		Source:   proxy.SourceManual,
+		Country:  req.Country,
+		Provider: req.Provider,
+	}
+	if err := h.proxies.Create(r.Context(), p); err != nil {
+		http.Error(w, "failed to add proxy", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.proxy.add", p.ID, p.Addr())
+	h.publishDiscovered(r.Context(), p.ID)
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, p)
+}
+
+// publishDiscovered notifies h.events, if any, that proxyID was just
+// added to the pool. It's best-effort: a publish failure is logged but
+// never fails the add itself.
+func (h *ProxyHandlers) publishDiscovered(ctx context.Context, proxyID string) {
+	if h.events == nil {
+		return
+	}
+	event := eventbus.Event{Type: eventbus.EventProxyDiscovered, Fields: map[string]string{"proxy_id": proxyID}}
+	if err := h.events.Publish(ctx, event); err != nil {
+		log.Printf("admin-api: failed to publish proxy.discovered event: %v", err)
+	}
+}
+
+// Item handles /api/admin/proxies/{id}... sub-routes: disable and an
+// on-demand health check.
+func (h *ProxyHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/api/admin/proxies/")
+	id, action, _ := strings.Cut(rest, "/")
+	if id == "" {
+		http.Error(w, "proxy id is required", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		h.get(w, r, id)
+	case action == "disable" && r.Method == http.MethodPost:
+		h.disable(w, r, id)
+	case action == "check" && r.Method == http.MethodPost:
+		h.check(w, r, id)
+	case action == "pool" && r.Method == http.MethodPut:
+		h.assignPool(w, r, id)
+	case action == "stage-history" && r.Method == http.MethodGet:
+		h.stageHistory(w, r, id)
+	case action == "" && r.Method == http.MethodDelete:
+		h.delete(w, r, id)
+	default:
+		http.Error(w, "not found", http.StatusNotFound)
+	}
+}
+
+// stageHistory handles GET /api/admin/proxies/{id}/stage-history,
+// returning a proxy's probation-lifecycle transitions, most recent
+// first, so an operator can see how it arrived at its current stage.
+func (h *ProxyHandlers) stageHistory(w http.ResponseWriter, r *http.Request, id string) {
+	history, err := h.proxies.StageHistory(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to load stage history", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, history)
+}
+
+// get handles GET /api/admin/proxies/{id}, returning the proxy's
+// inventory record enriched with its Redis-backed p50/p95 latency,
+// which ProxyDAO.Get alone leaves zero.
+func (h *ProxyHandlers) get(w http.ResponseWriter, r *http.Request, id string) {
+	p, err := h.proxies.Get(r.Context(), id)
+	if err != nil {
+		http.Error(w, "proxy not found", http.StatusNotFound)
+		return
+	}
+
+	if h.latencyHistogram != nil {
+		if p50, p95, err := h.latencyHistogram.Percentiles(r.Context(), id); err == nil {
+			p.P50LatencyMS, p.P95LatencyMS = int(p50), int(p95)
+		} else if err != redis.ErrNotFound {
+			log.Printf("admin-api: failed to read latency percentiles for proxy %s: %v", id, err)
+		}
+	}
+	writeJSON(w, p)
+}
+
+type assignPoolRequest struct {
+	PoolID string `json:"pool_id"`
+}
+
+// assignPool moves a proxy into the named pool, or unassigns it if
+// pool_id is omitted or empty.
+func (h *ProxyHandlers) assignPool(w http.ResponseWriter, r *http.Request, id string) {
+	req, ok := authmiddleware.BindAndValidate[assignPoolRequest](w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.proxies.AssignPool(r.Context(), id, req.PoolID); err != nil {
+		http.Error(w, "failed to assign pool", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "admin.proxy.assign_pool", id, req.PoolID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// delete soft-deletes a proxy; it remains in MySQL for audit until the
+// retention purge job hard-deletes it.
+func (h *ProxyHandlers) delete(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.proxies.SoftDelete(r.Context(), id); err != nil {
+		http.Error(w, "failed to delete proxy", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "admin.proxy.delete", id, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ProxyHandlers) disable(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.proxies.SetStatus(r.Context(), id, proxy.StatusBanned); err != nil {
+		http.Error(w, "failed to disable proxy", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "admin.proxy.disable", id, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// check triggers an immediate, out-of-band TCP reachability probe for a
+// single proxy and records the result, without waiting for proxy-pool's
+// next periodic sweep.
+func (h *ProxyHandlers) check(w http.ResponseWriter, r *http.Request, id string) {
+	p, err := h.proxies.Get(r.Context(), id)
+	if err != nil {
+		http.Error(w, "proxy not found", http.StatusNotFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), quickCheckTimeout)
+	defer cancel()
+
+	dialer := net.Dialer{Timeout: quickCheckTimeout}
+	conn, dialErr := dialer.DialContext(ctx, "tcp", p.Addr())
+	success := dialErr == nil
+	if conn != nil {
+		conn.Close()
+	}
+
+	if _, err := h.proxies.MarkAsChecked(r.Context(), id, success, time.Now().UTC()); err != nil {
+		http.Error(w, "failed to record check result", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.proxy.check", id, p.Addr())
+	writeJSON(w, map[string]bool{"success": success})
+}
+
+type importResult struct {
+	Imported int `json:"imported"`
+	Inserted int `json:"inserted"`
+	Updated  int `json:"updated"`
+}
+
+// Import bulk-imports proxies from an uploaded CSV or JSON file at
+// /api/admin/proxies/import. CSV rows are "host,port,protocol"; JSON is
+// an array of addProxyRequest objects.
+func (h *ProxyHandlers) Import(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var proxies []*proxy.Proxy
+	var err error
+	if strings.Contains(r.Header.Get("Content-Type"), "json") {
+		proxies, err = parseJSONImport(r.Body)
+	} else {
+		proxies, err = parseCSVImport(r.Body)
+	}
+	if err != nil {
+		http.Error(w, "failed to parse import file: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, p := range proxies {
+		p.Status = proxy.StatusPending
+		p.Source = proxy.SourceManual
+	}
+	inserted, updated, err := h.proxies.BulkUpsert(r.Context(), proxies)
+	if err != nil {
+		http.Error(w, "failed to import proxies", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.proxy.bulk_import", "", strconv.Itoa(len(proxies)))
+	writeJSON(w, importResult{Imported: len(proxies), Inserted: inserted, Updated: updated})
+}
+
+func parseJSONImport(body io.Reader) ([]*proxy.Proxy, error) {
+	var reqs []addProxyRequest
+	if err := json.NewDecoder(body).Decode(&reqs); err != nil {
+		return nil, err
+	}
+	proxies := make([]*proxy.Proxy, 0, len(reqs))
+	for _, req := range reqs {
+		proxies = append(proxies, &proxy.Proxy{
+			Host: req.Host, Port: req.Port, Protocol: req.Protocol,
+			Country: req.Country, Provider: req.Provider,
+		})
+	}
+	return proxies, nil
+}
+
+func parseCSVImport(body io.Reader) ([]*proxy.Proxy, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+
+	var proxies []*proxy.Proxy
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 {
+			continue
+		}
+
+		port, err := strconv.Atoi(strings.TrimSpace(record[1]))
+		if err != nil {
+			continue
+		}
+		protocol := proxy.ProtocolHTTP
+		if len(record) >= 3 && record[2] != "" {
+			protocol = proxy.Protocol(strings.TrimSpace(record[2]))
+		}
+		proxies = append(proxies, &proxy.Proxy{
+			Host:     strings.TrimSpace(record[0]),
+			Port:     port,
+			Protocol: protocol,
+		})
+	}
+	return proxies, nil
+}
+
+// defaultUsageReportRange is how far back UsageReport looks when the
+// caller doesn't pass from/to query parameters.
+const defaultUsageReportRange = 24 * time.Hour
+
+// defaultUsageReportMinRequests excludes a proxy from UsageReport unless
+// it has at least this many requests in range, so a single failed
+// request on an otherwise-idle proxy doesn't dominate the report.
+const defaultUsageReportMinRequests = 10
+
+// UsageReport handles GET /api/admin/proxies/usage-report: returns the
+// proxies with the most errors in the time range given by the
+// "from"/"to" query parameters (RFC 3339, defaulting to the last 24
+// hours), to guide which upstreams are worth pruning from the pool.
+// "min_requests" and "limit" override defaultUsageReportMinRequests and
+// the row count respectively.
+func (h *ProxyHandlers) UsageReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-defaultUsageReportRange)
+
+	q := r.URL.Query()
+	if v := q.Get("from"); v != "" {
+		if parsed, err := time.Parse(time.RFC3339, v); err == nil {
+			start = parsed
+		}
+	}
+	if v := q.Get("to"); v != "" {
+		if parsed, err := time.Parse(time.RFC3339, v); err == nil {
+			end = parsed
+		}
+	}
+
+	minRequests := int64(defaultUsageReportMinRequests)
+	if v := q.Get("min_requests"); v != "" {
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+			minRequests = parsed
+		}
+	}
+	limit := 20
+	if v := q.Get("limit"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			limit = parsed
+		}
+	}
+
+	report, err := h.usage.TopProxiesByErrors(r.Context(), start, end, minRequests, limit)
+	if err != nil {
+		http.Error(w, "failed to compute proxy usage report", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, report)
+}
+
+func (h *ProxyHandlers) record(r *http.Request, action, target, detail string) {
+	entry := audit.Entry{
+		ActorID:   middleware.ActorID(r.Context()),
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("admin-api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
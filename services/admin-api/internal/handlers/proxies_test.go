@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+func TestParseCSVImportSkipsMalformedRows(t *testing.T) {
+	body := "1.2.3.4,8080,http\nnot-enough-fields\n5.6.7.8,1080,socks5\n9.9.9.9,not-a-port\n"
+
+	proxies, err := parseCSVImport(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("parseCSVImport: %v", err)
+	}
+	if len(proxies) != 2 {
+		t.Fatalf("expected 2 proxies, got %d: %+v", len(proxies), proxies)
+	}
+	if proxies[0].Host != "1.2.3.4" || proxies[0].Port != 8080 || proxies[0].Protocol != proxy.ProtocolHTTP {
+		t.Fatalf("unexpected first proxy: %+v", proxies[0])
+	}
+	if proxies[1].Protocol != proxy.ProtocolSOCKS5 {
+		t.Fatalf("unexpected second proxy protocol: %+v", proxies[1])
+	}
+}
+
+func TestParseCSVImportDefaultsToHTTPProtocol(t *testing.T) {
+	proxies, err := parseCSVImport(strings.NewReader("1.2.3.4,3128\n"))
+	if err != nil {
+		t.Fatalf("parseCSVImport: %v", err)
+	}
+	if len(proxies) != 1 || proxies[0].Protocol != proxy.ProtocolHTTP {
+		t.Fatalf("unexpected proxies: %+v", proxies)
+	}
+}
+
+func TestParseJSONImport(t *testing.T) {
+	body := `[{"host":"1.2.3.4","port":8080,"protocol":"http","country":"US"}]`
+
+	proxies, err := parseJSONImport(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("parseJSONImport: %v", err)
+	}
+	if len(proxies) != 1 || proxies[0].Country != "US" {
+		t.Fatalf("unexpected proxies: %+v", proxies)
+	}
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/routing"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+)
+
+// RoutingRuleHandlers exposes CRUD over pkg/routing.DAO.
+type RoutingRuleHandlers struct {
+	rules    *routing.DAO
+	auditLog *audit.Logger
+}
+
+// NewRoutingRuleHandlers builds RoutingRuleHandlers.
+func NewRoutingRuleHandlers(rules *routing.DAO, auditLog *audit.Logger) *RoutingRuleHandlers {
+	return &RoutingRuleHandlers{rules: rules, auditLog: auditLog}
+}
+
+// Collection handles /api/admin/routing-rules: GET lists rules, POST
+// creates one.
+func (h *RoutingRuleHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *RoutingRuleHandlers) list(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.rules.List(r.Context())
+	if err != nil {
+		http.Error(w, "failed to list routing rules", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, rules)
+}
+
+type createRoutingRuleRequest struct {
+	Pattern  string         `json:"pattern" validate:"required"`
+	Country  string         `json:"country"`
+	Protocol proxy.Protocol `json:"protocol"`
+	MinScore float64        `json:"min_score"`
+	Priority int            `json:"priority"`
+}
+
+func (h *RoutingRuleHandlers) create(w http.ResponseWriter, r *http.Request) {
+	req, ok := authmiddleware.BindAndValidate[createRoutingRuleRequest](w, r)
+	if !ok {
+		return
+	}
+
+	rule := &routing.Rule{
+		Pattern:  req.Pattern,
+		Country:  req.Country,
+		Protocol: req.Protocol,
+		MinScore: req.MinScore,
+		Priority: req.Priority,
+	}
+	if err := h.rules.Insert(r.Context(), rule); err != nil {
+		http.Error(w, "failed to create routing rule", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.routing_rule.create", rule.ID, rule.Pattern)
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, rule)
+}
+
+// Item handles /api/admin/routing-rules/{id}: DELETE removes the rule.
+func (h *RoutingRuleHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/admin/routing-rules/")
+	if id == "" {
+		http.Error(w, "routing rule id is required", http.StatusBadRequest)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.rules.Delete(r.Context(), id); err != nil {
+		http.Error(w, "failed to delete routing rule", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "admin.routing_rule.delete", id, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *RoutingRuleHandlers) record(r *http.Request, action, target, detail string) {
+	entry := audit.Entry{
+		ActorID:   middleware.ActorID(r.Context()),
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("admin-api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
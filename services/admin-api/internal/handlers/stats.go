@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/stats"
+)
+
+// defaultStatsRange is how far back /api/admin/stats looks when the
+// caller doesn't pass from/to query parameters.
+const defaultStatsRange = 7 * 24 * time.Hour
+
+// StatsHandlers exposes the admin dashboard's aggregate view over
+// pkg/stats.
+type StatsHandlers struct {
+	dashboard *stats.Service
+}
+
+// NewStatsHandlers builds StatsHandlers.
+func NewStatsHandlers(dashboard *stats.Service) *StatsHandlers {
+	return &StatsHandlers{dashboard: dashboard}
+}
+
+// Dashboard handles GET /api/admin/stats: returns aggregate platform
+// stats for the time range given by the "from"/"to" query parameters
+// (RFC 3339), defaulting to the last 7 days.
+func (h *StatsHandlers) Dashboard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-defaultStatsRange)
+
+	q := r.URL.Query()
+	if v := q.Get("from"); v != "" {
+		if parsed, err := time.Parse(time.RFC3339, v); err == nil {
+			start = parsed
+		}
+	}
+	if v := q.Get("to"); v != "" {
+		if parsed, err := time.Parse(time.RFC3339, v); err == nil {
+			end = parsed
+		}
+	}
+
+	result, err := h.dashboard.Dashboard(r.Context(), start, end)
+	if err != nil {
+		http.Error(w, "failed to compute dashboard stats", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, result)
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+)
+
+// liveStatsInterval is how often the live stats stream pushes a new
+// snapshot to connected clients.
+const liveStatsInterval = 2 * time.Second
+
+// LiveStatsHandlers streams live gateway traffic counters over SSE so
+// the admin dashboard doesn't have to poll /api/admin/stats for numbers
+// that change every second.
+type LiveStatsHandlers struct {
+	redisClient goredis.UniversalClient
+	hotPool     *redis.HotZSet
+	sessions    *redis.SessionPins
+}
+
+// NewLiveStatsHandlers builds LiveStatsHandlers.
+func NewLiveStatsHandlers(redisClient goredis.UniversalClient, hotPool *redis.HotZSet, sessions *redis.SessionPins) *LiveStatsHandlers {
+	return &LiveStatsHandlers{redisClient: redisClient, hotPool: hotPool, sessions: sessions}
+}
+
+// Stream handles GET /api/admin/stats/stream: it pushes a JSON
+// redis.LiveSnapshot as a server-sent event every liveStatsInterval
+// until the client disconnects.
+func (h *LiveStatsHandlers) Stream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(liveStatsInterval)
+	defer ticker.Stop()
+
+	ctx := r.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			snap, err := redis.Snapshot(ctx, h.redisClient, h.hotPool, h.sessions)
+			if err != nil {
+				fmt.Fprintf(w, "event: error\ndata: %q\n\n", err.Error())
+				flusher.Flush()
+				continue
+			}
+			payload, err := json.Marshal(snap)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			flusher.Flush()
+		}
+	}
+}
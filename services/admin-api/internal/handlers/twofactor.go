@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/auth"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+)
+
+// totpIssuer names the platform in the otpauth:// URI an authenticator
+// app displays alongside the account.
+const totpIssuer = "proxy-platform"
+
+// TwoFactorHandlers lets an authenticated admin provision, confirm, and
+// remove TOTP-based two-factor auth on their own account.
+type TwoFactorHandlers struct {
+	twoFactor *auth.TwoFactorDAO
+	auditLog  *audit.Logger
+}
+
+// NewTwoFactorHandlers builds TwoFactorHandlers.
+func NewTwoFactorHandlers(twoFactor *auth.TwoFactorDAO, auditLog *audit.Logger) *TwoFactorHandlers {
+	return &TwoFactorHandlers{twoFactor: twoFactor, auditLog: auditLog}
+}
+
+type setupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// Setup handles POST /api/admin/2fa/setup: it generates a new TOTP
+// secret for the caller and returns it alongside a QR-code-ready
+// provisioning URI. The secret isn't active until confirmed via Enable.
+func (h *TwoFactorHandlers) Setup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	actorID := middleware.ActorID(r.Context())
+	secret, err := h.twoFactor.BeginSetup(r.Context(), actorID)
+	if err != nil {
+		http.Error(w, "failed to start two-factor setup", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, setupResponse{
+		Secret:          secret,
+		ProvisioningURI: auth.TOTPProvisioningURI(totpIssuer, actorID, secret),
+	})
+}
+
+type verifyCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type enableResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Enable handles POST /api/admin/2fa/enable: it confirms the pending
+// secret from Setup by checking a live TOTP code, then turns two-factor
+// auth on and returns a batch of recovery codes shown only this once.
+func (h *TwoFactorHandlers) Enable(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[verifyCodeRequest](w, r)
+	if !ok {
+		return
+	}
+
+	actorID := middleware.ActorID(r.Context())
+	recoveryCodes, err := h.twoFactor.Enable(r.Context(), actorID, req.Code)
+	if err != nil {
+		h.respondVerifyErr(w, err, "failed to enable two-factor auth")
+		return
+	}
+
+	h.record(r, "admin.2fa.enable", actorID)
+	writeJSON(w, enableResponse{RecoveryCodes: recoveryCodes})
+}
+
+// Disable handles POST /api/admin/2fa/disable: it requires a valid TOTP
+// or recovery code before turning two-factor auth off, so a stolen
+// session token alone can't be used to downgrade an account's security.
+func (h *TwoFactorHandlers) Disable(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[verifyCodeRequest](w, r)
+	if !ok {
+		return
+	}
+
+	actorID := middleware.ActorID(r.Context())
+	if err := h.twoFactor.VerifyCode(r.Context(), actorID, req.Code); err != nil {
+		h.respondVerifyErr(w, err, "failed to verify two-factor code")
+		return
+	}
+	if err := h.twoFactor.Disable(r.Context(), actorID); err != nil {
+		http.Error(w, "failed to disable two-factor auth", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.2fa.disable", actorID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TwoFactorHandlers) respondVerifyErr(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, auth.ErrTwoFactorNotPending):
+		http.Error(w, "two-factor setup has not been started", http.StatusConflict)
+	case errors.Is(err, auth.ErrTwoFactorCodeInvalid):
+		http.Error(w, "invalid two-factor code", http.StatusUnauthorized)
+	default:
+		http.Error(w, msg, http.StatusInternalServerError)
+	}
+}
+
+func (h *TwoFactorHandlers) record(r *http.Request, action, actorID string) {
+	entry := audit.Entry{
+		ActorID:   actorID,
+		Action:    action,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("admin-api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
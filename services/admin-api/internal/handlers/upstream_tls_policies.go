@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/upstreamtls"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+)
+
+// UpstreamTLSPolicyHandlers exposes CRUD over pkg/upstreamtls.DAO, the
+// per-provider TLS verification rules the gateway applies when dialing
+// an upstream proxy that speaks HTTPS.
+type UpstreamTLSPolicyHandlers struct {
+	policies *upstreamtls.DAO
+	auditLog *audit.Logger
+}
+
+// NewUpstreamTLSPolicyHandlers builds UpstreamTLSPolicyHandlers.
+func NewUpstreamTLSPolicyHandlers(policies *upstreamtls.DAO, auditLog *audit.Logger) *UpstreamTLSPolicyHandlers {
+	return &UpstreamTLSPolicyHandlers{policies: policies, auditLog: auditLog}
+}
+
+// Collection handles /api/admin/upstream-tls-policies: GET lists
+// policies, POST creates or replaces one.
+func (h *UpstreamTLSPolicyHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.upsert(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *UpstreamTLSPolicyHandlers) list(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.policies.List(r.Context())
+	if err != nil {
+		http.Error(w, "failed to list upstream TLS policies", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, policies)
+}
+
+type upsertUpstreamTLSPolicyRequest struct {
+	Provider   string `json:"provider" validate:"required"`
+	CABundle   string `json:"ca_bundle"`
+	SkipVerify bool   `json:"skip_verify"`
+}
+
+func (h *UpstreamTLSPolicyHandlers) upsert(w http.ResponseWriter, r *http.Request) {
+	req, ok := authmiddleware.BindAndValidate[upsertUpstreamTLSPolicyRequest](w, r)
+	if !ok {
+		return
+	}
+
+	policy := &upstreamtls.Policy{
+		Provider:   req.Provider,
+		CABundle:   []byte(req.CABundle),
+		SkipVerify: req.SkipVerify,
+	}
+	if err := h.policies.Upsert(r.Context(), policy); err != nil {
+		http.Error(w, "failed to save upstream TLS policy", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.upstream_tls_policy.upsert", policy.Provider, "")
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, policy)
+}
+
+// Item handles /api/admin/upstream-tls-policies/{provider}: DELETE
+// removes that provider's policy, reverting its HTTPS upstreams to
+// system root pool verification.
+func (h *UpstreamTLSPolicyHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	provider := strings.TrimPrefix(r.URL.Path, "/api/admin/upstream-tls-policies/")
+	if provider == "" {
+		http.Error(w, "provider is required", http.StatusBadRequest)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.policies.Delete(r.Context(), provider); err != nil {
+		http.Error(w, "failed to delete upstream TLS policy", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "admin.upstream_tls_policy.delete", provider, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *UpstreamTLSPolicyHandlers) record(r *http.Request, action, target, detail string) {
+	entry := audit.Entry{
+		ActorID:   middleware.ActorID(r.Context()),
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("admin-api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/archive"
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/pagination"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+)
+
+// UsageArchiveHandlers exposes read and restore operations over
+// pkg/archive's record of usage_logs rows exported to object storage.
+// Creating archives happens on the Archiver's own schedule, not through
+// this API.
+type UsageArchiveHandlers struct {
+	archives *archive.ArchiveDAO
+	archiver *archive.Archiver
+	auditLog *audit.Logger
+}
+
+// NewUsageArchiveHandlers builds UsageArchiveHandlers.
+func NewUsageArchiveHandlers(archives *archive.ArchiveDAO, archiver *archive.Archiver, auditLog *audit.Logger) *UsageArchiveHandlers {
+	return &UsageArchiveHandlers{archives: archives, archiver: archiver, auditLog: auditLog}
+}
+
+// Collection handles GET /api/admin/usage-archives: lists archives,
+// newest first.
+func (h *UsageArchiveHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	page, err := h.archives.List(r.Context(), pagination.Parse(r.URL.Query(), nil, ""))
+	if err != nil {
+		http.Error(w, "failed to list usage archives", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, page)
+}
+
+// Item handles /api/admin/usage-archives/{id}/restore.
+func (h *UsageArchiveHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/api/admin/usage-archives/")
+	id, action, _ := strings.Cut(rest, "/")
+	if id == "" {
+		http.Error(w, "archive id is required", http.StatusBadRequest)
+		return
+	}
+
+	if action != "restore" || r.Method != http.MethodPost {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	h.restore(w, r, id)
+}
+
+func (h *UsageArchiveHandlers) restore(w http.ResponseWriter, r *http.Request, id string) {
+	if h.archiver == nil {
+		http.Error(w, "usage log archival is not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	logs, err := h.archiver.Restore(r.Context(), id)
+	if err != nil {
+		if err == archive.ErrNotFound {
+			http.Error(w, "archive not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "failed to restore archive", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.usage_archive.restore", id, strconv.Itoa(len(logs)))
+	writeJSON(w, map[string]int{"restored": len(logs)})
+}
+
+func (h *UsageArchiveHandlers) record(r *http.Request, action, target, detail string) {
+	entry := audit.Entry{
+		ActorID:   middleware.ActorID(r.Context()),
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("admin-api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
@@ -0,0 +1,199 @@
+// Package handlers implements the admin-api HTTP surface for managing
+// platform resources (today: user accounts).
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/auth"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/pagination"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+)
+
+// UserHandlers exposes CRUD over pkg/user.DAO.
+type UserHandlers struct {
+	users    *user.DAO
+	auditLog *audit.Logger
+}
+
+// NewUserHandlers builds UserHandlers.
+func NewUserHandlers(users *user.DAO, auditLog *audit.Logger) *UserHandlers {
+	return &UserHandlers{users: users, auditLog: auditLog}
+}
+
+// Collection handles /api/admin/users: GET lists accounts, POST creates
+// one.
+func (h *UserHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *UserHandlers) list(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := user.ListFilter{
+		Status: user.Status(q.Get("status")),
+		Plan:   user.Plan(q.Get("plan")),
+		Page:   pagination.Parse(q, user.ListSort, "created_at"),
+	}
+
+	page, err := h.users.List(r.Context(), filter)
+	if err != nil {
+		http.Error(w, "failed to list users", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, page)
+}
+
+type createUserRequest struct {
+	Email    string    `json:"email" validate:"required,email"`
+	Password string    `json:"password" validate:"required"`
+	Plan     user.Plan `json:"plan"`
+}
+
+func (h *UserHandlers) create(w http.ResponseWriter, r *http.Request) {
+	req, ok := authmiddleware.BindAndValidate[createUserRequest](w, r)
+	if !ok {
+		return
+	}
+	if req.Plan == "" {
+		req.Plan = user.PlanFree
+	}
+	if err := auth.ValidatePassword(req.Password); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		http.Error(w, "failed to hash password", http.StatusInternalServerError)
+		return
+	}
+
+	created, err := h.users.Create(r.Context(), req.Email, hash, req.Plan)
+	if err != nil {
+		http.Error(w, "failed to create user", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "admin.user.create", created.ID, created.Email)
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, created)
+}
+
+// Item handles /api/admin/users/{id}... sub-routes: status, plan,
+// reset-password and the bare resource (DELETE for soft delete).
+func (h *UserHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/api/admin/users/")
+	id, action, _ := strings.Cut(rest, "/")
+	if id == "" {
+		http.Error(w, "user id is required", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodDelete:
+		h.softDelete(w, r, id)
+	case action == "status" && r.Method == http.MethodPatch:
+		h.updateStatus(w, r, id)
+	case action == "plan" && r.Method == http.MethodPatch:
+		h.updatePlan(w, r, id)
+	case action == "reset-password" && r.Method == http.MethodPost:
+		h.resetPassword(w, r, id)
+	default:
+		http.Error(w, "not found", http.StatusNotFound)
+	}
+}
+
+func (h *UserHandlers) softDelete(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.users.SoftDelete(r.Context(), id); err != nil {
+		h.respondUpdateErr(w, err, "failed to delete user")
+		return
+	}
+	h.record(r, "admin.user.soft_delete", id, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type updateStatusRequest struct {
+	Status user.Status `json:"status" validate:"required"`
+}
+
+func (h *UserHandlers) updateStatus(w http.ResponseWriter, r *http.Request, id string) {
+	req, ok := authmiddleware.BindAndValidate[updateStatusRequest](w, r)
+	if !ok {
+		return
+	}
+	if err := h.users.UpdateStatus(r.Context(), id, req.Status); err != nil {
+		h.respondUpdateErr(w, err, "failed to update status")
+		return
+	}
+	h.record(r, "admin.user.update_status", id, string(req.Status))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type updatePlanRequest struct {
+	Plan user.Plan `json:"plan" validate:"required"`
+}
+
+func (h *UserHandlers) updatePlan(w http.ResponseWriter, r *http.Request, id string) {
+	req, ok := authmiddleware.BindAndValidate[updatePlanRequest](w, r)
+	if !ok {
+		return
+	}
+	if err := h.users.UpdatePlan(r.Context(), id, req.Plan); err != nil {
+		h.respondUpdateErr(w, err, "failed to update plan")
+		return
+	}
+	h.record(r, "admin.user.update_plan", id, string(req.Plan))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *UserHandlers) resetPassword(w http.ResponseWriter, r *http.Request, id string) {
+	newPassword, err := h.users.ResetPassword(r.Context(), id)
+	if err != nil {
+		h.respondUpdateErr(w, err, "failed to reset password")
+		return
+	}
+	h.record(r, "admin.user.reset_password", id, "")
+	writeJSON(w, map[string]string{"password": newPassword})
+}
+
+func (h *UserHandlers) respondUpdateErr(w http.ResponseWriter, err error, msg string) {
+	if err == user.ErrNotFound {
+		http.Error(w, "user not found", http.StatusNotFound)
+		return
+	}
+	http.Error(w, msg, http.StatusInternalServerError)
+}
+
+func (h *UserHandlers) record(r *http.Request, action, target, detail string) {
+	entry := audit.Entry{
+		ActorID:   middleware.ActorID(r.Context()),
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		// The admin action has already committed; don't fail the
+		// request over a logging error.
+		log.Printf("admin-api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
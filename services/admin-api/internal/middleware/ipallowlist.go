@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apierrors"
+	"github.com/XXXXD-cation/proxy-platform/pkg/config"
+)
+
+// RequireAllowedIP rejects requests whose source IP isn't permitted by
+// the live config's Security.AdminIPAllowlist (CIDR ranges and bare
+// IPv4/IPv6 addresses both supported; see config.Security.Allows). It
+// reads the allowlist fresh from watcher on every request, so an
+// operator editing config.json takes effect without a restart. An empty
+// allowlist permits everything, so a fresh deployment isn't locked out
+// before an operator configures one.
+func RequireAllowedIP(watcher *config.Watcher) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			host, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				host = r.RemoteAddr
+			}
+
+			if !watcher.Get().Security.Allows(host) {
+				apierrors.Write(w, r, apierrors.New(apierrors.CodeForbidden, "source ip not permitted"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apierrors"
+	"github.com/XXXXD-cation/proxy-platform/pkg/maintenance"
+)
+
+// RejectWritesInMaintenance makes admin-api read-only while maintenance
+// mode is on: GET and HEAD requests pass through unchanged, but any
+// other method is rejected before it reaches the handler. Wrap routes
+// that need to work during maintenance (maintenance's own
+// enable/disable endpoints, in particular) with RequireAdmin directly
+// instead of this middleware.
+func RejectWritesInMaintenance(ctl *maintenance.Controller) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			enabled, err := ctl.Enabled(r.Context())
+			if err == nil && enabled {
+				apierrors.Write(w, r, apierrors.New(apierrors.CodeReadOnly, "platform is in maintenance mode"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
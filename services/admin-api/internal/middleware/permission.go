@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+)
+
+// RequirePermissionByMethod gates a route that handles both a read and a
+// write method behind authmiddleware.RequirePermission, picking read for
+// GET/HEAD and write for everything else. It exists because several
+// admin-api routes (e.g. /api/admin/proxies) multiplex read and write
+// operations onto one handler, so the permission can't be fixed at
+// registration time the way RequirePermission alone assumes.
+func RequirePermissionByMethod(read, write authmiddleware.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			perm := write
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				perm = read
+			}
+			authmiddleware.RequirePermission(perm)(next).ServeHTTP(w, r)
+		})
+	}
+}
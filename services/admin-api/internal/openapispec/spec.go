@@ -0,0 +1,96 @@
+// Package openapispec documents admin-api's HTTP surface as an OpenAPI
+// 3 document, served at /openapi.json (and rendered at /docs). Routes
+// are declared here by hand rather than derived from the mux or
+// handler signatures, so this file needs updating alongside main.go
+// whenever a route is added, removed, or reshaped.
+package openapispec
+
+import "github.com/XXXXD-cation/proxy-platform/pkg/openapi"
+
+// Build returns the admin-api OpenAPI document.
+func Build() *openapi.Builder {
+	b := openapi.NewBuilder(openapi.Info{
+		Title:       "proxy-platform admin API",
+		Version:     "1.0.0",
+		Description: "Operator-facing API for managing accounts, billing, routing, and platform configuration. Every request must carry an admin JWT; most mutating routes are additionally recorded to the audit log.",
+	})
+
+	ok := func(desc string) map[string]openapi.Response {
+		return map[string]openapi.Response{"200": {Description: desc}}
+	}
+
+	b.Add("/api/admin/users", "GET", openapi.Operation{Summary: "List accounts", Tags: []string{"users"}, Responses: ok("a page of accounts")})
+	b.Add("/api/admin/users", "POST", openapi.Operation{Summary: "Create an account", Tags: []string{"users"}, Responses: map[string]openapi.Response{"201": {Description: "the created account"}}})
+	b.Add("/api/admin/users/{id}", "GET", openapi.Operation{Summary: "Get an account", Tags: []string{"users"}, Responses: ok("the account")})
+
+	b.Add("/api/admin/proxies", "GET", openapi.Operation{Summary: "List proxies", Tags: []string{"proxies"}, Responses: ok("matching proxies")})
+	b.Add("/api/admin/proxies/import", "POST", openapi.Operation{Summary: "Bulk-import proxies", Tags: []string{"proxies"}, Responses: ok("import result summary")})
+	b.Add("/api/admin/proxies/usage-report", "GET", openapi.Operation{Summary: "Top proxies by error count, for pool pruning", Tags: []string{"proxies"}, Responses: ok("proxies ranked by error count")})
+	b.Add("/api/admin/proxies/{id}", "GET", openapi.Operation{Summary: "Get a proxy, including p50/p95 latency", Tags: []string{"proxies"}, Responses: ok("the proxy")})
+	b.Add("/api/admin/proxies/{id}/stage-history", "GET", openapi.Operation{Summary: "Get a proxy's probation-lifecycle stage transitions", Tags: []string{"proxies"}, Responses: ok("the proxy's stage transitions, most recent first")})
+
+	b.Add("/api/admin/invoices", "GET", openapi.Operation{Summary: "List invoices", Tags: []string{"billing"}, Responses: ok("a page of invoices")})
+	b.Add("/api/admin/invoices/generate", "POST", openapi.Operation{Summary: "Generate invoices for a period", Tags: []string{"billing"}, Responses: ok("generated invoice count")})
+	b.Add("/api/admin/invoices/{id}/mark-paid", "POST", openapi.Operation{Summary: "Mark an invoice paid", Tags: []string{"billing"}, Responses: map[string]openapi.Response{"204": {Description: "marked paid"}}})
+
+	b.Add("/api/admin/routing-rules", "GET", openapi.Operation{Summary: "List routing rules", Tags: []string{"routing"}, Responses: ok("all routing rules")})
+	b.Add("/api/admin/routing-rules/{id}", "PUT", openapi.Operation{Summary: "Replace a routing rule", Tags: []string{"routing"}, Responses: ok("the updated rule")})
+
+	b.Add("/api/admin/pools", "GET", openapi.Operation{Summary: "List proxy pools", Tags: []string{"pools"}, Responses: ok("all proxy pools")})
+	b.Add("/api/admin/pools", "POST", openapi.Operation{Summary: "Create a proxy pool", Tags: []string{"pools"}, Responses: map[string]openapi.Response{"201": {Description: "the created pool"}}})
+	b.Add("/api/admin/pools/{id}", "GET", openapi.Operation{Summary: "Get a proxy pool", Tags: []string{"pools"}, Responses: ok("the pool")})
+	b.Add("/api/admin/proxies/{id}/pool", "PUT", openapi.Operation{Summary: "Assign a proxy to a pool", Tags: []string{"pools"}, Responses: map[string]openapi.Response{"204": {Description: "assigned"}}})
+
+	b.Add("/api/admin/config/reload", "POST", openapi.Operation{Summary: "Force a config hot-reload", Tags: []string{"config"}, Responses: map[string]openapi.Response{"204": {Description: "reloaded"}}})
+
+	b.Add("/api/admin/2fa/setup", "POST", openapi.Operation{Summary: "Begin admin 2FA enrollment", Tags: []string{"auth"}, Responses: ok("TOTP secret and QR payload")})
+	b.Add("/api/admin/2fa/enable", "POST", openapi.Operation{Summary: "Confirm and enable admin 2FA", Tags: []string{"auth"}, Responses: map[string]openapi.Response{"204": {Description: "enabled"}}})
+	b.Add("/api/admin/2fa/disable", "POST", openapi.Operation{Summary: "Disable admin 2FA", Tags: []string{"auth"}, Responses: map[string]openapi.Response{"204": {Description: "disabled"}}})
+
+	b.Add("/api/admin/usage-archives", "GET", openapi.Operation{Summary: "List usage log archives", Tags: []string{"usage"}, Responses: ok("a page of archives")})
+	b.Add("/api/admin/usage-archives/{id}/restore", "POST", openapi.Operation{Summary: "Restore an archived usage window", Tags: []string{"usage"}, Responses: ok("restored row count")})
+
+	b.Add("/api/admin/audit-logs", "GET", openapi.Operation{Summary: "Search the audit log", Tags: []string{"audit"}, Responses: ok("a page of audit entries")})
+	b.Add("/api/admin/audit-logs/export", "GET", openapi.Operation{Summary: "Export the audit log as CSV", Tags: []string{"audit"}, Responses: map[string]openapi.Response{"200": {Description: "audit-log.csv"}}})
+
+	b.Add("/api/admin/stats", "GET", openapi.Operation{Summary: "Dashboard summary stats", Tags: []string{"stats"}, Responses: ok("aggregate platform stats")})
+	b.Add("/api/admin/stats/stream", "GET", openapi.Operation{Summary: "Live stats stream (SSE)", Tags: []string{"stats"}, Responses: ok("text/event-stream of stat updates")})
+
+	b.Add("/api/admin/alert-rules", "GET", openapi.Operation{Summary: "List alert rules", Tags: []string{"alerting"}, Responses: ok("all alert rules")})
+	b.Add("/api/admin/alert-rules/{id}", "PUT", openapi.Operation{Summary: "Replace an alert rule", Tags: []string{"alerting"}, Responses: ok("the updated rule")})
+
+	b.Add("/api/admin/plan-rate-limits", "GET", openapi.Operation{Summary: "List per-plan rate limit policies", Tags: []string{"rate limiting"}, Responses: ok("all policies")})
+	b.Add("/api/admin/plan-rate-limits/{plan}", "PUT", openapi.Operation{Summary: "Replace a plan's rate limit policy", Tags: []string{"rate limiting"}, Responses: ok("the updated policy")})
+
+	b.Add("/api/admin/header-policies", "GET", openapi.Operation{Summary: "List per-user header rewrite policies", Tags: []string{"gateway"}, Responses: ok("all policies")})
+	b.Add("/api/admin/header-policies/{user_id}", "PUT", openapi.Operation{Summary: "Replace a user's header rewrite policy", Tags: []string{"gateway"}, Responses: ok("the updated policy")})
+
+	b.Add("/api/admin/upstream-tls-policies", "GET", openapi.Operation{Summary: "List per-provider upstream TLS verification policies", Tags: []string{"gateway"}, Responses: ok("all policies")})
+	b.Add("/api/admin/upstream-tls-policies/{provider}", "PUT", openapi.Operation{Summary: "Replace a provider's upstream TLS verification policy", Tags: []string{"gateway"}, Responses: ok("the updated policy")})
+
+	b.Add("/api/admin/feature-flags", "GET", openapi.Operation{Summary: "List feature flags", Tags: []string{"feature flags"}, Responses: ok("all flags")})
+	b.Add("/api/admin/feature-flags", "POST", openapi.Operation{Summary: "Create or replace a feature flag", Tags: []string{"feature flags"}, Responses: map[string]openapi.Response{"201": {Description: "the created or replaced flag"}}})
+	b.Add("/api/admin/feature-flags/{name}", "DELETE", openapi.Operation{Summary: "Delete a feature flag", Tags: []string{"feature flags"}, Responses: map[string]openapi.Response{"204": {Description: "deleted"}}})
+
+	b.Add("/api/admin/maintenance", "GET", openapi.Operation{Summary: "Get platform maintenance mode state", Tags: []string{"maintenance"}, Responses: ok("current maintenance state")})
+	b.Add("/api/admin/maintenance/enable", "POST", openapi.Operation{Summary: "Enable maintenance mode", Description: "Gateways reject new sessions immediately; existing tunnels are left to drain on their own. admin-api's own mutating endpoints (other than this one and disable) start returning 503 until maintenance is disabled.", Tags: []string{"maintenance"}, Responses: ok("the maintenance state now in effect")})
+	b.Add("/api/admin/maintenance/disable", "POST", openapi.Operation{Summary: "Disable maintenance mode", Tags: []string{"maintenance"}, Responses: map[string]openapi.Response{"204": {Description: "disabled"}}})
+
+	b.Add("/api/admin/scheduler-jobs", "GET", openapi.Operation{Summary: "List background jobs with their schedule, pause state, and last run", Tags: []string{"scheduler"}, Responses: ok("all jobs")})
+	b.Add("/api/admin/scheduler-jobs/{name}/trigger", "POST", openapi.Operation{Summary: "Run a background job immediately", Tags: []string{"scheduler"}, Responses: map[string]openapi.Response{"202": {Description: "run triggered"}}})
+	b.Add("/api/admin/scheduler-jobs/{name}/pause", "POST", openapi.Operation{Summary: "Pause a background job", Tags: []string{"scheduler"}, Responses: map[string]openapi.Response{"204": {Description: "job paused"}}})
+	b.Add("/api/admin/scheduler-jobs/{name}/resume", "POST", openapi.Operation{Summary: "Resume a paused background job", Tags: []string{"scheduler"}, Responses: map[string]openapi.Response{"204": {Description: "job resumed"}}})
+
+	b.Add("/api/admin/db-pools", "GET", openapi.Operation{Summary: "MySQL primary and replica connection pool and replication-lag stats", Tags: []string{"stats"}, Responses: ok("pool stats")})
+
+	b.Add("/api/admin/runbook/confirm", "POST", openapi.Operation{Summary: "Issue a confirmation token for a runbook action", Tags: []string{"runbook"}, Responses: ok("confirmation token")})
+	b.Add("/api/admin/runbook/flush-redis-namespace", "POST", openapi.Operation{Summary: "Flush a Redis key namespace", Tags: []string{"runbook"}, Responses: ok("deleted key count")})
+	b.Add("/api/admin/runbook/rebuild-hot-set", "POST", openapi.Operation{Summary: "Rebuild the Redis hot proxy set from MySQL", Tags: []string{"runbook"}, Responses: ok("loaded proxy count")})
+	b.Add("/api/admin/runbook/resync-provider", "POST", openapi.Operation{Summary: "Force an out-of-band proxy source re-sync", Tags: []string{"runbook"}, Responses: map[string]openapi.Response{"202": {Description: "re-sync triggered"}}})
+	b.Add("/api/admin/runbook/restart-worker", "POST", openapi.Operation{Summary: "Restart a background worker", Tags: []string{"runbook"}, Responses: map[string]openapi.Response{"202": {Description: "restart triggered"}}})
+
+	b.Add("/healthz", "GET", openapi.Operation{Summary: "Liveness probe", Tags: []string{"ops"}, Responses: map[string]openapi.Response{"200": {Description: "process is up"}}})
+	b.Add("/readyz", "GET", openapi.Operation{Summary: "Readiness probe", Description: "Runs every registered dependency check (MySQL ping, Redis ping) and reports each one's status and latency in the response body.", Tags: []string{"ops"}, Responses: map[string]openapi.Response{"200": {Description: "ready to serve traffic; body reports per-dependency status"}, "503": {Description: "not ready: shutting down or a dependency check failed; body reports which"}}})
+
+	return b
+}
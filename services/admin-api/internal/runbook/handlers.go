@@ -0,0 +1,226 @@
+package runbook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/reconcile"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	"github.com/XXXXD-cation/proxy-platform/services/admin-api/internal/middleware"
+)
+
+// Resyncer triggers an out-of-band re-sync of a proxy source (e.g. a
+// free-crawler source or a paid provider). It is satisfied by whatever
+// scheduler each source-owning service exposes; admin-api only knows the
+// name it was asked to re-sync.
+type Resyncer interface {
+	Resync(ctx context.Context, provider string) error
+}
+
+// WorkerRestarter restarts a named background worker (health checkers,
+// the reconciler loop, feedback recorder, ...) without restarting the
+// whole process.
+type WorkerRestarter interface {
+	RestartWorker(ctx context.Context, worker string) error
+}
+
+// Handlers wires the runbook actions to HTTP endpoints. Every mutating
+// action is two-phase: a POST .../confirm issues a token describing the
+// intended action, and the actual POST must carry that token back.
+type Handlers struct {
+	tokens      *TokenStore
+	auditLog    *audit.Logger
+	redisClient goredis.UniversalClient
+	reconciler  *reconcile.Reconciler
+	resyncer    Resyncer
+	restarter   WorkerRestarter
+}
+
+// NewHandlers builds the runbook Handlers. resyncer and restarter may be
+// nil if the host service doesn't support those actions yet; the
+// corresponding endpoints respond 501 Not Implemented in that case.
+func NewHandlers(auditLog *audit.Logger, redisClient goredis.UniversalClient, reconciler *reconcile.Reconciler, resyncer Resyncer, restarter WorkerRestarter) *Handlers {
+	return &Handlers{
+		tokens:      NewTokenStore(),
+		auditLog:    auditLog,
+		redisClient: redisClient,
+		reconciler:  reconciler,
+		resyncer:    resyncer,
+		restarter:   restarter,
+	}
+}
+
+// confirmRequest describes the action a caller wants a token for.
+type confirmRequest struct {
+	Action string `json:"action"`
+}
+
+type confirmResponse struct {
+	Token string `json:"token"`
+}
+
+// Confirm issues a confirmation token for the named action. Call this
+// first, then replay the action's own endpoint with that token.
+func (h *Handlers) Confirm(w http.ResponseWriter, r *http.Request) {
+	var req confirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Action == "" {
+		http.Error(w, "action is required", http.StatusBadRequest)
+		return
+	}
+
+	token, err := h.tokens.Issue(req.Action)
+	if err != nil {
+		http.Error(w, "failed to issue confirmation token", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, confirmResponse{Token: token})
+}
+
+type flushRedisRequest struct {
+	Namespace string `json:"namespace"`
+	Token     string `json:"token"`
+}
+
+// FlushRedisNamespace deletes every key under a Redis namespace, e.g. to
+// clear a poisoned cache without touching unrelated keys.
+func (h *Handlers) FlushRedisNamespace(w http.ResponseWriter, r *http.Request) {
+	var req flushRedisRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Namespace == "" {
+		http.Error(w, "namespace is required", http.StatusBadRequest)
+		return
+	}
+
+	const action = "runbook.flush_redis_namespace"
+	if err := h.tokens.Consume(req.Token, action); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	deleted, err := redis.FlushNamespace(r.Context(), h.redisClient, req.Namespace)
+	if err != nil {
+		http.Error(w, "failed to flush namespace", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, action, req.Namespace, fmt.Sprintf("deleted %d keys", deleted))
+	writeJSON(w, map[string]int{"deleted": deleted})
+}
+
+// RebuildHotSet wipes and repopulates the Redis hot proxy set from MySQL.
+func (h *Handlers) RebuildHotSet(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	const action = "runbook.rebuild_hot_set"
+	if err := h.tokens.Consume(req.Token, action); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	loaded, err := h.reconciler.RebuildFromMySQL(r.Context())
+	if err != nil {
+		http.Error(w, "failed to rebuild hot set", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, action, "hotset", fmt.Sprintf("reloaded %d proxies from mysql", loaded))
+	writeJSON(w, map[string]int{"loaded": loaded})
+}
+
+type resyncProviderRequest struct {
+	Provider string `json:"provider"`
+	Token    string `json:"token"`
+}
+
+// ResyncProvider forces an out-of-band re-sync of a single proxy source.
+func (h *Handlers) ResyncProvider(w http.ResponseWriter, r *http.Request) {
+	var req resyncProviderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Provider == "" {
+		http.Error(w, "provider is required", http.StatusBadRequest)
+		return
+	}
+
+	const action = "runbook.resync_provider"
+	if err := h.tokens.Consume(req.Token, action); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	if h.resyncer == nil {
+		http.Error(w, "provider re-sync is not supported by this deployment", http.StatusNotImplemented)
+		return
+	}
+	if err := h.resyncer.Resync(r.Context(), req.Provider); err != nil {
+		http.Error(w, "failed to resync provider", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, action, req.Provider, "provider re-sync triggered")
+	w.WriteHeader(http.StatusAccepted)
+}
+
+type restartWorkerRequest struct {
+	Worker string `json:"worker"`
+	Token  string `json:"token"`
+}
+
+// RestartWorker restarts a single named background worker.
+func (h *Handlers) RestartWorker(w http.ResponseWriter, r *http.Request) {
+	var req restartWorkerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Worker == "" {
+		http.Error(w, "worker is required", http.StatusBadRequest)
+		return
+	}
+
+	const action = "runbook.restart_worker"
+	if err := h.tokens.Consume(req.Token, action); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	if h.restarter == nil {
+		http.Error(w, "worker restart is not supported by this deployment", http.StatusNotImplemented)
+		return
+	}
+	if err := h.restarter.RestartWorker(r.Context(), req.Worker); err != nil {
+		http.Error(w, "failed to restart worker", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, action, req.Worker, "worker restart triggered")
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handlers) record(r *http.Request, action, target, detail string) {
+	ctx := r.Context()
+	entry := audit.Entry{
+		ActorID:   middleware.ActorID(ctx),
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(ctx),
+	}
+	if err := h.auditLog.Record(ctx, entry); err != nil {
+		// Auditing must never block the operator action it's recording;
+		// the action above has already committed. Surface loudly instead.
+		log.Printf("runbook: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
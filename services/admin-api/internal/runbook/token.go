@@ -0,0 +1,73 @@
+// Package runbook implements guarded operator actions for routine but
+// destructive maintenance tasks (cache flushes, pool rebuilds, provider
+// re-syncs, worker restarts). Every action requires a short-lived
+// confirmation token obtained from a prior request, so a single
+// accidental click or replayed request can't trigger it.
+package runbook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// TokenTTL is how long a confirmation token remains valid after issuance.
+const TokenTTL = 2 * time.Minute
+
+// ErrInvalidToken is returned when a confirmation token is unknown,
+// expired, or was issued for a different action.
+var ErrInvalidToken = errors.New("runbook: invalid or expired confirmation token")
+
+type pendingConfirmation struct {
+	action  string
+	expires time.Time
+}
+
+// TokenStore issues and validates one-time confirmation tokens. It is
+// in-memory and per-process: confirmation is meant to guard against a
+// single operator's double-click, not to survive a restart.
+type TokenStore struct {
+	mu      sync.Mutex
+	pending map[string]pendingConfirmation
+}
+
+// NewTokenStore creates an empty TokenStore.
+func NewTokenStore() *TokenStore {
+	return &TokenStore{pending: make(map[string]pendingConfirmation)}
+}
+
+// Issue generates a confirmation token scoped to action, valid for
+// TokenTTL.
+func (s *TokenStore) Issue(action string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	s.pending[token] = pendingConfirmation{action: action, expires: time.Now().Add(TokenTTL)}
+	s.mu.Unlock()
+
+	return token, nil
+}
+
+// Consume validates that token was issued for action and has not
+// expired, then invalidates it so it cannot be reused.
+func (s *TokenStore) Consume(token, action string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	confirmation, ok := s.pending[token]
+	if !ok {
+		return ErrInvalidToken
+	}
+	delete(s.pending, token)
+
+	if confirmation.action != action || time.Now().After(confirmation.expires) {
+		return ErrInvalidToken
+	}
+	return nil
+}
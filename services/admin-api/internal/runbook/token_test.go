@@ -0,0 +1,53 @@
+package runbook
+
+import "testing"
+
+func TestTokenStoreConsumeValidToken(t *testing.T) {
+	store := NewTokenStore()
+
+	token, err := store.Issue("runbook.rebuild_hot_set")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	if err := store.Consume(token, "runbook.rebuild_hot_set"); err != nil {
+		t.Fatalf("Consume: %v", err)
+	}
+}
+
+func TestTokenStoreRejectsWrongAction(t *testing.T) {
+	store := NewTokenStore()
+
+	token, err := store.Issue("runbook.rebuild_hot_set")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	if err := store.Consume(token, "runbook.restart_worker"); err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestTokenStoreRejectsReuse(t *testing.T) {
+	store := NewTokenStore()
+
+	token, err := store.Issue("runbook.flush_redis_namespace")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	if err := store.Consume(token, "runbook.flush_redis_namespace"); err != nil {
+		t.Fatalf("first Consume: %v", err)
+	}
+	if err := store.Consume(token, "runbook.flush_redis_namespace"); err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken on reuse, got %v", err)
+	}
+}
+
+func TestTokenStoreRejectsUnknownToken(t *testing.T) {
+	store := NewTokenStore()
+
+	if err := store.Consume("not-a-real-token", "runbook.rebuild_hot_set"); err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
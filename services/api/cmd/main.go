@@ -0,0 +1,271 @@
+// Command api serves the customer-facing REST API under /api/v1: proxy
+// retrieval, API key lifecycle management, subscription billing, and
+// usage stats retrieval. Unlike gateway, which forwards proxy traffic,
+// this service only serves management calls, so requests are logged via
+// pkg/apilog rather than pkg/usage.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	_ "github.com/go-sql-driver/mysql"
+	"google.golang.org/grpc"
+
+	"github.com/XXXXD-cation/proxy-platform/migrations"
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	"github.com/XXXXD-cation/proxy-platform/pkg/apilog"
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/auth"
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing/stripe"
+	"github.com/XXXXD-cation/proxy-platform/pkg/blocklist"
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/eventbus"
+	"github.com/XXXXD-cation/proxy-platform/pkg/export"
+	"github.com/XXXXD-cation/proxy-platform/pkg/idempotency"
+	"github.com/XXXXD-cation/proxy-platform/pkg/ipallowlist"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/notify"
+	"github.com/XXXXD-cation/proxy-platform/pkg/oauth2"
+	"github.com/XXXXD-cation/proxy-platform/pkg/objstore"
+	"github.com/XXXXD-cation/proxy-platform/pkg/onboarding"
+	"github.com/XXXXD-cation/proxy-platform/pkg/organization"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	"github.com/XXXXD-cation/proxy-platform/pkg/reseller"
+	userv1 "github.com/XXXXD-cation/proxy-platform/pkg/rpc/userv1"
+	"github.com/XXXXD-cation/proxy-platform/pkg/secrets"
+	"github.com/XXXXD-cation/proxy-platform/pkg/secretsguard"
+	"github.com/XXXXD-cation/proxy-platform/pkg/server"
+	"github.com/XXXXD-cation/proxy-platform/pkg/targetpolicy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/tracing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+	"github.com/XXXXD-cation/proxy-platform/services/api/internal/grpcserver"
+	"github.com/XXXXD-cation/proxy-platform/services/api/internal/handlers"
+	"github.com/XXXXD-cation/proxy-platform/services/api/internal/middleware"
+)
+
+func main() {
+	run := server.New("api")
+
+	shutdownTracing, err := tracing.Init(run.Context(), "api")
+	if err != nil {
+		log.Fatalf("api: failed to init tracing: %v", err)
+	}
+	run.OnShutdown("tracing", shutdownTracing)
+
+	secretsResolver := secrets.NewDefaultResolver()
+
+	db, err := sql.Open("mysql", secretsResolver.MustGet(run.Context(), "MYSQL_DSN", ""))
+	if err != nil {
+		log.Fatalf("api: failed to open mysql connection: %v", err)
+	}
+	run.OnShutdown("mysql", func(context.Context) error { return db.Close() })
+	run.RegisterDependency("mysql", db.PingContext)
+
+	if err := migrate.Run(run.Context(), db, migrate.FS); err != nil {
+		log.Fatalf("api: failed to apply migrations: %v", err)
+	}
+
+	redisClient := redis.NewClient(redis.Config{
+		Addr:          secretsResolver.MustGet(run.Context(), "REDIS_ADDR", ""),
+		SentinelAddrs: redis.SplitAddrs(secretsResolver.MustGet(run.Context(), "REDIS_SENTINEL_ADDRS", "")),
+		MasterName:    secretsResolver.MustGet(run.Context(), "REDIS_MASTER_NAME", ""),
+		ClusterAddrs:  redis.SplitAddrs(secretsResolver.MustGet(run.Context(), "REDIS_CLUSTER_ADDRS", "")),
+		Password:      secretsResolver.MustGet(run.Context(), "REDIS_PASSWORD", ""),
+	})
+	run.OnShutdown("redis", func(context.Context) error { return redisClient.Close() })
+	run.RegisterDependency("redis", func(ctx context.Context) error { return redisClient.Ping(ctx).Err() })
+
+	keys := apikey.NewDAO(db)
+	allowedIPs := ipallowlist.NewDAO(db)
+	targetPolicies := targetpolicy.NewDAO(db)
+	blocklistDAO := blocklist.NewDAO(db)
+	ipCache := ipallowlist.NewCache(redisClient)
+	proxyDAO := dao.NewProxyDAO(db)
+	userDAO := user.NewDAO(db)
+	subscriptionDAO := billing.NewSubscriptionDAO(db)
+	requestLog := apilog.NewDAO(db)
+	auditLog := audit.NewLogger(db)
+	orgDAO := organization.NewDAO(db)
+	quotaDAO := reseller.NewDAO(db)
+	quotaEnforcer := reseller.NewEnforcer(redisClient)
+
+	proxyHandlers := handlers.NewProxyHandlers(proxyDAO, userDAO)
+	requireAPIKey := middleware.RequireAPIKey(keys)
+
+	jwtService := auth.NewJWTServiceFromString(secretsResolver.MustGet(run.Context(), "API_JWT_SECRET", ""))
+	requireAuth := authmiddleware.Auth(jwtService, keys)
+	idempotent := authmiddleware.Idempotency(idempotency.NewStore(redisClient))
+	refreshTokens := auth.NewRefreshTokenService(redisClient, jwtService)
+	twoFactorDAO := auth.NewTwoFactorDAO(db)
+	credentialService := auth.NewCredentialService(redisClient)
+	loginLockout := auth.NewLoginLockout(redisClient)
+	notificationOutbox := notify.NewOutboxDAO(db)
+	onboardingService := onboarding.NewService(db, userDAO, subscriptionDAO, keys)
+	eventBus := eventbus.NewRedisBus(redisClient)
+	authHandlers := handlers.NewAuthHandlers(userDAO, onboardingService, refreshTokens, jwtService, twoFactorDAO, credentialService, loginLockout, notificationOutbox, auditLog, eventBus)
+
+	oauthStates := oauth2.NewStateStore(redisClient)
+	oauthProviders := map[string]oauth2.Provider{
+		"google": oauth2.NewGoogleProvider(
+			os.Getenv("OAUTH_GOOGLE_CLIENT_ID"),
+			secretsResolver.MustGet(run.Context(), "OAUTH_GOOGLE_CLIENT_SECRET", ""),
+			os.Getenv("OAUTH_GOOGLE_REDIRECT_URL"),
+		),
+		"github": oauth2.NewGitHubProvider(
+			os.Getenv("OAUTH_GITHUB_CLIENT_ID"),
+			secretsResolver.MustGet(run.Context(), "OAUTH_GITHUB_CLIENT_SECRET", ""),
+			os.Getenv("OAUTH_GITHUB_REDIRECT_URL"),
+		),
+	}
+	oauthHandlers := handlers.NewOAuthHandlers(userDAO, refreshTokens, oauthStates, oauthProviders)
+
+	paymentProvider := stripe.New(
+		secretsResolver.MustGet(run.Context(), "STRIPE_SECRET_KEY", ""),
+		secretsResolver.MustGet(run.Context(), "STRIPE_WEBHOOK_SECRET", ""),
+		stripe.PriceIDs{
+			user.PlanPro:        os.Getenv("STRIPE_PRICE_ID_PRO"),
+			user.PlanEnterprise: os.Getenv("STRIPE_PRICE_ID_ENTERPRISE"),
+		},
+		os.Getenv("STRIPE_CHECKOUT_SUCCESS_URL"),
+		os.Getenv("STRIPE_CHECKOUT_CANCEL_URL"),
+	)
+	subscriber := billing.NewSubscriber(subscriptionDAO, userDAO, eventBus)
+	billingHandlers := handlers.NewBillingHandlers(paymentProvider, subscriber)
+	keyHandlers := handlers.NewKeyHandlers(keys, orgDAO, targetPolicies, auditLog)
+	orgHandlers := handlers.NewOrgHandlers(orgDAO, auditLog)
+	ipAllowlistHandlers := handlers.NewIPAllowlistHandlers(allowedIPs, ipCache)
+	notificationHandlers := handlers.NewNotificationHandlers(notify.NewPreferencesDAO(db))
+	usageDAO := usage.NewDAO(db)
+	rollupDAO := usage.NewRollupDAO(db)
+	usageHandlers := handlers.NewUsageHandlers(usageDAO, rollupDAO)
+	planDAO := billing.NewPlanDAO(db)
+	statsHandlers := handlers.NewStatsHandlers(usageDAO, rollupDAO, subscriptionDAO, planDAO)
+	blocklistOverrideHandlers := handlers.NewBlocklistOverrideHandlers(blocklistDAO, subscriptionDAO, planDAO)
+	subAccountHandlers := handlers.NewSubAccountHandlers(userDAO, quotaDAO, usageDAO, rollupDAO, auditLog)
+
+	// Export jobs are enqueued here but processed by admin-api's
+	// export.Worker, the same outbox-style split pkg/notify uses between
+	// producer and worker. Both sides must point at the same bucket.
+	exportDAO := export.NewDAO(db)
+	var exportHandlers *handlers.ExportHandlers
+	if bucket := secretsResolver.MustGet(run.Context(), "USAGE_EXPORT_BUCKET", ""); bucket != "" {
+		exportObjectStore := objstore.NewClient(
+			secretsResolver.MustGet(run.Context(), "USAGE_EXPORT_ENDPOINT", ""),
+			secretsResolver.MustGet(run.Context(), "USAGE_EXPORT_REGION", ""),
+			bucket,
+			secretsResolver.MustGet(run.Context(), "USAGE_EXPORT_ACCESS_KEY", ""),
+			secretsResolver.MustGet(run.Context(), "USAGE_EXPORT_SECRET_KEY", ""),
+		)
+		exportHandlers = handlers.NewExportHandlers(exportDAO, usageDAO, subscriptionDAO, planDAO, exportObjectStore)
+	} else {
+		log.Printf("api: USAGE_EXPORT_BUCKET not configured, usage log export disabled")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/auth/register", authHandlers.Register)
+	mux.HandleFunc("/api/auth/verify", authHandlers.Verify)
+	mux.HandleFunc("/api/auth/verify/resend", authHandlers.ResendVerification)
+	mux.HandleFunc("/api/auth/password/forgot", authHandlers.ForgotPassword)
+	mux.HandleFunc("/api/auth/password/reset", authHandlers.ResetPassword)
+	mux.HandleFunc("/api/auth/login", authHandlers.Login)
+	mux.HandleFunc("/api/auth/2fa/verify", authHandlers.VerifyTwoFactor)
+	mux.HandleFunc("/api/auth/oauth/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/callback") {
+			oauthHandlers.Callback(w, r)
+			return
+		}
+		oauthHandlers.Start(w, r)
+	})
+	mux.Handle("/api/v1/proxies", requireAPIKey(authmiddleware.RequirePermission(authmiddleware.PermProxyRead)(http.HandlerFunc(proxyHandlers.List))))
+	mux.Handle("/api/v1/billing/checkout-session", requireAuth(idempotent(http.HandlerFunc(billingHandlers.CreateCheckoutSession))))
+	mux.HandleFunc("/api/v1/billing/webhook", billingHandlers.Webhook)
+	mux.Handle("/api/v1/keys", requireAuth(idempotent(http.HandlerFunc(keyHandlers.Collection))))
+	mux.Handle("/api/v1/keys/", requireAuth(http.HandlerFunc(keyHandlers.Item)))
+	mux.Handle("/api/v1/organizations", requireAuth(idempotent(http.HandlerFunc(orgHandlers.Collection))))
+	mux.Handle("/api/v1/organizations/", requireAuth(http.HandlerFunc(orgHandlers.Item)))
+	mux.Handle("/api/v1/allowed-ips", requireAuth(http.HandlerFunc(ipAllowlistHandlers.Collection)))
+	mux.Handle("/api/v1/allowed-ips/", requireAuth(http.HandlerFunc(ipAllowlistHandlers.Item)))
+	mux.Handle("/api/v1/blocklist-overrides", requireAuth(http.HandlerFunc(blocklistOverrideHandlers.Collection)))
+	mux.Handle("/api/v1/blocklist-overrides/", requireAuth(http.HandlerFunc(blocklistOverrideHandlers.Item)))
+	mux.Handle("/api/v1/notifications/preferences", requireAuth(http.HandlerFunc(notificationHandlers.Preferences)))
+	mux.Handle("/api/v1/usage/summary", requireAuth(http.HandlerFunc(usageHandlers.Summary)))
+	mux.Handle("/api/v1/stats/summary", requireAuth(authmiddleware.RequirePermission(authmiddleware.PermStatsRead)(http.HandlerFunc(statsHandlers.Summary))))
+	mux.Handle("/api/v1/stats/timeseries", requireAuth(authmiddleware.RequirePermission(authmiddleware.PermStatsRead)(http.HandlerFunc(statsHandlers.Timeseries))))
+	mux.Handle("/api/v1/stats/top-domains", requireAuth(authmiddleware.RequirePermission(authmiddleware.PermStatsRead)(http.HandlerFunc(statsHandlers.TopDomains))))
+	mux.Handle("/api/v1/subaccounts", requireAuth(idempotent(http.HandlerFunc(subAccountHandlers.Collection))))
+	mux.Handle("/api/v1/subaccounts/", requireAuth(http.HandlerFunc(subAccountHandlers.Item)))
+	if exportHandlers != nil {
+		mux.Handle("/api/v1/exports", requireAuth(idempotent(http.HandlerFunc(exportHandlers.Collection))))
+		mux.Handle("/api/v1/exports/", requireAuth(http.HandlerFunc(exportHandlers.Item)))
+	}
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/readyz", run.ReadyHandler())
+
+	var allowedOrigins []string
+	if v := os.Getenv("API_ALLOWED_ORIGINS"); v != "" {
+		allowedOrigins = strings.Split(v, ",")
+	}
+	verifyOrigin := authmiddleware.VerifyOrigin(allowedOrigins)
+
+	// secretsGuardAllow permits the few responses that are meant to show
+	// a raw key: API key creation/rotation ("key") and the one-time key
+	// issued on email verification ("api_key").
+	secretsGuardAllow := func(path, field string) bool {
+		switch {
+		case path == "/api/v1/keys" && field == "key":
+			return true
+		case strings.HasPrefix(path, "/api/v1/keys/") && field == "key":
+			return true
+		case path == "/api/auth/verify" && field == "api_key":
+			return true
+		default:
+			return false
+		}
+	}
+	secretsGuard := secretsguard.Middleware(secretsGuardAllow, nil)
+
+	grpcAddr := os.Getenv("API_GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = ":9084"
+	}
+	grpcLn, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		log.Fatalf("api: failed to listen for grpc on %s: %v", grpcAddr, err)
+	}
+	grpcServer := grpc.NewServer(tracing.ServerOption())
+	userv1.RegisterUserServiceServer(grpcServer, grpcserver.New(jwtService, keys, userDAO, allowedIPs, ipCache, quotaDAO, quotaEnforcer))
+	run.OnShutdown("grpc server", server.GRPCCloser(grpcServer))
+	go func() {
+		log.Printf("api: grpc listening on %s", grpcAddr)
+		if err := grpcServer.Serve(grpcLn); err != nil {
+			log.Fatalf("api: grpc server failed: %v", err)
+		}
+	}()
+
+	addr := os.Getenv("API_ADDR")
+	if addr == "" {
+		addr = ":8084"
+	}
+	httpServer := &http.Server{Addr: addr, Handler: tracing.Middleware("api.http", apilog.Middleware(requestLog)(verifyOrigin(authmiddleware.RequestID(secretsGuard(mux)))))}
+	run.OnShutdown("http server", server.HTTPCloser(httpServer))
+
+	go func() {
+		log.Printf("api: listening on %s", addr)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("api: server failed: %v", err)
+		}
+	}()
+
+	run.Wait()
+}
@@ -0,0 +1,139 @@
+// Package grpcserver implements api's internal gRPC API: the
+// UserService other services call to authenticate a caller's credential
+// instead of querying the users/api_keys tables directly.
+package grpcserver
+
+import (
+	"context"
+	"log"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	"github.com/XXXXD-cation/proxy-platform/pkg/auth"
+	"github.com/XXXXD-cation/proxy-platform/pkg/ipallowlist"
+	"github.com/XXXXD-cation/proxy-platform/pkg/reseller"
+	userv1 "github.com/XXXXD-cation/proxy-platform/pkg/rpc/userv1"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// Server implements userv1.UserServiceServer.
+type Server struct {
+	userv1.UnimplementedUserServiceServer
+
+	jwtService *auth.JWTService
+	keys       *apikey.DAO
+	users      *user.DAO
+	allowedIPs *ipallowlist.DAO
+	ipCache    *ipallowlist.Cache
+	quotas     reseller.DAOInterface
+	quotaCheck *reseller.Enforcer
+}
+
+// New wraps the same JWT service, API key DAO and user DAO the
+// HTTP-facing Auth middleware already uses, plus the IP allowlist DAO
+// and cache used only by the client_ip credential branch, and the
+// reseller quota DAO/Enforcer used to gate sub-account traffic.
+func New(jwtService *auth.JWTService, keys *apikey.DAO, users *user.DAO, allowedIPs *ipallowlist.DAO, ipCache *ipallowlist.Cache, quotas reseller.DAOInterface, quotaCheck *reseller.Enforcer) *Server {
+	return &Server{jwtService: jwtService, keys: keys, users: users, allowedIPs: allowedIPs, ipCache: ipCache, quotas: quotas, quotaCheck: quotaCheck}
+}
+
+// authorizeUser looks up userID's current account and reports its plan
+// alongside whether it's still allowed to authenticate: a lookup
+// failure resolves to ("", true), since plan is an enrichment rather
+// than a credential and shouldn't fail authorization outright, but a
+// suspended account or a sub-account that has exhausted its parent's
+// quota allocation resolves to allowed = false, the same way a rejected
+// credential does.
+func (s *Server) authorizeUser(ctx context.Context, userID string) (plan string, allowed bool) {
+	u, err := s.users.Get(ctx, userID)
+	if err != nil {
+		return "", true
+	}
+	if u.Status != user.StatusActive {
+		return string(u.Plan), false
+	}
+	if u.ParentUserID != "" && s.quotas != nil && s.quotaCheck != nil {
+		quota, err := s.quotas.Get(ctx, userID)
+		if err == nil {
+			ok, err := s.quotaCheck.Allow(ctx, userID, quota.AllocatedRequests)
+			if err == nil && !ok {
+				return string(u.Plan), false
+			}
+		}
+	}
+	return string(u.Plan), true
+}
+
+// Authorize validates whichever credential was set on req and resolves
+// it to an identity, mirroring pkg/middleware.Auth's own logic. An
+// invalid or expired credential is reported as allowed = false rather
+// than an error, so a caller can't mistake a network failure for "not
+// authorized".
+func (s *Server) Authorize(ctx context.Context, req *userv1.AuthorizeRequest) (*userv1.AuthorizeResponse, error) {
+	switch credential := req.Credential.(type) {
+	case *userv1.AuthorizeRequest_Jwt:
+		claims, err := s.jwtService.Parse(credential.Jwt)
+		if err != nil {
+			return &userv1.AuthorizeResponse{Allowed: false}, nil
+		}
+		plan, allowed := s.authorizeUser(ctx, claims.UserID)
+		return &userv1.AuthorizeResponse{
+			Allowed: allowed,
+			UserId:  claims.UserID,
+			Role:    string(claims.Role),
+			Plan:    plan,
+		}, nil
+
+	case *userv1.AuthorizeRequest_ApiKey:
+		key, err := s.keys.LookupByRawKey(ctx, credential.ApiKey)
+		if err != nil {
+			return &userv1.AuthorizeResponse{Allowed: false}, nil
+		}
+		plan, allowed := s.authorizeUser(ctx, key.UserID)
+		return &userv1.AuthorizeResponse{
+			Allowed:                 allowed,
+			UserId:                  key.UserID,
+			Role:                    string(auth.RoleUser),
+			Permissions:             key.Permissions,
+			ApiKeyId:                key.ID,
+			RotationMode:            key.RotationMode,
+			RotationIntervalSeconds: int32(key.RotationIntervalSeconds),
+			Plan:                    plan,
+		}, nil
+
+	case *userv1.AuthorizeRequest_ClientIp:
+		userID, err := s.lookupIP(ctx, credential.ClientIp)
+		if err != nil {
+			return &userv1.AuthorizeResponse{Allowed: false}, nil
+		}
+		plan, allowed := s.authorizeUser(ctx, userID)
+		return &userv1.AuthorizeResponse{
+			Allowed: allowed,
+			UserId:  userID,
+			Role:    string(auth.RoleUser),
+			Plan:    plan,
+		}, nil
+
+	default:
+		return &userv1.AuthorizeResponse{Allowed: false}, nil
+	}
+}
+
+// lookupIP resolves ip to the user who allowlisted it, checking the
+// Redis cache first and falling back to MySQL on a miss. A DAO hit
+// repopulates the cache so the next connection from the same IP avoids
+// the round trip.
+func (s *Server) lookupIP(ctx context.Context, ip string) (string, error) {
+	userID, err := s.ipCache.Lookup(ctx, ip)
+	if err == nil {
+		return userID, nil
+	}
+
+	entry, err := s.allowedIPs.Lookup(ctx, ip)
+	if err != nil {
+		return "", err
+	}
+	if err := s.ipCache.Set(ctx, ip, entry.UserID); err != nil {
+		log.Printf("grpcserver: failed to cache ip allowlist entry: %v", err)
+	}
+	return entry.UserID, nil
+}
@@ -0,0 +1,499 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/auth"
+	"github.com/XXXXD-cation/proxy-platform/pkg/eventbus"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/notify"
+	"github.com/XXXXD-cation/proxy-platform/pkg/onboarding"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// AuthHandlers serves account registration, email verification, and
+// login, issuing an access+refresh token pair the same way a
+// JWT-authenticated session is renewed afterward. Accounts with
+// two-factor auth enabled get a partial token from Login instead, which
+// VerifyTwoFactor must upgrade before it's usable.
+type AuthHandlers struct {
+	users       *user.DAO
+	onboarding  *onboarding.Service
+	tokens      *auth.RefreshTokenService
+	jwt         *auth.JWTService
+	twoFactor   *auth.TwoFactorDAO
+	credentials *auth.CredentialService
+	lockout     *auth.LoginLockout
+	outbox      *notify.OutboxDAO
+	auditLog    *audit.Logger
+	events      eventbus.Publisher
+}
+
+// NewAuthHandlers builds AuthHandlers. events, if non-nil, is notified
+// with an eventbus.EventUserRegistered event after a successful
+// registration; a nil events disables that.
+func NewAuthHandlers(users *user.DAO, onboarding *onboarding.Service, tokens *auth.RefreshTokenService, jwt *auth.JWTService, twoFactor *auth.TwoFactorDAO, credentials *auth.CredentialService, lockout *auth.LoginLockout, outbox *notify.OutboxDAO, auditLog *audit.Logger, events eventbus.Publisher) *AuthHandlers {
+	return &AuthHandlers{users: users, onboarding: onboarding, tokens: tokens, jwt: jwt, twoFactor: twoFactor, credentials: credentials, lockout: lockout, outbox: outbox, auditLog: auditLog, events: events}
+}
+
+type registerRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type messageResponse struct {
+	Message string `json:"message"`
+}
+
+// Register handles POST /api/auth/register: it creates a new free-plan
+// account in user.StatusPendingVerification and emails it a
+// verification token. The account can't log in (see Login) until that
+// token is redeemed via Verify.
+func (h *AuthHandlers) Register(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[registerRequest](w, r)
+	if !ok {
+		return
+	}
+	if err := auth.ValidatePassword(req.Password); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		http.Error(w, "failed to hash password", http.StatusInternalServerError)
+		return
+	}
+
+	created, err := h.onboarding.CreateAccount(r.Context(), req.Email, hash)
+	if err != nil {
+		http.Error(w, "failed to register", http.StatusInternalServerError)
+		return
+	}
+	h.publishRegistered(r.Context(), created.ID)
+
+	if err := h.sendVerificationEmail(r.Context(), created); err != nil {
+		log.Printf("api: failed to queue verification email for %s: %v", created.ID, err)
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSONBody(w, messageResponse{Message: "account created; check your email for a verification code"})
+}
+
+type resendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResendVerification handles POST /api/auth/verify/resend: it re-issues
+// a verification token for a pending account and re-queues the email.
+// It reports the same success message whether or not the address
+// matches a pending account, so it can't be used to enumerate
+// registered emails.
+func (h *AuthHandlers) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[resendVerificationRequest](w, r)
+	if !ok {
+		return
+	}
+
+	if acct, err := h.users.GetByEmail(r.Context(), req.Email); err == nil && acct.Status == user.StatusPendingVerification {
+		if err := h.sendVerificationEmail(r.Context(), acct); err != nil {
+			log.Printf("api: failed to queue verification email for %s: %v", acct.ID, err)
+		}
+	}
+	writeJSONBody(w, messageResponse{Message: "if that address has a pending registration, a new verification code has been sent"})
+}
+
+type verifyRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// Verify handles POST /api/auth/verify: it redeems a pending account's
+// email verification token, activating it and provisioning its default
+// subscription and starter API key, then logs it in.
+func (h *AuthHandlers) Verify(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[verifyRequest](w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := h.credentials.ConsumeVerificationToken(r.Context(), req.Token)
+	if err != nil {
+		http.Error(w, "invalid or expired verification code", http.StatusUnauthorized)
+		return
+	}
+
+	activated, err := h.onboarding.VerifyAccount(r.Context(), userID)
+	if err != nil {
+		http.Error(w, "failed to verify account", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "auth.verify", activated.User.ID, "")
+
+	pair, err := h.tokens.IssuePair(r.Context(), activated.User.ID, auth.RoleUser, r.UserAgent())
+	if err != nil {
+		http.Error(w, "failed to issue tokens", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSONBody(w, verifyResponse{tokenPairResponse: tokenPairView(pair), APIKey: activated.APIKey})
+}
+
+type verifyResponse struct {
+	tokenPairResponse
+	APIKey string `json:"api_key"`
+}
+
+// sendVerificationEmail issues a fresh verification token for acct and
+// queues it for delivery through the notification outbox, the same
+// durable send path pkg/notify.Producer uses for subscription and quota
+// emails.
+func (h *AuthHandlers) sendVerificationEmail(ctx context.Context, acct *user.User) error {
+	token, err := h.credentials.IssueVerificationToken(ctx, acct.ID)
+	if err != nil {
+		return err
+	}
+	subject, body, err := notify.Render(notify.TypeEmailVerification, notify.EmailVerificationData{
+		Token:        token,
+		ExpiresHours: int(auth.VerificationTokenTTL.Hours()),
+	})
+	if err != nil {
+		return err
+	}
+	return h.outbox.Enqueue(ctx, &notify.OutboxEntry{
+		UserID:    acct.ID,
+		Type:      notify.TypeEmailVerification,
+		DedupeKey: token,
+		Subject:   subject,
+		Body:      body,
+	})
+}
+
+type forgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ForgotPassword handles POST /api/auth/password/forgot: it issues a
+// single-use password reset token for the given email and queues it for
+// delivery, if the email matches an account. It reports the same
+// success message either way, so it can't be used to enumerate
+// registered emails.
+func (h *AuthHandlers) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[forgotPasswordRequest](w, r)
+	if !ok {
+		return
+	}
+
+	if acct, err := h.users.GetByEmail(r.Context(), req.Email); err == nil {
+		if err := h.sendPasswordResetEmail(r.Context(), acct); err != nil {
+			log.Printf("api: failed to queue password reset email for %s: %v", acct.ID, err)
+		}
+		h.record(r, "auth.password.forgot", acct.ID, "")
+	}
+	writeJSONBody(w, messageResponse{Message: "if that address has an account, a password reset code has been sent"})
+}
+
+func (h *AuthHandlers) sendPasswordResetEmail(ctx context.Context, acct *user.User) error {
+	token, err := h.credentials.IssueResetToken(ctx, acct.ID)
+	if err != nil {
+		return err
+	}
+	subject, body, err := notify.Render(notify.TypePasswordReset, notify.PasswordResetData{
+		Token:        token,
+		ExpiresHours: int(auth.ResetTokenTTL.Hours()),
+	})
+	if err != nil {
+		return err
+	}
+	return h.outbox.Enqueue(ctx, &notify.OutboxEntry{
+		UserID:    acct.ID,
+		Type:      notify.TypePasswordReset,
+		DedupeKey: token,
+		Subject:   subject,
+		Body:      body,
+	})
+}
+
+type resetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+// ResetPassword handles POST /api/auth/password/reset: it redeems a
+// forgot-password token, rehashes the account's password, and revokes
+// every refresh-token session the account currently holds, so a
+// password reset also ends any session an attacker may have opened
+// with the old password. Already-issued access tokens remain valid
+// until their own short expiry, the same limitation
+// RefreshTokenService.RevokeAll documents for any other forced
+// sign-out.
+func (h *AuthHandlers) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[resetPasswordRequest](w, r)
+	if !ok {
+		return
+	}
+	if err := auth.ValidatePassword(req.NewPassword); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userID, err := h.credentials.ConsumeResetToken(r.Context(), req.Token)
+	if err != nil {
+		http.Error(w, "invalid or expired reset code", http.StatusUnauthorized)
+		return
+	}
+
+	hash, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		http.Error(w, "failed to hash password", http.StatusInternalServerError)
+		return
+	}
+	if err := h.users.SetPasswordHash(r.Context(), userID, hash); err != nil {
+		http.Error(w, "failed to reset password", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.tokens.RevokeAll(r.Context(), userID); err != nil {
+		log.Printf("api: failed to revoke sessions for %s after password reset: %v", userID, err)
+	}
+	h.record(r, "auth.password.reset", userID, "")
+
+	writeJSONBody(w, messageResponse{Message: "password updated"})
+}
+
+type loginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// Login handles POST /api/auth/login: it verifies the caller's password
+// and returns a fresh token pair.
+func (h *AuthHandlers) Login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[loginRequest](w, r)
+	if !ok {
+		return
+	}
+
+	locked, err := h.lockout.Locked(r.Context(), req.Email)
+	if err != nil {
+		http.Error(w, "failed to log in", http.StatusInternalServerError)
+		return
+	}
+	if locked {
+		h.record(r, "auth.login.locked", req.Email, "")
+		http.Error(w, "too many failed login attempts; try again later", http.StatusTooManyRequests)
+		return
+	}
+
+	acct, err := h.users.GetByEmail(r.Context(), req.Email)
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			h.recordLoginFailure(r, req.Email, req.Email, "unknown email")
+			http.Error(w, "invalid email or password", http.StatusUnauthorized)
+			return
+		}
+		http.Error(w, "failed to log in", http.StatusInternalServerError)
+		return
+	}
+	if err := auth.VerifyPassword(acct.PasswordHash, req.Password); err != nil {
+		h.recordLoginFailure(r, req.Email, acct.ID, "wrong password")
+		http.Error(w, "invalid email or password", http.StatusUnauthorized)
+		return
+	}
+	if acct.Status == user.StatusPendingVerification {
+		h.record(r, "auth.login.failure", acct.ID, "email not verified")
+		http.Error(w, "please verify your email before logging in", http.StatusForbidden)
+		return
+	}
+	if acct.Status != user.StatusActive {
+		h.record(r, "auth.login.failure", acct.ID, "account not active")
+		http.Error(w, "account is not active", http.StatusForbidden)
+		return
+	}
+	if err := h.lockout.Reset(r.Context(), req.Email); err != nil {
+		log.Printf("api: failed to reset login lockout for %s: %v", req.Email, err)
+	}
+
+	enabled, err := h.twoFactor.IsEnabled(r.Context(), acct.ID)
+	if err != nil {
+		http.Error(w, "failed to log in", http.StatusInternalServerError)
+		return
+	}
+	if enabled {
+		partial, err := h.jwt.IssuePartial(acct.ID, auth.RoleUser)
+		if err != nil {
+			http.Error(w, "failed to issue tokens", http.StatusInternalServerError)
+			return
+		}
+		writeJSONBody(w, loginResponse{PartialToken: partial, TwoFactorRequired: true})
+		return
+	}
+
+	pair, err := h.tokens.IssuePair(r.Context(), acct.ID, auth.RoleUser, r.UserAgent())
+	if err != nil {
+		http.Error(w, "failed to issue tokens", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "auth.login.success", acct.ID, "")
+	writeJSONBody(w, loginResponse{tokenPairResponse: tokenPairView(pair)})
+}
+
+// publishRegistered notifies h.events, if any, that userID just
+// registered. It's best-effort: a publish failure is logged but never
+// fails the registration itself.
+func (h *AuthHandlers) publishRegistered(ctx context.Context, userID string) {
+	if h.events == nil {
+		return
+	}
+	event := eventbus.Event{Type: eventbus.EventUserRegistered, Fields: map[string]string{"user_id": userID}}
+	if err := h.events.Publish(ctx, event); err != nil {
+		log.Printf("api: failed to publish user.registered event: %v", err)
+	}
+}
+
+// recordLoginFailure audits a login failure against actorID (the
+// account ID if known, otherwise the attempted email) and counts it
+// against email's LoginLockout, so a burst of failures against one
+// address locks it out regardless of whether each attempt got far
+// enough to resolve an account.
+func (h *AuthHandlers) recordLoginFailure(r *http.Request, email, actorID, detail string) {
+	h.record(r, "auth.login.failure", actorID, detail)
+	locked, err := h.lockout.RecordFailure(r.Context(), email)
+	if err != nil {
+		log.Printf("api: failed to record login lockout failure for %s: %v", email, err)
+		return
+	}
+	if locked {
+		h.record(r, "auth.login.locked", actorID, "")
+	}
+}
+
+func (h *AuthHandlers) record(r *http.Request, action, actorID, detail string) {
+	entry := audit.Entry{
+		ActorID:   actorID,
+		Action:    action,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
+
+type loginResponse struct {
+	tokenPairResponse
+	PartialToken      string `json:"partial_token,omitempty"`
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+}
+
+type verifyTwoFactorRequest struct {
+	PartialToken string `json:"partial_token" validate:"required"`
+	Code         string `json:"code" validate:"required"`
+}
+
+// twoFactorLockoutKey namespaces claims.UserID under h.lockout so a
+// run of failed 2FA code attempts is counted separately from that
+// user's email's password-login failures, while reusing the same
+// LoginLockoutThreshold/LoginLockoutWindow brute-force guard.
+func twoFactorLockoutKey(userID string) string {
+	return "2fa:" + userID
+}
+
+// VerifyTwoFactor handles POST /api/auth/2fa/verify: it upgrades a
+// partial token from Login into a full token pair once the caller
+// proves they hold a valid TOTP or recovery code for the account.
+// Attempts are throttled the same way password logins are, since a
+// valid partial token otherwise lets an attacker retry the 6-digit
+// code indefinitely within its TTL.
+func (h *AuthHandlers) VerifyTwoFactor(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[verifyTwoFactorRequest](w, r)
+	if !ok {
+		return
+	}
+
+	claims, err := h.jwt.Parse(req.PartialToken)
+	if err != nil || !claims.TwoFactorPending {
+		http.Error(w, "invalid or expired partial token", http.StatusUnauthorized)
+		return
+	}
+
+	lockoutKey := twoFactorLockoutKey(claims.UserID)
+	locked, err := h.lockout.Locked(r.Context(), lockoutKey)
+	if err != nil {
+		http.Error(w, "failed to verify two-factor code", http.StatusInternalServerError)
+		return
+	}
+	if locked {
+		h.record(r, "auth.2fa.locked", claims.UserID, "")
+		http.Error(w, "too many failed two-factor attempts; try again later", http.StatusTooManyRequests)
+		return
+	}
+
+	if err := h.twoFactor.VerifyCode(r.Context(), claims.UserID, req.Code); err != nil {
+		h.record(r, "auth.2fa.failure", claims.UserID, "")
+		if _, err := h.lockout.RecordFailure(r.Context(), lockoutKey); err != nil {
+			log.Printf("api: failed to record 2fa lockout failure for %s: %v", claims.UserID, err)
+		}
+		http.Error(w, "invalid two-factor code", http.StatusUnauthorized)
+		return
+	}
+	if err := h.lockout.Reset(r.Context(), lockoutKey); err != nil {
+		log.Printf("api: failed to reset 2fa lockout for %s: %v", claims.UserID, err)
+	}
+
+	pair, err := h.tokens.IssuePair(r.Context(), claims.UserID, claims.Role, r.UserAgent())
+	if err != nil {
+		http.Error(w, "failed to issue tokens", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "auth.2fa.success", claims.UserID, "")
+	writeJSONBody(w, tokenPairView(pair))
+}
+
+type tokenPairResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func tokenPairView(pair auth.TokenPair) tokenPairResponse {
+	return tokenPairResponse{AccessToken: pair.AccessToken, RefreshToken: pair.RefreshToken}
+}
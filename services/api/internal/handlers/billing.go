@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// BillingHandlers serves subscription purchase and payment-provider
+// webhook endpoints.
+type BillingHandlers struct {
+	provider   billing.PaymentProvider
+	subscriber *billing.Subscriber
+}
+
+// NewBillingHandlers builds BillingHandlers.
+func NewBillingHandlers(provider billing.PaymentProvider, subscriber *billing.Subscriber) *BillingHandlers {
+	return &BillingHandlers{provider: provider, subscriber: subscriber}
+}
+
+type checkoutSessionRequest struct {
+	Plan string `json:"plan" validate:"required,oneof=pro enterprise"`
+}
+
+// CreateCheckoutSession handles POST /api/v1/billing/checkout-session: it
+// starts a hosted checkout flow for the authenticated caller to
+// subscribe to the plan named in the request body.
+func (h *BillingHandlers) CreateCheckoutSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	body, ok := authmiddleware.BindAndValidate[checkoutSessionRequest](w, r)
+	if !ok {
+		return
+	}
+
+	session, err := h.provider.CreateCheckoutSession(r.Context(), identity.UserID, user.Plan(body.Plan))
+	if err != nil {
+		http.Error(w, "failed to create checkout session", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// Webhook handles POST /api/v1/billing/webhook: the payment provider's
+// server-to-server notification endpoint. It verifies the request's
+// signature itself, so it is not wrapped in the JWT/API-key auth
+// middleware like the rest of the service.
+func (h *BillingHandlers) Webhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	event, err := h.provider.VerifyAndParseWebhook(payload, r.Header.Get("Stripe-Signature"))
+	if err != nil {
+		http.Error(w, "invalid webhook", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.subscriber.Apply(r.Context(), event); err != nil {
+		log.Printf("api: failed to apply billing webhook event %q for user %q: %v", event.Type, event.UserID, err)
+		http.Error(w, "failed to apply event", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/blocklist"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// BlocklistOverrideHandlers lets enterprise-plan users exempt their
+// account from a specific pkg/blocklist pattern, e.g. one they believe
+// is miscategorized.
+type BlocklistOverrideHandlers struct {
+	overrides *blocklist.DAO
+	subs      *billing.SubscriptionDAO
+	plans     *billing.PlanDAO
+}
+
+// NewBlocklistOverrideHandlers builds BlocklistOverrideHandlers.
+func NewBlocklistOverrideHandlers(overrides *blocklist.DAO, subs *billing.SubscriptionDAO, plans *billing.PlanDAO) *BlocklistOverrideHandlers {
+	return &BlocklistOverrideHandlers{overrides: overrides, subs: subs, plans: plans}
+}
+
+// Collection handles /api/v1/blocklist-overrides: GET lists the
+// caller's overrides, POST adds one.
+func (h *BlocklistOverrideHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *BlocklistOverrideHandlers) list(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	overrides, err := h.overrides.ListOverrides(r.Context(), identity.UserID)
+	if err != nil {
+		http.Error(w, "failed to list blocklist overrides", http.StatusInternalServerError)
+		return
+	}
+	writeJSONBody(w, blocklistOverridesToView(overrides))
+}
+
+type createBlocklistOverrideRequest struct {
+	Pattern string `json:"pattern" validate:"required"`
+}
+
+func (h *BlocklistOverrideHandlers) create(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[createBlocklistOverrideRequest](w, r)
+	if !ok {
+		return
+	}
+
+	sub, err := h.subs.Get(r.Context(), identity.UserID)
+	if err != nil {
+		http.Error(w, "failed to load subscription", http.StatusInternalServerError)
+		return
+	}
+	plan, err := h.plans.Get(r.Context(), sub.Plan)
+	if err != nil {
+		http.Error(w, "failed to load plan", http.StatusInternalServerError)
+		return
+	}
+	if plan.Plan != user.PlanEnterprise {
+		http.Error(w, "blocklist overrides require an enterprise plan", http.StatusForbidden)
+		return
+	}
+
+	override, err := h.overrides.AddOverride(r.Context(), identity.UserID, req.Pattern)
+	if err != nil {
+		http.Error(w, "failed to create blocklist override", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSONBody(w, blocklistOverrideToView(override))
+}
+
+// Item handles /api/v1/blocklist-overrides/{id}: DELETE removes the
+// override.
+func (h *BlocklistOverrideHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/blocklist-overrides/")
+	if id == "" {
+		http.Error(w, "blocklist override id is required", http.StatusBadRequest)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.overrides.DeleteOverride(r.Context(), identity.UserID, id); err != nil {
+		http.Error(w, "failed to delete blocklist override", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// blocklistOverrideView is the JSON shape returned for a blocklist
+// override.
+type blocklistOverrideView struct {
+	ID        string    `json:"id"`
+	Pattern   string    `json:"pattern"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+func blocklistOverrideToView(o *blocklist.Override) blocklistOverrideView {
+	return blocklistOverrideView{ID: o.ID, Pattern: o.Pattern, CreatedAt: o.CreatedAt}
+}
+
+func blocklistOverridesToView(overrides []*blocklist.Override) []blocklistOverrideView {
+	out := make([]blocklistOverrideView, len(overrides))
+	for i, o := range overrides {
+		out[i] = blocklistOverrideToView(o)
+	}
+	return out
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/export"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/objstore"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+	"github.com/XXXXD-cation/proxy-platform/services/api/internal/planlimits"
+)
+
+// downloadLinkTTL is how long a signed export download URL stays valid.
+const downloadLinkTTL = 15 * time.Minute
+
+// ExportHandlers lets a user enqueue an export of their own usage_logs
+// rows and poll it for completion. Jobs are processed asynchronously by
+// export.Worker, which admin-api runs on a ticker; this handler only
+// enqueues and reads job state.
+type ExportHandlers struct {
+	jobs    *export.DAO
+	usage   *usage.DAO
+	subs    *billing.SubscriptionDAO
+	plans   *billing.PlanDAO
+	objects *objstore.Client
+}
+
+// NewExportHandlers builds ExportHandlers.
+func NewExportHandlers(jobs *export.DAO, usageDAO *usage.DAO, subs *billing.SubscriptionDAO, plans *billing.PlanDAO, objects *objstore.Client) *ExportHandlers {
+	return &ExportHandlers{jobs: jobs, usage: usageDAO, subs: subs, plans: plans, objects: objects}
+}
+
+// Collection handles /api/v1/exports: GET lists the caller's export
+// jobs, POST enqueues a new one.
+func (h *ExportHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *ExportHandlers) list(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	jobs, err := h.jobs.ListByUser(r.Context(), identity.UserID)
+	if err != nil {
+		http.Error(w, "failed to list exports", http.StatusInternalServerError)
+		return
+	}
+	views := make([]exportJobView, len(jobs))
+	for i, job := range jobs {
+		views[i] = h.jobView(job, "")
+	}
+	writeJSONBody(w, exportListResponse{Exports: views})
+}
+
+type exportListResponse struct {
+	Exports []exportJobView `json:"exports"`
+}
+
+type createExportRequest struct {
+	Format string    `json:"format" validate:"required,oneof=csv ndjson"`
+	From   time.Time `json:"from" validate:"required"`
+	To     time.Time `json:"to" validate:"required"`
+}
+
+func (h *ExportHandlers) create(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[createExportRequest](w, r)
+	if !ok {
+		return
+	}
+	if !req.From.Before(req.To) {
+		http.Error(w, "from must be before to", http.StatusBadRequest)
+		return
+	}
+
+	sub, err := h.subs.Get(r.Context(), identity.UserID)
+	if err != nil {
+		http.Error(w, "failed to load subscription", http.StatusInternalServerError)
+		return
+	}
+	plan, err := h.plans.Get(r.Context(), sub.Plan)
+	if err != nil {
+		http.Error(w, "failed to load plan", http.StatusInternalServerError)
+		return
+	}
+
+	estimate, err := h.usage.StatsInRange(r.Context(), identity.UserID, req.From, req.To)
+	if err != nil {
+		http.Error(w, "failed to estimate export size", http.StatusInternalServerError)
+		return
+	}
+	if limit := int64(planlimits.ExportRowLimit(plan.Plan)); estimate.RequestCount > limit {
+		http.Error(w, "date range exceeds your plan's export row limit; narrow the range or upgrade your plan", http.StatusUnprocessableEntity)
+		return
+	}
+
+	job := &export.Job{
+		UserID:     identity.UserID,
+		Format:     export.Format(req.Format),
+		RangeStart: req.From,
+		RangeEnd:   req.To,
+	}
+	if err := h.jobs.Enqueue(r.Context(), job); err != nil {
+		http.Error(w, "failed to enqueue export", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	writeJSONBody(w, h.jobView(job, ""))
+}
+
+// Item handles GET /api/v1/exports/{id}: returns the job's current
+// status, and, once complete, a signed download URL for its file.
+func (h *ExportHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/exports/")
+	if id == "" {
+		http.Error(w, "export id is required", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.jobs.Get(r.Context(), id)
+	if err != nil {
+		http.Error(w, "export not found", http.StatusNotFound)
+		return
+	}
+	if job.UserID != identity.UserID {
+		// 404, not 403: don't let a caller distinguish "not yours" from
+		// "doesn't exist" by probing IDs.
+		http.Error(w, "export not found", http.StatusNotFound)
+		return
+	}
+
+	downloadURL := ""
+	if job.Status == export.StatusComplete {
+		downloadURL, err = h.objects.PresignGetURL(job.ObjectKey, downloadLinkTTL)
+		if err != nil {
+			http.Error(w, "failed to sign download url", http.StatusInternalServerError)
+			return
+		}
+	}
+	writeJSONBody(w, h.jobView(job, downloadURL))
+}
+
+type exportJobView struct {
+	ID          string     `json:"id"`
+	Format      string     `json:"format"`
+	RangeStart  time.Time  `json:"range_start"`
+	RangeEnd    time.Time  `json:"range_end"`
+	Status      string     `json:"status"`
+	RowCount    int        `json:"row_count"`
+	SizeBytes   int64      `json:"size_bytes"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	DownloadURL string     `json:"download_url,omitempty"`
+}
+
+func (h *ExportHandlers) jobView(job *export.Job, downloadURL string) exportJobView {
+	return exportJobView{
+		ID:          job.ID,
+		Format:      string(job.Format),
+		RangeStart:  job.RangeStart,
+		RangeEnd:    job.RangeEnd,
+		Status:      string(job.Status),
+		RowCount:    job.RowCount,
+		SizeBytes:   job.SizeBytes,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		CompletedAt: job.CompletedAt,
+		DownloadURL: downloadURL,
+	}
+}
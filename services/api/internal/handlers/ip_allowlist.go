@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/ipallowlist"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+)
+
+// IPAllowlistHandlers lets authenticated users manage the source IPs
+// the gateway will authorize on their behalf, without a credential.
+type IPAllowlistHandlers struct {
+	allowedIPs *ipallowlist.DAO
+	cache      *ipallowlist.Cache
+}
+
+// NewIPAllowlistHandlers builds IPAllowlistHandlers.
+func NewIPAllowlistHandlers(allowedIPs *ipallowlist.DAO, cache *ipallowlist.Cache) *IPAllowlistHandlers {
+	return &IPAllowlistHandlers{allowedIPs: allowedIPs, cache: cache}
+}
+
+// Collection handles /api/v1/allowed-ips: GET lists the caller's
+// entries, POST adds one.
+func (h *IPAllowlistHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *IPAllowlistHandlers) list(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	entries, err := h.allowedIPs.List(r.Context(), identity.UserID)
+	if err != nil {
+		http.Error(w, "failed to list allowed ips", http.StatusInternalServerError)
+		return
+	}
+	writeJSONBody(w, entriesToView(entries))
+}
+
+type createIPAllowlistEntryRequest struct {
+	IPAddress string `json:"ip_address" validate:"required"`
+	Label     string `json:"label"`
+}
+
+func (h *IPAllowlistHandlers) create(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[createIPAllowlistEntryRequest](w, r)
+	if !ok {
+		return
+	}
+	if net.ParseIP(req.IPAddress) == nil {
+		http.Error(w, "ip_address must be a valid IP", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.allowedIPs.Insert(r.Context(), identity.UserID, req.IPAddress, req.Label)
+	if err != nil {
+		if err == ipallowlist.ErrDuplicateIP {
+			http.Error(w, "ip address already allowlisted", http.StatusConflict)
+			return
+		}
+		http.Error(w, "failed to create allowed ip", http.StatusInternalServerError)
+		return
+	}
+	if err := h.cache.Set(r.Context(), entry.IPAddress, entry.UserID); err != nil {
+		http.Error(w, "failed to cache allowed ip", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSONBody(w, entryToView(entry))
+}
+
+// Item handles /api/v1/allowed-ips/{id}: DELETE removes the entry.
+func (h *IPAllowlistHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/allowed-ips/")
+	if id == "" {
+		http.Error(w, "allowed ip id is required", http.StatusBadRequest)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	entries, err := h.allowedIPs.List(r.Context(), identity.UserID)
+	if err != nil {
+		http.Error(w, "failed to look up allowed ip", http.StatusInternalServerError)
+		return
+	}
+	var ip string
+	for _, e := range entries {
+		if e.ID == id {
+			ip = e.IPAddress
+			break
+		}
+	}
+
+	if err := h.allowedIPs.Delete(r.Context(), identity.UserID, id); err != nil {
+		http.Error(w, "failed to delete allowed ip", http.StatusInternalServerError)
+		return
+	}
+	if ip != "" {
+		if err := h.cache.Remove(r.Context(), ip); err != nil {
+			http.Error(w, "failed to evict cached allowed ip", http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ipAllowlistEntryView is the JSON shape returned for an allowlist
+// entry.
+type ipAllowlistEntryView struct {
+	ID        string    `json:"id"`
+	IPAddress string    `json:"ip_address"`
+	Label     string    `json:"label"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+func entryToView(e *ipallowlist.Entry) ipAllowlistEntryView {
+	return ipAllowlistEntryView{
+		ID:        e.ID,
+		IPAddress: e.IPAddress,
+		Label:     e.Label,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func entriesToView(entries []*ipallowlist.Entry) []ipAllowlistEntryView {
+	out := make([]ipAllowlistEntryView, len(entries))
+	for i, e := range entries {
+		out[i] = entryToView(e)
+	}
+	return out
+}
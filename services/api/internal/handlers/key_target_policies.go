@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/targetpolicy"
+)
+
+// targetPolicyRoute handles /api/v1/keys/{id}/target-policies... : GET
+// lists keyID's configured policy entries, POST adds one, and DELETE
+// /api/v1/keys/{id}/target-policies/{policyID} removes one. rest is
+// whatever followed "target-policies" in the path, either "" (the
+// collection) or "/{policyID}".
+func (h *KeyHandlers) targetPolicyRoute(w http.ResponseWriter, r *http.Request, userID, keyID, rest string) {
+	if err := h.ownsKey(r.Context(), userID, keyID); err != nil {
+		http.Error(w, "key not found", http.StatusNotFound)
+		return
+	}
+
+	policyID := strings.TrimPrefix(rest, "/")
+	switch {
+	case policyID == "" && r.Method == http.MethodGet:
+		h.listTargetPolicies(w, r, keyID)
+	case policyID == "" && r.Method == http.MethodPost:
+		h.createTargetPolicy(w, r, keyID)
+	case policyID != "" && r.Method == http.MethodDelete:
+		h.deleteTargetPolicy(w, r, keyID, policyID)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// ownsKey reports an error unless keyID is one of userID's own keys.
+func (h *KeyHandlers) ownsKey(ctx context.Context, userID, keyID string) error {
+	keys, err := h.keys.List(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if k.ID == keyID {
+			return nil
+		}
+	}
+	return apikey.ErrNotFound
+}
+
+func (h *KeyHandlers) listTargetPolicies(w http.ResponseWriter, r *http.Request, keyID string) {
+	entries, err := h.targetPolicies.List(r.Context(), keyID)
+	if err != nil {
+		http.Error(w, "failed to list target policies", http.StatusInternalServerError)
+		return
+	}
+	writeJSONBody(w, targetPolicyEntriesToView(entries))
+}
+
+type createTargetPolicyRequest struct {
+	Mode    string `json:"mode" validate:"required,oneof=allow deny"`
+	Pattern string `json:"pattern" validate:"required"`
+	Reason  string `json:"reason"`
+}
+
+func (h *KeyHandlers) createTargetPolicy(w http.ResponseWriter, r *http.Request, keyID string) {
+	req, ok := authmiddleware.BindAndValidate[createTargetPolicyRequest](w, r)
+	if !ok {
+		return
+	}
+
+	entry, err := h.targetPolicies.Insert(r.Context(), keyID, targetpolicy.Mode(req.Mode), req.Pattern, req.Reason)
+	if err != nil {
+		http.Error(w, "failed to create target policy", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSONBody(w, targetPolicyEntryToView(entry))
+}
+
+func (h *KeyHandlers) deleteTargetPolicy(w http.ResponseWriter, r *http.Request, keyID, policyID string) {
+	if err := h.targetPolicies.Delete(r.Context(), keyID, policyID); err != nil {
+		http.Error(w, "failed to delete target policy", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// targetPolicyEntryView is the JSON shape returned for a target-domain
+// policy entry.
+type targetPolicyEntryView struct {
+	ID      string `json:"id"`
+	Mode    string `json:"mode"`
+	Pattern string `json:"pattern"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+func targetPolicyEntryToView(e *targetpolicy.Entry) targetPolicyEntryView {
+	return targetPolicyEntryView{
+		ID:      e.ID,
+		Mode:    string(e.Mode),
+		Pattern: e.Pattern,
+		Reason:  e.Reason,
+	}
+}
+
+func targetPolicyEntriesToView(entries []*targetpolicy.Entry) []targetPolicyEntryView {
+	out := make([]targetPolicyEntryView, len(entries))
+	for i, e := range entries {
+		out[i] = targetPolicyEntryToView(e)
+	}
+	return out
+}
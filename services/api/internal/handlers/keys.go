@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/organization"
+	"github.com/XXXXD-cation/proxy-platform/pkg/targetpolicy"
+)
+
+// KeyHandlers lets authenticated users manage their own API keys, and
+// optionally the shared keys of an organization they belong to.
+type KeyHandlers struct {
+	keys           *apikey.DAO
+	orgs           organization.DAOInterface
+	targetPolicies *targetpolicy.DAO
+	auditLog       *audit.Logger
+}
+
+// NewKeyHandlers builds KeyHandlers.
+func NewKeyHandlers(keys *apikey.DAO, orgs organization.DAOInterface, targetPolicies *targetpolicy.DAO, auditLog *audit.Logger) *KeyHandlers {
+	return &KeyHandlers{keys: keys, orgs: orgs, targetPolicies: targetPolicies, auditLog: auditLog}
+}
+
+// Collection handles /api/v1/keys: GET lists the caller's keys (with
+// masked values), POST creates a new one.
+func (h *KeyHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *KeyHandlers) list(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	keys, err := h.keys.List(r.Context(), identity.UserID)
+	if err != nil {
+		http.Error(w, "failed to list keys", http.StatusInternalServerError)
+		return
+	}
+	writeJSONBody(w, keysToView(keys))
+}
+
+type createKeyRequest struct {
+	Name                    string     `json:"name" validate:"required"`
+	OrgID                   string     `json:"org_id"`
+	Permissions             []string   `json:"permissions"`
+	ExpiresAt               *time.Time `json:"expires_at"`
+	RotationMode            string     `json:"rotation_mode"`
+	RotationIntervalSeconds int        `json:"rotation_interval_seconds"`
+}
+
+func (h *KeyHandlers) create(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[createKeyRequest](w, r)
+	if !ok {
+		return
+	}
+	if !validRotationMode(req.RotationMode) {
+		http.Error(w, "invalid rotation_mode", http.StatusBadRequest)
+		return
+	}
+	if req.OrgID != "" {
+		member, err := h.orgs.GetMembership(r.Context(), req.OrgID, identity.UserID)
+		if err != nil {
+			if errors.Is(err, organization.ErrNotMember) {
+				http.Error(w, "not a member of this organization", http.StatusForbidden)
+				return
+			}
+			http.Error(w, "failed to check organization membership", http.StatusInternalServerError)
+			return
+		}
+		if member.Role != organization.RoleOwner && member.Role != organization.RoleAdmin {
+			http.Error(w, "insufficient organization role", http.StatusForbidden)
+			return
+		}
+	}
+
+	raw, key, err := h.keys.Generate(r.Context(), identity.UserID, req.OrgID, req.Name, req.Permissions, req.ExpiresAt, req.RotationMode, req.RotationIntervalSeconds)
+	if err != nil {
+		http.Error(w, "failed to create key", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "apikey.create", key.ID, req.Name)
+	w.WriteHeader(http.StatusCreated)
+	writeJSONBody(w, keyCreatedView{keyView: keyToView(key), Key: raw})
+}
+
+// Item handles /api/v1/keys/{id}... sub-routes: the bare resource
+// (DELETE to revoke) and rotate.
+func (h *KeyHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	rest := strings.TrimPrefix(r.URL.Path, "/api/v1/keys/")
+	id, action, _ := strings.Cut(rest, "/")
+	if id == "" {
+		http.Error(w, "key id is required", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodDelete:
+		h.revoke(w, r, identity.UserID, id)
+	case action == "rotate" && r.Method == http.MethodPost:
+		h.rotate(w, r, identity.UserID, id)
+	case action == "target-policies" || strings.HasPrefix(action, "target-policies/"):
+		h.targetPolicyRoute(w, r, identity.UserID, id, strings.TrimPrefix(action, "target-policies"))
+	default:
+		http.Error(w, "not found", http.StatusNotFound)
+	}
+}
+
+func (h *KeyHandlers) revoke(w http.ResponseWriter, r *http.Request, userID, id string) {
+	if err := h.keys.Revoke(r.Context(), userID, id); err != nil {
+		http.Error(w, "failed to revoke key", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "apikey.revoke", id, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *KeyHandlers) record(r *http.Request, action, target, detail string) {
+	identity, _ := authmiddleware.IdentityFromContext(r.Context())
+	entry := audit.Entry{
+		ActorID:   identity.UserID,
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
+
+func (h *KeyHandlers) rotate(w http.ResponseWriter, r *http.Request, userID, id string) {
+	raw, key, err := h.keys.Rotate(r.Context(), userID, id)
+	if err != nil {
+		if err == apikey.ErrNotFound {
+			http.Error(w, "key not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "failed to rotate key", http.StatusInternalServerError)
+		return
+	}
+	writeJSONBody(w, keyCreatedView{keyView: keyToView(key), Key: raw})
+}
+
+// validRotationMode reports whether mode is a recognized rotation mode,
+// treating "" as valid (it defaults to RotationModePerRequest).
+func validRotationMode(mode string) bool {
+	switch mode {
+	case "", apikey.RotationModePerRequest, apikey.RotationModeSticky, apikey.RotationModeInterval:
+		return true
+	default:
+		return false
+	}
+}
+
+// keyView is the JSON shape returned for a key the caller already has:
+// it never includes the raw value, only a masked suffix.
+type keyView struct {
+	ID                      string     `json:"id"`
+	OrgID                   string     `json:"org_id,omitempty"`
+	Name                    string     `json:"name"`
+	Status                  string     `json:"status"`
+	Permissions             []string   `json:"permissions"`
+	Masked                  string     `json:"masked"`
+	ExpiresAt               *time.Time `json:"expires_at,omitempty"`
+	CreatedAt               time.Time  `json:"created_at,omitempty"`
+	RotationMode            string     `json:"rotation_mode"`
+	RotationIntervalSeconds int        `json:"rotation_interval_seconds,omitempty"`
+}
+
+// keyCreatedView additionally includes the raw key value, shown exactly
+// once at creation/rotation time.
+type keyCreatedView struct {
+	keyView
+	Key string `json:"key"`
+}
+
+func keyToView(k *apikey.Key) keyView {
+	return keyView{
+		ID:                      k.ID,
+		OrgID:                   k.OrgID,
+		Name:                    k.Name,
+		Status:                  k.Status,
+		Permissions:             k.Permissions,
+		Masked:                  k.Masked(),
+		ExpiresAt:               k.ExpiresAt,
+		CreatedAt:               k.CreatedAt,
+		RotationMode:            k.RotationMode,
+		RotationIntervalSeconds: k.RotationIntervalSeconds,
+	}
+}
+
+func keysToView(keys []*apikey.Key) []keyView {
+	out := make([]keyView, len(keys))
+	for i, k := range keys {
+		out[i] = keyToView(k)
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/notify"
+)
+
+// NotificationHandlers lets authenticated users read and update their
+// own notification preferences.
+type NotificationHandlers struct {
+	prefs *notify.PreferencesDAO
+}
+
+// NewNotificationHandlers builds NotificationHandlers.
+func NewNotificationHandlers(prefs *notify.PreferencesDAO) *NotificationHandlers {
+	return &NotificationHandlers{prefs: prefs}
+}
+
+// Preferences handles /api/v1/notifications/preferences: GET returns the
+// caller's preferences, PUT replaces them.
+func (h *NotificationHandlers) Preferences(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.get(w, r, identity.UserID)
+	case http.MethodPut:
+		h.update(w, r, identity.UserID)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *NotificationHandlers) get(w http.ResponseWriter, r *http.Request, userID string) {
+	prefs, err := h.prefs.Get(r.Context(), userID)
+	if err != nil {
+		http.Error(w, "failed to load preferences", http.StatusInternalServerError)
+		return
+	}
+	writeJSONBody(w, prefs)
+}
+
+type updatePreferencesRequest struct {
+	SubscriptionExpiry bool `json:"subscription_expiry"`
+	QuotaWarnings      bool `json:"quota_warnings"`
+}
+
+func (h *NotificationHandlers) update(w http.ResponseWriter, r *http.Request, userID string) {
+	req, ok := authmiddleware.BindAndValidate[updatePreferencesRequest](w, r)
+	if !ok {
+		return
+	}
+
+	prefs := notify.Preferences{
+		UserID:             userID,
+		SubscriptionExpiry: req.SubscriptionExpiry,
+		QuotaWarnings:      req.QuotaWarnings,
+	}
+	if err := h.prefs.Upsert(r.Context(), prefs); err != nil {
+		http.Error(w, "failed to update preferences", http.StatusInternalServerError)
+		return
+	}
+	writeJSONBody(w, prefs)
+}
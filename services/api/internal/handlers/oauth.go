@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/auth"
+	"github.com/XXXXD-cation/proxy-platform/pkg/oauth2"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// errEmailNotVerified is returned by resolveAccount when it finds an
+// existing account matching the provider's claimed email but that
+// provider hasn't itself verified the email, so auto-linking (and the
+// account takeover it would allow) is refused.
+var errEmailNotVerified = errors.New("oauth email not verified")
+
+// OAuthHandlers serves the "Sign in with Google/GitHub" flow: starting
+// the redirect to the provider and handling its callback.
+type OAuthHandlers struct {
+	users     *user.DAO
+	tokens    *auth.RefreshTokenService
+	states    *oauth2.StateStore
+	providers map[string]oauth2.Provider
+}
+
+// NewOAuthHandlers builds OAuthHandlers over a set of configured
+// providers, keyed by the name used in the /api/auth/oauth/{provider}/...
+// path (e.g. "google", "github").
+func NewOAuthHandlers(users *user.DAO, tokens *auth.RefreshTokenService, states *oauth2.StateStore, providers map[string]oauth2.Provider) *OAuthHandlers {
+	return &OAuthHandlers{users: users, tokens: tokens, states: states, providers: providers}
+}
+
+type authURLResponse struct {
+	AuthURL string `json:"auth_url"`
+}
+
+// Start handles GET /api/auth/oauth/{provider}/start: it returns the URL
+// the client should navigate to in order to authorize with provider.
+func (h *OAuthHandlers) Start(w http.ResponseWriter, r *http.Request) {
+	provider, ok := h.provider(w, r, "/start")
+	if !ok {
+		return
+	}
+
+	state, err := h.states.IssueState(r.Context())
+	if err != nil {
+		http.Error(w, "failed to start login", http.StatusInternalServerError)
+		return
+	}
+	writeJSONBody(w, authURLResponse{AuthURL: provider.AuthCodeURL(state)})
+}
+
+// Callback handles GET /api/auth/oauth/{provider}/callback: it exchanges
+// the authorization code for the caller's identity, links it to an
+// existing account or auto-provisions a new one by email, and returns a
+// standard access+refresh token pair.
+func (h *OAuthHandlers) Callback(w http.ResponseWriter, r *http.Request) {
+	provider, ok := h.provider(w, r, "/callback")
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	if err := h.states.ConsumeState(r.Context(), q.Get("state")); err != nil {
+		http.Error(w, "invalid or expired login attempt", http.StatusBadRequest)
+		return
+	}
+	code := q.Get("code")
+	if code == "" {
+		http.Error(w, "code is required", http.StatusBadRequest)
+		return
+	}
+
+	accessToken, err := provider.Exchange(r.Context(), code)
+	if err != nil {
+		http.Error(w, "failed to complete login", http.StatusBadGateway)
+		return
+	}
+	identity, err := provider.FetchIdentity(r.Context(), accessToken)
+	if err != nil || identity.Email == "" {
+		http.Error(w, "failed to complete login", http.StatusBadGateway)
+		return
+	}
+
+	acct, err := h.resolveAccount(r, provider.Name, identity)
+	if err != nil {
+		if errors.Is(err, errEmailNotVerified) {
+			http.Error(w, "this email address is not verified with the identity provider", http.StatusForbidden)
+			return
+		}
+		http.Error(w, "failed to complete login", http.StatusInternalServerError)
+		return
+	}
+
+	pair, err := h.tokens.IssuePair(r.Context(), acct.ID, auth.RoleUser, r.UserAgent())
+	if err != nil {
+		http.Error(w, "failed to issue tokens", http.StatusInternalServerError)
+		return
+	}
+	writeJSONBody(w, tokenPairView(pair))
+}
+
+// resolveAccount finds the account already linked to identity, links
+// identity to an existing account with a matching email, or
+// auto-provisions a brand new account — in that order of preference.
+func (h *OAuthHandlers) resolveAccount(r *http.Request, providerName string, identity oauth2.Identity) (*user.User, error) {
+	if acct, err := h.users.GetByOAuthIdentity(r.Context(), providerName, identity.ProviderUserID); err == nil {
+		return acct, nil
+	} else if err != user.ErrNotFound {
+		return nil, err
+	}
+
+	if acct, err := h.users.GetByEmail(r.Context(), identity.Email); err == nil {
+		if !identity.EmailVerified {
+			return nil, errEmailNotVerified
+		}
+		if err := h.users.LinkOAuthIdentity(r.Context(), acct.ID, providerName, identity.ProviderUserID); err != nil {
+			return nil, err
+		}
+		return acct, nil
+	} else if err != user.ErrNotFound {
+		return nil, err
+	}
+
+	return h.users.CreateFromOAuth(r.Context(), identity.Email, providerName, identity.ProviderUserID)
+}
+
+func (h *OAuthHandlers) provider(w http.ResponseWriter, r *http.Request, suffix string) (oauth2.Provider, bool) {
+	rest := strings.TrimPrefix(r.URL.Path, "/api/auth/oauth/")
+	name := strings.TrimSuffix(rest, suffix)
+
+	provider, ok := h.providers[name]
+	if !ok {
+		http.Error(w, "unknown provider", http.StatusNotFound)
+		return oauth2.Provider{}, false
+	}
+	return provider, true
+}
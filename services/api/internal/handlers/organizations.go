@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/organization"
+)
+
+// OrgHandlers lets authenticated users create organizations and manage
+// the membership of the ones they belong to.
+type OrgHandlers struct {
+	orgs     organization.DAOInterface
+	auditLog *audit.Logger
+}
+
+// NewOrgHandlers builds OrgHandlers.
+func NewOrgHandlers(orgs organization.DAOInterface, auditLog *audit.Logger) *OrgHandlers {
+	return &OrgHandlers{orgs: orgs, auditLog: auditLog}
+}
+
+// Collection handles /api/v1/organizations: GET lists the organizations
+// the caller belongs to, POST creates a new one with the caller as its
+// founding owner.
+func (h *OrgHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *OrgHandlers) list(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	orgs, err := h.orgs.ListForUser(r.Context(), identity.UserID)
+	if err != nil {
+		http.Error(w, "failed to list organizations", http.StatusInternalServerError)
+		return
+	}
+	writeJSONBody(w, orgsToView(orgs))
+}
+
+type createOrgRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func (h *OrgHandlers) create(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[createOrgRequest](w, r)
+	if !ok {
+		return
+	}
+
+	org, err := h.orgs.Create(r.Context(), req.Name, identity.UserID)
+	if err != nil {
+		http.Error(w, "failed to create organization", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "organization.create", org.ID, org.Name)
+	w.WriteHeader(http.StatusCreated)
+	writeJSONBody(w, orgToView(org))
+}
+
+// Item handles /api/v1/organizations/{id}... sub-routes: the bare
+// resource (PATCH to rename, DELETE to remove) and membership
+// management under members.
+func (h *OrgHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	rest := strings.TrimPrefix(r.URL.Path, "/api/v1/organizations/")
+	id, sub, _ := strings.Cut(rest, "/")
+	if id == "" {
+		http.Error(w, "organization id is required", http.StatusBadRequest)
+		return
+	}
+
+	if memberID, ok := strings.CutPrefix(sub, "members/"); ok {
+		h.member(w, r, identity.UserID, id, memberID)
+		return
+	}
+
+	switch {
+	case sub == "" && r.Method == http.MethodPatch:
+		h.rename(w, r, identity.UserID, id)
+	case sub == "" && r.Method == http.MethodDelete:
+		h.delete(w, r, identity.UserID, id)
+	case sub == "members" && r.Method == http.MethodGet:
+		h.listMembers(w, r, identity.UserID, id)
+	case sub == "members" && r.Method == http.MethodPost:
+		h.addMember(w, r, identity.UserID, id)
+	default:
+		http.Error(w, "not found", http.StatusNotFound)
+	}
+}
+
+// requireRole loads the caller's membership in orgID and confirms it is
+// one of allowed, the way pkg/middleware.Auth confirms a credential
+// before a handler acts on it.
+func (h *OrgHandlers) requireRole(w http.ResponseWriter, r *http.Request, userID, orgID string, allowed ...organization.Role) (*organization.Member, bool) {
+	member, err := h.orgs.GetMembership(r.Context(), orgID, userID)
+	if err != nil {
+		if errors.Is(err, organization.ErrNotMember) {
+			http.Error(w, "not a member of this organization", http.StatusForbidden)
+			return nil, false
+		}
+		http.Error(w, "failed to check organization membership", http.StatusInternalServerError)
+		return nil, false
+	}
+	for _, role := range allowed {
+		if member.Role == role {
+			return member, true
+		}
+	}
+	http.Error(w, "insufficient organization role", http.StatusForbidden)
+	return nil, false
+}
+
+type renameOrgRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func (h *OrgHandlers) rename(w http.ResponseWriter, r *http.Request, userID, orgID string) {
+	if _, ok := h.requireRole(w, r, userID, orgID, organization.RoleOwner, organization.RoleAdmin); !ok {
+		return
+	}
+	req, ok := authmiddleware.BindAndValidate[renameOrgRequest](w, r)
+	if !ok {
+		return
+	}
+	if err := h.orgs.Rename(r.Context(), orgID, req.Name); err != nil {
+		http.Error(w, "failed to rename organization", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "organization.rename", orgID, req.Name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *OrgHandlers) delete(w http.ResponseWriter, r *http.Request, userID, orgID string) {
+	if _, ok := h.requireRole(w, r, userID, orgID, organization.RoleOwner); !ok {
+		return
+	}
+	if err := h.orgs.Delete(r.Context(), orgID); err != nil {
+		http.Error(w, "failed to delete organization", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "organization.delete", orgID, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *OrgHandlers) listMembers(w http.ResponseWriter, r *http.Request, userID, orgID string) {
+	if _, ok := h.requireRole(w, r, userID, orgID, organization.RoleOwner, organization.RoleAdmin, organization.RoleMember); !ok {
+		return
+	}
+	members, err := h.orgs.ListMembers(r.Context(), orgID)
+	if err != nil {
+		http.Error(w, "failed to list members", http.StatusInternalServerError)
+		return
+	}
+	writeJSONBody(w, membersToView(members))
+}
+
+type addMemberRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+	Role   string `json:"role" validate:"required"`
+}
+
+func (h *OrgHandlers) addMember(w http.ResponseWriter, r *http.Request, userID, orgID string) {
+	caller, ok := h.requireRole(w, r, userID, orgID, organization.RoleOwner, organization.RoleAdmin)
+	if !ok {
+		return
+	}
+	req, ok := authmiddleware.BindAndValidate[addMemberRequest](w, r)
+	if !ok {
+		return
+	}
+	role := organization.Role(req.Role)
+	if role != organization.RoleOwner && role != organization.RoleAdmin && role != organization.RoleMember {
+		http.Error(w, "invalid role", http.StatusBadRequest)
+		return
+	}
+	if role == organization.RoleOwner && caller.Role != organization.RoleOwner {
+		http.Error(w, "only an owner may grant the owner role", http.StatusForbidden)
+		return
+	}
+	if err := h.orgs.AddMember(r.Context(), orgID, req.UserID, role); err != nil {
+		http.Error(w, "failed to add member", http.StatusInternalServerError)
+		return
+	}
+	h.record(r, "organization.member.add", orgID, req.UserID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type updateMemberRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+func (h *OrgHandlers) member(w http.ResponseWriter, r *http.Request, userID, orgID, memberID string) {
+	caller, ok := h.requireRole(w, r, userID, orgID, organization.RoleOwner, organization.RoleAdmin)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		req, ok := authmiddleware.BindAndValidate[updateMemberRequest](w, r)
+		if !ok {
+			return
+		}
+		role := organization.Role(req.Role)
+		if role != organization.RoleOwner && role != organization.RoleAdmin && role != organization.RoleMember {
+			http.Error(w, "invalid role", http.StatusBadRequest)
+			return
+		}
+		if role == organization.RoleOwner && caller.Role != organization.RoleOwner {
+			http.Error(w, "only an owner may grant the owner role", http.StatusForbidden)
+			return
+		}
+		if err := h.orgs.UpdateMemberRole(r.Context(), orgID, memberID, role); err != nil {
+			if errors.Is(err, organization.ErrLastOwner) {
+				http.Error(w, "organization must keep at least one owner", http.StatusConflict)
+				return
+			}
+			http.Error(w, "failed to update member role", http.StatusInternalServerError)
+			return
+		}
+		h.record(r, "organization.member.update_role", orgID, memberID)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if err := h.orgs.RemoveMember(r.Context(), orgID, memberID); err != nil {
+			if errors.Is(err, organization.ErrLastOwner) {
+				http.Error(w, "organization must keep at least one owner", http.StatusConflict)
+				return
+			}
+			http.Error(w, "failed to remove member", http.StatusInternalServerError)
+			return
+		}
+		h.record(r, "organization.member.remove", orgID, memberID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *OrgHandlers) record(r *http.Request, action, target, detail string) {
+	identity, _ := authmiddleware.IdentityFromContext(r.Context())
+	entry := audit.Entry{
+		ActorID:   identity.UserID,
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
+
+// orgView is the JSON shape returned for an organization.
+type orgView struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+func orgToView(o *organization.Organization) orgView {
+	return orgView{ID: o.ID, Name: o.Name, CreatedAt: o.CreatedAt}
+}
+
+func orgsToView(orgs []*organization.Organization) []orgView {
+	out := make([]orgView, len(orgs))
+	for i, o := range orgs {
+		out[i] = orgToView(o)
+	}
+	return out
+}
+
+// memberView is the JSON shape returned for an organization membership.
+type memberView struct {
+	UserID   string    `json:"user_id"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at,omitempty"`
+}
+
+func memberToView(m *organization.Member) memberView {
+	return memberView{UserID: m.UserID, Role: string(m.Role), JoinedAt: m.JoinedAt}
+}
+
+func membersToView(members []*organization.Member) []memberView {
+	out := make([]memberView, len(members))
+	for i, m := range members {
+		out[i] = memberToView(m)
+	}
+	return out
+}
@@ -0,0 +1,124 @@
+// Package handlers implements the customer-facing REST API's HTTP
+// endpoints.
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+	"github.com/XXXXD-cation/proxy-platform/services/api/internal/middleware"
+	"github.com/XXXXD-cation/proxy-platform/services/api/internal/planlimits"
+)
+
+// ProxyHandlers serves proxy retrieval for authenticated customers.
+type ProxyHandlers struct {
+	proxies *dao.ProxyDAO
+	users   *user.DAO
+}
+
+// NewProxyHandlers builds ProxyHandlers.
+func NewProxyHandlers(proxies *dao.ProxyDAO, users *user.DAO) *ProxyHandlers {
+	return &ProxyHandlers{proxies: proxies, users: users}
+}
+
+// List handles GET /api/v1/proxies: returns proxies matching the query
+// parameters (country, protocol, min_score, max_latency_ms, count),
+// clamped to the caller's plan limit, in the format requested by the
+// "format" parameter (json, the default; txt, one "ip:port" per line; or
+// csv).
+func (h *ProxyHandlers) List(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	key := middleware.APIKey(r.Context())
+	if key == nil {
+		http.Error(w, "api key required", http.StatusUnauthorized)
+		return
+	}
+	caller, err := h.users.Get(r.Context(), key.UserID)
+	if err != nil {
+		http.Error(w, "failed to resolve account", http.StatusInternalServerError)
+		return
+	}
+
+	q := r.URL.Query()
+	filter := dao.ProxyFilter{
+		Country:  q.Get("country"),
+		Protocol: proxy.Protocol(q.Get("protocol")),
+	}
+	if v := q.Get("min_score"); v != "" {
+		if score, err := strconv.ParseFloat(v, 64); err == nil {
+			filter.MinScore = score
+		}
+	}
+	if v := q.Get("max_latency_ms"); v != "" {
+		if latency, err := strconv.Atoi(v); err == nil {
+			filter.MaxLatencyMS = latency
+		}
+	}
+
+	requested := 0
+	if v := q.Get("count"); v != "" {
+		if count, err := strconv.Atoi(v); err == nil {
+			requested = count
+		}
+	}
+	filter.Limit = planlimits.ClampProxyCount(caller.Plan, requested)
+
+	proxies, _, _, err := h.proxies.Search(r.Context(), filter)
+	if err != nil {
+		http.Error(w, "failed to fetch proxies", http.StatusInternalServerError)
+		return
+	}
+
+	switch q.Get("format") {
+	case "txt":
+		writeTXT(w, proxies)
+	case "csv":
+		writeCSV(w, proxies)
+	default:
+		writeJSON(w, proxies)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, proxies []*proxy.Proxy) {
+	writeJSONBody(w, proxies)
+}
+
+func writeJSONBody(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeTXT(w http.ResponseWriter, proxies []*proxy.Proxy) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	for _, p := range proxies {
+		fmt.Fprintln(w, p.Addr())
+	}
+}
+
+func writeCSV(w http.ResponseWriter, proxies []*proxy.Proxy) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	writer.Write([]string{"host", "port", "protocol", "country", "score", "latency_ms"})
+	for _, p := range proxies {
+		writer.Write([]string{
+			p.Host,
+			strconv.Itoa(p.Port),
+			string(p.Protocol),
+			p.Country,
+			strconv.FormatFloat(p.Score, 'f', 2, 64),
+			strconv.Itoa(p.LatencyMS),
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/billing"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+)
+
+// defaultTimeseriesRange is how far back Timeseries looks when the
+// caller doesn't pass from/to query parameters, for each granularity.
+var defaultTimeseriesRange = map[string]time.Duration{
+	"hour": 24 * time.Hour,
+	"day":  30 * 24 * time.Hour,
+}
+
+// defaultTopDomainsRange is how far back TopDomains looks when the
+// caller doesn't pass from/to query parameters.
+const defaultTopDomainsRange = 7 * 24 * time.Hour
+
+// defaultTopDomainsLimit and maxTopDomainsLimit bound TopDomains' "limit"
+// query parameter.
+const (
+	defaultTopDomainsLimit = 10
+	maxTopDomainsLimit     = 100
+)
+
+// StatsHandlers serves the customer-facing usage dashboard: today/month
+// totals against the caller's plan quota, a bucketed request timeseries,
+// and their most-requested target domains. All three read from the same
+// rollup tables pkg/usage already maintains for the admin dashboard.
+type StatsHandlers struct {
+	usage   *usage.DAO
+	rollups *usage.RollupDAO
+	subs    *billing.SubscriptionDAO
+	plans   *billing.PlanDAO
+}
+
+// NewStatsHandlers builds StatsHandlers.
+func NewStatsHandlers(usageDAO *usage.DAO, rollups *usage.RollupDAO, subs *billing.SubscriptionDAO, plans *billing.PlanDAO) *StatsHandlers {
+	return &StatsHandlers{usage: usageDAO, rollups: rollups, subs: subs, plans: plans}
+}
+
+// Summary handles GET /api/v1/stats/summary: today's and this month's
+// request volume, plus the caller's monthly quota and how much of it
+// remains.
+func (h *StatsHandlers) Summary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	now := time.Now().UTC()
+	today, err := h.rollups.TodayStats(r.Context(), h.usage, identity.UserID, now)
+	if err != nil {
+		http.Error(w, "failed to load usage stats", http.StatusInternalServerError)
+		return
+	}
+	month, err := h.rollups.MonthlyStats(r.Context(), h.usage, identity.UserID, now)
+	if err != nil {
+		http.Error(w, "failed to load usage stats", http.StatusInternalServerError)
+		return
+	}
+
+	sub, err := h.subs.Get(r.Context(), identity.UserID)
+	if err != nil {
+		http.Error(w, "failed to load subscription", http.StatusInternalServerError)
+		return
+	}
+	plan, err := h.plans.Get(r.Context(), sub.Plan)
+	if err != nil {
+		http.Error(w, "failed to load plan", http.StatusInternalServerError)
+		return
+	}
+
+	remaining := plan.QuotaRequests - month.RequestCount
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	writeJSONBody(w, statsSummaryResponse{
+		Today: statsView(today),
+		Month: statsView(month),
+		Quota: quotaView{
+			Plan:          string(plan.Plan),
+			QuotaRequests: plan.QuotaRequests,
+			UsedRequests:  month.RequestCount,
+			Remaining:     remaining,
+		},
+	})
+}
+
+type statsSummaryResponse struct {
+	Today usageStatsView `json:"today"`
+	Month usageStatsView `json:"month"`
+	Quota quotaView      `json:"quota"`
+}
+
+type quotaView struct {
+	Plan          string `json:"plan"`
+	QuotaRequests int64  `json:"quota_requests"`
+	UsedRequests  int64  `json:"used_requests"`
+	Remaining     int64  `json:"remaining"`
+}
+
+// Timeseries handles GET /api/v1/stats/timeseries: the caller's request
+// volume bucketed by hour or day (the "granularity" query parameter,
+// "hour" by default), over the range given by the "from"/"to" query
+// parameters (RFC 3339), defaulting to the last 24 hours for hourly
+// buckets or the last 30 days for daily buckets.
+func (h *StatsHandlers) Timeseries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+	granularity := q.Get("granularity")
+	if granularity == "" {
+		granularity = "hour"
+	}
+	if granularity != "hour" && granularity != "day" {
+		http.Error(w, `granularity must be "hour" or "day"`, http.StatusBadRequest)
+		return
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-defaultTimeseriesRange[granularity])
+	if v := q.Get("from"); v != "" {
+		if parsed, err := time.Parse(time.RFC3339, v); err == nil {
+			start = parsed
+		}
+	}
+	if v := q.Get("to"); v != "" {
+		if parsed, err := time.Parse(time.RFC3339, v); err == nil {
+			end = parsed
+		}
+	}
+
+	var buckets []timeseriesBucket
+	if granularity == "day" {
+		daily, err := h.rollups.DailyRange(r.Context(), identity.UserID, start, end)
+		if err != nil {
+			http.Error(w, "failed to load usage stats", http.StatusInternalServerError)
+			return
+		}
+		buckets = make([]timeseriesBucket, len(daily))
+		for i, b := range daily {
+			buckets[i] = timeseriesBucket{BucketStart: b.BucketDate, Stats: statsView(b.Stats), SuccessRate: successRate(b.Stats)}
+		}
+	} else {
+		hourly, err := h.rollups.HourlyRange(r.Context(), identity.UserID, start, end)
+		if err != nil {
+			http.Error(w, "failed to load usage stats", http.StatusInternalServerError)
+			return
+		}
+		buckets = make([]timeseriesBucket, len(hourly))
+		for i, b := range hourly {
+			buckets[i] = timeseriesBucket{BucketStart: b.BucketStart, Stats: statsView(b.Stats), SuccessRate: successRate(b.Stats)}
+		}
+	}
+
+	writeJSONBody(w, timeseriesResponse{Granularity: granularity, Buckets: buckets})
+}
+
+type timeseriesResponse struct {
+	Granularity string             `json:"granularity"`
+	Buckets     []timeseriesBucket `json:"buckets"`
+}
+
+type timeseriesBucket struct {
+	BucketStart time.Time      `json:"bucket_start"`
+	Stats       usageStatsView `json:"stats"`
+	SuccessRate float64        `json:"success_rate"`
+}
+
+func successRate(s usage.Stats) float64 {
+	if s.RequestCount == 0 {
+		return 1
+	}
+	return 1 - float64(s.ErrorCount)/float64(s.RequestCount)
+}
+
+// TopDomains handles GET /api/v1/stats/top-domains: the caller's
+// most-requested target domains over the range given by the "from"/"to"
+// query parameters (RFC 3339, defaulting to the last 7 days), most
+// requested first, bounded by the "limit" query parameter (10 by
+// default, 100 at most).
+func (h *StatsHandlers) TopDomains(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-defaultTopDomainsRange)
+
+	q := r.URL.Query()
+	if v := q.Get("from"); v != "" {
+		if parsed, err := time.Parse(time.RFC3339, v); err == nil {
+			start = parsed
+		}
+	}
+	if v := q.Get("to"); v != "" {
+		if parsed, err := time.Parse(time.RFC3339, v); err == nil {
+			end = parsed
+		}
+	}
+
+	limit := defaultTopDomainsLimit
+	if v := q.Get("limit"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+	if limit > maxTopDomainsLimit {
+		limit = maxTopDomainsLimit
+	}
+
+	top, err := h.usage.TopTargetHostsForUser(r.Context(), identity.UserID, start, end, limit)
+	if err != nil {
+		http.Error(w, "failed to load top domains", http.StatusInternalServerError)
+		return
+	}
+
+	domains := make([]topDomainView, len(top))
+	for i, t := range top {
+		domains[i] = topDomainView{TargetHost: t.TargetHost, RequestCount: t.Count}
+	}
+	writeJSONBody(w, topDomainsResponse{Domains: domains})
+}
+
+type topDomainsResponse struct {
+	Domains []topDomainView `json:"domains"`
+}
+
+type topDomainView struct {
+	TargetHost   string `json:"target_host"`
+	RequestCount int64  `json:"request_count"`
+}
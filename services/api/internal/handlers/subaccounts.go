@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/audit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/auth"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/reseller"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// SubAccountHandlers lets a reseller account create and manage the
+// limited sub-accounts it resells access through: allocating them a
+// share of its traffic, viewing their usage, and suspending them.
+type SubAccountHandlers struct {
+	users    *user.DAO
+	quotas   reseller.DAOInterface
+	usageDAO *usage.DAO
+	rollups  *usage.RollupDAO
+	auditLog *audit.Logger
+}
+
+// NewSubAccountHandlers builds SubAccountHandlers.
+func NewSubAccountHandlers(users *user.DAO, quotas reseller.DAOInterface, usageDAO *usage.DAO, rollups *usage.RollupDAO, auditLog *audit.Logger) *SubAccountHandlers {
+	return &SubAccountHandlers{users: users, quotas: quotas, usageDAO: usageDAO, rollups: rollups, auditLog: auditLog}
+}
+
+// Collection handles /api/v1/subaccounts: GET lists the caller's
+// sub-accounts, POST creates a new one.
+func (h *SubAccountHandlers) Collection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *SubAccountHandlers) list(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	subs, err := h.users.ListByParent(r.Context(), identity.UserID)
+	if err != nil {
+		http.Error(w, "failed to list sub-accounts", http.StatusInternalServerError)
+		return
+	}
+
+	out := make([]subAccountView, len(subs))
+	for i, sub := range subs {
+		out[i] = h.subAccountView(r, sub)
+	}
+	writeJSONBody(w, out)
+}
+
+type createSubAccountRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+	Plan     string `json:"plan"`
+}
+
+func (h *SubAccountHandlers) create(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	req, ok := authmiddleware.BindAndValidate[createSubAccountRequest](w, r)
+	if !ok {
+		return
+	}
+	if err := auth.ValidatePassword(req.Password); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	plan := user.Plan(req.Plan)
+	if plan == "" {
+		plan = user.PlanFree
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		http.Error(w, "failed to hash password", http.StatusInternalServerError)
+		return
+	}
+
+	sub, err := h.users.CreateSubAccount(r.Context(), identity.UserID, req.Email, hash, plan)
+	if err != nil {
+		http.Error(w, "failed to create sub-account", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "subaccount.create", sub.ID, sub.Email)
+	w.WriteHeader(http.StatusCreated)
+	writeJSONBody(w, h.subAccountView(r, sub))
+}
+
+// Item handles /api/v1/subaccounts/{id}... sub-routes: quota allocation
+// and suspend/activate.
+func (h *SubAccountHandlers) Item(w http.ResponseWriter, r *http.Request) {
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	rest := strings.TrimPrefix(r.URL.Path, "/api/v1/subaccounts/")
+	id, action, _ := strings.Cut(rest, "/")
+	if id == "" {
+		http.Error(w, "sub-account id is required", http.StatusBadRequest)
+		return
+	}
+
+	sub, ok := h.requireOwnedSubAccount(w, r, identity.UserID, id)
+	if !ok {
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		writeJSONBody(w, h.subAccountView(r, sub))
+	case action == "quota" && r.Method == http.MethodPut:
+		h.allocateQuota(w, r, sub)
+	case action == "suspend" && r.Method == http.MethodPost:
+		h.setStatus(w, r, sub, user.StatusSuspended)
+	case action == "activate" && r.Method == http.MethodPost:
+		h.setStatus(w, r, sub, user.StatusActive)
+	default:
+		http.Error(w, "not found", http.StatusNotFound)
+	}
+}
+
+// requireOwnedSubAccount loads id and confirms it is a sub-account of
+// parentUserID, so one reseller can't manage another's sub-accounts by
+// guessing IDs.
+func (h *SubAccountHandlers) requireOwnedSubAccount(w http.ResponseWriter, r *http.Request, parentUserID, id string) (*user.User, bool) {
+	sub, err := h.users.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			http.Error(w, "sub-account not found", http.StatusNotFound)
+			return nil, false
+		}
+		http.Error(w, "failed to load sub-account", http.StatusInternalServerError)
+		return nil, false
+	}
+	if sub.ParentUserID != parentUserID {
+		http.Error(w, "sub-account not found", http.StatusNotFound)
+		return nil, false
+	}
+	return sub, true
+}
+
+type allocateQuotaRequest struct {
+	AllocatedRequests int64 `json:"allocated_requests" validate:"required"`
+}
+
+func (h *SubAccountHandlers) allocateQuota(w http.ResponseWriter, r *http.Request, sub *user.User) {
+	req, ok := authmiddleware.BindAndValidate[allocateQuotaRequest](w, r)
+	if !ok {
+		return
+	}
+	if req.AllocatedRequests < 0 {
+		http.Error(w, "allocated_requests must not be negative", http.StatusBadRequest)
+		return
+	}
+
+	identity, _ := authmiddleware.IdentityFromContext(r.Context())
+	if _, err := h.quotas.Allocate(r.Context(), identity.UserID, sub.ID, req.AllocatedRequests); err != nil {
+		http.Error(w, "failed to allocate quota", http.StatusInternalServerError)
+		return
+	}
+
+	h.record(r, "subaccount.quota.allocate", sub.ID, "")
+	writeJSONBody(w, h.subAccountView(r, sub))
+}
+
+func (h *SubAccountHandlers) setStatus(w http.ResponseWriter, r *http.Request, sub *user.User, status user.Status) {
+	if err := h.users.UpdateStatus(r.Context(), sub.ID, status); err != nil {
+		http.Error(w, "failed to update sub-account status", http.StatusInternalServerError)
+		return
+	}
+	sub.Status = status
+
+	action := "subaccount.suspend"
+	if status == user.StatusActive {
+		action = "subaccount.activate"
+	}
+	h.record(r, action, sub.ID, "")
+	writeJSONBody(w, h.subAccountView(r, sub))
+}
+
+func (h *SubAccountHandlers) record(r *http.Request, action, target, detail string) {
+	identity, _ := authmiddleware.IdentityFromContext(r.Context())
+	entry := audit.Entry{
+		ActorID:   identity.UserID,
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		IP:        audit.ClientIP(r),
+		RequestID: authmiddleware.RequestIDFromContext(r.Context()),
+	}
+	if err := h.auditLog.Record(r.Context(), entry); err != nil {
+		log.Printf("api: WARNING failed to write audit entry for %s: %v", action, err)
+	}
+}
+
+// subAccountView is the JSON shape returned for a sub-account: its
+// account details, its current quota allocation (nil if unlimited), and
+// its usage so far this month.
+type subAccountView struct {
+	ID                string         `json:"id"`
+	Email             string         `json:"email"`
+	Status            string         `json:"status"`
+	Plan              string         `json:"plan"`
+	AllocatedRequests *int64         `json:"allocated_requests,omitempty"`
+	MonthToDate       usageStatsView `json:"month_to_date"`
+	CreatedAt         time.Time      `json:"created_at,omitempty"`
+}
+
+func (h *SubAccountHandlers) subAccountView(r *http.Request, sub *user.User) subAccountView {
+	view := subAccountView{
+		ID:        sub.ID,
+		Email:     sub.Email,
+		Status:    string(sub.Status),
+		Plan:      string(sub.Plan),
+		CreatedAt: sub.CreatedAt,
+	}
+	if quota, err := h.quotas.Get(r.Context(), sub.ID); err == nil {
+		view.AllocatedRequests = &quota.AllocatedRequests
+	}
+	if stats, err := h.rollups.MonthlyStats(r.Context(), h.usageDAO, sub.ID, time.Now().UTC()); err == nil {
+		view.MonthToDate = statsView(stats)
+	}
+	return view
+}
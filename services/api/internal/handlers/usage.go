@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+)
+
+// UsageHandlers serves usage statistics for authenticated customers.
+type UsageHandlers struct {
+	usage   *usage.DAO
+	rollups *usage.RollupDAO
+}
+
+// NewUsageHandlers builds UsageHandlers.
+func NewUsageHandlers(usageDAO *usage.DAO, rollups *usage.RollupDAO) *UsageHandlers {
+	return &UsageHandlers{usage: usageDAO, rollups: rollups}
+}
+
+// Summary handles GET /api/v1/usage/summary: it returns the caller's
+// request/byte/error counts for today and for the current calendar
+// month, combining completed rollup buckets with the not-yet-rolled-up
+// tail the same way the admin dashboards do.
+func (h *UsageHandlers) Summary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	identity, ok := authmiddleware.IdentityFromContext(r.Context())
+	if !ok {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	now := time.Now().UTC()
+	today, err := h.rollups.TodayStats(r.Context(), h.usage, identity.UserID, now)
+	if err != nil {
+		http.Error(w, "failed to load usage stats", http.StatusInternalServerError)
+		return
+	}
+	month, err := h.rollups.MonthlyStats(r.Context(), h.usage, identity.UserID, now)
+	if err != nil {
+		http.Error(w, "failed to load usage stats", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSONBody(w, usageSummaryResponse{Today: statsView(today), Month: statsView(month)})
+}
+
+type usageSummaryResponse struct {
+	Today usageStatsView `json:"today"`
+	Month usageStatsView `json:"month"`
+}
+
+// usageStatsView is the JSON shape for a usage.Stats bucket.
+type usageStatsView struct {
+	RequestCount int64 `json:"request_count"`
+	BytesIn      int64 `json:"bytes_in"`
+	BytesOut     int64 `json:"bytes_out"`
+	ErrorCount   int64 `json:"error_count"`
+}
+
+func statsView(s usage.Stats) usageStatsView {
+	return usageStatsView{
+		RequestCount: s.RequestCount,
+		BytesIn:      s.BytesIn,
+		BytesOut:     s.BytesOut,
+		ErrorCount:   s.ErrorCount,
+	}
+}
@@ -0,0 +1,61 @@
+// Package middleware holds HTTP middleware shared across the
+// customer-facing API's handlers.
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apierrors"
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	"github.com/XXXXD-cation/proxy-platform/pkg/apilog"
+	"github.com/XXXXD-cation/proxy-platform/pkg/auth"
+	authmiddleware "github.com/XXXXD-cation/proxy-platform/pkg/middleware"
+)
+
+type contextKey int
+
+const apiKeyContextKey contextKey = iota
+
+// RequireAPIKey authenticates requests via the X-API-Key header, looking
+// the raw key up against pkg/apikey. It also attaches the caller's
+// identity to the request context, both via apilog.WithIdentity (so the
+// request logging middleware attributes the call correctly) and via
+// authmiddleware.WithIdentity, scoped to exactly the key's own
+// Permissions, so authmiddleware.RequirePermission can gate routes
+// reached through an API key the same way it gates JWT-authenticated
+// ones.
+func RequireAPIKey(keys *apikey.DAO) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw := r.Header.Get("X-API-Key")
+			if raw == "" {
+				apierrors.Write(w, r, apierrors.New(apierrors.CodeUnauthenticated, "api key required"))
+				return
+			}
+
+			key, err := keys.LookupByRawKey(r.Context(), raw)
+			if err != nil {
+				apierrors.Write(w, r, apierrors.New(apierrors.CodeUnauthenticated, "invalid api key"))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), apiKeyContextKey, key)
+			ctx = apilog.WithIdentity(ctx, key.UserID, key.ID)
+			ctx = authmiddleware.WithIdentity(ctx, authmiddleware.Identity{
+				UserID:      key.UserID,
+				Role:        auth.RoleUser,
+				Permissions: key.Permissions,
+				APIKeyID:    key.ID,
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// APIKey returns the caller's API key stashed in ctx by RequireAPIKey,
+// or nil if none is present.
+func APIKey(ctx context.Context) *apikey.Key {
+	key, _ := ctx.Value(apiKeyContextKey).(*apikey.Key)
+	return key
+}
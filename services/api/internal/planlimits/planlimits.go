@@ -0,0 +1,47 @@
+// Package planlimits maps a user's subscription plan to the limits it
+// grants on the customer-facing API, starting with how many proxies can
+// be fetched in one /api/v1/proxies request.
+package planlimits
+
+import "github.com/XXXXD-cation/proxy-platform/pkg/user"
+
+// MaxProxiesPerRequest is the largest count a plan is allowed to request
+// in one call. Plans not listed here fall back to PlanFree's limit.
+var MaxProxiesPerRequest = map[user.Plan]int{
+	user.PlanFree:       10,
+	user.PlanPro:        100,
+	user.PlanEnterprise: 1000,
+}
+
+// ClampProxyCount returns the smaller of requested and the plan's
+// per-request limit. A non-positive requested value is treated as "use
+// the plan's full limit".
+func ClampProxyCount(plan user.Plan, requested int) int {
+	limit, ok := MaxProxiesPerRequest[plan]
+	if !ok {
+		limit = MaxProxiesPerRequest[user.PlanFree]
+	}
+	if requested <= 0 || requested > limit {
+		return limit
+	}
+	return requested
+}
+
+// MaxExportRows is the largest number of usage_logs rows a plan may
+// export in a single job. Plans not listed here fall back to
+// PlanFree's limit.
+var MaxExportRows = map[user.Plan]int{
+	user.PlanFree:       10_000,
+	user.PlanPro:        500_000,
+	user.PlanEnterprise: 5_000_000,
+}
+
+// ExportRowLimit returns the largest number of rows plan may export in
+// a single job.
+func ExportRowLimit(plan user.Plan) int {
+	limit, ok := MaxExportRows[plan]
+	if !ok {
+		limit = MaxExportRows[user.PlanFree]
+	}
+	return limit
+}
@@ -0,0 +1,43 @@
+package planlimits
+
+import (
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+func TestClampProxyCountCapsAtPlanLimit(t *testing.T) {
+	if got := ClampProxyCount(user.PlanFree, 500); got != 10 {
+		t.Fatalf("expected free plan to cap at 10, got %d", got)
+	}
+}
+
+func TestClampProxyCountDefaultsToPlanLimitWhenUnrequested(t *testing.T) {
+	if got := ClampProxyCount(user.PlanPro, 0); got != 100 {
+		t.Fatalf("expected pro plan default of 100, got %d", got)
+	}
+}
+
+func TestClampProxyCountAllowsBelowLimit(t *testing.T) {
+	if got := ClampProxyCount(user.PlanEnterprise, 5); got != 5 {
+		t.Fatalf("expected requested count of 5 to pass through, got %d", got)
+	}
+}
+
+func TestClampProxyCountUnknownPlanFallsBackToFree(t *testing.T) {
+	if got := ClampProxyCount(user.Plan("bogus"), 500); got != 10 {
+		t.Fatalf("expected unknown plan to fall back to free limit of 10, got %d", got)
+	}
+}
+
+func TestExportRowLimitUnknownPlanFallsBackToFree(t *testing.T) {
+	if got := ExportRowLimit(user.Plan("bogus")); got != MaxExportRows[user.PlanFree] {
+		t.Fatalf("expected unknown plan to fall back to free limit of %d, got %d", MaxExportRows[user.PlanFree], got)
+	}
+}
+
+func TestExportRowLimitKnownPlan(t *testing.T) {
+	if got := ExportRowLimit(user.PlanPro); got != MaxExportRows[user.PlanPro] {
+		t.Fatalf("expected pro plan limit of %d, got %d", MaxExportRows[user.PlanPro], got)
+	}
+}
@@ -0,0 +1,105 @@
+// Command free-crawler periodically scrapes free public proxy lists and
+// imports newly discovered proxies with proxy.SourceFree.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+
+	"github.com/XXXXD-cation/proxy-platform/migrations"
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/eventbus"
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	"github.com/XXXXD-cation/proxy-platform/pkg/secrets"
+	"github.com/XXXXD-cation/proxy-platform/pkg/server"
+	"github.com/XXXXD-cation/proxy-platform/services/free-crawler/internal/crawler"
+	"github.com/XXXXD-cation/proxy-platform/services/free-crawler/internal/sources"
+)
+
+func main() {
+	run := server.New("free-crawler")
+
+	secretsResolver := secrets.NewDefaultResolver()
+
+	db, err := sql.Open("mysql", secretsResolver.MustGet(run.Context(), "MYSQL_DSN", ""))
+	if err != nil {
+		log.Fatalf("free-crawler: failed to open mysql connection: %v", err)
+	}
+	run.OnShutdown("mysql", func(context.Context) error { return db.Close() })
+	run.RegisterDependency("mysql", db.PingContext)
+
+	if err := migrate.Run(run.Context(), db, migrate.FS); err != nil {
+		log.Fatalf("free-crawler: failed to apply migrations: %v", err)
+	}
+
+	proxyDAO := dao.NewProxyDAO(db)
+
+	redisClient := redis.NewClient(redis.Config{
+		Addr:          secretsResolver.MustGet(run.Context(), "REDIS_ADDR", ""),
+		SentinelAddrs: redis.SplitAddrs(secretsResolver.MustGet(run.Context(), "REDIS_SENTINEL_ADDRS", "")),
+		MasterName:    secretsResolver.MustGet(run.Context(), "REDIS_MASTER_NAME", ""),
+		ClusterAddrs:  redis.SplitAddrs(secretsResolver.MustGet(run.Context(), "REDIS_CLUSTER_ADDRS", "")),
+		Password:      secretsResolver.MustGet(run.Context(), "REDIS_PASSWORD", ""),
+	})
+	run.OnShutdown("redis", func(context.Context) error { return redisClient.Close() })
+	run.RegisterDependency("redis", func(ctx context.Context) error { return redisClient.Ping(ctx).Err() })
+	eventBus := eventbus.NewRedisBus(redisClient)
+
+	pipeline := crawler.NewPipeline(proxyDAO, eventBus,
+		&sources.PlainTextList{SourceName: "proxyscrape-txt", URL: "https://api.proxyscrape.com/v2/?request=getproxies&protocol=http", Protocol: proxy.ProtocolHTTP, Client: http.DefaultClient},
+		&sources.HTMLTableList{SourceName: "free-proxy-list", URL: "https://free-proxy-list.net/", Protocol: proxy.ProtocolHTTP, Client: http.DefaultClient},
+		&sources.JSONAPIList{SourceName: "proxy-list-json", URL: "https://www.proxy-list.download/api/v1/get?type=socks5", Protocol: proxy.ProtocolSOCKS5, Client: http.DefaultClient},
+	)
+	const crawlInterval = 15 * time.Minute
+	scheduler := crawler.NewScheduler(pipeline, crawlInterval)
+	run.RegisterDependency("crawl scheduler", func(context.Context) error {
+		lastRun := scheduler.LastRunAt()
+		if lastRun.IsZero() {
+			return nil // still waiting on its first pass
+		}
+		if age := time.Since(lastRun); age > 2*crawlInterval {
+			return fmt.Errorf("no crawl pass completed in %s", age.Round(time.Second))
+		}
+		return nil
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/crawler/start", func(w http.ResponseWriter, r *http.Request) {
+		go func() {
+			result := pipeline.Run(context.Background())
+			log.Printf("free-crawler: on-demand run complete, discovered=%d imported=%d", result.Discovered, result.Imported)
+		}()
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("crawl started"))
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/readyz", run.ReadyHandler())
+
+	run.Go(scheduler.Run)
+
+	addr := os.Getenv("FREE_CRAWLER_ADDR")
+	if addr == "" {
+		addr = ":8082"
+	}
+	httpServer := &http.Server{Addr: addr, Handler: mux}
+	run.OnShutdown("http server", server.HTTPCloser(httpServer))
+
+	go func() {
+		log.Printf("free-crawler: listening on %s", addr)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("free-crawler: server failed: %v", err)
+		}
+	}()
+
+	run.Wait()
+}
@@ -0,0 +1,116 @@
+// Package crawler implements a pluggable pipeline for discovering free
+// public proxies: each Source plugin fetches candidates in its own
+// format, the pipeline dedups them against what is already known, and
+// bulk-imports the rest with proxy.SourceFree.
+package crawler
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/eventbus"
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// Candidate is a proxy endpoint discovered by a Source, not yet
+// deduplicated or persisted.
+type Candidate struct {
+	Host     string
+	Port     int
+	Protocol proxy.Protocol
+}
+
+// Source is a single free-proxy-list plugin: a site or feed format the
+// crawler knows how to parse.
+type Source interface {
+	Name() string
+	Fetch(ctx context.Context) ([]Candidate, error)
+}
+
+// Pipeline runs a set of Sources, dedups their candidates against
+// ProxyDAO, and bulk-imports anything new.
+type Pipeline struct {
+	sources  []Source
+	proxyDAO *dao.ProxyDAO
+	events   eventbus.Publisher
+}
+
+// NewPipeline creates a Pipeline over the given sources. events, if
+// non-nil, is notified with an eventbus.EventProxyDiscovered event
+// after each pass that imports at least one new proxy; a nil events
+// disables that.
+func NewPipeline(proxyDAO *dao.ProxyDAO, events eventbus.Publisher, sources ...Source) *Pipeline {
+	return &Pipeline{sources: sources, proxyDAO: proxyDAO, events: events}
+}
+
+// RunResult summarizes a single pipeline pass.
+type RunResult struct {
+	Discovered int
+	Imported   int
+	Inserted   int
+	Updated    int
+	Errors     []error
+}
+
+// Run fetches every source, discards candidates already known, and
+// bulk-imports the rest.
+func (p *Pipeline) Run(ctx context.Context) RunResult {
+	var result RunResult
+	var fresh []*proxy.Proxy
+
+	for _, src := range p.sources {
+		candidates, err := src.Fetch(ctx)
+		if err != nil {
+			log.Printf("crawler: source %s failed: %v", src.Name(), err)
+			result.Errors = append(result.Errors, err)
+			continue
+		}
+		result.Discovered += len(candidates)
+
+		for _, c := range candidates {
+			if _, err := p.proxyDAO.GetByIPPort(ctx, c.Host, c.Port); err == nil {
+				continue // already known, skip
+			}
+			fresh = append(fresh, &proxy.Proxy{
+				ID:       uuid.NewString(),
+				Host:     c.Host,
+				Port:     c.Port,
+				Protocol: c.Protocol,
+				Status:   proxy.StatusPending,
+				Source:   proxy.SourceFree,
+			})
+		}
+	}
+
+	if len(fresh) > 0 {
+		inserted, updated, err := p.proxyDAO.BulkUpsert(ctx, fresh)
+		if err != nil {
+			log.Printf("crawler: bulk import failed: %v", err)
+			result.Errors = append(result.Errors, err)
+		} else {
+			result.Imported = len(fresh)
+			result.Inserted = inserted
+			result.Updated = updated
+			p.publishDiscovered(ctx, inserted)
+		}
+	}
+
+	return result
+}
+
+// publishDiscovered notifies p.events, if any, that count new proxies
+// were just imported. It's best-effort: a publish failure is logged
+// but never fails the pass itself.
+func (p *Pipeline) publishDiscovered(ctx context.Context, count int) {
+	if p.events == nil || count == 0 {
+		return
+	}
+	event := eventbus.Event{Type: eventbus.EventProxyDiscovered, Fields: map[string]string{"count": strconv.Itoa(count)}}
+	if err := p.events.Publish(ctx, event); err != nil {
+		log.Printf("crawler: failed to publish proxy.discovered event: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package crawler
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// Scheduler periodically runs a Pipeline until stopped.
+type Scheduler struct {
+	pipeline *Pipeline
+	interval time.Duration
+
+	lastRun atomic.Int64 // unix nano of the last completed pass, 0 until Run's first pass finishes
+}
+
+// NewScheduler creates a Scheduler that runs pipeline every interval.
+func NewScheduler(pipeline *Pipeline, interval time.Duration) *Scheduler {
+	return &Scheduler{pipeline: pipeline, interval: interval}
+}
+
+// Run blocks, triggering a pipeline pass immediately and then on every
+// tick, until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.runOnce(ctx)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runOnce(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context) {
+	result := s.pipeline.Run(ctx)
+	log.Printf("crawler: pass complete, discovered=%d imported=%d errors=%d",
+		result.Discovered, result.Imported, len(result.Errors))
+	s.lastRun.Store(time.Now().UnixNano())
+}
+
+// LastRunAt reports when the most recent pass finished, or the zero
+// time if Run hasn't completed a pass yet. Use it to back a readiness
+// check for the scheduler's liveness.
+func (s *Scheduler) LastRunAt() time.Time {
+	ns := s.lastRun.Load()
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
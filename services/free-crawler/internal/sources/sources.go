@@ -0,0 +1,156 @@
+// Package sources implements free-crawler Source plugins for the public
+// proxy list formats we scrape.
+package sources
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/services/free-crawler/internal/crawler"
+)
+
+// httpGetter is satisfied by *http.Client; kept as an interface so
+// sources are testable without a real network call.
+type httpGetter interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// PlainTextList handles sources that publish a newline-separated list of
+// "ip:port" entries.
+type PlainTextList struct {
+	SourceName string
+	URL        string
+	Protocol   proxy.Protocol
+	Client     httpGetter
+}
+
+func (s *PlainTextList) Name() string { return s.SourceName }
+
+var ipPortPattern = regexp.MustCompile(`^(\d{1,3}(?:\.\d{1,3}){3}):(\d{1,5})$`)
+
+func (s *PlainTextList) Fetch(ctx context.Context) ([]crawler.Candidate, error) {
+	body, err := fetch(ctx, s.Client, s.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var candidates []crawler.Candidate
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		c, ok := parseIPPortLine(scanner.Text(), s.Protocol)
+		if ok {
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates, scanner.Err()
+}
+
+func parseIPPortLine(line string, protocol proxy.Protocol) (crawler.Candidate, bool) {
+	m := ipPortPattern.FindStringSubmatch(line)
+	if m == nil {
+		return crawler.Candidate{}, false
+	}
+	port, err := strconv.Atoi(m[2])
+	if err != nil {
+		return crawler.Candidate{}, false
+	}
+	return crawler.Candidate{Host: m[1], Port: port, Protocol: protocol}, true
+}
+
+// HTMLTableList handles "free-proxy-list"-style sites that render
+// candidates as rows of an HTML table with IP and port cells.
+type HTMLTableList struct {
+	SourceName string
+	URL        string
+	Protocol   proxy.Protocol
+	Client     httpGetter
+}
+
+func (s *HTMLTableList) Name() string { return s.SourceName }
+
+var tableRowPattern = regexp.MustCompile(`<td>(\d{1,3}(?:\.\d{1,3}){3})</td>\s*<td>(\d{1,5})</td>`)
+
+func (s *HTMLTableList) Fetch(ctx context.Context) ([]crawler.Candidate, error) {
+	body, err := fetch(ctx, s.Client, s.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	html, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []crawler.Candidate
+	for _, m := range tableRowPattern.FindAllStringSubmatch(string(html), -1) {
+		port, err := strconv.Atoi(m[2])
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, crawler.Candidate{Host: m[1], Port: port, Protocol: s.Protocol})
+	}
+	return candidates, nil
+}
+
+// JSONAPIList handles sources that expose a JSON array of {ip, port}
+// objects.
+type JSONAPIList struct {
+	SourceName string
+	URL        string
+	Protocol   proxy.Protocol
+	Client     httpGetter
+}
+
+func (s *JSONAPIList) Name() string { return s.SourceName }
+
+type jsonAPIEntry struct {
+	IP   string `json:"ip"`
+	Port int    `json:"port"`
+}
+
+func (s *JSONAPIList) Fetch(ctx context.Context) ([]crawler.Candidate, error) {
+	body, err := fetch(ctx, s.Client, s.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var entries []jsonAPIEntry
+	if err := json.NewDecoder(body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("sources: decode json list from %s: %w", s.SourceName, err)
+	}
+
+	candidates := make([]crawler.Candidate, 0, len(entries))
+	for _, e := range entries {
+		candidates = append(candidates, crawler.Candidate{Host: e.IP, Port: e.Port, Protocol: s.Protocol})
+	}
+	return candidates, nil
+}
+
+func fetch(ctx context.Context, client httpGetter, url string) (io.ReadCloser, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("sources: unexpected status %d from %s", resp.StatusCode, url)
+	}
+	return resp.Body, nil
+}
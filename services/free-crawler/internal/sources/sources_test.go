@@ -0,0 +1,82 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+type fakeClient struct {
+	body       string
+	statusCode int
+}
+
+func (f *fakeClient) Do(req *http.Request) (*http.Response, error) {
+	status := f.statusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(bytes.NewBufferString(f.body)),
+	}, nil
+}
+
+func TestPlainTextListParsesIPPortLines(t *testing.T) {
+	src := &PlainTextList{
+		SourceName: "plaintext",
+		Protocol:   proxy.ProtocolHTTP,
+		Client:     &fakeClient{body: "1.2.3.4:8080\nnot-a-proxy\n5.6.7.8:3128\n"},
+	}
+
+	candidates, err := src.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(candidates) != 2 {
+		t.Fatalf("expected 2 candidates, got %d: %+v", len(candidates), candidates)
+	}
+	if candidates[0].Host != "1.2.3.4" || candidates[0].Port != 8080 {
+		t.Fatalf("unexpected first candidate: %+v", candidates[0])
+	}
+}
+
+func TestHTMLTableListParsesRows(t *testing.T) {
+	html := `<table><tr><td>9.9.9.9</td><td>80</td></tr></table>`
+	src := &HTMLTableList{SourceName: "html", Protocol: proxy.ProtocolHTTP, Client: &fakeClient{body: html}}
+
+	candidates, err := src.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].Host != "9.9.9.9" || candidates[0].Port != 80 {
+		t.Fatalf("unexpected candidates: %+v", candidates)
+	}
+}
+
+func TestJSONAPIListParsesEntries(t *testing.T) {
+	body := `[{"ip":"1.1.1.1","port":1080},{"ip":"2.2.2.2","port":1081}]`
+	src := &JSONAPIList{SourceName: "json", Protocol: proxy.ProtocolSOCKS5, Client: &fakeClient{body: body}}
+
+	candidates, err := src.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(candidates) != 2 || candidates[1].Host != "2.2.2.2" {
+		t.Fatalf("unexpected candidates: %+v", candidates)
+	}
+}
+
+func TestFetchRejectsNonOKStatus(t *testing.T) {
+	src := &PlainTextList{SourceName: "plaintext", Client: &fakeClient{statusCode: http.StatusForbidden}}
+
+	_, err := src.Fetch(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("expected error mentioning 403, got %v", err)
+	}
+}
@@ -0,0 +1,323 @@
+// Command gateway is the platform's forwarding proxy entrypoint: it
+// authenticates inbound client traffic and tunnels it through a proxy
+// selected from the hot pool.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"encoding/base64"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/XXXXD-cation/proxy-platform/migrations"
+	"github.com/XXXXD-cation/proxy-platform/pkg/bandwidth"
+	"github.com/XXXXD-cation/proxy-platform/pkg/blocklist"
+	"github.com/XXXXD-cation/proxy-platform/pkg/circuitbreaker"
+	"github.com/XXXXD-cation/proxy-platform/pkg/envelope"
+	"github.com/XXXXD-cation/proxy-platform/pkg/eventbus"
+	"github.com/XXXXD-cation/proxy-platform/pkg/featureflags"
+	"github.com/XXXXD-cation/proxy-platform/pkg/headerpolicy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/http2upstream"
+	"github.com/XXXXD-cation/proxy-platform/pkg/maintenance"
+	"github.com/XXXXD-cation/proxy-platform/pkg/openapi"
+	"github.com/XXXXD-cation/proxy-platform/pkg/ratelimit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	"github.com/XXXXD-cation/proxy-platform/pkg/routing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/rpcclient"
+	"github.com/XXXXD-cation/proxy-platform/pkg/secrets"
+	"github.com/XXXXD-cation/proxy-platform/pkg/server"
+	"github.com/XXXXD-cation/proxy-platform/pkg/targetpolicy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/tracing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/upstreamtls"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+	"github.com/XXXXD-cation/proxy-platform/services/gateway/internal/engine"
+	"github.com/XXXXD-cation/proxy-platform/services/gateway/internal/openapispec"
+)
+
+// version is stamped at build time via -ldflags "-X main.version=...".
+var version = "dev"
+
+// newUsageDAO enables per-tenant TargetHost encryption if a master key
+// is configured; otherwise usage logs are stored in the clear, as
+// before.
+func newUsageDAO(db *sql.DB, encoded string) *usage.DAO {
+	if encoded == "" {
+		return usage.NewDAO(db)
+	}
+
+	masterKey, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		log.Fatalf("gateway: invalid USAGE_LOG_MASTER_KEY: %v", err)
+	}
+
+	manager := envelope.NewManager(db, envelope.StaticMasterKey(masterKey))
+	tenants := envelope.NewTenantEncryptionDAO(db)
+	return usage.NewEncryptingDAO(db, manager, tenants)
+}
+
+// usageFlushIntervalFromEnv and usageFlushSizeFromEnv let operators tune
+// how aggressively the usage BatchWriter flushes without a rebuild; an
+// unset or invalid value falls back to the package defaults.
+func usageFlushIntervalFromEnv() time.Duration {
+	ms, err := strconv.Atoi(os.Getenv("USAGE_FLUSH_INTERVAL_MS"))
+	if err != nil || ms <= 0 {
+		return usage.DefaultFlushInterval
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+func usageFlushSizeFromEnv() int {
+	size, err := strconv.Atoi(os.Getenv("USAGE_FLUSH_SIZE"))
+	if err != nil || size <= 0 {
+		return usage.DefaultFlushSize
+	}
+	return size
+}
+
+// blocklistRefreshIntervalFromEnv lets operators tune how often the
+// malware/phishing blocklist is refreshed without a rebuild; an unset
+// or invalid value falls back to blocklist.DefaultRefreshInterval.
+func blocklistRefreshIntervalFromEnv() time.Duration {
+	ms, err := strconv.Atoi(os.Getenv("BLOCKLIST_REFRESH_INTERVAL_MS"))
+	if err != nil || ms <= 0 {
+		return blocklist.DefaultRefreshInterval
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// rateLimitDefaultFromEnv builds the Config a PolicyResolver falls back
+// to for plans with no policy configured in plan_rate_limits, from
+// RATE_LIMIT_PER_MINUTE and RATE_LIMIT_ALGORITHM. RATE_LIMIT_PER_MINUTE
+// unset or non-positive leaves those plans unlimited.
+func rateLimitDefaultFromEnv() ratelimit.Config {
+	limit, err := strconv.Atoi(os.Getenv("RATE_LIMIT_PER_MINUTE"))
+	if err != nil || limit <= 0 {
+		limit = 0
+	}
+
+	algorithm := ratelimit.Algorithm(os.Getenv("RATE_LIMIT_ALGORITHM"))
+	if algorithm == "" {
+		algorithm = ratelimit.AlgorithmSlidingWindow
+	}
+
+	return ratelimit.Config{Algorithm: algorithm, Limit: limit, Window: time.Minute}
+}
+
+// rateLimitCacheFromEnv builds the CacheConfig a PolicyResolver uses to
+// approximate most Allow calls in-process instead of round-tripping to
+// Redis, from RATE_LIMIT_CACHE_SYNC_EVERY and
+// RATE_LIMIT_CACHE_SYNC_INTERVAL_MS. Leaving both unset or non-positive
+// returns the zero value, disabling the cache entirely (every call
+// syncs).
+func rateLimitCacheFromEnv() ratelimit.CacheConfig {
+	syncEvery, err := strconv.Atoi(os.Getenv("RATE_LIMIT_CACHE_SYNC_EVERY"))
+	if err != nil || syncEvery <= 0 {
+		syncEvery = 0
+	}
+
+	syncIntervalMS, err := strconv.Atoi(os.Getenv("RATE_LIMIT_CACHE_SYNC_INTERVAL_MS"))
+	syncInterval := time.Duration(0)
+	if err == nil && syncIntervalMS > 0 {
+		syncInterval = time.Duration(syncIntervalMS) * time.Millisecond
+	}
+
+	return ratelimit.CacheConfig{SyncEvery: syncEvery, SyncInterval: syncInterval}
+}
+
+func proxyPoolGRPCAddr() string {
+	if addr := os.Getenv("PROXY_POOL_GRPC_ADDR"); addr != "" {
+		return addr
+	}
+	return "localhost:9083"
+}
+
+func apiGRPCAddr() string {
+	if addr := os.Getenv("API_GRPC_ADDR"); addr != "" {
+		return addr
+	}
+	return "localhost:9084"
+}
+
+// gatewayTLSConfig builds the *tls.Config the main HTTP listener
+// terminates TLS with, from either ACME autocert
+// (GATEWAY_TLS_AUTOCERT_DOMAINS, a comma-separated allowlist cached
+// under GATEWAY_TLS_AUTOCERT_CACHE_DIR) or a static certificate/key
+// pair (GATEWAY_TLS_CERT_FILE / GATEWAY_TLS_KEY_FILE). Autocert takes
+// priority if both are set. It returns nil if neither is configured,
+// leaving the listener on plain HTTP as before. When autocert is used,
+// it also starts the HTTP-01 challenge listener on
+// GATEWAY_TLS_AUTOCERT_HTTP_ADDR (default :80), registered with run for
+// graceful shutdown alongside the other listeners.
+func gatewayTLSConfig(run *server.Runner) *tls.Config {
+	if domains := os.Getenv("GATEWAY_TLS_AUTOCERT_DOMAINS"); domains != "" {
+		cacheDir := os.Getenv("GATEWAY_TLS_AUTOCERT_CACHE_DIR")
+		if cacheDir == "" {
+			cacheDir = "/var/cache/gateway-autocert"
+		}
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(strings.Split(domains, ",")...),
+			Cache:      autocert.DirCache(cacheDir),
+		}
+
+		httpAddr := os.Getenv("GATEWAY_TLS_AUTOCERT_HTTP_ADDR")
+		if httpAddr == "" {
+			httpAddr = ":80"
+		}
+		challengeServer := &http.Server{Addr: httpAddr, Handler: manager.HTTPHandler(nil)}
+		run.OnShutdown("autocert http-01 listener", server.HTTPCloser(challengeServer))
+		go func() {
+			log.Printf("gateway: autocert http-01 challenge listener on %s", httpAddr)
+			if err := challengeServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("gateway: autocert http-01 listener failed: %v", err)
+			}
+		}()
+
+		return manager.TLSConfig()
+	}
+
+	certFile := os.Getenv("GATEWAY_TLS_CERT_FILE")
+	keyFile := os.Getenv("GATEWAY_TLS_KEY_FILE")
+	if certFile == "" || keyFile == "" {
+		return nil
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		log.Fatalf("gateway: failed to load TLS cert/key: %v", err)
+	}
+	return &tls.Config{Certificates: []tls.Certificate{cert}}
+}
+
+func main() {
+	log.Printf("gateway: starting version %s", version)
+
+	run := server.New("gateway")
+
+	shutdownTracing, err := tracing.Init(run.Context(), "gateway")
+	if err != nil {
+		log.Fatalf("gateway: failed to init tracing: %v", err)
+	}
+	run.OnShutdown("tracing", shutdownTracing)
+
+	secretsResolver := secrets.NewDefaultResolver()
+
+	dsn := secretsResolver.MustGet(run.Context(), "MYSQL_DSN", "")
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		log.Fatalf("gateway: failed to open mysql connection: %v", err)
+	}
+	run.OnShutdown("mysql", func(context.Context) error { return db.Close() })
+	run.RegisterDependency("mysql", db.PingContext)
+
+	if err := migrate.Run(run.Context(), db, migrate.FS); err != nil {
+		log.Fatalf("gateway: failed to apply migrations: %v", err)
+	}
+
+	poolConn, err := rpcclient.Dial(proxyPoolGRPCAddr())
+	if err != nil {
+		log.Fatalf("gateway: failed to dial proxy-pool grpc: %v", err)
+	}
+	run.OnShutdown("proxy-pool grpc conn", func(context.Context) error { return poolConn.Close() })
+	pool := rpcclient.NewPoolClient(poolConn)
+
+	userConn, err := rpcclient.Dial(apiGRPCAddr())
+	if err != nil {
+		log.Fatalf("gateway: failed to dial api grpc: %v", err)
+	}
+	run.OnShutdown("api grpc conn", func(context.Context) error { return userConn.Close() })
+	users := rpcclient.NewUserClient(userConn)
+
+	redisClient := redis.NewClient(redis.Config{
+		Addr:          secretsResolver.MustGet(run.Context(), "REDIS_ADDR", ""),
+		SentinelAddrs: redis.SplitAddrs(secretsResolver.MustGet(run.Context(), "REDIS_SENTINEL_ADDRS", "")),
+		MasterName:    secretsResolver.MustGet(run.Context(), "REDIS_MASTER_NAME", ""),
+		ClusterAddrs:  redis.SplitAddrs(secretsResolver.MustGet(run.Context(), "REDIS_CLUSTER_ADDRS", "")),
+		Password:      secretsResolver.MustGet(run.Context(), "REDIS_PASSWORD", ""),
+	})
+	run.OnShutdown("redis", func(context.Context) error { return redisClient.Close() })
+	run.RegisterDependency("redis", func(ctx context.Context) error { return redisClient.Ping(ctx).Err() })
+	eventBus := eventbus.NewRedisBus(redisClient)
+	usageDAO := newUsageDAO(db, secretsResolver.MustGet(run.Context(), "USAGE_LOG_MASTER_KEY", ""))
+	usageWriter := usage.NewBatchWriter(usageDAO, usageFlushIntervalFromEnv(), usageFlushSizeFromEnv(), eventBus)
+	run.Go(usageWriter.Run)
+	run.OnShutdown("usage writer", func(context.Context) error { usageWriter.Close(); return nil })
+	routingRules := routing.NewDAO(db)
+	headerPolicies := headerpolicy.NewDAO(db)
+	upstreamTLSPolicies := upstreamtls.NewDAO(db)
+	http2Policies := http2upstream.NewDAO(db)
+	targetPolicies := targetpolicy.NewDAO(db)
+	blocklistDAO := blocklist.NewDAO(db)
+	blocklistRefresher := blocklist.NewRefresher(blocklistDAO, os.Getenv("BLOCKLIST_LOCAL_FILE"), os.Getenv("BLOCKLIST_FEED_URL"))
+	run.Go(func(ctx context.Context) { blocklistRefresher.Run(ctx, blocklistRefreshIntervalFromEnv()) })
+	sessionPins := redis.NewSessionPins(redisClient)
+
+	limiter := ratelimit.NewPolicyResolver(redisClient, ratelimit.NewPolicyDAO(db), rateLimitDefaultFromEnv())
+	limiter.Cache = rateLimitCacheFromEnv()
+	concurrency := ratelimit.NewConcurrencyLimiter(redisClient)
+	bwRegistry := bandwidth.NewRegistry()
+	breakers := circuitbreaker.New(circuitbreaker.DefaultConfig())
+
+	flags := featureflags.NewResolver(featureflags.NewDAO(db), redisClient)
+	run.Go(flags.Run)
+	maintenanceCtl := maintenance.NewController(redisClient)
+
+	eng := engine.New(pool, users, usageWriter, routingRules, sessionPins, redisClient, limiter, concurrency, bwRegistry, breakers, headerPolicies, upstreamTLSPolicies, http2Policies, targetPolicies, blocklistDAO, flags, maintenanceCtl, os.Getenv("GATEWAY_REGION"))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", run.ReadyHandler())
+	mux.HandleFunc("/openapi.json", openapi.Handler(openapispec.Build()))
+	mux.HandleFunc("/docs", openapi.UIHandler("/openapi.json"))
+	mux.Handle("/", eng)
+
+	socksAddr := os.Getenv("GATEWAY_SOCKS5_ADDR")
+	if socksAddr == "" {
+		socksAddr = ":1080"
+	}
+	socksLn, err := net.Listen("tcp", socksAddr)
+	if err != nil {
+		log.Fatalf("gateway: failed to listen for socks5 on %s: %v", socksAddr, err)
+	}
+	run.OnShutdown("socks5 listener", func(context.Context) error { return socksLn.Close() })
+	go func() {
+		log.Printf("gateway: socks5 listening on %s", socksAddr)
+		if err := eng.ServeSOCKS5(socksLn); err != nil && run.Ready() {
+			log.Fatalf("gateway: socks5 listener failed: %v", err)
+		}
+	}()
+
+	addr := os.Getenv("GATEWAY_ADDR")
+	if addr == "" {
+		addr = ":8080"
+	}
+	httpServer := &http.Server{Addr: addr, Handler: tracing.Middleware("gateway.http", mux), TLSConfig: gatewayTLSConfig(run)}
+	run.OnShutdown("http server", server.HTTPCloser(httpServer))
+
+	go func() {
+		log.Printf("gateway: listening on %s", addr)
+		var err error
+		if httpServer.TLSConfig != nil {
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			log.Fatalf("gateway: server failed: %v", err)
+		}
+	}()
+
+	run.Wait()
+}
@@ -0,0 +1,148 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	"github.com/XXXXD-cation/proxy-platform/pkg/bandwidth"
+	proxymodel "github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	"github.com/XXXXD-cation/proxy-platform/pkg/tracing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+)
+
+// handleConnect tunnels an HTTPS (CONNECT) request: it dials the chosen
+// upstream proxy, asks it to CONNECT to the client's real target, then
+// splices bytes between the client and the upstream connection.
+func (e *Engine) handleConnect(w http.ResponseWriter, r *http.Request, key *apikey.Key, upstream *proxymodel.Proxy, start time.Time) {
+	ctx, span := tracing.Tracer("gateway").Start(r.Context(), "upstream.connect")
+	defer span.End()
+	r = r.WithContext(ctx)
+	domain := hostOnly(r.Host)
+	traceID := traceIDString(span)
+	timing := newTimingCapture(start)
+
+	dialer := net.Dialer{Timeout: 10 * time.Second}
+	upstreamConn, err := dialer.DialContext(timing.withClientTrace(ctx), "tcp", upstream.Addr())
+	if err != nil {
+		e.reportOutcome(upstream.ID, domain, false, time.Since(start))
+		http.Error(w, "failed to reach upstream proxy", http.StatusBadGateway)
+		return
+	}
+	defer upstreamConn.Close()
+
+	if upstream.Protocol == proxymodel.ProtocolHTTPS {
+		tlsConn := tls.Client(upstreamConn, e.upstreamTLSConfig(ctx, upstream))
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			e.reportOutcome(upstream.ID, domain, false, time.Since(start))
+			http.Error(w, "failed to negotiate TLS with upstream proxy", http.StatusBadGateway)
+			return
+		}
+		upstreamConn = tlsConn
+	}
+
+	proxyToTargetStart := time.Now()
+	connectReq := fmt.Sprintf("CONNECT %s HTTP/1.1\r\nHost: %s\r\n\r\n", r.Host, r.Host)
+	if _, err := upstreamConn.Write([]byte(connectReq)); err != nil {
+		e.reportOutcome(upstream.ID, domain, false, time.Since(start))
+		http.Error(w, "failed to tunnel through upstream proxy", http.StatusBadGateway)
+		return
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(upstreamConn), r)
+	if err != nil {
+		e.reportOutcome(upstream.ID, domain, false, time.Since(start))
+		http.Error(w, "failed to tunnel through upstream proxy", http.StatusBadGateway)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		e.reportOutcome(upstream.ID, domain, false, time.Since(start))
+		http.Error(w, "upstream proxy refused CONNECT", http.StatusBadGateway)
+		return
+	}
+	timing.markProxyToTarget(time.Since(proxyToTargetStart))
+	e.reportOutcome(upstream.ID, domain, true, time.Since(start))
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "tunneling unsupported", http.StatusInternalServerError)
+		return
+	}
+	clientConn, _, err := hijacker.Hijack()
+	if err != nil {
+		http.Error(w, "failed to hijack connection", http.StatusInternalServerError)
+		return
+	}
+	defer clientConn.Close()
+
+	if _, err := clientConn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n")); err != nil {
+		return
+	}
+
+	bytesIn, bytesOut := e.splice(ctx, clientConn, upstreamConn, key)
+	if err := redis.IncrLiveBytes(ctx, e.redisClient, bytesIn+bytesOut); err != nil {
+		log.Printf("engine: failed to record live bytes counter: %v", err)
+	}
+
+	total := time.Since(start)
+	snapshot := timing.snapshot(total)
+	e.recordUsage(usage.Log{
+		UserID:     key.UserID,
+		APIKeyID:   key.ID,
+		ProxyAddr:  upstream.Addr(),
+		ProxyID:    upstream.ID,
+		TargetHost: r.Host,
+		Protocol:   "https",
+		BytesIn:    bytesIn,
+		BytesOut:   bytesOut,
+		TraceID:    traceID,
+		Timing:     &snapshot,
+		DurationMS: total.Milliseconds(),
+	})
+}
+
+// splice copies bytes in both directions between client and upstream
+// until either side closes, returning bytes read from and written to
+// the client respectively. If e.bandwidth is non-nil, each direction is
+// shaped to key's plan cap via a shared per-user Limiter, so a user's
+// simultaneous tunnels draw from one aggregate allowance rather than
+// each getting their own.
+func (e *Engine) splice(ctx context.Context, client, upstream net.Conn, key *apikey.Key) (bytesFromClient, bytesFromUpstream int64) {
+	done := make(chan int64, 2)
+
+	var fromClient io.Reader = client
+	var fromUpstream io.Reader = upstream
+	if e.bandwidth != nil {
+		fromClient = bandwidth.NewThrottledReader(ctx, client, e.bandwidth.ForUser(key.UserID, "up", key.Plan))
+		fromUpstream = bandwidth.NewThrottledReader(ctx, upstream, e.bandwidth.ForUser(key.UserID, "down", key.Plan))
+	}
+
+	go func() {
+		n, err := io.Copy(upstream, fromClient)
+		if err != nil {
+			log.Printf("engine: client->upstream copy ended: %v", err)
+		}
+		if cw, ok := upstream.(interface{ CloseWrite() error }); ok {
+			cw.CloseWrite()
+		}
+		done <- n
+	}()
+
+	n, err := io.Copy(client, fromUpstream)
+	if err != nil {
+		log.Printf("engine: upstream->client copy ended: %v", err)
+	}
+	bytesFromUpstream = n
+
+	bytesFromClient = <-done
+	return bytesFromClient, bytesFromUpstream
+}
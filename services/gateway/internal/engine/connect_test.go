@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+)
+
+func TestSpliceCopiesBothDirectionsAndCounts(t *testing.T) {
+	e := &Engine{}
+
+	client, clientRemote := net.Pipe()
+	upstream, upstreamRemote := net.Pipe()
+
+	clientPayload := []byte("request from client")
+	upstreamPayload := []byte("response from upstream")
+
+	// Drain each direction's forwarded bytes on the matching remote end,
+	// since net.Pipe is unbuffered: a Write only returns once a Read on
+	// the peer has consumed it.
+	go io.CopyN(io.Discard, upstreamRemote, int64(len(clientPayload)))
+	go io.CopyN(io.Discard, clientRemote, int64(len(upstreamPayload)))
+
+	go func() {
+		clientRemote.Write(clientPayload)
+		clientRemote.Close()
+	}()
+	go func() {
+		upstreamRemote.Write(upstreamPayload)
+		upstreamRemote.Close()
+	}()
+
+	result := make(chan [2]int64, 1)
+	go func() {
+		bytesFromClient, bytesFromUpstream := e.splice(nil, client, upstream, &apikey.Key{UserID: "u1"})
+		result <- [2]int64{bytesFromClient, bytesFromUpstream}
+	}()
+
+	select {
+	case r := <-result:
+		if int(r[0]) != len(clientPayload) {
+			t.Errorf("bytesFromClient = %d, want %d", r[0], len(clientPayload))
+		}
+		if int(r[1]) != len(upstreamPayload) {
+			t.Errorf("bytesFromUpstream = %d, want %d", r[1], len(upstreamPayload))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("splice did not return once both sides closed")
+	}
+}
+
+func TestSpliceReturnsOnceBothSidesClosed(t *testing.T) {
+	e := &Engine{}
+
+	client, clientRemote := net.Pipe()
+	upstream, upstreamRemote := net.Pipe()
+	clientRemote.Close()
+	upstreamRemote.Close()
+
+	done := make(chan struct{})
+	go func() {
+		e.splice(nil, client, upstream, &apikey.Key{UserID: "u1"})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("splice should return once both ends are already closed")
+	}
+}
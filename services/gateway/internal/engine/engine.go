@@ -0,0 +1,600 @@
+// Package engine implements the gateway's forwarding proxy logic: it
+// authenticates inbound clients, picks an upstream proxy from the hot
+// pool, and tunnels or forwards their traffic through it.
+package engine
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	"github.com/XXXXD-cation/proxy-platform/pkg/bandwidth"
+	"github.com/XXXXD-cation/proxy-platform/pkg/blocklist"
+	"github.com/XXXXD-cation/proxy-platform/pkg/circuitbreaker"
+	"github.com/XXXXD-cation/proxy-platform/pkg/featureflags"
+	"github.com/XXXXD-cation/proxy-platform/pkg/headerpolicy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/http2upstream"
+	"github.com/XXXXD-cation/proxy-platform/pkg/maintenance"
+	proxymodel "github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/ratelimit"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	"github.com/XXXXD-cation/proxy-platform/pkg/routing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/rpcclient"
+	"github.com/XXXXD-cation/proxy-platform/pkg/targetpolicy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/upstreamtls"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// Engine is the gateway's core forwarding proxy: it implements
+// http.Handler and is meant to be bound to a dedicated listener port,
+// separate from any admin/health endpoints.
+type Engine struct {
+	pool           *rpcclient.PoolClient
+	users          *rpcclient.UserClient
+	usage          *usage.BatchWriter
+	rules          *routing.DAO
+	sessions       *redis.SessionPins
+	redisClient    goredis.UniversalClient
+	limiter        ratelimit.PlanLimiter
+	concurrency    *ratelimit.ConcurrencyLimiter
+	bandwidth      *bandwidth.Registry
+	breakers       *circuitbreaker.Registry
+	headerPolicies *headerpolicy.DAO
+	upstreamTLS    *upstreamtls.DAO
+	http2Policies  *http2upstream.DAO
+	targetPolicies *targetpolicy.DAO
+	blocklist      *blocklist.DAO
+	flags          *featureflags.Resolver
+	maintenance    *maintenance.Controller
+	region         string
+	transports     *transportCache
+}
+
+// HeaderPolicyRewriteFlag gates applyHeaderPolicy: a user's configured
+// header policy is only applied once this flag is enabled for them, so
+// the rewrite behavior can be rolled out gradually instead of taking
+// effect for every user with a policy the moment one is configured. A
+// nil flags resolver disables the gate, applying every configured
+// policy unconditionally, so existing deployments without a resolver
+// wired up keep today's behavior.
+const HeaderPolicyRewriteFlag = "header_policy_rewrite"
+
+// New creates a forwarding Engine. Proxies are selected and API keys are
+// authenticated over gRPC, via pool and users respectively, rather than
+// the gateway querying MySQL or the Redis hot pool itself; proxy-pool
+// and api remain the only services that do. Usage events are buffered
+// and batch-inserted by usageWriter rather than written synchronously,
+// so the caller is expected to have started usageWriter.Run separately.
+// redisClient is used only for the live request/failure counters the
+// admin dashboard streams from; it's the same client sessions is built
+// on. limiter, if non-nil, is consulted per authenticated key before a
+// request is forwarded, applying whichever limit its plan resolves to;
+// a nil limiter disables rate limiting entirely. concurrency, if
+// non-nil, caps how many connections a key's user and the key itself
+// may hold open at once; a nil concurrency disables that check. bw, if
+// non-nil, shapes each tunnel's throughput to its key's plan cap; a nil
+// bw disables bandwidth shaping entirely. breakers tracks per-upstream
+// failure streaks so a proxy that starts failing mid-session is skipped
+// immediately rather than retried until the next health-check sweep
+// deactivates it; a nil breakers disables that check, so every selected
+// upstream is always allowed. headerPolicies, if non-nil, is consulted
+// per forwarded (non-CONNECT) request to strip/inject headers and
+// optionally randomize User-Agent for that request's user; a nil
+// headerPolicies leaves every request's headers as the client sent them.
+// upstreamTLS, if non-nil, is consulted whenever the selected upstream's
+// Protocol is proxy.ProtocolHTTPS to decide how its certificate is
+// validated (a custom CA bundle, or skipping verification), keyed by
+// the upstream's Provider; a nil upstreamTLS trusts the system root
+// pool for every HTTPS upstream. http2Policies, if non-nil, is
+// consulted the same way (keyed by the upstream's Provider) to decide
+// whether handleForward may negotiate HTTP/2 with that upstream at
+// all; a nil http2Policies, or no policy configured for a provider,
+// allows HTTP/2 (ALPN-negotiated for HTTPS upstreams, h2c for plain
+// ones), falling back to HTTP/1.1 for any upstream that doesn't speak
+// it. targetPolicies, if non-nil, is
+// consulted for every authenticated request to decide whether the
+// key is allowed to proxy to its target host at all, denying and
+// recording it (with a reason) before an upstream is ever picked; a
+// nil targetPolicies allows every target, matching pre-policy
+// behavior. blocklistDAO, if non-nil, is consulted right after
+// targetPolicies to reject requests aimed at a known malware/phishing
+// host (pkg/blocklist), regardless of the key's own policy, recording
+// the block's category the same way; a nil blocklistDAO performs no
+// such check. flags, if non-nil, gates newly-added
+// behaviors (starting with HeaderPolicyRewriteFlag) behind a rollout
+// instead of turning on for every caller at once; a nil flags disables
+// every such gate, matching pre-flag behavior. maintenanceCtl, if
+// non-nil, is checked at the top of every request; while maintenance
+// mode is on, new requests are rejected with a 503 rather than being
+// authenticated or routed, but requests already past that check
+// (including open CONNECT tunnels) are left to finish on their own,
+// since the engine keeps no registry of in-flight tunnels to
+// force-close. region identifies this gateway's own deployment region
+// to proxy-pool on every Acquire/Report call, so a multi-region
+// deployment's selection can rank candidates by the latency this
+// specific gateway observes rather than one vantage-point-agnostic
+// score; an empty region disables that ranking, matching pre-region
+// behavior.
+func New(pool *rpcclient.PoolClient, users *rpcclient.UserClient, usageWriter *usage.BatchWriter, rules *routing.DAO, sessions *redis.SessionPins, redisClient goredis.UniversalClient, limiter ratelimit.PlanLimiter, concurrency *ratelimit.ConcurrencyLimiter, bw *bandwidth.Registry, breakers *circuitbreaker.Registry, headerPolicies *headerpolicy.DAO, upstreamTLS *upstreamtls.DAO, http2Policies *http2upstream.DAO, targetPolicies *targetpolicy.DAO, blocklistDAO *blocklist.DAO, flags *featureflags.Resolver, maintenanceCtl *maintenance.Controller, region string) *Engine {
+	if breakers != nil {
+		breakers.OnOpen(func(upstreamID string) {
+			log.Printf("engine: circuit breaker opened for proxy %s", upstreamID)
+		})
+	}
+	return &Engine{pool: pool, users: users, usage: usageWriter, rules: rules, sessions: sessions, redisClient: redisClient, limiter: limiter, concurrency: concurrency, bandwidth: bw, breakers: breakers, headerPolicies: headerPolicies, upstreamTLS: upstreamTLS, http2Policies: http2Policies, targetPolicies: targetPolicies, blocklist: blocklistDAO, flags: flags, maintenance: maintenanceCtl, region: region, transports: newTransportCache()}
+}
+
+// applyHeaderPolicy rewrites outReq's headers per userID's configured
+// policy, if any, once userID is in HeaderPolicyRewriteFlag's rollout.
+// It's best-effort: a lookup failure (including no policy configured)
+// just leaves outReq's headers as the client sent them, consistent with
+// this engine's other fail-open checks.
+func (e *Engine) applyHeaderPolicy(ctx context.Context, outReq *http.Request, userID string) {
+	if e.headerPolicies == nil {
+		return
+	}
+	if e.flags != nil && !e.flags.Enabled(ctx, HeaderPolicyRewriteFlag, userID) {
+		return
+	}
+	policy, err := e.headerPolicies.Get(ctx, userID)
+	if err != nil {
+		if err != headerpolicy.ErrNotFound {
+			log.Printf("engine: failed to load header policy for user %s: %v", userID, err)
+		}
+		return
+	}
+	headerpolicy.Apply(outReq, policy)
+}
+
+// upstreamTLSConfig returns the *tls.Config to present when dialing
+// upstream, or nil if upstream speaks plain TCP. A lookup failure for
+// upstream's provider (other than no policy configured) fails open to
+// the system root pool, consistent with this engine's other fail-open
+// checks.
+func (e *Engine) upstreamTLSConfig(ctx context.Context, upstream *proxymodel.Proxy) *tls.Config {
+	if upstream.Protocol != proxymodel.ProtocolHTTPS {
+		return nil
+	}
+	if e.upstreamTLS == nil {
+		return upstreamtls.Config(upstream.Host, nil)
+	}
+	policy, err := e.upstreamTLS.Get(ctx, upstream.Provider)
+	if err != nil {
+		if !errors.Is(err, upstreamtls.ErrNotFound) {
+			log.Printf("engine: failed to load upstream TLS policy for provider %q: %v", upstream.Provider, err)
+		}
+		policy = nil
+	}
+	return upstreamtls.Config(upstream.Host, policy)
+}
+
+// useHTTP2 reports whether handleForward may negotiate HTTP/2 with
+// upstream. A lookup failure for upstream's provider (other than no
+// policy configured) fails open to allowing it, consistent with this
+// engine's other fail-open checks.
+func (e *Engine) useHTTP2(ctx context.Context, upstream *proxymodel.Proxy) bool {
+	if e.http2Policies == nil {
+		return true
+	}
+	policy, err := e.http2Policies.Get(ctx, upstream.Provider)
+	if err != nil {
+		if !errors.Is(err, http2upstream.ErrNotFound) {
+			log.Printf("engine: failed to load HTTP/2 policy for provider %q: %v", upstream.Provider, err)
+		}
+		return true
+	}
+	return !policy.Disabled
+}
+
+// checkTargetPolicy reports whether key's configured target-domain
+// policy (pkg/targetpolicy) permits proxying to host, and if not, why.
+// It fails open (allows the request) on a lookup error, consistent with
+// this engine's other fail-open checks, since a MySQL hiccup shouldn't
+// block proxying platform-wide.
+func (e *Engine) checkTargetPolicy(ctx context.Context, key *apikey.Key, host string) (reason string, allowed bool) {
+	if e.targetPolicies == nil {
+		return "", true
+	}
+	decision, err := e.targetPolicies.Evaluate(ctx, key.ID, hostOnly(host))
+	if err != nil {
+		log.Printf("engine: target policy check failed for key %s: %v", key.ID, err)
+		return "", true
+	}
+	return decision.Reason, decision.Allowed
+}
+
+// denyTarget records a usage log entry for a request checkTargetPolicy
+// rejected, with DenialReason set so it's distinguishable from a
+// successfully proxied request, and responds 403 to the client.
+func (e *Engine) denyTarget(w http.ResponseWriter, r *http.Request, key *apikey.Key, reason string) {
+	protocol := "http"
+	if r.Method == http.MethodConnect {
+		protocol = "https"
+	}
+	e.recordUsage(usage.Log{
+		UserID:       key.UserID,
+		APIKeyID:     key.ID,
+		TargetHost:   r.Host,
+		Protocol:     protocol,
+		StatusCode:   http.StatusForbidden,
+		DenialReason: reason,
+	})
+	http.Error(w, "target domain denied: "+reason, http.StatusForbidden)
+}
+
+// checkBlocklist reports whether host is a known malware/phishing host
+// (pkg/blocklist), and if so, which category it's listed under. Unlike
+// checkTargetPolicy, this isn't something a key's own policy can permit
+// around: an enterprise user can only exempt themselves from a specific
+// listed pattern via a configured blocklist.Override, not from the
+// blocklist entirely. It fails open on a lookup error, consistent with
+// this engine's other fail-open checks.
+func (e *Engine) checkBlocklist(ctx context.Context, key *apikey.Key, host string) (category string, blocked bool) {
+	if e.blocklist == nil {
+		return "", false
+	}
+	decision, err := e.blocklist.Evaluate(ctx, key.UserID, hostOnly(host))
+	if err != nil {
+		log.Printf("engine: blocklist check failed for user %s: %v", key.UserID, err)
+		return "", false
+	}
+	return decision.Category, decision.Blocked
+}
+
+// denyBlocked records a usage log entry for a request checkBlocklist
+// rejected, with DenialReason set to the matched category, and responds
+// 403 to the client.
+func (e *Engine) denyBlocked(w http.ResponseWriter, r *http.Request, key *apikey.Key, category string) {
+	protocol := "http"
+	if r.Method == http.MethodConnect {
+		protocol = "https"
+	}
+	e.recordUsage(usage.Log{
+		UserID:       key.UserID,
+		APIKeyID:     key.ID,
+		TargetHost:   r.Host,
+		Protocol:     protocol,
+		StatusCode:   http.StatusForbidden,
+		DenialReason: "blocklisted: " + category,
+	})
+	http.Error(w, "target domain blocked: "+category, http.StatusForbidden)
+}
+
+// checkRateLimit reports whether key is within the rate limit its plan
+// resolves to. It fails open (allows the request) on a PlanLimiter
+// error, since a Redis or MySQL hiccup shouldn't take down proxying
+// entirely.
+func (e *Engine) checkRateLimit(ctx context.Context, key *apikey.Key) bool {
+	if e.limiter == nil {
+		return true
+	}
+	allowed, err := e.limiter.Allow(ctx, key.Plan, key.ID)
+	if err != nil {
+		log.Printf("engine: rate limit check failed for key %s: %v", key.ID, err)
+		return true
+	}
+	return allowed
+}
+
+// acquireConn reserves a concurrent-connection slot for both key's
+// user and key itself, under its plan's MaxConcurrentFor limit, so a
+// user can't bypass the limit by spreading connections across several
+// API keys. ok is false only if one of the two is already at its
+// limit; a reservation that fails open due to a Redis error reports ok
+// == true, consistent with checkRateLimit. Every call that returns ok
+// == true must have release invoked exactly once, typically via defer,
+// when the connection closes.
+func (e *Engine) acquireConn(ctx context.Context, key *apikey.Key) (release func(), ok bool) {
+	noop := func() {}
+	if e.concurrency == nil {
+		return noop, true
+	}
+
+	limit := ratelimit.MaxConcurrentFor(key.Plan)
+
+	userOK, err := e.concurrency.Acquire(ctx, key.UserID, limit)
+	if err != nil {
+		log.Printf("engine: concurrency check failed for user %s: %v", key.UserID, err)
+		return noop, true
+	}
+	if !userOK {
+		return noop, false
+	}
+
+	keyOK, err := e.concurrency.Acquire(ctx, key.ID, limit)
+	if err != nil {
+		log.Printf("engine: concurrency check failed for key %s: %v", key.ID, err)
+		e.releaseConn(key)
+		return noop, true
+	}
+	if !keyOK {
+		e.releaseUserConn(key)
+		return noop, false
+	}
+
+	return func() { e.releaseConn(key) }, true
+}
+
+// releaseUserConn frees only the user-scoped slot acquireConn reserved,
+// for the case where the key-scoped Acquire that would normally pair
+// with it never succeeded.
+func (e *Engine) releaseUserConn(key *apikey.Key) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcclient.DefaultCallTimeout)
+	defer cancel()
+	if err := e.concurrency.Release(ctx, key.UserID); err != nil {
+		log.Printf("engine: failed to release concurrency slot for user %s: %v", key.UserID, err)
+	}
+}
+
+// releaseConn frees the user- and key-scoped slots acquireConn
+// reserved for key.
+func (e *Engine) releaseConn(key *apikey.Key) {
+	e.releaseUserConn(key)
+	ctx, cancel := context.WithTimeout(context.Background(), rpcclient.DefaultCallTimeout)
+	defer cancel()
+	if err := e.concurrency.Release(ctx, key.ID); err != nil {
+		log.Printf("engine: failed to release concurrency slot for key %s: %v", key.ID, err)
+	}
+}
+
+// ServeHTTP dispatches CONNECT (tunneling, used for HTTPS) and
+// absolute-URI (plain HTTP proxying) requests.
+func (e *Engine) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if e.maintenance != nil {
+		state, err := e.maintenance.Status(r.Context())
+		if err != nil {
+			log.Printf("engine: failed to check maintenance mode: %v", err)
+		} else if state.Enabled {
+			msg := "platform is in maintenance mode, try again shortly"
+			if state.Reason != "" {
+				msg += ": " + state.Reason
+			}
+			http.Error(w, msg, http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	if err := redis.IncrLiveRequest(r.Context(), e.redisClient); err != nil {
+		log.Printf("engine: failed to record live request counter: %v", err)
+	}
+
+	key, err := e.authenticate(r)
+	if err != nil {
+		http.Error(w, "proxy authentication required", http.StatusProxyAuthRequired)
+		return
+	}
+	if !e.checkRateLimit(r.Context(), key) {
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+	if reason, allowed := e.checkTargetPolicy(r.Context(), key, r.Host); !allowed {
+		e.denyTarget(w, r, key, reason)
+		return
+	}
+	if category, blocked := e.checkBlocklist(r.Context(), key, r.Host); blocked {
+		e.denyBlocked(w, r, key, category)
+		return
+	}
+	release, ok := e.acquireConn(r.Context(), key)
+	if !ok {
+		if err := redis.IncrLiveConcurrencyRejection(r.Context(), e.redisClient); err != nil {
+			log.Printf("engine: failed to record live concurrency rejection counter: %v", err)
+		}
+		http.Error(w, "too many concurrent connections", http.StatusTooManyRequests)
+		return
+	}
+	defer release()
+
+	upstream, err := e.resolveUpstream(r.Context(), r.Host, sessionIDFromRequest(r), key, geoFromUsername(proxyUsername(r)))
+	if err != nil {
+		http.Error(w, "no upstream proxies available", http.StatusServiceUnavailable)
+		return
+	}
+
+	start := time.Now()
+	if r.Method == http.MethodConnect {
+		e.handleConnect(w, r, key, upstream, start)
+		return
+	}
+	e.handleForward(w, r, key, upstream, start)
+}
+
+// pickUpstream chooses an upstream proxy for target via proxy-pool's
+// ProxyPoolService. A non-empty geo filter (requested by the client via
+// its proxy username) takes priority over routing rules; otherwise
+// selection honors the highest-priority routing rule matching target, if
+// any. pinnedID, if non-empty, is tried first by proxy-pool so sticky
+// sessions keep their upstream without the gateway needing its own
+// access to the hot pool. excludeBlacklisted, true only for premium-plan
+// keys, excludes proxies flagged by the reputation checker. qos raises
+// the minimum score floor for QoSPriority traffic via qosMinScore, on
+// top of whatever a matching routing rule already requires, so
+// enterprise traffic gets priority access to the top-scored proxies
+// under contention while other plans keep drawing from the general pool.
+func (e *Engine) pickUpstream(ctx context.Context, target string, geo geoFilter, pinnedID string, excludeBlacklisted bool, qos user.QoSClass) (*proxymodel.Proxy, error) {
+	domain := hostOnly(target)
+
+	if !geo.empty() {
+		return e.pool.Acquire(ctx, rpcclient.AcquireParams{
+			Country:            geo.Country,
+			City:               geo.City,
+			ASN:                geo.ASN,
+			MinScore:           qosMinScore(qos, 0),
+			PinnedID:           pinnedID,
+			TargetDomain:       domain,
+			ExcludeBlacklisted: excludeBlacklisted,
+			GatewayID:          e.region,
+		})
+	}
+
+	rule, err := e.rules.Match(ctx, domain)
+	if err != nil {
+		return e.pool.Acquire(ctx, rpcclient.AcquireParams{
+			MinScore:           qosMinScore(qos, 0),
+			PinnedID:           pinnedID,
+			TargetDomain:       domain,
+			ExcludeBlacklisted: excludeBlacklisted,
+			GatewayID:          e.region,
+		})
+	}
+	return e.pool.Acquire(ctx, rpcclient.AcquireParams{
+		Country:            rule.Country,
+		Protocol:           rule.Protocol,
+		MinScore:           qosMinScore(qos, rule.MinScore),
+		PinnedID:           pinnedID,
+		TargetDomain:       domain,
+		ExcludeBlacklisted: excludeBlacklisted,
+		GatewayID:          e.region,
+	})
+}
+
+// qosPriorityMinScore is the score floor applied to QoSPriority traffic
+// when nothing more specific (a routing rule's own MinScore) already
+// requires better.
+const qosPriorityMinScore = 0.8
+
+// qosMinScore returns the effective MinScore to request for qos, never
+// lower than base (a routing rule's own floor, or 0 if none applies).
+func qosMinScore(qos user.QoSClass, base float64) float64 {
+	if qos == user.QoSPriority && qosPriorityMinScore > base {
+		return qosPriorityMinScore
+	}
+	return base
+}
+
+// maxBreakerRetries bounds how many times pickAllowed re-picks an
+// upstream after the local circuit breaker rejects one, before giving
+// up and using the last (still breaker-open) candidate anyway.
+const maxBreakerRetries = 3
+
+// pickAllowed wraps pickUpstream with the local circuit breaker: a
+// candidate whose breaker is Open or HalfOpen-with-a-probe-in-flight is
+// discarded and another is picked in its place, up to maxBreakerRetries
+// times. pinnedID is honored only on the first attempt; a proxy-pool
+// hot-pool bucket usually has more than one qualifying member and picks
+// among them at random (see redis.HotZSet.PickAbove), so a retry has a
+// real chance of landing on a healthy upstream instead of the same one.
+// If every attempt is rejected, the last candidate is returned anyway
+// rather than failing the request outright, consistent with this
+// engine's fail-open handling of other best-effort checks. A nil
+// e.breakers disables this entirely, returning pickUpstream's first
+// result unchecked.
+func (e *Engine) pickAllowed(ctx context.Context, target string, geo geoFilter, pinnedID string, excludeBlacklisted bool, qos user.QoSClass) (*proxymodel.Proxy, error) {
+	pid := pinnedID
+	var upstream *proxymodel.Proxy
+	for attempt := 0; attempt < maxBreakerRetries; attempt++ {
+		p, err := e.pickUpstream(ctx, target, geo, pid, excludeBlacklisted, qos)
+		if err != nil {
+			return nil, err
+		}
+		upstream = p
+		if e.breakers == nil || e.breakers.Allow(upstream.ID) {
+			return upstream, nil
+		}
+		pid = ""
+	}
+	return upstream, nil
+}
+
+// authenticate extracts an API key from either the Proxy-Authorization
+// header (standard for HTTP proxies: Basic user:apikey) or an X-Api-Key
+// header, and validates it against api's UserService. A request with no
+// credential at all falls back to matching the client's source IP
+// against its owner's IP allowlist, for customers who've configured one
+// instead of distributing API keys.
+func (e *Engine) authenticate(r *http.Request) (*apikey.Key, error) {
+	raw := rawAPIKey(r)
+	if raw != "" {
+		return e.users.Authorize(r.Context(), raw)
+	}
+	return e.users.AuthorizeByIP(r.Context(), clientIP(r.RemoteAddr))
+}
+
+// clientIP strips the port from a net.Conn-style "host:port" remote
+// address, since the allowlist matches bare IPs. remoteAddr without a
+// parseable port (already a bare IP, or malformed) is returned as-is.
+func clientIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+func rawAPIKey(r *http.Request) string {
+	if header := r.Header.Get("X-Api-Key"); header != "" {
+		return header
+	}
+
+	auth := r.Header.Get("Proxy-Authorization")
+	const prefix = "Basic "
+	if !strings.HasPrefix(auth, prefix) {
+		return ""
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(auth, prefix))
+	if err != nil {
+		return ""
+	}
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+// recordUsage enqueues a usage log entry for batched persistence. It is
+// best-effort: a full buffer must never fail or block the proxied
+// request itself, so the event is dropped and logged instead.
+func (e *Engine) recordUsage(entry usage.Log) {
+	if err := e.usage.Record(entry); err != nil {
+		log.Printf("engine: failed to record usage log: %v", err)
+	}
+}
+
+// reportOutcome tells proxy-pool how upstream performed against
+// targetDomain on this request, feeding live traffic back into both the
+// proxy's general health and its per-domain track record between
+// health-check sweeps. It's best-effort and run in its own goroutine so
+// a slow or unreachable proxy-pool never adds latency to the response
+// already sent to the client.
+func (e *Engine) reportOutcome(upstreamID, targetDomain string, success bool, latency time.Duration) {
+	e.recordBreakerOutcome(upstreamID, success)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), rpcclient.DefaultCallTimeout)
+		defer cancel()
+		if err := e.pool.Report(ctx, upstreamID, success, int(latency.Milliseconds()), targetDomain, e.region); err != nil {
+			log.Printf("engine: failed to report outcome for proxy %s: %v", upstreamID, err)
+		}
+		if !success {
+			if err := redis.IncrLiveFailure(ctx, e.redisClient); err != nil {
+				log.Printf("engine: failed to record live failure counter: %v", err)
+			}
+		}
+	}()
+}
+
+// recordBreakerOutcome updates upstreamID's local circuit breaker, if
+// one is configured. It runs synchronously, unlike the gRPC report to
+// proxy-pool, so the very next request to resolveUpstream already sees
+// an up-to-date Allow decision instead of racing the backgrounded RPC.
+func (e *Engine) recordBreakerOutcome(upstreamID string, success bool) {
+	if e.breakers == nil {
+		return
+	}
+	if success {
+		e.breakers.RecordSuccess(upstreamID)
+		return
+	}
+	e.breakers.RecordFailure(upstreamID)
+}
@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+func TestRawAPIKeyPrefersExplicitHeader(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	r.Header.Set("X-Api-Key", "abc123")
+
+	if got := rawAPIKey(r); got != "abc123" {
+		t.Fatalf("expected abc123, got %q", got)
+	}
+}
+
+func TestRawAPIKeyFromProxyAuthorization(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	r.SetBasicAuth("user", "the-api-key")
+	r.Header.Set("Proxy-Authorization", r.Header.Get("Authorization"))
+	r.Header.Del("Authorization")
+
+	if got := rawAPIKey(r); got != "the-api-key" {
+		t.Fatalf("expected the-api-key, got %q", got)
+	}
+}
+
+func TestRawAPIKeyMissing(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	if got := rawAPIKey(r); got != "" {
+		t.Fatalf("expected empty string, got %q", got)
+	}
+}
+
+func TestClientIPStripsPort(t *testing.T) {
+	if got := clientIP("203.0.113.5:54321"); got != "203.0.113.5" {
+		t.Fatalf("expected 203.0.113.5, got %q", got)
+	}
+}
+
+func TestClientIPPassesThroughBareIP(t *testing.T) {
+	if got := clientIP("203.0.113.5"); got != "203.0.113.5" {
+		t.Fatalf("expected 203.0.113.5, got %q", got)
+	}
+}
+
+func TestQoSMinScoreStandardLeavesBaseUnchanged(t *testing.T) {
+	if got := qosMinScore(user.QoSStandard, 0.3); got != 0.3 {
+		t.Fatalf("expected base 0.3 unchanged, got %v", got)
+	}
+}
+
+func TestQoSMinScorePriorityRaisesFloor(t *testing.T) {
+	if got := qosMinScore(user.QoSPriority, 0); got != qosPriorityMinScore {
+		t.Fatalf("expected %v, got %v", qosPriorityMinScore, got)
+	}
+}
+
+func TestQoSMinScorePriorityKeepsHigherRuleFloor(t *testing.T) {
+	const ruleFloor = 0.95
+	if got := qosMinScore(user.QoSPriority, ruleFloor); got != ruleFloor {
+		t.Fatalf("expected rule's own floor %v to win, got %v", ruleFloor, got)
+	}
+}
@@ -0,0 +1,163 @@
+package engine
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	"github.com/XXXXD-cation/proxy-platform/pkg/bandwidth"
+	proxymodel "github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	"github.com/XXXXD-cation/proxy-platform/pkg/tracing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+)
+
+// handleForward proxies a plain absolute-URI HTTP request (GET/POST/...)
+// through the chosen upstream proxy and streams the response back.
+func (e *Engine) handleForward(w http.ResponseWriter, r *http.Request, key *apikey.Key, upstream *proxymodel.Proxy, start time.Time) {
+	ctx, span := tracing.Tracer("gateway").Start(r.Context(), "upstream.forward")
+	defer span.End()
+	domain := hostOnly(r.Host)
+	traceID := traceIDString(span)
+	timing := newTimingCapture(start)
+
+	scheme := "http"
+	var tlsConfig *tls.Config
+	if upstream.Protocol == proxymodel.ProtocolHTTPS {
+		scheme = "https"
+		tlsConfig = e.upstreamTLSConfig(ctx, upstream)
+	}
+	transport := e.upstreamTransport(ctx, upstream, scheme, tlsConfig)
+
+	outReq := r.Clone(timing.withClientTrace(ctx))
+	outReq.RequestURI = ""
+	outReq.Header.Del("Proxy-Authorization")
+	e.applyHeaderPolicy(ctx, outReq, key.UserID)
+	if outReq.Body != nil && e.bandwidth != nil {
+		outReq.Body = io.NopCloser(bandwidth.NewThrottledReader(ctx, outReq.Body, e.bandwidth.ForUser(key.UserID, "up", key.Plan)))
+	}
+
+	resp, err := transport.RoundTrip(outReq)
+	if err != nil {
+		e.reportOutcome(upstream.ID, domain, false, time.Since(start))
+		http.Error(w, "failed to reach target through upstream proxy", http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	e.reportOutcome(upstream.ID, domain, true, time.Since(start))
+
+	for k, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+
+	var respBody io.Reader = resp.Body
+	if e.bandwidth != nil {
+		respBody = bandwidth.NewThrottledReader(ctx, resp.Body, e.bandwidth.ForUser(key.UserID, "down", key.Plan))
+	}
+	bytesOut, _ := io.Copy(w, respBody)
+	if err := redis.IncrLiveBytes(ctx, e.redisClient, r.ContentLength+bytesOut); err != nil {
+		log.Printf("engine: failed to record live bytes counter: %v", err)
+	}
+
+	total := time.Since(start)
+	snapshot := timing.snapshot(total)
+	e.recordUsage(usage.Log{
+		UserID:     key.UserID,
+		APIKeyID:   key.ID,
+		ProxyAddr:  upstream.Addr(),
+		ProxyID:    upstream.ID,
+		TargetHost: r.Host,
+		Protocol:   "http",
+		BytesIn:    r.ContentLength,
+		BytesOut:   bytesOut,
+		StatusCode: resp.StatusCode,
+		DurationMS: total.Milliseconds(),
+		TraceID:    traceID,
+		Timing:     &snapshot,
+	})
+}
+
+// upstreamTransport returns the RoundTripper handleForward sends the
+// outbound request through, building it once per (upstream ID, scheme,
+// HTTP/2-enabled) and reusing it afterward so repeated forwards to the
+// same upstream reuse its underlying TCP/TLS connection(s) instead of
+// paying a fresh handshake on every request.
+func (e *Engine) upstreamTransport(ctx context.Context, upstream *proxymodel.Proxy, scheme string, tlsConfig *tls.Config) http.RoundTripper {
+	useHTTP2 := e.useHTTP2(ctx, upstream)
+	key := upstream.ID + "|" + scheme + "|" + strconv.FormatBool(useHTTP2)
+	return e.transports.get(key, func() http.RoundTripper {
+		return buildUpstreamTransport(upstream, scheme, tlsConfig, useHTTP2)
+	})
+}
+
+// buildUpstreamTransport constructs the RoundTripper for a single
+// (upstream, scheme, useHTTP2) combination. When useHTTP2 is set, an
+// HTTPS upstream gets h2 ALPN-negotiated over its TLS connection with
+// an automatic fallback to HTTP/1.1 if it doesn't offer it, and a
+// plain-TCP upstream gets h2c (HTTP/2 without TLS), which internal
+// proxies sometimes speak and which has nothing to negotiate since
+// there's no ALPN without TLS. Either way the upstream is reached via
+// req.URL rewritten to proxy.ProxyURL the same way a plain HTTP/1.1
+// forward would be; HTTP/2 only changes the wire protocol used to
+// reach it.
+func buildUpstreamTransport(upstream *proxymodel.Proxy, scheme string, tlsConfig *tls.Config, useHTTP2 bool) http.RoundTripper {
+	upstreamURL := &url.URL{Scheme: scheme, Host: upstream.Addr()}
+	transport := &http.Transport{Proxy: http.ProxyURL(upstreamURL), TLSClientConfig: tlsConfig}
+	if !useHTTP2 {
+		return transport
+	}
+	if scheme == "https" {
+		if err := http2.ConfigureTransport(transport); err != nil {
+			log.Printf("engine: failed to enable HTTP/2 for upstream %s: %v", upstream.ID, err)
+		}
+		return transport
+	}
+	return &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, _ string, _ *tls.Config) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, upstream.Addr())
+		},
+	}
+}
+
+// transportCache holds one http.RoundTripper per key, built once and
+// reused afterward, the same way bandwidth.Registry builds one Limiter
+// per (userID, direction): a later HTTP/2 or TLS policy change for an
+// upstream already cached here only takes effect on the gateway's next
+// restart, since upstream transport settings change rarely enough that
+// live invalidation isn't worth the bookkeeping.
+type transportCache struct {
+	mu    sync.Mutex
+	items map[string]http.RoundTripper
+}
+
+func newTransportCache() *transportCache {
+	return &transportCache{items: make(map[string]http.RoundTripper)}
+}
+
+// get returns the cached RoundTripper for key, calling build to create
+// and cache it on a miss.
+func (c *transportCache) get(key string, build func() http.RoundTripper) http.RoundTripper {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t, ok := c.items[key]; ok {
+		return t
+	}
+	t := build()
+	c.items[key] = t
+	return t
+}
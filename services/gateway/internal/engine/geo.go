@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"strconv"
+	"strings"
+)
+
+// geoFilter narrows proxy selection to a specific country, city, and/or
+// ASN, as requested by the client via its proxy username. Proxy-pool's
+// ProxyPoolService does the actual matching; the gateway only parses and
+// forwards the criteria.
+type geoFilter struct {
+	Country string
+	City    string
+	ASN     int
+}
+
+// empty reports whether the filter has no criteria set, i.e. the client
+// didn't request geo-targeting.
+func (g geoFilter) empty() bool {
+	return g.Country == "" && g.City == "" && g.ASN == 0
+}
+
+// geoFromUsername parses geo-targeting markers out of a proxy username
+// of the form "<anything>-country-<cc>-city-<name>-asn-<n>", in any
+// order and with any subset present, e.g. "user-country-de-city-berlin".
+func geoFromUsername(username string) geoFilter {
+	parts := strings.Split(username, "-")
+	var g geoFilter
+	for i := 0; i < len(parts)-1; i++ {
+		switch parts[i] {
+		case "country":
+			g.Country = parts[i+1]
+		case "city":
+			g.City = parts[i+1]
+		case "asn":
+			if asn, err := strconv.Atoi(parts[i+1]); err == nil {
+				g.ASN = asn
+			}
+		}
+	}
+	return g
+}
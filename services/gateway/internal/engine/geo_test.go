@@ -0,0 +1,24 @@
+package engine
+
+import (
+	"testing"
+)
+
+func TestGeoFromUsername(t *testing.T) {
+	cases := []struct {
+		username string
+		want     geoFilter
+	}{
+		{"user-country-de-city-berlin", geoFilter{Country: "de", City: "berlin"}},
+		{"user-country-us", geoFilter{Country: "us"}},
+		{"user-asn-12345", geoFilter{ASN: 12345}},
+		{"user-country-de-city-berlin-session-abc", geoFilter{Country: "de", City: "berlin"}},
+		{"user", geoFilter{}},
+	}
+
+	for _, c := range cases {
+		if got := geoFromUsername(c.username); got != c.want {
+			t.Errorf("geoFromUsername(%q) = %+v, want %+v", c.username, got, c.want)
+		}
+	}
+}
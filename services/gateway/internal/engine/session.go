@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	proxymodel "github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/user"
+)
+
+// sessionPinTTL is how long a sticky session keeps its pinned upstream
+// without being used. Each use refreshes it, so an active scraping
+// session never loses its exit IP mid-run.
+const sessionPinTTL = 10 * time.Minute
+
+// pin describes how a request's upstream should be pinned in Redis:
+// under what key, for how long, and whether a cache hit refreshes the
+// TTL. refreshOnHit is true for sticky pins (the pin should outlast an
+// active session/key) and false for interval rotation, where the TTL
+// set at pin time is what forces the next request onto a new upstream.
+type pin struct {
+	key          string
+	ttl          time.Duration
+	refreshOnHit bool
+}
+
+// pinFor derives the pinning behavior for a request: an explicit client
+// session ID takes priority and is always sticky; absent that, a key
+// with RotationModeSticky or RotationModeInterval pins per-key instead,
+// and RotationModePerRequest (or an unset key) means no pinning at all.
+func pinFor(sessionID string, key *apikey.Key) (pin, bool) {
+	if sessionID != "" {
+		return pin{key: "session:" + sessionID, ttl: sessionPinTTL, refreshOnHit: true}, true
+	}
+	if key == nil {
+		return pin{}, false
+	}
+
+	switch key.RotationMode {
+	case apikey.RotationModeSticky:
+		return pin{key: "apikey:" + key.ID, ttl: sessionPinTTL, refreshOnHit: true}, true
+	case apikey.RotationModeInterval:
+		ttl := time.Duration(key.RotationIntervalSeconds) * time.Second
+		if ttl <= 0 {
+			return pin{}, false
+		}
+		return pin{key: "apikey:" + key.ID, ttl: ttl, refreshOnHit: false}, true
+	default:
+		return pin{}, false
+	}
+}
+
+// resolveUpstream picks an upstream proxy for target, honoring whichever
+// pin applies: an explicit sticky session (sessionID) takes priority,
+// otherwise the authenticated key's own rotation policy. The candidate
+// pinned ID, if any, is passed to pickUpstream (via pickAllowed) so
+// proxy-pool can reuse it when it's still eligible; the pin is (re)set
+// whenever a different upstream came back, or the same one did but the
+// pin says hits should refresh it. Blacklisted proxies are excluded for
+// premium-plan keys, and enterprise-plan keys get priority access to
+// top-scored proxies under contention (see qosMinScore). pickAllowed
+// additionally steers around any upstream the local circuit breaker
+// currently considers broken.
+func (e *Engine) resolveUpstream(ctx context.Context, target, sessionID string, key *apikey.Key, geo geoFilter) (*proxymodel.Proxy, error) {
+	p, pinned := pinFor(sessionID, key)
+	pinnedID := ""
+	if pinned {
+		pinnedID, _ = e.sessions.Get(ctx, p.key)
+	}
+
+	excludeBlacklisted := key != nil && key.Plan.IsPremium()
+	qos := user.QoSStandard
+	if key != nil {
+		qos = key.Plan.QoSClass()
+	}
+	upstream, err := e.pickAllowed(ctx, target, geo, pinnedID, excludeBlacklisted, qos)
+	if err != nil {
+		return nil, err
+	}
+
+	if pinned && (upstream.ID != pinnedID || p.refreshOnHit) {
+		e.sessions.Pin(ctx, p.key, upstream.ID, p.ttl)
+	}
+	return upstream, nil
+}
+
+// hostOnly strips an optional ":port" suffix from target, for the
+// places only the bare domain matters: routing rules and per-domain
+// proxy preference.
+func hostOnly(target string) string {
+	host := target
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return host
+}
+
+// sessionIDFromRequest extracts a sticky-session ID from an HTTP
+// request: the X-Session-Id header if set, otherwise a "-session-<id>"
+// suffix on the Proxy-Authorization username.
+func sessionIDFromRequest(r *http.Request) string {
+	if id := r.Header.Get("X-Session-Id"); id != "" {
+		return id
+	}
+	return sessionIDFromUsername(proxyUsername(r))
+}
+
+// proxyUsername returns the username half of a Proxy-Authorization:
+// Basic header, e.g. "user" in "user:apikey".
+func proxyUsername(r *http.Request) string {
+	auth := r.Header.Get("Proxy-Authorization")
+	const prefix = "Basic "
+	if !strings.HasPrefix(auth, prefix) {
+		return ""
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(auth, prefix))
+	if err != nil {
+		return ""
+	}
+	username, _, _ := strings.Cut(string(decoded), ":")
+	return username
+}
+
+// sessionIDFromUsername pulls the session ID out of a username of the
+// form "<anything>-session-<id>", as used by scrapers that encode
+// per-request options into the proxy username (e.g. "user-session-abc").
+// It returns "" if the username doesn't contain that marker.
+func sessionIDFromUsername(username string) string {
+	const marker = "-session-"
+	idx := strings.Index(username, marker)
+	if idx == -1 {
+		return ""
+	}
+	return username[idx+len(marker):]
+}
@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+)
+
+func TestPinFor(t *testing.T) {
+	stickyKey := &apikey.Key{ID: "k1", RotationMode: apikey.RotationModeSticky}
+	intervalKey := &apikey.Key{ID: "k2", RotationMode: apikey.RotationModeInterval, RotationIntervalSeconds: 30}
+	perRequestKey := &apikey.Key{ID: "k3", RotationMode: apikey.RotationModePerRequest}
+
+	cases := []struct {
+		name        string
+		sessionID   string
+		key         *apikey.Key
+		wantPinned  bool
+		wantKey     string
+		wantRefresh bool
+	}{
+		{"explicit session wins over key policy", "abc", stickyKey, true, "session:abc", true},
+		{"sticky key with no session", "", stickyKey, true, "apikey:k1", true},
+		{"interval key does not refresh on hit", "", intervalKey, true, "apikey:k2", false},
+		{"per_request key never pins", "", perRequestKey, false, "", false},
+		{"nil key never pins", "", nil, false, "", false},
+		{"interval key with no interval configured never pins", "", &apikey.Key{ID: "k4", RotationMode: apikey.RotationModeInterval}, false, "", false},
+	}
+
+	for _, c := range cases {
+		p, pinned := pinFor(c.sessionID, c.key)
+		if pinned != c.wantPinned {
+			t.Errorf("%s: pinFor() pinned = %v, want %v", c.name, pinned, c.wantPinned)
+			continue
+		}
+		if !pinned {
+			continue
+		}
+		if p.key != c.wantKey {
+			t.Errorf("%s: pinFor() key = %q, want %q", c.name, p.key, c.wantKey)
+		}
+		if p.refreshOnHit != c.wantRefresh {
+			t.Errorf("%s: pinFor() refreshOnHit = %v, want %v", c.name, p.refreshOnHit, c.wantRefresh)
+		}
+	}
+}
+
+func TestSessionIDFromUsername(t *testing.T) {
+	cases := []struct {
+		username string
+		want     string
+	}{
+		{"user-session-abc", "abc"},
+		{"scraper1-session-xyz789", "xyz789"},
+		{"user", ""},
+		{"", ""},
+	}
+
+	for _, c := range cases {
+		if got := sessionIDFromUsername(c.username); got != c.want {
+			t.Errorf("sessionIDFromUsername(%q) = %q, want %q", c.username, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	"github.com/XXXXD-cation/proxy-platform/pkg/tracing"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+)
+
+// SOCKS5 protocol constants (RFC 1928 / RFC 1929).
+const (
+	socksVersion5           = 0x05
+	socksAuthNone           = 0x00
+	socksAuthUserPass       = 0x02
+	socksAuthNoAcceptable   = 0xFF
+	socksCmdConnect         = 0x01
+	socksCmdUDPAssociate    = 0x03
+	socksAddrIPv4           = 0x01
+	socksAddrDomain         = 0x03
+	socksAddrIPv6           = 0x04
+	socksRepSucceeded       = 0x00
+	socksRepGeneralFail     = 0x01
+	socksRepHostUnreach     = 0x04
+	socksRepCmdNotSupported = 0x07
+)
+
+// ServeSOCKS5 accepts SOCKS5 connections on ln until it is closed. Each
+// connection authenticates with the platform's usual API key (carried as
+// the SOCKS5 username/password) and is otherwise tunneled exactly like a
+// CONNECT request.
+func (e *Engine) ServeSOCKS5(ln net.Listener) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		go e.handleSOCKS5Conn(conn)
+	}
+}
+
+func (e *Engine) handleSOCKS5Conn(conn net.Conn) {
+	defer conn.Close()
+
+	ctx, span := tracing.Tracer("gateway").Start(context.Background(), "upstream.socks5")
+	defer span.End()
+
+	key, username, cmd, target, err := socks5Handshake(ctx, conn, e)
+	if err != nil {
+		log.Printf("engine: socks5 handshake failed: %v", err)
+		return
+	}
+	if !e.checkRateLimit(ctx, key) {
+		writeSOCKS5Reply(conn, socksRepGeneralFail)
+		return
+	}
+	if cmd == socksCmdUDPAssociate {
+		e.handleUDPAssociate(ctx, conn, key, span)
+		return
+	}
+	if reason, allowed := e.checkTargetPolicy(ctx, key, target); !allowed {
+		e.recordUsage(usage.Log{
+			UserID:       key.UserID,
+			APIKeyID:     key.ID,
+			TargetHost:   target,
+			Protocol:     "socks5",
+			StatusCode:   http.StatusForbidden,
+			DenialReason: reason,
+		})
+		writeSOCKS5Reply(conn, socksRepGeneralFail)
+		return
+	}
+	if category, blocked := e.checkBlocklist(ctx, key, target); blocked {
+		e.recordUsage(usage.Log{
+			UserID:       key.UserID,
+			APIKeyID:     key.ID,
+			TargetHost:   target,
+			Protocol:     "socks5",
+			StatusCode:   http.StatusForbidden,
+			DenialReason: "blocklisted: " + category,
+		})
+		writeSOCKS5Reply(conn, socksRepGeneralFail)
+		return
+	}
+	release, ok := e.acquireConn(ctx, key)
+	if !ok {
+		if err := redis.IncrLiveConcurrencyRejection(ctx, e.redisClient); err != nil {
+			log.Printf("engine: failed to record live concurrency rejection counter: %v", err)
+		}
+		writeSOCKS5Reply(conn, socksRepGeneralFail)
+		return
+	}
+	defer release()
+
+	upstream, err := e.resolveUpstream(ctx, target, sessionIDFromUsername(username), key, geoFromUsername(username))
+	if err != nil {
+		writeSOCKS5Reply(conn, socksRepGeneralFail)
+		return
+	}
+	domain := hostOnly(target)
+	traceID := traceIDString(span)
+
+	start := time.Now()
+	timing := newTimingCapture(start)
+	dialer := net.Dialer{Timeout: 10 * time.Second}
+	upstreamConn, err := dialer.DialContext(timing.withClientTrace(ctx), "tcp", upstream.Addr())
+	if err != nil {
+		e.reportOutcome(upstream.ID, domain, false, time.Since(start))
+		writeSOCKS5Reply(conn, socksRepHostUnreach)
+		return
+	}
+	defer upstreamConn.Close()
+
+	proxyToTargetStart := time.Now()
+	connectReq := fmt.Sprintf("CONNECT %s HTTP/1.1\r\nHost: %s\r\n\r\n", target, target)
+	if _, err := upstreamConn.Write([]byte(connectReq)); err != nil {
+		e.reportOutcome(upstream.ID, domain, false, time.Since(start))
+		writeSOCKS5Reply(conn, socksRepGeneralFail)
+		return
+	}
+	upstreamResp, err := http.ReadResponse(bufio.NewReader(upstreamConn), nil)
+	if err != nil || upstreamResp.StatusCode != http.StatusOK {
+		e.reportOutcome(upstream.ID, domain, false, time.Since(start))
+		writeSOCKS5Reply(conn, socksRepGeneralFail)
+		return
+	}
+	upstreamResp.Body.Close()
+	timing.markProxyToTarget(time.Since(proxyToTargetStart))
+	e.reportOutcome(upstream.ID, domain, true, time.Since(start))
+
+	if err := writeSOCKS5Reply(conn, socksRepSucceeded); err != nil {
+		return
+	}
+
+	bytesIn, bytesOut := e.splice(ctx, conn, upstreamConn, key)
+	if err := redis.IncrLiveBytes(ctx, e.redisClient, bytesIn+bytesOut); err != nil {
+		log.Printf("engine: failed to record live bytes counter: %v", err)
+	}
+
+	total := time.Since(start)
+	snapshot := timing.snapshot(total)
+	e.recordUsage(usage.Log{
+		UserID:     key.UserID,
+		APIKeyID:   key.ID,
+		ProxyAddr:  upstream.Addr(),
+		ProxyID:    upstream.ID,
+		TargetHost: target,
+		Protocol:   "socks5",
+		BytesIn:    bytesIn,
+		BytesOut:   bytesOut,
+		TraceID:    traceID,
+		Timing:     &snapshot,
+		DurationMS: total.Milliseconds(),
+	})
+}
+
+// socks5Handshake performs method negotiation, then authenticates one
+// of two ways depending on what the client offered: username/password
+// (the API key is sent as the password), or, for a client offering only
+// "no auth", by matching its source IP against its owner's IP
+// allowlist. It then parses the request, returning the authenticated
+// key, the client's username (which may encode a sticky-session ID,
+// e.g. "user-session-abc"; empty for IP auth), the requested command
+// (socksCmdConnect or socksCmdUDPAssociate), and the "host:port"
+// target (for socksCmdUDPAssociate this is the client's own expected
+// source, per RFC 1928 §7, which handleUDPAssociate doesn't rely on).
+func socks5Handshake(ctx context.Context, conn net.Conn, e *Engine) (*apikey.Key, string, byte, string, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, "", 0, "", err
+	}
+	if header[0] != socksVersion5 {
+		return nil, "", 0, "", errors.New("unsupported socks version")
+	}
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return nil, "", 0, "", err
+	}
+
+	hasUserPass, hasNoAuth := false, false
+	for _, m := range methods {
+		switch m {
+		case socksAuthUserPass:
+			hasUserPass = true
+		case socksAuthNone:
+			hasNoAuth = true
+		}
+	}
+	if !hasUserPass {
+		if !hasNoAuth {
+			conn.Write([]byte{socksVersion5, socksAuthNoAcceptable})
+			return nil, "", 0, "", errors.New("client does not support username/password or no-auth")
+		}
+		return socks5NoAuthHandshake(ctx, conn, e)
+	}
+	conn.Write([]byte{socksVersion5, socksAuthUserPass})
+
+	authHeader := make([]byte, 2)
+	if _, err := io.ReadFull(conn, authHeader); err != nil {
+		return nil, "", 0, "", err
+	}
+	username := make([]byte, authHeader[1])
+	if _, err := io.ReadFull(conn, username); err != nil {
+		return nil, "", 0, "", err
+	}
+	pwLen := make([]byte, 1)
+	if _, err := io.ReadFull(conn, pwLen); err != nil {
+		return nil, "", 0, "", err
+	}
+	password := make([]byte, pwLen[0])
+	if _, err := io.ReadFull(conn, password); err != nil {
+		return nil, "", 0, "", err
+	}
+
+	key, err := e.users.Authorize(ctx, string(password))
+	if err != nil {
+		conn.Write([]byte{0x01, 0x01}) // auth failure
+		return nil, "", 0, "", err
+	}
+	conn.Write([]byte{0x01, 0x00}) // auth success
+
+	cmd, target, err := readSOCKS5Request(conn)
+	if err != nil {
+		return nil, "", 0, "", err
+	}
+	return key, string(username), cmd, target, nil
+}
+
+// socks5NoAuthHandshake authorizes a client that offered no
+// username/password by matching its source IP against its owner's IP
+// allowlist, then parses the request exactly like the username/password
+// path.
+func socks5NoAuthHandshake(ctx context.Context, conn net.Conn, e *Engine) (*apikey.Key, string, byte, string, error) {
+	conn.Write([]byte{socksVersion5, socksAuthNone})
+
+	key, err := e.users.AuthorizeByIP(ctx, clientIP(conn.RemoteAddr().String()))
+	if err != nil {
+		return nil, "", 0, "", err
+	}
+
+	cmd, target, err := readSOCKS5Request(conn)
+	if err != nil {
+		return nil, "", 0, "", err
+	}
+	return key, "", cmd, target, nil
+}
+
+func readSOCKS5Request(conn net.Conn) (byte, string, error) {
+	req := make([]byte, 4)
+	if _, err := io.ReadFull(conn, req); err != nil {
+		return 0, "", err
+	}
+	if req[0] != socksVersion5 {
+		return 0, "", errors.New("unsupported socks version")
+	}
+	if req[1] != socksCmdConnect && req[1] != socksCmdUDPAssociate {
+		return 0, "", errors.New("only the CONNECT and UDP ASSOCIATE commands are supported")
+	}
+	cmd := req[1]
+
+	var host string
+	switch req[3] {
+	case socksAddrIPv4:
+		addr := make([]byte, 4)
+		if _, err := io.ReadFull(conn, addr); err != nil {
+			return 0, "", err
+		}
+		host = net.IP(addr).String()
+	case socksAddrDomain:
+		lenBuf := make([]byte, 1)
+		if _, err := io.ReadFull(conn, lenBuf); err != nil {
+			return 0, "", err
+		}
+		domain := make([]byte, lenBuf[0])
+		if _, err := io.ReadFull(conn, domain); err != nil {
+			return 0, "", err
+		}
+		host = string(domain)
+	case socksAddrIPv6:
+		addr := make([]byte, 16)
+		if _, err := io.ReadFull(conn, addr); err != nil {
+			return 0, "", err
+		}
+		host = net.IP(addr).String()
+	default:
+		return 0, "", errors.New("unsupported address type")
+	}
+
+	portBuf := make([]byte, 2)
+	if _, err := io.ReadFull(conn, portBuf); err != nil {
+		return 0, "", err
+	}
+	port := binary.BigEndian.Uint16(portBuf)
+
+	return cmd, fmt.Sprintf("%s:%d", host, port), nil
+}
+
+func writeSOCKS5Reply(conn net.Conn, rep byte) error {
+	_, err := conn.Write([]byte{socksVersion5, rep, 0x00, socksAddrIPv4, 0, 0, 0, 0, 0, 0})
+	return err
+}
@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+)
+
+// fakeConn adapts an io.Reader/io.Writer pair to net.Conn for feeding
+// fixed byte sequences into the SOCKS5 parsers without a real socket. It
+// also records whatever it's written and can report a fixed RemoteAddr,
+// for exercising code that replies over the connection or falls back to
+// the peer address.
+type fakeConn struct {
+	net.Conn
+	r          *bytes.Reader
+	written    bytes.Buffer
+	remoteAddr net.Addr
+}
+
+func (f *fakeConn) Read(p []byte) (int, error)       { return f.r.Read(p) }
+func (f *fakeConn) Write(p []byte) (int, error)      { return f.written.Write(p) }
+func (f *fakeConn) SetDeadline(time.Time) error      { return nil }
+func (f *fakeConn) SetReadDeadline(time.Time) error  { return nil }
+func (f *fakeConn) SetWriteDeadline(time.Time) error { return nil }
+func (f *fakeConn) RemoteAddr() net.Addr             { return f.remoteAddr }
+
+func newFakeConn(b []byte) *fakeConn {
+	return &fakeConn{r: bytes.NewReader(b)}
+}
+
+func TestReadSOCKS5RequestAddressTypes(t *testing.T) {
+	cases := []struct {
+		name    string
+		req     []byte
+		wantCmd byte
+		want    string
+	}{
+		{
+			name:    "ipv4 connect",
+			req:     append([]byte{socksVersion5, socksCmdConnect, 0x00, socksAddrIPv4}, append([]byte{93, 184, 216, 34}, 0x00, 0x50)...),
+			wantCmd: socksCmdConnect,
+			want:    "93.184.216.34:80",
+		},
+		{
+			name:    "domain connect",
+			req:     append([]byte{socksVersion5, socksCmdConnect, 0x00, socksAddrDomain, 11}, append([]byte("example.com"), 0x01, 0xBB)...),
+			wantCmd: socksCmdConnect,
+			want:    "example.com:443",
+		},
+		{
+			name: "ipv6 connect",
+			req: append([]byte{socksVersion5, socksCmdConnect, 0x00, socksAddrIPv6},
+				append([]byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, 0x1F, 0x90)...),
+			wantCmd: socksCmdConnect,
+			want:    "2001:db8::1:8080",
+		},
+		{
+			name:    "udp associate ipv4",
+			req:     append([]byte{socksVersion5, socksCmdUDPAssociate, 0x00, socksAddrIPv4}, append([]byte{0, 0, 0, 0}, 0x00, 0x00)...),
+			wantCmd: socksCmdUDPAssociate,
+			want:    "0.0.0.0:0",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmd, target, err := readSOCKS5Request(newFakeConn(c.req))
+			if err != nil {
+				t.Fatalf("readSOCKS5Request: %v", err)
+			}
+			if cmd != c.wantCmd {
+				t.Errorf("cmd = 0x%02x, want 0x%02x", cmd, c.wantCmd)
+			}
+			if target != c.want {
+				t.Errorf("target = %q, want %q", target, c.want)
+			}
+		})
+	}
+}
+
+func TestReadSOCKS5RequestRejectsBadVersion(t *testing.T) {
+	req := []byte{0x04, socksCmdConnect, 0x00, socksAddrIPv4, 1, 2, 3, 4, 0, 80}
+	if _, _, err := readSOCKS5Request(newFakeConn(req)); err == nil {
+		t.Fatal("expected an error for an unsupported SOCKS version")
+	}
+}
+
+func TestReadSOCKS5RequestRejectsUnsupportedCommand(t *testing.T) {
+	const cmdBind = 0x02
+	req := []byte{socksVersion5, cmdBind, 0x00, socksAddrIPv4, 1, 2, 3, 4, 0, 80}
+	if _, _, err := readSOCKS5Request(newFakeConn(req)); err == nil {
+		t.Fatal("expected an error for an unsupported command")
+	}
+}
+
+func TestReadSOCKS5RequestRejectsUnsupportedAddressType(t *testing.T) {
+	const addrUnknown = 0x02
+	req := []byte{socksVersion5, socksCmdConnect, 0x00, addrUnknown}
+	if _, _, err := readSOCKS5Request(newFakeConn(req)); err == nil {
+		t.Fatal("expected an error for an unsupported address type")
+	}
+}
+
+func TestReadSOCKS5RequestTruncatedDomainLength(t *testing.T) {
+	// Declares a 10-byte domain but supplies none of it.
+	req := []byte{socksVersion5, socksCmdConnect, 0x00, socksAddrDomain, 10}
+	if _, _, err := readSOCKS5Request(newFakeConn(req)); err == nil {
+		t.Fatal("expected an error when the domain bytes are truncated")
+	}
+}
+
+func TestReadSOCKS5RequestTruncatedPort(t *testing.T) {
+	// A complete IPv4 address but only one of the two port bytes.
+	req := []byte{socksVersion5, socksCmdConnect, 0x00, socksAddrIPv4, 1, 2, 3, 4, 0x00}
+	if _, _, err := readSOCKS5Request(newFakeConn(req)); err == nil {
+		t.Fatal("expected an error when the port is truncated")
+	}
+}
+
+func TestReadSOCKS5RequestTruncatedHeader(t *testing.T) {
+	req := []byte{socksVersion5}
+	if _, _, err := readSOCKS5Request(newFakeConn(req)); err == nil {
+		t.Fatal("expected an error when the request header itself is truncated")
+	}
+}
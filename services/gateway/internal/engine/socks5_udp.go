@@ -0,0 +1,303 @@
+package engine
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/apikey"
+	proxymodel "github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	"github.com/XXXXD-cation/proxy-platform/pkg/rpcclient"
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+)
+
+// udpAssociateIdleTimeout tears a UDP ASSOCIATE session down after this
+// long without a datagram in either direction. Unlike a CONNECT tunnel,
+// a stuck UDP relay has no TCP half-close to signal the client is done
+// with it, so it needs its own bound.
+const udpAssociateIdleTimeout = 2 * time.Minute
+
+// udpDatagramBufferSize is sized for the largest UDP payload a socket
+// can deliver in one read (65507, the max UDP payload over IPv4).
+const udpDatagramBufferSize = 65507
+
+// handleUDPAssociate implements the SOCKS5 UDP ASSOCIATE command (RFC
+// 1928 §7). Only a SOCKS5 upstream can itself relay UDP, so it acquires
+// one from proxy-pool, performs UDP ASSOCIATE against it to get its
+// relay address, allocates a local UDP socket for the client, and
+// reports that socket's address back over conn. It then splices
+// datagrams between the client and the upstream's relay until conn (the
+// control connection) closes or the session goes idle, tracking the
+// client's source address NAT-style: only datagrams from the address
+// that opened the association are forwarded, and replies are routed
+// back to whichever address most recently used it.
+func (e *Engine) handleUDPAssociate(ctx context.Context, conn net.Conn, key *apikey.Key, span trace.Span) {
+	if key == nil || !key.Plan.AllowsUDPAssociate() {
+		writeSOCKS5Reply(conn, socksRepCmdNotSupported)
+		return
+	}
+
+	upstream, err := e.pool.Acquire(ctx, rpcclient.AcquireParams{
+		Protocol:  proxymodel.ProtocolSOCKS5,
+		GatewayID: e.region,
+	})
+	if err != nil {
+		writeSOCKS5Reply(conn, socksRepGeneralFail)
+		return
+	}
+
+	upstreamCtrl, upstreamRelay, err := dialSOCKS5UDPAssociate(ctx, upstream.Addr())
+	if err != nil {
+		e.reportOutcome(upstream.ID, "", false, 0)
+		writeSOCKS5Reply(conn, socksRepGeneralFail)
+		return
+	}
+	defer upstreamCtrl.Close()
+
+	clientRelay, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4zero, Port: 0})
+	if err != nil {
+		e.reportOutcome(upstream.ID, "", false, 0)
+		writeSOCKS5Reply(conn, socksRepGeneralFail)
+		return
+	}
+	defer clientRelay.Close()
+
+	upstreamConn, err := net.DialUDP("udp", nil, upstreamRelay)
+	if err != nil {
+		e.reportOutcome(upstream.ID, "", false, 0)
+		writeSOCKS5Reply(conn, socksRepGeneralFail)
+		return
+	}
+	defer upstreamConn.Close()
+
+	if err := writeSOCKS5UDPReply(conn, clientRelay.LocalAddr().(*net.UDPAddr)); err != nil {
+		return
+	}
+
+	traceID := traceIDString(span)
+	start := time.Now()
+	bytesIn, bytesOut := e.relayUDPAssociate(ctx, conn, clientRelay, upstreamConn, clientIP(conn.RemoteAddr().String()), key)
+	total := time.Since(start)
+
+	if err := redis.IncrLiveBytes(ctx, e.redisClient, bytesIn+bytesOut); err != nil {
+		log.Printf("engine: failed to record live bytes counter: %v", err)
+	}
+	e.reportOutcome(upstream.ID, "", true, total)
+	e.recordUsage(usage.Log{
+		UserID:     key.UserID,
+		APIKeyID:   key.ID,
+		ProxyAddr:  upstream.Addr(),
+		ProxyID:    upstream.ID,
+		Protocol:   "socks5-udp",
+		BytesIn:    bytesIn,
+		BytesOut:   bytesOut,
+		TraceID:    traceID,
+		DurationMS: total.Milliseconds(),
+	})
+}
+
+// relayUDPAssociate copies datagrams between clientRelay and
+// upstreamConn until ctrl (the client's SOCKS5 control connection)
+// closes or the association sits idle past udpAssociateIdleTimeout,
+// returning the total bytes moved in each direction. Datagrams arriving
+// on clientRelay from anywhere but expectedClientIP are silently
+// dropped, the same NAT-style source check most SOCKS5 UDP relays
+// apply, since a UDP socket otherwise has no notion of "the connection
+// that opened it" the way the tunneled TCP paths do.
+func (e *Engine) relayUDPAssociate(ctx context.Context, ctrl net.Conn, clientRelay, upstreamConn *net.UDPConn, expectedClientIP string, key *apikey.Key) (bytesIn, bytesOut int64) {
+	var wg sync.WaitGroup
+	var clientAddrMu sync.Mutex
+	var clientAddr *net.UDPAddr
+
+	closeOnce := sync.OnceFunc(func() {
+		clientRelay.Close()
+		upstreamConn.Close()
+	})
+	go func() {
+		io.Copy(io.Discard, ctrl)
+		closeOnce()
+	}()
+	defer closeOnce()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		buf := make([]byte, udpDatagramBufferSize)
+		for {
+			clientRelay.SetReadDeadline(time.Now().Add(udpAssociateIdleTimeout))
+			n, addr, err := clientRelay.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			if addr.IP.String() != expectedClientIP {
+				continue
+			}
+			clientAddrMu.Lock()
+			clientAddr = addr
+			clientAddrMu.Unlock()
+
+			if e.bandwidth != nil {
+				if err := e.bandwidth.ForUser(key.UserID, "up", key.Plan).WaitN(ctx, n); err != nil {
+					continue
+				}
+			}
+			if _, err := upstreamConn.Write(buf[:n]); err != nil {
+				return
+			}
+			atomic.AddInt64(&bytesIn, int64(n))
+		}
+	}()
+
+	buf := make([]byte, udpDatagramBufferSize)
+	for {
+		upstreamConn.SetReadDeadline(time.Now().Add(udpAssociateIdleTimeout))
+		n, err := upstreamConn.Read(buf)
+		if err != nil {
+			break
+		}
+		clientAddrMu.Lock()
+		dst := clientAddr
+		clientAddrMu.Unlock()
+		if dst == nil {
+			continue
+		}
+
+		if e.bandwidth != nil {
+			if err := e.bandwidth.ForUser(key.UserID, "down", key.Plan).WaitN(ctx, n); err != nil {
+				continue
+			}
+		}
+		if _, err := clientRelay.WriteToUDP(buf[:n], dst); err != nil {
+			break
+		}
+		atomic.AddInt64(&bytesOut, int64(n))
+	}
+
+	closeOnce()
+	wg.Wait()
+	return atomic.LoadInt64(&bytesIn), atomic.LoadInt64(&bytesOut)
+}
+
+// writeSOCKS5UDPReply replies to a UDP ASSOCIATE request with addr, the
+// local UDP socket the client should send its datagrams to. Servers
+// that bind that socket to a wildcard address (as this one does) send
+// back an unspecified BND.ADDR; the established convention, which
+// every SOCKS5 client handling UDP ASSOCIATE already follows, is for
+// the client to fall back to the address it reached the control
+// connection on.
+func writeSOCKS5UDPReply(conn net.Conn, addr *net.UDPAddr) error {
+	reply := make([]byte, 0, 10)
+	reply = append(reply, socksVersion5, socksRepSucceeded, 0x00, socksAddrIPv4)
+	reply = append(reply, addr.IP.To4()...)
+	portBuf := make([]byte, 2)
+	binary.BigEndian.PutUint16(portBuf, uint16(addr.Port))
+	reply = append(reply, portBuf...)
+	_, err := conn.Write(reply)
+	return err
+}
+
+// dialSOCKS5UDPAssociate acts as a SOCKS5 client against addr (a
+// proxy.ProtocolSOCKS5 upstream): it connects, negotiates no-auth, and
+// issues a UDP ASSOCIATE request, returning the open control connection
+// (which must stay open for the life of the association, per RFC 1928
+// §7) and the relay address the upstream reported.
+func dialSOCKS5UDPAssociate(ctx context.Context, addr string) (net.Conn, *net.UDPAddr, error) {
+	dialer := net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := conn.Write([]byte{socksVersion5, 0x01, socksAuthNone}); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	method := make([]byte, 2)
+	if _, err := io.ReadFull(conn, method); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if method[0] != socksVersion5 || method[1] != socksAuthNone {
+		conn.Close()
+		return nil, nil, errors.New("upstream socks5 proxy requires an unsupported auth method")
+	}
+
+	req := []byte{socksVersion5, socksCmdUDPAssociate, 0x00, socksAddrIPv4, 0, 0, 0, 0, 0, 0}
+	if _, err := conn.Write(req); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	relayAddr, err := readSOCKS5UDPAssociateReply(conn)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return conn, relayAddr, nil
+}
+
+func readSOCKS5UDPAssociateReply(conn net.Conn) (*net.UDPAddr, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, err
+	}
+	if header[0] != socksVersion5 || header[1] != socksRepSucceeded {
+		return nil, fmt.Errorf("upstream refused UDP ASSOCIATE: rep=0x%02x", header[1])
+	}
+
+	var ip net.IP
+	switch header[3] {
+	case socksAddrIPv4:
+		addr := make([]byte, 4)
+		if _, err := io.ReadFull(conn, addr); err != nil {
+			return nil, err
+		}
+		ip = net.IP(addr)
+	case socksAddrIPv6:
+		addr := make([]byte, 16)
+		if _, err := io.ReadFull(conn, addr); err != nil {
+			return nil, err
+		}
+		ip = net.IP(addr)
+	case socksAddrDomain:
+		lenBuf := make([]byte, 1)
+		if _, err := io.ReadFull(conn, lenBuf); err != nil {
+			return nil, err
+		}
+		domain := make([]byte, lenBuf[0])
+		if _, err := io.ReadFull(conn, domain); err != nil {
+			return nil, err
+		}
+		resolved, err := net.ResolveIPAddr("ip", string(domain))
+		if err != nil {
+			return nil, err
+		}
+		ip = resolved.IP
+	default:
+		return nil, errors.New("unsupported address type in UDP ASSOCIATE reply")
+	}
+
+	portBuf := make([]byte, 2)
+	if _, err := io.ReadFull(conn, portBuf); err != nil {
+		return nil, err
+	}
+	port := binary.BigEndian.Uint16(portBuf)
+
+	if ip.IsUnspecified() {
+		host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+		if err != nil {
+			return nil, err
+		}
+		ip = net.ParseIP(host)
+	}
+	return &net.UDPAddr{IP: ip, Port: int(port)}, nil
+}
@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestWriteSOCKS5UDPReply(t *testing.T) {
+	conn := newFakeConn(nil)
+	port := 51820
+	addr := &net.UDPAddr{IP: net.IPv4(203, 0, 113, 5), Port: port}
+
+	if err := writeSOCKS5UDPReply(conn, addr); err != nil {
+		t.Fatalf("writeSOCKS5UDPReply: %v", err)
+	}
+
+	want := []byte{
+		socksVersion5, socksRepSucceeded, 0x00, socksAddrIPv4,
+		203, 0, 113, 5,
+		byte(port >> 8), byte(port),
+	}
+
+	if !bytes.Equal(conn.written.Bytes(), want) {
+		t.Errorf("wrote %x, want %x", conn.written.Bytes(), want)
+	}
+}
+
+func TestReadSOCKS5UDPAssociateReplyIPv4(t *testing.T) {
+	reply := []byte{socksVersion5, socksRepSucceeded, 0x00, socksAddrIPv4, 198, 51, 100, 7, 0x1F, 0x90}
+	addr, err := readSOCKS5UDPAssociateReply(newFakeConn(reply))
+	if err != nil {
+		t.Fatalf("readSOCKS5UDPAssociateReply: %v", err)
+	}
+	if addr.IP.String() != "198.51.100.7" || addr.Port != 8080 {
+		t.Errorf("got %s:%d, want 198.51.100.7:8080", addr.IP, addr.Port)
+	}
+}
+
+func TestReadSOCKS5UDPAssociateReplyIPv6(t *testing.T) {
+	reply := append([]byte{socksVersion5, socksRepSucceeded, 0x00, socksAddrIPv6},
+		append([]byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, 0x1F, 0x90)...)
+	addr, err := readSOCKS5UDPAssociateReply(newFakeConn(reply))
+	if err != nil {
+		t.Fatalf("readSOCKS5UDPAssociateReply: %v", err)
+	}
+	if addr.IP.String() != "2001:db8::1" || addr.Port != 8080 {
+		t.Errorf("got %s:%d, want 2001:db8::1:8080", addr.IP, addr.Port)
+	}
+}
+
+func TestReadSOCKS5UDPAssociateReplyUnspecifiedFallsBackToRemoteAddr(t *testing.T) {
+	reply := []byte{socksVersion5, socksRepSucceeded, 0x00, socksAddrIPv4, 0, 0, 0, 0, 0x1F, 0x90}
+	conn := newFakeConn(reply)
+	conn.remoteAddr = &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1080}
+
+	addr, err := readSOCKS5UDPAssociateReply(conn)
+	if err != nil {
+		t.Fatalf("readSOCKS5UDPAssociateReply: %v", err)
+	}
+	if addr.IP.String() != "192.0.2.1" {
+		t.Errorf("IP = %s, want fallback to remote addr 192.0.2.1", addr.IP)
+	}
+	if addr.Port != 8080 {
+		t.Errorf("Port = %d, want the port from the reply (8080)", addr.Port)
+	}
+}
+
+func TestReadSOCKS5UDPAssociateReplyRejectsFailure(t *testing.T) {
+	const repGeneralFailure = 0x01
+	reply := []byte{socksVersion5, repGeneralFailure, 0x00, socksAddrIPv4, 0, 0, 0, 0, 0, 0}
+	if _, err := readSOCKS5UDPAssociateReply(newFakeConn(reply)); err == nil {
+		t.Fatal("expected an error when the upstream refuses UDP ASSOCIATE")
+	}
+}
+
+func TestReadSOCKS5UDPAssociateReplyRejectsUnsupportedAddressType(t *testing.T) {
+	const addrUnknown = 0x02
+	reply := []byte{socksVersion5, socksRepSucceeded, 0x00, addrUnknown}
+	if _, err := readSOCKS5UDPAssociateReply(newFakeConn(reply)); err == nil {
+		t.Fatal("expected an error for an unsupported address type")
+	}
+}
+
+func TestReadSOCKS5UDPAssociateReplyTruncatedHeader(t *testing.T) {
+	reply := []byte{socksVersion5}
+	if _, err := readSOCKS5UDPAssociateReply(newFakeConn(reply)); err == nil {
+		t.Fatal("expected an error when the reply header is truncated")
+	}
+}
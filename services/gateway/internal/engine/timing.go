@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"context"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/usage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceIDString returns span's W3C trace ID for usage.Log.TraceID, or
+// "" if tracing is disabled (see tracing.Init), in which case span's
+// context carries no valid trace ID.
+func traceIDString(span trace.Span) string {
+	if sc := span.SpanContext(); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	return ""
+}
+
+// timingCapture accumulates the latency breakdown for one proxied
+// request: DNS resolution and dial time observed via an httptrace hook
+// attached to the outgoing connection, plus phases the caller times
+// directly (the upstream-proxy handshake for a tunneled CONNECT/SOCKS5
+// session, time to first response byte for a plain forward). Hooks can
+// fire from goroutines other than the caller's, so every field is
+// guarded by mu.
+type timingCapture struct {
+	mu           sync.Mutex
+	requestStart time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	timing       usage.Timing
+}
+
+// newTimingCapture starts a capture anchored at requestStart, the same
+// instant recordUsage's DurationMS is measured from, so TTFBMS and
+// TotalMS in the resulting usage.Timing are on a shared clock.
+func newTimingCapture(requestStart time.Time) *timingCapture {
+	return &timingCapture{requestStart: requestStart}
+}
+
+// withClientTrace returns ctx augmented with an httptrace.ClientTrace
+// that records DNS and connect-to-proxy dial time into tc. The net
+// package invokes these hooks for any dial made with this context,
+// whether or not net/http is involved.
+func (tc *timingCapture) withClientTrace(ctx context.Context) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			tc.mu.Lock()
+			tc.dnsStart = time.Now()
+			tc.mu.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			tc.mu.Lock()
+			if !tc.dnsStart.IsZero() {
+				tc.timing.DNSMS = time.Since(tc.dnsStart).Milliseconds()
+			}
+			tc.mu.Unlock()
+		},
+		ConnectStart: func(network, addr string) {
+			tc.mu.Lock()
+			tc.connectStart = time.Now()
+			tc.mu.Unlock()
+		},
+		ConnectDone: func(network, addr string, err error) {
+			tc.mu.Lock()
+			if !tc.connectStart.IsZero() {
+				tc.timing.ConnectToProxyMS = time.Since(tc.connectStart).Milliseconds()
+			}
+			tc.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			tc.mu.Lock()
+			tc.timing.TTFBMS = time.Since(tc.requestStart).Milliseconds()
+			tc.mu.Unlock()
+		},
+	})
+}
+
+// markProxyToTarget records how long the upstream proxy took to
+// establish the tunnel to the real target, for a CONNECT/SOCKS5 session
+// where the gateway can see that handshake explicitly. Plain forwarding
+// has no equivalent: the transport can't distinguish "proxy talking to
+// target" from the rest of TTFB, so ProxyToTargetMS is left at zero
+// there.
+func (tc *timingCapture) markProxyToTarget(d time.Duration) {
+	tc.mu.Lock()
+	tc.timing.ProxyToTargetMS = d.Milliseconds()
+	tc.mu.Unlock()
+}
+
+// snapshot returns the captured breakdown with TotalMS filled in.
+func (tc *timingCapture) snapshot(total time.Duration) usage.Timing {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	t := tc.timing
+	t.TotalMS = total.Milliseconds()
+	return t
+}
@@ -0,0 +1,22 @@
+// Package openapispec documents gateway's HTTP surface as an OpenAPI 3
+// document, served at /openapi.json (and rendered at /docs). gateway's
+// primary job is forwarding proxy traffic through an arbitrary upstream
+// on "/" — that isn't a fixed, documentable REST operation, so this
+// spec only covers its two genuine HTTP endpoints, the health probes.
+package openapispec
+
+import "github.com/XXXXD-cation/proxy-platform/pkg/openapi"
+
+// Build returns the gateway OpenAPI document.
+func Build() *openapi.Builder {
+	b := openapi.NewBuilder(openapi.Info{
+		Title:       "proxy-platform gateway",
+		Version:     "1.0.0",
+		Description: "Forwards proxy traffic to the pool's hot set. Its request-handling path (\"/\") is a transparent forward, not a fixed REST API, so it isn't documented as an operation here.",
+	})
+
+	b.Add("/healthz", "GET", openapi.Operation{Summary: "Liveness probe", Tags: []string{"ops"}, Responses: map[string]openapi.Response{"200": {Description: "process is up"}}})
+	b.Add("/readyz", "GET", openapi.Operation{Summary: "Readiness probe", Description: "Runs every registered dependency check (MySQL ping, Redis ping) and reports each one's status and latency in the response body.", Tags: []string{"ops"}, Responses: map[string]openapi.Response{"200": {Description: "ready to serve traffic; body reports per-dependency status"}, "503": {Description: "not ready: shutting down or a dependency check failed; body reports which"}}})
+
+	return b
+}
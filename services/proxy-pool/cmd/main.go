@@ -0,0 +1,215 @@
+// Command proxy-pool owns the lifecycle of the proxy inventory: concurrent
+// health checking today, with scoring and validation to follow.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"google.golang.org/grpc"
+
+	"github.com/XXXXD-cation/proxy-platform/migrations"
+	"github.com/XXXXD-cation/proxy-platform/pkg/config"
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/eventbus"
+	"github.com/XXXXD-cation/proxy-platform/pkg/geoip"
+	"github.com/XXXXD-cation/proxy-platform/pkg/openapi"
+	"github.com/XXXXD-cation/proxy-platform/pkg/pool"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	proxypoolv1 "github.com/XXXXD-cation/proxy-platform/pkg/rpc/proxypoolv1"
+	"github.com/XXXXD-cation/proxy-platform/pkg/secrets"
+	"github.com/XXXXD-cation/proxy-platform/pkg/server"
+	"github.com/XXXXD-cation/proxy-platform/pkg/tracing"
+	"github.com/XXXXD-cation/proxy-platform/services/proxy-pool/internal/dedup"
+	"github.com/XXXXD-cation/proxy-platform/services/proxy-pool/internal/geoenrich"
+	"github.com/XXXXD-cation/proxy-platform/services/proxy-pool/internal/grpcserver"
+	"github.com/XXXXD-cation/proxy-platform/services/proxy-pool/internal/healthcheck"
+	"github.com/XXXXD-cation/proxy-platform/services/proxy-pool/internal/openapispec"
+	"github.com/XXXXD-cation/proxy-platform/services/proxy-pool/internal/poolmanager"
+	"github.com/XXXXD-cation/proxy-platform/services/proxy-pool/internal/scorer"
+	"github.com/XXXXD-cation/proxy-platform/services/proxy-pool/internal/scoresweep"
+)
+
+// SweepInterval is how often the health checker re-probes every active
+// proxy.
+const SweepInterval = 2 * time.Minute
+
+// GeoEnrichInterval is how often the GeoIP enrichment worker backfills
+// proxies missing geo data.
+const GeoEnrichInterval = 10 * time.Minute
+
+// PoolManagerInterval is how often the pool manager re-enforces every
+// pool's MaxProxies/MinQualityScore policy.
+const PoolManagerInterval = 5 * time.Minute
+
+// ScoreSweepInterval is how often every active proxy's score is
+// recomputed from scratch, independent of health checks, so
+// scorer.Config's staleness decay applies even to proxies that haven't
+// been freshly probed.
+const ScoreSweepInterval = 15 * time.Minute
+
+// DedupInterval is how often the cross-provider exit-IP duplicate
+// detection sweep runs. It only needs to catch up with exit IPs the
+// health-check sweep has already recorded, so it runs far less often
+// than SweepInterval itself.
+const DedupInterval = 30 * time.Minute
+
+// scorerConfigFromSettings translates the operator-tunable fields of a
+// hot-reloadable config.Config into a scorer.Config, defaulting
+// anything the operator hasn't set.
+func scorerConfigFromSettings(cfg *config.Config) scorer.Config {
+	sc := scorer.DefaultConfig()
+	sc.Weights = scorer.WeightsFromMap(cfg.ScorerWeights, scorer.DefaultWeights)
+	if cfg.ScorerLatencyCeilingMS > 0 {
+		sc.LatencyCeilingMS = cfg.ScorerLatencyCeilingMS
+	}
+	sc.DecayHalfLife = time.Duration(cfg.ScorerDecayHalfLifeSeconds) * time.Second
+	return sc
+}
+
+// newGeoLookuper opens the local MaxMind City database configured via
+// GEOIP_CITY_DB_PATH, pairing it with an ASN database if
+// GEOIP_ASN_DB_PATH is also set. It returns nil if no local database is
+// configured, in which case enrichment relies solely on the online
+// fallback.
+func newGeoLookuper() geoip.Lookuper {
+	cityDBPath := os.Getenv("GEOIP_CITY_DB_PATH")
+	if cityDBPath == "" {
+		return nil
+	}
+	reader, err := geoip.Open(cityDBPath, os.Getenv("GEOIP_ASN_DB_PATH"))
+	if err != nil {
+		log.Printf("proxy-pool: failed to open local GeoIP database, falling back to online lookups only: %v", err)
+		return nil
+	}
+	return reader
+}
+
+func main() {
+	run := server.New("proxy-pool")
+
+	shutdownTracing, err := tracing.Init(run.Context(), "proxy-pool")
+	if err != nil {
+		log.Fatalf("proxy-pool: failed to init tracing: %v", err)
+	}
+	run.OnShutdown("tracing", shutdownTracing)
+
+	configDir := os.Getenv("CONFIG_DIR")
+	if configDir == "" {
+		configDir = "."
+	}
+	configWatcher, err := config.NewWatcher(configDir)
+	if err != nil {
+		log.Fatalf("proxy-pool: failed to start config watcher on %s: %v", configDir, err)
+	}
+	run.OnShutdown("config watcher", func(context.Context) error { return configWatcher.Close() })
+
+	secretsResolver := secrets.NewDefaultResolver()
+
+	db, err := sql.Open("mysql", secretsResolver.MustGet(run.Context(), "MYSQL_DSN", ""))
+	if err != nil {
+		log.Fatalf("proxy-pool: failed to open mysql connection: %v", err)
+	}
+	run.OnShutdown("mysql", func(context.Context) error { return db.Close() })
+	run.RegisterDependency("mysql", db.PingContext)
+
+	if err := migrate.Run(run.Context(), db, migrate.FS); err != nil {
+		log.Fatalf("proxy-pool: failed to apply migrations: %v", err)
+	}
+
+	proxyDAO := dao.NewProxyDAO(db)
+	healthCheckDAO := dao.NewProxyHealthCheckDAO(db)
+	poolDAO := pool.NewDAO(db)
+	redisClient := redis.NewClient(redis.Config{
+		Addr:          secretsResolver.MustGet(run.Context(), "REDIS_ADDR", ""),
+		SentinelAddrs: redis.SplitAddrs(secretsResolver.MustGet(run.Context(), "REDIS_SENTINEL_ADDRS", "")),
+		MasterName:    secretsResolver.MustGet(run.Context(), "REDIS_MASTER_NAME", ""),
+		ClusterAddrs:  redis.SplitAddrs(secretsResolver.MustGet(run.Context(), "REDIS_CLUSTER_ADDRS", "")),
+		Password:      secretsResolver.MustGet(run.Context(), "REDIS_PASSWORD", ""),
+	})
+	run.OnShutdown("redis", func(context.Context) error { return redisClient.Close() })
+	run.RegisterDependency("redis", func(ctx context.Context) error { return redisClient.Ping(ctx).Err() })
+	hotZSet := redis.NewHotZSet(redisClient)
+	domainScore := redis.NewDomainScore(redisClient)
+	regionLatency := redis.NewRegionLatency(redisClient)
+	eventBus := eventbus.NewRedisBus(redisClient)
+	worker := healthcheck.NewWorker(healthcheck.MultiProbeChecker{}, proxyDAO, healthCheckDAO, poolDAO, redisClient, eventBus, healthcheck.DefaultConcurrency)
+	geoWorker := geoenrich.NewWorker(proxyDAO, newGeoLookuper(), geoip.NewOnlineClient())
+	poolMgr := poolmanager.NewManager(poolDAO, proxyDAO)
+	scoreSweepWorker := scoresweep.NewWorker(proxyDAO)
+	dedupWorker := dedup.NewWorker(proxyDAO)
+
+	applyScorerConfig := func(cfg *config.Config) {
+		sc := scorerConfigFromSettings(cfg)
+		worker.SetScorerConfig(sc)
+		scoreSweepWorker.SetConfig(sc)
+	}
+	applyScorerConfig(configWatcher.Get())
+	configWatcher.OnChange(applyScorerConfig)
+
+	run.RegisterDependency("healthcheck worker", func(context.Context) error {
+		lastSweep := worker.LastSweepAt()
+		if lastSweep.IsZero() {
+			return nil // still waiting on its first sweep
+		}
+		if age := time.Since(lastSweep); age > 2*SweepInterval {
+			return fmt.Errorf("no sweep completed in %s", age.Round(time.Second))
+		}
+		return nil
+	})
+
+	run.Go(func(ctx context.Context) { worker.Run(ctx, SweepInterval) })
+	run.Go(func(ctx context.Context) { geoWorker.Run(ctx, GeoEnrichInterval) })
+	run.Go(func(ctx context.Context) { poolMgr.Run(ctx, PoolManagerInterval) })
+	run.Go(func(ctx context.Context) { scoreSweepWorker.Run(ctx, ScoreSweepInterval) })
+	run.Go(func(ctx context.Context) { dedupWorker.Run(ctx, DedupInterval) })
+
+	grpcAddr := os.Getenv("PROXY_POOL_GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = ":9083"
+	}
+	grpcLn, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		log.Fatalf("proxy-pool: failed to listen for grpc on %s: %v", grpcAddr, err)
+	}
+	grpcServer := grpc.NewServer(tracing.ServerOption())
+	proxypoolv1.RegisterProxyPoolServiceServer(grpcServer, grpcserver.New(proxyDAO, healthCheckDAO, hotZSet, domainScore, regionLatency))
+	run.OnShutdown("grpc server", server.GRPCCloser(grpcServer))
+	go func() {
+		log.Printf("proxy-pool: grpc listening on %s", grpcAddr)
+		if err := grpcServer.Serve(grpcLn); err != nil {
+			log.Fatalf("proxy-pool: grpc server failed: %v", err)
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/readyz", run.ReadyHandler())
+	mux.HandleFunc("/openapi.json", openapi.Handler(openapispec.Build()))
+	mux.HandleFunc("/docs", openapi.UIHandler("/openapi.json"))
+
+	addr := os.Getenv("PROXY_POOL_ADDR")
+	if addr == "" {
+		addr = ":8083"
+	}
+	httpServer := &http.Server{Addr: addr, Handler: mux}
+	run.OnShutdown("http server", server.HTTPCloser(httpServer))
+
+	go func() {
+		log.Printf("proxy-pool: listening on %s", addr)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("proxy-pool: server failed: %v", err)
+		}
+	}()
+
+	run.Wait()
+}
@@ -0,0 +1,88 @@
+// Package dedup runs periodic sweeps that find proxies from different
+// providers that are actually the same exit, so selection and admin
+// reporting can tell resold inventory apart from genuinely independent
+// capacity.
+package dedup
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+)
+
+// Worker groups proxies by the exit IP recorded on them (see
+// validator.AnonymityDetector.ExitIP, which the health-check worker
+// calls on every probe) and flags every member but the best-scoring one
+// in each cross-provider group as a duplicate of it.
+type Worker struct {
+	proxyDAO *dao.ProxyDAO
+}
+
+// NewWorker builds a Worker.
+func NewWorker(proxyDAO *dao.ProxyDAO) *Worker {
+	return &Worker{proxyDAO: proxyDAO}
+}
+
+// Run sweeps every `interval` until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.RunOnce(ctx); err != nil {
+				log.Printf("dedup: sweep failed: %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce re-derives every cross-provider exit-IP group and flags
+// duplicates within it, keeping the highest-scoring member of each group
+// as the canonical, unflagged proxy. It blocks until the sweep
+// completes.
+func (w *Worker) RunOnce(ctx context.Context) error {
+	groups, err := w.proxyDAO.GroupByExitIP(ctx)
+	if err != nil {
+		return err
+	}
+
+	flagged := 0
+	for _, group := range groups {
+		canonical := pickCanonical(group.Members)
+		for _, m := range group.Members {
+			if m.ID == canonical.ID {
+				if err := w.proxyDAO.ClearDuplicate(ctx, m.ID); err != nil {
+					log.Printf("dedup: failed to clear duplicate flag for canonical proxy %s: %v", m.ID, err)
+				}
+				continue
+			}
+			if err := w.proxyDAO.FlagDuplicate(ctx, m.ID, canonical.ID); err != nil {
+				log.Printf("dedup: failed to flag proxy %s as a duplicate of %s: %v", m.ID, canonical.ID, err)
+				continue
+			}
+			flagged++
+		}
+	}
+
+	log.Printf("dedup: sweep complete, flagged %d duplicates across %d exit IPs", flagged, len(groups))
+	return nil
+}
+
+// pickCanonical returns the member of an exit-IP group to keep as the
+// unflagged, canonical proxy: the highest-scoring one, since that's the
+// one selection would otherwise have picked anyway.
+func pickCanonical(members []dao.ExitIPMember) dao.ExitIPMember {
+	best := members[0]
+	for _, m := range members[1:] {
+		if m.Score > best.Score {
+			best = m
+		}
+	}
+	return best
+}
@@ -0,0 +1,29 @@
+package dedup
+
+import (
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+)
+
+func TestPickCanonical(t *testing.T) {
+	members := []dao.ExitIPMember{
+		{ID: "a", Provider: "provider-a", Score: 0.4},
+		{ID: "b", Provider: "provider-b", Score: 0.9},
+		{ID: "c", Provider: "provider-c", Score: 0.7},
+	}
+
+	got := pickCanonical(members)
+	if got.ID != "b" {
+		t.Errorf("pickCanonical() = %+v, want member b (highest score)", got)
+	}
+}
+
+func TestPickCanonicalSingleMember(t *testing.T) {
+	members := []dao.ExitIPMember{{ID: "only", Provider: "provider-a", Score: 0.1}}
+
+	got := pickCanonical(members)
+	if got.ID != "only" {
+		t.Errorf("pickCanonical() = %+v, want the only member", got)
+	}
+}
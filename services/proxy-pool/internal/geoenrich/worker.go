@@ -0,0 +1,99 @@
+// Package geoenrich runs periodic sweeps that backfill proxies with
+// GeoIP data (country, city, ASN, ISP) as they're discovered by the
+// crawler or imported from commercial providers.
+package geoenrich
+
+import (
+	"context"
+	"log"
+	"net"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/geoip"
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// Worker sweeps proxies missing geo data and enriches them, preferring a
+// local MaxMind database and falling back to an online lookup when the
+// primary source has no record (or isn't configured).
+type Worker struct {
+	proxyDAO *dao.ProxyDAO
+	primary  geoip.Lookuper // local MaxMind reader; may be nil
+	fallback geoip.Lookuper // online lookup; may be nil
+}
+
+// NewWorker builds a Worker. Either primary or fallback may be nil, but
+// not both.
+func NewWorker(proxyDAO *dao.ProxyDAO, primary, fallback geoip.Lookuper) *Worker {
+	return &Worker{proxyDAO: proxyDAO, primary: primary, fallback: fallback}
+}
+
+// Run sweeps every `interval` until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.RunOnce(ctx); err != nil {
+				log.Printf("geoenrich: sweep failed: %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce enriches every proxy currently missing geo data and blocks
+// until the sweep completes.
+func (w *Worker) RunOnce(ctx context.Context) error {
+	proxies, err := w.proxyDAO.ListMissingGeo(ctx)
+	if err != nil {
+		return err
+	}
+
+	enriched := 0
+	for _, p := range proxies {
+		if w.enrichOne(ctx, p) {
+			enriched++
+		}
+	}
+
+	log.Printf("geoenrich: sweep complete, enriched %d/%d proxies", enriched, len(proxies))
+	return nil
+}
+
+func (w *Worker) enrichOne(ctx context.Context, p *proxy.Proxy) bool {
+	ip := net.ParseIP(p.Host)
+	if ip == nil {
+		// p.Host is a hostname rather than a bare IP; not enrichable by
+		// IP-based GeoIP lookup.
+		return false
+	}
+
+	rec, err := w.lookup(ctx, ip)
+	if err != nil {
+		log.Printf("geoenrich: lookup failed for proxy %s (%s): %v", p.ID, p.Host, err)
+		return false
+	}
+
+	if err := w.proxyDAO.UpdateGeo(ctx, p.ID, rec.CountryCode, rec.City, rec.ASN); err != nil {
+		log.Printf("geoenrich: failed to persist geo for proxy %s: %v", p.ID, err)
+		return false
+	}
+	return true
+}
+
+func (w *Worker) lookup(ctx context.Context, ip net.IP) (geoip.Record, error) {
+	if w.primary != nil {
+		if rec, err := w.primary.Lookup(ctx, ip); err == nil {
+			return rec, nil
+		}
+	}
+	if w.fallback != nil {
+		return w.fallback.Lookup(ctx, ip)
+	}
+	return geoip.Record{}, geoip.ErrNotFound
+}
@@ -0,0 +1,288 @@
+// Package grpcserver implements proxy-pool's internal gRPC API: the
+// ProxyPoolService other services call instead of querying MySQL or the
+// Redis hot pool directly.
+package grpcserver
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	proxypoolv1 "github.com/XXXXD-cation/proxy-platform/pkg/rpc/proxypoolv1"
+	"github.com/XXXXD-cation/proxy-platform/pkg/tracing"
+)
+
+// probationSampleRate is the fraction of non-pinned, non-domain-proven
+// Acquire calls that are steered to a probationary proxy instead of the
+// normal hot pool, so newly crawled proxies accumulate a real traffic
+// track record without ever being a paying caller's only option.
+const probationSampleRate = 0.02
+
+// Server implements proxypoolv1.ProxyPoolServiceServer.
+type Server struct {
+	proxypoolv1.UnimplementedProxyPoolServiceServer
+
+	proxyDAO       *dao.ProxyDAO
+	healthCheckDAO *dao.ProxyHealthCheckDAO
+	hotZSet        *redis.HotZSet
+	domainScore    *redis.DomainScore
+	regionLatency  *redis.RegionLatency
+}
+
+// New wraps the same store and hot pool the HTTP-facing parts of
+// proxy-pool already use.
+func New(proxyDAO *dao.ProxyDAO, healthCheckDAO *dao.ProxyHealthCheckDAO, hotZSet *redis.HotZSet, domainScore *redis.DomainScore, regionLatency *redis.RegionLatency) *Server {
+	return &Server{proxyDAO: proxyDAO, healthCheckDAO: healthCheckDAO, hotZSet: hotZSet, domainScore: domainScore, regionLatency: regionLatency}
+}
+
+// Acquire selects a proxy matching req's criteria. A pinned_id is tried
+// first so callers can honor sticky sessions without needing their own
+// access to the hot pool; a target_domain next, so a proxy already
+// proven against that site is preferred even if it isn't the top
+// general-purpose pick; a small, random slice of ordinary requests is
+// then steered to a probationary proxy instead (see
+// tryProbationSample), so new proxies build a track record; city/ASN
+// granularity and exclude_blacklisted aren't represented in the hot
+// pool's ZSET buckets, so those requests go straight to MySQL's
+// geo-indexed query, and everything else is hot-pool-first with a MySQL
+// fallback, ranked by gateway_id's own latency vantage point when a
+// caller provides one (see tryRegionBest) and at random otherwise.
+// Every path but probation sampling excludes probationary proxies.
+func (s *Server) Acquire(ctx context.Context, req *proxypoolv1.AcquireRequest) (*proxypoolv1.AcquireResponse, error) {
+	if req.PinnedId != "" {
+		if p, ok := s.tryPinned(ctx, req); ok {
+			return &proxypoolv1.AcquireResponse{Proxy: toProto(p)}, nil
+		}
+	}
+
+	p, err := s.pick(ctx, req)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if p == nil {
+		return nil, status.Error(codes.NotFound, "no upstream proxies available")
+	}
+	return &proxypoolv1.AcquireResponse{Proxy: toProto(p)}, nil
+}
+
+// tryPinned returns the pinned proxy if it's still hot and matches req's
+// geo criteria, mirroring the sticky-session check the gateway used to
+// do itself against the hot pool directly before this lookup moved here.
+func (s *Server) tryPinned(ctx context.Context, req *proxypoolv1.AcquireRequest) (*proxy.Proxy, bool) {
+	p, err := s.hotZSet.GetProxy(ctx, req.PinnedId)
+	if err != nil {
+		return nil, false
+	}
+	if !matchesGeo(p, req) {
+		return nil, false
+	}
+	if req.ExcludeBlacklisted && p.Blacklisted {
+		return nil, false
+	}
+	return p, true
+}
+
+// tryDomainProven returns the proxy with the best recorded track record
+// against req's target domain, if one exists, is still hot, and still
+// matches req's other criteria. A proxy that's generally healthy can
+// still be blocked by a specific site, so this is checked ahead of the
+// usual geo/hot-pool selection whenever a caller names a target domain.
+func (s *Server) tryDomainProven(ctx context.Context, req *proxypoolv1.AcquireRequest) (*proxy.Proxy, bool) {
+	id, err := s.domainScore.Best(ctx, req.TargetDomain)
+	if err != nil {
+		return nil, false
+	}
+	p, err := s.hotZSet.GetProxy(ctx, id)
+	if err != nil {
+		return nil, false
+	}
+	if !matchesGeo(p, req) {
+		return nil, false
+	}
+	if req.Protocol != "" && !strings.EqualFold(string(p.Protocol), req.Protocol) {
+		return nil, false
+	}
+	if req.ExcludeBlacklisted && p.Blacklisted {
+		return nil, false
+	}
+	return p, true
+}
+
+// matchesGeo reports whether p satisfies every geo criterion req sets.
+func matchesGeo(p *proxy.Proxy, req *proxypoolv1.AcquireRequest) bool {
+	if req.Country != "" && !strings.EqualFold(p.Country, req.Country) {
+		return false
+	}
+	if req.City != "" && !strings.EqualFold(p.City, req.City) {
+		return false
+	}
+	if req.Asn != 0 && p.ASN != int(req.Asn) {
+		return false
+	}
+	return true
+}
+
+func (s *Server) pick(ctx context.Context, req *proxypoolv1.AcquireRequest) (*proxy.Proxy, error) {
+	if req.TargetDomain != "" {
+		if p, ok := s.tryDomainProven(ctx, req); ok {
+			return p, nil
+		}
+	}
+
+	if p, ok := s.tryProbationSample(ctx, req); ok {
+		return p, nil
+	}
+
+	if req.City != "" || req.Asn != 0 || req.ExcludeBlacklisted {
+		spanCtx, end := tracing.DBSpan(ctx, "proxy.get_by_geo")
+		results, err := s.proxyDAO.GetByGeo(spanCtx, dao.GeoFilter{
+			Country:            req.Country,
+			City:               req.City,
+			ASN:                int(req.Asn),
+			MinScore:           req.MinScore,
+			ExcludeProbation:   true,
+			ExcludeBlacklisted: req.ExcludeBlacklisted,
+			Limit:              1,
+		})
+		end(err)
+		if err != nil || len(results) == 0 {
+			return nil, err
+		}
+		return results[0], nil
+	}
+
+	protocol := proxy.Protocol(req.Protocol)
+	if req.GatewayId != "" {
+		if p, ok := s.tryRegionBest(ctx, req, protocol); ok {
+			return p, nil
+		}
+	}
+	if p, err := s.hotZSet.PickAbove(ctx, req.Country, protocol, req.MinScore); err == nil {
+		return p, nil
+	}
+
+	spanCtx, end := tracing.DBSpan(ctx, "proxy.search")
+	results, _, _, err := s.proxyDAO.Search(spanCtx, dao.ProxyFilter{
+		Status:           proxy.StatusHealthy,
+		Country:          req.Country,
+		Protocol:         protocol,
+		MinScore:         req.MinScore,
+		ExcludeProbation: true,
+		Limit:            1,
+	})
+	end(err)
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// tryRegionBest ranks the same (country, protocol, min_score) hot-pool
+// bucket PickAbove would otherwise draw from at random by latency
+// observed from req.GatewayId's own vantage point, so a multi-region
+// deployment's gateways each prefer what's actually close to them. It
+// reports ok == false if the bucket is empty or none of its members
+// have a recorded latency from that gateway yet, leaving the caller to
+// fall back to PickAbove's random pick.
+func (s *Server) tryRegionBest(ctx context.Context, req *proxypoolv1.AcquireRequest, protocol proxy.Protocol) (*proxy.Proxy, bool) {
+	candidates, err := s.hotZSet.CandidatesAbove(ctx, req.Country, protocol, req.MinScore)
+	if err != nil || len(candidates) == 0 {
+		return nil, false
+	}
+	id, err := s.regionLatency.BestOf(ctx, req.GatewayId, candidates)
+	if err != nil {
+		return nil, false
+	}
+	p, err := s.hotZSet.GetProxy(ctx, id)
+	if err != nil {
+		return nil, false
+	}
+	return p, true
+}
+
+// tryProbationSample occasionally (probationSampleRate of the time)
+// steers a request to a probationary proxy instead of the normal pool,
+// so proxies still earning trust see real traffic to graduate on. It's
+// skipped for pinned/domain-proven lookups (those have a specific
+// upstream in mind) and for geo/exclude-blacklisted requests, which a
+// probationary proxy's thinner track record shouldn't be trusted to
+// satisfy.
+func (s *Server) tryProbationSample(ctx context.Context, req *proxypoolv1.AcquireRequest) (*proxy.Proxy, bool) {
+	if req.City != "" || req.Asn != 0 || req.ExcludeBlacklisted || rand.Float64() >= probationSampleRate {
+		return nil, false
+	}
+
+	spanCtx, end := tracing.DBSpan(ctx, "proxy.probation_sample")
+	results, _, _, err := s.proxyDAO.Search(spanCtx, dao.ProxyFilter{
+		Status:   proxy.StatusHealthy,
+		Country:  req.Country,
+		Protocol: proxy.Protocol(req.Protocol),
+		Stage:    proxy.StageProbation,
+		Limit:    1,
+	})
+	end(err)
+	if err != nil || len(results) == 0 {
+		return nil, false
+	}
+	return results[0], true
+}
+
+// Release signals that a caller is done with a proxy it acquired. The
+// pool doesn't track exclusive leases today, so this is currently a
+// no-op hook kept so a future reference-counting scheme doesn't require
+// an API change.
+func (s *Server) Release(ctx context.Context, req *proxypoolv1.ReleaseRequest) (*proxypoolv1.ReleaseResponse, error) {
+	return &proxypoolv1.ReleaseResponse{}, nil
+}
+
+// Report records the outcome of a single use of a proxy, the same way
+// the periodic health-check sweep does, so live traffic feeds back into
+// a proxy's health between sweeps.
+func (s *Server) Report(ctx context.Context, req *proxypoolv1.ReportRequest) (*proxypoolv1.ReportResponse, error) {
+	if err := s.proxyDAO.UpdateLatency(ctx, req.ProxyId, int(req.LatencyMs)); err != nil {
+		return nil, status.Errorf(codes.Internal, "update latency: %v", err)
+	}
+	if _, err := s.proxyDAO.MarkAsChecked(ctx, req.ProxyId, req.Success, time.Now().UTC()); err != nil {
+		return nil, status.Errorf(codes.Internal, "mark checked: %v", err)
+	}
+	if req.TargetDomain != "" {
+		if err := s.domainScore.RecordOutcome(ctx, req.TargetDomain, req.ProxyId, req.Success); err != nil {
+			return nil, status.Errorf(codes.Internal, "record domain outcome: %v", err)
+		}
+	}
+	if req.GatewayId != "" {
+		if err := s.regionLatency.Record(ctx, req.GatewayId, req.ProxyId, int(req.LatencyMs)); err != nil {
+			return nil, status.Errorf(codes.Internal, "record region latency: %v", err)
+		}
+		if err := s.healthCheckDAO.Insert(ctx, &dao.ProxyHealthCheck{
+			ProxyID:   req.ProxyId,
+			GatewayID: req.GatewayId,
+			Success:   req.Success,
+			LatencyMS: int(req.LatencyMs),
+			CheckedAt: time.Now().UTC(),
+		}); err != nil {
+			return nil, status.Errorf(codes.Internal, "record region health check: %v", err)
+		}
+	}
+	return &proxypoolv1.ReportResponse{}, nil
+}
+
+func toProto(p *proxy.Proxy) *proxypoolv1.Proxy {
+	return &proxypoolv1.Proxy{
+		Id:       p.ID,
+		Host:     p.Host,
+		Port:     int32(p.Port),
+		Protocol: string(p.Protocol),
+		Country:  p.Country,
+		City:     p.City,
+		Asn:      int32(p.ASN),
+		Score:    p.Score,
+	}
+}
@@ -0,0 +1,32 @@
+package grpcserver
+
+import (
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	proxypoolv1 "github.com/XXXXD-cation/proxy-platform/pkg/rpc/proxypoolv1"
+)
+
+func TestMatchesGeo(t *testing.T) {
+	p := &proxy.Proxy{Country: "DE", City: "Berlin", ASN: 3320}
+
+	cases := []struct {
+		name string
+		req  *proxypoolv1.AcquireRequest
+		want bool
+	}{
+		{"empty request matches anything", &proxypoolv1.AcquireRequest{}, true},
+		{"matching country is case-insensitive", &proxypoolv1.AcquireRequest{Country: "de"}, true},
+		{"mismatched country", &proxypoolv1.AcquireRequest{Country: "fr"}, false},
+		{"matching city", &proxypoolv1.AcquireRequest{City: "berlin"}, true},
+		{"mismatched city", &proxypoolv1.AcquireRequest{City: "munich"}, false},
+		{"matching asn", &proxypoolv1.AcquireRequest{Asn: 3320}, true},
+		{"mismatched asn", &proxypoolv1.AcquireRequest{Asn: 1234}, false},
+	}
+
+	for _, c := range cases {
+		if got := matchesGeo(p, c.req); got != c.want {
+			t.Errorf("%s: matchesGeo() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Package healthcheck implements the concurrent probing subsystem that
+// keeps the proxies table's health fields up to date: TCP reachability,
+// an HTTP GET proxied through the candidate, an HTTPS CONNECT tunnel,
+// and a SOCKS4/5 handshake.
+package healthcheck
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/services/proxy-pool/validator"
+)
+
+// DialTimeout bounds every individual probe step (TCP connect, HTTP
+// round trip, CONNECT handshake).
+const DialTimeout = 5 * time.Second
+
+// ProbeTarget is the well-known, low-traffic endpoint probes are made
+// against. It deliberately doesn't depend on any of the platform's own
+// infrastructure being reachable.
+const ProbeTarget = "https://www.google.com/generate_204"
+
+// socksValidator handles the SOCKS4/5 handshake check for
+// MultiProbeChecker; it's the only part of the full validator library
+// the routine sweep needs; deeper integrity/latency-percentile checks
+// in validator.Validator are for on-demand validation instead.
+var socksValidator = validator.NewValidator(validator.Config{Timeout: DialTimeout})
+
+// MultiProbeChecker runs escalating checks against a proxy: a raw TCP
+// connect, then an HTTP GET plus HTTPS CONNECT tunnel for HTTP/HTTPS
+// proxies or a SOCKS4/5 handshake for SOCKS proxies. All steps for the
+// proxy's protocol must succeed for it to be considered healthy. It
+// satisfies pkg/health.Checker.
+type MultiProbeChecker struct{}
+
+// Check implements health.Checker.
+func (MultiProbeChecker) Check(ctx context.Context, p *proxy.Proxy) error {
+	if err := tcpConnect(ctx, p); err != nil {
+		return fmt.Errorf("healthcheck: tcp connect: %w", err)
+	}
+
+	switch p.Protocol {
+	case proxy.ProtocolHTTP, proxy.ProtocolHTTPS:
+		if err := httpGetThrough(ctx, p); err != nil {
+			return fmt.Errorf("healthcheck: http get: %w", err)
+		}
+		if err := httpsConnectThrough(ctx, p); err != nil {
+			return fmt.Errorf("healthcheck: https connect: %w", err)
+		}
+	case proxy.ProtocolSOCKS4, proxy.ProtocolSOCKS5:
+		if err := socksValidator.Check(ctx, p); err != nil {
+			return fmt.Errorf("healthcheck: socks handshake: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func tcpConnect(ctx context.Context, p *proxy.Proxy) error {
+	dialer := net.Dialer{Timeout: DialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.Addr())
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func httpGetThrough(ctx context.Context, p *proxy.Proxy) error {
+	client, err := clientThrough(p)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ProbeTarget, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("upstream returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func httpsConnectThrough(ctx context.Context, p *proxy.Proxy) error {
+	dialer := net.Dialer{Timeout: DialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.Addr())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	const target = "www.google.com:443"
+	fmt.Fprintf(conn, "CONNECT %s HTTP/1.1\r\nHost: %s\r\n\r\n", target, target)
+
+	conn.SetDeadline(time.Now().Add(DialTimeout))
+	resp, err := http.ReadResponse(bufio.NewReader(conn), &http.Request{Method: http.MethodConnect})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("CONNECT refused with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func clientThrough(p *proxy.Proxy) (*http.Client, error) {
+	proxyURL, err := url.Parse(fmt.Sprintf("http://%s", p.Addr()))
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Timeout: DialTimeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyURL(proxyURL),
+		},
+	}, nil
+}
@@ -0,0 +1,67 @@
+package healthcheck
+
+import (
+	"fmt"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// probationGraduateChecks is how many consecutive successful checks a
+// probationary proxy needs before it's trusted with unrestricted
+// traffic.
+const probationGraduateChecks = 10
+
+// probationFailureLimit is how many consecutive failures a probationary
+// proxy is allowed before it's retired outright: a proxy that can't even
+// get through its trial period isn't worth holding onto at arm's length.
+const probationFailureLimit = 2
+
+// activeDegradeFailures is how many consecutive failures knock an
+// active proxy down to degraded rather than retiring it immediately; a
+// proven proxy gets the benefit of the doubt that a probationary one
+// doesn't.
+const activeDegradeFailures = 3
+
+// degradedRetireFailures is how many consecutive failures, counted from
+// the point a proxy entered StageDegraded, before it's retired.
+const degradedRetireFailures = 5
+
+// nextStage derives p's lifecycle stage from its post-check state:
+// current stage, whether the check just run succeeded, its consecutive
+// failure streak (as MarkAsChecked just computed it) and, while on
+// probation, its consecutive-success streak (as IncrementProbationChecks
+// just computed it). It returns the unchanged stage and an empty reason
+// when nothing should move, so callers can skip the DB write entirely on
+// the (common) no-op case. StageRetired is terminal: once retired, a
+// proxy only leaves that stage via manual operator action, not this
+// function.
+func nextStage(current proxy.Stage, success bool, consecutiveFailures, probationChecks int) (proxy.Stage, string) {
+	switch current {
+	case proxy.StageProbation:
+		if consecutiveFailures >= probationFailureLimit {
+			return proxy.StageRetired, fmt.Sprintf("failed %d consecutive checks during probation", consecutiveFailures)
+		}
+		if probationChecks >= probationGraduateChecks {
+			return proxy.StageActive, fmt.Sprintf("passed %d consecutive checks during probation", probationChecks)
+		}
+		return current, ""
+
+	case proxy.StageActive:
+		if consecutiveFailures >= activeDegradeFailures {
+			return proxy.StageDegraded, fmt.Sprintf("failed %d consecutive checks", consecutiveFailures)
+		}
+		return current, ""
+
+	case proxy.StageDegraded:
+		if consecutiveFailures >= degradedRetireFailures {
+			return proxy.StageRetired, fmt.Sprintf("failed %d consecutive checks while degraded", consecutiveFailures)
+		}
+		if success && consecutiveFailures == 0 {
+			return proxy.StageActive, "recovered from a degraded run"
+		}
+		return current, ""
+
+	default: // StageRetired, or an unrecognized stage: leave it alone
+		return current, ""
+	}
+}
@@ -0,0 +1,80 @@
+package healthcheck
+
+import (
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+func TestNextStage(t *testing.T) {
+	tests := []struct {
+		name                string
+		current             proxy.Stage
+		success             bool
+		consecutiveFailures int
+		probationChecks     int
+		want                proxy.Stage
+		wantMoved           bool
+	}{
+		{
+			name:    "probation with too few checks stays put",
+			current: proxy.StageProbation,
+			success: true, probationChecks: probationGraduateChecks - 1,
+			want: proxy.StageProbation, wantMoved: false,
+		},
+		{
+			name:    "probation graduates once it earns enough checks",
+			current: proxy.StageProbation,
+			success: true, probationChecks: probationGraduateChecks,
+			want: proxy.StageActive, wantMoved: true,
+		},
+		{
+			name:    "probation retires after too many failures",
+			current: proxy.StageProbation,
+			success: false, consecutiveFailures: probationFailureLimit,
+			want: proxy.StageRetired, wantMoved: true,
+		},
+		{
+			name:    "active proxy tolerates an isolated failure",
+			current: proxy.StageActive,
+			success: false, consecutiveFailures: activeDegradeFailures - 1,
+			want: proxy.StageActive, wantMoved: false,
+		},
+		{
+			name:    "active proxy degrades after a failure streak",
+			current: proxy.StageActive,
+			success: false, consecutiveFailures: activeDegradeFailures,
+			want: proxy.StageDegraded, wantMoved: true,
+		},
+		{
+			name:    "degraded proxy recovers on a clean check",
+			current: proxy.StageDegraded,
+			success: true, consecutiveFailures: 0,
+			want: proxy.StageActive, wantMoved: true,
+		},
+		{
+			name:    "degraded proxy retires after enough failures",
+			current: proxy.StageDegraded,
+			success: false, consecutiveFailures: degradedRetireFailures,
+			want: proxy.StageRetired, wantMoved: true,
+		},
+		{
+			name:    "retired proxy never moves on its own",
+			current: proxy.StageRetired,
+			success: false, consecutiveFailures: 100,
+			want: proxy.StageRetired, wantMoved: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := nextStage(tt.current, tt.success, tt.consecutiveFailures, tt.probationChecks)
+			if got != tt.want {
+				t.Errorf("nextStage() stage = %v, want %v", got, tt.want)
+			}
+			if moved := reason != ""; moved != tt.wantMoved {
+				t.Errorf("nextStage() reason = %q, wantMoved %v", reason, tt.wantMoved)
+			}
+		})
+	}
+}
@@ -0,0 +1,412 @@
+package healthcheck
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/eventbus"
+	"github.com/XXXXD-cation/proxy-platform/pkg/health"
+	"github.com/XXXXD-cation/proxy-platform/pkg/pool"
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+	"github.com/XXXXD-cation/proxy-platform/pkg/redis"
+	"github.com/XXXXD-cation/proxy-platform/services/proxy-pool/internal/scorer"
+	"github.com/XXXXD-cation/proxy-platform/services/proxy-pool/reputation"
+	"github.com/XXXXD-cation/proxy-platform/services/proxy-pool/validator"
+)
+
+// poolPriorityWeight scales a pool's Priority into a hot-pool score
+// boost: each priority point shifts a member ahead of proxies up to
+// this many score points better outside any pool, without letting a
+// merely-decent pool proxy leapfrog a dramatically healthier
+// unassigned one.
+const poolPriorityWeight = 0.01
+
+// DefaultConcurrency bounds how many proxies are probed at once, keeping
+// a single sweep from opening thousands of sockets simultaneously.
+const DefaultConcurrency = 50
+
+// Worker runs periodic sweeps that concurrently probe every active proxy
+// and persist the results.
+type Worker struct {
+	checker           health.Checker
+	proxyDAO          *dao.ProxyDAO
+	healthCheckDAO    *dao.ProxyHealthCheckDAO
+	poolDAO           *pool.DAO
+	anonymityDetector *validator.AnonymityDetector
+	reputationChecker *reputation.Checker
+	redisClient       goredis.UniversalClient
+	hotZSet           *redis.HotZSet
+	latencyHistogram  *redis.LatencyHistogram
+	events            eventbus.Publisher
+	concurrency       int
+
+	poolPriorityMu sync.RWMutex
+	poolPriority   map[string]int // pool ID -> Priority, refreshed once per sweep
+
+	scorerConfig atomic.Pointer[scorer.Config] // live, operator-tunable; defaults to scorer.DefaultConfig()
+
+	lastSweep atomic.Int64 // unix nano of the last completed sweep, 0 until Run's first tick fires
+}
+
+// NewWorker builds a Worker. A concurrency <= 0 uses DefaultConcurrency.
+// redisClient may be nil, in which case anonymity detection still runs
+// and is persisted to MySQL but the live Redis breakdown and hot pool
+// are skipped. poolDAO may be nil, in which case pool membership has no
+// effect on scheduling. events, if non-nil, is notified with an
+// eventbus.EventProxyDeactivated event whenever a sweep deactivates a
+// proxy; a nil events disables that.
+func NewWorker(checker health.Checker, proxyDAO *dao.ProxyDAO, healthCheckDAO *dao.ProxyHealthCheckDAO, poolDAO *pool.DAO, redisClient goredis.UniversalClient, events eventbus.Publisher, concurrency int) *Worker {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	w := &Worker{
+		checker:           checker,
+		proxyDAO:          proxyDAO,
+		healthCheckDAO:    healthCheckDAO,
+		poolDAO:           poolDAO,
+		anonymityDetector: &validator.AnonymityDetector{},
+		reputationChecker: reputation.NewChecker(reputation.Config{}),
+		redisClient:       redisClient,
+		events:            events,
+		concurrency:       concurrency,
+	}
+	if redisClient != nil {
+		w.hotZSet = redis.NewHotZSet(redisClient)
+		w.latencyHistogram = redis.NewLatencyHistogram(redisClient)
+	}
+	defaultScorerConfig := scorer.DefaultConfig()
+	w.scorerConfig.Store(&defaultScorerConfig)
+	return w
+}
+
+// SetScorerConfig replaces the weights and thresholds used to score
+// every subsequently-probed proxy. It's safe to call concurrently with
+// a running sweep; an operator's config reload takes effect starting
+// with the next proxy probed, not mid-sweep.
+func (w *Worker) SetScorerConfig(cfg scorer.Config) {
+	w.scorerConfig.Store(&cfg)
+}
+
+// Run sweeps every `interval` until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.RunOnce(ctx); err != nil {
+				log.Printf("healthcheck: sweep failed: %v", err)
+			}
+			w.lastSweep.Store(time.Now().UnixNano())
+		}
+	}
+}
+
+// LastSweepAt reports when the most recent sweep finished (successfully
+// or not), or the zero time if Run hasn't completed a sweep yet. Use it
+// to back a readiness check for the worker's liveness.
+func (w *Worker) LastSweepAt() time.Time {
+	ns := w.lastSweep.Load()
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+// RunOnce probes every active proxy once, fanning out across
+// w.concurrency workers, and blocks until the sweep completes.
+func (w *Worker) RunOnce(ctx context.Context) error {
+	proxies, err := w.proxyDAO.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.refreshPoolPriority(ctx)
+
+	jobs := make(chan *proxy.Proxy)
+	var wg sync.WaitGroup
+	for i := 0; i < w.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				w.probeOne(ctx, p)
+			}
+		}()
+	}
+
+	for _, p := range proxies {
+		jobs <- p
+	}
+	close(jobs)
+	wg.Wait()
+
+	log.Printf("healthcheck: sweep complete, probed %d proxies", len(proxies))
+	return nil
+}
+
+func (w *Worker) probeOne(ctx context.Context, p *proxy.Proxy) {
+	start := time.Now()
+	checkErr := w.checker.Check(ctx, p)
+	latency := time.Since(start)
+	now := time.Now().UTC()
+	success := checkErr == nil
+
+	errMsg := ""
+	if checkErr != nil {
+		errMsg = checkErr.Error()
+	}
+	if err := w.healthCheckDAO.Insert(ctx, &dao.ProxyHealthCheck{
+		ProxyID:   p.ID,
+		Protocol:  string(p.Protocol),
+		Success:   success,
+		LatencyMS: int(latency.Milliseconds()),
+		Error:     errMsg,
+		CheckedAt: now,
+	}); err != nil {
+		log.Printf("healthcheck: failed to record check for proxy %s: %v", p.ID, err)
+	}
+
+	if err := w.proxyDAO.UpdateLatency(ctx, p.ID, int(latency.Milliseconds())); err != nil {
+		log.Printf("healthcheck: failed to update latency for proxy %s: %v", p.ID, err)
+	}
+
+	deactivated, err := w.proxyDAO.MarkAsChecked(ctx, p.ID, success, now)
+	if err != nil {
+		log.Printf("healthcheck: failed to mark proxy %s checked: %v", p.ID, err)
+		return
+	}
+	if deactivated {
+		p.Status = proxy.StatusDead
+		p.ConsecutiveFailures = dao.MaxConsecutiveFailures
+		log.Printf("healthcheck: proxy %s deactivated after %d consecutive failures", p.ID, dao.MaxConsecutiveFailures)
+		w.publishDeactivated(ctx, p.ID)
+	} else if success {
+		p.Status = proxy.StatusHealthy
+		p.ConsecutiveFailures = 0
+	} else {
+		p.ConsecutiveFailures++
+	}
+
+	w.advanceStage(ctx, p, success)
+
+	rate, err := w.recentSuccessRate(ctx, p.ID)
+	if err != nil {
+		log.Printf("healthcheck: failed to compute success rate for proxy %s: %v", p.ID, err)
+		return
+	}
+	if err := w.proxyDAO.UpdateSuccessRate(ctx, p.ID, rate); err != nil {
+		log.Printf("healthcheck: failed to update success rate for proxy %s: %v", p.ID, err)
+	}
+
+	p.LatencyMS = int(latency.Milliseconds())
+	p.SuccessRate = rate
+	w.recordLatency(ctx, p)
+
+	if success && (p.Protocol == proxy.ProtocolHTTP || p.Protocol == proxy.ProtocolHTTPS) {
+		w.detectAnonymity(ctx, p)
+	}
+
+	if success {
+		w.checkReputation(ctx, p)
+	}
+
+	p.Score = w.scorerConfig.Load().Score(p)
+	if err := w.proxyDAO.UpdateScore(ctx, p.ID, p.Score); err != nil {
+		log.Printf("healthcheck: failed to update score for proxy %s: %v", p.ID, err)
+	}
+
+	w.syncHotZSet(ctx, p, deactivated)
+}
+
+// publishDeactivated notifies w.events, if any, that proxyID was just
+// deactivated. It's best-effort: a publish failure is logged but never
+// fails the sweep itself.
+func (w *Worker) publishDeactivated(ctx context.Context, proxyID string) {
+	if w.events == nil {
+		return
+	}
+	event := eventbus.Event{Type: eventbus.EventProxyDeactivated, Fields: map[string]string{"proxy_id": proxyID}}
+	if err := w.events.Publish(ctx, event); err != nil {
+		log.Printf("healthcheck: failed to publish proxy.deactivated event: %v", err)
+	}
+}
+
+// advanceStage runs p's probation-lifecycle transition for this check's
+// outcome, if any, persisting it (and its history entry) via
+// TransitionStage. While on probation it also tracks the consecutive-
+// success streak nextStage graduates on; proxies past probation don't
+// need that counter, so it's left alone for them.
+func (w *Worker) advanceStage(ctx context.Context, p *proxy.Proxy, success bool) {
+	probationChecks := p.ProbationChecks
+	if p.Stage == proxy.StageProbation {
+		count, err := w.proxyDAO.IncrementProbationChecks(ctx, p.ID, success)
+		if err != nil {
+			log.Printf("healthcheck: failed to update probation checks for proxy %s: %v", p.ID, err)
+		} else {
+			probationChecks = count
+		}
+	}
+	p.ProbationChecks = probationChecks
+
+	to, reason := nextStage(p.Stage, success, p.ConsecutiveFailures, probationChecks)
+	if reason == "" {
+		return
+	}
+	if err := w.proxyDAO.TransitionStage(ctx, p.ID, to, reason); err != nil {
+		log.Printf("healthcheck: failed to transition proxy %s to stage %s: %v", p.ID, to, err)
+		return
+	}
+	log.Printf("healthcheck: proxy %s moved from stage %s to %s: %s", p.ID, p.Stage, to, reason)
+	p.Stage = to
+}
+
+// syncHotZSet keeps the Redis hot pool in step with this check's
+// outcome: a deactivated proxy is evicted immediately, rather than
+// waiting for the next reconciliation pass, and a still-healthy one is
+// re-scored in place, boosted by its pool's scheduling priority, if any.
+// A proxy on probation or retired is also evicted regardless of health:
+// neither is eligible for the hot pool's general-purpose traffic, the
+// former until it graduates and the latter permanently.
+func (w *Worker) syncHotZSet(ctx context.Context, p *proxy.Proxy, deactivated bool) {
+	if w.hotZSet == nil {
+		return
+	}
+
+	if deactivated || p.Status != proxy.StatusHealthy || p.Stage == proxy.StageProbation || p.Stage == proxy.StageRetired {
+		if err := w.hotZSet.RemoveProxy(ctx, p.ID); err != nil {
+			log.Printf("healthcheck: failed to evict proxy %s from hot pool: %v", p.ID, err)
+		}
+		return
+	}
+
+	boost := w.poolPriorityBoost(p.PoolID)
+	if boost == 0 {
+		if err := w.hotZSet.AddProxy(ctx, p); err != nil {
+			log.Printf("healthcheck: failed to refresh proxy %s in hot pool: %v", p.ID, err)
+		}
+		return
+	}
+
+	boosted := *p
+	boosted.Score += boost
+	if err := w.hotZSet.AddProxy(ctx, &boosted); err != nil {
+		log.Printf("healthcheck: failed to refresh proxy %s in hot pool: %v", p.ID, err)
+	}
+}
+
+// recordLatency adds this check's latency to p's Redis histogram and
+// refreshes p.P50LatencyMS/P95LatencyMS from it, so scorer.Config.Score
+// (called right after) sees up-to-date percentiles. A no-op if no Redis
+// client is configured.
+func (w *Worker) recordLatency(ctx context.Context, p *proxy.Proxy) {
+	if w.latencyHistogram == nil {
+		return
+	}
+	if err := w.latencyHistogram.Record(ctx, p.ID, p.LatencyMS); err != nil {
+		log.Printf("healthcheck: failed to record latency for proxy %s: %v", p.ID, err)
+		return
+	}
+
+	p50, p95, err := w.latencyHistogram.Percentiles(ctx, p.ID)
+	if err != nil {
+		log.Printf("healthcheck: failed to read latency percentiles for proxy %s: %v", p.ID, err)
+		return
+	}
+	p.P50LatencyMS = int(p50)
+	p.P95LatencyMS = int(p95)
+}
+
+// refreshPoolPriority reloads the pool ID -> Priority map once per
+// sweep, rather than querying MySQL for every probed proxy.
+func (w *Worker) refreshPoolPriority(ctx context.Context) {
+	if w.poolDAO == nil {
+		return
+	}
+	pools, err := w.poolDAO.List(ctx)
+	if err != nil {
+		log.Printf("healthcheck: failed to load pool priorities: %v", err)
+		return
+	}
+
+	priority := make(map[string]int, len(pools))
+	for _, p := range pools {
+		priority[p.ID] = p.Priority
+	}
+
+	w.poolPriorityMu.Lock()
+	w.poolPriority = priority
+	w.poolPriorityMu.Unlock()
+}
+
+// poolPriorityBoost returns the hot-pool score boost for a proxy
+// assigned to poolID, 0 if it isn't assigned to a known pool.
+func (w *Worker) poolPriorityBoost(poolID string) float64 {
+	if poolID == "" {
+		return 0
+	}
+	w.poolPriorityMu.RLock()
+	defer w.poolPriorityMu.RUnlock()
+	return float64(w.poolPriority[poolID]) * poolPriorityWeight
+}
+
+// detectAnonymity probes p's anonymity level and, off that same judge-
+// endpoint round trip, its exit IP, so the dedup worker can later
+// correlate exit IPs across providers without probing again itself.
+func (w *Worker) detectAnonymity(ctx context.Context, p *proxy.Proxy) {
+	level, exitIP, err := w.anonymityDetector.DetectWithExitIP(ctx, p, "")
+	if err != nil {
+		log.Printf("healthcheck: anonymity detection failed for proxy %s: %v", p.ID, err)
+		return
+	}
+	p.AnonymityLevel = level
+	p.ExitIP = exitIP
+
+	if err := w.proxyDAO.UpdateAnonymityLevel(ctx, p.ID, level); err != nil {
+		log.Printf("healthcheck: failed to update anonymity level for proxy %s: %v", p.ID, err)
+	}
+	if err := w.proxyDAO.UpdateExitIP(ctx, p.ID, exitIP); err != nil {
+		log.Printf("healthcheck: failed to update exit IP for proxy %s: %v", p.ID, err)
+	}
+	if w.redisClient != nil {
+		if err := redis.IncrAnonymityLevel(ctx, w.redisClient, level); err != nil {
+			log.Printf("healthcheck: failed to record anonymity metric for proxy %s: %v", p.ID, err)
+		}
+	}
+}
+
+// checkReputation queries DNSBL zones (and an optional reputation API) for
+// p's host and persists the result. A proxy only needs re-checking every
+// so often, not on every sweep, but until a dedicated schedule lands this
+// simply runs alongside the regular health probe.
+func (w *Worker) checkReputation(ctx context.Context, p *proxy.Proxy) {
+	result := w.reputationChecker.Check(ctx, p.Host)
+	p.ReputationScore = result.Score
+	p.Blacklisted = result.Flagged
+
+	if err := w.proxyDAO.UpdateReputation(ctx, p.ID, result.Score, result.Flagged); err != nil {
+		log.Printf("healthcheck: failed to update reputation for proxy %s: %v", p.ID, err)
+	}
+}
+
+// recentSuccessRate is a placeholder until request-driven rolling windows
+// land; today it simply reflects the outcome of the most recent check.
+func (w *Worker) recentSuccessRate(ctx context.Context, proxyID string) (float64, error) {
+	p, err := w.proxyDAO.Get(ctx, proxyID)
+	if err != nil {
+		return 0, err
+	}
+	if p.Status == proxy.StatusHealthy {
+		return 1, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,22 @@
+// Package openapispec documents proxy-pool's HTTP surface as an
+// OpenAPI 3 document, served at /openapi.json (and rendered at /docs).
+// proxy-pool's real API is proxypoolv1, a gRPC service, not HTTP; its
+// HTTP listener only exists for the health probes, so that's all this
+// spec documents.
+package openapispec
+
+import "github.com/XXXXD-cation/proxy-platform/pkg/openapi"
+
+// Build returns the proxy-pool OpenAPI document.
+func Build() *openapi.Builder {
+	b := openapi.NewBuilder(openapi.Info{
+		Title:       "proxy-platform proxy-pool",
+		Version:     "1.0.0",
+		Description: "Owns the proxy inventory lifecycle: health checking, scoring, and geo enrichment. Its service API (proxypoolv1) is gRPC, not HTTP, so it isn't represented here; this document covers only the HTTP health probes.",
+	})
+
+	b.Add("/healthz", "GET", openapi.Operation{Summary: "Liveness probe", Tags: []string{"ops"}, Responses: map[string]openapi.Response{"200": {Description: "process is up"}}})
+	b.Add("/readyz", "GET", openapi.Operation{Summary: "Readiness probe", Description: "Runs every registered dependency check (MySQL ping, Redis ping, health-check worker freshness) and reports each one's status and latency in the response body.", Tags: []string{"ops"}, Responses: map[string]openapi.Response{"200": {Description: "ready to serve traffic; body reports per-dependency status"}, "503": {Description: "not ready: shutting down or a dependency check failed; body reports which"}}})
+
+	return b
+}
@@ -0,0 +1,118 @@
+// Package poolmanager runs periodic sweeps that enforce each
+// pkg/pool.Pool's capacity and quality policy: proxies scoring below
+// MinQualityScore are unassigned, and once a pool is still over
+// MaxProxies after that, its worst-scoring remaining members are
+// unassigned too, down to the limit. Unassigned proxies stay in the
+// general inventory; they simply no longer count against that pool.
+package poolmanager
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/pkg/pool"
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// Manager sweeps every configured pool and evicts members that no
+// longer satisfy its capacity or quality policy.
+type Manager struct {
+	pools    *pool.DAO
+	proxyDAO *dao.ProxyDAO
+}
+
+// NewManager builds a Manager.
+func NewManager(pools *pool.DAO, proxyDAO *dao.ProxyDAO) *Manager {
+	return &Manager{pools: pools, proxyDAO: proxyDAO}
+}
+
+// Run sweeps every `interval` until ctx is cancelled.
+func (m *Manager) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.RunOnce(ctx); err != nil {
+				log.Printf("poolmanager: sweep failed: %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce enforces every pool's policy once and blocks until the sweep
+// completes.
+func (m *Manager) RunOnce(ctx context.Context) error {
+	pools, err := m.pools.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	evicted := 0
+	for _, p := range pools {
+		n, err := m.enforceOne(ctx, p)
+		if err != nil {
+			log.Printf("poolmanager: failed to enforce pool %s (%s): %v", p.ID, p.Name, err)
+			continue
+		}
+		evicted += n
+	}
+
+	log.Printf("poolmanager: sweep complete, evicted %d proxies across %d pools", evicted, len(pools))
+	return nil
+}
+
+// enforceOne evicts p's worst members in excess of its policy and
+// returns how many it evicted.
+func (m *Manager) enforceOne(ctx context.Context, p *pool.Pool) (int, error) {
+	members, err := m.proxyDAO.ListByPool(ctx, p.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	keep := selectToKeep(members, p.MaxProxies, p.MinQualityScore)
+	keepIDs := make(map[string]bool, len(keep))
+	for _, k := range keep {
+		keepIDs[k.ID] = true
+	}
+
+	evicted := 0
+	for _, member := range members {
+		if keepIDs[member.ID] {
+			continue
+		}
+		if err := m.proxyDAO.AssignPool(ctx, member.ID, ""); err != nil {
+			return evicted, err
+		}
+		evicted++
+	}
+	return evicted, nil
+}
+
+// selectToKeep returns the members of a pool that satisfy its policy:
+// first those at or above minQualityScore (minQualityScore <= 0 means no
+// floor), then, if still over maxProxies (maxProxies <= 0 means
+// unlimited), only the highest-scoring ones up to the cap. members must
+// already be sorted highest score first, as ProxyDAO.ListByPool returns
+// them.
+func selectToKeep(members []*proxy.Proxy, maxProxies int, minQualityScore float64) []*proxy.Proxy {
+	qualified := members
+	if minQualityScore > 0 {
+		qualified = make([]*proxy.Proxy, 0, len(members))
+		for _, p := range members {
+			if p.Score >= minQualityScore {
+				qualified = append(qualified, p)
+			}
+		}
+	}
+
+	if maxProxies > 0 && len(qualified) > maxProxies {
+		qualified = qualified[:maxProxies]
+	}
+	return qualified
+}
@@ -0,0 +1,43 @@
+package poolmanager
+
+import (
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+func TestSelectToKeep(t *testing.T) {
+	members := []*proxy.Proxy{
+		{ID: "a", Score: 0.9},
+		{ID: "b", Score: 0.7},
+		{ID: "c", Score: 0.5},
+		{ID: "d", Score: 0.2},
+	}
+
+	cases := []struct {
+		name            string
+		maxProxies      int
+		minQualityScore float64
+		want            []string
+	}{
+		{"no policy keeps everyone", 0, 0, []string{"a", "b", "c", "d"}},
+		{"min quality drops the worst", 0, 0.5, []string{"a", "b", "c"}},
+		{"max proxies caps to the top N", 2, 0, []string{"a", "b"}},
+		{"both apply, quality first", 2, 0.5, []string{"a", "b"}},
+		{"max proxies larger than qualified is a no-op", 10, 0.5, []string{"a", "b", "c"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			kept := selectToKeep(members, c.maxProxies, c.minQualityScore)
+			if len(kept) != len(c.want) {
+				t.Fatalf("selectToKeep() kept %d members, want %d", len(kept), len(c.want))
+			}
+			for i, p := range kept {
+				if p.ID != c.want[i] {
+					t.Errorf("selectToKeep()[%d] = %q, want %q", i, p.ID, c.want[i])
+				}
+			}
+		})
+	}
+}
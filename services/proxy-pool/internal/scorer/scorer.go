@@ -0,0 +1,168 @@
+// Package scorer computes a proxy's overall quality score from its
+// health-check history. Weights and thresholds are configurable per
+// deployment (see Config) rather than hardcoded, and staleness decay
+// lets a score fade once a proxy hasn't been freshly checked in a
+// while, so an operator-tuned policy degrades gracefully between
+// sweeps instead of jumping only at the moment of the next probe.
+package scorer
+
+import (
+	"math"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// Weights are the named components combined into a proxy's overall
+// score. They need not sum to 1; Score normalizes by their total so an
+// operator can tune relative importance without also re-balancing every
+// other weight.
+type Weights struct {
+	Success   float64
+	Latency   float64
+	Anonymity float64
+	// Stability rewards proxies with few recent consecutive failures,
+	// independent of the success-rate window Success already covers; a
+	// proxy can have a healthy recent success rate and still be
+	// mid-flap.
+	Stability float64
+}
+
+// DefaultWeights matches this package's original hardcoded split, with
+// a nonzero Stability component carved out of what was previously
+// implicit in Success alone.
+var DefaultWeights = Weights{
+	Success:   0.45,
+	Latency:   0.25,
+	Anonymity: 0.15,
+	Stability: 0.15,
+}
+
+// Named keys for Weights fields, as they appear in
+// config.Config.ScorerWeights, e.g. {"success": 0.5}.
+const (
+	weightKeySuccess   = "success"
+	weightKeyLatency   = "latency"
+	weightKeyAnonymity = "anonymity"
+	weightKeyStability = "stability"
+)
+
+// WeightsFromMap overlays named overrides onto defaults, leaving any
+// weight whose key is absent from named untouched. A key present with
+// an explicit 0 disables that component; only absence falls back to
+// the default.
+func WeightsFromMap(named map[string]float64, defaults Weights) Weights {
+	w := defaults
+	if v, ok := named[weightKeySuccess]; ok {
+		w.Success = v
+	}
+	if v, ok := named[weightKeyLatency]; ok {
+		w.Latency = v
+	}
+	if v, ok := named[weightKeyAnonymity]; ok {
+		w.Anonymity = v
+	}
+	if v, ok := named[weightKeyStability]; ok {
+		w.Stability = v
+	}
+	return w
+}
+
+// defaultLatencyCeilingMS is the latency, in milliseconds, at or above
+// which the latency component of the score bottoms out at zero.
+const defaultLatencyCeilingMS = 3000
+
+// Config bundles everything Score needs: the component weights and the
+// thresholds that shape them.
+type Config struct {
+	Weights Weights
+
+	// LatencyCeilingMS is the latency, in milliseconds, at or above
+	// which the latency component bottoms out at zero. Zero or
+	// negative falls back to defaultLatencyCeilingMS.
+	LatencyCeilingMS int
+
+	// DecayHalfLife is how long it takes a stale score to fade to half
+	// its freshly-checked value, based on time since LastCheckedAt.
+	// Zero disables decay.
+	DecayHalfLife time.Duration
+}
+
+// DefaultConfig returns the settings that reproduce this package's
+// pre-configurable behavior: the original weight split plus the
+// original latency ceiling, with decay disabled.
+func DefaultConfig() Config {
+	return Config{
+		Weights:          DefaultWeights,
+		LatencyCeilingMS: defaultLatencyCeilingMS,
+	}
+}
+
+var anonymityScore = map[proxy.AnonymityLevel]float64{
+	proxy.AnonymityElite:       1,
+	proxy.AnonymityAnonymous:   0.6,
+	proxy.AnonymityTransparent: 0.2,
+	proxy.AnonymityUnknown:     0,
+}
+
+// Score returns p's overall quality in [0, 1], combining its success
+// rate, latency, anonymity level, and recent stability per c's weights,
+// then fading the result by how long it's been since p was last
+// checked.
+func (c Config) Score(p *proxy.Proxy) float64 {
+	ceiling := c.LatencyCeilingMS
+	if ceiling <= 0 {
+		ceiling = defaultLatencyCeilingMS
+	}
+
+	// Prefer the tail (p95) over the single most recent sample when a
+	// histogram is available: a proxy that's usually fast but
+	// occasionally spikes should score worse than LatencyMS alone, read
+	// right after a lucky fast check, would suggest.
+	latencyMS := p.LatencyMS
+	if p.P95LatencyMS > 0 {
+		latencyMS = p.P95LatencyMS
+	}
+	latencyComponent := 1 - float64(latencyMS)/float64(ceiling)
+	if latencyComponent < 0 {
+		latencyComponent = 0
+	}
+
+	stabilityComponent := 1 / (1 + float64(p.ConsecutiveFailures))
+
+	w := c.Weights
+	total := w.Success + w.Latency + w.Anonymity + w.Stability
+	if total <= 0 {
+		return 0
+	}
+
+	raw := w.Success*p.SuccessRate +
+		w.Latency*latencyComponent +
+		w.Anonymity*anonymityScore[p.AnonymityLevel] +
+		w.Stability*stabilityComponent
+	raw /= total
+
+	return raw * c.decayFactor(p.LastCheckedAt)
+}
+
+// decayFactor returns the exponential-decay multiplier for a score
+// last checked at lastCheckedAt: 1 when fresh, halving every
+// DecayHalfLife thereafter. Decay is disabled (factor always 1) when
+// DecayHalfLife is zero or lastCheckedAt is unset.
+func (c Config) decayFactor(lastCheckedAt time.Time) float64 {
+	if c.DecayHalfLife <= 0 || lastCheckedAt.IsZero() {
+		return 1
+	}
+	staleness := time.Since(lastCheckedAt)
+	if staleness <= 0 {
+		return 1
+	}
+	halfLives := float64(staleness) / float64(c.DecayHalfLife)
+	return math.Exp(-math.Ln2 * halfLives)
+}
+
+// Score returns p's overall quality using DefaultConfig, for callers
+// that don't need operator-tunable weights.
+func Score(p *proxy.Proxy) float64 {
+	return DefaultConfig().Score(p)
+}
@@ -0,0 +1,115 @@
+package scorer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+func TestWeightsFromMap(t *testing.T) {
+	tests := []struct {
+		name  string
+		named map[string]float64
+		want  Weights
+	}{
+		{
+			name:  "nil map keeps defaults",
+			named: nil,
+			want:  DefaultWeights,
+		},
+		{
+			name:  "absent keys keep their default",
+			named: map[string]float64{"success": 0.9},
+			want:  Weights{Success: 0.9, Latency: DefaultWeights.Latency, Anonymity: DefaultWeights.Anonymity, Stability: DefaultWeights.Stability},
+		},
+		{
+			name:  "explicit zero disables a component",
+			named: map[string]float64{"stability": 0},
+			want:  Weights{Success: DefaultWeights.Success, Latency: DefaultWeights.Latency, Anonymity: DefaultWeights.Anonymity, Stability: 0},
+		},
+		{
+			name:  "all keys override",
+			named: map[string]float64{"success": 1, "latency": 2, "anonymity": 3, "stability": 4},
+			want:  Weights{Success: 1, Latency: 2, Anonymity: 3, Stability: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WeightsFromMap(tt.named, DefaultWeights)
+			if got != tt.want {
+				t.Errorf("WeightsFromMap(%v) = %+v, want %+v", tt.named, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigScore(t *testing.T) {
+	cfg := DefaultConfig()
+
+	best := &proxy.Proxy{
+		SuccessRate:    1,
+		LatencyMS:      0,
+		AnonymityLevel: proxy.AnonymityElite,
+		LastCheckedAt:  time.Now(),
+	}
+	if got := cfg.Score(best); got < 0.99 {
+		t.Errorf("Score(best proxy) = %v, want close to 1", got)
+	}
+
+	worst := &proxy.Proxy{
+		SuccessRate:         0,
+		LatencyMS:           cfg.LatencyCeilingMS * 2,
+		AnonymityLevel:      proxy.AnonymityUnknown,
+		ConsecutiveFailures: 1000,
+		LastCheckedAt:       time.Now(),
+	}
+	if got := cfg.Score(worst); got > 0.01 {
+		t.Errorf("Score(worst proxy) = %v, want close to 0", got)
+	}
+}
+
+func TestConfigScoreStabilityPenalizesFlapping(t *testing.T) {
+	cfg := DefaultConfig()
+	base := &proxy.Proxy{SuccessRate: 0.8, LatencyMS: 100, AnonymityLevel: proxy.AnonymityAnonymous, LastCheckedAt: time.Now()}
+
+	stable := *base
+	flapping := *base
+	flapping.ConsecutiveFailures = 5
+
+	if cfg.Score(&flapping) >= cfg.Score(&stable) {
+		t.Errorf("a proxy with recent consecutive failures should score lower than an otherwise identical stable one")
+	}
+}
+
+func TestConfigScoreDecay(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DecayHalfLife = time.Hour
+
+	p := &proxy.Proxy{SuccessRate: 1, LatencyMS: 0, AnonymityLevel: proxy.AnonymityElite}
+
+	p.LastCheckedAt = time.Now()
+	fresh := cfg.Score(p)
+
+	p.LastCheckedAt = time.Now().Add(-time.Hour)
+	stale := cfg.Score(p)
+
+	if stale >= fresh {
+		t.Errorf("a score checked one half-life ago (%v) should be lower than a fresh one (%v)", stale, fresh)
+	}
+	if got, want := stale/fresh, 0.5; got < want-0.05 || got > want+0.05 {
+		t.Errorf("score one half-life old = %v of fresh, want ~0.5", got)
+	}
+}
+
+func TestConfigScoreNoDecayWhenDisabled(t *testing.T) {
+	cfg := DefaultConfig() // DecayHalfLife is zero: disabled
+
+	p := &proxy.Proxy{SuccessRate: 1, LatencyMS: 0, AnonymityLevel: proxy.AnonymityElite, LastCheckedAt: time.Now().Add(-365 * 24 * time.Hour)}
+	fresh := &proxy.Proxy{SuccessRate: 1, LatencyMS: 0, AnonymityLevel: proxy.AnonymityElite, LastCheckedAt: time.Now()}
+
+	if cfg.Score(p) != cfg.Score(fresh) {
+		t.Errorf("decay should have no effect when DecayHalfLife is zero")
+	}
+}
@@ -0,0 +1,80 @@
+// Package scoresweep periodically recomputes every active proxy's
+// quality score and writes it back to MySQL, independent of the
+// health-check sweep. This is what makes scorer.Config's staleness
+// decay actually bite: the health-check worker only rescoring a proxy
+// it just probed can never observe decay, since LastCheckedAt is set to
+// "now" in the same step. A proxy that simply stops being probed still
+// needs its score to fade.
+package scoresweep
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/dao"
+	"github.com/XXXXD-cation/proxy-platform/services/proxy-pool/internal/scorer"
+)
+
+// Worker recomputes scores for every active proxy on a fixed interval.
+type Worker struct {
+	proxyDAO *dao.ProxyDAO
+
+	config atomic.Pointer[scorer.Config] // live, operator-tunable; defaults to scorer.DefaultConfig()
+}
+
+// NewWorker builds a Worker.
+func NewWorker(proxyDAO *dao.ProxyDAO) *Worker {
+	w := &Worker{proxyDAO: proxyDAO}
+	cfg := scorer.DefaultConfig()
+	w.config.Store(&cfg)
+	return w
+}
+
+// SetConfig replaces the weights and thresholds used by every
+// subsequent recalculation. Safe to call concurrently with a running
+// sweep; it takes effect starting with the next sweep.
+func (w *Worker) SetConfig(cfg scorer.Config) {
+	w.config.Store(&cfg)
+}
+
+// Run sweeps every `interval` until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.RunOnce(ctx); err != nil {
+				log.Printf("scoresweep: sweep failed: %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce recomputes and persists the score of every active proxy, and
+// blocks until the sweep completes.
+func (w *Worker) RunOnce(ctx context.Context) error {
+	proxies, err := w.proxyDAO.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg := w.config.Load()
+	updated := 0
+	for _, p := range proxies {
+		score := cfg.Score(p)
+		if err := w.proxyDAO.UpdateScore(ctx, p.ID, score); err != nil {
+			log.Printf("scoresweep: failed to update score for proxy %s: %v", p.ID, err)
+			continue
+		}
+		updated++
+	}
+
+	log.Printf("scoresweep: sweep complete, recalculated %d/%d proxies", updated, len(proxies))
+	return nil
+}
@@ -0,0 +1,40 @@
+package reputation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// apiResponse is the shape expected back from Config.APIEndpoint.
+type apiResponse struct {
+	Abusive bool `json:"abusive"`
+}
+
+// queryReputationAPI asks cfg.APIEndpoint whether ip is abusive.
+func queryReputationAPI(ctx context.Context, cfg Config, ip string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.APIEndpoint+"?ip="+ip, nil)
+	if err != nil {
+		return false, err
+	}
+	if cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.APIKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("reputation: query api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("reputation: api returned status %d", resp.StatusCode)
+	}
+
+	var parsed apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return false, fmt.Errorf("reputation: decode api response: %w", err)
+	}
+	return parsed.Abusive, nil
+}
@@ -0,0 +1,90 @@
+// Package reputation checks a proxy's IP against public DNS blacklists
+// (DNSBLs) and, optionally, a third-party reputation API, so the pool can
+// keep abused or flagged IPs out of premium customers' rotations.
+package reputation
+
+import (
+	"context"
+	"net"
+)
+
+// Result is the outcome of a Check against a single IP.
+type Result struct {
+	// Flagged is true once Score crosses Config.Threshold.
+	Flagged bool
+
+	// Score is the fraction of consulted sources (DNSBL zones plus the
+	// optional API) that listed the IP, in [0, 1].
+	Score float64
+
+	// ListedZones are the DNSBL zones that listed the IP. Does not
+	// include the optional API check; see ListedByAPI.
+	ListedZones []string
+
+	// ListedByAPI is true if Config.APIEndpoint was consulted and
+	// reported the IP as abusive.
+	ListedByAPI bool
+}
+
+// Checker queries DNSBL zones and an optional third-party reputation API
+// to classify a proxy IP's standing.
+type Checker struct {
+	cfg      Config
+	resolver *net.Resolver
+}
+
+// NewChecker builds a Checker. Zero-valued fields of cfg are filled in
+// from DefaultConfig.
+func NewChecker(cfg Config) *Checker {
+	return &Checker{cfg: withDefaults(cfg), resolver: net.DefaultResolver}
+}
+
+// Check consults every configured DNSBL zone, and the reputation API if
+// configured, and returns the combined Result. A zone or the API that
+// couldn't be queried (network error, timeout) is excluded from the
+// denominator rather than counted as either listed or clean.
+func (c *Checker) Check(ctx context.Context, ip string) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	var result Result
+	consulted := 0
+	listed := 0
+
+	for _, zone := range c.cfg.Zones {
+		ok, err := lookupZone(ctx, c.resolver, ip, zone)
+		if err != nil {
+			continue
+		}
+		consulted++
+		if ok {
+			listed++
+			result.ListedZones = append(result.ListedZones, zone)
+		}
+	}
+
+	if c.cfg.APIEndpoint != "" {
+		ok, err := queryReputationAPI(ctx, c.cfg, ip)
+		if err == nil {
+			consulted++
+			if ok {
+				listed++
+				result.ListedByAPI = true
+			}
+		}
+	}
+
+	result.Score, result.Flagged = score(listed, consulted, c.cfg.Threshold)
+	return result
+}
+
+// score computes the listed fraction of consulted sources and whether it
+// crosses threshold. A consulted count of zero (nothing could be queried)
+// is never flagged, regardless of threshold.
+func score(listed, consulted int, threshold float64) (fraction float64, flagged bool) {
+	if consulted == 0 {
+		return 0, false
+	}
+	fraction = float64(listed) / float64(consulted)
+	return fraction, fraction >= threshold
+}
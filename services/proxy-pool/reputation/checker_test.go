@@ -0,0 +1,32 @@
+package reputation
+
+import "testing"
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name         string
+		listed       int
+		consulted    int
+		threshold    float64
+		wantFraction float64
+		wantFlagged  bool
+	}{
+		{name: "clean", listed: 0, consulted: 2, threshold: 0.25, wantFraction: 0, wantFlagged: false},
+		{name: "below threshold", listed: 1, consulted: 5, threshold: 0.25, wantFraction: 0.2, wantFlagged: false},
+		{name: "at threshold", listed: 1, consulted: 4, threshold: 0.25, wantFraction: 0.25, wantFlagged: true},
+		{name: "above threshold", listed: 2, consulted: 2, threshold: 0.25, wantFraction: 1, wantFlagged: true},
+		{name: "nothing consulted", listed: 0, consulted: 0, threshold: 0.25, wantFraction: 0, wantFlagged: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fraction, flagged := score(tt.listed, tt.consulted, tt.threshold)
+			if fraction != tt.wantFraction {
+				t.Errorf("score() fraction = %v, want %v", fraction, tt.wantFraction)
+			}
+			if flagged != tt.wantFlagged {
+				t.Errorf("score() flagged = %v, want %v", flagged, tt.wantFlagged)
+			}
+		})
+	}
+}
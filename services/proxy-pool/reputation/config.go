@@ -0,0 +1,54 @@
+package reputation
+
+import "time"
+
+// Config bounds and overrides a Checker's behavior. Zero-value fields are
+// filled in from DefaultConfig by NewChecker.
+type Config struct {
+	// Zones is the set of DNSBL (DNS blacklist) zones consulted for each
+	// IP, e.g. "zen.spamhaus.org". A zone that lists the IP counts as one
+	// flagged source toward Result.Score.
+	Zones []string
+
+	// Timeout bounds each individual DNSBL lookup and the optional API
+	// check.
+	Timeout time.Duration
+
+	// Threshold is the minimum fraction of consulted sources (in [0, 1])
+	// that must list an IP for Result.Flagged to be true.
+	Threshold float64
+
+	// APIEndpoint, if set, is an additional third-party reputation API
+	// consulted alongside the DNSBL zones. It is called as
+	// "<APIEndpoint>?ip=<ip>" and must return JSON matching apiResponse.
+	// Left empty, only the DNSBL zones are consulted.
+	APIEndpoint string
+
+	// APIKey, if set, is sent as a Bearer token when calling APIEndpoint.
+	APIKey string
+}
+
+// DefaultConfig consults two well-known, widely trusted DNSBLs and flags
+// an IP once a quarter of consulted sources list it.
+func DefaultConfig() Config {
+	return Config{
+		Zones:     []string{"zen.spamhaus.org", "b.barracudacentral.org"},
+		Timeout:   5 * time.Second,
+		Threshold: 0.25,
+	}
+}
+
+// withDefaults fills in any zero-valued fields of cfg from DefaultConfig.
+func withDefaults(cfg Config) Config {
+	d := DefaultConfig()
+	if len(cfg.Zones) == 0 {
+		cfg.Zones = d.Zones
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = d.Timeout
+	}
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = d.Threshold
+	}
+	return cfg
+}
@@ -0,0 +1,43 @@
+package reputation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// reverseIPv4Query builds the DNSBL query name for ip against zone, e.g.
+// "1.2.3.4" against "zen.spamhaus.org" becomes "4.3.2.1.zen.spamhaus.org":
+// DNSBLs index IPv4 addresses octet-reversed under their zone.
+func reverseIPv4Query(ip, zone string) (string, error) {
+	parsed := net.ParseIP(ip).To4()
+	if parsed == nil {
+		return "", fmt.Errorf("reputation: %q is not an IPv4 address", ip)
+	}
+	octets := strings.Split(parsed.String(), ".")
+	reversed := []string{octets[3], octets[2], octets[1], octets[0]}
+	return strings.Join(reversed, ".") + "." + zone, nil
+}
+
+// lookupZone reports whether ip is listed in zone. A successful A-record
+// lookup means the IP is listed; NXDOMAIN (the resolver returning "not
+// found") means it isn't. Any other error is returned so callers can
+// distinguish "not listed" from "couldn't check".
+func lookupZone(ctx context.Context, resolver *net.Resolver, ip, zone string) (bool, error) {
+	query, err := reverseIPv4Query(ip, zone)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = resolver.LookupHost(ctx, query)
+	if err == nil {
+		return true, nil
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false, nil
+	}
+	return false, fmt.Errorf("reputation: query %s: %w", zone, err)
+}
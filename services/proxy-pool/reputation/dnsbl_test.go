@@ -0,0 +1,34 @@
+package reputation
+
+import "testing"
+
+func TestReverseIPv4Query(t *testing.T) {
+	tests := []struct {
+		ip      string
+		zone    string
+		want    string
+		wantErr bool
+	}{
+		{ip: "1.2.3.4", zone: "zen.spamhaus.org", want: "4.3.2.1.zen.spamhaus.org"},
+		{ip: "127.0.0.2", zone: "b.barracudacentral.org", want: "2.0.0.127.b.barracudacentral.org"},
+		{ip: "not-an-ip", zone: "zen.spamhaus.org", wantErr: true},
+		{ip: "::1", zone: "zen.spamhaus.org", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := reverseIPv4Query(tt.ip, tt.zone)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("reverseIPv4Query(%q, %q): expected error", tt.ip, tt.zone)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("reverseIPv4Query(%q, %q): unexpected error: %v", tt.ip, tt.zone, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("reverseIPv4Query(%q, %q) = %q, want %q", tt.ip, tt.zone, got, tt.want)
+		}
+	}
+}
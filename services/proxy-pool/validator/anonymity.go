@@ -0,0 +1,133 @@
+// Package validator implements protocol-aware checks used to classify and
+// score proxies beyond a simple up/down health probe.
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// JudgeEndpoint echoes back the request headers it received, as seen by
+// the upstream, so we can detect whether a proxy leaked the real client
+// IP or identified itself as a proxy.
+const JudgeEndpoint = "https://httpbin.org/get"
+
+// ProbeTimeout bounds the judge-endpoint round trip.
+const ProbeTimeout = 5 * time.Second
+
+// leakHeaders are checked case-insensitively for anything that reveals a
+// client IP or the presence of a proxy in the chain.
+var leakHeaders = []string{"X-Forwarded-For", "Via", "Forwarded", "X-Real-Ip", "Proxy-Connection"}
+
+type judgeResponse struct {
+	Headers map[string]string `json:"headers"`
+	Origin  string            `json:"origin"`
+}
+
+// AnonymityDetector classifies a proxy's anonymity level by routing a
+// request through it to JudgeEndpoint and inspecting what the judge saw.
+type AnonymityDetector struct {
+	// JudgeEndpoint overrides the default judge URL; used by tests.
+	JudgeEndpoint string
+}
+
+// Detect sends a request through p and classifies it as transparent (the
+// real client IP leaked through), anonymous (a proxy header was present
+// but the IP did not leak), or elite (no proxy-identifying headers at
+// all).
+func (d *AnonymityDetector) Detect(ctx context.Context, p *proxy.Proxy, clientIP string) (proxy.AnonymityLevel, error) {
+	parsed, err := d.probe(ctx, p)
+	if err != nil {
+		return proxy.AnonymityUnknown, err
+	}
+	return classify(parsed, clientIP), nil
+}
+
+// ExitIP returns the external IP the judge endpoint saw the request
+// arrive from, i.e. p's real exit IP rather than whatever host/port it
+// advertises. Commercial providers frequently front several advertised
+// endpoints with the same exit, so this is the basis for cross-provider
+// duplicate detection; see services/proxy-pool/internal/dedup.
+func (d *AnonymityDetector) ExitIP(ctx context.Context, p *proxy.Proxy) (string, error) {
+	parsed, err := d.probe(ctx, p)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Origin, nil
+}
+
+// DetectWithExitIP does the combined work of Detect and ExitIP off a
+// single judge-endpoint round trip, for callers that need both results
+// and want to avoid probing the proxy twice.
+func (d *AnonymityDetector) DetectWithExitIP(ctx context.Context, p *proxy.Proxy, clientIP string) (proxy.AnonymityLevel, string, error) {
+	parsed, err := d.probe(ctx, p)
+	if err != nil {
+		return proxy.AnonymityUnknown, "", err
+	}
+	return classify(parsed, clientIP), parsed.Origin, nil
+}
+
+// classify interprets a judge-endpoint response as transparent (the real
+// client IP leaked through), anonymous (a proxy header was present but
+// the IP did not leak), or elite (no proxy-identifying headers at all).
+func classify(parsed judgeResponse, clientIP string) proxy.AnonymityLevel {
+	if clientIP != "" && strings.Contains(parsed.Origin, clientIP) {
+		return proxy.AnonymityTransparent
+	}
+	for header, value := range parsed.Headers {
+		for _, leak := range leakHeaders {
+			if !strings.EqualFold(header, leak) {
+				continue
+			}
+			if clientIP != "" && strings.Contains(value, clientIP) {
+				return proxy.AnonymityTransparent
+			}
+			return proxy.AnonymityAnonymous
+		}
+	}
+	return proxy.AnonymityElite
+}
+
+// probe routes a single request through p to the judge endpoint and
+// decodes what it saw, shared by Detect and ExitIP so both read off one
+// round trip's worth of judge-endpoint logic.
+func (d *AnonymityDetector) probe(ctx context.Context, p *proxy.Proxy) (judgeResponse, error) {
+	endpoint := d.JudgeEndpoint
+	if endpoint == "" {
+		endpoint = JudgeEndpoint
+	}
+
+	proxyURL, err := url.Parse(fmt.Sprintf("http://%s", p.Addr()))
+	if err != nil {
+		return judgeResponse{}, err
+	}
+
+	client := &http.Client{
+		Timeout:   ProbeTimeout,
+		Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return judgeResponse{}, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return judgeResponse{}, fmt.Errorf("validator: judge request through %s: %w", p.Addr(), err)
+	}
+	defer resp.Body.Close()
+
+	var parsed judgeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return judgeResponse{}, fmt.Errorf("validator: decode judge response: %w", err)
+	}
+	return parsed, nil
+}
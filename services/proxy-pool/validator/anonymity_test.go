@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+func judgeServer(t *testing.T, headers map[string]string, origin string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(judgeResponse{Headers: headers, Origin: origin})
+	}))
+}
+
+func proxyForServer(t *testing.T, srv *httptest.Server) *proxy.Proxy {
+	t.Helper()
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("parse server URL: %v", err)
+	}
+	host, portStr, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		t.Fatalf("split host port: %v", err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatalf("parse port: %v", err)
+	}
+	return &proxy.Proxy{Host: host, Port: port, Protocol: proxy.ProtocolHTTP}
+}
+
+func TestDetectElite(t *testing.T) {
+	srv := judgeServer(t, nil, "1.2.3.4")
+	defer srv.Close()
+
+	d := &AnonymityDetector{JudgeEndpoint: "http://example.invalid/get"}
+	level, err := d.Detect(context.Background(), proxyForServer(t, srv), "9.9.9.9")
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	if level != proxy.AnonymityElite {
+		t.Fatalf("expected elite, got %s", level)
+	}
+}
+
+func TestDetectAnonymous(t *testing.T) {
+	srv := judgeServer(t, map[string]string{"Via": "1.1 proxy"}, "1.2.3.4")
+	defer srv.Close()
+
+	d := &AnonymityDetector{JudgeEndpoint: "http://example.invalid/get"}
+	level, err := d.Detect(context.Background(), proxyForServer(t, srv), "9.9.9.9")
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	if level != proxy.AnonymityAnonymous {
+		t.Fatalf("expected anonymous, got %s", level)
+	}
+}
+
+func TestDetectTransparentViaOrigin(t *testing.T) {
+	srv := judgeServer(t, nil, "9.9.9.9")
+	defer srv.Close()
+
+	d := &AnonymityDetector{JudgeEndpoint: "http://example.invalid/get"}
+	level, err := d.Detect(context.Background(), proxyForServer(t, srv), "9.9.9.9")
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	if level != proxy.AnonymityTransparent {
+		t.Fatalf("expected transparent, got %s", level)
+	}
+}
+
+func TestDetectTransparentViaLeakedHeader(t *testing.T) {
+	srv := judgeServer(t, map[string]string{"X-Forwarded-For": "9.9.9.9"}, "1.2.3.4")
+	defer srv.Close()
+
+	d := &AnonymityDetector{JudgeEndpoint: "http://example.invalid/get"}
+	level, err := d.Detect(context.Background(), proxyForServer(t, srv), "9.9.9.9")
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	if level != proxy.AnonymityTransparent {
+		t.Fatalf("expected transparent, got %s", level)
+	}
+}
+
+func TestExitIP(t *testing.T) {
+	srv := judgeServer(t, nil, "5.6.7.8")
+	defer srv.Close()
+
+	d := &AnonymityDetector{JudgeEndpoint: "http://example.invalid/get"}
+	ip, err := d.ExitIP(context.Background(), proxyForServer(t, srv))
+	if err != nil {
+		t.Fatalf("ExitIP: %v", err)
+	}
+	if ip != "5.6.7.8" {
+		t.Fatalf("expected 5.6.7.8, got %s", ip)
+	}
+}
+
+func TestDetectWithExitIP(t *testing.T) {
+	srv := judgeServer(t, map[string]string{"Via": "1.1 proxy"}, "5.6.7.8")
+	defer srv.Close()
+
+	d := &AnonymityDetector{JudgeEndpoint: "http://example.invalid/get"}
+	level, ip, err := d.DetectWithExitIP(context.Background(), proxyForServer(t, srv), "9.9.9.9")
+	if err != nil {
+		t.Fatalf("DetectWithExitIP: %v", err)
+	}
+	if level != proxy.AnonymityAnonymous {
+		t.Fatalf("expected anonymous, got %s", level)
+	}
+	if ip != "5.6.7.8" {
+		t.Fatalf("expected 5.6.7.8, got %s", ip)
+	}
+}
@@ -0,0 +1,64 @@
+package validator
+
+import "time"
+
+// Config bounds and overrides a Validator's behavior. Zero-value fields
+// are filled in from DefaultConfig by NewValidator.
+type Config struct {
+	// Timeout bounds every individual probe step (TCP connect, HTTP
+	// round trip, CONNECT handshake, SOCKS handshake).
+	Timeout time.Duration
+
+	// ProbeTarget is the endpoint used for the HTTP GET check.
+	ProbeTarget string
+
+	// IntegrityTarget is the endpoint used for the response-body
+	// integrity check. It must return a fixed, well-known body so a
+	// proxy that tampers with traffic (injected ads, captive portals,
+	// transparent caching) can be detected.
+	IntegrityTarget string
+
+	// ConnectTarget is the host:port dialed for the HTTPS CONNECT and
+	// SOCKS4/5 handshake checks.
+	ConnectTarget string
+
+	// LatencySamples is how many probes LatencyPercentiles runs before
+	// computing p50/p95. A value <= 1 disables percentile computation;
+	// Validate falls back to a single latency sample.
+	LatencySamples int
+}
+
+// DefaultConfig mirrors the targets and timeout that
+// services/proxy-pool/internal/healthcheck's MultiProbeChecker already
+// uses, so a Validator run with defaults classifies a proxy the same way
+// the regular sweep does.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:         5 * time.Second,
+		ProbeTarget:     "https://www.google.com/generate_204",
+		IntegrityTarget: "https://www.google.com/generate_204",
+		ConnectTarget:   "www.google.com:443",
+		LatencySamples:  3,
+	}
+}
+
+// withDefaults fills in any zero-valued fields of cfg from DefaultConfig.
+func withDefaults(cfg Config) Config {
+	d := DefaultConfig()
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = d.Timeout
+	}
+	if cfg.ProbeTarget == "" {
+		cfg.ProbeTarget = d.ProbeTarget
+	}
+	if cfg.IntegrityTarget == "" {
+		cfg.IntegrityTarget = d.IntegrityTarget
+	}
+	if cfg.ConnectTarget == "" {
+		cfg.ConnectTarget = d.ConnectTarget
+	}
+	if cfg.LatencySamples <= 0 {
+		cfg.LatencySamples = d.LatencySamples
+	}
+	return cfg
+}
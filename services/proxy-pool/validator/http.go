@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+func httpClientThrough(p *proxy.Proxy, timeout time.Duration) (*http.Client, error) {
+	proxyURL, err := url.Parse(fmt.Sprintf("http://%s", p.Addr()))
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)},
+	}, nil
+}
+
+// httpGetThrough issues a GET to target through p, returning the
+// response body so callers can run an integrity check against it.
+func httpGetThrough(ctx context.Context, p *proxy.Proxy, target string, timeout time.Duration) (status int, body []byte, err error) {
+	client, err := httpClientThrough(p, timeout)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("validator: http get through %s: %w", p.Addr(), err)
+	}
+	defer resp.Body.Close()
+
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("validator: read response body: %w", err)
+	}
+	return resp.StatusCode, body, nil
+}
+
+// httpsConnectThrough opens a raw TCP connection to p and issues an
+// HTTPS CONNECT for target, verifying the proxy will tunnel TLS traffic
+// rather than just plain HTTP.
+func httpsConnectThrough(ctx context.Context, p *proxy.Proxy, target string, timeout time.Duration) error {
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.Addr())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	fmt.Fprintf(conn, "CONNECT %s HTTP/1.1\r\nHost: %s\r\n\r\n", target, target)
+
+	conn.SetDeadline(time.Now().Add(timeout))
+	resp, err := http.ReadResponse(bufio.NewReader(conn), &http.Request{Method: http.MethodConnect})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("CONNECT refused with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// latencyPercentiles calls probe up to samples times (stopping early
+// only on ctx cancellation), timing each attempt, and returns the 50th
+// and 95th percentile latency across the attempts that succeeded. A
+// single successful sample is reported as both p50 and p95. If every
+// attempt fails, it returns the error from the last attempt.
+func latencyPercentiles(ctx context.Context, samples int, probe func(context.Context) error) (p50, p95 time.Duration, err error) {
+	durations := make([]time.Duration, 0, samples)
+
+	for i := 0; i < samples; i++ {
+		if ctx.Err() != nil {
+			break
+		}
+		start := time.Now()
+		probeErr := probe(ctx)
+		elapsed := time.Since(start)
+		if probeErr != nil {
+			err = probeErr
+			continue
+		}
+		err = nil
+		durations = append(durations, elapsed)
+	}
+
+	if len(durations) == 0 {
+		return 0, 0, err
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return percentile(durations, 0.50), percentile(durations, 0.95), nil
+}
+
+// percentile returns the nearest-rank percentile (0 <= q <= 1) of a
+// sorted, non-empty slice, using the "closest rank" method: the smallest
+// value at or above the fraction q of the samples.
+func percentile(sorted []time.Duration, q float64) time.Duration {
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
@@ -0,0 +1,153 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// socksConnect dials p and performs a CONNECT handshake for target
+// ("host:port") using the SOCKS version implied by p.Protocol. A
+// successful return means the proxy accepted the handshake and reported
+// the tunnel as established; it does not read any application data.
+func socksConnect(ctx context.Context, p *proxy.Proxy, target string, timeout time.Duration) error {
+	host, portStr, err := net.SplitHostPort(target)
+	if err != nil {
+		return fmt.Errorf("validator: invalid connect target %q: %w", target, err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return fmt.Errorf("validator: invalid connect port %q: %w", portStr, err)
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.Addr())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	switch p.Protocol {
+	case proxy.ProtocolSOCKS4:
+		return socks4Handshake(conn, host, port)
+	case proxy.ProtocolSOCKS5:
+		return socks5Handshake(conn, host, port)
+	default:
+		return fmt.Errorf("validator: socksConnect called with non-SOCKS protocol %q", p.Protocol)
+	}
+}
+
+// socks4Handshake speaks the SOCKS4A extension (RFC-less, but universally
+// supported) so the proxy resolves host itself rather than requiring us
+// to resolve it first.
+func socks4Handshake(conn net.Conn, host string, port int) error {
+	req := []byte{0x04, 0x01, byte(port >> 8), byte(port)}
+	req = append(req, 0, 0, 0, 1) // invalid IP (0.0.0.x) signals SOCKS4A
+	req = append(req, 0)          // empty USERID, NUL-terminated
+	req = append(req, []byte(host)...)
+	req = append(req, 0)
+
+	if _, err := conn.Write(req); err != nil {
+		return fmt.Errorf("validator: socks4 write request: %w", err)
+	}
+
+	resp := make([]byte, 8)
+	if _, err := readFull(conn, resp); err != nil {
+		return fmt.Errorf("validator: socks4 read response: %w", err)
+	}
+	if resp[0] != 0x00 {
+		return fmt.Errorf("validator: socks4 malformed response, VN=%#x", resp[0])
+	}
+	const grantedCode = 0x5a
+	if resp[1] != grantedCode {
+		return fmt.Errorf("validator: socks4 request rejected, CD=%#x", resp[1])
+	}
+	return nil
+}
+
+// socks5Handshake does the two-round-trip SOCKS5 negotiation: method
+// selection (no-auth only, matching what a public proxy list entry is
+// expected to support), then a CONNECT request with a domain-name
+// address so the proxy performs its own DNS resolution.
+func socks5Handshake(conn net.Conn, host string, port int) error {
+	if _, err := conn.Write([]byte{0x05, 0x01, 0x00}); err != nil {
+		return fmt.Errorf("validator: socks5 write greeting: %w", err)
+	}
+
+	greetingResp := make([]byte, 2)
+	if _, err := readFull(conn, greetingResp); err != nil {
+		return fmt.Errorf("validator: socks5 read greeting response: %w", err)
+	}
+	if greetingResp[0] != 0x05 {
+		return fmt.Errorf("validator: socks5 unexpected version %#x", greetingResp[0])
+	}
+	if greetingResp[1] != 0x00 {
+		return fmt.Errorf("validator: socks5 no acceptable auth method, selected %#x", greetingResp[1])
+	}
+
+	if len(host) > 255 {
+		return fmt.Errorf("validator: socks5 domain name too long: %d bytes", len(host))
+	}
+	req := []byte{0x05, 0x01, 0x00, 0x03, byte(len(host))}
+	req = append(req, []byte(host)...)
+	req = append(req, byte(port>>8), byte(port))
+	if _, err := conn.Write(req); err != nil {
+		return fmt.Errorf("validator: socks5 write request: %w", err)
+	}
+
+	header := make([]byte, 4)
+	if _, err := readFull(conn, header); err != nil {
+		return fmt.Errorf("validator: socks5 read response header: %w", err)
+	}
+	if header[0] != 0x05 {
+		return fmt.Errorf("validator: socks5 unexpected response version %#x", header[0])
+	}
+	if header[1] != 0x00 {
+		return fmt.Errorf("validator: socks5 request failed, REP=%#x", header[1])
+	}
+
+	// Drain BND.ADDR + BND.PORT so the connection is left in a clean
+	// state; its contents are irrelevant to handshake success.
+	addrLen, err := socks5AddrLen(conn, header[3])
+	if err != nil {
+		return err
+	}
+	if _, err := readFull(conn, make([]byte, addrLen+2)); err != nil {
+		return fmt.Errorf("validator: socks5 read bound address: %w", err)
+	}
+	return nil
+}
+
+func socks5AddrLen(conn net.Conn, atyp byte) (int, error) {
+	switch atyp {
+	case 0x01: // IPv4
+		return net.IPv4len, nil
+	case 0x04: // IPv6
+		return net.IPv6len, nil
+	case 0x03: // domain name, length-prefixed
+		lenByte := make([]byte, 1)
+		if _, err := readFull(conn, lenByte); err != nil {
+			return 0, fmt.Errorf("validator: socks5 read bound address length: %w", err)
+		}
+		return int(lenByte[0]), nil
+	default:
+		return 0, fmt.Errorf("validator: socks5 unknown ATYP %#x in response", atyp)
+	}
+}
+
+func readFull(conn net.Conn, buf []byte) (int, error) {
+	total := 0
+	for total < len(buf) {
+		n, err := conn.Read(buf[total:])
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// fakeSocks4Server accepts a single connection, reads a SOCKS4/4A
+// CONNECT request (without validating its contents) and replies with
+// grant or reject per granted.
+func fakeSocks4Server(t *testing.T, granted bool) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		buf := make([]byte, 512)
+		_, _ = conn.Read(buf)
+
+		code := byte(0x5a)
+		if !granted {
+			code = 0x5b
+		}
+		conn.Write([]byte{0x00, code, 0, 0, 0, 0, 0, 0})
+	}()
+	return ln
+}
+
+// fakeSocks5Server accepts a single connection, does the no-auth method
+// negotiation, reads a CONNECT request, and replies with success or
+// failure per granted.
+func fakeSocks5Server(t *testing.T, granted bool) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		greeting := make([]byte, 3)
+		if _, err := readFull(conn, greeting); err != nil {
+			return
+		}
+		conn.Write([]byte{0x05, 0x00})
+
+		header := make([]byte, 4)
+		if _, err := readFull(conn, header); err != nil {
+			return
+		}
+		switch header[3] {
+		case 0x01:
+			readFull(conn, make([]byte, net.IPv4len+2))
+		case 0x03:
+			lenByte := make([]byte, 1)
+			readFull(conn, lenByte)
+			readFull(conn, make([]byte, int(lenByte[0])+2))
+		case 0x04:
+			readFull(conn, make([]byte, net.IPv6len+2))
+		}
+
+		rep := byte(0x00)
+		if !granted {
+			rep = 0x01
+		}
+		conn.Write([]byte{0x05, rep, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+	}()
+	return ln
+}
+
+func proxyFor(t *testing.T, ln net.Listener, protocol proxy.Protocol) *proxy.Proxy {
+	t.Helper()
+	host, portStr, err := net.SplitHostPort(ln.Addr().String())
+	if err != nil {
+		t.Fatalf("split host port: %v", err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatalf("parse port: %v", err)
+	}
+	return &proxy.Proxy{Host: host, Port: port, Protocol: protocol}
+}
+
+func TestSocks4HandshakeGranted(t *testing.T) {
+	ln := fakeSocks4Server(t, true)
+	defer ln.Close()
+
+	err := socksConnect(context.Background(), proxyFor(t, ln, proxy.ProtocolSOCKS4), "example.com:443", time.Second)
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+}
+
+func TestSocks4HandshakeRejected(t *testing.T) {
+	ln := fakeSocks4Server(t, false)
+	defer ln.Close()
+
+	err := socksConnect(context.Background(), proxyFor(t, ln, proxy.ProtocolSOCKS4), "example.com:443", time.Second)
+	if err == nil {
+		t.Fatal("expected error for rejected SOCKS4 request")
+	}
+}
+
+func TestSocks5HandshakeGranted(t *testing.T) {
+	ln := fakeSocks5Server(t, true)
+	defer ln.Close()
+
+	err := socksConnect(context.Background(), proxyFor(t, ln, proxy.ProtocolSOCKS5), "example.com:443", time.Second)
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+}
+
+func TestSocks5HandshakeRejected(t *testing.T) {
+	ln := fakeSocks5Server(t, false)
+	defer ln.Close()
+
+	err := socksConnect(context.Background(), proxyFor(t, ln, proxy.ProtocolSOCKS5), "example.com:443", time.Second)
+	if err == nil {
+		t.Fatal("expected error for rejected SOCKS5 request")
+	}
+}
@@ -0,0 +1,109 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// Result is the outcome of a full Validate pass against a single proxy.
+type Result struct {
+	// Success is true if the protocol-appropriate connectivity check
+	// (HTTP GET, HTTPS CONNECT, or SOCKS4/5 handshake) succeeded.
+	Success bool
+
+	// BodyIntegrityOK is true if the response body fetched through the
+	// proxy for Config.IntegrityTarget matched what was expected.
+	// Always false for SOCKS4/5 proxies, which this validator doesn't
+	// probe for a fetchable body.
+	BodyIntegrityOK bool
+
+	// LatencyP50MS and LatencyP95MS are the 50th and 95th percentile
+	// round-trip latencies, in milliseconds, across Config.LatencySamples
+	// connectivity probes. Both are 0 if every probe failed.
+	LatencyP50MS int
+	LatencyP95MS int
+}
+
+// Validator runs the full set of protocol-aware checks used to decide
+// whether a proxy is usable and how it should be scored: a protocol
+// connectivity check, a response-body integrity check, and latency
+// percentiles across repeated probes.
+type Validator struct {
+	cfg Config
+}
+
+// NewValidator builds a Validator. Zero-valued fields of cfg are filled
+// in from DefaultConfig.
+func NewValidator(cfg Config) *Validator {
+	return &Validator{cfg: withDefaults(cfg)}
+}
+
+// Check implements pkg/health.Checker, so a Validator can be dropped in
+// anywhere a simple liveness probe is expected.
+func (v *Validator) Check(ctx context.Context, p *proxy.Proxy) error {
+	return v.connect(ctx, p)
+}
+
+// Validate runs the full check suite against p and returns a Result.
+// Unlike Check, it does not return an error for a failed connectivity
+// probe; Result.Success reports that instead, so callers get partial
+// information (e.g. latency of the attempts that did fail) in one call.
+func (v *Validator) Validate(ctx context.Context, p *proxy.Proxy) Result {
+	var result Result
+
+	p50, p95, connectErr := latencyPercentiles(ctx, v.cfg.LatencySamples, func(ctx context.Context) error {
+		return v.connect(ctx, p)
+	})
+	result.Success = connectErr == nil
+	result.LatencyP50MS = int(p50.Milliseconds())
+	result.LatencyP95MS = int(p95.Milliseconds())
+
+	if result.Success && (p.Protocol == proxy.ProtocolHTTP || p.Protocol == proxy.ProtocolHTTPS) {
+		result.BodyIntegrityOK = v.checkBodyIntegrity(ctx, p)
+	}
+
+	return result
+}
+
+// connect runs the protocol-appropriate connectivity check: an HTTP GET
+// plus HTTPS CONNECT for HTTP/HTTPS proxies, or a SOCKS4/5 handshake for
+// SOCKS proxies.
+func (v *Validator) connect(ctx context.Context, p *proxy.Proxy) error {
+	switch p.Protocol {
+	case proxy.ProtocolHTTP, proxy.ProtocolHTTPS:
+		status, _, err := httpGetThrough(ctx, p, v.cfg.ProbeTarget, v.cfg.Timeout)
+		if err != nil {
+			return fmt.Errorf("validator: http get: %w", err)
+		}
+		if status >= 500 {
+			return fmt.Errorf("validator: http get through %s: upstream returned status %d", p.Addr(), status)
+		}
+		if err := httpsConnectThrough(ctx, p, v.cfg.ConnectTarget, v.cfg.Timeout); err != nil {
+			return fmt.Errorf("validator: https connect: %w", err)
+		}
+		return nil
+	case proxy.ProtocolSOCKS4, proxy.ProtocolSOCKS5:
+		if err := socksConnect(ctx, p, v.cfg.ConnectTarget, v.cfg.Timeout); err != nil {
+			return fmt.Errorf("validator: socks handshake: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("validator: unsupported protocol %q", p.Protocol)
+	}
+}
+
+// checkBodyIntegrity fetches Config.IntegrityTarget through p and
+// compares the response against the known-empty body
+// https://www.google.com/generate_204 always returns, catching proxies
+// that tamper with traffic (injected ads, captive portals) even though
+// they pass a basic status-code check.
+func (v *Validator) checkBodyIntegrity(ctx context.Context, p *proxy.Proxy) bool {
+	status, body, err := httpGetThrough(ctx, p, v.cfg.IntegrityTarget, v.cfg.Timeout)
+	if err != nil {
+		return false
+	}
+	return status < 300 && len(bytes.TrimSpace(body)) == 0
+}
@@ -0,0 +1,135 @@
+package validator
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/XXXXD-cation/proxy-platform/pkg/proxy"
+)
+
+// forwardProxyServer is a minimal HTTP forward proxy: it round-trips
+// whatever the client asked for with body and status controlled by the
+// test, regardless of the requested URL, standing in for a real proxy
+// for the purposes of httpGetThrough/checkBodyIntegrity.
+func forwardProxyServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestValidateHTTPSuccessWithIntegrityOK(t *testing.T) {
+	srv := forwardProxyServer(t, http.StatusOK, "")
+	defer srv.Close()
+
+	v := NewValidator(Config{
+		Timeout:         time.Second,
+		ProbeTarget:     "http://example.invalid/probe",
+		IntegrityTarget: "http://example.invalid/probe",
+		ConnectTarget:   "example.invalid:443",
+		LatencySamples:  2,
+	})
+	p := proxyForServer(t, srv)
+
+	result := v.Validate(context.Background(), p)
+	if !result.Success {
+		t.Fatalf("expected success, got %+v", result)
+	}
+	if !result.BodyIntegrityOK {
+		t.Fatalf("expected body integrity ok, got %+v", result)
+	}
+	if result.LatencyP50MS < 0 || result.LatencyP95MS < 0 {
+		t.Fatalf("expected non-negative latency, got %+v", result)
+	}
+}
+
+func TestValidateHTTPIntegrityFailsOnTamperedBody(t *testing.T) {
+	// The GET succeeds with a 2xx, but the body isn't the expected empty
+	// response: a tampering or ad-injection proxy would look like this.
+	srv := forwardProxyServer(t, http.StatusOK, "<html>injected ad</html>")
+	defer srv.Close()
+
+	v := NewValidator(Config{
+		Timeout:         time.Second,
+		ProbeTarget:     "http://example.invalid/probe",
+		IntegrityTarget: "http://example.invalid/probe",
+		ConnectTarget:   "example.invalid:443",
+		LatencySamples:  1,
+	})
+	p := proxyForServer(t, srv)
+
+	result := v.Validate(context.Background(), p)
+	if !result.Success {
+		t.Fatalf("expected success (2xx status), got %+v", result)
+	}
+	if result.BodyIntegrityOK {
+		t.Fatal("expected body integrity check to fail on tampered body")
+	}
+}
+
+func TestValidateHTTPFailsOnUpstreamError(t *testing.T) {
+	srv := forwardProxyServer(t, http.StatusBadGateway, "")
+	defer srv.Close()
+
+	v := NewValidator(Config{
+		Timeout:        time.Second,
+		ProbeTarget:    "http://example.invalid/probe",
+		LatencySamples: 1,
+	})
+	p := proxyForServer(t, srv)
+
+	result := v.Validate(context.Background(), p)
+	if result.Success {
+		t.Fatal("expected failure on 502 from upstream")
+	}
+}
+
+func TestValidateUnreachableProxyFails(t *testing.T) {
+	v := NewValidator(Config{Timeout: 200 * time.Millisecond, LatencySamples: 1})
+	p := &proxy.Proxy{Host: "127.0.0.1", Port: 1, Protocol: proxy.ProtocolHTTP}
+
+	result := v.Validate(context.Background(), p)
+	if result.Success {
+		t.Fatal("expected failure connecting to a closed port")
+	}
+}
+
+func TestPercentileSingleSample(t *testing.T) {
+	durations := []time.Duration{100 * time.Millisecond}
+	if got := percentile(durations, 0.5); got != 100*time.Millisecond {
+		t.Fatalf("expected 100ms, got %v", got)
+	}
+	if got := percentile(durations, 0.95); got != 100*time.Millisecond {
+		t.Fatalf("expected 100ms, got %v", got)
+	}
+}
+
+func TestPercentileMultipleSamples(t *testing.T) {
+	durations := []time.Duration{
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		30 * time.Millisecond,
+		40 * time.Millisecond,
+		100 * time.Millisecond,
+	}
+	if got := percentile(durations, 0.5); got != 30*time.Millisecond {
+		t.Fatalf("expected p50=30ms, got %v", got)
+	}
+	if got := percentile(durations, 0.95); got != 100*time.Millisecond {
+		t.Fatalf("expected p95=100ms, got %v", got)
+	}
+}
+
+func TestLatencyPercentilesAllFailuresReturnsError(t *testing.T) {
+	_, _, err := latencyPercentiles(context.Background(), 3, func(ctx context.Context) error {
+		return net.ErrClosed
+	})
+	if err == nil {
+		t.Fatal("expected error when every probe fails")
+	}
+}